@@ -0,0 +1,114 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"inventory-service-go/commons"
+	"inventory-service-go/metrics"
+	"inventory-service-go/tracing"
+	"time"
+)
+
+type APIKeyRow struct {
+	Id            int64        `db:"id"`
+	AltId         uuid.UUID    `db:"alt_id"`
+	Name          string       `db:"name"`
+	Prefix        string       `db:"prefix"`
+	KeyHash       string       `db:"key_hash"`
+	Scopes        string       `db:"scopes"`
+	ExpiresAt     sql.NullTime `db:"expires_at"`
+	LastUsedAt    sql.NullTime `db:"last_used_at"`
+	RevokedAt     sql.NullTime `db:"revoked_at"`
+	CreatedBy     string       `db:"created_by"`
+	CreatedAt     time.Time    `db:"created_at"`
+	LastChangedBy string       `db:"last_changed_by"`
+	LastUpdate    time.Time    `db:"last_update"`
+	TenantId      uuid.UUID    `db:"tenant_id"`
+}
+
+// CreateAPIKeyRequest creates a key with the scopes, a key without ExpiresAt does not expire. A key without a Tenant
+// is of the tenant creating it.
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Tenant    uuid.UUID  `json:"tenant_id"`
+	CreatedBy string     `json:"created_by" audit:"actor"`
+}
+
+const (
+	CreateAPIKeyQuery            = `INSERT INTO api_keys (name, prefix, key_hash, scopes, expires_at, created_by, last_changed_by, tenant_id) VALUES ($1, $2, $3, $4, $5, $6, $6, $7) RETURNING *`
+	GetAPIKeyQuery               = `SELECT * FROM api_keys WHERE alt_id = $1`
+	GetAPIKeyByHashQuery         = `SELECT * FROM api_keys WHERE key_hash = $1`
+	GetAllAPIKeysQuery           = `SELECT * FROM api_keys ORDER BY id`
+	GetAllAPIKeysPaginationQuery = `SELECT * FROM api_keys WHERE id > $1 ORDER BY id LIMIT $2`
+	RevokeAPIKeyQuery            = `UPDATE api_keys SET revoked_at = coalesce(revoked_at, now()), last_changed_by = $2, last_update = now() WHERE alt_id = $1 RETURNING *`
+	// TouchAPIKeyQuery records the use of a key at most once a minute, not a write for every request
+	TouchAPIKeyQuery = `UPDATE api_keys SET last_used_at = now() WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')`
+)
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, request CreateAPIKeyRequest, prefix string, keyHash string) (APIKeyRow, error)
+	GetAPIKey(ctx context.Context, id uuid.UUID) (APIKeyRow, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (APIKeyRow, error)
+	GetAPIKeys(ctx context.Context, pagination *commons.Pagination) ([]APIKeyRow, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID, changedBy string) (APIKeyRow, error)
+	TouchAPIKey(ctx context.Context, id int64) error
+}
+
+type APIKeyRepositoryImpl struct {
+	db *commons.TenantDB
+}
+
+func NewAPIKeyRepository(db *sqlx.DB) *APIKeyRepositoryImpl {
+	return &APIKeyRepositoryImpl{db: commons.NewTenantDB(db)}
+}
+
+func (r *APIKeyRepositoryImpl) CreateAPIKey(ctx context.Context, request CreateAPIKeyRequest, prefix string, keyHash string) (APIKeyRow, error) {
+	defer metrics.ObserveQuery("apikey", "CreateAPIKey")()
+	var row APIKeyRow
+	err := r.db.GetContext(tracing.Statement(ctx, "CreateAPIKey"), &row, CreateAPIKeyQuery, request.Name, prefix, keyHash, joinScopes(request.Scopes), request.ExpiresAt, request.CreatedBy, request.Tenant)
+	return row, err
+}
+
+func (r *APIKeyRepositoryImpl) GetAPIKey(ctx context.Context, id uuid.UUID) (APIKeyRow, error) {
+	defer metrics.ObserveQuery("apikey", "GetAPIKey")()
+	var row APIKeyRow
+	err := r.db.GetContext(tracing.Statement(ctx, "GetAPIKey"), &row, GetAPIKeyQuery, id)
+	return row, err
+}
+
+func (r *APIKeyRepositoryImpl) GetAPIKeyByHash(ctx context.Context, keyHash string) (APIKeyRow, error) {
+	defer metrics.ObserveQuery("apikey", "GetAPIKeyByHash")()
+	var row APIKeyRow
+	err := r.db.GetContext(tracing.Statement(ctx, "GetAPIKeyByHash"), &row, GetAPIKeyByHashQuery, keyHash)
+	return row, err
+}
+
+func (r *APIKeyRepositoryImpl) GetAPIKeys(ctx context.Context, pagination *commons.Pagination) ([]APIKeyRow, error) {
+	defer metrics.ObserveQuery("apikey", "GetAPIKeys")()
+	var rows []APIKeyRow
+	var err error
+	if pagination != nil {
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetAPIKeysPage"), &rows, GetAllAPIKeysPaginationQuery, pagination.LastId, pagination.PageSize)
+	} else {
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetAPIKeys"), &rows, GetAllAPIKeysQuery)
+	}
+	return rows, err
+}
+
+// RevokeAPIKey keeps the time the key was first revoked.
+func (r *APIKeyRepositoryImpl) RevokeAPIKey(ctx context.Context, id uuid.UUID, changedBy string) (APIKeyRow, error) {
+	defer metrics.ObserveQuery("apikey", "RevokeAPIKey")()
+	var row APIKeyRow
+	err := r.db.GetContext(tracing.Statement(ctx, "RevokeAPIKey"), &row, RevokeAPIKeyQuery, id, changedBy)
+	return row, err
+}
+
+func (r *APIKeyRepositoryImpl) TouchAPIKey(ctx context.Context, id int64) error {
+	defer metrics.ObserveQuery("apikey", "TouchAPIKey")()
+	_, err := r.db.ExecContext(tracing.Statement(ctx, "TouchAPIKey"), TouchAPIKeyQuery, id)
+	return err
+}
@@ -0,0 +1,95 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+var apiKeyColumns = []string{"id", "alt_id", "name", "prefix", "key_hash", "scopes", "expires_at", "last_used_at", "revoked_at", "created_by", "created_at", "last_changed_by", "last_update"}
+
+func TestAPIKeyRepositoryImpl_CreateAPIKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	expires := now.Add(time.Hour)
+	request := CreateAPIKeyRequest{Name: "nightly", Scopes: []string{"items:read", "invoices:write"}, ExpiresAt: &expires, Tenant: commons.DefaultTenant, CreatedBy: "admin"}
+	mock.ExpectQuery("INSERT INTO api_keys").WithArgs("nightly", "inv_abcdefgh", "hash", "items:read invoices:write", &expires, "admin", commons.DefaultTenant).
+		WillReturnRows(sqlmock.NewRows(apiKeyColumns).
+			AddRow(1, uuid.New(), "nightly", "inv_abcdefgh", "hash", "items:read invoices:write", expires, nil, nil, "admin", now, "admin", now))
+	mock.ExpectQuery("INSERT INTO api_keys").WillReturnError(errors.New("error"))
+
+	r := NewAPIKeyRepository(sqlx.NewDb(db, "mockDb"))
+
+	row, err := r.CreateAPIKey(context.Background(), request, "inv_abcdefgh", "hash")
+	assert.Nil(t, err)
+	assert.Equal(t, "items:read invoices:write", row.Scopes)
+	assert.True(t, row.ExpiresAt.Valid)
+	_, err = r.CreateAPIKey(context.Background(), request, "inv_abcdefgh", "hash")
+	assert.Error(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepositoryImpl_Get(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	id := uuid.New()
+	row := func() *sqlmock.Rows {
+		return sqlmock.NewRows(apiKeyColumns).AddRow(1, id, "nightly", "inv_abcdefgh", "hash", "read", nil, now, nil, "admin", now, "admin", now)
+	}
+	mock.ExpectQuery("SELECT \\* FROM api_keys WHERE alt_id = \\$1").WithArgs(id).WillReturnRows(row())
+	mock.ExpectQuery("SELECT \\* FROM api_keys WHERE key_hash = \\$1").WithArgs("hash").WillReturnRows(row())
+	mock.ExpectQuery("SELECT \\* FROM api_keys WHERE key_hash = \\$1").WithArgs("unknown").WillReturnRows(sqlmock.NewRows(apiKeyColumns))
+	mock.ExpectQuery("SELECT \\* FROM api_keys ORDER BY id").WillReturnRows(row())
+	mock.ExpectQuery("SELECT \\* FROM api_keys WHERE id > \\$1 ORDER BY id LIMIT \\$2").WithArgs(1, 10).WillReturnRows(sqlmock.NewRows(apiKeyColumns))
+
+	r := NewAPIKeyRepository(sqlx.NewDb(db, "mockDb"))
+
+	byId, err := r.GetAPIKey(context.Background(), id)
+	assert.Nil(t, err)
+	assert.Equal(t, id, byId.AltId)
+	byHash, err := r.GetAPIKeyByHash(context.Background(), "hash")
+	assert.Nil(t, err)
+	assert.True(t, byHash.LastUsedAt.Valid)
+	_, err = r.GetAPIKeyByHash(context.Background(), "unknown")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	all, err := r.GetAPIKeys(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, all, 1)
+	page, err := r.GetAPIKeys(context.Background(), &commons.Pagination{LastId: 1, PageSize: 10})
+	assert.Nil(t, err)
+	assert.Empty(t, page)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepositoryImpl_RevokeAndTouch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	id := uuid.New()
+	mock.ExpectQuery("UPDATE api_keys SET revoked_at = coalesce\\(revoked_at, now\\(\\)\\)").WithArgs(id, "admin").
+		WillReturnRows(sqlmock.NewRows(apiKeyColumns).AddRow(1, id, "nightly", "inv_abcdefgh", "hash", "read", nil, nil, now, "admin", now, "admin", now))
+	mock.ExpectExec("UPDATE api_keys SET last_used_at = now\\(\\)").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := NewAPIKeyRepository(sqlx.NewDb(db, "mockDb"))
+
+	row, err := r.RevokeAPIKey(context.Background(), id, "admin")
+	assert.Nil(t, err)
+	assert.True(t, row.RevokedAt.Valid)
+	assert.Nil(t, r.TouchAPIKey(context.Background(), 1))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,227 @@
+// Package apikey manages the long-lived keys machine clients send in the X-API-Key header instead of a token. A key
+// carries scopes, may expire and can be revoked, only its SHA-256 is stored.
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// KeyPrefix starts every key, so a leaked key is recognized by secret scanners.
+	KeyPrefix = "inv_"
+	// displayLength is how much of a key is kept in the clear to tell the keys apart.
+	displayLength = len(KeyPrefix) + 8
+	// ActorPrefix starts the names the changes made with a key are attributed to.
+	ActorPrefix = "api-key:"
+)
+
+var (
+	ErrInvalidAPIKey = commons.NewValidationError("invalid api key")
+	// ErrUnauthorized does not tell an unknown, an expired and a revoked key apart.
+	ErrUnauthorized = errors.New("invalid api key")
+)
+
+// scopePattern is read or write, for all resources or, as in items:read, for one. Write allows reading as well.
+var scopePattern = regexp.MustCompile(`^([a-z][a-z-]*:)?(read|write)$`)
+
+type APIKey struct {
+	Seq        int               `json:"seq"`
+	Id         uuid.UUID         `json:"id"`
+	Name       string            `json:"name"`
+	Prefix     string            `json:"prefix"`
+	Scopes     []string          `json:"scopes"`
+	ExpiresAt  string            `json:"expires_at,omitempty"`
+	LastUsedAt string            `json:"last_used_at,omitempty"`
+	Revoked    bool              `json:"revoked"`
+	RevokedAt  string            `json:"revoked_at,omitempty"`
+	Tenant     uuid.UUID         `json:"tenant_id"`
+	AuditInfo  commons.AuditInfo `json:"audit_info"`
+}
+
+// CreatedAPIKey is the only time the key itself is shown.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// Actor is the name the changes made with the key are attributed to in the audit fields. It is built from the id,
+// as the key names are not unique and a revoked key's name may be given to a new one.
+func (k APIKey) Actor() string {
+	return ActorPrefix + k.Id.String()
+}
+
+// Allows tells whether the key may read, or with write also change, the resource, e.g. items.
+func (k APIKey) Allows(resource string, write bool) bool {
+	for _, scope := range k.Scopes {
+		on, access, found := strings.Cut(scope, ":")
+		if !found {
+			on, access = "", scope
+		}
+		if (on == "" || on == resource) && (access == "write" || !write) {
+			return true
+		}
+	}
+	return false
+}
+
+func fromRow(row APIKeyRow) APIKey {
+	k := APIKey{
+		Seq:     int(row.Id),
+		Id:      row.AltId,
+		Name:    row.Name,
+		Prefix:  row.Prefix,
+		Scopes:  strings.Fields(row.Scopes),
+		Revoked: row.RevokedAt.Valid,
+		Tenant:  row.TenantId,
+		AuditInfo: commons.AuditInfo{
+			CreatedBy:      row.CreatedBy,
+			CreatedAt:      row.CreatedAt.Format(time.RFC3339),
+			LastChangedBy:  row.LastChangedBy,
+			LastUpdate:     row.LastUpdate.Format(time.RFC3339),
+			CreatedTime:    row.CreatedAt,
+			LastUpdateTime: row.LastUpdate,
+		},
+	}
+	if row.ExpiresAt.Valid {
+		k.ExpiresAt = row.ExpiresAt.Time.Format(time.RFC3339)
+	}
+	if row.LastUsedAt.Valid {
+		k.LastUsedAt = row.LastUsedAt.Time.Format(time.RFC3339)
+	}
+	if row.RevokedAt.Valid {
+		k.RevokedAt = row.RevokedAt.Time.Format(time.RFC3339)
+	}
+	return k
+}
+
+func joinScopes(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
+
+// hash is what is stored of a key. The keys are random, so unlike passwords they need no slow hash.
+func hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+type APIKeyService interface {
+	// CreateAPIKey returns the key, which cannot be looked up later.
+	CreateAPIKey(ctx context.Context, request CreateAPIKeyRequest) (CreatedAPIKey, error)
+	GetAPIKey(ctx context.Context, id uuid.UUID) (APIKey, error)
+	GetAPIKeys(ctx context.Context, pagination *commons.Pagination) ([]APIKey, error)
+	// RevokeAPIKey rejects the key from then on.
+	RevokeAPIKey(ctx context.Context, id uuid.UUID, changedBy string) (APIKey, error)
+	// Authenticate returns the key of a request and records its use, ErrUnauthorized when it is unknown, expired or
+	// revoked.
+	Authenticate(ctx context.Context, key string) (APIKey, error)
+}
+
+type APIKeyServiceImpl struct {
+	repo APIKeyRepository
+	now  func() time.Time
+}
+
+func NewAPIKeyService(repo APIKeyRepository) *APIKeyServiceImpl {
+	return &APIKeyServiceImpl{repo: repo, now: time.Now}
+}
+
+func (s *APIKeyServiceImpl) CreateAPIKey(ctx context.Context, request CreateAPIKeyRequest) (CreatedAPIKey, error) {
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" || len(request.Name) > 255 {
+		return CreatedAPIKey{}, fmt.Errorf("%w: name must be between 1 and 255 characters", ErrInvalidAPIKey)
+	}
+	if len(request.Scopes) == 0 {
+		return CreatedAPIKey{}, fmt.Errorf("%w: a key needs at least one scope", ErrInvalidAPIKey)
+	}
+	for _, scope := range request.Scopes {
+		if !scopePattern.MatchString(scope) {
+			return CreatedAPIKey{}, fmt.Errorf("%w: scope %q is not read, write, <resource>:read or <resource>:write", ErrInvalidAPIKey, scope)
+		}
+	}
+	request.Scopes = slices.Compact(slices.Sorted(slices.Values(request.Scopes)))
+	if len(joinScopes(request.Scopes)) > 1024 {
+		return CreatedAPIKey{}, fmt.Errorf("%w: too many scopes", ErrInvalidAPIKey)
+	}
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(s.now()) {
+		return CreatedAPIKey{}, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidAPIKey)
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return CreatedAPIKey{}, err
+	}
+	key := KeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	if request.Tenant == uuid.Nil {
+		request.Tenant = commons.DefaultTenant
+		if tenant, ok := commons.TenantFrom(ctx); ok {
+			request.Tenant = tenant
+		}
+	}
+	row, err := s.repo.CreateAPIKey(ctx, request, key[:displayLength], hash(key))
+	if err != nil {
+		return CreatedAPIKey{}, err
+	}
+	return CreatedAPIKey{APIKey: fromRow(row), Key: key}, nil
+}
+
+func (s *APIKeyServiceImpl) GetAPIKey(ctx context.Context, id uuid.UUID) (APIKey, error) {
+	row, err := s.repo.GetAPIKey(ctx, id)
+	if err != nil {
+		return APIKey{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *APIKeyServiceImpl) GetAPIKeys(ctx context.Context, pagination *commons.Pagination) ([]APIKey, error) {
+	rows, err := s.repo.GetAPIKeys(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]APIKey, len(rows))
+	for i, row := range rows {
+		keys[i] = fromRow(row)
+	}
+	return keys, nil
+}
+
+func (s *APIKeyServiceImpl) RevokeAPIKey(ctx context.Context, id uuid.UUID, changedBy string) (APIKey, error) {
+	row, err := s.repo.RevokeAPIKey(ctx, id, changedBy)
+	if err != nil {
+		return APIKey{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *APIKeyServiceImpl) Authenticate(ctx context.Context, key string) (APIKey, error) {
+	if !strings.HasPrefix(key, KeyPrefix) {
+		return APIKey{}, ErrUnauthorized
+	}
+	// the tenant is not known before the key is, see commons.AsSystem
+	ctx = commons.AsSystem(ctx)
+	row, err := s.repo.GetAPIKeyByHash(ctx, hash(key))
+	if errors.Is(err, sql.ErrNoRows) {
+		return APIKey{}, ErrUnauthorized
+	}
+	if err != nil {
+		return APIKey{}, err
+	}
+	if row.RevokedAt.Valid || (row.ExpiresAt.Valid && !row.ExpiresAt.Time.After(s.now())) {
+		return APIKey{}, ErrUnauthorized
+	}
+	if err = s.repo.TouchAPIKey(ctx, row.Id); err != nil {
+		return APIKey{}, err
+	}
+	return fromRow(row), nil
+}
@@ -0,0 +1,213 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"strings"
+	"testing"
+	"time"
+)
+
+func apiKeyRow(name string, scopes string) APIKeyRow {
+	now := time.Now()
+	return APIKeyRow{Id: 1, AltId: uuid.New(), Name: name, Prefix: "inv_abcdefgh", KeyHash: "hash", Scopes: scopes, CreatedBy: "admin", CreatedAt: now, LastChangedBy: "admin", LastUpdate: now}
+}
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	// the hash the repository was given
+	var stored string
+	testCases := []struct {
+		name     string
+		request  CreateAPIKeyRequest
+		wantErr  error
+		mockFunc func(mockRepo *MockAPIKeyRepository)
+	}{
+		{
+			name:    "Create API Key Successfully",
+			request: CreateAPIKeyRequest{Name: " nightly ", Scopes: []string{"items:read", "write", "items:read"}, CreatedBy: "admin"},
+			mockFunc: func(mockRepo *MockAPIKeyRepository) {
+				mockRepo.EXPECT().CreateAPIKey(gomock.Any(), CreateAPIKeyRequest{Name: "nightly", Scopes: []string{"items:read", "write"}, Tenant: commons.DefaultTenant, CreatedBy: "admin"}, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ CreateAPIKeyRequest, prefix string, keyHash string) (APIKeyRow, error) {
+						assert.True(t, strings.HasPrefix(prefix, KeyPrefix))
+						assert.Len(t, prefix, 12)
+						assert.Len(t, keyHash, 64)
+						row := apiKeyRow("nightly", "items:read write")
+						row.Prefix = prefix
+						row.KeyHash = keyHash
+						stored = keyHash
+						return row, nil
+					})
+			},
+		},
+		{
+			name:     "No Name",
+			request:  CreateAPIKeyRequest{Name: " ", Scopes: []string{"read"}},
+			wantErr:  ErrInvalidAPIKey,
+			mockFunc: func(*MockAPIKeyRepository) {},
+		},
+		{
+			name:     "No Scopes",
+			request:  CreateAPIKeyRequest{Name: "nightly"},
+			wantErr:  ErrInvalidAPIKey,
+			mockFunc: func(*MockAPIKeyRepository) {},
+		},
+		{
+			name:     "Unknown Scope",
+			request:  CreateAPIKeyRequest{Name: "nightly", Scopes: []string{"items:delete"}},
+			wantErr:  ErrInvalidAPIKey,
+			mockFunc: func(*MockAPIKeyRepository) {},
+		},
+		{
+			name:     "Expired",
+			request:  CreateAPIKeyRequest{Name: "nightly", Scopes: []string{"read"}, ExpiresAt: &past},
+			wantErr:  ErrInvalidAPIKey,
+			mockFunc: func(*MockAPIKeyRepository) {},
+		},
+		{
+			name:    "Repository Error",
+			request: CreateAPIKeyRequest{Name: "nightly", Scopes: []string{"read"}},
+			wantErr: sql.ErrConnDone,
+			mockFunc: func(mockRepo *MockAPIKeyRepository) {
+				mockRepo.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(APIKeyRow{}, sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := NewMockAPIKeyRepository(ctrl)
+			tc.mockFunc(mockRepo)
+			s := NewAPIKeyService(mockRepo)
+
+			created, err := s.CreateAPIKey(context.Background(), tc.request)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+			assert.Equal(t, stored, hash(created.Key))
+			assert.Equal(t, []string{"items:read", "write"}, created.Scopes)
+		})
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	key := KeyPrefix + "secret"
+	now := time.Now()
+	testCases := []struct {
+		name     string
+		key      string
+		wantErr  error
+		mockFunc func(mockRepo *MockAPIKeyRepository)
+	}{
+		{
+			name: "Valid Key",
+			key:  key,
+			mockFunc: func(mockRepo *MockAPIKeyRepository) {
+				row := apiKeyRow("nightly", "read")
+				row.ExpiresAt = sql.NullTime{Time: now.Add(time.Hour), Valid: true}
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hash(key)).Return(row, nil)
+				mockRepo.EXPECT().TouchAPIKey(gomock.Any(), int64(1)).Return(nil)
+			},
+		},
+		{
+			name:     "Not A Key",
+			key:      "Bearer token",
+			wantErr:  ErrUnauthorized,
+			mockFunc: func(*MockAPIKeyRepository) {},
+		},
+		{
+			name:    "Unknown Key",
+			key:     key,
+			wantErr: ErrUnauthorized,
+			mockFunc: func(mockRepo *MockAPIKeyRepository) {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hash(key)).Return(APIKeyRow{}, sql.ErrNoRows)
+			},
+		},
+		{
+			name:    "Revoked Key",
+			key:     key,
+			wantErr: ErrUnauthorized,
+			mockFunc: func(mockRepo *MockAPIKeyRepository) {
+				row := apiKeyRow("nightly", "read")
+				row.RevokedAt = sql.NullTime{Time: now, Valid: true}
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hash(key)).Return(row, nil)
+			},
+		},
+		{
+			name:    "Expired Key",
+			key:     key,
+			wantErr: ErrUnauthorized,
+			mockFunc: func(mockRepo *MockAPIKeyRepository) {
+				row := apiKeyRow("nightly", "read")
+				row.ExpiresAt = sql.NullTime{Time: now.Add(-time.Second), Valid: true}
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hash(key)).Return(row, nil)
+			},
+		},
+		{
+			name:    "Repository Error",
+			key:     key,
+			wantErr: sql.ErrConnDone,
+			mockFunc: func(mockRepo *MockAPIKeyRepository) {
+				mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hash(key)).Return(APIKeyRow{}, sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := NewMockAPIKeyRepository(ctrl)
+			tc.mockFunc(mockRepo)
+			s := NewAPIKeyService(mockRepo)
+
+			result, err := s.Authenticate(context.Background(), tc.key)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "api-key:"+result.Id.String(), result.Actor())
+			assert.NotEmpty(t, result.ExpiresAt)
+		})
+	}
+}
+
+func TestAPIKeyService_RevokeAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := NewMockAPIKeyRepository(ctrl)
+	s := NewAPIKeyService(mockRepo)
+	id := uuid.New()
+	row := apiKeyRow("nightly", "read")
+	row.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	mockRepo.EXPECT().RevokeAPIKey(gomock.Any(), id, "admin").Return(row, nil)
+	mockRepo.EXPECT().RevokeAPIKey(gomock.Any(), id, "admin").Return(APIKeyRow{}, errors.New("error"))
+
+	revoked, err := s.RevokeAPIKey(context.Background(), id, "admin")
+	assert.NoError(t, err)
+	assert.True(t, revoked.Revoked)
+	assert.NotEmpty(t, revoked.RevokedAt)
+	_, err = s.RevokeAPIKey(context.Background(), id, "admin")
+	assert.Error(t, err)
+}
+
+func TestAPIKey_Allows(t *testing.T) {
+	key := APIKey{Scopes: []string{"items:read", "invoices:write"}}
+	assert.True(t, key.Allows("items", false))
+	assert.False(t, key.Allows("items", true))
+	assert.True(t, key.Allows("invoices", false))
+	assert.True(t, key.Allows("invoices", true))
+	assert.False(t, key.Allows("persons", false))
+
+	key = APIKey{Scopes: []string{"read"}}
+	assert.True(t, key.Allows("persons", false))
+	assert.False(t, key.Allows("persons", true))
+	key = APIKey{Scopes: []string{"write"}}
+	assert.True(t, key.Allows("persons", true))
+}
@@ -0,0 +1,20 @@
+//go:build wireinject
+// +build wireinject
+
+package apikey
+
+import (
+	"github.com/google/wire"
+	"inventory-service-go/commons"
+)
+
+func InitializeAPIKeyService() (APIKeyService, error) {
+	wire.Build(
+		NewAPIKeyService,
+		NewAPIKeyRepository,
+		commons.GetDB,
+		wire.Bind(new(APIKeyRepository), new(*APIKeyRepositoryImpl)),
+		wire.Bind(new(APIKeyService), new(*APIKeyServiceImpl)),
+	)
+	return nil, nil
+}
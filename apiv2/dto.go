@@ -0,0 +1,124 @@
+// Package apiv2 is version 2 of the REST API, served under /api/v2 next to version 1 by the same services. Its
+// responses are DTOs of its own rather than the types of the services:
+//   - the ids of the database, the seq of v1, are left out, lists are paged with an opaque cursor instead
+//   - timestamps are RFC 3339 in UTC everywhere
+//   - lists are wrapped in a List, errors are an Error
+//
+// Its OpenAPI document is docs/v2, generated apart from the one of v1, see the README.
+package apiv2
+
+import (
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"inventory-service-go/person"
+	"inventory-service-go/tax"
+	"time"
+)
+
+type AuditInfo struct {
+	CreatedBy     string    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	LastChangedBy string    `json:"last_changed_by"`
+	LastUpdatedAt time.Time `json:"last_updated_at"`
+}
+
+type Person struct {
+	Id        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	AuditInfo AuditInfo `json:"audit_info"`
+}
+
+type Item struct {
+	Id          uuid.UUID     `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	UnitPrice   commons.Money `json:"unit_price"`
+	TaxCategory string        `json:"tax_category"`
+	AuditInfo   AuditInfo     `json:"audit_info"`
+}
+
+// Invoice has the lines, discounts and tax as v1 has them, only the items are v2 DTOs.
+type Invoice struct {
+	Id               uuid.UUID                 `json:"id"`
+	UserId           uuid.UUID                 `json:"user_id"`
+	Number           string                    `json:"number,omitempty"`
+	IssuedAt         *time.Time                `json:"issued_at,omitempty"`
+	Paid             bool                      `json:"paid"`
+	Total            commons.Money             `json:"total"`
+	TaxJurisdiction  string                    `json:"tax_jurisdiction"`
+	PricesIncludeTax bool                      `json:"prices_include_tax"`
+	Items            []Item                    `json:"items"`
+	Lines            []invoice.InvoiceLine     `json:"lines,omitempty"`
+	Discounts        []invoice.InvoiceDiscount `json:"discounts,omitempty"`
+	Tax              *tax.Breakdown            `json:"tax,omitempty"`
+	AuditInfo        AuditInfo                 `json:"audit_info"`
+}
+
+// List is a page of a list, NextCursor fetches the next page and is left out on the last one.
+type List[T any] struct {
+	Data       []T    `json:"data"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func toAuditInfo(a commons.AuditInfo) AuditInfo {
+	return AuditInfo{
+		CreatedBy:     a.CreatedBy,
+		CreatedAt:     a.CreatedTime.UTC(),
+		LastChangedBy: a.LastChangedBy,
+		LastUpdatedAt: a.LastUpdateTime.UTC(),
+	}
+}
+
+func toPerson(p person.Person) Person {
+	return Person{
+		Id:        p.Id,
+		Name:      p.Name,
+		Email:     p.Email,
+		AuditInfo: toAuditInfo(p.AuditInfo),
+	}
+}
+
+func toItem(i item.Item) Item {
+	return Item{
+		Id:          i.Id,
+		Name:        i.Name,
+		Description: i.Description,
+		UnitPrice:   i.UnitPrice,
+		TaxCategory: i.TaxCategory,
+		AuditInfo:   toAuditInfo(i.AuditInfo),
+	}
+}
+
+func toInvoice(i invoice.Invoice) Invoice {
+	result := Invoice{
+		Id:               i.Id,
+		UserId:           i.UserId,
+		Number:           i.Number,
+		Paid:             i.Paid,
+		Total:            i.Total,
+		TaxJurisdiction:  i.TaxJurisdiction,
+		PricesIncludeTax: i.PricesIncludeTax,
+		Items:            mapAll(i.Items, toItem),
+		Lines:            i.Lines,
+		Discounts:        i.Discounts,
+		Tax:              i.Tax,
+		AuditInfo:        toAuditInfo(i.AuditInfo),
+	}
+	if !i.IssuedTime.IsZero() {
+		issuedAt := i.IssuedTime.UTC()
+		result.IssuedAt = &issuedAt
+	}
+	return result
+}
+
+// mapAll maps a list, an empty or nil list to an empty one so it is [] in JSON.
+func mapAll[T any, D any](list []T, mapping func(T) D) []D {
+	result := make([]D, 0, len(list))
+	for _, element := range list {
+		result = append(result, mapping(element))
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+package apiv2
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serve runs a request through the routes of v2.
+func serve(a context.ApplicationContext, method string, target string, body string) *httptest.ResponseRecorder {
+	e := echo.New()
+	Routes(e.Group("/api/v2"), a)
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, target, reader)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRoutes(t *testing.T) {
+	e := echo.New()
+	Routes(e.Group("/api/v2"), context.MockApplicationContext(nil, nil, nil))
+	assert.Equal(t, 22, len(e.Routes()))
+}
+
+func TestToInvoice(t *testing.T) {
+	created := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	local := created.In(time.FixedZone("CEST", 2*60*60))
+	result := toInvoice(invoice.Invoice{
+		Seq:        7,
+		Id:         uuid.New(),
+		Total:      commons.NewMoney(1000, "EUR"),
+		IssuedAt:   local.Format(time.RFC3339),
+		IssuedTime: local,
+		Items:      []item.Item{{Seq: 3, Name: "widget", AuditInfo: commons.AuditInfo{CreatedAt: local.String(), CreatedTime: local}}},
+		AuditInfo: commons.AuditInfo{
+			CreatedBy:      "unit_test",
+			CreatedAt:      local.Format(time.RFC3339),
+			LastChangedBy:  "unit_test",
+			LastUpdate:     local.Format(time.RFC3339),
+			CreatedTime:    local,
+			LastUpdateTime: local,
+		},
+	})
+	assert.Equal(t, created, *result.IssuedAt)
+	assert.Equal(t, AuditInfo{CreatedBy: "unit_test", CreatedAt: created, LastChangedBy: "unit_test", LastUpdatedAt: created}, result.AuditInfo)
+	assert.Equal(t, created, result.Items[0].AuditInfo.CreatedAt)
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.NotContains(t, string(body), "seq")
+	assert.Contains(t, string(body), `"created_at":"2026-10-19T12:00:00Z"`)
+
+	assert.Nil(t, toInvoice(invoice.Invoice{}).IssuedAt, "an invoice that was not issued has no issued_at")
+	assert.Equal(t, []Item{}, toInvoice(invoice.Invoice{}).Items)
+}
+
+func TestCursor(t *testing.T) {
+	seq, err := decodeCursor(encodeCursor(42))
+	assert.NoError(t, err)
+	assert.Equal(t, 42, seq)
+	for _, cursor := range []string{"42", "!", encodeCursor(-1)} {
+		_, err = decodeCursor(cursor)
+		assert.Error(t, err, cursor)
+	}
+
+	pagination := &commons.Pagination{PageSize: 2}
+	full := page([]int{1, 2}, pagination, func(i int) int { return i }, func(i int) int { return i })
+	assert.Equal(t, encodeCursor(2), full.NextCursor)
+	last := page([]int{3}, pagination, func(i int) int { return i }, func(i int) int { return i })
+	assert.Empty(t, last.NextCursor)
+}
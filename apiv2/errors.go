@@ -0,0 +1,45 @@
+package apiv2
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/commons"
+	"inventory-service-go/logging"
+	"net/http"
+)
+
+// Error is the body of every error response of v2, Code is one of the Code constants.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+const (
+	CodeBadRequest = "bad_request"
+	CodeNotFound   = "not_found"
+	CodeInternal   = "internal"
+)
+
+// idMismatch answers an update with another id in the body than in the path, the id in the body may be left out.
+const idMismatch = "id in path does not match id in body"
+
+func badRequest(c echo.Context, message string) error {
+	return c.JSON(http.StatusBadRequest, Error{Code: CodeBadRequest, Message: message})
+}
+
+// serviceError answers an error of a service as commons.HandleServiceError does for v1, keeping the details of
+// unexpected errors in the log.
+func serviceError(c echo.Context, err error) error {
+	var validationError *commons.ValidationError
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return c.JSON(http.StatusNotFound, Error{Code: CodeNotFound, Message: "not found"})
+	case errors.As(err, &validationError):
+		return badRequest(c, err.Error())
+	default:
+		ctx := c.Request().Context()
+		logging.FromContext(ctx).ErrorContext(ctx, "Unexpected service error", "error", err)
+		return c.JSON(http.StatusInternalServerError, Error{Code: CodeInternal, Message: "internal error"})
+	}
+}
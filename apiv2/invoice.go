@@ -0,0 +1,374 @@
+package apiv2
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/context"
+	"inventory-service-go/invoice"
+	"net/http"
+)
+
+// GetAllInvoices
+//
+//	@Summary		List Invoices
+//	@Description	List the Invoices a page at a time
+//	@Id				all_invoices
+//	@Tags			invoice
+//	@Produce		json
+//	@Param			cursor	query		string	false	"next_cursor of the previous page"
+//	@Param			limit	query		int		false	"number of invoices per page, at most 100"	default(20)
+//	@Success		200		{object}	apiv2.List[apiv2.Invoice]	"OK"
+//	@Failure		400		{object}	apiv2.Error					"Bad Request"
+//	@Failure		500		{object}	apiv2.Error					"Internal Server Error"
+//	@Router			/invoices [get]
+func GetAllInvoices(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		pagination, err := paginationFromRequest(c)
+		if err != nil {
+			return badRequest(c, err.Error())
+		}
+		invoices, err := a.InvoiceService().GetAllInvoices(c.Request().Context(), pagination)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, page(invoices, pagination, func(i invoice.Invoice) int { return i.Seq }, toInvoice))
+	}
+}
+
+// GetAllInvoicesForUser
+//
+//	@Summary		List Invoices for User
+//	@Description	List all the Invoices of a user, in one page
+//	@Id				get_all_for_user_invoice
+//	@Tags			invoice
+//	@Produce		json
+//	@Param			userId	path		string						true	"id of a user"	format(uuid)
+//	@Success		200		{object}	apiv2.List[apiv2.Invoice]	"OK"
+//	@Failure		400		{object}	apiv2.Error					"Bad Request"
+//	@Failure		500		{object}	apiv2.Error					"Internal Server Error"
+//	@Router			/invoices/user/{userId} [get]
+func GetAllInvoicesForUser(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userId, err := uuid.Parse(c.Param("userId"))
+		if err != nil {
+			return badRequest(c, "invalid userId")
+		}
+		invoices, err := a.InvoiceService().GetInvoicesForUser(c.Request().Context(), userId)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, List[Invoice]{Data: mapAll(invoices, toInvoice)})
+	}
+}
+
+// GetInvoice
+//
+//	@Summary		Get Invoice
+//	@Description	Get a specific Invoice
+//	@Id				get_invoice
+//	@Tags			invoice
+//	@Produce		json
+//	@Param			id			path		string			true	"id of the invoice requested"	format(uuid)
+//	@Param			withItems	query		bool			false	"return with Items (if there are any attached to the invoice)"
+//	@Success		200			{object}	apiv2.Invoice	"OK"
+//	@Failure		400			{object}	apiv2.Error		"Bad Request"
+//	@Failure		404			{object}	apiv2.Error		"Not Found"
+//	@Failure		500			{object}	apiv2.Error		"Internal Server Error"
+//	@Router			/invoices/{id} [get]
+func GetInvoice(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		result, err := a.InvoiceService().GetInvoice(c.Request().Context(), id, c.QueryParam("withItems") == "true")
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, toInvoice(result))
+	}
+}
+
+// GetInvoiceByNumber
+//
+//	@Summary		Get Invoice by Number
+//	@Description	Get an issued Invoice by its number
+//	@Id				get_invoice_by_number
+//	@Tags			invoice
+//	@Produce		json
+//	@Param			number	path		string			true	"invoice number"
+//	@Success		200		{object}	apiv2.Invoice	"OK"
+//	@Failure		404		{object}	apiv2.Error		"Not Found"
+//	@Failure		500		{object}	apiv2.Error		"Internal Server Error"
+//	@Router			/invoices/by-number/{number} [get]
+func GetInvoiceByNumber(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		result, err := a.InvoiceService().GetInvoiceByNumber(c.Request().Context(), c.Param("number"))
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, toInvoice(result))
+	}
+}
+
+// CreateInvoice
+//
+//	@Summary		Create Invoice
+//	@Description	Create an Invoice
+//	@Id				create_invoice
+//	@Tags			invoice
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		invoice.CreateInvoiceRequest	true	"Create Invoice Request"
+//	@Success		201		{object}	apiv2.Invoice					"Created"
+//	@Failure		400		{object}	apiv2.Error						"Bad Request"
+//	@Failure		500		{object}	apiv2.Error						"Internal Server Error"
+//	@Router			/invoices [post]
+func CreateInvoice(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var request invoice.CreateInvoiceRequest
+		if err := c.Bind(&request); err != nil {
+			return badRequest(c, "invalid request body")
+		}
+		result, err := a.InvoiceService().CreateInvoice(c.Request().Context(), request)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusCreated, toInvoice(result))
+	}
+}
+
+// UpdateInvoice
+//
+//	@Summary		Update Invoice
+//	@Description	Update an Invoice, the id in the body may be left out
+//	@Id				update_invoice
+//	@Tags			invoice
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"id of the invoice"	format(uuid)
+//	@Param			request	body		invoice.UpdateInvoiceRequest	true	"Update Invoice Request"
+//	@Success		200		{object}	apiv2.Invoice					"OK"
+//	@Failure		400		{object}	apiv2.Error						"Bad Request"
+//	@Failure		404		{object}	apiv2.Error						"Not Found"
+//	@Failure		500		{object}	apiv2.Error						"Internal Server Error"
+//	@Router			/invoices/{id} [put]
+func UpdateInvoice(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		var request invoice.UpdateInvoiceRequest
+		if err = c.Bind(&request); err != nil {
+			return badRequest(c, "invalid request body")
+		}
+		if request.Id != uuid.Nil && request.Id != id {
+			return badRequest(c, idMismatch)
+		}
+		request.Id = id
+		result, err := a.InvoiceService().UpdateInvoice(c.Request().Context(), request)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, toInvoice(result))
+	}
+}
+
+// DeleteInvoice
+//
+//	@Summary		Delete Invoice
+//	@Description	Remove a specific Invoice
+//	@Id				delete_invoice
+//	@Tags			invoice
+//	@Produce		json
+//	@Param			id	path		string					true	"id of the invoice to be deleted"	format(uuid)
+//	@Success		200	{object}	commons.DeleteResult	"OK"
+//	@Failure		400	{object}	apiv2.Error				"Bad Request"
+//	@Failure		404	{object}	apiv2.Error				"Not Found"
+//	@Failure		500	{object}	apiv2.Error				"Internal Server Error"
+//	@Router			/invoices/{id} [delete]
+func DeleteInvoice(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		result, err := a.InvoiceService().DeleteInvoice(c.Request().Context(), id)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// AddItemsToInvoice
+//
+//	@Summary		Add Items to Invoice
+//	@Description	Add Items to an Invoice, the invoice_id in the body may be left out
+//	@Id				add_items_to_invoice
+//	@Tags			invoice
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"id of the invoice"	format(uuid)
+//	@Param			request	body		invoice.ItemsToInvoiceRequest	true	"Add Items to Invoice Request"
+//	@Success		200		{object}	invoice.ItemsToInvoiceResponse	"OK"
+//	@Failure		400		{object}	apiv2.Error						"Bad Request"
+//	@Failure		404		{object}	apiv2.Error						"Not Found"
+//	@Failure		500		{object}	apiv2.Error						"Internal Server Error"
+//	@Router			/invoices/{id}/items [post]
+func AddItemsToInvoice(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		var request invoice.ItemsToInvoiceRequest
+		if err = c.Bind(&request); err != nil {
+			return badRequest(c, "invalid request body")
+		}
+		if request.InvoiceId != uuid.Nil && request.InvoiceId != id {
+			return badRequest(c, idMismatch)
+		}
+		request.InvoiceId = id
+		result, err := a.InvoiceService().AddItemsToInvoice(c.Request().Context(), request)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// RemoveItemFromInvoice
+//
+//	@Summary		Remove Item From Invoice
+//	@Description	Remove a specific Item from a specific Invoice
+//	@Id				remove_item_from_invoice
+//	@Tags			invoice
+//	@Produce		json
+//	@Param			id		path		string							true	"id of the invoice"				format(uuid)
+//	@Param			itemId	path		string							true	"id of the item to be removed"	format(uuid)
+//	@Success		200		{object}	invoice.ItemsToInvoiceResponse	"OK"
+//	@Failure		400		{object}	apiv2.Error						"Bad Request"
+//	@Failure		404		{object}	apiv2.Error						"Not Found"
+//	@Failure		500		{object}	apiv2.Error						"Internal Server Error"
+//	@Router			/invoices/{id}/items/{itemId} [delete]
+func RemoveItemFromInvoice(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		itemId, err := uuid.Parse(c.Param("itemId"))
+		if err != nil {
+			return badRequest(c, "invalid itemId")
+		}
+		result, err := a.InvoiceService().RemoveItemFromInvoice(c.Request().Context(), invoice.SimpleInvoiceItem{InvoiceId: id, ItemId: itemId})
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// AddInvoiceDiscount
+//
+//	@Summary		Add Invoice Discount
+//	@Description	Take a percentage or a fixed amount off the whole invoice
+//	@Id				add_invoice_discount
+//	@Tags			invoice
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"id of the invoice"	format(uuid)
+//	@Param			request	body		invoice.InvoiceDiscountRequest	true	"Invoice Discount Request"
+//	@Success		200		{object}	apiv2.Invoice					"OK"
+//	@Failure		400		{object}	apiv2.Error						"Bad Request"
+//	@Failure		404		{object}	apiv2.Error						"Not Found"
+//	@Failure		500		{object}	apiv2.Error						"Internal Server Error"
+//	@Router			/invoices/{id}/discounts [post]
+func AddInvoiceDiscount(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		var request invoice.InvoiceDiscountRequest
+		if err = c.Bind(&request); err != nil {
+			return badRequest(c, "invalid request body")
+		}
+		request.InvoiceId = id
+		result, err := a.InvoiceService().AddDiscount(c.Request().Context(), request)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, toInvoice(result))
+	}
+}
+
+// ApplyPromotion
+//
+//	@Summary		Apply Promotion
+//	@Description	Apply a promotion code to an invoice
+//	@Id				apply_promotion
+//	@Tags			invoice
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"id of the invoice"	format(uuid)
+//	@Param			request	body		invoice.PromotionCodeRequest	true	"Promotion Code Request"
+//	@Success		200		{object}	apiv2.Invoice					"OK"
+//	@Failure		400		{object}	apiv2.Error						"Bad Request"
+//	@Failure		404		{object}	apiv2.Error						"Not Found"
+//	@Failure		500		{object}	apiv2.Error						"Internal Server Error"
+//	@Router			/invoices/{id}/promotions [post]
+func ApplyPromotion(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		var request invoice.PromotionCodeRequest
+		if err = c.Bind(&request); err != nil {
+			return badRequest(c, "invalid request body")
+		}
+		request.InvoiceId = id
+		result, err := a.InvoiceService().ApplyPromotion(c.Request().Context(), request)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, toInvoice(result))
+	}
+}
+
+// IssueInvoice
+//
+//	@Summary		Issue Invoice
+//	@Description	Give an invoice its number, issuing it again keeps the number
+//	@Id				issue_invoice
+//	@Tags			invoice
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"id of the invoice"	format(uuid)
+//	@Param			request	body		invoice.IssueInvoiceRequest	true	"Issue Invoice Request"
+//	@Success		200		{object}	apiv2.Invoice				"OK"
+//	@Failure		400		{object}	apiv2.Error					"Bad Request"
+//	@Failure		404		{object}	apiv2.Error					"Not Found"
+//	@Failure		500		{object}	apiv2.Error					"Internal Server Error"
+//	@Router			/invoices/{id}/issue [post]
+func IssueInvoice(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		var request invoice.IssueInvoiceRequest
+		if err = c.Bind(&request); err != nil {
+			return badRequest(c, "invalid request body")
+		}
+		request.Id = id
+		result, err := a.InvoiceService().IssueInvoice(c.Request().Context(), request)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, toInvoice(result))
+	}
+}
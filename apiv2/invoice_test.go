@@ -0,0 +1,124 @@
+package apiv2
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"inventory-service-go/promotion"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func invoiceFixture() invoice.Invoice {
+	created := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	return invoice.Invoice{
+		Seq:    9,
+		Id:     uuid.New(),
+		UserId: uuid.New(),
+		Total:  commons.NewMoney(2500, "EUR"),
+		Items:  []item.Item{},
+		AuditInfo: commons.AuditInfo{
+			CreatedBy:      "unit_test",
+			CreatedAt:      created.Format(time.RFC3339),
+			LastChangedBy:  "unit_test",
+			LastUpdate:     created.Format(time.RFC3339),
+			CreatedTime:    created,
+			LastUpdateTime: created,
+		},
+	}
+}
+
+func TestGetAllInvoices(t *testing.T) {
+	controller := gomock.NewController(t)
+	invoices := invoice.NewMockInvoiceService(controller)
+	a := context.MockApplicationContext(nil, nil, invoices)
+	found := invoiceFixture()
+
+	invoices.EXPECT().GetAllInvoices(gomock.Any(), &commons.Pagination{PageSize: defaultLimit}).Return([]invoice.Invoice{found}, nil)
+	rec := serve(a, http.MethodGet, "/api/v2/invoices", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"data": [{
+		"id": "`+found.Id.String()+`", "user_id": "`+found.UserId.String()+`", "paid": false,
+		"total": {"amount": "25.00", "currency": "EUR"}, "tax_jurisdiction": "", "prices_include_tax": false, "items": [],
+		"audit_info": {"created_by": "unit_test", "created_at": "2026-10-19T12:00:00Z", "last_changed_by": "unit_test", "last_updated_at": "2026-10-19T12:00:00Z"}
+	}]}`, rec.Body.String())
+
+	invoices.EXPECT().GetInvoicesForUser(gomock.Any(), found.UserId).Return(nil, nil)
+	rec = serve(a, http.MethodGet, "/api/v2/invoices/user/"+found.UserId.String(), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"data": []}`, rec.Body.String())
+}
+
+func TestGetInvoice(t *testing.T) {
+	controller := gomock.NewController(t)
+	invoices := invoice.NewMockInvoiceService(controller)
+	a := context.MockApplicationContext(nil, nil, invoices)
+	found := invoiceFixture()
+	issued := time.Date(2026, time.October, 19, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	found.Number, found.IssuedAt, found.IssuedTime = "INV-2026-000001", issued.Format(time.RFC3339), issued
+
+	invoices.EXPECT().GetInvoice(gomock.Any(), found.Id, true).Return(found, nil)
+	rec := serve(a, http.MethodGet, "/api/v2/invoices/"+found.Id.String()+"?withItems=true", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"issued_at":"2026-10-19T12:00:00Z"`)
+
+	invoices.EXPECT().GetInvoiceByNumber(gomock.Any(), "INV-2026-000002").Return(invoice.Invoice{}, sql.ErrNoRows)
+	rec = serve(a, http.MethodGet, "/api/v2/invoices/by-number/INV-2026-000002", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestInvoiceMutations(t *testing.T) {
+	controller := gomock.NewController(t)
+	invoices := invoice.NewMockInvoiceService(controller)
+	a := context.MockApplicationContext(nil, nil, invoices)
+	found := invoiceFixture()
+	id, itemId := found.Id, uuid.New()
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		expect       func()
+		expectedCode int
+	}{
+		{"create", http.MethodPost, "/api/v2/invoices", `{"user_id": "` + found.UserId.String() + `", "created_by": "unit_test"}`, func() {
+			invoices.EXPECT().CreateInvoice(gomock.Any(), invoice.CreateInvoiceRequest{UserId: found.UserId, CreatedBy: "unit_test"}).Return(found, nil)
+		}, http.StatusCreated},
+		{"update", http.MethodPut, "/api/v2/invoices/" + id.String(), `{"paid": true}`, func() {
+			invoices.EXPECT().UpdateInvoice(gomock.Any(), invoice.UpdateInvoiceRequest{Id: id, Paid: true}).Return(found, nil)
+		}, http.StatusOK},
+		{"delete", http.MethodDelete, "/api/v2/invoices/" + id.String(), "", func() {
+			invoices.EXPECT().DeleteInvoice(gomock.Any(), id).Return(commons.DeleteResult{Id: id, Deleted: true}, nil)
+		}, http.StatusOK},
+		{"add items", http.MethodPost, "/api/v2/invoices/" + id.String() + "/items", `{"items": ["` + itemId.String() + `"]}`, func() {
+			invoices.EXPECT().AddItemsToInvoice(gomock.Any(), invoice.ItemsToInvoiceRequest{InvoiceId: id, Items: []uuid.UUID{itemId}}).Return(invoice.ItemsToInvoiceResponse{InvoiceId: id, Success: true}, nil)
+		}, http.StatusOK},
+		{"add items to another invoice", http.MethodPost, "/api/v2/invoices/" + id.String() + "/items", `{"invoice_id": "` + uuid.NewString() + `"}`, func() {}, http.StatusBadRequest},
+		{"remove item", http.MethodDelete, "/api/v2/invoices/" + id.String() + "/items/" + itemId.String(), "", func() {
+			invoices.EXPECT().RemoveItemFromInvoice(gomock.Any(), invoice.SimpleInvoiceItem{InvoiceId: id, ItemId: itemId}).Return(invoice.ItemsToInvoiceResponse{InvoiceId: id, Success: true}, nil)
+		}, http.StatusOK},
+		{"discount", http.MethodPost, "/api/v2/invoices/" + id.String() + "/discounts", `{"discount": {"type": "fixed", "value": "5"}, "created_by": "unit_test"}`, func() {
+			invoices.EXPECT().AddDiscount(gomock.Any(), invoice.InvoiceDiscountRequest{InvoiceId: id, Discount: promotion.Discount{Type: promotion.FixedAmount, Value: "5"}, CreatedBy: "unit_test"}).Return(found, nil)
+		}, http.StatusOK},
+		{"promotion", http.MethodPost, "/api/v2/invoices/" + id.String() + "/promotions", `{"code": "SPRING", "created_by": "unit_test"}`, func() {
+			invoices.EXPECT().ApplyPromotion(gomock.Any(), invoice.PromotionCodeRequest{InvoiceId: id, Code: "SPRING", CreatedBy: "unit_test"}).Return(found, nil)
+		}, http.StatusOK},
+		{"issue", http.MethodPost, "/api/v2/invoices/" + id.String() + "/issue", `{"issued_by": "unit_test"}`, func() {
+			invoices.EXPECT().IssueInvoice(gomock.Any(), invoice.IssueInvoiceRequest{Id: id, IssuedBy: "unit_test"}).Return(found, nil)
+		}, http.StatusOK},
+		{"invalid id", http.MethodPost, "/api/v2/invoices/1/issue", `{}`, func() {}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.expect()
+			rec := serve(a, tt.method, tt.path, tt.body)
+			assert.Equal(t, tt.expectedCode, rec.Code, rec.Body.String())
+		})
+	}
+}
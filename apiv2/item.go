@@ -0,0 +1,154 @@
+package apiv2
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/context"
+	"inventory-service-go/item"
+	"net/http"
+)
+
+// GetAllItems
+//
+//	@Summary		List Items
+//	@Description	List the Items a page at a time
+//	@Id				all_items
+//	@Tags			item
+//	@Produce		json
+//	@Param			cursor	query		string	false	"next_cursor of the previous page"
+//	@Param			limit	query		int		false	"number of items per page, at most 100"	default(20)
+//	@Success		200		{object}	apiv2.List[apiv2.Item]	"OK"
+//	@Failure		400		{object}	apiv2.Error					"Bad Request"
+//	@Failure		500		{object}	apiv2.Error					"Internal Server Error"
+//	@Router			/items [get]
+func GetAllItems(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		pagination, err := paginationFromRequest(c)
+		if err != nil {
+			return badRequest(c, err.Error())
+		}
+		items, err := a.ItemService().GetItems(c.Request().Context(), pagination)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, page(items, pagination, func(i item.Item) int { return i.Seq }, toItem))
+	}
+}
+
+// GetItem
+//
+//	@Summary		Get Item
+//	@Description	Get a specific Item
+//	@Id				get_item
+//	@Tags			item
+//	@Produce		json
+//	@Param			id	path		string			true	"id of the Item requested"	format(uuid)
+//	@Success		200	{object}	apiv2.Item	"OK"
+//	@Failure		400	{object}	apiv2.Error		"Bad Request"
+//	@Failure		404	{object}	apiv2.Error		"Not Found"
+//	@Failure		500	{object}	apiv2.Error		"Internal Server Error"
+//	@Router			/items/{id} [get]
+func GetItem(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		result, err := a.ItemService().GetItem(c.Request().Context(), id)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, toItem(*result))
+	}
+}
+
+// CreateItem
+//
+//	@Summary		Create Item
+//	@Description	Create an Item
+//	@Id				create_item
+//	@Tags			item
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		item.CreateItemRequest	true	"Create Item Request"
+//	@Success		201		{object}	apiv2.Item				"Created"
+//	@Failure		400		{object}	apiv2.Error					"Bad Request"
+//	@Failure		500		{object}	apiv2.Error					"Internal Server Error"
+//	@Router			/items [post]
+func CreateItem(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var request item.CreateItemRequest
+		if err := c.Bind(&request); err != nil {
+			return badRequest(c, "invalid request body")
+		}
+		result, err := a.ItemService().CreateItem(c.Request().Context(), request)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusCreated, toItem(*result))
+	}
+}
+
+// UpdateItem
+//
+//	@Summary		Update Item
+//	@Description	Update an Item, the id in the body may be left out
+//	@Id				update_item
+//	@Tags			item
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"id of the Item"	format(uuid)
+//	@Param			request	body		item.UpdateItemRequest	true	"Update Item Request"
+//	@Success		200		{object}	apiv2.Item				"OK"
+//	@Failure		400		{object}	apiv2.Error					"Bad Request"
+//	@Failure		404		{object}	apiv2.Error					"Not Found"
+//	@Failure		500		{object}	apiv2.Error					"Internal Server Error"
+//	@Router			/items/{id} [put]
+func UpdateItem(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		var request item.UpdateItemRequest
+		if err = c.Bind(&request); err != nil {
+			return badRequest(c, "invalid request body")
+		}
+		if request.Id != uuid.Nil && request.Id != id {
+			return badRequest(c, idMismatch)
+		}
+		request.Id = id
+		result, err := a.ItemService().UpdateItem(c.Request().Context(), request)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, toItem(*result))
+	}
+}
+
+// DeleteItem
+//
+//	@Summary		Delete Item
+//	@Description	Remove a specific Item
+//	@Id				delete_item
+//	@Tags			item
+//	@Produce		json
+//	@Param			id	path		string					true	"id of the Item to be deleted"	format(uuid)
+//	@Success		200	{object}	commons.DeleteResult	"OK"
+//	@Failure		400	{object}	apiv2.Error				"Bad Request"
+//	@Failure		404	{object}	apiv2.Error				"Not Found"
+//	@Failure		500	{object}	apiv2.Error				"Internal Server Error"
+//	@Router			/items/{id} [delete]
+func DeleteItem(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		result, err := a.ItemService().DeleteItem(c.Request().Context(), id)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
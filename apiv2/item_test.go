@@ -0,0 +1,57 @@
+package apiv2
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/item"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestItems(t *testing.T) {
+	controller := gomock.NewController(t)
+	items := item.NewMockItemService(controller)
+	a := context.MockApplicationContext(nil, items, nil)
+	created := time.Date(2026, time.October, 19, 14, 0, 0, 500000000, time.FixedZone("CEST", 2*60*60))
+	widget := item.Item{
+		Seq:       3,
+		Id:        uuid.New(),
+		Name:      "widget",
+		UnitPrice: commons.NewMoney(1250, "EUR"),
+		AuditInfo: commons.AuditInfo{CreatedAt: created.Format(time.RFC3339Nano), LastUpdate: created.Format(time.RFC3339Nano), CreatedTime: created, LastUpdateTime: created},
+	}
+
+	items.EXPECT().GetItems(gomock.Any(), &commons.Pagination{PageSize: 1}).Return([]item.Item{widget}, nil)
+	rec := serve(a, http.MethodGet, "/api/v2/items?limit=1", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"data": [{
+			"id": "`+widget.Id.String()+`", "name": "widget", "description": "", "tax_category": "",
+			"unit_price": {"amount": "12.50", "currency": "EUR"},
+			"audit_info": {"created_by": "", "created_at": "2026-10-19T12:00:00.5Z", "last_changed_by": "", "last_updated_at": "2026-10-19T12:00:00.5Z"}
+		}],
+		"next_cursor": "`+encodeCursor(3)+`"
+	}`, rec.Body.String())
+
+	items.EXPECT().GetItem(gomock.Any(), widget.Id).Return(&widget, nil)
+	rec = serve(a, http.MethodGet, "/api/v2/items/"+widget.Id.String(), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	request := item.CreateItemRequest{Name: "widget", UnitPrice: commons.NewMoney(1250, "EUR"), CreatedBy: "unit_test"}
+	items.EXPECT().CreateItem(gomock.Any(), request).Return(nil, commons.NewValidationError("name already taken"))
+	rec = serve(a, http.MethodPost, "/api/v2/items", `{"name": "widget", "unit_price": {"amount": "12.50", "currency": "EUR"}, "created_by": "unit_test"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"code": "bad_request", "message": "name already taken"}`, rec.Body.String())
+
+	items.EXPECT().UpdateItem(gomock.Any(), item.UpdateItemRequest{Id: widget.Id, Name: "gadget"}).Return(&widget, nil)
+	rec = serve(a, http.MethodPut, "/api/v2/items/"+widget.Id.String(), `{"name": "gadget"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	items.EXPECT().DeleteItem(gomock.Any(), widget.Id).Return(&commons.DeleteResult{Id: widget.Id, Deleted: true}, nil)
+	rec = serve(a, http.MethodDelete, "/api/v2/items/"+widget.Id.String(), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
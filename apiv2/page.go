@@ -0,0 +1,64 @@
+package apiv2
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/commons"
+	"strconv"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+var errInvalidPage = errors.New("invalid cursor or limit")
+
+// paginationFromRequest reads the cursor and limit query parameters. Unlike v1 every list is paged, the first page
+// when there is no cursor.
+func paginationFromRequest(c echo.Context) (*commons.Pagination, error) {
+	pagination := &commons.Pagination{PageSize: defaultLimit}
+	if limit := c.QueryParam("limit"); limit != "" {
+		size, err := strconv.Atoi(limit)
+		if err != nil || size < 1 || size > maxLimit {
+			return nil, errInvalidPage
+		}
+		pagination.PageSize = size
+	}
+	if cursor := c.QueryParam("cursor"); cursor != "" {
+		lastId, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, errInvalidPage
+		}
+		pagination.LastId = lastId
+	}
+	return pagination, nil
+}
+
+// The cursor is the seq of the last element of a page. It is encoded to keep clients from taking it for an id,
+// not to hide it.
+func encodeCursor(seq int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(seq)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	seq, err := strconv.Atoi(string(decoded))
+	if err == nil && seq < 0 {
+		err = errInvalidPage
+	}
+	return seq, err
+}
+
+// page maps a page fetched with pagination, with a cursor to the next page when this one is full.
+func page[T any, D any](list []T, pagination *commons.Pagination, seq func(T) int, mapping func(T) D) List[D] {
+	result := List[D]{Data: mapAll(list, mapping)}
+	if len(list) > 0 && len(list) >= pagination.PageSize {
+		result.NextCursor = encodeCursor(seq(list[len(list)-1]))
+	}
+	return result
+}
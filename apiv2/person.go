@@ -0,0 +1,154 @@
+package apiv2
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/context"
+	"inventory-service-go/person"
+	"net/http"
+)
+
+// GetAllPersons
+//
+//	@Summary		List Persons
+//	@Description	List the Persons a page at a time
+//	@Id				all_persons
+//	@Tags			person
+//	@Produce		json
+//	@Param			cursor	query		string	false	"next_cursor of the previous page"
+//	@Param			limit	query		int		false	"number of persons per page, at most 100"	default(20)
+//	@Success		200		{object}	apiv2.List[apiv2.Person]	"OK"
+//	@Failure		400		{object}	apiv2.Error					"Bad Request"
+//	@Failure		500		{object}	apiv2.Error					"Internal Server Error"
+//	@Router			/persons [get]
+func GetAllPersons(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		pagination, err := paginationFromRequest(c)
+		if err != nil {
+			return badRequest(c, err.Error())
+		}
+		persons, err := a.PersonService().GetAll(c.Request().Context(), pagination)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, page(persons, pagination, func(p person.Person) int { return p.Seq }, toPerson))
+	}
+}
+
+// GetPerson
+//
+//	@Summary		Get Person
+//	@Description	Get a specific Person
+//	@Id				get_person
+//	@Tags			person
+//	@Produce		json
+//	@Param			id	path		string			true	"id of the Person requested"	format(uuid)
+//	@Success		200	{object}	apiv2.Person	"OK"
+//	@Failure		400	{object}	apiv2.Error		"Bad Request"
+//	@Failure		404	{object}	apiv2.Error		"Not Found"
+//	@Failure		500	{object}	apiv2.Error		"Internal Server Error"
+//	@Router			/persons/{id} [get]
+func GetPerson(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		result, err := a.PersonService().GetById(c.Request().Context(), id)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, toPerson(*result))
+	}
+}
+
+// CreatePerson
+//
+//	@Summary		Create Person
+//	@Description	Create a Person
+//	@Id				create_person
+//	@Tags			person
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		person.CreatePersonRequest	true	"Create Person Request"
+//	@Success		201		{object}	apiv2.Person				"Created"
+//	@Failure		400		{object}	apiv2.Error					"Bad Request"
+//	@Failure		500		{object}	apiv2.Error					"Internal Server Error"
+//	@Router			/persons [post]
+func CreatePerson(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var request person.CreatePersonRequest
+		if err := c.Bind(&request); err != nil {
+			return badRequest(c, "invalid request body")
+		}
+		result, err := a.PersonService().Create(c.Request().Context(), request)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusCreated, toPerson(*result))
+	}
+}
+
+// UpdatePerson
+//
+//	@Summary		Update Person
+//	@Description	Update a Person, the id in the body may be left out
+//	@Id				update_person
+//	@Tags			person
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"id of the Person"	format(uuid)
+//	@Param			request	body		person.UpdatePersonRequest	true	"Update Person Request"
+//	@Success		200		{object}	apiv2.Person				"OK"
+//	@Failure		400		{object}	apiv2.Error					"Bad Request"
+//	@Failure		404		{object}	apiv2.Error					"Not Found"
+//	@Failure		500		{object}	apiv2.Error					"Internal Server Error"
+//	@Router			/persons/{id} [put]
+func UpdatePerson(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		var request person.UpdatePersonRequest
+		if err = c.Bind(&request); err != nil {
+			return badRequest(c, "invalid request body")
+		}
+		if request.Id != uuid.Nil && request.Id != id {
+			return badRequest(c, idMismatch)
+		}
+		request.Id = id
+		result, err := a.PersonService().Update(c.Request().Context(), request)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, toPerson(*result))
+	}
+}
+
+// DeletePerson
+//
+//	@Summary		Delete Person
+//	@Description	Remove a specific Person
+//	@Id				delete_person
+//	@Tags			person
+//	@Produce		json
+//	@Param			id	path		string					true	"id of the Person to be deleted"	format(uuid)
+//	@Success		200	{object}	commons.DeleteResult	"OK"
+//	@Failure		400	{object}	apiv2.Error				"Bad Request"
+//	@Failure		404	{object}	apiv2.Error				"Not Found"
+//	@Failure		500	{object}	apiv2.Error				"Internal Server Error"
+//	@Router			/persons/{id} [delete]
+func DeletePerson(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return badRequest(c, "invalid id")
+		}
+		result, err := a.PersonService().DeleteByUuid(c.Request().Context(), id)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,142 @@
+package apiv2
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/person"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func personFixture(seq int) person.Person {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	return person.Person{
+		Seq:   seq,
+		Id:    uuid.New(),
+		Name:  "John Doe",
+		Email: "john.doe@test.com",
+		AuditInfo: commons.AuditInfo{
+			CreatedBy:      "unit_test",
+			CreatedAt:      now.String(),
+			LastUpdate:     now.String(),
+			LastChangedBy:  "unit_test",
+			CreatedTime:    now,
+			LastUpdateTime: now,
+		},
+	}
+}
+
+func TestGetAllPersons(t *testing.T) {
+	controller := gomock.NewController(t)
+	persons := person.NewMockPersonService(controller)
+	a := context.MockApplicationContext(persons, nil, nil)
+	first, second := personFixture(5), personFixture(6)
+
+	persons.EXPECT().GetAll(gomock.Any(), &commons.Pagination{LastId: 0, PageSize: 2}).Return([]person.Person{first, second}, nil)
+	rec := serve(a, http.MethodGet, "/api/v2/persons?limit=2", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"data": [
+			{"id": "`+first.Id.String()+`", "name": "John Doe", "email": "john.doe@test.com", "audit_info": {"created_by": "unit_test", "created_at": "2026-10-19T12:00:00Z", "last_changed_by": "unit_test", "last_updated_at": "2026-10-19T12:00:00Z"}},
+			{"id": "`+second.Id.String()+`", "name": "John Doe", "email": "john.doe@test.com", "audit_info": {"created_by": "unit_test", "created_at": "2026-10-19T12:00:00Z", "last_changed_by": "unit_test", "last_updated_at": "2026-10-19T12:00:00Z"}}
+		],
+		"next_cursor": "`+encodeCursor(6)+`"
+	}`, rec.Body.String())
+
+	persons.EXPECT().GetAll(gomock.Any(), &commons.Pagination{LastId: 6, PageSize: defaultLimit}).Return([]person.Person{}, nil)
+	rec = serve(a, http.MethodGet, "/api/v2/persons?cursor="+encodeCursor(6), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"data": []}`, rec.Body.String())
+
+	for _, query := range []string{"limit=0", "limit=101", "cursor=6"} {
+		rec = serve(a, http.MethodGet, "/api/v2/persons?"+query, "")
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+		assert.JSONEq(t, `{"code": "bad_request", "message": "invalid cursor or limit"}`, rec.Body.String())
+	}
+}
+
+func TestGetPerson(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name         string
+		id           string
+		err          error
+		expectedCode int
+		expectedBody string
+	}{
+		{"found", id.String(), nil, http.StatusOK, ""},
+		{"invalid id", "1", nil, http.StatusBadRequest, `{"code": "bad_request", "message": "invalid id"}`},
+		{"not found", id.String(), sql.ErrNoRows, http.StatusNotFound, `{"code": "not_found", "message": "not found"}`},
+		{"internal error", id.String(), errors.New("connection refused"), http.StatusInternalServerError, `{"code": "internal", "message": "internal error"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			persons := person.NewMockPersonService(controller)
+			if tt.id != "1" {
+				found := personFixture(1)
+				persons.EXPECT().GetById(gomock.Any(), id).Return(&found, tt.err)
+			}
+
+			rec := serve(context.MockApplicationContext(persons, nil, nil), http.MethodGet, "/api/v2/persons/"+tt.id, "")
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCreatePerson(t *testing.T) {
+	controller := gomock.NewController(t)
+	persons := person.NewMockPersonService(controller)
+	created := personFixture(1)
+	persons.EXPECT().Create(gomock.Any(), person.CreatePersonRequest{Name: "John Doe", Email: "john.doe@test.com", CreatedBy: "unit_test"}).Return(&created, nil)
+
+	rec := serve(context.MockApplicationContext(persons, nil, nil), http.MethodPost, "/api/v2/persons", `{"name": "John Doe", "email": "john.doe@test.com", "created_by": "unit_test"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), created.Id.String())
+}
+
+func TestUpdatePerson(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode int
+	}{
+		{"id left out", `{"name": "Jane Doe"}`, http.StatusOK},
+		{"same id", `{"id": "` + id.String() + `", "name": "Jane Doe"}`, http.StatusOK},
+		{"other id", `{"id": "` + uuid.NewString() + `", "name": "Jane Doe"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			persons := person.NewMockPersonService(controller)
+			if tt.expectedCode == http.StatusOK {
+				updated := personFixture(1)
+				persons.EXPECT().Update(gomock.Any(), person.UpdatePersonRequest{Id: id, Name: "Jane Doe"}).Return(&updated, nil)
+			}
+
+			rec := serve(context.MockApplicationContext(persons, nil, nil), http.MethodPut, "/api/v2/persons/"+id.String(), tt.body)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestDeletePerson(t *testing.T) {
+	controller := gomock.NewController(t)
+	persons := person.NewMockPersonService(controller)
+	id := uuid.New()
+	persons.EXPECT().DeleteByUuid(gomock.Any(), id).Return(&commons.DeleteResult{Id: id, Deleted: true}, nil)
+
+	rec := serve(context.MockApplicationContext(persons, nil, nil), http.MethodDelete, "/api/v2/persons/"+id.String(), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"id": "`+id.String()+`", "deleted": true}`, rec.Body.String())
+}
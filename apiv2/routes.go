@@ -0,0 +1,47 @@
+package apiv2
+
+import (
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/context"
+)
+
+// Routes registers v2 on g, the /api/v2 group. It takes the tokens of /api/v1/authorize.
+//
+//	@title			Inventory Service API
+//	@version		2.0
+//	@description	Version 2 of the inventory-service API, with the ids of the database left out, RFC 3339 timestamps and paged lists.
+//
+//	@contact.name	API Support
+//	@contact.url	http://localhost/support
+//
+//	@license.name	Apache 2.0
+//	@license.url	http://www.apache.org/licenses/LICENSE-2.0.html
+//
+//	@host			localhost:8080
+//	@BasePath		/api/v2
+func Routes(g *echo.Group, a context.ApplicationContext) {
+	g.GET("/persons", GetAllPersons(a))
+	g.GET("/persons/:id", GetPerson(a))
+	g.POST("/persons", CreatePerson(a))
+	g.PUT("/persons/:id", UpdatePerson(a))
+	g.DELETE("/persons/:id", DeletePerson(a))
+
+	g.GET("/items", GetAllItems(a))
+	g.GET("/items/:id", GetItem(a))
+	g.POST("/items", CreateItem(a))
+	g.PUT("/items/:id", UpdateItem(a))
+	g.DELETE("/items/:id", DeleteItem(a))
+
+	g.GET("/invoices", GetAllInvoices(a))
+	g.GET("/invoices/:id", GetInvoice(a))
+	g.GET("/invoices/by-number/:number", GetInvoiceByNumber(a))
+	g.GET("/invoices/user/:userId", GetAllInvoicesForUser(a))
+	g.POST("/invoices", CreateInvoice(a))
+	g.PUT("/invoices/:id", UpdateInvoice(a))
+	g.DELETE("/invoices/:id", DeleteInvoice(a))
+	g.POST("/invoices/:id/items", AddItemsToInvoice(a))
+	g.DELETE("/invoices/:id/items/:itemId", RemoveItemFromInvoice(a))
+	g.POST("/invoices/:id/discounts", AddInvoiceDiscount(a))
+	g.POST("/invoices/:id/promotions", ApplyPromotion(a))
+	g.POST("/invoices/:id/issue", IssueInvoice(a))
+}
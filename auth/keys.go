@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+)
+
+// minRSABits is the smallest RSA key accepted for RS256, RFC 7518 section 3.3.
+const minRSABits = 2048
+
+// SigningKey is a private key of a KeySet. Kid is the JWK thumbprint of its public key (RFC 7638), so the same file
+// has the same kid on every replica.
+type SigningKey struct {
+	Kid        string
+	Method     jwt.SigningMethod
+	Private    crypto.Signer
+	ActiveFrom time.Time
+}
+
+// LoadSigningKey reads a PEM private key from path, see ParseSigningKey.
+func LoadSigningKey(path string, activeFrom time.Time) (SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SigningKey{}, err
+	}
+	key, err := ParseSigningKey(data, activeFrom)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// ParseSigningKey parses an RSA key, which signs with RS256, or an Ed25519 key, which signs with EdDSA. RSA keys may
+// be PKCS #1 or PKCS #8, Ed25519 keys PKCS #8 as written by openssl genpkey.
+func ParseSigningKey(data []byte, activeFrom time.Time) (SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return SigningKey{}, errors.New("no PEM private key found")
+	}
+	var parsed any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return SigningKey{}, err
+	}
+	key := SigningKey{ActiveFrom: activeFrom}
+	switch private := parsed.(type) {
+	case *rsa.PrivateKey:
+		if private.N.BitLen() < minRSABits {
+			return SigningKey{}, fmt.Errorf("RSA keys need at least %d bits", minRSABits)
+		}
+		key.Method, key.Private = jwt.SigningMethodRS256, private
+	case ed25519.PrivateKey:
+		key.Method, key.Private = jwt.SigningMethodEdDSA, private
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported key type %T, only RSA and Ed25519 are", parsed)
+	}
+	key.Kid = key.JWK().thumbprint()
+	return key, nil
+}
+
+// JWK is a public key of a JWKS, RFC 7517. N and E are set for RSA keys, Crv and X for Ed25519 keys (RFC 8037).
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is the JSON Web Key Set verifiers fetch the public keys of the tokens from.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is the public key.
+func (k SigningKey) JWK() JWK {
+	jwk := JWK{Use: "sig", Alg: k.Method.Alg(), Kid: k.Kid}
+	switch public := k.Private.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty, jwk.Crv = "OKP", "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+	return jwk
+}
+
+// thumbprint hashes the required members of the key in lexicographic order, RFC 7638 section 3.
+func (j JWK) thumbprint() string {
+	var members any
+	if j.Kty == "RSA" {
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{j.E, j.Kty, j.N}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{j.Crv, j.Kty, j.X}
+	}
+	// the members are plain base64url and names, nothing json.Marshal would escape
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// KeySet rotates the signing keys on the schedule of their ActiveFrom. The latest active key signs, and every key is
+// published for overlap around its time:
+//   - a key is published overlap before it becomes active, so the verifiers have fetched it when the first token
+//     signed with it arrives
+//   - a key is published until overlap after its successor became active, so the tokens it signed stay valid until
+//     they expire, overlap has to be at least the token TTL
+//
+// A key whose successor has no schedule, the keys of the environment, is retired from when the set was loaded.
+type KeySet struct {
+	keys    []SigningKey
+	overlap time.Duration
+	loaded  time.Time
+	now     func() time.Time
+}
+
+// NewKeySet orders the keys by ActiveFrom, keys of the same time in the order given, the later signing. Until the
+// first becomes active it signs anyway.
+func NewKeySet(keys []SigningKey, overlap time.Duration) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("a key set needs a key")
+	}
+	kids := map[string]bool{}
+	for _, key := range keys {
+		if kids[key.Kid] {
+			return nil, fmt.Errorf("key %s is given twice", key.Kid)
+		}
+		kids[key.Kid] = true
+	}
+	keys = append([]SigningKey(nil), keys...)
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].ActiveFrom.Before(keys[j].ActiveFrom) })
+	return &KeySet{keys: keys, overlap: overlap, loaded: time.Now(), now: time.Now}, nil
+}
+
+// signing is the index of the signing key at now.
+func (s *KeySet) signing(now time.Time) int {
+	current := 0
+	for i, key := range s.keys {
+		if !key.ActiveFrom.After(now) {
+			current = i
+		}
+	}
+	return current
+}
+
+// published tells whether the key at i is published at now, see KeySet.
+func (s *KeySet) published(i int, now time.Time) bool {
+	current := s.signing(now)
+	switch {
+	case i > current:
+		return !now.Before(s.keys[i].ActiveFrom.Add(-s.overlap))
+	case i < current:
+		retired := s.keys[i+1].ActiveFrom
+		if retired.Before(s.loaded) {
+			retired = s.loaded
+		}
+		return now.Before(retired.Add(s.overlap))
+	}
+	return true
+}
+
+// Signing is the key new tokens are signed with.
+func (s *KeySet) Signing() SigningKey {
+	return s.keys[s.signing(s.now())]
+}
+
+// Verifying is the published key of kid.
+func (s *KeySet) Verifying(kid string) (SigningKey, bool) {
+	now := s.now()
+	for i, key := range s.keys {
+		if key.Kid == kid && s.published(i, now) {
+			return key, true
+		}
+	}
+	return SigningKey{}, false
+}
+
+// JWKS are the published keys.
+func (s *KeySet) JWKS() JWKS {
+	now := s.now()
+	jwks := JWKS{Keys: []JWK{}}
+	for i, key := range s.keys {
+		if s.published(i, now) {
+			jwks.Keys = append(jwks.Keys, key.JWK())
+		}
+	}
+	return jwks
+}
@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/config"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func ed25519PEM(t *testing.T) []byte {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when generating a key", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when marshalling a key", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func rsaPEM(t *testing.T, bits int) []byte {
+	private, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when generating a key", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(private)})
+}
+
+func signingKey(t *testing.T, activeFrom time.Time) SigningKey {
+	key, err := ParseSigningKey(ed25519PEM(t), activeFrom)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing a key", err)
+	}
+	return key
+}
+
+func TestParseSigningKey(t *testing.T) {
+	ec, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when generating a key", err)
+	}
+	ecDER, _ := x509.MarshalPKCS8PrivateKey(ec)
+
+	t.Run("Ed25519", func(t *testing.T) {
+		key, err := ParseSigningKey(ed25519PEM(t), time.Time{})
+		assert.NoError(t, err)
+		assert.Equal(t, jwt.SigningMethodEdDSA, key.Method)
+		assert.Len(t, key.Kid, 43)
+		jwk := key.JWK()
+		assert.Equal(t, "OKP", jwk.Kty)
+		assert.Equal(t, "Ed25519", jwk.Crv)
+		assert.Equal(t, "EdDSA", jwk.Alg)
+		assert.Equal(t, key.Kid, jwk.Kid)
+	})
+	t.Run("RSA", func(t *testing.T) {
+		key, err := ParseSigningKey(rsaPEM(t, 2048), time.Time{})
+		assert.NoError(t, err)
+		assert.Equal(t, jwt.SigningMethodRS256, key.Method)
+		jwk := key.JWK()
+		assert.Equal(t, "RSA", jwk.Kty)
+		assert.Equal(t, "AQAB", jwk.E)
+		assert.Equal(t, "RS256", jwk.Alg)
+	})
+	t.Run("invalid keys", func(t *testing.T) {
+		for name, data := range map[string][]byte{
+			"not PEM":    []byte("secret"),
+			"public key": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte{1}}),
+			"malformed":  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte{1}}),
+			"ECDSA":      pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecDER}),
+			"short RSA":  rsaPEM(t, 1024),
+		} {
+			_, err := ParseSigningKey(data, time.Time{})
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+func TestJWK_Thumbprint(t *testing.T) {
+	// RFC 8037 appendix A.3
+	jwk := JWK{Kty: "OKP", Crv: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}
+	assert.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", jwk.thumbprint())
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	october := signingKey(t, time.Time{})
+	november := signingKey(t, start.AddDate(0, 1, 0))
+	set, err := NewKeySet([]SigningKey{november, october}, 24*time.Hour)
+	assert.NoError(t, err)
+	set.loaded = start
+	kids := func() []string {
+		var kids []string
+		for _, jwk := range set.JWKS().Keys {
+			kids = append(kids, jwk.Kid)
+		}
+		return kids
+	}
+	at := func(t time.Time) { set.now = func() time.Time { return t } }
+
+	at(start)
+	assert.Equal(t, october.Kid, set.Signing().Kid)
+	assert.Equal(t, []string{october.Kid}, kids())
+	_, ok := set.Verifying(november.Kid)
+	assert.False(t, ok)
+
+	// published a day before it signs
+	at(november.ActiveFrom.Add(-time.Hour))
+	assert.Equal(t, october.Kid, set.Signing().Kid)
+	assert.Equal(t, []string{october.Kid, november.Kid}, kids())
+
+	// the tokens of october are valid for a day more
+	at(november.ActiveFrom.Add(time.Hour))
+	assert.Equal(t, november.Kid, set.Signing().Kid)
+	_, ok = set.Verifying(october.Kid)
+	assert.True(t, ok)
+
+	at(november.ActiveFrom.Add(25 * time.Hour))
+	assert.Equal(t, []string{november.Kid}, kids())
+	_, ok = set.Verifying(october.Kid)
+	assert.False(t, ok)
+}
+
+func TestKeySet_Unscheduled(t *testing.T) {
+	old, current := signingKey(t, time.Time{}), signingKey(t, time.Time{})
+	set, err := NewKeySet([]SigningKey{old, current}, time.Hour)
+	assert.NoError(t, err)
+	// the later key signs and the former is retired from when the set was loaded
+	assert.Equal(t, current.Kid, set.Signing().Kid)
+	_, ok := set.Verifying(old.Kid)
+	assert.True(t, ok)
+	set.now = func() time.Time { return set.loaded.Add(time.Hour) }
+	_, ok = set.Verifying(old.Kid)
+	assert.False(t, ok)
+
+	_, err = NewKeySet(nil, time.Hour)
+	assert.Error(t, err)
+	_, err = NewKeySet([]SigningKey{old, old}, time.Hour)
+	assert.Error(t, err)
+}
+
+func TestJwtAuthProvider_Keys(t *testing.T) {
+	key := signingKey(t, time.Time{})
+	other := signingKey(t, time.Time{})
+	set, err := NewKeySet([]SigningKey{key}, time.Hour)
+	assert.NoError(t, err)
+	provider := &JwtAuthProvider{Keys: set}
+
+	token, err := provider.Authenticate(context.Background(), "foo", "bar")
+	assert.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "EdDSA", parsed.Header["alg"])
+	assert.Equal(t, key.Kid, parsed.Header["kid"])
+	claims, err := provider.ValidateToken(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", claims.Username)
+	assert.Len(t, provider.JWKS().Keys, 1)
+
+	// signed with a key that is not in the set
+	forged := jwt.NewWithClaims(jwt.SigningMethodEdDSA, &Claims{Username: "foo"})
+	forged.Header["kid"] = other.Kid
+	signed, _ := forged.SignedString(other.Private)
+	_, err = provider.ValidateToken(context.Background(), signed)
+	assert.Error(t, err)
+	// signed with the key of the set without a kid
+	delete(forged.Header, "kid")
+	signed, _ = forged.SignedString(key.Private)
+	_, err = provider.ValidateToken(context.Background(), signed)
+	assert.Error(t, err)
+
+	// HS256 tokens only with a secret, as before the switch
+	hs256, _ := NewJwtAuthProvider("dummy_secret").Authenticate(context.Background(), "foo", "bar")
+	_, err = provider.ValidateToken(context.Background(), hs256)
+	assert.Error(t, err)
+	provider.Secret = "dummy_secret"
+	_, err = provider.ValidateToken(context.Background(), hs256)
+	assert.NoError(t, err)
+	assert.Empty(t, NewJwtAuthProvider("dummy_secret").JWKS().Keys)
+}
+
+func TestNewFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwt.pem")
+	assert.NoError(t, os.WriteFile(path, ed25519PEM(t), 0o600))
+
+	provider, err := NewFromConfig(config.JWT{Secret: "secret", TokenTTL: time.Hour}, config.OAuth{}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, provider.Keys)
+	provider, err = NewFromConfig(config.JWT{TokenTTL: time.Hour, Keys: []config.SigningKey{{File: path}}, RotationOverlap: time.Hour}, config.OAuth{}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, provider.JWKS().Keys, 1)
+	_, err = NewFromConfig(config.JWT{Keys: []config.SigningKey{{File: path + ".missing"}}}, config.OAuth{}, nil)
+	assert.Error(t, err)
+}
@@ -1,29 +1,115 @@
 package auth
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/config"
 	"os"
 	"time"
 )
 
+// HeaderAPIKey carries the API key of machine clients instead of a token.
+const HeaderAPIKey = "X-API-Key"
+
 type Credentials struct {
 	ClientId     string `json:"client_id"`
 	ClientSecret string `json:"client_secret"`
 }
 
+// TokenCredentials is the token a client is given for its Credentials.
+type TokenCredentials struct {
+	Token     string `json:"token"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// Claims of the tokens. Tenant is the tenant the token is scoped to, tokens without one are of the default tenant.
 type Claims struct {
 	Username string `json:"username"`
+	Tenant   string `json:"tenant,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// TenantId is the tenant of the token, commons.DefaultTenant when it has none.
+func (c *Claims) TenantId() (uuid.UUID, error) {
+	if c.Tenant == "" {
+		return commons.DefaultTenant, nil
+	}
+	return uuid.Parse(c.Tenant)
+}
+
 type AuthProvider interface {
-	Authenticate(username, password string) (string, error)
+	Authenticate(ctx context.Context, username, password string) (string, error)
+	// VerifyClient checks the client secret of a service account, for the client credentials grant, and returns the
+	// tenant of the account.
+	VerifyClient(ctx context.Context, clientId, secret string) (uuid.UUID, error)
+	// IssueToken signs a token for the subject of the tenant without checking any credentials, for the tokens of
+	// service accounts. A ttl of zero is the configured TokenTTL.
+	IssueToken(subject string, tenant uuid.UUID, ttl time.Duration) (string, error)
+	// ValidateToken checks the signature, expiry, issuer and audience of a token the provider issued, and that the
+	// account it was issued to is still enabled, for every API that takes them.
+	ValidateToken(ctx context.Context, token string) (*Claims, error)
 	GetSecret() []byte
+	// JWKS are the public keys the tokens are verified with, none for HS256 tokens.
+	JWKS() JWKS
 }
 
+// ErrInvalidCredentials is all a client is told when it cannot sign in.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Verifier checks the password of a user or the client secret of a service account, returning the tenant of the
+// account or ErrInvalidCredentials when it does not match. CheckEnabled fails when the account was disabled or is
+// unknown, the tokens issued to it are no longer valid then.
+type Verifier interface {
+	VerifyCredentials(ctx context.Context, username string, password string) (uuid.UUID, error)
+	VerifyClient(ctx context.Context, username string, secret string) (uuid.UUID, error)
+	CheckEnabled(ctx context.Context, username string) error
+}
+
+// DefaultTokenTTL is how long issued tokens are valid unless configured otherwise.
+const DefaultTokenTTL = 12 * time.Hour
+
+// JwtAuthProvider signs HS256 tokens for the users Users verifies. Without Users only the development credentials
+// foo/bar are accepted, which is left to the tests: the server always verifies the users of the users table.
 type JwtAuthProvider struct {
-	Secret string
+	Secret   string
+	TokenTTL time.Duration
+	Users    Verifier
+	// Issuer and Audience are the iss and aud of the tokens, which are only valid with them when they are set.
+	Issuer   string
+	Audience string
+	// Keys sign the tokens with RS256 or EdDSA instead, with the kid of the key in their header. Secret then only
+	// verifies the HS256 tokens issued before the switch and should be unset once they have expired.
+	Keys *KeySet
+}
+
+// NewFromConfig is the provider of the settings, loading the keys from their PEM files. The tokens are issued by the
+// OAuth issuer, for its audience.
+func NewFromConfig(settings config.JWT, oauth config.OAuth, users Verifier) (*JwtAuthProvider, error) {
+	provider := &JwtAuthProvider{
+		Secret:   settings.Secret,
+		TokenTTL: settings.TokenTTL,
+		Users:    users,
+		Issuer:   oauth.IssuerURL(),
+		Audience: oauth.Audience,
+	}
+	if len(settings.Keys) == 0 {
+		return provider, nil
+	}
+	var keys []SigningKey
+	for _, file := range settings.Keys {
+		key, err := LoadSigningKey(file.File, file.ActiveFrom)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	var err error
+	provider.Keys, err = NewKeySet(keys, settings.RotationOverlap)
+	return provider, err
 }
 
 func NewAuthProvider() AuthProvider {
@@ -36,34 +122,133 @@ func NewAuthProvider() AuthProvider {
 
 func NewJwtAuthProvider(secret string) *JwtAuthProvider {
 	return &JwtAuthProvider{
-		Secret: secret,
+		Secret:   secret,
+		TokenTTL: DefaultTokenTTL,
 	}
 }
 
-func (p *JwtAuthProvider) Authenticate(username, password string) (string, error) {
-	// TODO - lookup from db eventually
+func (p *JwtAuthProvider) Authenticate(ctx context.Context, username, password string) (string, error) {
+	if p.Users != nil {
+		tenant, err := p.Users.VerifyCredentials(ctx, username, password)
+		if err != nil {
+			return "", err
+		}
+		return p.generateToken(username, tenant, 0)
+	}
 	if username == "foo" && password == "bar" {
-		return p.generateToken(username)
+		return p.generateToken(username, uuid.Nil, 0)
 	} else {
-		return "", errors.New("Invalid credentials")
+		return "", ErrInvalidCredentials
+	}
+}
+
+func (p *JwtAuthProvider) VerifyClient(ctx context.Context, clientId, secret string) (uuid.UUID, error) {
+	if p.Users != nil {
+		return p.Users.VerifyClient(ctx, clientId, secret)
+	}
+	if clientId == "foo" && secret == "bar" {
+		return uuid.Nil, nil
+	}
+	return uuid.Nil, ErrInvalidCredentials
+}
+
+func (p *JwtAuthProvider) IssueToken(subject string, tenant uuid.UUID, ttl time.Duration) (string, error) {
+	if subject == "" {
+		return "", errors.New("a token needs a subject")
 	}
+	return p.generateToken(subject, tenant, ttl)
+}
+
+func (p *JwtAuthProvider) ValidateToken(ctx context.Context, token string) (*Claims, error) {
+	claims := &Claims{}
+	options := []jwt.ParserOption{jwt.WithValidMethods(p.validMethods())}
+	if p.Issuer != "" {
+		options = append(options, jwt.WithIssuer(p.Issuer))
+	}
+	if p.Audience != "" {
+		options = append(options, jwt.WithAudience(p.Audience))
+	}
+	_, err := jwt.ParseWithClaims(token, claims, p.verificationKey, options...)
+	if err != nil {
+		return nil, err
+	}
+	if p.Users != nil {
+		if err = p.Users.CheckEnabled(ctx, claims.Username); err != nil {
+			return nil, err
+		}
+	}
+	return claims, nil
+}
+
+func (p *JwtAuthProvider) validMethods() []string {
+	if p.Keys == nil {
+		return []string{jwt.SigningMethodHS256.Alg()}
+	}
+	methods := []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+	if p.Secret != "" {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	return methods
+}
+
+// verificationKey selects the key of a token by its kid, or is the secret for HS256.
+func (p *JwtAuthProvider) verificationKey(token *jwt.Token) (interface{}, error) {
+	if token.Method == jwt.SigningMethodHS256 {
+		return p.GetSecret(), nil
+	}
+	kid, _ := token.Header["kid"].(string)
+	key, ok := p.Keys.Verifying(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	// a key signs with one algorithm only, RFC 8725 section 3.1
+	if key.Method != token.Method {
+		return nil, fmt.Errorf("key %q does not sign with %s", kid, token.Method.Alg())
+	}
+	return key.Private.Public(), nil
+}
+
+func (p *JwtAuthProvider) JWKS() JWKS {
+	if p.Keys == nil {
+		return JWKS{Keys: []JWK{}}
+	}
+	return p.Keys.JWKS()
 }
 
 func (p *JwtAuthProvider) GetSecret() []byte {
 	return []byte(p.Secret)
 }
 
-func (p *JwtAuthProvider) generateToken(username string) (string, error) {
+// generateToken leaves out the tenant when it is uuid.Nil, the token is then of the default tenant.
+func (p *JwtAuthProvider) generateToken(username string, tenant uuid.UUID, ttl time.Duration) (string, error) {
 	now := time.Now()
-	twelveHoursFromNow := now.Add(time.Hour * 12)
+	if ttl <= 0 {
+		ttl = p.TokenTTL
+	}
+	if ttl <= 0 {
+		ttl = DefaultTokenTTL
+	}
 	claims := &Claims{
 		Username: username,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:   p.Issuer,
 			IssuedAt: jwt.NewNumericDate(now),
 			// In JWT, the expiry time is expressed as unix milliseconds.
-			ExpiresAt: jwt.NewNumericDate(twelveHoursFromNow),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
 		},
 	}
+	if tenant != uuid.Nil {
+		claims.Tenant = tenant.String()
+	}
+	if p.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{p.Audience}
+	}
+	if p.Keys != nil {
+		key := p.Keys.Signing()
+		token := jwt.NewWithClaims(key.Method, claims)
+		token.Header["kid"] = key.Kid
+		return token.SignedString(key.Private)
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(p.Secret))
 }
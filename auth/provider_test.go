@@ -1,10 +1,15 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestJwtAuthProvider_Authenticate(t *testing.T) {
@@ -43,7 +48,7 @@ func TestJwtAuthProvider_Authenticate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			provider := NewJwtAuthProvider("dummy_secret")
-			token, err := provider.Authenticate(tt.username, tt.password)
+			token, err := provider.Authenticate(context.Background(), tt.username, tt.password)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -131,3 +136,176 @@ func TestJwtAuthProvider_GetSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestJwtAuthProvider_TokenTTL(t *testing.T) {
+	provider := &JwtAuthProvider{Secret: "dummy_secret", TokenTTL: time.Hour}
+	token, err := provider.Authenticate(context.Background(), "foo", "bar")
+	assert.NoError(t, err)
+	claims := &Claims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte("dummy_secret"), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, claims.ExpiresAt.Sub(claims.IssuedAt.Time))
+}
+
+type verifierFunc func(ctx context.Context, username string, password string) (uuid.UUID, error)
+
+func (f verifierFunc) VerifyCredentials(ctx context.Context, username string, password string) (uuid.UUID, error) {
+	return f(ctx, username, password)
+}
+
+// VerifyClient takes the client secret for the password.
+func (f verifierFunc) VerifyClient(ctx context.Context, username string, secret string) (uuid.UUID, error) {
+	return f(ctx, "client:"+username, secret)
+}
+
+// CheckEnabled asks without a password.
+func (f verifierFunc) CheckEnabled(ctx context.Context, username string) error {
+	_, err := f(ctx, "enabled:"+username, "")
+	return err
+}
+
+func TestJwtAuthProvider_AuthenticateUsers(t *testing.T) {
+	provider := NewJwtAuthProvider("dummy_secret")
+	tenant := uuid.New()
+	provider.Users = verifierFunc(func(_ context.Context, username string, password string) (uuid.UUID, error) {
+		switch {
+		case username == "ann" && password == "secret-password":
+			return tenant, nil
+		case username == "enabled:ann":
+			return uuid.Nil, nil
+		case username == "down":
+			return uuid.Nil, errors.New("connection refused")
+		default:
+			return uuid.Nil, ErrInvalidCredentials
+		}
+	})
+
+	token, err := provider.Authenticate(context.Background(), "ann", "secret-password")
+	assert.NoError(t, err)
+	claims, err := provider.ValidateToken(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, tenant.String(), claims.Tenant)
+	_, err = provider.Authenticate(context.Background(), "foo", "bar")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = provider.Authenticate(context.Background(), "down", "secret-password")
+	assert.EqualError(t, err, "connection refused")
+}
+
+func TestJwtAuthProvider_VerifyClient(t *testing.T) {
+	provider := NewJwtAuthProvider("dummy_secret")
+	tenant, err := provider.VerifyClient(context.Background(), "foo", "bar")
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Nil, tenant)
+	_, err = provider.VerifyClient(context.Background(), "foo", "wrong")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	expected := uuid.New()
+	provider.Users = verifierFunc(func(_ context.Context, username string, password string) (uuid.UUID, error) {
+		if username == "client:billing" && password == "client-secret" {
+			return expected, nil
+		}
+		return uuid.Nil, ErrInvalidCredentials
+	})
+	tenant, err = provider.VerifyClient(context.Background(), "billing", "client-secret")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, tenant)
+	// the development credentials are only for a provider without users
+	_, err = provider.VerifyClient(context.Background(), "foo", "bar")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestJwtAuthProvider_IssueToken(t *testing.T) {
+	provider := NewJwtAuthProvider("dummy_secret")
+	token, err := provider.IssueToken("billing", uuid.Nil, 30*24*time.Hour)
+	assert.NoError(t, err)
+	claims := &Claims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte("dummy_secret"), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", claims.Username)
+	assert.Equal(t, 30*24*time.Hour, claims.ExpiresAt.Sub(claims.IssuedAt.Time))
+
+	assert.Empty(t, claims.Tenant)
+
+	_, err = provider.IssueToken("", uuid.Nil, 0)
+	assert.Error(t, err)
+}
+
+func TestJwtAuthProvider_ValidateToken(t *testing.T) {
+	provider := NewJwtAuthProvider("dummy_secret")
+	token, err := provider.IssueToken("billing", uuid.Nil, time.Hour)
+	assert.NoError(t, err)
+	claims, err := provider.ValidateToken(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", claims.Username)
+
+	_, err = NewJwtAuthProvider("other_secret").ValidateToken(context.Background(), token)
+	assert.ErrorIs(t, err, jwt.ErrSignatureInvalid)
+
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{Username: "billing", RegisteredClaims: jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+	}}).SignedString([]byte("dummy_secret"))
+	assert.NoError(t, err)
+	_, err = provider.ValidateToken(context.Background(), expired)
+	assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+
+	// only the algorithm the provider signs with is accepted
+	other, err := jwt.NewWithClaims(jwt.SigningMethodHS512, &Claims{Username: "billing"}).SignedString([]byte("dummy_secret"))
+	assert.NoError(t, err)
+	_, err = provider.ValidateToken(context.Background(), other)
+	assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+}
+
+func TestJwtAuthProvider_ValidateToken_DisabledAccount(t *testing.T) {
+	provider := NewJwtAuthProvider("dummy_secret")
+	token, err := provider.IssueToken("billing", uuid.Nil, time.Hour)
+	assert.NoError(t, err)
+	disabled := errors.New("user is disabled")
+	provider.Users = verifierFunc(func(_ context.Context, username string, _ string) (uuid.UUID, error) {
+		assert.Equal(t, "enabled:billing", username)
+		return uuid.Nil, disabled
+	})
+
+	_, err = provider.ValidateToken(context.Background(), token)
+	assert.ErrorIs(t, err, disabled)
+}
+
+func TestJwtAuthProvider_IssuerAndAudience(t *testing.T) {
+	provider := NewJwtAuthProvider("dummy_secret")
+	provider.Issuer, provider.Audience = "https://inventory.example.com", "inventory-service"
+	token, err := provider.IssueToken("billing", uuid.Nil, time.Hour)
+	assert.NoError(t, err)
+	claims, err := provider.ValidateToken(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://inventory.example.com", claims.Issuer)
+	assert.Equal(t, jwt.ClaimStrings{"inventory-service"}, claims.Audience)
+
+	// a token of the same secret for another issuer or audience, or without them, is not valid here
+	for _, other := range []*JwtAuthProvider{
+		{Secret: "dummy_secret", Issuer: "https://other.example.com", Audience: "inventory-service"},
+		{Secret: "dummy_secret", Issuer: "https://inventory.example.com", Audience: "billing"},
+		{Secret: "dummy_secret"},
+	} {
+		token, err := other.IssueToken("billing", uuid.Nil, time.Hour)
+		assert.NoError(t, err)
+		_, err = provider.ValidateToken(context.Background(), token)
+		assert.Error(t, err)
+	}
+}
+
+func TestClaims_TenantId(t *testing.T) {
+	tenant, err := (&Claims{}).TenantId()
+	assert.NoError(t, err)
+	assert.Equal(t, commons.DefaultTenant, tenant)
+
+	expected := uuid.New()
+	tenant, err = (&Claims{Tenant: expected.String()}).TenantId()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, tenant)
+
+	_, err = (&Claims{Tenant: "acme"}).TenantId()
+	assert.Error(t, err)
+}
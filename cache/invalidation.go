@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+	"inventory-service-go/commons"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Channel is the Postgres channel invalidations are sent on, the payload is "<cache name>:<key>".
+const Channel = "cache_invalidation"
+
+const (
+	NOTIFY_QUERY = `SELECT pg_notify($1, $2)`
+	// reconnectDelay is the wait before listening again after the listening connection was lost
+	reconnectDelay = 5 * time.Second
+)
+
+// Invalidator drops keys from the caches of every replica.
+type Invalidator interface {
+	// Invalidate drops the key from the named cache, here and on the other replicas.
+	Invalidate(name string, key string) error
+	// Register receives the invalidations of the named cache. purge is called whenever invalidations may have been
+	// missed, while the listening connection was down.
+	Register(name string, remove func(key string), purge func())
+}
+
+type target struct {
+	remove func(key string)
+	purge  func()
+}
+
+// Bus sends invalidations with NOTIFY and receives them with LISTEN on a connection of its own, the connection pool
+// of the repositories cannot hold on to one.
+type Bus struct {
+	db      *sqlx.DB
+	uri     string
+	mu      sync.RWMutex
+	targets map[string][]target
+	cancel  context.CancelFunc
+	done    sync.WaitGroup
+}
+
+var (
+	bus     *Bus
+	busOnce sync.Once
+)
+
+// GetBus returns the bus of the process, listening from the first call on.
+func GetBus(db *sqlx.DB) *Bus {
+	busOnce.Do(func() {
+		bus = NewBus(db, commons.DatabaseURL())
+		bus.Start()
+	})
+	return bus
+}
+
+func NewBus(db *sqlx.DB, uri string) *Bus {
+	return &Bus{db: db, uri: uri, targets: map[string][]target{}}
+}
+
+func (b *Bus) Register(name string, remove func(key string), purge func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.targets[name] = append(b.targets[name], target{remove: remove, purge: purge})
+}
+
+func (b *Bus) Invalidate(name string, key string) error {
+	_, err := b.db.Exec(NOTIFY_QUERY, Channel, name+":"+key)
+	return err
+}
+
+func (b *Bus) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	b.done.Add(1)
+	go func() {
+		defer b.done.Done()
+		for {
+			err := b.listen(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Error("Error listening for cache invalidations, caches are only invalidated locally", "error", err)
+			select {
+			case <-time.After(reconnectDelay):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop closes the listening connection.
+func (b *Bus) Stop() {
+	if b.cancel != nil {
+		b.cancel()
+		b.done.Wait()
+	}
+}
+
+func (b *Bus) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, b.uri)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if _, err = conn.Exec(ctx, "LISTEN "+Channel); err != nil {
+		return err
+	}
+	// whatever was sent while not listening is lost
+	b.purgeAll()
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		b.dispatch(notification.Payload)
+	}
+}
+
+func (b *Bus) dispatch(payload string) {
+	name, key, found := strings.Cut(payload, ":")
+	if !found {
+		return
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, t := range b.targets[name] {
+		t.remove(key)
+	}
+}
+
+func (b *Bus) purgeAll() {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, targets := range b.targets {
+		for _, t := range targets {
+			t.purge()
+		}
+	}
+}
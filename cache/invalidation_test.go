@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestBus_Invalidate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	b := NewBus(sqlx.NewDb(db, "sqlmock"), "")
+
+	mock.ExpectExec(regexp.QuoteMeta(NOTIFY_QUERY)).WithArgs(Channel, "items:42").WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, b.Invalidate("items", "42"))
+
+	mock.ExpectExec(regexp.QuoteMeta(NOTIFY_QUERY)).WithArgs(Channel, "items:42").WillReturnError(errors.New("BOOM"))
+	assert.Error(t, b.Invalidate("items", "42"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBus_Dispatch(t *testing.T) {
+	b := NewBus(nil, "")
+	items := NewLRU[string, int]("items", Config{Size: 10, TTL: DefaultTTL})
+	persons := NewLRU[string, int]("persons", Config{Size: 10, TTL: DefaultTTL})
+	b.Register("items", items.Remove, items.Purge)
+	b.Register("persons", persons.Remove, persons.Purge)
+	items.Add("42", 1)
+	items.Add("43", 1)
+	persons.Add("42", 1)
+
+	b.dispatch("items:42")
+	b.dispatch("malformed")
+	b.dispatch("unknown:43")
+	_, ok := items.Get("42")
+	assert.False(t, ok)
+	_, ok = items.Get("43")
+	assert.True(t, ok)
+	_, ok = persons.Get("42")
+	assert.True(t, ok)
+
+	b.purgeAll()
+	assert.Equal(t, 0, items.Stats().Size)
+	assert.Equal(t, 0, persons.Stats().Size)
+}
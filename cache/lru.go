@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultSize = 1000
+	DefaultTTL  = 5 * time.Minute
+)
+
+// Config sizes a cache, a Size of 0 disables it, see config.Caches.
+type Config struct {
+	Size int
+	TTL  time.Duration
+}
+
+func (c Config) Enabled() bool {
+	return c.Size > 0
+}
+
+// Stats are the counters of a cache since it was created.
+type Stats struct {
+	Name      string `json:"name"`
+	Size      int    `json:"size"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+}
+
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// LRU is a bounded cache that evicts the least recently used entry when it is full and treats entries older than
+// the TTL as missing.
+type LRU[K comparable, V any] struct {
+	mu      sync.Mutex
+	name    string
+	config  Config
+	entries map[K]*list.Element
+	order   *list.List
+	// generation changes on every removal, see Generation
+	generation uint64
+	stats      Stats
+	now        func() time.Time
+}
+
+func NewLRU[K comparable, V any](name string, config Config) *LRU[K, V] {
+	c := &LRU[K, V]{
+		name:    name,
+		config:  config,
+		entries: make(map[K]*list.Element, config.Size),
+		order:   list.New(),
+		now:     time.Now,
+	}
+	register(c)
+	return c
+}
+
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	return c.getIf(key, func(V) bool { return true })
+}
+
+// getIf is Get for a value that only counts if it matches, a value that does not is a miss and is left in the cache.
+func (c *LRU[K, V]) getIf(key K, matches func(V) bool) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		ent := e.Value.(*entry[K, V])
+		if !c.now().Before(ent.expires) {
+			c.remove(e)
+		} else if matches(ent.value) {
+			c.order.MoveToFront(e)
+			c.stats.Hits++
+			return ent.value, true
+		}
+	}
+	c.stats.Misses++
+	var zero V
+	return zero, false
+}
+
+// Generation is taken before loading a missing value, so AddSince can tell whether the value was invalidated while
+// it was being loaded.
+func (c *LRU[K, V]) Generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+func (c *LRU[K, V]) Add(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.add(key, value)
+}
+
+// AddSince adds the value unless anything was removed from the cache since the generation was taken, in which case
+// the value may already be stale.
+func (c *LRU[K, V]) AddSince(key K, value V, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation == generation {
+		c.add(key, value)
+	}
+}
+
+func (c *LRU[K, V]) add(key K, value V) {
+	expires := c.now().Add(c.config.TTL)
+	if e, ok := c.entries[key]; ok {
+		ent := e.Value.(*entry[K, V])
+		ent.value, ent.expires = value, expires
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expires: expires})
+	if c.order.Len() > c.config.Size {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *LRU[K, V]) Remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+}
+
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.entries = make(map[K]*list.Element, c.config.Size)
+	c.order.Init()
+}
+
+func (c *LRU[K, V]) remove(e *list.Element) {
+	c.order.Remove(e)
+	delete(c.entries, e.Value.(*entry[K, V]).key)
+}
+
+func (c *LRU[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Name = c.name
+	stats.Size = c.order.Len()
+	return stats
+}
+
+type statser interface {
+	Stats() Stats
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []statser
+)
+
+func register(c statser) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, c)
+}
+
+// AllStats returns the stats of every cache created in the process.
+func AllStats() []Stats {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	stats := make([]Stats, 0, len(registry))
+	for _, c := range registry {
+		stats = append(stats, c.Stats())
+	}
+	return stats
+}
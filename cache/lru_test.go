@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestConfig_Enabled(t *testing.T) {
+	assert.True(t, Config{Size: DefaultSize, TTL: DefaultTTL}.Enabled())
+	assert.False(t, Config{Size: 0, TTL: 30 * time.Second}.Enabled())
+}
+
+func TestLRU_Get(t *testing.T) {
+	c := NewLRU[string, int]("test", Config{Size: 2, TTL: time.Minute})
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	c.Add("a", 1)
+	c.Add("b", 2)
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	// b is the least recently used
+	c.Add("c", 3)
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = c.Get("a")
+	assert.False(t, ok, "expired")
+
+	assert.Equal(t, Stats{Name: "test", Size: 1, Hits: 2, Misses: 3, Evictions: 1}, c.Stats())
+	assert.Contains(t, AllStats(), c.Stats())
+}
+
+func TestLRU_Remove(t *testing.T) {
+	c := NewLRU[string, int]("test", Config{Size: 2, TTL: time.Minute})
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Remove("a")
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	c.Purge()
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Stats().Size)
+}
+
+func TestLRU_AddSince(t *testing.T) {
+	c := NewLRU[string, int]("test", Config{Size: 2, TTL: time.Minute})
+	generation := c.Generation()
+	c.AddSince("a", 1, generation)
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	generation = c.Generation()
+	// invalidated while b was being loaded
+	c.Remove("b")
+	c.AddSince("b", 2, generation)
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: invalidation.go
+//
+// Generated by this command:
+//
+//	mockgen -source invalidation.go -destination mock_invalidation.go -package cache
+//
+
+// Package cache is a generated GoMock package.
+package cache
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInvalidator is a mock of Invalidator interface.
+type MockInvalidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvalidatorMockRecorder
+}
+
+// MockInvalidatorMockRecorder is the mock recorder for MockInvalidator.
+type MockInvalidatorMockRecorder struct {
+	mock *MockInvalidator
+}
+
+// NewMockInvalidator creates a new mock instance.
+func NewMockInvalidator(ctrl *gomock.Controller) *MockInvalidator {
+	mock := &MockInvalidator{ctrl: ctrl}
+	mock.recorder = &MockInvalidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvalidator) EXPECT() *MockInvalidatorMockRecorder {
+	return m.recorder
+}
+
+// Invalidate mocks base method.
+func (m *MockInvalidator) Invalidate(name, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidate", name, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockInvalidatorMockRecorder) Invalidate(name, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockInvalidator)(nil).Invalidate), name, key)
+}
+
+// Register mocks base method.
+func (m *MockInvalidator) Register(name string, remove func(string), purge func()) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Register", name, remove, purge)
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockInvalidatorMockRecorder) Register(name, remove, purge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockInvalidator)(nil).Register), name, remove, purge)
+}
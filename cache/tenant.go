@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+)
+
+type tenantValue[V any] struct {
+	tenant uuid.UUID
+	value  V
+}
+
+// TenantLRU caches the rows of a tenant table by id. A value is cached with the tenant that read it and is a miss for
+// every other tenant, the keys stay the ids so an invalidation removes the value whichever tenant read it.
+type TenantLRU[V any] struct {
+	lru *LRU[uuid.UUID, tenantValue[V]]
+}
+
+func NewTenantLRU[V any](name string, config Config) *TenantLRU[V] {
+	return &TenantLRU[V]{lru: NewLRU[uuid.UUID, tenantValue[V]](name, config)}
+}
+
+// tenantOf is uuid.Nil for the system, which only gets the values it read itself.
+func tenantOf(ctx context.Context) uuid.UUID {
+	tenant, _ := commons.TenantFrom(ctx)
+	return tenant
+}
+
+// Get counts the value of another tenant as a miss, as it is one for this tenant.
+func (c *TenantLRU[V]) Get(ctx context.Context, id uuid.UUID) (V, bool) {
+	tenant := tenantOf(ctx)
+	v, ok := c.lru.getIf(id, func(v tenantValue[V]) bool { return v.tenant == tenant })
+	return v.value, ok
+}
+
+func (c *TenantLRU[V]) Generation() uint64 {
+	return c.lru.Generation()
+}
+
+func (c *TenantLRU[V]) Add(ctx context.Context, id uuid.UUID, value V) {
+	c.lru.Add(id, tenantValue[V]{tenant: tenantOf(ctx), value: value})
+}
+
+// AddSince adds the value as LRU.AddSince does.
+func (c *TenantLRU[V]) AddSince(ctx context.Context, id uuid.UUID, value V, generation uint64) {
+	c.lru.AddSince(id, tenantValue[V]{tenant: tenantOf(ctx), value: value}, generation)
+}
+
+func (c *TenantLRU[V]) Remove(id uuid.UUID) {
+	c.lru.Remove(id)
+}
+
+func (c *TenantLRU[V]) Purge() {
+	c.lru.Purge()
+}
+
+func (c *TenantLRU[V]) Stats() Stats {
+	return c.lru.Stats()
+}
@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+func TestTenantLRU(t *testing.T) {
+	c := NewTenantLRU[string]("test_tenant", Config{Size: 2, TTL: time.Minute})
+	id := uuid.New()
+	tenant := commons.WithTenant(context.Background(), uuid.New())
+	other := commons.WithTenant(context.Background(), uuid.New())
+
+	c.Add(tenant, id, "a")
+	v, ok := c.Get(tenant, id)
+	assert.True(t, ok)
+	assert.Equal(t, "a", v)
+	_, ok = c.Get(other, id)
+	assert.False(t, ok)
+	_, ok = c.Get(context.Background(), id)
+	assert.False(t, ok)
+	stats := c.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(2), stats.Misses, "the values of other tenants are misses")
+	assert.Equal(t, 1, stats.Size)
+
+	// the other tenant replaces the value, and with it the tenant
+	c.AddSince(other, id, "b", c.Generation())
+	_, ok = c.Get(tenant, id)
+	assert.False(t, ok)
+	v, _ = c.Get(other, id)
+	assert.Equal(t, "b", v)
+
+	c.Remove(id)
+	_, ok = c.Get(other, id)
+	assert.False(t, ok)
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"github.com/golang-jwt/jwt/v5"
+	"inventory-service-go/auth"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// refreshMargin is how long before it expires a token is replaced, so it does not expire on the way to the server.
+const refreshMargin = time.Minute
+
+// tokenCache holds the token of a client, shared by its copies.
+type tokenCache struct {
+	mu          sync.Mutex
+	credentials *auth.Credentials
+	token       string
+	// expiry is zero for a token without an expiry, it is then used until the server rejects it
+	expiry time.Time
+	// apiKey is sent instead of a token
+	apiKey string
+}
+
+// WithCredentials has the client authorize with the client credentials before its first call and again before the
+// token expires or after it was rejected.
+func (c *Client) WithCredentials(clientId string, clientSecret string) *Client {
+	copied := *c
+	copied.tokens = &tokenCache{credentials: &auth.Credentials{ClientId: clientId, ClientSecret: clientSecret}}
+	return &copied
+}
+
+// WithToken has the client send a token obtained elsewhere, it is not refreshed.
+func (c *Client) WithToken(token string) *Client {
+	copied := *c
+	copied.tokens = &tokenCache{token: token}
+	return &copied
+}
+
+// WithAPIKey has the client send an API key in the X-API-Key header instead of a token.
+func (c *Client) WithAPIKey(key string) *Client {
+	copied := *c
+	copied.tokens = &tokenCache{apiKey: key}
+	return &copied
+}
+
+// Authorize exchanges client credentials for a token, see WithCredentials to have the client take care of it.
+func (c *Client) Authorize(ctx context.Context, credentials auth.Credentials) (auth.TokenCredentials, error) {
+	var result auth.TokenCredentials
+	err := c.do(ctx, call{method: http.MethodPost, path: "/authorize", body: credentials, public: true}, &result)
+	return result, err
+}
+
+// accessToken returns the cached token, authorizing first when there is none or it is about to expire. Without
+// credentials or a token the calls are made without one.
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	t := c.tokens
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.credentials == nil || (t.token != "" && (t.expiry.IsZero() || time.Now().Add(refreshMargin).Before(t.expiry))) {
+		return t.token, nil
+	}
+	result, err := c.Authorize(ctx, *t.credentials)
+	if err != nil {
+		return "", err
+	}
+	t.token, t.expiry = result.Token, expiryOf(result.Token)
+	return t.token, nil
+}
+
+func (t *tokenCache) refreshable() bool {
+	return t.credentials != nil
+}
+
+// invalidate drops the token unless another call already replaced it.
+func (t *tokenCache) invalidate(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token == token {
+		t.token, t.expiry = "", time.Time{}
+	}
+}
+
+// expiryOf reads the expiry of a token without verifying it, which is up to the server.
+func expiryOf(token string) time.Time {
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil || claims.ExpiresAt == nil {
+		return time.Time{}
+	}
+	return claims.ExpiresAt.Time
+}
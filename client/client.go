@@ -0,0 +1,220 @@
+// Package client is a typed Go client of the REST API. It reuses the request and response types of the service, takes
+// care of the token and retries the calls that are safe to repeat:
+//
+//	c := client.New("http://localhost:8080").WithCredentials(clientId, clientSecret)
+//	for p, err := range c.AllPersons(ctx, 100) {
+//		...
+//	}
+//
+// The event stream and the GraphQL endpoint are left to the clients of those protocols.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"inventory-service-go/auth"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	apiPath        = "/api/v1"
+	defaultRetries = 2
+	defaultBackoff = 200 * time.Millisecond
+	maxBackoff     = 5 * time.Second
+)
+
+// Client calls the API on baseURL, e.g. http://localhost:8080. It is safe for concurrent use, the With methods return
+// a copy with the setting changed.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	retries    int
+	backoff    time.Duration
+	tokens     *tokenCache
+}
+
+func New(baseURL string) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		retries:    defaultRetries,
+		backoff:    defaultBackoff,
+		tokens:     &tokenCache{},
+	}
+}
+
+func (c *Client) WithHTTPClient(httpClient *http.Client) *Client {
+	copied := *c
+	copied.httpClient = httpClient
+	return &copied
+}
+
+// WithRetries sets how often an idempotent call is repeated after a network error or a 429, 502, 503 or 504, waiting
+// backoff before the first retry and twice as long before every next one, unless the server sends Retry-After.
+func (c *Client) WithRetries(retries int, backoff time.Duration) *Client {
+	copied := *c
+	copied.retries = retries
+	copied.backoff = backoff
+	return &copied
+}
+
+// call is a request to the API, path is relative to /api/v1 unless root is set.
+type call struct {
+	method string
+	path   string
+	query  url.Values
+	body   any
+	// root calls go to the root of the server, like the probes
+	root bool
+	// public calls are made without a token
+	public bool
+	// once calls are not retried, also when idempotent
+	once bool
+}
+
+// do makes the call and decodes a successful response into out, if given.
+func (c *Client) do(ctx context.Context, call call, out any) error {
+	resp, err := c.send(ctx, call)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return decodeError(resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding the response of %s %s: %w", call.method, call.path, err)
+	}
+	return nil
+}
+
+// send makes the call, retrying it while allowed. A rejected token is refreshed and the call made again, once.
+func (c *Client) send(ctx context.Context, call call) (*http.Response, error) {
+	var body []byte
+	if call.body != nil {
+		var err error
+		if body, err = json.Marshal(call.body); err != nil {
+			return nil, err
+		}
+	}
+	attempts := 1
+	if !call.once && idempotent(call.method) {
+		attempts += c.retries
+	}
+	refreshed := false
+	for attempt := 0; ; attempt++ {
+		req, err := c.newRequest(ctx, call, body)
+		if err != nil {
+			return nil, err
+		}
+		var token string
+		if !call.public && c.tokens.apiKey != "" {
+			req.Header.Set(auth.HeaderAPIKey, c.tokens.apiKey)
+		} else if !call.public {
+			if token, err = c.accessToken(ctx); err != nil {
+				return nil, err
+			}
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		resp, err := c.httpClient.Do(req)
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && token != "" && !refreshed && c.tokens.refreshable() {
+			discard(resp)
+			c.tokens.invalidate(token)
+			refreshed = true
+			attempt--
+			continue
+		}
+		if attempt+1 >= attempts || !retryable(resp, err) || ctx.Err() != nil {
+			return resp, err
+		}
+		wait := c.wait(attempt, resp)
+		if resp != nil {
+			discard(resp)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) newRequest(ctx context.Context, call call, body []byte) (*http.Request, error) {
+	target := c.baseURL + call.path
+	if !call.root {
+		target = c.baseURL + apiPath + call.path
+	}
+	if len(call.query) > 0 {
+		target += "?" + call.query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, call.method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// wait is the backoff before the given retry, or what the server asks for with Retry-After in seconds.
+func (c *Client) wait(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, maxBackoff)
+		}
+	}
+	return min(c.backoff<<attempt, maxBackoff)
+}
+
+// idempotent methods can be repeated without changing the outcome, the POSTs of the API all create or change something.
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodPut || method == http.MethodDelete
+}
+
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// discard reads what is left of the body so the connection can be reused.
+func discard(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+func pathOf(segments ...string) string {
+	var b strings.Builder
+	for _, segment := range segments {
+		b.WriteString("/")
+		b.WriteString(url.PathEscape(segment))
+	}
+	return b.String()
+}
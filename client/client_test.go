@@ -0,0 +1,253 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/auth"
+	"inventory-service-go/person"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func signedToken(t *testing.T, expiresIn time.Duration) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+		ID:        strconv.FormatInt(time.Now().UnixNano(), 10),
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return token
+}
+
+// authServer issues tokens expiring in expiresIn and accepts the ones it issued last on /api/v1/persons.
+type authServer struct {
+	t          *testing.T
+	expiresIn  time.Duration
+	authorized atomic.Int32
+	current    atomic.Value
+}
+
+func (s *authServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/api/v1/authorize":
+		var credentials auth.Credentials
+		_ = json.NewDecoder(r.Body).Decode(&credentials)
+		if credentials != (auth.Credentials{ClientId: "client", ClientSecret: "secret"}) {
+			http.Error(w, "Invalid client credentials", http.StatusUnauthorized)
+			return
+		}
+		s.authorized.Add(1)
+		token := signedToken(s.t, s.expiresIn)
+		s.current.Store(token)
+		_ = json.NewEncoder(w).Encode(auth.TokenCredentials{Token: token, CreatedAt: time.Now().Unix()})
+	default:
+		if r.Header.Get("Authorization") != "Bearer "+s.current.Load().(string) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("[]"))
+	}
+}
+
+func TestClient_Credentials(t *testing.T) {
+	s := &authServer{t: t, expiresIn: time.Hour}
+	s.current.Store("")
+	server := httptest.NewServer(s)
+	defer server.Close()
+	c := New(server.URL).WithCredentials("client", "secret")
+
+	for i := 0; i < 3; i++ {
+		_, err := c.ListPersons(context.Background(), nil)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), s.authorized.Load(), "the token is cached")
+
+	// the server forgets the token, e.g. after a restart with another secret
+	s.current.Store("another")
+	_, err := c.ListPersons(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), s.authorized.Load(), "a rejected token is replaced")
+
+	_, err = New(server.URL).WithCredentials("client", "wrong").ListPersons(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.Equal(t, "Invalid client credentials", err.(*Error).Message)
+}
+
+func TestClient_CredentialsExpiring(t *testing.T) {
+	s := &authServer{t: t, expiresIn: 30 * time.Second}
+	s.current.Store("")
+	server := httptest.NewServer(s)
+	defer server.Close()
+	c := New(server.URL).WithCredentials("client", "secret")
+
+	for i := 0; i < 2; i++ {
+		_, err := c.ListPersons(context.Background(), nil)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), s.authorized.Load(), "a token about to expire is replaced")
+}
+
+func TestClient_WithToken(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("Authorization")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := New(server.URL).WithToken("static").ListPersons(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrUnauthorized, "a static token is not refreshed")
+	assert.Equal(t, "Bearer static", header)
+}
+
+func TestClient_WithAPIKey(t *testing.T) {
+	var key, authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, authorization = r.Header.Get("X-API-Key"), r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	_, err := New(server.URL).WithAPIKey("inv_secret").ListPersons(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "inv_secret", key)
+	assert.Empty(t, authorization)
+}
+
+func TestClient_Retries(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		statuses      []int
+		expectCalls   int32
+		expectedError error
+	}{
+		{"get recovers", http.MethodGet, []int{503, 502, 200}, 3, nil},
+		{"get gives up", http.MethodGet, []int{503, 503, 503, 200}, 3, ErrServer},
+		{"put recovers", http.MethodPut, []int{429, 200}, 2, nil},
+		{"post is not retried", http.MethodPost, []int{503, 200}, 1, ErrServer},
+		{"client errors are not retried", http.MethodGet, []int{404, 200}, 1, ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[calls.Add(1)-1]
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte("{}"))
+			}))
+			defer server.Close()
+			c := New(server.URL).WithRetries(2, time.Millisecond)
+
+			err := c.do(context.Background(), call{method: tt.method, path: "/persons", body: map[string]string{}}, nil)
+			if tt.expectedError == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expectedError)
+			}
+			assert.Equal(t, tt.expectCalls, calls.Load())
+		})
+	}
+}
+
+func TestClient_RetriesCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := New(server.URL).WithRetries(10, time.Second).ListPersons(ctx, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError error
+		expectMessage string
+	}{
+		{"json string", 400, `"id in path does not match id in body"`, ErrBadRequest, "id in path does not match id in body"},
+		{"empty object", 404, `{}`, ErrNotFound, "Not Found"},
+		{"echo error", 500, `{"message":"Internal Server Error"}`, ErrServer, "Internal Server Error"},
+		{"plain text", 401, "Unauthorized\n", ErrUnauthorized, "Unauthorized"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := New(server.URL).WithRetries(0, 0).ListPersons(context.Background(), nil)
+			assert.ErrorIs(t, err, tt.expectedError)
+			var apiError *Error
+			if !errors.As(err, &apiError) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assert.Equal(t, tt.status, apiError.StatusCode)
+			assert.Equal(t, tt.expectMessage, apiError.Message)
+		})
+	}
+}
+
+func TestClient_AllPersons(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		lastId, _ := strconv.Atoi(r.URL.Query().Get("last_id"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+		var page []person.Person
+		for seq := lastId + 1; seq <= min(lastId+pageSize, 5); seq++ {
+			page = append(page, person.Person{Seq: seq, Name: fmt.Sprint("person ", seq)})
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+	c := New(server.URL)
+
+	var seqs []int
+	for p, err := range c.AllPersons(context.Background(), 2) {
+		assert.NoError(t, err)
+		seqs = append(seqs, p.Seq)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, seqs)
+	assert.Equal(t, []string{"last_id=0&page_size=2", "last_id=2&page_size=2", "last_id=4&page_size=2"}, queries)
+
+	queries = nil
+	for p := range c.AllPersons(context.Background(), 2) {
+		if p.Seq == 1 {
+			break
+		}
+	}
+	assert.Len(t, queries, 1, "no page is fetched after the loop ended")
+}
+
+func TestClient_AllPersonsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var errs []error
+	for _, err := range New(server.URL).WithRetries(0, 0).AllPersons(context.Background(), 2) {
+		errs = append(errs, err)
+	}
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrServer)
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/currency"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// ListExchangeRates returns a page of exchange rates, or all of them without pagination.
+func (c *Client) ListExchangeRates(ctx context.Context, pagination *commons.Pagination) ([]currency.ExchangeRate, error) {
+	var result []currency.ExchangeRate
+	err := c.do(ctx, call{method: http.MethodGet, path: "/exchange-rates", query: paginationQuery(pagination)}, &result)
+	return result, err
+}
+
+// AllExchangeRates iterates through the exchange rates page by page.
+func (c *Client) AllExchangeRates(ctx context.Context, pageSize int) iter.Seq2[currency.ExchangeRate, error] {
+	return pages(ctx, pageSize, func(r currency.ExchangeRate) int { return r.Seq }, c.ListExchangeRates)
+}
+
+func (c *Client) GetExchangeRate(ctx context.Context, id uuid.UUID) (currency.ExchangeRate, error) {
+	var result currency.ExchangeRate
+	err := c.do(ctx, call{method: http.MethodGet, path: pathOf("exchange-rates", id.String())}, &result)
+	return result, err
+}
+
+func (c *Client) CreateExchangeRate(ctx context.Context, request currency.CreateExchangeRateRequest) (currency.ExchangeRate, error) {
+	var result currency.ExchangeRate
+	err := c.do(ctx, call{method: http.MethodPost, path: "/exchange-rates", body: request}, &result)
+	return result, err
+}
+
+func (c *Client) UpdateExchangeRate(ctx context.Context, request currency.UpdateExchangeRateRequest) (currency.ExchangeRate, error) {
+	var result currency.ExchangeRate
+	err := c.do(ctx, call{method: http.MethodPut, path: pathOf("exchange-rates", request.Id.String()), body: request}, &result)
+	return result, err
+}
+
+func (c *Client) DeleteExchangeRate(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	var result commons.DeleteResult
+	err := c.do(ctx, call{method: http.MethodDelete, path: pathOf("exchange-rates", id.String())}, &result)
+	return result, err
+}
+
+// ConvertAmount converts an amount at the rate effective on a date, today when on is zero, into the currency to or the
+// base currency when to is empty.
+func (c *Client) ConvertAmount(ctx context.Context, amount commons.Money, to string, on commons.Date) (commons.Money, error) {
+	query := url.Values{"amount": {amount.Amount()}, "from": {amount.Currency}}
+	if to != "" {
+		query.Set("to", to)
+	}
+	if !on.IsZero() {
+		query.Set("date", on.String())
+	}
+	var result commons.Money
+	err := c.do(ctx, call{method: http.MethodGet, path: "/exchange-rates/convert", query: query}, &result)
+	return result, err
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"inventory-service-go/health"
+	"net/http"
+)
+
+// Live calls the liveness probe.
+func (c *Client) Live(ctx context.Context) (health.Report, error) {
+	var result health.Report
+	err := c.do(ctx, call{method: http.MethodGet, path: "/healthz", root: true, public: true, once: true}, &result)
+	return result, err
+}
+
+// Ready calls the readiness probe. A service that is not ready answers 503 Service Unavailable with its report, which
+// is returned rather than an error.
+func (c *Client) Ready(ctx context.Context) (health.Report, error) {
+	resp, err := c.send(ctx, call{method: http.MethodGet, path: "/readyz", root: true, public: true, once: true})
+	if err != nil {
+		return health.Report{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return health.Report{}, decodeError(resp)
+	}
+	var result health.Report
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	return result, err
+}
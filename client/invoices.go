@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/invoice"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// ListInvoices returns a page of invoices, or all of them without pagination.
+func (c *Client) ListInvoices(ctx context.Context, pagination *commons.Pagination) ([]invoice.Invoice, error) {
+	var result []invoice.Invoice
+	err := c.do(ctx, call{method: http.MethodGet, path: "/invoices", query: paginationQuery(pagination)}, &result)
+	return result, err
+}
+
+// AllInvoices iterates through the invoices page by page.
+func (c *Client) AllInvoices(ctx context.Context, pageSize int) iter.Seq2[invoice.Invoice, error] {
+	return pages(ctx, pageSize, func(i invoice.Invoice) int { return i.Seq }, c.ListInvoices)
+}
+
+// GetInvoicesForUser returns all the invoices of a user, the API does not page them.
+func (c *Client) GetInvoicesForUser(ctx context.Context, userId uuid.UUID) ([]invoice.Invoice, error) {
+	var result []invoice.Invoice
+	err := c.do(ctx, call{method: http.MethodGet, path: pathOf("invoices", "user", userId.String())}, &result)
+	return result, err
+}
+
+func (c *Client) GetInvoice(ctx context.Context, id uuid.UUID, withItems bool) (invoice.Invoice, error) {
+	var query url.Values
+	if withItems {
+		query = url.Values{"withItems": {"true"}}
+	}
+	var result invoice.Invoice
+	err := c.do(ctx, call{method: http.MethodGet, path: pathOf("invoices", id.String()), query: query}, &result)
+	return result, err
+}
+
+func (c *Client) GetInvoiceByNumber(ctx context.Context, number string) (invoice.Invoice, error) {
+	var result invoice.Invoice
+	err := c.do(ctx, call{method: http.MethodGet, path: pathOf("invoices", "by-number", number)}, &result)
+	return result, err
+}
+
+func (c *Client) CreateInvoice(ctx context.Context, request invoice.CreateInvoiceRequest) (invoice.Invoice, error) {
+	var result invoice.Invoice
+	err := c.do(ctx, call{method: http.MethodPost, path: "/invoices", body: request}, &result)
+	return result, err
+}
+
+func (c *Client) UpdateInvoice(ctx context.Context, request invoice.UpdateInvoiceRequest) (invoice.Invoice, error) {
+	var result invoice.Invoice
+	err := c.do(ctx, call{method: http.MethodPut, path: pathOf("invoices", request.Id.String()), body: request}, &result)
+	return result, err
+}
+
+func (c *Client) DeleteInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	var result commons.DeleteResult
+	err := c.do(ctx, call{method: http.MethodDelete, path: pathOf("invoices", id.String())}, &result)
+	return result, err
+}
+
+func (c *Client) AddItemsToInvoice(ctx context.Context, request invoice.ItemsToInvoiceRequest) (invoice.ItemsToInvoiceResponse, error) {
+	var result invoice.ItemsToInvoiceResponse
+	err := c.do(ctx, call{method: http.MethodPost, path: pathOf("invoices", request.InvoiceId.String(), "items"), body: request}, &result)
+	return result, err
+}
+
+func (c *Client) RemoveItemFromInvoice(ctx context.Context, invoiceId uuid.UUID, itemId uuid.UUID) (invoice.ItemsToInvoiceResponse, error) {
+	var result invoice.ItemsToInvoiceResponse
+	err := c.do(ctx, call{method: http.MethodDelete, path: pathOf("invoices", invoiceId.String(), "items", itemId.String())}, &result)
+	return result, err
+}
+
+func (c *Client) AddInvoiceDiscount(ctx context.Context, request invoice.InvoiceDiscountRequest) (invoice.Invoice, error) {
+	var result invoice.Invoice
+	err := c.do(ctx, call{method: http.MethodPost, path: pathOf("invoices", request.InvoiceId.String(), "discounts"), body: request}, &result)
+	return result, err
+}
+
+func (c *Client) ApplyPromotion(ctx context.Context, request invoice.PromotionCodeRequest) (invoice.Invoice, error) {
+	var result invoice.Invoice
+	err := c.do(ctx, call{method: http.MethodPost, path: pathOf("invoices", request.InvoiceId.String(), "promotions"), body: request}, &result)
+	return result, err
+}
+
+func (c *Client) IssueInvoice(ctx context.Context, request invoice.IssueInvoiceRequest) (invoice.Invoice, error) {
+	var result invoice.Invoice
+	err := c.do(ctx, call{method: http.MethodPost, path: pathOf("invoices", request.Id.String(), "issue"), body: request}, &result)
+	return result, err
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/item"
+	"iter"
+	"net/http"
+)
+
+// ListItems returns a page of items, or all of them without pagination.
+func (c *Client) ListItems(ctx context.Context, pagination *commons.Pagination) ([]item.Item, error) {
+	var result []item.Item
+	err := c.do(ctx, call{method: http.MethodGet, path: "/items", query: paginationQuery(pagination)}, &result)
+	return result, err
+}
+
+// AllItems iterates through the items page by page.
+func (c *Client) AllItems(ctx context.Context, pageSize int) iter.Seq2[item.Item, error] {
+	return pages(ctx, pageSize, func(i item.Item) int { return i.Seq }, c.ListItems)
+}
+
+func (c *Client) GetItem(ctx context.Context, id uuid.UUID) (item.Item, error) {
+	var result item.Item
+	err := c.do(ctx, call{method: http.MethodGet, path: pathOf("items", id.String())}, &result)
+	return result, err
+}
+
+func (c *Client) CreateItem(ctx context.Context, request item.CreateItemRequest) (item.Item, error) {
+	var result item.Item
+	err := c.do(ctx, call{method: http.MethodPost, path: "/items", body: request}, &result)
+	return result, err
+}
+
+func (c *Client) UpdateItem(ctx context.Context, request item.UpdateItemRequest) (item.Item, error) {
+	var result item.Item
+	err := c.do(ctx, call{method: http.MethodPut, path: pathOf("items", request.Id.String()), body: request}, &result)
+	return result, err
+}
+
+func (c *Client) DeleteItem(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	var result commons.DeleteResult
+	err := c.do(ctx, call{method: http.MethodDelete, path: pathOf("items", id.String())}, &result)
+	return result, err
+}
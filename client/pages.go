@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"inventory-service-go/commons"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+const defaultPageSize = 100
+
+func paginationQuery(pagination *commons.Pagination) url.Values {
+	if pagination == nil {
+		return nil
+	}
+	return url.Values{
+		"last_id":   {strconv.Itoa(pagination.LastId)},
+		"page_size": {strconv.Itoa(pagination.PageSize)},
+	}
+}
+
+// pages iterates through all the pages of a list, fetching the next page after the seq of the last element of the
+// previous one. It stops at the first error, after yielding it, or at a page that is not full.
+func pages[T any](ctx context.Context, pageSize int, seq func(T) int, list func(context.Context, *commons.Pagination) ([]T, error)) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return func(yield func(T, error) bool) {
+		pagination := &commons.Pagination{PageSize: pageSize}
+		for {
+			page, err := list(ctx, pagination)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, element := range page {
+				if !yield(element, nil) {
+					return
+				}
+			}
+			if len(page) < pageSize {
+				return
+			}
+			pagination = &commons.Pagination{LastId: seq(page[len(page)-1]), PageSize: pageSize}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/person"
+	"iter"
+	"net/http"
+)
+
+// ListPersons returns a page of persons, or all of them without pagination.
+func (c *Client) ListPersons(ctx context.Context, pagination *commons.Pagination) ([]person.Person, error) {
+	var result []person.Person
+	err := c.do(ctx, call{method: http.MethodGet, path: "/persons", query: paginationQuery(pagination)}, &result)
+	return result, err
+}
+
+// AllPersons iterates through the persons page by page.
+func (c *Client) AllPersons(ctx context.Context, pageSize int) iter.Seq2[person.Person, error] {
+	return pages(ctx, pageSize, func(p person.Person) int { return p.Seq }, c.ListPersons)
+}
+
+func (c *Client) GetPerson(ctx context.Context, id uuid.UUID) (person.Person, error) {
+	var result person.Person
+	err := c.do(ctx, call{method: http.MethodGet, path: pathOf("persons", id.String())}, &result)
+	return result, err
+}
+
+func (c *Client) CreatePerson(ctx context.Context, request person.CreatePersonRequest) (person.Person, error) {
+	var result person.Person
+	err := c.do(ctx, call{method: http.MethodPost, path: "/persons", body: request}, &result)
+	return result, err
+}
+
+func (c *Client) UpdatePerson(ctx context.Context, request person.UpdatePersonRequest) (person.Person, error) {
+	var result person.Person
+	err := c.do(ctx, call{method: http.MethodPut, path: pathOf("persons", request.Id.String()), body: request}, &result)
+	return result, err
+}
+
+func (c *Client) DeletePerson(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	var result commons.DeleteResult
+	err := c.do(ctx, call{method: http.MethodDelete, path: pathOf("persons", id.String())}, &result)
+	return result, err
+}
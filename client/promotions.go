@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/promotion"
+	"iter"
+	"net/http"
+)
+
+// ListPromotions returns a page of promotions, or all of them without pagination.
+func (c *Client) ListPromotions(ctx context.Context, pagination *commons.Pagination) ([]promotion.Promotion, error) {
+	var result []promotion.Promotion
+	err := c.do(ctx, call{method: http.MethodGet, path: "/promotions", query: paginationQuery(pagination)}, &result)
+	return result, err
+}
+
+// AllPromotions iterates through the promotions page by page.
+func (c *Client) AllPromotions(ctx context.Context, pageSize int) iter.Seq2[promotion.Promotion, error] {
+	return pages(ctx, pageSize, func(p promotion.Promotion) int { return p.Seq }, c.ListPromotions)
+}
+
+func (c *Client) GetPromotion(ctx context.Context, id uuid.UUID) (promotion.Promotion, error) {
+	var result promotion.Promotion
+	err := c.do(ctx, call{method: http.MethodGet, path: pathOf("promotions", id.String())}, &result)
+	return result, err
+}
+
+func (c *Client) CreatePromotion(ctx context.Context, request promotion.CreatePromotionRequest) (promotion.Promotion, error) {
+	var result promotion.Promotion
+	err := c.do(ctx, call{method: http.MethodPost, path: "/promotions", body: request}, &result)
+	return result, err
+}
+
+func (c *Client) UpdatePromotion(ctx context.Context, request promotion.UpdatePromotionRequest) (promotion.Promotion, error) {
+	var result promotion.Promotion
+	err := c.do(ctx, call{method: http.MethodPut, path: pathOf("promotions", request.Id.String()), body: request}, &result)
+	return result, err
+}
+
+func (c *Client) DeletePromotion(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	var result commons.DeleteResult
+	err := c.do(ctx, call{method: http.MethodDelete, path: pathOf("promotions", id.String())}, &result)
+	return result, err
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/recurring"
+	"iter"
+	"net/http"
+)
+
+// ListRecurringInvoices returns a page of recurring invoices, or all of them without pagination.
+func (c *Client) ListRecurringInvoices(ctx context.Context, pagination *commons.Pagination) ([]recurring.RecurringInvoice, error) {
+	var result []recurring.RecurringInvoice
+	err := c.do(ctx, call{method: http.MethodGet, path: "/recurring-invoices", query: paginationQuery(pagination)}, &result)
+	return result, err
+}
+
+// AllRecurringInvoices iterates through the recurring invoices page by page.
+func (c *Client) AllRecurringInvoices(ctx context.Context, pageSize int) iter.Seq2[recurring.RecurringInvoice, error] {
+	return pages(ctx, pageSize, func(r recurring.RecurringInvoice) int { return r.Seq }, c.ListRecurringInvoices)
+}
+
+func (c *Client) GetRecurringInvoice(ctx context.Context, id uuid.UUID) (recurring.RecurringInvoice, error) {
+	var result recurring.RecurringInvoice
+	err := c.do(ctx, call{method: http.MethodGet, path: pathOf("recurring-invoices", id.String())}, &result)
+	return result, err
+}
+
+func (c *Client) CreateRecurringInvoice(ctx context.Context, request recurring.CreateRecurringInvoiceRequest) (recurring.RecurringInvoice, error) {
+	var result recurring.RecurringInvoice
+	err := c.do(ctx, call{method: http.MethodPost, path: "/recurring-invoices", body: request}, &result)
+	return result, err
+}
+
+func (c *Client) UpdateRecurringInvoice(ctx context.Context, request recurring.UpdateRecurringInvoiceRequest) (recurring.RecurringInvoice, error) {
+	var result recurring.RecurringInvoice
+	err := c.do(ctx, call{method: http.MethodPut, path: pathOf("recurring-invoices", request.Id.String()), body: request}, &result)
+	return result, err
+}
+
+func (c *Client) DeleteRecurringInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	var result commons.DeleteResult
+	err := c.do(ctx, call{method: http.MethodDelete, path: pathOf("recurring-invoices", id.String())}, &result)
+	return result, err
+}
+
+// GetRecurringInvoiceRuns lists the invoices created for a recurring invoice so far.
+func (c *Client) GetRecurringInvoiceRuns(ctx context.Context, id uuid.UUID) ([]recurring.Run, error) {
+	var result []recurring.Run
+	err := c.do(ctx, call{method: http.MethodGet, path: pathOf("recurring-invoices", id.String(), "runs")}, &result)
+	return result, err
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	gocontext "context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/handlers"
+	"inventory-service-go/health"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"inventory-service-go/person"
+	"inventory-service-go/promotion"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type mocks struct {
+	persons  *person.MockPersonService
+	items    *item.MockItemService
+	invoices *invoice.MockInvoiceService
+}
+
+// newAPI serves the routes of the API on mocked services, so the client is tested against the handlers it calls.
+func newAPI(t *testing.T, checker *health.Checker) (*Client, mocks) {
+	controller := gomock.NewController(t)
+	m := mocks{
+		persons:  person.NewMockPersonService(controller),
+		items:    item.NewMockItemService(controller),
+		invoices: invoice.NewMockInvoiceService(controller),
+	}
+	appContext := context.MockApplicationContext(m.persons, m.items, m.invoices)
+	if checker != nil {
+		appContext = appContext.WithHealthChecker(checker)
+	}
+	e := echo.New()
+	apiV1 := e.Group("/api/v1")
+	handlers.PersonRoutes(apiV1, appContext)
+	handlers.ItemRoutes(apiV1, appContext)
+	handlers.InvoiceRoutes(apiV1, appContext)
+	handlers.HealthRoutes(e.Group(""), appContext)
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return New(server.URL).WithRetries(0, 0), m
+}
+
+func TestClient_Persons(t *testing.T) {
+	c, m := newAPI(t, nil)
+	ctx := gocontext.Background()
+	id := uuid.New()
+	ann := person.Person{Seq: 1, Id: id, Name: "Ann", Email: "ann@example.com"}
+
+	m.persons.EXPECT().GetAll(gomock.Any(), &commons.Pagination{LastId: 0, PageSize: 10}).Return([]person.Person{ann}, nil)
+	persons, err := c.ListPersons(ctx, &commons.Pagination{PageSize: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []person.Person{ann}, persons)
+
+	request := person.UpdatePersonRequest{Id: id, Name: "Ann", Email: "ann@example.com", LastChangedBy: "unit_test"}
+	m.persons.EXPECT().Update(gomock.Any(), request).Return(&ann, nil)
+	updated, err := c.UpdatePerson(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, ann, updated)
+
+	m.persons.EXPECT().DeleteByUuid(gomock.Any(), id).Return(nil, sql.ErrNoRows)
+	_, err = c.DeletePerson(ctx, id)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestClient_Items(t *testing.T) {
+	c, m := newAPI(t, nil)
+	ctx := gocontext.Background()
+	request := item.CreateItemRequest{Name: "widget", UnitPrice: commons.NewMoney(1250, "EUR"), CreatedBy: "unit_test"}
+	created := item.Item{Seq: 1, Id: uuid.New(), Name: "widget", UnitPrice: commons.NewMoney(1250, "EUR")}
+
+	m.items.EXPECT().CreateItem(gomock.Any(), request).Return(&created, nil)
+	result, err := c.CreateItem(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, created.UnitPrice, result.UnitPrice)
+
+	m.items.EXPECT().GetItem(gomock.Any(), created.Id).Return(&created, nil)
+	result, err = c.GetItem(ctx, created.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, created.Id, result.Id)
+}
+
+func TestClient_Invoices(t *testing.T) {
+	c, m := newAPI(t, nil)
+	ctx := gocontext.Background()
+	id, itemId := uuid.New(), uuid.New()
+	found := invoice.Invoice{Seq: 1, Id: id, Total: commons.NewMoney(2500, "EUR"), Items: []item.Item{{Id: itemId, Name: "widget"}}}
+
+	m.invoices.EXPECT().GetInvoice(gomock.Any(), id, true).Return(found, nil)
+	result, err := c.GetInvoice(ctx, id, true)
+	assert.NoError(t, err)
+	assert.Equal(t, found.Total, result.Total)
+	assert.Equal(t, itemId, result.Items[0].Id)
+
+	m.invoices.EXPECT().GetInvoiceByNumber(gomock.Any(), "INV-2026-000123").Return(found, nil)
+	_, err = c.GetInvoiceByNumber(ctx, "INV-2026-000123")
+	assert.NoError(t, err)
+
+	request := invoice.ItemsToInvoiceRequest{
+		InvoiceId:  id,
+		Items:      []uuid.UUID{itemId},
+		Quantities: map[uuid.UUID]int64{itemId: 2},
+		Discounts:  map[uuid.UUID]promotion.Discount{itemId: {Type: promotion.Percentage, Value: "10"}},
+	}
+	m.invoices.EXPECT().AddItemsToInvoice(gomock.Any(), request).Return(invoice.ItemsToInvoiceResponse{InvoiceId: id, Items: request.Items, Success: true}, nil)
+	added, err := c.AddItemsToInvoice(ctx, request)
+	assert.NoError(t, err)
+	assert.True(t, added.Success)
+
+	m.invoices.EXPECT().RemoveItemFromInvoice(gomock.Any(), invoice.SimpleInvoiceItem{InvoiceId: id, ItemId: itemId}).Return(invoice.ItemsToInvoiceResponse{InvoiceId: id, Success: true}, nil)
+	removed, err := c.RemoveItemFromInvoice(ctx, id, itemId)
+	assert.NoError(t, err)
+	assert.True(t, removed.Success)
+
+	m.invoices.EXPECT().IssueInvoice(gomock.Any(), invoice.IssueInvoiceRequest{Id: id, IssuedBy: "unit_test"}).Return(invoice.Invoice{}, commons.NewValidationError("invoice has no items"))
+	_, err = c.IssueInvoice(ctx, invoice.IssueInvoiceRequest{Id: id, IssuedBy: "unit_test"})
+	assert.ErrorIs(t, err, ErrBadRequest)
+	assert.Equal(t, "invoice has no items", err.(*Error).Message)
+}
+
+func TestClient_Health(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Shutdown()
+	c, _ := newAPI(t, checker)
+	ctx := gocontext.Background()
+
+	live, err := c.Live(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, health.StatusUp, live.Status)
+
+	ready, err := c.Ready(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, health.StatusDown, ready.Status)
+}
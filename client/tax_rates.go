@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/tax"
+	"iter"
+	"net/http"
+)
+
+// ListTaxRates returns a page of tax rates, or all of them without pagination.
+func (c *Client) ListTaxRates(ctx context.Context, pagination *commons.Pagination) ([]tax.TaxRate, error) {
+	var result []tax.TaxRate
+	err := c.do(ctx, call{method: http.MethodGet, path: "/tax-rates", query: paginationQuery(pagination)}, &result)
+	return result, err
+}
+
+// AllTaxRates iterates through the tax rates page by page.
+func (c *Client) AllTaxRates(ctx context.Context, pageSize int) iter.Seq2[tax.TaxRate, error] {
+	return pages(ctx, pageSize, func(r tax.TaxRate) int { return r.Seq }, c.ListTaxRates)
+}
+
+func (c *Client) GetTaxRate(ctx context.Context, id uuid.UUID) (tax.TaxRate, error) {
+	var result tax.TaxRate
+	err := c.do(ctx, call{method: http.MethodGet, path: pathOf("tax-rates", id.String())}, &result)
+	return result, err
+}
+
+func (c *Client) CreateTaxRate(ctx context.Context, request tax.CreateTaxRateRequest) (tax.TaxRate, error) {
+	var result tax.TaxRate
+	err := c.do(ctx, call{method: http.MethodPost, path: "/tax-rates", body: request}, &result)
+	return result, err
+}
+
+func (c *Client) UpdateTaxRate(ctx context.Context, request tax.UpdateTaxRateRequest) (tax.TaxRate, error) {
+	var result tax.TaxRate
+	err := c.do(ctx, call{method: http.MethodPut, path: pathOf("tax-rates", request.Id.String()), body: request}, &result)
+	return result, err
+}
+
+func (c *Client) DeleteTaxRate(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	var result commons.DeleteResult
+	err := c.do(ctx, call{method: http.MethodDelete, path: pathOf("tax-rates", id.String())}, &result)
+	return result, err
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/webhook"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// ListWebhooks returns a page of webhook subscriptions, or all of them without pagination.
+func (c *Client) ListWebhooks(ctx context.Context, pagination *commons.Pagination) ([]webhook.Subscription, error) {
+	var result []webhook.Subscription
+	err := c.do(ctx, call{method: http.MethodGet, path: "/webhooks", query: paginationQuery(pagination)}, &result)
+	return result, err
+}
+
+// AllWebhooks iterates through the webhook subscriptions page by page.
+func (c *Client) AllWebhooks(ctx context.Context, pageSize int) iter.Seq2[webhook.Subscription, error] {
+	return pages(ctx, pageSize, func(s webhook.Subscription) int { return s.Seq }, c.ListWebhooks)
+}
+
+func (c *Client) GetWebhook(ctx context.Context, id uuid.UUID) (webhook.Subscription, error) {
+	var result webhook.Subscription
+	err := c.do(ctx, call{method: http.MethodGet, path: pathOf("webhooks", id.String())}, &result)
+	return result, err
+}
+
+func (c *Client) CreateWebhook(ctx context.Context, request webhook.CreateSubscriptionRequest) (webhook.Subscription, error) {
+	var result webhook.Subscription
+	err := c.do(ctx, call{method: http.MethodPost, path: "/webhooks", body: request}, &result)
+	return result, err
+}
+
+func (c *Client) UpdateWebhook(ctx context.Context, request webhook.UpdateSubscriptionRequest) (webhook.Subscription, error) {
+	var result webhook.Subscription
+	err := c.do(ctx, call{method: http.MethodPut, path: pathOf("webhooks", request.Id.String()), body: request}, &result)
+	return result, err
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	var result commons.DeleteResult
+	err := c.do(ctx, call{method: http.MethodDelete, path: pathOf("webhooks", id.String())}, &result)
+	return result, err
+}
+
+// GetWebhookDeliveries is the delivery log of a subscription, only the deliveries with the given status unless it is
+// empty.
+func (c *Client) GetWebhookDeliveries(ctx context.Context, id uuid.UUID, status string) ([]webhook.Delivery, error) {
+	var query url.Values
+	if status != "" {
+		query = url.Values{"status": {status}}
+	}
+	var result []webhook.Delivery
+	err := c.do(ctx, call{method: http.MethodGet, path: pathOf("webhooks", id.String(), "deliveries"), query: query}, &result)
+	return result, err
+}
+
+// GetWebhookDeadLetters lists the deliveries of all subscriptions that were given up on.
+func (c *Client) GetWebhookDeadLetters(ctx context.Context) ([]webhook.Delivery, error) {
+	var result []webhook.Delivery
+	err := c.do(ctx, call{method: http.MethodGet, path: "/webhooks/dead-letters"}, &result)
+	return result, err
+}
+
+func (c *Client) RedeliverWebhook(ctx context.Context, id uuid.UUID, deliveryId uuid.UUID) (webhook.Delivery, error) {
+	var result webhook.Delivery
+	err := c.do(ctx, call{method: http.MethodPost, path: pathOf("webhooks", id.String(), "deliveries", deliveryId.String(), "redeliver")}, &result)
+	return result, err
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/invoice"
+)
+
+func recalculateInvoices(ctx context.Context, c *ctl, args []string) error {
+	var dryRun bool
+	flags, err := parseFlags(c, "invoices recalculate", args, func(flags *flag.FlagSet) {
+		flags.BoolVar(&dryRun, "dry-run", false, "only print the totals that would change")
+	})
+	if err != nil {
+		return err
+	}
+	var ids []uuid.UUID
+	for _, arg := range flags.Args() {
+		id, err := uuid.Parse(arg)
+		if err != nil {
+			return fmt.Errorf("invoice id %q: %w", arg, err)
+		}
+		ids = append(ids, id)
+	}
+	invoices, err := c.invoiceService()
+	if err != nil {
+		return err
+	}
+	// the totals of issued invoices are only reported, the invoices were sent with them
+	checked, changed, issued := 0, 0, 0
+	recalculate := func(id uuid.UUID) error {
+		checked++
+		var before, after commons.Money
+		var wasIssued bool
+		if dryRun {
+			inv, err := invoices.GetInvoice(ctx, id, true)
+			if err != nil {
+				return fmt.Errorf("invoice %s: %w", id, err)
+			}
+			before, after, wasIssued = inv.Total, inv.Tax.Total, inv.Number != ""
+		} else {
+			stored, err := invoices.GetInvoice(ctx, id, false)
+			if err != nil {
+				return fmt.Errorf("invoice %s: %w", id, err)
+			}
+			inv, _, err := invoices.RecalculateTotal(ctx, id)
+			if errors.Is(err, invoice.ErrInvoiceIssued) {
+				before, after, wasIssued = stored.Total, inv.Tax.Total, true
+			} else if err != nil {
+				return fmt.Errorf("invoice %s: %w", id, err)
+			} else {
+				before, after = stored.Total, inv.Total
+			}
+		}
+		if before == after {
+			return nil
+		}
+		if wasIssued {
+			issued++
+			fmt.Fprintf(c.out, "%s %s -> %s, issued, left as it is\n", id, before, after)
+		} else {
+			changed++
+			fmt.Fprintf(c.out, "%s %s -> %s\n", id, before, after)
+		}
+		return nil
+	}
+
+	if len(ids) > 0 {
+		for _, id := range ids {
+			if err = recalculate(id); err != nil {
+				return err
+			}
+		}
+	} else {
+		err = eachPage(func(pagination *commons.Pagination) ([]invoice.Invoice, error) {
+			return invoices.GetAllInvoices(ctx, pagination)
+		}, func(inv invoice.Invoice) int { return inv.Seq }, func(inv invoice.Invoice) error {
+			return recalculate(inv.Id)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	verb := "changed"
+	if dryRun {
+		verb = "would change"
+	}
+	fmt.Fprintf(c.out, "checked %d invoices, %s %d", checked, verb, changed)
+	if issued > 0 {
+		fmt.Fprintf(c.out, ", %d issued ones drifted", issued)
+	}
+	fmt.Fprintln(c.out)
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/invoice"
+	"inventory-service-go/tax"
+	"testing"
+)
+
+func TestRecalculateInvoices(t *testing.T) {
+	drifted, correct := uuid.New(), uuid.New()
+	usd := func(minorUnits int64) commons.Money { return commons.NewMoney(minorUnits, "USD") }
+
+	t.Run("All Invoices", func(t *testing.T) {
+		c, out := newCtl(t, "")
+		mockInvoices := invoice.NewMockInvoiceService(gomock.NewController(t))
+		mockInvoices.EXPECT().GetAllInvoices(gomock.Any(), gomock.Any()).Return([]invoice.Invoice{{Seq: 1, Id: drifted}, {Seq: 2, Id: correct}}, nil)
+		mockInvoices.EXPECT().GetInvoice(gomock.Any(), drifted, false).Return(invoice.Invoice{Id: drifted, Total: usd(1500)}, nil)
+		mockInvoices.EXPECT().RecalculateTotal(gomock.Any(), drifted).Return(invoice.Invoice{Id: drifted, Total: usd(2000)}, true, nil)
+		mockInvoices.EXPECT().GetInvoice(gomock.Any(), correct, false).Return(invoice.Invoice{Id: correct, Total: usd(2000)}, nil)
+		mockInvoices.EXPECT().RecalculateTotal(gomock.Any(), correct).Return(invoice.Invoice{Id: correct, Total: usd(2000)}, false, nil)
+		c.invoices = mockInvoices
+
+		assert.NoError(t, run(context.Background(), c, []string{"invoices", "recalculate"}))
+		assert.Equal(t, drifted.String()+" 15.00 USD -> 20.00 USD\nchecked 2 invoices, changed 1\n", out.String())
+	})
+
+	t.Run("Dry Run", func(t *testing.T) {
+		c, out := newCtl(t, "")
+		mockInvoices := invoice.NewMockInvoiceService(gomock.NewController(t))
+		mockInvoices.EXPECT().GetInvoice(gomock.Any(), drifted, true).
+			Return(invoice.Invoice{Id: drifted, Total: usd(1500), Tax: &tax.Breakdown{Total: usd(2000)}}, nil)
+		c.invoices = mockInvoices
+
+		assert.NoError(t, run(context.Background(), c, []string{"invoices", "recalculate", "-dry-run", drifted.String()}))
+		assert.Equal(t, drifted.String()+" 15.00 USD -> 20.00 USD\nchecked 1 invoices, would change 1\n", out.String())
+	})
+
+	t.Run("Issued Invoice", func(t *testing.T) {
+		c, out := newCtl(t, "")
+		mockInvoices := invoice.NewMockInvoiceService(gomock.NewController(t))
+		mockInvoices.EXPECT().GetInvoice(gomock.Any(), drifted, false).Return(invoice.Invoice{Id: drifted, Total: usd(1500)}, nil)
+		mockInvoices.EXPECT().RecalculateTotal(gomock.Any(), drifted).
+			Return(invoice.Invoice{Id: drifted, Number: "INV-2026-000001", Total: usd(1500), Tax: &tax.Breakdown{Total: usd(2000)}}, false, invoice.ErrInvoiceIssued)
+		c.invoices = mockInvoices
+
+		assert.NoError(t, run(context.Background(), c, []string{"invoices", "recalculate", drifted.String()}))
+		assert.Equal(t, drifted.String()+" 15.00 USD -> 20.00 USD, issued, left as it is\nchecked 1 invoices, changed 0, 1 issued ones drifted\n", out.String())
+	})
+
+	t.Run("Invalid Id", func(t *testing.T) {
+		c, _ := newCtl(t, "")
+		c.invoices = invoice.NewMockInvoiceService(gomock.NewController(t))
+		assert.ErrorContains(t, run(context.Background(), c, []string{"invoices", "recalculate", "INV-1"}), `invoice id "INV-1"`)
+	})
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"inventory-service-go/commons"
+	"inventory-service-go/item"
+)
+
+func exportItems(ctx context.Context, c *ctl, _ []string) error {
+	items, err := c.itemService()
+	if err != nil {
+		return err
+	}
+	// written as it is read, as a JSON array with one item per line
+	first := true
+	fmt.Fprint(c.out, "[")
+	err = eachPage(func(pagination *commons.Pagination) ([]item.Item, error) {
+		return items.GetItems(ctx, pagination)
+	}, func(i item.Item) int { return i.Seq }, func(i item.Item) error {
+		data, err := json.Marshal(i)
+		if err != nil {
+			return err
+		}
+		if !first {
+			fmt.Fprint(c.out, ",")
+		}
+		first = false
+		_, err = fmt.Fprintf(c.out, "\n%s", data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(c.out, "\n]")
+	return err
+}
+
+// importItems creates new items, the ids and audit info of an export are not kept. It goes on past the items that
+// cannot be created and fails in the end when there were any.
+func importItems(ctx context.Context, c *ctl, _ []string) error {
+	var requests []item.CreateItemRequest
+	if err := json.NewDecoder(c.in).Decode(&requests); err != nil {
+		return fmt.Errorf("reading the items: %w", err)
+	}
+	items, err := c.itemService()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	created := 0
+	for i, request := range requests {
+		request.CreatedBy = c.actor
+		if _, err := items.CreateItem(ctx, request); err != nil {
+			errs = append(errs, fmt.Errorf("item %d (%s): %w", i+1, request.Name, err))
+			continue
+		}
+		created++
+	}
+	fmt.Fprintf(c.out, "created %d of %d items\n", created, len(requests))
+	return errors.Join(errs...)
+}
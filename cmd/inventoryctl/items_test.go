@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/item"
+	"testing"
+)
+
+func TestExportItems(t *testing.T) {
+	c, out := newCtl(t, "")
+	mockItems := item.NewMockItemService(gomock.NewController(t))
+	exported := []item.Item{
+		{Seq: 1, Id: uuid.New(), Name: "Widget", UnitPrice: commons.NewMoney(1250, "USD")},
+		{Seq: 2, Id: uuid.New(), Name: "Gadget", UnitPrice: commons.NewMoney(99, "EUR")},
+	}
+	mockItems.EXPECT().GetItems(gomock.Any(), &commons.Pagination{PageSize: pageSize}).Return(exported, nil)
+	c.items = mockItems
+
+	assert.NoError(t, run(context.Background(), c, []string{"items", "export"}))
+	var got []item.Item
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, exported, got)
+}
+
+func TestImportItems(t *testing.T) {
+	c, out := newCtl(t, `[
+{"seq": 1, "name": "Widget", "unit_price": {"amount": "12.50", "currency": "USD"}, "audit_info": {"created_by": "someone"}},
+{"name": "Broken", "unit_price": {"amount": "1.00", "currency": "XXX"}}
+]`)
+	mockItems := item.NewMockItemService(gomock.NewController(t))
+	mockItems.EXPECT().CreateItem(gomock.Any(), item.CreateItemRequest{Name: "Widget", UnitPrice: commons.NewMoney(1250, "USD"), CreatedBy: "unit_test"}).
+		Return(&item.Item{}, nil)
+	mockItems.EXPECT().CreateItem(gomock.Any(), gomock.Any()).Return(nil, commons.ErrUnsupportedCurrency)
+	c.items = mockItems
+
+	err := run(context.Background(), c, []string{"items", "import"})
+	assert.ErrorIs(t, err, commons.ErrUnsupportedCurrency)
+	assert.ErrorContains(t, err, "item 2 (Broken)")
+	assert.Equal(t, "created 1 of 2 items\n", out.String())
+}
+
+func TestImportItems_InvalidJson(t *testing.T) {
+	c, _ := newCtl(t, "not json")
+	c.items = item.NewMockItemService(gomock.NewController(t))
+	var syntaxError *json.SyntaxError
+	assert.True(t, errors.As(run(context.Background(), c, []string{"items", "import"}), &syntaxError))
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/google/uuid"
+	"inventory-service-go/apikey"
+	"inventory-service-go/commons"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+func createKey(ctx context.Context, c *ctl, args []string) error {
+	var scopes string
+	var ttl time.Duration
+	flags, err := parseFlags(c, "keys create", args, func(flags *flag.FlagSet) {
+		flags.StringVar(&scopes, "scopes", "read", "comma separated scopes: read, write, <resource>:read or <resource>:write")
+		flags.DurationVar(&ttl, "ttl", 0, "how long the key is valid, it does not expire when not given")
+	})
+	if err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("expected exactly one key name")
+	}
+	if ttl < 0 {
+		return errors.New("the ttl must not be negative")
+	}
+	request := apikey.CreateAPIKeyRequest{Name: flags.Arg(0), CreatedBy: c.actor}
+	for _, scope := range strings.Split(scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			request.Scopes = append(request.Scopes, scope)
+		}
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		request.ExpiresAt = &expiresAt
+	}
+	keys, err := c.apiKeyService()
+	if err != nil {
+		return err
+	}
+	created, err := keys.CreateAPIKey(ctx, request)
+	if err != nil {
+		return err
+	}
+	// only the key, so it can be piped into a secret store, it cannot be shown again
+	fmt.Fprintln(c.out, created.Key)
+	return nil
+}
+
+func listKeys(ctx context.Context, c *ctl, _ []string) error {
+	keys, err := c.apiKeyService()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tTENANT\tPREFIX\tSCOPES\tEXPIRES AT\tLAST USED AT\tREVOKED AT")
+	err = eachPage(func(pagination *commons.Pagination) ([]apikey.APIKey, error) {
+		return keys.GetAPIKeys(ctx, pagination)
+	}, func(k apikey.APIKey) int { return k.Seq }, func(k apikey.APIKey) error {
+		_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", k.Id, k.Name, k.Tenant, k.Prefix, strings.Join(k.Scopes, ","), k.ExpiresAt, k.LastUsedAt, k.RevokedAt)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func revokeKey(ctx context.Context, c *ctl, args []string) error {
+	if len(args) != 1 {
+		return errors.New("expected exactly one key id")
+	}
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		return err
+	}
+	keys, err := c.apiKeyService()
+	if err != nil {
+		return err
+	}
+	revoked, err := keys.RevokeAPIKey(ctx, id, c.actor)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "revoked key %s %s\n", revoked.Name, revoked.Prefix)
+	return nil
+}
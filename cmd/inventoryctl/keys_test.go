@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/apikey"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateKey(t *testing.T) {
+	c, out := newCtl(t, "")
+	mockKeys := apikey.NewMockAPIKeyService(gomock.NewController(t))
+	mockKeys.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request apikey.CreateAPIKeyRequest) (apikey.CreatedAPIKey, error) {
+			assert.Equal(t, "nightly", request.Name)
+			assert.Equal(t, []string{"items:read", "invoices:write"}, request.Scopes)
+			assert.Equal(t, "unit_test", request.CreatedBy)
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), *request.ExpiresAt, time.Minute)
+			return apikey.CreatedAPIKey{Key: "inv_secret"}, nil
+		})
+	c.apiKeys = mockKeys
+
+	assert.NoError(t, run(context.Background(), c, []string{"keys", "create", "-scopes", "items:read, invoices:write", "-ttl", "24h", "nightly"}))
+	assert.Equal(t, "inv_secret\n", out.String())
+
+	assert.Error(t, run(context.Background(), c, []string{"keys", "create"}))
+	assert.Error(t, run(context.Background(), c, []string{"keys", "create", "-ttl", "-1h", "nightly"}))
+}
+
+func TestListAndRevokeKeys(t *testing.T) {
+	c, out := newCtl(t, "")
+	mockKeys := apikey.NewMockAPIKeyService(gomock.NewController(t))
+	id := uuid.New()
+	mockKeys.EXPECT().GetAPIKeys(gomock.Any(), gomock.Any()).Return([]apikey.APIKey{
+		{Seq: 1, Id: id, Name: "nightly", Prefix: "inv_abcdefgh", Scopes: []string{"read", "items:write"}, LastUsedAt: "2026-10-18T02:00:00Z"},
+	}, nil)
+	mockKeys.EXPECT().RevokeAPIKey(gomock.Any(), id, "unit_test").Return(apikey.APIKey{Name: "nightly", Prefix: "inv_abcdefgh", Revoked: true}, nil)
+	c.apiKeys = mockKeys
+
+	assert.NoError(t, run(context.Background(), c, []string{"keys", "list"}))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[1], "read,items:write")
+	assert.Contains(t, lines[1], "2026-10-18T02:00:00Z")
+
+	out.Reset()
+	assert.NoError(t, run(context.Background(), c, []string{"keys", "revoke", id.String()}))
+	assert.Equal(t, "revoked key nightly inv_abcdefgh\n", out.String())
+	assert.Error(t, run(context.Background(), c, []string{"keys", "revoke", "nightly"}))
+}
@@ -0,0 +1,268 @@
+// Command inventoryctl operates the inventory service from the command line. It goes through the same services as
+// the HTTP API, so their validation, events and cache invalidation apply to its changes as well. It reads the same
+// settings as the server, see the config package.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/joho/godotenv"
+	"inventory-service-go/apikey"
+	"inventory-service-go/auth"
+	"inventory-service-go/cache"
+	"inventory-service-go/commons"
+	"inventory-service-go/config"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"inventory-service-go/person"
+	"inventory-service-go/tenant"
+	"inventory-service-go/user"
+	"inventory-service-go/webhook"
+	"io"
+	"io/fs"
+	"os"
+	osuser "os/user"
+	"sort"
+	"strings"
+)
+
+// pageSize is how many rows are read at a time when a command goes through a whole table.
+const pageSize = 100
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, c *ctl, args []string) error
+}
+
+var commands = map[string]command{
+	"migrate up":           {"apply the pending schema migrations", migrateUp},
+	"migrate status":       {"print the schema version of the database", migrateStatus},
+	"users create":         {"create a user, [-service-account] [-password-stdin] <username>", createUser},
+	"users disable":        {"keep a user from signing in, <username>", disableUser},
+	"users list":           {"list the users", listUsers},
+	"users secret":         {"give a service account a new OAuth client secret and print it, <username>", rotateClientSecret},
+	"tokens mint":          {"issue a token for a service account, [-ttl 720h] <username>, at most 90 days", mintToken},
+	"tenants create":       {"create a tenant, <name>", createTenant},
+	"tenants list":         {"list the tenants", listTenants},
+	"keys create":          {"create an API key and print it, [-scopes items:read,invoices:write] [-ttl 2160h] <name>", createKey},
+	"keys list":            {"list the API keys", listKeys},
+	"keys revoke":          {"revoke an API key, <id>", revokeKey},
+	"items export":         {"write all items to stdout as JSON", exportItems},
+	"items import":         {"create the items of a JSON array read from stdin, as written by items export", importItems},
+	"invoices recalculate": {"work the invoice totals out again and store the ones that drifted, [-dry-run] [invoice id...]", recalculateInvoices},
+	"report":               {"print the consistency report, fails when it finds problems", report},
+}
+
+func main() {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "inventoryctl: loading .env: %v\n", err)
+		os.Exit(1)
+	}
+	c := &ctl{in: os.Stdin, out: os.Stdout, actor: actor()}
+	if err := run(context.Background(), c, os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "inventoryctl: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, c *ctl, args []string) error {
+	flags := flag.NewFlagSet("inventoryctl", flag.ContinueOnError)
+	flags.SetOutput(c.out)
+	path := flags.String("config", "", "YAML file to read the settings from, CONFIG_FILE when not given")
+	scope := flags.String("tenant", "", "name or id of the tenant to run the command as, as the system when not given, which sees every tenant and creates in the default one")
+	flags.Usage = func() { usage(c.out, flags) }
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	name, cmd, rest, ok := lookup(flags.Args())
+	if !ok {
+		flags.Usage()
+		return fmt.Errorf("unknown command %q", strings.Join(flags.Args(), " "))
+	}
+	if c.config == nil {
+		var configArgs []string
+		if *path != "" {
+			configArgs = []string{"-config", *path}
+		}
+		cfg, err := config.Load(configArgs, os.LookupEnv)
+		if err != nil {
+			return err
+		}
+		commons.ConfigureDB(commons.DBConfig(cfg.Database))
+		c.config = &cfg
+	}
+	defer commons.CloseDB()
+	if *scope != "" {
+		t, err := findTenant(ctx, c, *scope)
+		if err != nil {
+			return err
+		}
+		ctx = commons.WithTenant(ctx, t.Id)
+	} else {
+		ctx = commons.AsSystem(ctx)
+	}
+	if err := cmd.run(ctx, c, rest); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// lookup finds the command named by the first one or two arguments.
+func lookup(args []string) (string, command, []string, bool) {
+	for n := min(2, len(args)); n > 0; n-- {
+		name := strings.Join(args[:n], " ")
+		if cmd, ok := commands[name]; ok {
+			return name, cmd, args[n:], true
+		}
+	}
+	return "", command{}, nil, false
+}
+
+func usage(w io.Writer, flags *flag.FlagSet) {
+	fmt.Fprintln(w, "Usage: inventoryctl [-config file] [-tenant name] <command> [arguments]")
+	fmt.Fprintln(w, "\nCommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-22s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintln(w, "\nFlags:")
+	flags.PrintDefaults()
+}
+
+// actor is recorded as the creator of what the commands create, and as the last to change what they change.
+func actor() string {
+	if u, err := osuser.Current(); err == nil && u.Username != "" {
+		return "inventoryctl/" + u.Username
+	}
+	return "inventoryctl"
+}
+
+// ctl is what the commands run against. The services are wired by their initializers the first time a command asks
+// for them, tests set them beforehand.
+type ctl struct {
+	in     io.Reader
+	out    io.Writer
+	actor  string
+	config *config.Config
+
+	db       *sqlx.DB
+	auth     auth.AuthProvider
+	users    user.UserService
+	items    item.ItemService
+	persons  person.PersonService
+	invoices invoice.InvoiceService
+	webhooks webhook.WebhookService
+	apiKeys  apikey.APIKeyService
+	tenants  tenant.TenantService
+}
+
+func (c *ctl) database() *sqlx.DB {
+	if c.db == nil {
+		c.db = commons.GetDB()
+	}
+	return c.db
+}
+
+func (c *ctl) authProvider() (auth.AuthProvider, error) {
+	if c.auth == nil {
+		provider, err := auth.NewFromConfig(c.config.JWT, c.config.OAuth, nil)
+		if err != nil {
+			return nil, err
+		}
+		c.auth = provider
+	}
+	return c.auth, nil
+}
+
+func (c *ctl) userService() (user.UserService, error) {
+	var err error
+	if c.users == nil {
+		c.users, err = user.InitializeUserService(cache.Config(c.config.Caches.Users))
+	}
+	return c.users, err
+}
+
+func (c *ctl) itemService() (item.ItemService, error) {
+	var err error
+	if c.items == nil {
+		c.items, err = item.InitializeItemService(cache.Config(c.config.Caches.Items))
+	}
+	return c.items, err
+}
+
+func (c *ctl) personService() (person.PersonService, error) {
+	var err error
+	if c.persons == nil {
+		c.persons, err = person.InitializePersonService(cache.Config(c.config.Caches.Persons))
+	}
+	return c.persons, err
+}
+
+func (c *ctl) invoiceService() (invoice.InvoiceService, error) {
+	var err error
+	if c.invoices == nil {
+		c.invoices, err = invoice.InitializeInvoiceService(cache.Config(c.config.Caches.Items), c.config.InvoiceNumbers)
+	}
+	return c.invoices, err
+}
+
+func (c *ctl) webhookService() (webhook.WebhookService, error) {
+	var err error
+	if c.webhooks == nil {
+		c.webhooks, err = webhook.InitializeWebhookService(c.config.Webhooks)
+	}
+	return c.webhooks, err
+}
+
+func (c *ctl) apiKeyService() (apikey.APIKeyService, error) {
+	var err error
+	if c.apiKeys == nil {
+		c.apiKeys, err = apikey.InitializeAPIKeyService()
+	}
+	return c.apiKeys, err
+}
+
+func (c *ctl) tenantService() (tenant.TenantService, error) {
+	var err error
+	if c.tenants == nil {
+		c.tenants, err = tenant.InitializeTenantService()
+	}
+	return c.tenants, err
+}
+
+// eachPage goes through all rows fetch returns, a page at a time, seq being the id the next page starts after.
+func eachPage[T any](fetch func(pagination *commons.Pagination) ([]T, error), seq func(T) int, fn func(T) error) error {
+	pagination := &commons.Pagination{PageSize: pageSize}
+	for {
+		page, err := fetch(pagination)
+		if err != nil {
+			return err
+		}
+		for _, row := range page {
+			if err = fn(row); err != nil {
+				return err
+			}
+		}
+		if len(page) < pagination.PageSize {
+			return nil
+		}
+		pagination.LastId = seq(page[len(page)-1])
+	}
+}
+
+// parseFlags parses the flags of a command, which need to come before its arguments.
+func parseFlags(c *ctl, name string, args []string, define func(flags *flag.FlagSet)) (*flag.FlagSet, error) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(c.out)
+	define(flags)
+	return flags, flags.Parse(args)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"inventory-service-go/config"
+	"strings"
+	"testing"
+)
+
+func newCtl(t *testing.T, in string) (*ctl, *bytes.Buffer) {
+	cfg := config.Default()
+	cfg.JWT.Secret = "dummy_secret"
+	out := &bytes.Buffer{}
+	return &ctl{in: strings.NewReader(in), out: out, actor: "unit_test", config: &cfg}, out
+}
+
+func TestRun_Usage(t *testing.T) {
+	c, out := newCtl(t, "")
+	err := run(context.Background(), c, []string{"users", "rename", "ann"})
+	assert.ErrorContains(t, err, `unknown command "users rename ann"`)
+	assert.Contains(t, out.String(), "invoices recalculate")
+	assert.Contains(t, out.String(), "-config")
+}
+
+func TestLookup(t *testing.T) {
+	name, _, rest, ok := lookup([]string{"users", "disable", "ann"})
+	assert.True(t, ok)
+	assert.Equal(t, "users disable", name)
+	assert.Equal(t, []string{"ann"}, rest)
+
+	name, _, rest, ok = lookup([]string{"report"})
+	assert.True(t, ok)
+	assert.Equal(t, "report", name)
+	assert.Empty(t, rest)
+
+	_, _, _, ok = lookup([]string{"users"})
+	assert.False(t, ok)
+}
+
+func TestEachPage(t *testing.T) {
+	var pages []commons.Pagination
+	var seen []int
+	err := eachPage(func(pagination *commons.Pagination) ([]int, error) {
+		pages = append(pages, *pagination)
+		if pagination.LastId >= 2*pageSize {
+			return []int{2*pageSize + 1}, nil
+		}
+		page := make([]int, pageSize)
+		for i := range page {
+			page[i] = pagination.LastId + i + 1
+		}
+		return page, nil
+	}, func(i int) int { return i }, func(i int) error {
+		seen = append(seen, i)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, seen, 2*pageSize+1)
+	assert.Equal(t, []commons.Pagination{{PageSize: pageSize}, {LastId: pageSize, PageSize: pageSize}, {LastId: 2 * pageSize, PageSize: pageSize}}, pages)
+}
+
+func TestMigrateStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(1, false))
+	c, out := newCtl(t, "")
+	c.db = sqlx.NewDb(db, "mockDb")
+
+	assert.NoError(t, run(context.Background(), c, []string{"migrate", "status"}))
+	assert.Regexp(t, `^version 1 of \d+, \d+ pending\n$`, out.String())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
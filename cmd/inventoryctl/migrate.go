@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"inventory-service-go/migrations"
+)
+
+func migrateUp(ctx context.Context, c *ctl, _ []string) error {
+	applied, err := migrations.Up(ctx, c.database())
+	for _, version := range applied {
+		fmt.Fprintf(c.out, "applied %d\n", version)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Fprintln(c.out, "no pending migrations")
+	}
+	return nil
+}
+
+func migrateStatus(ctx context.Context, c *ctl, _ []string) error {
+	latest, err := migrations.Latest()
+	if err != nil {
+		return err
+	}
+	status, err := migrations.CurrentStatus(ctx, c.database())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "version %d of %d", status.Version, latest)
+	switch {
+	case status.Dirty:
+		fmt.Fprint(c.out, ", dirty")
+	case status.Version < latest:
+		fmt.Fprintf(c.out, ", %d pending", latest-status.Version)
+	}
+	fmt.Fprintln(c.out)
+	return nil
+}
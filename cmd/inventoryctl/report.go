@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/invoice"
+	"inventory-service-go/migrations"
+)
+
+// report checks what the database constraints do not: that the schema is up to date, that the stored invoice totals
+// match their lines, that every invoice belongs to a known person and that no webhook deliveries were given up on.
+func report(ctx context.Context, c *ctl, _ []string) error {
+	problems := 0
+	problem := func(format string, args ...any) {
+		problems++
+		fmt.Fprintf(c.out, "  "+format+"\n", args...)
+	}
+
+	fmt.Fprintln(c.out, "schema")
+	latest, err := migrations.Latest()
+	if err != nil {
+		return err
+	}
+	status, err := migrations.CurrentStatus(ctx, c.database())
+	if err != nil {
+		return err
+	}
+	if status.Dirty {
+		problem("version %d is dirty", status.Version)
+	} else if status.Version != latest {
+		problem("at version %d, expected %d", status.Version, latest)
+	}
+
+	invoices, err := c.invoiceService()
+	if err != nil {
+		return err
+	}
+	persons, err := c.personService()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.out, "invoices")
+	known := map[uuid.UUID]bool{}
+	err = eachPage(func(pagination *commons.Pagination) ([]invoice.Invoice, error) {
+		return invoices.GetAllInvoices(ctx, pagination)
+	}, func(inv invoice.Invoice) int { return inv.Seq }, func(inv invoice.Invoice) error {
+		full, err := invoices.GetInvoice(ctx, inv.Id, true)
+		if err != nil {
+			return fmt.Errorf("invoice %s: %w", inv.Id, err)
+		}
+		if full.Total != full.Tax.Total {
+			problem("%s total is %s, its lines add up to %s", inv.Id, full.Total, full.Tax.Total)
+		}
+		if _, ok := known[inv.UserId]; !ok {
+			_, err := persons.GetById(ctx, inv.UserId)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("person %s: %w", inv.UserId, err)
+			}
+			known[inv.UserId] = err == nil
+		}
+		if !known[inv.UserId] {
+			problem("%s belongs to the unknown person %s", inv.Id, inv.UserId)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := c.webhookService()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.out, "webhooks")
+	dead, err := webhooks.GetDeadLetters(ctx)
+	if err != nil {
+		return err
+	}
+	for _, d := range dead {
+		problem("%s of %s to subscription %s was given up on after %d attempts", d.EventType, d.EventId, d.SubscriptionId, d.Attempts)
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("found %d problems", problems)
+	}
+	fmt.Fprintln(c.out, "no problems found")
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/invoice"
+	"inventory-service-go/migrations"
+	"inventory-service-go/person"
+	"inventory-service-go/tax"
+	"inventory-service-go/webhook"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	latest, err := migrations.Latest()
+	assert.NoError(t, err)
+	usd := func(minorUnits int64) commons.Money { return commons.NewMoney(minorUnits, "USD") }
+	known, unknown := uuid.New(), uuid.New()
+	invoices := []invoice.Invoice{
+		{Seq: 1, Id: uuid.New(), UserId: known, Total: usd(2000), Tax: &tax.Breakdown{Total: usd(2000)}},
+		{Seq: 2, Id: uuid.New(), UserId: known, Total: usd(1500), Tax: &tax.Breakdown{Total: usd(2000)}},
+		{Seq: 3, Id: uuid.New(), UserId: unknown, Total: usd(0), Tax: &tax.Breakdown{Total: usd(0)}},
+	}
+
+	testCases := []struct {
+		name     string
+		invoices []invoice.Invoice
+		dead     []webhook.Delivery
+		wantErr  string
+	}{
+		{"Consistent", invoices[:1], nil, ""},
+		{"Problems", invoices, []webhook.Delivery{{EventType: "InvoicePaid", Attempts: 8}}, "found 3 problems"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").
+				WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(latest, false))
+			controller := gomock.NewController(t)
+			mockInvoices := invoice.NewMockInvoiceService(controller)
+			mockInvoices.EXPECT().GetAllInvoices(gomock.Any(), gomock.Any()).Return(tc.invoices, nil)
+			for _, inv := range tc.invoices {
+				mockInvoices.EXPECT().GetInvoice(gomock.Any(), inv.Id, true).Return(inv, nil)
+			}
+			mockPersons := person.NewMockPersonService(controller)
+			mockPersons.EXPECT().GetById(gomock.Any(), known).Return(&person.Person{Id: known}, nil)
+			mockPersons.EXPECT().GetById(gomock.Any(), unknown).Return(&person.Person{}, sql.ErrNoRows).MaxTimes(1)
+			mockWebhooks := webhook.NewMockWebhookService(controller)
+			mockWebhooks.EXPECT().GetDeadLetters(gomock.Any()).Return(tc.dead, nil)
+			c, out := newCtl(t, "")
+			c.db, c.invoices, c.persons, c.webhooks = sqlx.NewDb(db, "mockDb"), mockInvoices, mockPersons, mockWebhooks
+
+			err = run(context.Background(), c, []string{"report"})
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, "schema\ninvoices\nwebhooks\nno problems found\n", out.String())
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+				assert.Contains(t, out.String(), "total is 15.00 USD, its lines add up to 20.00 USD")
+				assert.Contains(t, out.String(), "belongs to the unknown person "+unknown.String())
+				assert.Contains(t, out.String(), "InvoicePaid")
+			}
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/tenant"
+	"text/tabwriter"
+)
+
+func createTenant(ctx context.Context, c *ctl, args []string) error {
+	if len(args) != 1 {
+		return errors.New("expected exactly one tenant name")
+	}
+	tenants, err := c.tenantService()
+	if err != nil {
+		return err
+	}
+	created, err := tenants.CreateTenant(ctx, tenant.CreateTenantRequest{Name: args[0], CreatedBy: c.actor})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "created tenant %s %s\n", created.Name, created.Id)
+	return nil
+}
+
+func listTenants(ctx context.Context, c *ctl, _ []string) error {
+	tenants, err := c.tenantService()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tCREATED BY\tCREATED AT")
+	err = eachPage(func(pagination *commons.Pagination) ([]tenant.Tenant, error) {
+		return tenants.GetTenants(ctx, pagination)
+	}, func(t tenant.Tenant) int { return t.Seq }, func(t tenant.Tenant) error {
+		_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.Id, t.Name, t.AuditInfo.CreatedBy, t.AuditInfo.CreatedAt)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// findTenant looks the tenant of the -tenant flag up by its id or its name.
+func findTenant(ctx context.Context, c *ctl, nameOrId string) (tenant.Tenant, error) {
+	tenants, err := c.tenantService()
+	if err != nil {
+		return tenant.Tenant{}, err
+	}
+	var t tenant.Tenant
+	if id, parseErr := uuid.Parse(nameOrId); parseErr == nil {
+		t, err = tenants.GetTenant(ctx, id)
+	} else {
+		t, err = tenants.GetTenantByName(ctx, nameOrId)
+	}
+	if err != nil {
+		return tenant.Tenant{}, fmt.Errorf("tenant %s: %w", nameOrId, err)
+	}
+	return t, nil
+}
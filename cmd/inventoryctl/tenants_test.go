@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/tenant"
+	"inventory-service-go/user"
+	"strings"
+	"testing"
+)
+
+func TestCreateTenant(t *testing.T) {
+	c, out := newCtl(t, "")
+	mockTenants := tenant.NewMockTenantService(gomock.NewController(t))
+	id := uuid.New()
+	mockTenants.EXPECT().CreateTenant(gomock.Any(), tenant.CreateTenantRequest{Name: "acme", CreatedBy: "unit_test"}).Return(tenant.Tenant{Id: id, Name: "acme"}, nil)
+	c.tenants = mockTenants
+
+	assert.NoError(t, run(context.Background(), c, []string{"tenants", "create", "acme"}))
+	assert.Equal(t, "created tenant acme "+id.String()+"\n", out.String())
+	assert.Error(t, run(context.Background(), c, []string{"tenants", "create"}))
+}
+
+func TestListTenants(t *testing.T) {
+	c, out := newCtl(t, "")
+	mockTenants := tenant.NewMockTenantService(gomock.NewController(t))
+	mockTenants.EXPECT().GetTenants(gomock.Any(), gomock.Any()).Return([]tenant.Tenant{
+		{Seq: 1, Id: commons.DefaultTenant, Name: "default"},
+		{Seq: 2, Id: uuid.New(), Name: "acme"},
+	}, nil)
+	c.tenants = mockTenants
+
+	assert.NoError(t, run(context.Background(), c, []string{"tenants", "list"}))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines[1], commons.DefaultTenant.String())
+}
+
+func TestRun_Tenant(t *testing.T) {
+	c, _ := newCtl(t, "")
+	controller := gomock.NewController(t)
+	mockTenants := tenant.NewMockTenantService(controller)
+	mockUsers := user.NewMockUserService(controller)
+	acme := tenant.Tenant{Id: uuid.New(), Name: "acme"}
+	mockTenants.EXPECT().GetTenantByName(gomock.Any(), "acme").Return(acme, nil)
+	mockTenants.EXPECT().GetTenant(gomock.Any(), acme.Id).Return(acme, nil)
+	mockTenants.EXPECT().GetTenantByName(gomock.Any(), "unknown").Return(tenant.Tenant{}, sql.ErrNoRows)
+	// the command runs as the tenant
+	mockUsers.EXPECT().DisableUser(gomock.Any(), "ann", "unit_test").DoAndReturn(func(ctx context.Context, _ string, _ string) (user.User, error) {
+		scoped, ok := commons.TenantFrom(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, acme.Id, scoped)
+		return user.User{Username: "ann"}, nil
+	}).Times(2)
+	c.tenants = mockTenants
+	c.users = mockUsers
+
+	assert.NoError(t, run(context.Background(), c, []string{"-tenant", "acme", "users", "disable", "ann"}))
+	assert.NoError(t, run(context.Background(), c, []string{"-tenant", acme.Id.String(), "users", "disable", "ann"}))
+	assert.ErrorIs(t, run(context.Background(), c, []string{"-tenant", "unknown", "users", "disable", "ann"}), sql.ErrNoRows)
+}
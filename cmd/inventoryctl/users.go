@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"inventory-service-go/commons"
+	"inventory-service-go/user"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+var errUsername = errors.New("expected exactly one username")
+
+// maxMintTTL bounds the tokens of service accounts, a leaked token is only stopped by disabling its account.
+const maxMintTTL = 90 * 24 * time.Hour
+
+func createUser(ctx context.Context, c *ctl, args []string) error {
+	var serviceAccount, passwordStdin bool
+	flags, err := parseFlags(c, "users create", args, func(flags *flag.FlagSet) {
+		flags.BoolVar(&serviceAccount, "service-account", false, "create a service account, which has no password")
+		flags.BoolVar(&passwordStdin, "password-stdin", false, "read the password from the first line of stdin")
+	})
+	if err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errUsername
+	}
+	request := user.CreateUserRequest{Username: flags.Arg(0), ServiceAccount: serviceAccount, CreatedBy: c.actor}
+	if passwordStdin {
+		// the password is not taken as a flag, it would end up in the shell history and the process list
+		line, err := bufio.NewReader(c.in).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("reading the password: %w", err)
+		}
+		request.Password = strings.TrimRight(line, "\r\n")
+	}
+	users, err := c.userService()
+	if err != nil {
+		return err
+	}
+	created, err := users.CreateUser(ctx, request)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "created %s %s\n", kind(created), created.Username)
+	return nil
+}
+
+func disableUser(ctx context.Context, c *ctl, args []string) error {
+	if len(args) != 1 {
+		return errUsername
+	}
+	users, err := c.userService()
+	if err != nil {
+		return err
+	}
+	disabled, err := users.DisableUser(ctx, args[0], c.actor)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "disabled %s %s, the tokens issued to it are no longer valid\n", kind(disabled), disabled.Username)
+	return nil
+}
+
+func rotateClientSecret(ctx context.Context, c *ctl, args []string) error {
+	if len(args) != 1 {
+		return errUsername
+	}
+	users, err := c.userService()
+	if err != nil {
+		return err
+	}
+	secret, err := users.RotateClientSecret(ctx, args[0], c.actor)
+	if err != nil {
+		return err
+	}
+	// only the secret, so it can be piped into a secret store
+	fmt.Fprintln(c.out, secret)
+	return nil
+}
+
+func listUsers(ctx context.Context, c *ctl, _ []string) error {
+	users, err := c.userService()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "USERNAME\tKIND\tTENANT\tDISABLED\tCREATED BY\tCREATED AT")
+	err = eachPage(func(pagination *commons.Pagination) ([]user.User, error) {
+		return users.GetUsers(ctx, pagination)
+	}, func(u user.User) int { return u.Seq }, func(u user.User) error {
+		_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", u.Username, kind(u), u.Tenant, u.DisabledAt, u.AuditInfo.CreatedBy, u.AuditInfo.CreatedAt)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func kind(u user.User) string {
+	if u.ServiceAccount {
+		return "service account"
+	}
+	return "user"
+}
+
+func mintToken(ctx context.Context, c *ctl, args []string) error {
+	var ttl time.Duration
+	flags, err := parseFlags(c, "tokens mint", args, func(flags *flag.FlagSet) {
+		flags.DurationVar(&ttl, "ttl", 0, "how long the token is valid, the configured JWT token TTL when not given")
+	})
+	if err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errUsername
+	}
+	if ttl < 0 {
+		return errors.New("the ttl must not be negative")
+	}
+	if ttl > maxMintTTL {
+		return fmt.Errorf("the ttl must not be longer than %s", maxMintTTL)
+	}
+	// the key that signs it is retired that long after its successor took over, see auth.KeySet
+	if len(c.config.JWT.Keys) > 0 && ttl > c.config.JWT.RotationOverlap {
+		return fmt.Errorf("the ttl must not be longer than the rotation overlap of the keys, %s", c.config.JWT.RotationOverlap)
+	}
+	users, err := c.userService()
+	if err != nil {
+		return err
+	}
+	account, err := users.GetServiceAccount(ctx, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	provider, err := c.authProvider()
+	if err != nil {
+		return err
+	}
+	token, err := provider.IssueToken(account.Username, account.Tenant, ttl)
+	if err != nil {
+		return err
+	}
+	// only the token, so it can be piped into a secret store
+	fmt.Fprintln(c.out, token)
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/auth"
+	"inventory-service-go/config"
+	"inventory-service-go/user"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateUser(t *testing.T) {
+	c, out := newCtl(t, "secret-password\n")
+	mockUsers := user.NewMockUserService(gomock.NewController(t))
+	mockUsers.EXPECT().CreateUser(gomock.Any(), user.CreateUserRequest{Username: "ann", Password: "secret-password", CreatedBy: "unit_test"}).
+		Return(user.User{Username: "ann"}, nil)
+	mockUsers.EXPECT().CreateUser(gomock.Any(), user.CreateUserRequest{Username: "billing", ServiceAccount: true, CreatedBy: "unit_test"}).
+		Return(user.User{Username: "billing", ServiceAccount: true}, nil)
+	c.users = mockUsers
+
+	assert.NoError(t, run(context.Background(), c, []string{"users", "create", "-password-stdin", "ann"}))
+	assert.NoError(t, run(context.Background(), c, []string{"users", "create", "-service-account", "billing"}))
+	assert.Equal(t, "created user ann\ncreated service account billing\n", out.String())
+
+	assert.ErrorIs(t, run(context.Background(), c, []string{"users", "create"}), errUsername)
+}
+
+func TestDisableUser(t *testing.T) {
+	c, out := newCtl(t, "")
+	mockUsers := user.NewMockUserService(gomock.NewController(t))
+	mockUsers.EXPECT().DisableUser(gomock.Any(), "ann", "unit_test").Return(user.User{Username: "ann", Disabled: true}, nil)
+	c.users = mockUsers
+
+	assert.NoError(t, run(context.Background(), c, []string{"users", "disable", "ann"}))
+	assert.True(t, strings.HasPrefix(out.String(), "disabled user ann"))
+}
+
+func TestRotateClientSecret(t *testing.T) {
+	c, out := newCtl(t, "")
+	mockUsers := user.NewMockUserService(gomock.NewController(t))
+	mockUsers.EXPECT().RotateClientSecret(gomock.Any(), "billing", "unit_test").Return("client-secret", nil)
+	mockUsers.EXPECT().RotateClientSecret(gomock.Any(), "ann", "unit_test").Return("", user.ErrNotServiceAccount)
+	c.users = mockUsers
+
+	assert.NoError(t, run(context.Background(), c, []string{"users", "secret", "billing"}))
+	assert.Equal(t, "client-secret\n", out.String())
+	assert.ErrorIs(t, run(context.Background(), c, []string{"users", "secret", "ann"}), user.ErrNotServiceAccount)
+}
+
+func TestListUsers(t *testing.T) {
+	c, out := newCtl(t, "")
+	mockUsers := user.NewMockUserService(gomock.NewController(t))
+	mockUsers.EXPECT().GetUsers(gomock.Any(), gomock.Any()).Return([]user.User{
+		{Seq: 1, Username: "ann"},
+		{Seq: 2, Username: "billing", ServiceAccount: true, Disabled: true, DisabledAt: "2024-05-01T00:00:00Z"},
+	}, nil)
+	c.users = mockUsers
+
+	assert.NoError(t, run(context.Background(), c, []string{"users", "list"}))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines[2], "service account")
+	assert.Contains(t, lines[2], "2024-05-01T00:00:00Z")
+}
+
+func TestMintToken(t *testing.T) {
+	c, out := newCtl(t, "")
+	mockUsers := user.NewMockUserService(gomock.NewController(t))
+	tenant := uuid.New()
+	mockUsers.EXPECT().GetServiceAccount(gomock.Any(), "billing").Return(user.User{Username: "billing", ServiceAccount: true, Tenant: tenant}, nil)
+	mockUsers.EXPECT().GetServiceAccount(gomock.Any(), "ann").Return(user.User{}, user.ErrNotServiceAccount)
+	c.users = mockUsers
+
+	assert.NoError(t, run(context.Background(), c, []string{"tokens", "mint", "-ttl", "720h", "billing"}))
+	claims := &auth.Claims{}
+	_, err := jwt.ParseWithClaims(strings.TrimSpace(out.String()), claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte("dummy_secret"), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", claims.Username)
+	assert.Equal(t, tenant.String(), claims.Tenant)
+	assert.Equal(t, 720*time.Hour, claims.ExpiresAt.Sub(claims.IssuedAt.Time))
+
+	assert.ErrorIs(t, run(context.Background(), c, []string{"tokens", "mint", "ann"}), user.ErrNotServiceAccount)
+}
+
+func TestMintToken_TTL(t *testing.T) {
+	c, _ := newCtl(t, "")
+	c.users = user.NewMockUserService(gomock.NewController(t))
+
+	assert.ErrorContains(t, run(context.Background(), c, []string{"tokens", "mint", "-ttl", "2161h", "billing"}), "must not be longer than 2160h0m0s")
+	assert.ErrorContains(t, run(context.Background(), c, []string{"tokens", "mint", "-ttl", "-1h", "billing"}), "must not be negative")
+	// a key is retired a rotation overlap after it was replaced
+	c.config.JWT.Keys = []config.SigningKey{{File: "jwt.pem"}}
+	assert.ErrorContains(t, run(context.Background(), c, []string{"tokens", "mint", "-ttl", "720h", "billing"}), "rotation overlap")
+}
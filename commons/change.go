@@ -0,0 +1,57 @@
+package commons
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sync"
+)
+
+const (
+	ChangeCreated = "created"
+	ChangeUpdated = "updated"
+	ChangeDeleted = "deleted"
+)
+
+// Change is a write a service made. Topic is the kind of entity that changed, e.g. "items", and Data the entity
+// after the change, nil when it was deleted. Tenant is the tenant the change was written for.
+type Change struct {
+	Topic  string
+	Action string
+	Id     uuid.UUID
+	Data   any
+	Tenant uuid.UUID
+}
+
+type ChangeHook func(change Change)
+
+// ChangeNotifier is implemented by services that let in-process listeners observe their changes.
+type ChangeNotifier interface {
+	OnChange(hook ChangeHook)
+}
+
+// ChangeHooks is embedded by services to implement ChangeNotifier. Hooks are called synchronously once a change was
+// written, so they must not block.
+type ChangeHooks struct {
+	mu    sync.RWMutex
+	hooks []ChangeHook
+}
+
+func (h *ChangeHooks) OnChange(hook ChangeHook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, hook)
+}
+
+// Notify calls the hooks with the change written as the tenant of ctx, a change written by the system went to the
+// default tenant.
+func (h *ChangeHooks) Notify(ctx context.Context, change Change) {
+	change.Tenant = DefaultTenant
+	if tenant, ok := TenantFrom(ctx); ok {
+		change.Tenant = tenant
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, hook := range h.hooks {
+		hook(change)
+	}
+}
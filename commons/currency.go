@@ -0,0 +1,36 @@
+package commons
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var ErrUnsupportedCurrency = NewValidationError("unsupported currency")
+
+// SupportedCurrencies are the ISO 4217 codes items and invoices can be priced in.
+var SupportedCurrencies = []string{"EUR", "GBP", "USD"}
+
+// currencyDecimals are the decimal places of the SupportedCurrencies, as in ISO 4217, at most MinorUnitScale.
+var currencyDecimals = map[string]int{"EUR": 2, "GBP": 2, "USD": 2}
+
+// Decimals is the number of decimal places an amount in the currency may have, MinorUnitScale for a currency that is
+// not supported, which is refused elsewhere.
+func Decimals(currency string) int {
+	if decimals, found := currencyDecimals[strings.ToUpper(currency)]; found {
+		return decimals
+	}
+	return MinorUnitScale
+}
+
+// NormalizeCurrency upper cases the code and checks it is one of SupportedCurrencies, an empty code means DefaultCurrency.
+func NormalizeCurrency(currency string) (string, error) {
+	if currency == "" {
+		return DefaultCurrency, nil
+	}
+	code := strings.ToUpper(strings.TrimSpace(currency))
+	if !slices.Contains(SupportedCurrencies, code) {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedCurrency, currency)
+	}
+	return code, nil
+}
@@ -6,19 +6,49 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
+	"inventory-service-go/tracing"
 	"log"
 	"os"
 	"sync"
 	"time"
 )
 
+// DBConfig is how GetDB connects, see ConfigureDB.
+type DBConfig struct {
+	URL             string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
 var (
 	// DB is a global variable that holds the connection to the database
-	sqlxDb *sqlx.DB
-	once   sync.Once
+	sqlxDb   *sqlx.DB
+	pgxPool  *pgxpool.Pool
+	once     sync.Once
+	dbConfig = DBConfig{
+		MaxOpenConns:    4,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: 30 * time.Minute,
+	}
 )
 
+// ConfigureDB sets how GetDB connects, it has to be called before the first call of GetDB. Without it the URL is read
+// from DATABASE_URL.
+func ConfigureDB(config DBConfig) {
+	dbConfig = config
+}
+
+// DatabaseURL is the URL GetDB connects to, for the connections that cannot come from the pool.
+func DatabaseURL() string {
+	return getDbUri()
+}
+
 func getDbUri() string {
+	if dbConfig.URL != "" {
+		return dbConfig.URL
+	}
 	dbConnectionString := os.Getenv("DATABASE_URL")
 	return dbConnectionString
 }
@@ -27,24 +57,44 @@ func GetDB() *sqlx.DB {
 	if sqlxDb == nil {
 		once.Do(func() {
 			uri := getDbUri()
-			db, err := pgxCreateDB(uri)
+			db, pool, err := pgxCreateDB(uri)
 			if err != nil {
 				log.Panicf("Error connecting to the database: %v", err)
 			}
-			db.SetMaxIdleConns(2)
-			db.SetMaxOpenConns(4)
-			db.SetConnMaxLifetime(time.Duration(30) * time.Minute)
+			db.SetMaxIdleConns(dbConfig.MaxIdleConns)
+			db.SetMaxOpenConns(dbConfig.MaxOpenConns)
+			db.SetConnMaxLifetime(dbConfig.ConnMaxLifetime)
+			db.SetConnMaxIdleTime(dbConfig.ConnMaxIdleTime)
 			sqlxDb = db
+			pgxPool = pool
 		})
 	}
 	return sqlxDb
 }
 
-func pgxCreateDB(uri string) (*sqlx.DB, error) {
-	pool, err := pgxpool.New(context.Background(), uri)
+// CloseDB closes the connections of GetDB, on shutdown.
+func CloseDB() error {
+	if sqlxDb == nil {
+		return nil
+	}
+	err := sqlxDb.Close()
+	// closing the database/sql handle leaves the pool it was opened from open
+	pgxPool.Close()
+	return err
+}
+
+func pgxCreateDB(uri string) (*sqlx.DB, *pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(uri)
+	if err != nil {
+		return nil, nil, err
+	}
+	config.ConnConfig.Tracer = tracing.QueryTracer{}
+	// the pool would otherwise cap the open connections of database/sql at its own default
+	config.MaxConns = int32(dbConfig.MaxOpenConns)
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	afterConnect := stdlib.OptionAfterConnect(func(ctx context.Context, conn *pgx.Conn) error {
@@ -53,5 +103,5 @@ func pgxCreateDB(uri string) (*sqlx.DB, error) {
 	})
 
 	pgxdb := stdlib.OpenDBFromPool(pool, afterConnect)
-	return sqlx.NewDb(pgxdb, "pgx"), nil
+	return sqlx.NewDb(pgxdb, "pgx"), pool, nil
 }
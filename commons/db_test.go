@@ -31,3 +31,18 @@ func TestGetDB_GetDB(t *testing.T) {
 		assert.NotNil(t, p)
 	})
 }
+
+func TestConfigureDB(t *testing.T) {
+	once = sync.Once{}
+	sqlxDb = nil
+	defaults := dbConfig
+	defer func() { dbConfig = defaults }()
+	ConfigureDB(DBConfig{URL: "postgres://localhost:5432/configured?sslmode=disable", MaxOpenConns: 3, MaxIdleConns: 1})
+
+	db := GetDB()
+	assert.Equal(t, 3, db.Stats().MaxOpenConnections)
+	assert.Equal(t, int32(3), pgxPool.Config().MaxConns)
+	assert.Equal(t, "configured", pgxPool.Config().ConnConfig.Database)
+	assert.NoError(t, CloseDB())
+	sqlxDb = nil
+}
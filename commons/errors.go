@@ -0,0 +1,14 @@
+package commons
+
+// ValidationError is returned by services for bad client input and is reported as 400 Bad Request.
+type ValidationError struct {
+	msg string
+}
+
+func NewValidationError(msg string) error {
+	return &ValidationError{msg: msg}
+}
+
+func (e *ValidationError) Error() string {
+	return e.msg
+}
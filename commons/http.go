@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"github.com/labstack/echo/v4"
+	"inventory-service-go/logging"
 	"net/http"
 )
 
@@ -11,9 +12,18 @@ func HandleServiceError(c echo.Context, err error) error {
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return c.JSON(http.StatusNotFound, err)
+		} else if isBadRequest(err) {
+			return c.JSON(http.StatusBadRequest, err.Error())
 		} else {
+			ctx := c.Request().Context()
+			logging.FromContext(ctx).ErrorContext(ctx, "Unexpected service error", "error", err)
 			return c.JSON(http.StatusInternalServerError, err)
 		}
 	}
 	return err
 }
+
+func isBadRequest(err error) bool {
+	var validationError *ValidationError
+	return errors.As(err, &validationError)
+}
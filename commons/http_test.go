@@ -3,6 +3,7 @@ package commons
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/labstack/echo/v4"
 	"net/http"
 	"net/http/httptest"
@@ -19,6 +20,7 @@ func TestHandleServiceError(t *testing.T) {
 		{"No Error", nil, http.StatusOK, false},
 		{"SQL No Rows Error", sql.ErrNoRows, http.StatusNotFound, false},
 		{"Generic Error", errors.New("generic error"), http.StatusInternalServerError, false},
+		{"Validation Error", fmt.Errorf("%w: JPY", ErrUnsupportedCurrency), http.StatusBadRequest, false},
 		{"Unknown Error", errors.New("unknown error"), http.StatusInternalServerError, true},
 	}
 
@@ -1,6 +1,9 @@
 package commons
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 // struct that represents results of a delete operation
 type DeleteResult struct {
@@ -13,9 +16,13 @@ type Pagination struct {
 	PageSize int `json:"page_size"`
 }
 
+// AuditInfo has the timestamps formatted as each service hands them out, CreatedTime and LastUpdateTime are the same
+// timestamps for the APIs that format them themselves.
 type AuditInfo struct {
-	CreatedBy     string `json:"created_by"`
-	CreatedAt     string `json:"created_at"`
-	LastUpdate    string `json:"last_update"`
-	LastChangedBy string `json:"last_change_by"`
+	CreatedBy      string    `json:"created_by"`
+	CreatedAt      string    `json:"created_at"`
+	LastUpdate     string    `json:"last_update"`
+	LastChangedBy  string    `json:"last_change_by"`
+	CreatedTime    time.Time `json:"-"`
+	LastUpdateTime time.Time `json:"-"`
 }
@@ -0,0 +1,338 @@
+package commons
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is assumed for amounts that are stored or supplied without a currency.
+const DefaultCurrency = "USD"
+
+// MinorUnitScale is the number of decimal places kept by Money (i.e. cents).
+const MinorUnitScale = 2
+
+var (
+	ErrInvalidAmount    = NewValidationError("invalid money amount")
+	ErrCurrencyMismatch = NewValidationError("currency mismatch")
+)
+
+var minorUnitsPerMajor = big.NewRat(100, 1)
+
+// plainDecimal is the only form of amount ParseMoney accepts, e.g. "12", "-12.3" or "12.34".
+var plainDecimal = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// Money is an exact monetary amount held as integer minor units (cents) plus an ISO 4217 currency code.
+// It maps to a Postgres numeric column and serializes to JSON as {"amount": "12.34", "currency": "USD"}.
+type Money struct {
+	MinorUnits int64
+	Currency   string
+}
+
+func NewMoney(minorUnits int64, currency string) Money {
+	return Money{
+		MinorUnits: minorUnits,
+		Currency:   currency,
+	}
+}
+
+// ParseMoney parses a plain decimal amount such as "12.34", fractions ("1/3") and exponents ("1e2") are refused, as
+// are more decimal places than the currency has (see Decimals), so an amount is never rounded.
+func ParseMoney(amount string, currency string) (Money, error) {
+	amount = strings.TrimSpace(amount)
+	if !plainDecimal.MatchString(amount) {
+		return Money{}, fmt.Errorf("%w: %q is not a decimal amount", ErrInvalidAmount, amount)
+	}
+	if _, fraction, _ := strings.Cut(amount, "."); len(fraction) > Decimals(currency) {
+		return Money{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidAmount, amount, Decimals(currency))
+	}
+	minorUnits, err := parseMinorUnits(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(minorUnits, currency), nil
+}
+
+func MustParseMoney(amount string, currency string) Money {
+	m, err := ParseMoney(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// parseMinorUnits rounds to the minor units, the numeric columns Scan reads may have more decimal places.
+func parseMinorUnits(amount string) (int64, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	return RoundRat(r.Mul(r, minorUnitsPerMajor))
+}
+
+// RoundRat rounds r half away from zero to the nearest integer.
+func RoundRat(r *big.Rat) (int64, error) {
+	num := new(big.Int).Abs(r.Num())
+	quo, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if rem.Mul(rem, big.NewInt(2)).Cmp(r.Denom()) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	if r.Sign() < 0 {
+		quo.Neg(quo)
+	}
+	if !quo.IsInt64() {
+		return 0, fmt.Errorf("%w: %s is out of range", ErrInvalidAmount, r.FloatString(MinorUnitScale))
+	}
+	return quo.Int64(), nil
+}
+
+// Amount returns the decimal representation of the amount without the currency, e.g. "-12.30".
+func (m Money) Amount() string {
+	sign := ""
+	units := m.MinorUnits
+	if units < 0 {
+		sign = "-"
+	}
+	major := units / 100
+	minor := units % 100
+	if major < 0 {
+		major = -major
+	}
+	if minor < 0 {
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, major, minor)
+}
+
+func (m Money) String() string {
+	return m.Amount() + " " + m.Currency
+}
+
+// Rat returns the amount in major units as an exact rational.
+func (m Money) Rat() *big.Rat {
+	return new(big.Rat).SetFrac64(m.MinorUnits, 100)
+}
+
+func (m Money) IsZero() bool {
+	return m.MinorUnits == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.MinorUnits < 0
+}
+
+// Add fails like Mul when the sum does not fit the minor units.
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	sum := m.MinorUnits + other.MinorUnits
+	// the sum wrapped around when it moved the other way than the added amount
+	if (other.MinorUnits > 0 && sum < m.MinorUnits) || (other.MinorUnits < 0 && sum > m.MinorUnits) {
+		return Money{}, fmt.Errorf("%w: %s plus %s is out of range", ErrInvalidAmount, m.Amount(), other.Amount())
+	}
+	return NewMoney(sum, currency), nil
+}
+
+// Sub fails like Mul when the difference does not fit the minor units.
+func (m Money) Sub(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	difference := m.MinorUnits - other.MinorUnits
+	if (other.MinorUnits > 0 && difference > m.MinorUnits) || (other.MinorUnits < 0 && difference < m.MinorUnits) {
+		return Money{}, fmt.Errorf("%w: %s minus %s is out of range", ErrInvalidAmount, m.Amount(), other.Amount())
+	}
+	return NewMoney(difference, currency), nil
+}
+
+// Mul multiplies the amount by a quantity, failing when the product does not fit the minor units.
+func (m Money) Mul(quantity int64) (Money, error) {
+	product := new(big.Int).Mul(big.NewInt(m.MinorUnits), big.NewInt(quantity))
+	if !product.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %s times %d is out of range", ErrInvalidAmount, m.Amount(), quantity)
+	}
+	return NewMoney(product.Int64(), m.Currency), nil
+}
+
+// commonCurrency treats a zero value Money without a currency as compatible with any currency so sums can start from Money{}.
+func (m Money) commonCurrency(other Money) (string, error) {
+	switch {
+	case m.Currency == other.Currency:
+		return m.Currency, nil
+	case m.Currency == "" && m.MinorUnits == 0:
+		return other.Currency, nil
+	case other.Currency == "" && other.MinorUnits == 0:
+		return m.Currency, nil
+	default:
+		return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+}
+
+// Value writes the amount only - the currency is stored in its own column.
+func (m Money) Value() (driver.Value, error) {
+	return m.Amount(), nil
+}
+
+// Scan reads a numeric column. The currency is left untouched, defaulting to DefaultCurrency when not yet set.
+func (m *Money) Scan(src any) error {
+	var amount string
+	switch v := src.(type) {
+	case string:
+		amount = v
+	case []byte:
+		amount = string(v)
+	case float64:
+		amount = strconv.FormatFloat(v, 'f', -1, 64)
+	case int64:
+		amount = strconv.FormatInt(v, 10)
+	default:
+		return fmt.Errorf("%w: cannot scan %T into Money", ErrInvalidAmount, src)
+	}
+	minorUnits, err := parseMinorUnits(amount)
+	if err != nil {
+		return err
+	}
+	m.MinorUnits = minorUnits
+	if m.Currency == "" {
+		m.Currency = DefaultCurrency
+	}
+	return nil
+}
+
+// MoneyJson is the wire format of Money, it is also substituted for Money in the OpenAPI docs (see .swaggo).
+type MoneyJson struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(MoneyJson{
+		Amount:   m.Amount(),
+		Currency: m.Currency,
+	})
+}
+
+// UnmarshalJSON accepts the {"amount": "12.34", "currency": "USD"} form as well as a bare string or number
+// amount (in DefaultCurrency) so existing clients keep working.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var amount, currency string
+	switch data[0] {
+	case '{':
+		var raw struct {
+			Amount   json.Number `json:"amount"`
+			Currency string      `json:"currency"`
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+		amount, currency = raw.Amount.String(), raw.Currency
+	case '"':
+		if err := json.Unmarshal(data, &amount); err != nil {
+			return err
+		}
+	default:
+		amount = string(data)
+	}
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	parsed, err := ParseMoney(amount, currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// NullMoney is Money that may be NULL, e.g. from an outer join.
+type NullMoney struct {
+	Money Money
+	Valid bool
+}
+
+func (n *NullMoney) Scan(src any) error {
+	if src == nil {
+		n.Money, n.Valid = Money{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.Money.Scan(src)
+}
+
+func (n NullMoney) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Money.Value()
+}
+
+// Convert multiplies the amount by an exact exchange rate, rounding half away from zero into the target currency.
+func (m Money) Convert(rate *big.Rat, currency string) (Money, error) {
+	minorUnits, err := RoundRat(new(big.Rat).Mul(new(big.Rat).SetInt64(m.MinorUnits), rate))
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(minorUnits, currency), nil
+}
+
+// MulRat multiplies the amount by an exact factor (e.g. a tax rate), rounding half away from zero.
+func (m Money) MulRat(factor *big.Rat) (Money, error) {
+	return m.Convert(factor, m.Currency)
+}
+
+// Allocate splits the amount in proportion to the weights so the parts always add back up to the amount, the
+// minor units left over by rounding down go to the parts with the largest remainders.
+func (m Money) Allocate(weights []int64) ([]Money, error) {
+	parts := make([]Money, len(weights))
+	var totalWeight int64
+	for _, w := range weights {
+		if w < 0 {
+			return nil, fmt.Errorf("%w: negative allocation weight", ErrInvalidAmount)
+		}
+		totalWeight += w
+	}
+	if totalWeight == 0 {
+		for i := range parts {
+			parts[i] = NewMoney(0, m.Currency)
+		}
+		return parts, nil
+	}
+	remainders := make([]*big.Int, len(weights))
+	allocated := int64(0)
+	for i, w := range weights {
+		quo, rem := new(big.Int).QuoRem(new(big.Int).Mul(big.NewInt(m.MinorUnits), big.NewInt(w)), big.NewInt(totalWeight), new(big.Int))
+		parts[i] = NewMoney(quo.Int64(), m.Currency)
+		remainders[i] = rem.Abs(rem)
+		allocated += quo.Int64()
+	}
+	step := int64(1)
+	left := m.MinorUnits - allocated
+	if left < 0 {
+		step, left = -1, -left
+	}
+	for ; left > 0; left-- {
+		largest := -1
+		for i, rem := range remainders {
+			if weights[i] > 0 && (largest < 0 || rem.Cmp(remainders[largest]) > 0) {
+				largest = i
+			}
+		}
+		parts[largest].MinorUnits += step
+		remainders[largest] = big.NewInt(-1)
+	}
+	return parts, nil
+}
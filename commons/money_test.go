@@ -0,0 +1,220 @@
+package commons
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		expected int64
+		wantErr  bool
+	}{
+		{"Whole Amount", "10", 1000, false},
+		{"Two Decimals", "10.99", 1099, false},
+		{"One Decimal", "0.5", 50, false},
+		{"Negative", "-12.30", -1230, false},
+		{"Padded", " 10.5 ", 1050, false},
+		{"Too Many Decimals", "1.005", 0, true},
+		{"Trailing Zero Past The Cents", "1.000", 0, true},
+		{"Exponent", "1e2", 0, true},
+		{"Fraction", "1/3", 0, true},
+		{"Leading Plus", "+1", 0, true},
+		{"No Leading Digit", ".5", 0, true},
+		{"Out Of Range", "92233720368547758.08", 0, true},
+		{"Garbage", "ten dollars", 0, true},
+		{"Empty", "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseMoney(tt.amount, "USD")
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidAmount))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, NewMoney(tt.expected, "USD"), m)
+			}
+		})
+	}
+}
+
+func TestMoney_Amount(t *testing.T) {
+	assert.Equal(t, "10.99", NewMoney(1099, "USD").Amount())
+	assert.Equal(t, "0.05", NewMoney(5, "USD").Amount())
+	assert.Equal(t, "-0.05", NewMoney(-5, "USD").Amount())
+	assert.Equal(t, "-12.30", NewMoney(-1230, "USD").Amount())
+	assert.Equal(t, "12.30 EUR", NewMoney(1230, "EUR").String())
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	// the classic float64 failure: 0.1 + 0.2 != 0.3
+	sum, err := MustParseMoney("0.10", "USD").Add(MustParseMoney("0.20", "USD"))
+	assert.NoError(t, err)
+	assert.Equal(t, MustParseMoney("0.30", "USD"), sum)
+
+	sum, err = Money{}.Add(NewMoney(100, "EUR"))
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(100, "EUR"), sum)
+
+	diff, err := NewMoney(100, "USD").Sub(NewMoney(250, "USD"))
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(-150, "USD"), diff)
+
+	_, err = NewMoney(100, "USD").Add(NewMoney(100, "EUR"))
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+
+	product, err := NewMoney(1099, "USD").Mul(3)
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(3297, "USD"), product)
+
+	_, err = NewMoney(math.MaxInt64/2+1, "USD").Mul(2)
+	assert.True(t, errors.Is(err, ErrInvalidAmount))
+	_, err = NewMoney(-1, "USD").Mul(math.MinInt64)
+	assert.True(t, errors.Is(err, ErrInvalidAmount))
+}
+
+func TestMoney_AddSub_Range(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     int64
+		sub      bool
+		expected int64
+		wantErr  bool
+	}{
+		{"Add Up To MaxInt64", math.MaxInt64 - 1, 1, false, math.MaxInt64, false},
+		{"Add Past MaxInt64", math.MaxInt64, 1, false, 0, true},
+		{"Add Down To MinInt64", math.MinInt64 + 1, -1, false, math.MinInt64, false},
+		{"Add Past MinInt64", math.MinInt64, -1, false, 0, true},
+		{"Add MaxInt64 To MinInt64", math.MinInt64, math.MaxInt64, false, -1, false},
+		{"Sub Down To MinInt64", math.MinInt64 + 1, 1, true, math.MinInt64, false},
+		{"Sub Past MinInt64", math.MinInt64, 1, true, 0, true},
+		{"Sub Up To MaxInt64", math.MaxInt64 - 1, -1, true, math.MaxInt64, false},
+		{"Sub Past MaxInt64", math.MaxInt64, -1, true, 0, true},
+		{"Sub MinInt64 From Zero", 0, math.MinInt64, true, 0, true},
+		{"Sub MinInt64 From -1", -1, math.MinInt64, true, math.MaxInt64, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Money
+			var err error
+			if tt.sub {
+				m, err = NewMoney(tt.a, "USD").Sub(NewMoney(tt.b, "USD"))
+			} else {
+				m, err = NewMoney(tt.a, "USD").Add(NewMoney(tt.b, "USD"))
+			}
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidAmount))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, NewMoney(tt.expected, "USD"), m)
+			}
+		})
+	}
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	parts, err := NewMoney(1000, "USD").Allocate([]int64{1, 1, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []Money{NewMoney(334, "USD"), NewMoney(333, "USD"), NewMoney(333, "USD")}, parts)
+
+	parts, err = NewMoney(500, "EUR").Allocate([]int64{2000, 0, 1000})
+	assert.NoError(t, err)
+	assert.Equal(t, []Money{NewMoney(333, "EUR"), NewMoney(0, "EUR"), NewMoney(167, "EUR")}, parts)
+
+	parts, err = NewMoney(-100, "EUR").Allocate([]int64{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []Money{NewMoney(-33, "EUR"), NewMoney(-67, "EUR")}, parts)
+
+	parts, err = NewMoney(100, "EUR").Allocate([]int64{0, 0})
+	assert.NoError(t, err)
+	assert.Equal(t, []Money{NewMoney(0, "EUR"), NewMoney(0, "EUR")}, parts)
+
+	_, err = NewMoney(100, "EUR").Allocate([]int64{-1, 2})
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestMoney_Scan(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      any
+		expected Money
+		wantErr  bool
+	}{
+		{"String", "10.99", NewMoney(1099, DefaultCurrency), false},
+		{"Bytes", []byte("123.45"), NewMoney(12345, DefaultCurrency), false},
+		{"Float", 10.99, NewMoney(1099, DefaultCurrency), false},
+		{"Int", int64(7), NewMoney(700, DefaultCurrency), false},
+		{"Unsupported", true, Money{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Money
+			err := m.Scan(tt.src)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, m)
+			}
+		})
+	}
+
+	m := Money{Currency: "GBP"}
+	assert.NoError(t, m.Scan("1.50"))
+	assert.Equal(t, NewMoney(150, "GBP"), m)
+
+	value, err := NewMoney(1099, "USD").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "10.99", value)
+}
+
+func TestNullMoney_Scan(t *testing.T) {
+	var n NullMoney
+	assert.NoError(t, n.Scan(nil))
+	assert.False(t, n.Valid)
+	value, err := n.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	assert.NoError(t, n.Scan("2.00"))
+	assert.True(t, n.Valid)
+	assert.Equal(t, NewMoney(200, DefaultCurrency), n.Money)
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(NewMoney(1099, "EUR"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"10.99","currency":"EUR"}`, string(data))
+
+	tests := []struct {
+		name     string
+		json     string
+		expected Money
+		wantErr  bool
+	}{
+		{"Object", `{"amount":"10.99","currency":"EUR"}`, NewMoney(1099, "EUR"), false},
+		{"Object Numeric Amount", `{"amount":10.99,"currency":"GBP"}`, NewMoney(1099, "GBP"), false},
+		{"Object No Currency", `{"amount":"1.00"}`, NewMoney(100, DefaultCurrency), false},
+		{"Bare Number", `130.5`, NewMoney(13050, DefaultCurrency), false},
+		{"Bare String", `"0.30"`, NewMoney(30, DefaultCurrency), false},
+		{"Null", `null`, Money{}, false},
+		{"Invalid Amount", `{"amount":"abc","currency":"USD"}`, Money{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Money
+			err := json.Unmarshal([]byte(tt.json), &m)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, m)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package commons
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"inventory-service-go/tracing"
+)
+
+// DefaultTenant owns the data written before there were tenants, and is the tenant of the tokens without one.
+var DefaultTenant = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// SetTenantQuery scopes the rest of the transaction to a tenant, the row-level security policies of the tenant
+// tables only let it see and write the rows of that tenant. See migration 000012_tenants.
+const SetTenantQuery = `SELECT set_config('app.tenant_id', $1, true)`
+
+// SetSystemQuery lets the rest of the transaction see and write the rows of every tenant, see migration
+// 000013_tenant_fail_closed.
+const SetSystemQuery = `SELECT set_config('app.system', 'on', true)`
+
+// BypassesRLSQuery tells whether the role of the connection is not bound by the row-level security policies.
+const BypassesRLSQuery = `SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user`
+
+type tenantKey struct{}
+
+type systemKey struct{}
+
+// WithTenant scopes the queries run with the context to the tenant.
+func WithTenant(ctx context.Context, tenant uuid.UUID) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFrom is the tenant of the context.
+func TenantFrom(ctx context.Context) (uuid.UUID, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(uuid.UUID)
+	return tenant, ok
+}
+
+// AsSystem lets the queries run with the context see the rows of every tenant, unless a tenant is set on it as well.
+// Only the background workers, inventoryctl without -tenant and the lookups authenticating a request, which do not
+// know the tenant yet, run as the system.
+func AsSystem(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemKey{}, true)
+}
+
+// IsSystem tells whether the context runs as the system, see AsSystem.
+func IsSystem(ctx context.Context) bool {
+	system, _ := ctx.Value(systemKey{}).(bool)
+	return system
+}
+
+// TenantDB runs the queries of a context with a tenant in a transaction scoped to the tenant, those of a context
+// running as the system in one that sees every tenant. The queries of a context with neither run as they are and the
+// row-level security policies show them no rows. The repositories of the tenant tables go through it instead of the
+// *sqlx.DB, it has no methods without a context so none can bypass the tenant.
+type TenantDB struct {
+	db *sqlx.DB
+}
+
+func NewTenantDB(db *sqlx.DB) *TenantDB {
+	return &TenantDB{db: db}
+}
+
+// BeginTxx begins a transaction scoped to the tenant of ctx, or seeing every tenant when ctx runs as the system.
+func (t *TenantDB) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	tx, err := t.db.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if tenant, ok := TenantFrom(ctx); ok {
+		_, err = tx.ExecContext(tracing.Statement(ctx, "SetTenantQuery"), SetTenantQuery, tenant.String())
+	} else if IsSystem(ctx) {
+		_, err = tx.ExecContext(tracing.Statement(ctx, "SetSystemQuery"), SetSystemQuery)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (t *TenantDB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return t.scoped(ctx, func(q sqlx.ExtContext) error {
+		return sqlx.GetContext(ctx, q, dest, query, args...)
+	})
+}
+
+func (t *TenantDB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return t.scoped(ctx, func(q sqlx.ExtContext) error {
+		return sqlx.SelectContext(ctx, q, dest, query, args...)
+	})
+}
+
+func (t *TenantDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := t.scoped(ctx, func(q sqlx.ExtContext) error {
+		var err error
+		result, err = q.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+// MustExecContext panics when the statement fails, as sqlx.DB.MustExecContext.
+func (t *TenantDB) MustExecContext(ctx context.Context, query string, args ...interface{}) sql.Result {
+	result, err := t.ExecContext(ctx, query, args...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (t *TenantDB) scoped(ctx context.Context, run func(q sqlx.ExtContext) error) error {
+	if _, ok := TenantFrom(ctx); !ok && !IsSystem(ctx) {
+		return run(t.db)
+	}
+	tx, err := t.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err = run(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// BypassesRLS tells whether the role the service connects with is a superuser or has BYPASSRLS, the tenants would then
+// not be isolated at all.
+func BypassesRLS(ctx context.Context, db *sqlx.DB) (bool, error) {
+	var bypasses bool
+	err := db.GetContext(tracing.Statement(ctx, "BypassesRLSQuery"), &bypasses, BypassesRLSQuery)
+	return bypasses, err
+}
@@ -0,0 +1,91 @@
+package commons
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTenantFrom(t *testing.T) {
+	_, ok := TenantFrom(context.Background())
+	assert.False(t, ok)
+	tenant := uuid.New()
+	got, ok := TenantFrom(WithTenant(context.Background(), tenant))
+	assert.True(t, ok)
+	assert.Equal(t, tenant, got)
+}
+
+func TestAsSystem(t *testing.T) {
+	assert.False(t, IsSystem(context.Background()))
+	system := AsSystem(context.Background())
+	assert.True(t, IsSystem(system))
+	_, ok := TenantFrom(system)
+	assert.False(t, ok)
+}
+
+func TestTenantDB(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	tenantDB := NewTenantDB(sqlx.NewDb(db, "mockDb"))
+	tenant := uuid.New()
+	scoped := WithTenant(context.Background(), tenant)
+
+	t.Run("without a tenant", func(t *testing.T) {
+		mock.ExpectQuery("SELECT name FROM persons").WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("foo"))
+		var name string
+		assert.NoError(t, tenantDB.GetContext(context.Background(), &name, "SELECT name FROM persons"))
+		assert.Equal(t, "foo", name)
+	})
+	t.Run("as the system", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT set_config\\('app.system', 'on', true\\)").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT name FROM persons").WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("foo"))
+		mock.ExpectCommit()
+		var name string
+		assert.NoError(t, tenantDB.GetContext(AsSystem(context.Background()), &name, "SELECT name FROM persons"))
+		assert.Equal(t, "foo", name)
+	})
+	t.Run("with a tenant", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT set_config").WithArgs(tenant.String()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT name FROM persons").WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("foo").AddRow("bar"))
+		mock.ExpectCommit()
+		var names []string
+		assert.NoError(t, tenantDB.SelectContext(scoped, &names, "SELECT name FROM persons"))
+		assert.Equal(t, []string{"foo", "bar"}, names)
+	})
+	t.Run("failed statement", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT set_config").WithArgs(tenant.String()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM persons").WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+		_, err := tenantDB.ExecContext(scoped, "DELETE FROM persons")
+		assert.Error(t, err)
+	})
+	t.Run("transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT set_config").WithArgs(tenant.String()).WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+		_, err := tenantDB.BeginTxx(scoped, nil)
+		assert.Error(t, err)
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBypassesRLS(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	mock.ExpectQuery("SELECT rolsuper OR rolbypassrls FROM pg_roles").WillReturnRows(sqlmock.NewRows([]string{"bypasses"}).AddRow(true))
+	bypasses, err := BypassesRLS(context.Background(), sqlx.NewDb(db, "mockDb"))
+	assert.NoError(t, err)
+	assert.True(t, bypasses)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,465 @@
+// Package config loads the settings of the server. Every setting has a default, which an optional YAML file, the
+// environment and the command line flags override in that order.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"inventory-service-go/commons"
+	"inventory-service-go/logging"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	Server    Server    `yaml:"server"`
+	GRPC      GRPC      `yaml:"grpc"`
+	TLS       TLS       `yaml:"tls"`
+	Database  Database  `yaml:"database"`
+	CORS      CORS      `yaml:"cors"`
+	JWT       JWT       `yaml:"jwt"`
+	RateLimit RateLimit `yaml:"rate_limit"`
+	APIKeys   APIKeys   `yaml:"api_keys"`
+	OAuth     OAuth     `yaml:"oauth"`
+	Tenants   Tenants   `yaml:"tenants"`
+	Currency  Currency  `yaml:"currency"`
+	Metrics   Metrics   `yaml:"metrics"`
+	Logging   Logging   `yaml:"logging"`
+	Tracing   Tracing   `yaml:"tracing"`
+	Caches    Caches    `yaml:"caches"`
+	Health    Health    `yaml:"health"`
+	Stream    Stream    `yaml:"stream"`
+	// InvoiceNumbers is the format of the numbers given to invoices when they are issued, see invoice.NumberFormat.
+	InvoiceNumbers InvoiceNumbers `yaml:"invoice_numbers"`
+	Recurring      Recurring      `yaml:"recurring"`
+	Webhooks       Webhooks       `yaml:"webhooks"`
+	Outbox         Outbox         `yaml:"outbox"`
+}
+
+type Server struct {
+	Address           string        `yaml:"address"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	// ShutdownDelay is how long readiness fails before the server stops taking requests, long enough for the load
+	// balancer to notice.
+	ShutdownDelay time.Duration `yaml:"shutdown_delay"`
+	// ShutdownTimeout bounds draining the requests in flight.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// GRPC is the gRPC API, served by the same process on a port of its own. It is off when Address is empty, which only
+// the file and the flag can set, an empty environment variable is ignored.
+type GRPC struct {
+	Address string `yaml:"address"`
+}
+
+// TLS is served when both files are set, by the gRPC API as well.
+type TLS struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+}
+
+func (t TLS) Enabled() bool {
+	return t.CertFile != "" && t.KeyFile != ""
+}
+
+type Database struct {
+	URL             string        `yaml:"url"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+}
+
+type CORS struct {
+	AllowOrigins []string `yaml:"allow_origins"`
+}
+
+// JWT signs the tokens with Secret (HS256), or with the Keys (RS256 or EdDSA) whose public keys are published as a
+// JWKS, see auth.KeySet for their rotation.
+type JWT struct {
+	Secret   string        `yaml:"secret"`
+	TokenTTL time.Duration `yaml:"token_ttl"`
+	Keys     []SigningKey  `yaml:"keys"`
+	// RotationOverlap is how long a key is published before it signs and after its successor took over.
+	RotationOverlap time.Duration `yaml:"rotation_overlap"`
+}
+
+// SigningKey is a PEM private key, RSA or Ed25519, that signs from ActiveFrom on, from the start when zero.
+type SigningKey struct {
+	File       string    `yaml:"file"`
+	ActiveFrom time.Time `yaml:"active_from"`
+}
+
+// RateLimit limits the requests of every client, known by its token or, on /api/v1/authorize, by its IP, see
+// ratelimit. Store is memory, where every replica limits on its own, or postgres, where the replicas share the limits.
+type RateLimit struct {
+	Store string `yaml:"store"`
+	// Default limits the routes of no group.
+	Default Limit `yaml:"default"`
+	// Groups are keyed by the prefix of their routes, e.g. /api/v1/invoices, the longest matching one applies.
+	Groups map[string]Limit `yaml:"groups"`
+	// DailyQuota is how many requests a client may make a day (UTC), 0 for no quota. Quotas overrides it by client.
+	DailyQuota int            `yaml:"daily_quota"`
+	Quotas     map[string]int `yaml:"quotas"`
+}
+
+// Limit is a token bucket of Burst requests refilled with Rate requests a minute, a Rate of 0 does not limit.
+type Limit struct {
+	Rate  int `yaml:"rate"`
+	Burst int `yaml:"burst"`
+}
+
+// APIKeys are managed by the Admins, users signed in with a token. Without admins they are only managed with
+// inventoryctl.
+type APIKeys struct {
+	Admins []string `yaml:"admins"`
+}
+
+// Tenants are created by the Admins, users signed in with a token, whatever their tenant. Without admins they are only
+// created with inventoryctl. The exchange and tax rates are shared by all tenants, so they are changed by the same admins.
+type Tenants struct {
+	Admins []string `yaml:"admins"`
+}
+
+// OAuth is the authorization server of handlers.OAuthRoutes. Issuer is its public URL, e.g. https://inventory.example.com,
+// without it the OAuth endpoints are not served. The tokens carry the Issuer and the Audience, the services that
+// accept them, as iss and aud.
+type OAuth struct {
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+}
+
+// Currency is what the amounts of the reports are normalized to, see currency.ExchangeRateService.Normalize.
+type Currency struct {
+	Base string `yaml:"base"`
+}
+
+// Metrics is /metrics, which the JWT middleware skips as scrapers cannot log in. It asks for Token as a bearer token
+// unless it is made Public, e.g. on a network only Prometheus can reach.
+type Metrics struct {
+	Token  string `yaml:"token"`
+	Public bool   `yaml:"public"`
+}
+
+// Logging is written as JSON lines to stdout, Level is debug, info, warn or error.
+type Logging struct {
+	Level string `yaml:"level"`
+}
+
+// Tracing sends the spans to the Exporters, stdout or otlp, and records none without them, see tracing.Setup. The
+// otlp exporter sends to an insecure collector on localhost:4318 unless an Endpoint is set, it still reads the other
+// OTEL_EXPORTER_OTLP_ variables, e.g. the headers, itself.
+type Tracing struct {
+	Exporters   []string `yaml:"exporters"`
+	Endpoint    string   `yaml:"endpoint"`
+	ServiceName string   `yaml:"service_name"`
+}
+
+// Caches are the read-through caches of every replica, invalidated on all of them when what they hold changes.
+type Caches struct {
+	Items   Cache `yaml:"items"`
+	Persons Cache `yaml:"persons"`
+	// Users are the enabled users, which every request with a token checks.
+	Users Cache `yaml:"users"`
+}
+
+// Cache keeps up to Size entries for TTL each, a Size of 0 turns it off.
+type Cache struct {
+	Size int           `yaml:"size"`
+	TTL  time.Duration `yaml:"ttl"`
+}
+
+// Health bounds the checks of /health/ready by ReadinessTimeout.
+type Health struct {
+	ReadinessTimeout time.Duration `yaml:"readiness_timeout"`
+}
+
+// Stream keeps the last BufferSize events for the clients resuming the stream.
+type Stream struct {
+	BufferSize int `yaml:"buffer_size"`
+}
+
+type InvoiceNumbers struct {
+	Prefix      string `yaml:"prefix"`
+	YearlyReset bool   `yaml:"yearly_reset"`
+	Padding     int    `yaml:"padding"`
+}
+
+// Recurring issues the due recurring invoices every Interval.
+type Recurring struct {
+	Interval time.Duration `yaml:"interval"`
+}
+
+// Webhooks sends the due deliveries every DispatchInterval and dead-letters a delivery after MaxAttempts.
+type Webhooks struct {
+	DispatchInterval time.Duration `yaml:"dispatch_interval"`
+	MaxAttempts      int           `yaml:"max_attempts"`
+}
+
+// Outbox relays the events every RelayInterval to the Sinks, log, file, which appends them to File, and http, which
+// posts them to HTTPURL.
+type Outbox struct {
+	RelayInterval time.Duration `yaml:"relay_interval"`
+	Sinks         []string      `yaml:"sinks"`
+	File          string        `yaml:"file"`
+	HTTPURL       string        `yaml:"http_url"`
+}
+
+// IssuerURL is the Issuer without a trailing slash, as it appears in the tokens and the metadata.
+func (o OAuth) IssuerURL() string {
+	return strings.TrimSuffix(o.Issuer, "/")
+}
+
+// Default returns the settings used unless they are overridden. It has no database URL or JWT secret, those always
+// have to be given.
+func Default() Config {
+	return Config{
+		Server: Server{
+			Address:           ":8080",
+			ReadTimeout:       30 * time.Second,
+			ReadHeaderTimeout: 10 * time.Second,
+			// the stream is exempt, see handlers.Stream
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     2 * time.Minute,
+			ShutdownTimeout: 30 * time.Second,
+		},
+		GRPC: GRPC{
+			Address: ":9090",
+		},
+		Database: Database{
+			MaxOpenConns:    4,
+			MaxIdleConns:    2,
+			ConnMaxLifetime: 30 * time.Minute,
+		},
+		CORS: CORS{
+			AllowOrigins: []string{"*"},
+		},
+		JWT: JWT{
+			TokenTTL:        12 * time.Hour,
+			RotationOverlap: 24 * time.Hour,
+		},
+		OAuth: OAuth{
+			Audience: "inventory-service",
+		},
+		Currency: Currency{
+			Base: commons.DefaultCurrency,
+		},
+		RateLimit: RateLimit{
+			Store:   "memory",
+			Default: Limit{Rate: 600, Burst: 100},
+			Groups: map[string]Limit{
+				// guessing passwords takes many tries
+				"/api/v1/authorize": {Rate: 10, Burst: 5},
+				"/oauth/token":      {Rate: 10, Burst: 5},
+			},
+		},
+		Logging: Logging{
+			Level: "info",
+		},
+		Tracing: Tracing{
+			ServiceName: "inventory-service-go",
+		},
+		Caches: Caches{
+			Items:   Cache{Size: 1000, TTL: 5 * time.Minute},
+			Persons: Cache{Size: 1000, TTL: 5 * time.Minute},
+			Users:   Cache{Size: 1000, TTL: 5 * time.Minute},
+		},
+		Health: Health{
+			ReadinessTimeout: 2 * time.Second,
+		},
+		Stream: Stream{
+			BufferSize: 1024,
+		},
+		InvoiceNumbers: InvoiceNumbers{
+			Prefix:      "INV",
+			YearlyReset: true,
+			Padding:     6,
+		},
+		Recurring: Recurring{
+			Interval: time.Hour,
+		},
+		Webhooks: Webhooks{
+			DispatchInterval: 5 * time.Second,
+			MaxAttempts:      8,
+		},
+		Outbox: Outbox{
+			RelayInterval: 5 * time.Second,
+			Sinks:         []string{"log"},
+			File:          "outbox.jsonl",
+		},
+	}
+}
+
+// Validate returns all problems of the settings at once.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Server.Address == "" {
+		errs = append(errs, errors.New("server.address is required"))
+	}
+	if c.GRPC.Address != "" && c.GRPC.Address == c.Server.Address {
+		errs = append(errs, errors.New("grpc.address must differ from server.address"))
+	}
+	for _, timeout := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.read_header_timeout", c.Server.ReadHeaderTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"server.shutdown_delay", c.Server.ShutdownDelay},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+		{"database.conn_max_lifetime", c.Database.ConnMaxLifetime},
+		{"database.conn_max_idle_time", c.Database.ConnMaxIdleTime},
+	} {
+		if timeout.value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative", timeout.name))
+		}
+	}
+	for _, interval := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"health.readiness_timeout", c.Health.ReadinessTimeout},
+		{"recurring.interval", c.Recurring.Interval},
+		{"webhooks.dispatch_interval", c.Webhooks.DispatchInterval},
+		{"outbox.relay_interval", c.Outbox.RelayInterval},
+	} {
+		if interval.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", interval.name))
+		}
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("tls.cert_file and tls.key_file must be set together"))
+	}
+	if c.Database.URL == "" {
+		errs = append(errs, errors.New("database.url is required"))
+	}
+	if c.Database.MaxOpenConns < 1 {
+		errs = append(errs, errors.New("database.max_open_conns must be at least 1"))
+	}
+	if c.Database.MaxIdleConns < 0 || c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		errs = append(errs, errors.New("database.max_idle_conns must be between 0 and database.max_open_conns"))
+	}
+	if len(c.CORS.AllowOrigins) == 0 {
+		errs = append(errs, errors.New("cors.allow_origins must not be empty"))
+	}
+	if c.JWT.Secret == "" && len(c.JWT.Keys) == 0 {
+		errs = append(errs, errors.New("jwt.secret is required"))
+	}
+	if c.JWT.TokenTTL <= 0 {
+		errs = append(errs, errors.New("jwt.token_ttl must be positive"))
+	}
+	for i, key := range c.JWT.Keys {
+		if key.File == "" {
+			errs = append(errs, fmt.Errorf("jwt.keys[%d].file is required", i))
+		}
+	}
+	// the tokens of a retired key would otherwise be rejected before they expire
+	if len(c.JWT.Keys) > 0 && c.JWT.RotationOverlap < c.JWT.TokenTTL {
+		errs = append(errs, errors.New("jwt.rotation_overlap must be at least jwt.token_ttl"))
+	}
+	errs = append(errs, c.RateLimit.validate()...)
+	if c.Metrics.Token == "" && !c.Metrics.Public {
+		errs = append(errs, errors.New("metrics.token is required unless metrics.public is set"))
+	}
+	if _, err := commons.NormalizeCurrency(c.Currency.Base); err != nil || c.Currency.Base == "" {
+		errs = append(errs, fmt.Errorf("currency.base must be one of %s", strings.Join(commons.SupportedCurrencies, ", ")))
+	}
+	if _, err := logging.ParseLevel(c.Logging.Level); err != nil {
+		errs = append(errs, errors.New("logging.level must be debug, info, warn or error"))
+	}
+	if c.Tracing.ServiceName == "" {
+		errs = append(errs, errors.New("tracing.service_name is required"))
+	}
+	errs = append(errs, c.Caches.Items.validate("caches.items")...)
+	errs = append(errs, c.Caches.Persons.validate("caches.persons")...)
+	errs = append(errs, c.Caches.Users.validate("caches.users")...)
+	if c.Stream.BufferSize < 1 {
+		errs = append(errs, errors.New("stream.buffer_size must be at least 1"))
+	}
+	// a counter of 18 digits still fits an int64
+	if c.InvoiceNumbers.Padding < 1 || c.InvoiceNumbers.Padding > 18 {
+		errs = append(errs, errors.New("invoice_numbers.padding must be between 1 and 18"))
+	}
+	if c.Webhooks.MaxAttempts < 1 {
+		errs = append(errs, errors.New("webhooks.max_attempts must be at least 1"))
+	}
+	errs = append(errs, c.Outbox.validate()...)
+	if c.OAuth.Issuer != "" {
+		// RFC 8414 section 2
+		if u, err := url.Parse(c.OAuth.Issuer); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+			errs = append(errs, errors.New("oauth.issuer must be an http(s) URL without query or fragment"))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r RateLimit) validate() []error {
+	var errs []error
+	if r.Store != "memory" && r.Store != "postgres" {
+		errs = append(errs, errors.New("rate_limit.store must be memory or postgres"))
+	}
+	errs = append(errs, r.Default.validate("rate_limit.default")...)
+	for prefix, limit := range r.Groups {
+		if !strings.HasPrefix(prefix, "/") {
+			errs = append(errs, fmt.Errorf("rate_limit.groups: %q must start with /", prefix))
+		}
+		errs = append(errs, limit.validate(fmt.Sprintf("rate_limit.groups[%s]", prefix))...)
+	}
+	if r.DailyQuota < 0 {
+		errs = append(errs, errors.New("rate_limit.daily_quota must not be negative"))
+	}
+	for client, quota := range r.Quotas {
+		if quota < 0 {
+			errs = append(errs, fmt.Errorf("rate_limit.quotas[%s] must not be negative", client))
+		}
+	}
+	return errs
+}
+
+func (l Limit) validate(name string) []error {
+	var errs []error
+	if l.Rate < 0 {
+		errs = append(errs, fmt.Errorf("%s.rate must not be negative", name))
+	}
+	if l.Rate > 0 && l.Burst < 1 {
+		errs = append(errs, fmt.Errorf("%s.burst must be at least 1", name))
+	}
+	return errs
+}
+
+func (c Cache) validate(name string) []error {
+	var errs []error
+	if c.Size < 0 {
+		errs = append(errs, fmt.Errorf("%s.size must not be negative", name))
+	}
+	if c.Size > 0 && c.TTL <= 0 {
+		errs = append(errs, fmt.Errorf("%s.ttl must be positive", name))
+	}
+	return errs
+}
+
+func (o Outbox) validate() []error {
+	var errs []error
+	for _, sink := range o.Sinks {
+		switch strings.ToLower(sink) {
+		case "log":
+		case "file":
+			if o.File == "" {
+				errs = append(errs, errors.New("outbox.file is required for the file sink"))
+			}
+		case "http":
+			if o.HTTPURL == "" {
+				errs = append(errs, errors.New("outbox.http_url is required for the http sink"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("outbox.sinks: unknown sink %q, must be log, file or http", sink))
+		}
+	}
+	return errs
+}
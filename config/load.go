@@ -0,0 +1,191 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setting binds a field of the config to its environment variable and flag.
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	field func(c *Config) any
+}
+
+var settings = []setting{
+	{"LISTEN_ADDRESS", "listen-address", "address the server listens on", func(c *Config) any { return &c.Server.Address }},
+	{"READ_TIMEOUT", "read-timeout", "timeout for reading a request", func(c *Config) any { return &c.Server.ReadTimeout }},
+	{"READ_HEADER_TIMEOUT", "read-header-timeout", "timeout for reading the request headers", func(c *Config) any { return &c.Server.ReadHeaderTimeout }},
+	{"WRITE_TIMEOUT", "write-timeout", "timeout for writing a response", func(c *Config) any { return &c.Server.WriteTimeout }},
+	{"IDLE_TIMEOUT", "idle-timeout", "how long idle keep-alive connections are kept", func(c *Config) any { return &c.Server.IdleTimeout }},
+	{"SHUTDOWN_DELAY", "shutdown-delay", "how long readiness fails before the server stops taking requests", func(c *Config) any { return &c.Server.ShutdownDelay }},
+	{"SHUTDOWN_TIMEOUT", "shutdown-timeout", "how long the requests in flight are drained", func(c *Config) any { return &c.Server.ShutdownTimeout }},
+	{"GRPC_LISTEN_ADDRESS", "grpc-listen-address", "address the gRPC API listens on, an empty flag turns it off", func(c *Config) any { return &c.GRPC.Address }},
+	{"TLS_CERT_FILE", "tls-cert-file", "certificate to serve TLS with", func(c *Config) any { return &c.TLS.CertFile }},
+	{"TLS_KEY_FILE", "tls-key-file", "key of the TLS certificate", func(c *Config) any { return &c.TLS.KeyFile }},
+	{"DATABASE_URL", "database-url", "PostgreSQL connection URL", func(c *Config) any { return &c.Database.URL }},
+	{"DB_MAX_OPEN_CONNS", "db-max-open-conns", "maximum number of open database connections", func(c *Config) any { return &c.Database.MaxOpenConns }},
+	{"DB_MAX_IDLE_CONNS", "db-max-idle-conns", "maximum number of idle database connections", func(c *Config) any { return &c.Database.MaxIdleConns }},
+	{"DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "maximum lifetime of a database connection", func(c *Config) any { return &c.Database.ConnMaxLifetime }},
+	{"DB_CONN_MAX_IDLE_TIME", "db-conn-max-idle-time", "maximum idle time of a database connection", func(c *Config) any { return &c.Database.ConnMaxIdleTime }},
+	{"CORS_ALLOW_ORIGINS", "cors-allow-origins", "comma separated origins allowed to call the API", func(c *Config) any { return &c.CORS.AllowOrigins }},
+	{"JWT_SECRET", "jwt-secret", "secret the tokens are signed with", func(c *Config) any { return &c.JWT.Secret }},
+	{"JWT_TOKEN_TTL", "jwt-token-ttl", "how long issued tokens are valid", func(c *Config) any { return &c.JWT.TokenTTL }},
+	{"JWT_KEYS", "jwt-keys", "comma separated PEM key files to sign the tokens with, each file@RFC3339 time to schedule it", func(c *Config) any { return &c.JWT.Keys }},
+	{"JWT_ROTATION_OVERLAP", "jwt-rotation-overlap", "how long a key is published before it signs and after it was replaced", func(c *Config) any { return &c.JWT.RotationOverlap }},
+	{"RATE_LIMIT_STORE", "rate-limit-store", "where the rate limits are kept, memory or postgres", func(c *Config) any { return &c.RateLimit.Store }},
+	{"RATE_LIMIT_RATE", "rate-limit-rate", "requests a minute a client may make on the routes of no group, 0 for no limit", func(c *Config) any { return &c.RateLimit.Default.Rate }},
+	{"RATE_LIMIT_BURST", "rate-limit-burst", "requests a client may make at once on the routes of no group", func(c *Config) any { return &c.RateLimit.Default.Burst }},
+	{"RATE_LIMIT_DAILY_QUOTA", "rate-limit-daily-quota", "requests a client may make a day, 0 for no quota", func(c *Config) any { return &c.RateLimit.DailyQuota }},
+	{"API_KEY_ADMINS", "api-key-admins", "comma separated users who manage the API keys", func(c *Config) any { return &c.APIKeys.Admins }},
+	{"TENANT_ADMINS", "tenant-admins", "comma separated users who create the tenants and change the exchange and tax rates", func(c *Config) any { return &c.Tenants.Admins }},
+	{"OAUTH_ISSUER", "oauth-issuer", "public URL of the OAuth2 authorization server, which is off without it", func(c *Config) any { return &c.OAuth.Issuer }},
+	{"OAUTH_AUDIENCE", "oauth-audience", "audience of the tokens", func(c *Config) any { return &c.OAuth.Audience }},
+	{"METRICS_TOKEN", "metrics-token", "bearer token /metrics asks for", func(c *Config) any { return &c.Metrics.Token }},
+	{"METRICS_PUBLIC", "metrics-public", "serve /metrics without a token", func(c *Config) any { return &c.Metrics.Public }},
+	{"BASE_CURRENCY", "base-currency", "currency the reports are normalized to", func(c *Config) any { return &c.Currency.Base }},
+	{"LOG_LEVEL", "log-level", "level of the logs, debug, info, warn or error", func(c *Config) any { return &c.Logging.Level }},
+	{"OTEL_TRACES_EXPORTER", "traces-exporter", "comma separated exporters the spans are sent to, stdout or otlp, none records no spans", func(c *Config) any { return &c.Tracing.Exporters }},
+	{"OTEL_EXPORTER_OTLP_ENDPOINT", "otlp-endpoint", "URL of the collector the otlp exporter sends to", func(c *Config) any { return &c.Tracing.Endpoint }},
+	{"OTEL_SERVICE_NAME", "service-name", "service name of the spans", func(c *Config) any { return &c.Tracing.ServiceName }},
+	{"ITEM_CACHE_SIZE", "item-cache-size", "items cached, 0 turns the cache off", func(c *Config) any { return &c.Caches.Items.Size }},
+	{"ITEM_CACHE_TTL", "item-cache-ttl", "how long an item is cached", func(c *Config) any { return &c.Caches.Items.TTL }},
+	{"PERSON_CACHE_SIZE", "person-cache-size", "persons cached, 0 turns the cache off", func(c *Config) any { return &c.Caches.Persons.Size }},
+	{"PERSON_CACHE_TTL", "person-cache-ttl", "how long a person is cached", func(c *Config) any { return &c.Caches.Persons.TTL }},
+	{"USER_CACHE_SIZE", "user-cache-size", "enabled users cached, 0 turns the cache off", func(c *Config) any { return &c.Caches.Users.Size }},
+	{"USER_CACHE_TTL", "user-cache-ttl", "how long an enabled user is cached", func(c *Config) any { return &c.Caches.Users.TTL }},
+	{"READINESS_TIMEOUT", "readiness-timeout", "timeout of the readiness checks", func(c *Config) any { return &c.Health.ReadinessTimeout }},
+	{"STREAM_BUFFER_SIZE", "stream-buffer-size", "events kept for the stream clients that resume", func(c *Config) any { return &c.Stream.BufferSize }},
+	{"INVOICE_NUMBER_PREFIX", "invoice-number-prefix", "prefix of the invoice numbers, an empty flag leaves it out", func(c *Config) any { return &c.InvoiceNumbers.Prefix }},
+	{"INVOICE_NUMBER_YEARLY_RESET", "invoice-number-yearly-reset", "start a new series of invoice numbers every year", func(c *Config) any { return &c.InvoiceNumbers.YearlyReset }},
+	{"INVOICE_NUMBER_PADDING", "invoice-number-padding", "digits the invoice numbers are padded to", func(c *Config) any { return &c.InvoiceNumbers.Padding }},
+	{"RECURRING_INVOICE_INTERVAL", "recurring-invoice-interval", "how often the due recurring invoices are issued", func(c *Config) any { return &c.Recurring.Interval }},
+	{"WEBHOOK_DISPATCH_INTERVAL", "webhook-dispatch-interval", "how often the due webhook deliveries are sent", func(c *Config) any { return &c.Webhooks.DispatchInterval }},
+	{"WEBHOOK_MAX_ATTEMPTS", "webhook-max-attempts", "attempts after which a webhook delivery is dead-lettered", func(c *Config) any { return &c.Webhooks.MaxAttempts }},
+	{"OUTBOX_RELAY_INTERVAL", "outbox-relay-interval", "how often the outbox events are relayed", func(c *Config) any { return &c.Outbox.RelayInterval }},
+	{"OUTBOX_SINKS", "outbox-sinks", "comma separated sinks the outbox events are relayed to, log, file or http", func(c *Config) any { return &c.Outbox.Sinks }},
+	{"OUTBOX_FILE", "outbox-file", "file the file sink appends the outbox events to", func(c *Config) any { return &c.Outbox.File }},
+	{"OUTBOX_HTTP_URL", "outbox-http-url", "URL the http sink posts the outbox events to", func(c *Config) any { return &c.Outbox.HTTPURL }},
+}
+
+// Load reads the settings from the YAML file named by the -config flag or CONFIG_FILE, if any, then from the
+// environment and then from the flags in args, and validates them.
+func Load(args []string, lookupEnv func(string) (string, bool)) (Config, error) {
+	config := Default()
+	flags := flag.NewFlagSet("inventory-service-go", flag.ContinueOnError)
+	path := flags.String("config", "", "YAML file to read the settings from, CONFIG_FILE when not given")
+	values := map[string]*string{}
+	for _, s := range settings {
+		values[s.flag] = flags.String(s.flag, "", s.usage+", "+s.env+" when not given")
+	}
+	if err := flags.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	if *path == "" {
+		*path, _ = lookupEnv("CONFIG_FILE")
+	}
+	if *path != "" {
+		if err := readFile(*path, &config); err != nil {
+			return Config{}, err
+		}
+	}
+	var errs []error
+	for _, s := range settings {
+		if value, ok := lookupEnv(s.env); ok && value != "" {
+			if err := set(s.field(&config), value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", s.env, err))
+			}
+		}
+	}
+	flags.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if s.flag == f.Name {
+				if err := set(s.field(&config), *values[s.flag]); err != nil {
+					errs = append(errs, fmt.Errorf("-%s: %w", s.flag, err))
+				}
+			}
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return Config{}, err
+	}
+	return config, config.Validate()
+}
+
+func readFile(path string, config *Config) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	decoder := yaml.NewDecoder(file)
+	// a misspelled setting would otherwise be silently ignored
+	decoder.KnownFields(true)
+	if err = decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	return nil
+}
+
+func set(field any, value string) error {
+	switch field := field.(type) {
+	case *string:
+		*field = value
+	case *bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field = b
+	case *int:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*field = i
+	case *time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*field = d
+	case *[]string:
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		*field = list
+	case *[]SigningKey:
+		var keys []SigningKey
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			file, activeFrom, scheduled := strings.Cut(item, "@")
+			key := SigningKey{File: file}
+			if scheduled {
+				t, err := time.Parse(time.RFC3339, activeFrom)
+				if err != nil {
+					return err
+				}
+				key.ActiveFrom = t
+			}
+			keys = append(keys, key)
+		}
+		*field = keys
+	default:
+		return fmt.Errorf("unsupported setting type %T", field)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Env(t *testing.T) {
+	config, err := Load(nil, env(map[string]string{
+		"DATABASE_URL":       "postgresql://localhost/inventory",
+		"JWT_SECRET":         "secret",
+		"LISTEN_ADDRESS":     ":8081",
+		"DB_MAX_OPEN_CONNS":  "10",
+		"WRITE_TIMEOUT":      "1m",
+		"CORS_ALLOW_ORIGINS": "https://a.example.com, https://b.example.com,",
+		"API_KEY_ADMINS":     "ann,bob",
+		"TENANT_ADMINS":      "ann",
+		"OAUTH_ISSUER":       "https://inventory.example.com",
+		"OAUTH_AUDIENCE":     "billing",
+		"BASE_CURRENCY":      "EUR",
+		"METRICS_TOKEN":      "s3cret",
+		"JWT_KEYS":           "keys/october.pem, keys/november.pem@2026-11-01T00:00:00Z",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, ":8081", config.Server.Address)
+	assert.Equal(t, 10, config.Database.MaxOpenConns)
+	assert.Equal(t, time.Minute, config.Server.WriteTimeout)
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, config.CORS.AllowOrigins)
+	assert.Equal(t, []string{"ann", "bob"}, config.APIKeys.Admins)
+	assert.Equal(t, []string{"ann"}, config.Tenants.Admins)
+	assert.Equal(t, "https://inventory.example.com", config.OAuth.Issuer)
+	assert.Equal(t, "billing", config.OAuth.Audience)
+	assert.Equal(t, "EUR", config.Currency.Base)
+	assert.Equal(t, Metrics{Token: "s3cret"}, config.Metrics)
+	assert.Equal(t, []SigningKey{
+		{File: "keys/october.pem"},
+		{File: "keys/november.pem", ActiveFrom: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+	}, config.JWT.Keys)
+	assert.Equal(t, Default().Server.ReadTimeout, config.Server.ReadTimeout)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeFile(t, `
+server:
+  address: ":7070"
+  read_timeout: 5s
+database:
+  url: postgresql://file/inventory
+  max_open_conns: 8
+jwt:
+  secret: file
+metrics:
+  token: file
+`)
+	config, err := Load([]string{"-config", path, "-listen-address", ":6060"}, env(map[string]string{
+		"LISTEN_ADDRESS": ":9090",
+		"JWT_SECRET":     "env",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, ":6060", config.Server.Address)
+	assert.Equal(t, 5*time.Second, config.Server.ReadTimeout)
+	assert.Equal(t, "postgresql://file/inventory", config.Database.URL)
+	assert.Equal(t, 8, config.Database.MaxOpenConns)
+	assert.Equal(t, "env", config.JWT.Secret)
+
+	config, err = Load(nil, env(map[string]string{"CONFIG_FILE": path}))
+	assert.NoError(t, err)
+	assert.Equal(t, ":7070", config.Server.Address)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	_, err := Load(nil, env(nil))
+	assert.ErrorContains(t, err, "database.url is required")
+	assert.ErrorContains(t, err, "jwt.secret is required")
+	assert.ErrorContains(t, err, "metrics.token is required unless metrics.public is set")
+
+	_, err = Load([]string{"-db-max-open-conns", "many"}, env(map[string]string{"JWT_TOKEN_TTL": "forever"}))
+	assert.ErrorContains(t, err, "JWT_TOKEN_TTL")
+	assert.ErrorContains(t, err, "-db-max-open-conns")
+
+	_, err = Load(nil, env(map[string]string{"JWT_KEYS": "jwt.pem@tomorrow"}))
+	assert.ErrorContains(t, err, "JWT_KEYS")
+
+	_, err = Load(nil, env(map[string]string{"METRICS_PUBLIC": "maybe"}))
+	assert.ErrorContains(t, err, "METRICS_PUBLIC")
+
+	_, err = Load([]string{"-unknown"}, env(nil))
+	assert.Error(t, err)
+
+	_, err = Load([]string{"-config", writeFile(t, "server:\n  adress: \":9090\"\n")}, env(nil))
+	assert.ErrorContains(t, err, "adress")
+
+	_, err = Load([]string{"-config", filepath.Join(t.TempDir(), "missing.yaml")}, env(nil))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLoad_RateLimit(t *testing.T) {
+	path := writeFile(t, `
+database:
+  url: postgresql://file/inventory
+jwt:
+  secret: file
+metrics:
+  public: true
+rate_limit:
+  store: postgres
+  groups:
+    /api/v1/invoices:
+      rate: 60
+      burst: 10
+  quotas:
+    reporting: 100000
+`)
+	config, err := Load([]string{"-config", path}, env(map[string]string{"RATE_LIMIT_RATE": "120", "RATE_LIMIT_DAILY_QUOTA": "5000"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "postgres", config.RateLimit.Store)
+	assert.Equal(t, 120, config.RateLimit.Default.Rate)
+	assert.Equal(t, Default().RateLimit.Default.Burst, config.RateLimit.Default.Burst)
+	// the groups of the file are added to the default ones
+	assert.Equal(t, Limit{Rate: 60, Burst: 10}, config.RateLimit.Groups["/api/v1/invoices"])
+	assert.Equal(t, Default().RateLimit.Groups["/api/v1/authorize"], config.RateLimit.Groups["/api/v1/authorize"])
+	assert.Equal(t, 5000, config.RateLimit.DailyQuota)
+	assert.Equal(t, map[string]int{"reporting": 100000}, config.RateLimit.Quotas)
+}
+
+func TestLoad_Services(t *testing.T) {
+	path := writeFile(t, `
+database:
+  url: postgresql://file/inventory
+jwt:
+  secret: file
+metrics:
+  public: true
+caches:
+  persons:
+    size: 0
+outbox:
+  sinks: [log, http]
+  http_url: http://localhost:9000/events
+`)
+	config, err := Load([]string{"-config", path, "-invoice-number-prefix", ""}, env(map[string]string{
+		"LOG_LEVEL":                   "debug",
+		"OTEL_TRACES_EXPORTER":        "otlp, stdout",
+		"OTEL_EXPORTER_OTLP_ENDPOINT": "https://collector.example.com:4318",
+		"ITEM_CACHE_SIZE":             "500",
+		"USER_CACHE_TTL":              "30s",
+		"READINESS_TIMEOUT":           "500ms",
+		"STREAM_BUFFER_SIZE":          "64",
+		"INVOICE_NUMBER_YEARLY_RESET": "false",
+		"INVOICE_NUMBER_PADDING":      "8",
+		"RECURRING_INVOICE_INTERVAL":  "15m",
+		"WEBHOOK_DISPATCH_INTERVAL":   "1s",
+		"WEBHOOK_MAX_ATTEMPTS":        "3",
+		"OUTBOX_RELAY_INTERVAL":       "2s",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, Logging{Level: "debug"}, config.Logging)
+	assert.Equal(t, Tracing{Exporters: []string{"otlp", "stdout"}, Endpoint: "https://collector.example.com:4318", ServiceName: "inventory-service-go"}, config.Tracing)
+	assert.Equal(t, Cache{Size: 500, TTL: 5 * time.Minute}, config.Caches.Items)
+	assert.Equal(t, Cache{Size: 0, TTL: 5 * time.Minute}, config.Caches.Persons)
+	assert.Equal(t, Cache{Size: 1000, TTL: 30 * time.Second}, config.Caches.Users)
+	assert.Equal(t, 500*time.Millisecond, config.Health.ReadinessTimeout)
+	assert.Equal(t, 64, config.Stream.BufferSize)
+	// the empty flag leaves the prefix out
+	assert.Equal(t, InvoiceNumbers{Prefix: "", YearlyReset: false, Padding: 8}, config.InvoiceNumbers)
+	assert.Equal(t, 15*time.Minute, config.Recurring.Interval)
+	assert.Equal(t, Webhooks{DispatchInterval: time.Second, MaxAttempts: 3}, config.Webhooks)
+	assert.Equal(t, Outbox{RelayInterval: 2 * time.Second, Sinks: []string{"log", "http"}, File: "outbox.jsonl", HTTPURL: "http://localhost:9000/events"}, config.Outbox)
+}
+
+func TestLoad_JWTKeys(t *testing.T) {
+	path := writeFile(t, `
+database:
+  url: postgresql://file/inventory
+jwt:
+  keys:
+    - file: keys/october.pem
+    - file: keys/november.pem
+      active_from: 2026-11-01T00:00:00Z
+  rotation_overlap: 48h
+`)
+	config, err := Load([]string{"-config", path}, env(map[string]string{"METRICS_PUBLIC": "true"}))
+	assert.NoError(t, err)
+	assert.True(t, config.Metrics.Public)
+	assert.Equal(t, "keys/october.pem", config.JWT.Keys[0].File)
+	assert.True(t, config.JWT.Keys[0].ActiveFrom.IsZero())
+	assert.Equal(t, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), config.JWT.Keys[1].ActiveFrom)
+	assert.Equal(t, 48*time.Hour, config.JWT.RotationOverlap)
+}
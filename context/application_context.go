@@ -1,10 +1,25 @@
 package context
 
 import (
+	"inventory-service-go/apikey"
 	"inventory-service-go/auth"
+	"inventory-service-go/cache"
+	"inventory-service-go/commons"
+	"inventory-service-go/config"
+	"inventory-service-go/currency"
+	"inventory-service-go/health"
 	"inventory-service-go/invoice"
 	"inventory-service-go/item"
+	"inventory-service-go/migrations"
 	"inventory-service-go/person"
+	"inventory-service-go/promotion"
+	"inventory-service-go/recurring"
+	"inventory-service-go/stream"
+	"inventory-service-go/tax"
+	"inventory-service-go/tenant"
+	"inventory-service-go/user"
+	"inventory-service-go/webhook"
+	"log/slog"
 )
 
 type ApplicationContext struct {
@@ -12,26 +27,94 @@ type ApplicationContext struct {
 	itemService    item.ItemService
 	invoiceService invoice.InvoiceService
 	authProvider   auth.AuthProvider
+
+	exchangeRateService currency.ExchangeRateService
+	taxRateService      tax.TaxRateService
+	promotionService    promotion.PromotionService
+	recurringService    recurring.RecurringInvoiceService
+	webhookService      webhook.WebhookService
+	streamBroker        *stream.Broker
+	logger              *slog.Logger
+	healthChecker       *health.Checker
+	apiKeyService       apikey.APIKeyService
+	tenantService       tenant.TenantService
 }
 
-func NewApplicationContext() ApplicationContext {
-	p, err := person.InitializePersonService()
+func NewApplicationContext(cfg config.Config) ApplicationContext {
+	p, err := person.InitializePersonService(cache.Config(cfg.Caches.Persons))
+	if err != nil {
+		panic(err)
+	}
+	i, err := item.InitializeItemService(cache.Config(cfg.Caches.Items))
+	if err != nil {
+		panic(err)
+	}
+	inv, err := invoice.InitializeInvoiceService(cache.Config(cfg.Caches.Items), cfg.InvoiceNumbers)
+	if err != nil {
+		panic(err)
+	}
+	rates, err := currency.InitializeExchangeRateService(cfg.Currency)
+	if err != nil {
+		panic(err)
+	}
+	taxRates, err := tax.InitializeTaxRateService()
+	if err != nil {
+		panic(err)
+	}
+	promotions, err := promotion.InitializePromotionService()
+	if err != nil {
+		panic(err)
+	}
+	recurringInvoices, err := recurring.InitializeRecurringInvoiceService(cache.Config(cfg.Caches.Items), cfg.InvoiceNumbers)
 	if err != nil {
 		panic(err)
 	}
-	i, err := item.InitializeItemService()
+	webhooks, err := webhook.InitializeWebhookService(cfg.Webhooks)
 	if err != nil {
 		panic(err)
 	}
-	inv, err := invoice.InitializeInvoiceService()
+	users, err := user.InitializeUserService(cache.Config(cfg.Caches.Users))
 	if err != nil {
 		panic(err)
 	}
+	apiKeys, err := apikey.InitializeAPIKeyService()
+	if err != nil {
+		panic(err)
+	}
+	tenants, err := tenant.InitializeTenantService()
+	if err != nil {
+		panic(err)
+	}
+	authProvider, err := auth.NewFromConfig(cfg.JWT, cfg.OAuth, users)
+	if err != nil {
+		panic(err)
+	}
+	latestMigration, err := migrations.Latest()
+	if err != nil {
+		panic(err)
+	}
+	db := commons.GetDB()
+	checker := health.NewChecker(cfg.Health.ReadinessTimeout)
+	checker.Add("database", health.Database(db.DB))
+	checker.Add("migrations", health.Migrations(db, latestMigration))
+	checker.Add("pool", health.Pool(db.DB))
+	broker := stream.NewBroker(cfg.Stream.BufferSize)
+	broker.Observe(p, i, inv, recurringInvoices)
 	return ApplicationContext{
-		personService:  p,
-		itemService:    i,
-		invoiceService: inv,
-		authProvider:   auth.NewAuthProvider(),
+		personService:       p,
+		itemService:         i,
+		invoiceService:      inv,
+		authProvider:        authProvider,
+		exchangeRateService: rates,
+		taxRateService:      taxRates,
+		promotionService:    promotions,
+		recurringService:    recurringInvoices,
+		webhookService:      webhooks,
+		streamBroker:        broker,
+		logger:              slog.Default(),
+		healthChecker:       checker,
+		apiKeyService:       apiKeys,
+		tenantService:       tenants,
 	}
 }
 
@@ -41,9 +124,77 @@ func MockApplicationContext(mockPersonService person.PersonService, mockItemServ
 		itemService:    mockItemService,
 		invoiceService: mockInvoiceService,
 		authProvider:   auth.NewJwtAuthProvider("dummy_secret"),
+		logger:         slog.Default(),
+		healthChecker:  health.NewChecker(health.DefaultTimeout),
 	}
 }
 
+// WithExchangeRateService returns a copy of the context using the given service, mainly for tests.
+func (a ApplicationContext) WithExchangeRateService(exchangeRateService currency.ExchangeRateService) ApplicationContext {
+	a.exchangeRateService = exchangeRateService
+	return a
+}
+
+// WithTaxRateService returns a copy of the context using the given service, mainly for tests.
+func (a ApplicationContext) WithTaxRateService(taxRateService tax.TaxRateService) ApplicationContext {
+	a.taxRateService = taxRateService
+	return a
+}
+
+// WithPromotionService returns a copy of the context using the given service, mainly for tests.
+func (a ApplicationContext) WithPromotionService(promotionService promotion.PromotionService) ApplicationContext {
+	a.promotionService = promotionService
+	return a
+}
+
+// WithRecurringInvoiceService returns a copy of the context using the given service, mainly for tests.
+func (a ApplicationContext) WithRecurringInvoiceService(recurringService recurring.RecurringInvoiceService) ApplicationContext {
+	a.recurringService = recurringService
+	return a
+}
+
+// WithWebhookService returns a copy of the context using the given service, mainly for tests.
+func (a ApplicationContext) WithWebhookService(webhookService webhook.WebhookService) ApplicationContext {
+	a.webhookService = webhookService
+	return a
+}
+
+// WithStreamBroker returns a copy of the context using the given broker, mainly for tests.
+func (a ApplicationContext) WithStreamBroker(streamBroker *stream.Broker) ApplicationContext {
+	a.streamBroker = streamBroker
+	return a
+}
+
+// WithHealthChecker returns a copy of the context using the given checker, mainly for tests.
+func (a ApplicationContext) WithHealthChecker(healthChecker *health.Checker) ApplicationContext {
+	a.healthChecker = healthChecker
+	return a
+}
+
+// WithAPIKeyService returns a copy of the context using the given service, mainly for tests.
+func (a ApplicationContext) WithAPIKeyService(apiKeyService apikey.APIKeyService) ApplicationContext {
+	a.apiKeyService = apiKeyService
+	return a
+}
+
+// WithTenantService returns a copy of the context using the given service, mainly for tests.
+func (a ApplicationContext) WithTenantService(tenantService tenant.TenantService) ApplicationContext {
+	a.tenantService = tenantService
+	return a
+}
+
+// WithAuthProvider returns a copy of the context using the given provider, mainly for tests.
+func (a ApplicationContext) WithAuthProvider(authProvider auth.AuthProvider) ApplicationContext {
+	a.authProvider = authProvider
+	return a
+}
+
+// WithLogger returns a copy of the context using the given logger.
+func (a ApplicationContext) WithLogger(logger *slog.Logger) ApplicationContext {
+	a.logger = logger
+	return a
+}
+
 func (a ApplicationContext) PersonService() person.PersonService {
 	return a.personService
 }
@@ -59,3 +210,43 @@ func (a ApplicationContext) ItemService() item.ItemService {
 func (a ApplicationContext) InvoiceService() invoice.InvoiceService {
 	return a.invoiceService
 }
+
+func (a ApplicationContext) ExchangeRateService() currency.ExchangeRateService {
+	return a.exchangeRateService
+}
+
+func (a ApplicationContext) TaxRateService() tax.TaxRateService {
+	return a.taxRateService
+}
+
+func (a ApplicationContext) PromotionService() promotion.PromotionService {
+	return a.promotionService
+}
+
+func (a ApplicationContext) RecurringInvoiceService() recurring.RecurringInvoiceService {
+	return a.recurringService
+}
+
+func (a ApplicationContext) WebhookService() webhook.WebhookService {
+	return a.webhookService
+}
+
+func (a ApplicationContext) StreamBroker() *stream.Broker {
+	return a.streamBroker
+}
+
+func (a ApplicationContext) Logger() *slog.Logger {
+	return a.logger
+}
+
+func (a ApplicationContext) HealthChecker() *health.Checker {
+	return a.healthChecker
+}
+
+func (a ApplicationContext) APIKeyService() apikey.APIKeyService {
+	return a.apiKeyService
+}
+
+func (a ApplicationContext) TenantService() tenant.TenantService {
+	return a.tenantService
+}
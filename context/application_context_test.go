@@ -2,15 +2,29 @@ package context
 
 import (
 	"go.uber.org/mock/gomock"
+	"inventory-service-go/apikey"
 	"inventory-service-go/auth"
+	"inventory-service-go/config"
+	"inventory-service-go/currency"
+	"inventory-service-go/health"
 	"inventory-service-go/invoice"
 	"inventory-service-go/item"
 	"inventory-service-go/person"
+	"inventory-service-go/promotion"
+	"inventory-service-go/recurring"
+	"inventory-service-go/stream"
+	"inventory-service-go/tax"
+	"inventory-service-go/webhook"
+	"io"
+	"log/slog"
 	"testing"
+	"time"
 )
 
 func TestNewApplicationContext(t *testing.T) {
-	appCtx := NewApplicationContext()
+	cfg := config.Default()
+	cfg.JWT.Secret = "secret"
+	appCtx := NewApplicationContext(cfg)
 	if _, ok := appCtx.AuthProvider().(auth.AuthProvider); !ok {
 		t.Error("AuthProvider should be of type auth.AuthProvider")
 	}
@@ -23,6 +37,30 @@ func TestNewApplicationContext(t *testing.T) {
 	if _, ok := appCtx.InvoiceService().(invoice.InvoiceService); !ok {
 		t.Error("InvoiceService should be of type invoice.InvoiceService")
 	}
+	if _, ok := appCtx.ExchangeRateService().(currency.ExchangeRateService); !ok {
+		t.Error("ExchangeRateService should be of type currency.ExchangeRateService")
+	}
+	if _, ok := appCtx.TaxRateService().(tax.TaxRateService); !ok {
+		t.Error("TaxRateService should be of type tax.TaxRateService")
+	}
+	if _, ok := appCtx.PromotionService().(promotion.PromotionService); !ok {
+		t.Error("PromotionService should be of type promotion.PromotionService")
+	}
+	if _, ok := appCtx.RecurringInvoiceService().(recurring.RecurringInvoiceService); !ok {
+		t.Error("RecurringInvoiceService should be of type recurring.RecurringInvoiceService")
+	}
+	if _, ok := appCtx.WebhookService().(webhook.WebhookService); !ok {
+		t.Error("WebhookService should be of type webhook.WebhookService")
+	}
+	if appCtx.StreamBroker() == nil {
+		t.Error("StreamBroker should be set")
+	}
+	if appCtx.HealthChecker() == nil {
+		t.Error("HealthChecker should be set")
+	}
+	if _, ok := appCtx.APIKeyService().(apikey.APIKeyService); !ok {
+		t.Error("APIKeyService should be of type apikey.APIKeyService")
+	}
 }
 
 func TestMockApplicationContext(t *testing.T) {
@@ -43,4 +81,47 @@ func TestMockApplicationContext(t *testing.T) {
 	if _, ok := appCtx.InvoiceService().(invoice.InvoiceService); !ok {
 		t.Error("InvoiceService should be of type invoice.InvoiceService")
 	}
+	appCtx = appCtx.WithExchangeRateService(currency.NewMockExchangeRateService(controller))
+	if _, ok := appCtx.ExchangeRateService().(*currency.MockExchangeRateService); !ok {
+		t.Error("ExchangeRateService should be of type *currency.MockExchangeRateService")
+	}
+	appCtx = appCtx.WithTaxRateService(tax.NewMockTaxRateService(controller))
+	if _, ok := appCtx.TaxRateService().(*tax.MockTaxRateService); !ok {
+		t.Error("TaxRateService should be of type *tax.MockTaxRateService")
+	}
+	appCtx = appCtx.WithPromotionService(promotion.NewMockPromotionService(controller))
+	if _, ok := appCtx.PromotionService().(*promotion.MockPromotionService); !ok {
+		t.Error("PromotionService should be of type *promotion.MockPromotionService")
+	}
+	appCtx = appCtx.WithRecurringInvoiceService(recurring.NewMockRecurringInvoiceService(controller))
+	if _, ok := appCtx.RecurringInvoiceService().(*recurring.MockRecurringInvoiceService); !ok {
+		t.Error("RecurringInvoiceService should be of type *recurring.MockRecurringInvoiceService")
+	}
+	appCtx = appCtx.WithWebhookService(webhook.NewMockWebhookService(controller))
+	if _, ok := appCtx.WebhookService().(*webhook.MockWebhookService); !ok {
+		t.Error("WebhookService should be of type *webhook.MockWebhookService")
+	}
+	broker := stream.NewBroker(10)
+	appCtx = appCtx.WithStreamBroker(broker)
+	if appCtx.StreamBroker() != broker {
+		t.Error("StreamBroker should be the given broker")
+	}
+	if appCtx.Logger() != slog.Default() {
+		t.Error("Logger should default to the default logger")
+	}
+	checker := health.NewChecker(time.Second)
+	appCtx = appCtx.WithHealthChecker(checker)
+	if appCtx.HealthChecker() != checker {
+		t.Error("HealthChecker should be the given checker")
+	}
+	provider := auth.NewJwtAuthProvider("other_secret")
+	appCtx = appCtx.WithAuthProvider(provider)
+	if appCtx.AuthProvider() != provider {
+		t.Error("AuthProvider should be the given provider")
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	appCtx = appCtx.WithLogger(logger)
+	if appCtx.Logger() != logger {
+		t.Error("Logger should be the given logger")
+	}
 }
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source repository.go -destination mock_repository.go -package currency
+//
+
+// Package currency is a generated GoMock package.
+package currency
+
+import (
+	commons "inventory-service-go/commons"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExchangeRateRepository is a mock of ExchangeRateRepository interface.
+type MockExchangeRateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateRepositoryMockRecorder
+}
+
+// MockExchangeRateRepositoryMockRecorder is the mock recorder for MockExchangeRateRepository.
+type MockExchangeRateRepositoryMockRecorder struct {
+	mock *MockExchangeRateRepository
+}
+
+// NewMockExchangeRateRepository creates a new mock instance.
+func NewMockExchangeRateRepository(ctrl *gomock.Controller) *MockExchangeRateRepository {
+	mock := &MockExchangeRateRepository{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRateRepository) EXPECT() *MockExchangeRateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRate mocks base method.
+func (m *MockExchangeRateRepository) CreateRate(request CreateExchangeRateRequest) (ExchangeRateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRate", request)
+	ret0, _ := ret[0].(ExchangeRateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRate indicates an expected call of CreateRate.
+func (mr *MockExchangeRateRepositoryMockRecorder) CreateRate(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRate", reflect.TypeOf((*MockExchangeRateRepository)(nil).CreateRate), request)
+}
+
+// DeleteRate mocks base method.
+func (m *MockExchangeRateRepository) DeleteRate(id uuid.UUID) (commons.DeleteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRate", id)
+	ret0, _ := ret[0].(commons.DeleteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRate indicates an expected call of DeleteRate.
+func (mr *MockExchangeRateRepositoryMockRecorder) DeleteRate(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRate", reflect.TypeOf((*MockExchangeRateRepository)(nil).DeleteRate), id)
+}
+
+// GetEffectiveRate mocks base method.
+func (m *MockExchangeRateRepository) GetEffectiveRate(from, to string, on commons.Date) (ExchangeRateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectiveRate", from, to, on)
+	ret0, _ := ret[0].(ExchangeRateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffectiveRate indicates an expected call of GetEffectiveRate.
+func (mr *MockExchangeRateRepositoryMockRecorder) GetEffectiveRate(from, to, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectiveRate", reflect.TypeOf((*MockExchangeRateRepository)(nil).GetEffectiveRate), from, to, on)
+}
+
+// GetRate mocks base method.
+func (m *MockExchangeRateRepository) GetRate(id uuid.UUID) (ExchangeRateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRate", id)
+	ret0, _ := ret[0].(ExchangeRateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRate indicates an expected call of GetRate.
+func (mr *MockExchangeRateRepositoryMockRecorder) GetRate(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRate", reflect.TypeOf((*MockExchangeRateRepository)(nil).GetRate), id)
+}
+
+// GetRates mocks base method.
+func (m *MockExchangeRateRepository) GetRates(pagination *commons.Pagination) ([]ExchangeRateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRates", pagination)
+	ret0, _ := ret[0].([]ExchangeRateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRates indicates an expected call of GetRates.
+func (mr *MockExchangeRateRepositoryMockRecorder) GetRates(pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockExchangeRateRepository)(nil).GetRates), pagination)
+}
+
+// UpdateRate mocks base method.
+func (m *MockExchangeRateRepository) UpdateRate(request UpdateExchangeRateRequest) (ExchangeRateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRate", request)
+	ret0, _ := ret[0].(ExchangeRateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRate indicates an expected call of UpdateRate.
+func (mr *MockExchangeRateRepositoryMockRecorder) UpdateRate(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRate", reflect.TypeOf((*MockExchangeRateRepository)(nil).UpdateRate), request)
+}
@@ -0,0 +1,199 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source service.go -destination mock_service.go -package currency
+//
+
+// Package currency is a generated GoMock package.
+package currency
+
+import (
+	commons "inventory-service-go/commons"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConverter is a mock of Converter interface.
+type MockConverter struct {
+	ctrl     *gomock.Controller
+	recorder *MockConverterMockRecorder
+}
+
+// MockConverterMockRecorder is the mock recorder for MockConverter.
+type MockConverterMockRecorder struct {
+	mock *MockConverter
+}
+
+// NewMockConverter creates a new mock instance.
+func NewMockConverter(ctrl *gomock.Controller) *MockConverter {
+	mock := &MockConverter{ctrl: ctrl}
+	mock.recorder = &MockConverterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConverter) EXPECT() *MockConverterMockRecorder {
+	return m.recorder
+}
+
+// Convert mocks base method.
+func (m *MockConverter) Convert(amount commons.Money, to string, on time.Time) (commons.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Convert", amount, to, on)
+	ret0, _ := ret[0].(commons.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Convert indicates an expected call of Convert.
+func (mr *MockConverterMockRecorder) Convert(amount, to, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Convert", reflect.TypeOf((*MockConverter)(nil).Convert), amount, to, on)
+}
+
+// MockExchangeRateService is a mock of ExchangeRateService interface.
+type MockExchangeRateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateServiceMockRecorder
+}
+
+// MockExchangeRateServiceMockRecorder is the mock recorder for MockExchangeRateService.
+type MockExchangeRateServiceMockRecorder struct {
+	mock *MockExchangeRateService
+}
+
+// NewMockExchangeRateService creates a new mock instance.
+func NewMockExchangeRateService(ctrl *gomock.Controller) *MockExchangeRateService {
+	mock := &MockExchangeRateService{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRateService) EXPECT() *MockExchangeRateServiceMockRecorder {
+	return m.recorder
+}
+
+// BaseCurrency mocks base method.
+func (m *MockExchangeRateService) BaseCurrency() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseCurrency")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseCurrency indicates an expected call of BaseCurrency.
+func (mr *MockExchangeRateServiceMockRecorder) BaseCurrency() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseCurrency", reflect.TypeOf((*MockExchangeRateService)(nil).BaseCurrency))
+}
+
+// Convert mocks base method.
+func (m *MockExchangeRateService) Convert(amount commons.Money, to string, on time.Time) (commons.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Convert", amount, to, on)
+	ret0, _ := ret[0].(commons.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Convert indicates an expected call of Convert.
+func (mr *MockExchangeRateServiceMockRecorder) Convert(amount, to, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Convert", reflect.TypeOf((*MockExchangeRateService)(nil).Convert), amount, to, on)
+}
+
+// CreateRate mocks base method.
+func (m *MockExchangeRateService) CreateRate(request CreateExchangeRateRequest) (ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRate", request)
+	ret0, _ := ret[0].(ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRate indicates an expected call of CreateRate.
+func (mr *MockExchangeRateServiceMockRecorder) CreateRate(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRate", reflect.TypeOf((*MockExchangeRateService)(nil).CreateRate), request)
+}
+
+// DeleteRate mocks base method.
+func (m *MockExchangeRateService) DeleteRate(id uuid.UUID) (commons.DeleteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRate", id)
+	ret0, _ := ret[0].(commons.DeleteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRate indicates an expected call of DeleteRate.
+func (mr *MockExchangeRateServiceMockRecorder) DeleteRate(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRate", reflect.TypeOf((*MockExchangeRateService)(nil).DeleteRate), id)
+}
+
+// GetRate mocks base method.
+func (m *MockExchangeRateService) GetRate(id uuid.UUID) (ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRate", id)
+	ret0, _ := ret[0].(ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRate indicates an expected call of GetRate.
+func (mr *MockExchangeRateServiceMockRecorder) GetRate(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRate", reflect.TypeOf((*MockExchangeRateService)(nil).GetRate), id)
+}
+
+// GetRates mocks base method.
+func (m *MockExchangeRateService) GetRates(pagination *commons.Pagination) ([]ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRates", pagination)
+	ret0, _ := ret[0].([]ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRates indicates an expected call of GetRates.
+func (mr *MockExchangeRateServiceMockRecorder) GetRates(pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockExchangeRateService)(nil).GetRates), pagination)
+}
+
+// Normalize mocks base method.
+func (m *MockExchangeRateService) Normalize(amount commons.Money, on time.Time) (commons.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Normalize", amount, on)
+	ret0, _ := ret[0].(commons.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Normalize indicates an expected call of Normalize.
+func (mr *MockExchangeRateServiceMockRecorder) Normalize(amount, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Normalize", reflect.TypeOf((*MockExchangeRateService)(nil).Normalize), amount, on)
+}
+
+// UpdateRate mocks base method.
+func (m *MockExchangeRateService) UpdateRate(request UpdateExchangeRateRequest) (ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRate", request)
+	ret0, _ := ret[0].(ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRate indicates an expected call of UpdateRate.
+func (mr *MockExchangeRateServiceMockRecorder) UpdateRate(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRate", reflect.TypeOf((*MockExchangeRateService)(nil).UpdateRate), request)
+}
@@ -0,0 +1,117 @@
+package currency
+
+import (
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"inventory-service-go/commons"
+	"inventory-service-go/metrics"
+	"time"
+)
+
+type ExchangeRateRow struct {
+	Id            int64        `db:"id"`
+	AltId         uuid.UUID    `db:"alt_id"`
+	FromCurrency  string       `db:"from_currency"`
+	ToCurrency    string       `db:"to_currency"`
+	Rate          string       `db:"rate"`
+	EffectiveDate commons.Date `db:"effective_date"`
+	CreatedBy     string       `db:"created_by"`
+	CreatedAt     time.Time    `db:"created_at"`
+	LastChangedBy string       `db:"last_changed_by"`
+	LastUpdate    time.Time    `db:"last_update"`
+}
+
+type CreateExchangeRateRequest struct {
+	FromCurrency  string       `json:"from_currency"`
+	ToCurrency    string       `json:"to_currency"`
+	Rate          string       `json:"rate"`
+	EffectiveDate commons.Date `json:"effective_date"`
+	CreatedBy     string       `json:"created_by" audit:"actor"`
+}
+
+type UpdateExchangeRateRequest struct {
+	Id            uuid.UUID    `json:"id"`
+	Rate          string       `json:"rate"`
+	EffectiveDate commons.Date `json:"effective_date"`
+	LastChangedBy string       `json:"last_changed_by" audit:"actor"`
+}
+
+const (
+	CreateRateQuery            = `INSERT INTO exchange_rates (from_currency, to_currency, rate, effective_date, created_by, last_changed_by) VALUES ($1, $2, $3, $4, $5, $5) RETURNING *`
+	UpdateRateQuery            = `UPDATE exchange_rates SET rate = $2, effective_date = $3, last_changed_by = $4, last_update = now() WHERE alt_id = $1 RETURNING *`
+	DeleteRateQuery            = `DELETE FROM exchange_rates WHERE alt_id = $1`
+	GetRateQuery               = `SELECT * FROM exchange_rates WHERE alt_id = $1`
+	GetAllRatesQuery           = `SELECT * FROM exchange_rates ORDER BY id`
+	GetAllRatesPaginationQuery = `SELECT * FROM exchange_rates WHERE id > $1 ORDER BY id LIMIT $2`
+	GetEffectiveRateQuery      = `SELECT * FROM exchange_rates WHERE from_currency = $1 AND to_currency = $2 AND effective_date <= $3 ORDER BY effective_date DESC LIMIT 1`
+)
+
+type ExchangeRateRepository interface {
+	CreateRate(request CreateExchangeRateRequest) (ExchangeRateRow, error)
+	UpdateRate(request UpdateExchangeRateRequest) (ExchangeRateRow, error)
+	DeleteRate(id uuid.UUID) (commons.DeleteResult, error)
+	GetRate(id uuid.UUID) (ExchangeRateRow, error)
+	GetRates(pagination *commons.Pagination) ([]ExchangeRateRow, error)
+	GetEffectiveRate(from string, to string, on commons.Date) (ExchangeRateRow, error)
+}
+
+type ExchangeRateRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewExchangeRateRepository(db *sqlx.DB) *ExchangeRateRepositoryImpl {
+	return &ExchangeRateRepositoryImpl{db: db}
+}
+
+func (r *ExchangeRateRepositoryImpl) CreateRate(request CreateExchangeRateRequest) (ExchangeRateRow, error) {
+	defer metrics.ObserveQuery("currency", "CreateRate")()
+	var row ExchangeRateRow
+	err := r.db.Get(&row, CreateRateQuery, request.FromCurrency, request.ToCurrency, request.Rate, request.EffectiveDate, request.CreatedBy)
+	return row, err
+}
+
+func (r *ExchangeRateRepositoryImpl) UpdateRate(request UpdateExchangeRateRequest) (ExchangeRateRow, error) {
+	defer metrics.ObserveQuery("currency", "UpdateRate")()
+	var row ExchangeRateRow
+	err := r.db.Get(&row, UpdateRateQuery, request.Id, request.Rate, request.EffectiveDate, request.LastChangedBy)
+	return row, err
+}
+
+func (r *ExchangeRateRepositoryImpl) DeleteRate(id uuid.UUID) (commons.DeleteResult, error) {
+	defer metrics.ObserveQuery("currency", "DeleteRate")()
+	result, err := r.db.Exec(DeleteRateQuery, id)
+	if err != nil {
+		return commons.DeleteResult{}, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return commons.DeleteResult{
+		Id:      id,
+		Deleted: rowsAffected > 0,
+	}, nil
+}
+
+func (r *ExchangeRateRepositoryImpl) GetRate(id uuid.UUID) (ExchangeRateRow, error) {
+	defer metrics.ObserveQuery("currency", "GetRate")()
+	var row ExchangeRateRow
+	err := r.db.Get(&row, GetRateQuery, id)
+	return row, err
+}
+
+func (r *ExchangeRateRepositoryImpl) GetRates(pagination *commons.Pagination) ([]ExchangeRateRow, error) {
+	defer metrics.ObserveQuery("currency", "GetRates")()
+	var rows []ExchangeRateRow
+	var err error
+	if pagination == nil {
+		err = r.db.Select(&rows, GetAllRatesQuery)
+	} else {
+		err = r.db.Select(&rows, GetAllRatesPaginationQuery, pagination.LastId, pagination.PageSize)
+	}
+	return rows, err
+}
+
+func (r *ExchangeRateRepositoryImpl) GetEffectiveRate(from string, to string, on commons.Date) (ExchangeRateRow, error) {
+	defer metrics.ObserveQuery("currency", "GetEffectiveRate")()
+	var row ExchangeRateRow
+	err := r.db.Get(&row, GetEffectiveRateQuery, from, to, on)
+	return row, err
+}
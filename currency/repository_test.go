@@ -0,0 +1,155 @@
+package currency
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+var exchangeRateColumns = []string{"id", "alt_id", "from_currency", "to_currency", "rate", "effective_date", "created_by", "created_at", "last_changed_by", "last_update"}
+
+func TestExchangeRateRepositoryImpl_CreateRate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	newUuid := uuid.New()
+	request := CreateExchangeRateRequest{
+		FromCurrency:  "USD",
+		ToCurrency:    "EUR",
+		Rate:          "0.92",
+		EffectiveDate: commons.NewDate(2026, time.January, 1),
+		CreatedBy:     "unit_test",
+	}
+	testCases := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"Successful Rate Creation", false},
+		{"Failed Rate Creation", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expectation := mock.ExpectQuery("INSERT INTO exchange_rates").
+				WithArgs(request.FromCurrency, request.ToCurrency, request.Rate, "2026-01-01", request.CreatedBy)
+			if tc.wantErr {
+				expectation.WillReturnError(errors.New("error"))
+			} else {
+				expectation.WillReturnRows(sqlmock.NewRows(exchangeRateColumns).
+					AddRow(1, newUuid, "USD", "EUR", "0.92000000", now, "unit_test", now, "unit_test", now))
+			}
+
+			r := NewExchangeRateRepository(sqlx.NewDb(db, "mockDb"))
+
+			result, err := r.CreateRate(request)
+			if tc.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, newUuid, result.AltId)
+				assert.Equal(t, "0.92000000", result.Rate)
+				assert.Equal(t, commons.DateOf(now), result.EffectiveDate)
+			}
+		})
+	}
+}
+
+func TestExchangeRateRepositoryImpl_UpdateRate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	request := UpdateExchangeRateRequest{
+		Id:            uuid.New(),
+		Rate:          "0.93",
+		EffectiveDate: commons.NewDate(2026, time.February, 1),
+		LastChangedBy: "unit_test",
+	}
+	mock.ExpectQuery("UPDATE exchange_rates").
+		WithArgs(request.Id, request.Rate, "2026-02-01", request.LastChangedBy).
+		WillReturnRows(sqlmock.NewRows(exchangeRateColumns).
+			AddRow(1, request.Id, "USD", "EUR", "0.93000000", "2026-02-01", "unit_test", now, "unit_test", now))
+
+	r := NewExchangeRateRepository(sqlx.NewDb(db, "mockDb"))
+
+	result, err := r.UpdateRate(request)
+	assert.Nil(t, err)
+	assert.Equal(t, request.Id, result.AltId)
+	assert.Equal(t, request.EffectiveDate, result.EffectiveDate)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateRepositoryImpl_DeleteRate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	id := uuid.New()
+	r := NewExchangeRateRepository(sqlx.NewDb(db, "mockDb"))
+
+	mock.ExpectExec("DELETE FROM exchange_rates").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+	result, err := r.DeleteRate(id)
+	assert.Nil(t, err)
+	assert.Equal(t, commons.DeleteResult{Id: id, Deleted: true}, result)
+
+	mock.ExpectExec("DELETE FROM exchange_rates").WithArgs(id).WillReturnError(errors.New("error"))
+	_, err = r.DeleteRate(id)
+	assert.NotNil(t, err)
+}
+
+func TestExchangeRateRepositoryImpl_GetRates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(exchangeRateColumns).
+			AddRow(1, uuid.New(), "USD", "EUR", "0.92", now, "unit_test", now, "unit_test", now).
+			AddRow(2, uuid.New(), "GBP", "USD", "1.27", now, "unit_test", now, "unit_test", now)
+	}
+	r := NewExchangeRateRepository(sqlx.NewDb(db, "mockDb"))
+
+	mock.ExpectQuery("SELECT \\* FROM exchange_rates ORDER BY id").WillReturnRows(rows())
+	results, err := r.GetRates(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(results))
+
+	mock.ExpectQuery("SELECT \\* FROM exchange_rates WHERE id > \\$1").WithArgs(0, 10).WillReturnRows(rows())
+	results, err = r.GetRates(&commons.Pagination{LastId: 0, PageSize: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(results))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestExchangeRateRepositoryImpl_GetEffectiveRate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	id := uuid.New()
+	on := commons.NewDate(2026, time.March, 15)
+	mock.ExpectQuery("SELECT \\* FROM exchange_rates WHERE from_currency = \\$1 AND to_currency = \\$2 AND effective_date <= \\$3").
+		WithArgs("USD", "EUR", "2026-03-15").
+		WillReturnRows(sqlmock.NewRows(exchangeRateColumns).
+			AddRow(1, id, "USD", "EUR", "0.92", "2026-03-01", "unit_test", now, "unit_test", now))
+
+	r := NewExchangeRateRepository(sqlx.NewDb(db, "mockDb"))
+
+	result, err := r.GetEffectiveRate("USD", "EUR", on)
+	assert.Nil(t, err)
+	assert.Equal(t, id, result.AltId)
+	assert.Equal(t, commons.NewDate(2026, time.March, 1), result.EffectiveDate)
+
+	mock.ExpectQuery("SELECT \\* FROM exchange_rates WHERE alt_id = \\$1").WithArgs(id).WillReturnError(errors.New("error"))
+	_, err = r.GetRate(id)
+	assert.NotNil(t, err)
+}
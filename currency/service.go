@@ -0,0 +1,203 @@
+package currency
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/config"
+	"math/big"
+	"time"
+)
+
+var (
+	ErrInvalidRate    = commons.NewValidationError("invalid exchange rate")
+	ErrNoExchangeRate = commons.NewValidationError("no exchange rate")
+)
+
+type ExchangeRate struct {
+	Seq           int               `json:"seq"`
+	Id            uuid.UUID         `json:"id"`
+	FromCurrency  string            `json:"from_currency"`
+	ToCurrency    string            `json:"to_currency"`
+	Rate          string            `json:"rate"`
+	EffectiveDate commons.Date      `json:"effective_date"`
+	AuditInfo     commons.AuditInfo `json:"audit_info"`
+}
+
+func fromRow(row ExchangeRateRow) ExchangeRate {
+	return ExchangeRate{
+		Seq:           int(row.Id),
+		Id:            row.AltId,
+		FromCurrency:  row.FromCurrency,
+		ToCurrency:    row.ToCurrency,
+		Rate:          row.Rate,
+		EffectiveDate: row.EffectiveDate,
+		AuditInfo: commons.AuditInfo{
+			CreatedBy:      row.CreatedBy,
+			CreatedAt:      row.CreatedAt.Format(time.RFC3339),
+			LastChangedBy:  row.LastChangedBy,
+			LastUpdate:     row.LastUpdate.Format(time.RFC3339),
+			CreatedTime:    row.CreatedAt,
+			LastUpdateTime: row.LastUpdate,
+		},
+	}
+}
+
+// Converter converts amounts between currencies using the rate in effect on a given day.
+type Converter interface {
+	Convert(amount commons.Money, to string, on time.Time) (commons.Money, error)
+}
+
+type ExchangeRateService interface {
+	Converter
+	CreateRate(request CreateExchangeRateRequest) (ExchangeRate, error)
+	UpdateRate(request UpdateExchangeRateRequest) (ExchangeRate, error)
+	DeleteRate(id uuid.UUID) (commons.DeleteResult, error)
+	GetRate(id uuid.UUID) (ExchangeRate, error)
+	GetRates(pagination *commons.Pagination) ([]ExchangeRate, error)
+	// Normalize converts an amount into the base currency reports are expressed in.
+	Normalize(amount commons.Money, on time.Time) (commons.Money, error)
+	BaseCurrency() string
+}
+
+type ExchangeRateServiceImpl struct {
+	repo         ExchangeRateRepository
+	baseCurrency string
+}
+
+// NewExchangeRateService normalizes to commons.DefaultCurrency, which does for the services only converting amounts.
+func NewExchangeRateService(repo ExchangeRateRepository) *ExchangeRateServiceImpl {
+	return &ExchangeRateServiceImpl{
+		repo:         repo,
+		baseCurrency: commons.DefaultCurrency,
+	}
+}
+
+// NewFromConfig normalizes to the configured base currency.
+func NewFromConfig(repo ExchangeRateRepository, settings config.Currency) (*ExchangeRateServiceImpl, error) {
+	baseCurrency, err := commons.NormalizeCurrency(settings.Base)
+	if err != nil {
+		return nil, err
+	}
+	return &ExchangeRateServiceImpl{
+		repo:         repo,
+		baseCurrency: baseCurrency,
+	}, nil
+}
+
+func parseRate(rate string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok || r.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRate, rate)
+	}
+	return r, nil
+}
+
+func (s *ExchangeRateServiceImpl) CreateRate(request CreateExchangeRateRequest) (ExchangeRate, error) {
+	from, err := commons.NormalizeCurrency(request.FromCurrency)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+	to, err := commons.NormalizeCurrency(request.ToCurrency)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+	if from == to {
+		return ExchangeRate{}, fmt.Errorf("%w: %s to %s", ErrInvalidRate, from, to)
+	}
+	if _, err := parseRate(request.Rate); err != nil {
+		return ExchangeRate{}, err
+	}
+	request.FromCurrency, request.ToCurrency = from, to
+	row, err := s.repo.CreateRate(request)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *ExchangeRateServiceImpl) UpdateRate(request UpdateExchangeRateRequest) (ExchangeRate, error) {
+	if _, err := parseRate(request.Rate); err != nil {
+		return ExchangeRate{}, err
+	}
+	row, err := s.repo.UpdateRate(request)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *ExchangeRateServiceImpl) DeleteRate(id uuid.UUID) (commons.DeleteResult, error) {
+	return s.repo.DeleteRate(id)
+}
+
+func (s *ExchangeRateServiceImpl) GetRate(id uuid.UUID) (ExchangeRate, error) {
+	row, err := s.repo.GetRate(id)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *ExchangeRateServiceImpl) GetRates(pagination *commons.Pagination) ([]ExchangeRate, error) {
+	rows, err := s.repo.GetRates(pagination)
+	if err != nil {
+		return nil, err
+	}
+	rates := make([]ExchangeRate, len(rows))
+	for i, row := range rows {
+		rates[i] = fromRow(row)
+	}
+	return rates, nil
+}
+
+// Convert uses the latest from -> to rate effective on the day, falling back to the inverse of a to -> from rate.
+func (s *ExchangeRateServiceImpl) Convert(amount commons.Money, to string, on time.Time) (commons.Money, error) {
+	to, err := commons.NormalizeCurrency(to)
+	if err != nil {
+		return commons.Money{}, err
+	}
+	from, err := commons.NormalizeCurrency(amount.Currency)
+	if err != nil {
+		return commons.Money{}, err
+	}
+	if from == to {
+		return commons.NewMoney(amount.MinorUnits, to), nil
+	}
+	rate, err := s.effectiveRate(from, to, commons.DateOf(on))
+	if err != nil {
+		return commons.Money{}, err
+	}
+	return amount.Convert(rate, to)
+}
+
+func (s *ExchangeRateServiceImpl) effectiveRate(from string, to string, on commons.Date) (*big.Rat, error) {
+	row, err := s.repo.GetEffectiveRate(from, to, on)
+	if err == nil {
+		return parseRate(row.Rate)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	inverse, err := s.repo.GetEffectiveRate(to, from, on)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s to %s on %s", ErrNoExchangeRate, from, to, on)
+	} else if err != nil {
+		return nil, err
+	}
+	rate, err := parseRate(inverse.Rate)
+	if err != nil {
+		return nil, err
+	}
+	return rate.Inv(rate), nil
+}
+
+func (s *ExchangeRateServiceImpl) Normalize(amount commons.Money, on time.Time) (commons.Money, error) {
+	return s.Convert(amount, s.baseCurrency, on)
+}
+
+func (s *ExchangeRateServiceImpl) BaseCurrency() string {
+	return s.baseCurrency
+}
@@ -0,0 +1,218 @@
+package currency
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/config"
+	"testing"
+	"time"
+)
+
+func TestExchangeRateService_CreateRate(t *testing.T) {
+	now := time.Now()
+	row := ExchangeRateRow{
+		Id:            1,
+		AltId:         uuid.New(),
+		FromCurrency:  "USD",
+		ToCurrency:    "EUR",
+		Rate:          "0.92",
+		EffectiveDate: commons.NewDate(2026, time.January, 1),
+		CreatedBy:     "unit_test",
+		CreatedAt:     now,
+		LastChangedBy: "unit_test",
+		LastUpdate:    now,
+	}
+	testCases := []struct {
+		name     string
+		request  CreateExchangeRateRequest
+		want     ExchangeRate
+		wantErr  error
+		mockFunc func(mockRepo *MockExchangeRateRepository)
+	}{
+		{
+			name:    "Create Rate Successfully",
+			request: CreateExchangeRateRequest{FromCurrency: "usd", ToCurrency: "eur", Rate: "0.92", EffectiveDate: row.EffectiveDate, CreatedBy: "unit_test"},
+			want:    fromRow(row),
+			mockFunc: func(mockRepo *MockExchangeRateRepository) {
+				mockRepo.EXPECT().CreateRate(CreateExchangeRateRequest{FromCurrency: "USD", ToCurrency: "EUR", Rate: "0.92", EffectiveDate: row.EffectiveDate, CreatedBy: "unit_test"}).Return(row, nil)
+			},
+		},
+		{
+			name:     "Unsupported Currency",
+			request:  CreateExchangeRateRequest{FromCurrency: "USD", ToCurrency: "JPY", Rate: "150"},
+			wantErr:  commons.ErrUnsupportedCurrency,
+			mockFunc: func(mockRepo *MockExchangeRateRepository) {},
+		},
+		{
+			name:     "Same Currency",
+			request:  CreateExchangeRateRequest{FromCurrency: "USD", ToCurrency: "USD", Rate: "1"},
+			wantErr:  ErrInvalidRate,
+			mockFunc: func(mockRepo *MockExchangeRateRepository) {},
+		},
+		{
+			name:     "Invalid Rate",
+			request:  CreateExchangeRateRequest{FromCurrency: "USD", ToCurrency: "EUR", Rate: "-0.92"},
+			wantErr:  ErrInvalidRate,
+			mockFunc: func(mockRepo *MockExchangeRateRepository) {},
+		},
+	}
+	controller := gomock.NewController(t)
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := NewMockExchangeRateRepository(controller)
+			tt.mockFunc(mockRepo)
+			service := NewExchangeRateService(mockRepo)
+			result, err := service.CreateRate(tt.request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, result)
+			}
+		})
+	}
+}
+
+func TestExchangeRateService_UpdateRate(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := NewMockExchangeRateRepository(controller)
+	service := NewExchangeRateService(mockRepo)
+	request := UpdateExchangeRateRequest{Id: uuid.New(), Rate: "0.93", EffectiveDate: commons.NewDate(2026, time.February, 1)}
+
+	mockRepo.EXPECT().UpdateRate(request).Return(ExchangeRateRow{Id: 1, AltId: request.Id, Rate: "0.93"}, nil)
+	result, err := service.UpdateRate(request)
+	assert.NoError(t, err)
+	assert.Equal(t, request.Id, result.Id)
+
+	request.Rate = "zero"
+	_, err = service.UpdateRate(request)
+	assert.ErrorIs(t, err, ErrInvalidRate)
+}
+
+func TestExchangeRateService_GetRates(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := NewMockExchangeRateRepository(controller)
+	service := NewExchangeRateService(mockRepo)
+	id := uuid.New()
+
+	mockRepo.EXPECT().GetRates(nil).Return([]ExchangeRateRow{{Id: 1, AltId: id}}, nil)
+	results, err := service.GetRates(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, id, results[0].Id)
+
+	mockRepo.EXPECT().GetRates(nil).Return(nil, errors.New("Repo Error"))
+	_, err = service.GetRates(nil)
+	assert.Error(t, err)
+
+	mockRepo.EXPECT().GetRate(id).Return(ExchangeRateRow{}, sql.ErrNoRows)
+	_, err = service.GetRate(id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	mockRepo.EXPECT().DeleteRate(id).Return(commons.DeleteResult{Id: id, Deleted: true}, nil)
+	deleted, err := service.DeleteRate(id)
+	assert.NoError(t, err)
+	assert.True(t, deleted.Deleted)
+}
+
+func TestExchangeRateService_Convert(t *testing.T) {
+	on := time.Date(2026, time.March, 15, 13, 30, 0, 0, time.UTC)
+	day := commons.NewDate(2026, time.March, 15)
+	testCases := []struct {
+		name     string
+		amount   commons.Money
+		to       string
+		want     commons.Money
+		wantErr  error
+		mockFunc func(mockRepo *MockExchangeRateRepository)
+	}{
+		{
+			name:     "Same Currency",
+			amount:   commons.NewMoney(1099, "USD"),
+			to:       "USD",
+			want:     commons.NewMoney(1099, "USD"),
+			mockFunc: func(mockRepo *MockExchangeRateRepository) {},
+		},
+		{
+			name:   "Direct Rate",
+			amount: commons.NewMoney(1099, "USD"),
+			to:     "EUR",
+			want:   commons.NewMoney(1011, "EUR"),
+			mockFunc: func(mockRepo *MockExchangeRateRepository) {
+				mockRepo.EXPECT().GetEffectiveRate("USD", "EUR", day).Return(ExchangeRateRow{Rate: "0.92"}, nil)
+			},
+		},
+		{
+			name:   "Inverse Rate",
+			amount: commons.NewMoney(1000, "EUR"),
+			to:     "USD",
+			want:   commons.NewMoney(1087, "USD"),
+			mockFunc: func(mockRepo *MockExchangeRateRepository) {
+				mockRepo.EXPECT().GetEffectiveRate("EUR", "USD", day).Return(ExchangeRateRow{}, sql.ErrNoRows)
+				mockRepo.EXPECT().GetEffectiveRate("USD", "EUR", day).Return(ExchangeRateRow{Rate: "0.92"}, nil)
+			},
+		},
+		{
+			name:    "No Rate",
+			amount:  commons.NewMoney(1000, "GBP"),
+			to:      "EUR",
+			wantErr: ErrNoExchangeRate,
+			mockFunc: func(mockRepo *MockExchangeRateRepository) {
+				mockRepo.EXPECT().GetEffectiveRate("GBP", "EUR", day).Return(ExchangeRateRow{}, sql.ErrNoRows)
+				mockRepo.EXPECT().GetEffectiveRate("EUR", "GBP", day).Return(ExchangeRateRow{}, sql.ErrNoRows)
+			},
+		},
+		{
+			name:    "Repo Error",
+			amount:  commons.NewMoney(1000, "GBP"),
+			to:      "EUR",
+			wantErr: sql.ErrConnDone,
+			mockFunc: func(mockRepo *MockExchangeRateRepository) {
+				mockRepo.EXPECT().GetEffectiveRate("GBP", "EUR", day).Return(ExchangeRateRow{}, sql.ErrConnDone)
+			},
+		},
+		{
+			name:     "Unsupported Currency",
+			amount:   commons.NewMoney(1000, "USD"),
+			to:       "JPY",
+			wantErr:  commons.ErrUnsupportedCurrency,
+			mockFunc: func(mockRepo *MockExchangeRateRepository) {},
+		},
+	}
+	controller := gomock.NewController(t)
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := NewMockExchangeRateRepository(controller)
+			tt.mockFunc(mockRepo)
+			service := NewExchangeRateService(mockRepo)
+			result, err := service.Convert(tt.amount, tt.to, on)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, result)
+			}
+		})
+	}
+}
+
+func TestExchangeRateService_Normalize(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := NewMockExchangeRateRepository(controller)
+	service, err := NewFromConfig(mockRepo, config.Currency{Base: "eur"})
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", service.BaseCurrency())
+	assert.Equal(t, commons.DefaultCurrency, NewExchangeRateService(mockRepo).BaseCurrency())
+
+	_, err = NewFromConfig(mockRepo, config.Currency{Base: "JPY"})
+	assert.ErrorIs(t, err, commons.ErrUnsupportedCurrency)
+
+	mockRepo.EXPECT().GetEffectiveRate("GBP", "EUR", gomock.Any()).Return(ExchangeRateRow{Rate: "1.17"}, nil)
+	result, err := service.Normalize(commons.NewMoney(1000, "GBP"), time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, commons.NewMoney(1170, "EUR"), result)
+}
@@ -0,0 +1,21 @@
+//go:build wireinject
+// +build wireinject
+
+package currency
+
+import (
+	"github.com/google/wire"
+	"inventory-service-go/commons"
+	"inventory-service-go/config"
+)
+
+func InitializeExchangeRateService(settings config.Currency) (ExchangeRateService, error) {
+	wire.Build(
+		NewFromConfig,
+		NewExchangeRateRepository,
+		commons.GetDB,
+		wire.Bind(new(ExchangeRateRepository), new(*ExchangeRateRepositoryImpl)),
+		wire.Bind(new(ExchangeRateService), new(*ExchangeRateServiceImpl)),
+	)
+	return nil, nil
+}
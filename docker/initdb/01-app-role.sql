@@ -0,0 +1,8 @@
+-- the role the server connects with. It is not a superuser, so the row-level security policies isolating the tenants
+-- hold for it, and the server refuses to start with one that is. POSTGRES_USER owns the tables and runs the
+-- migrations, the app role may only read and write the rows.
+CREATE ROLE inventory_app LOGIN PASSWORD 'secret';
+GRANT USAGE ON SCHEMA public TO inventory_app;
+ALTER DEFAULT PRIVILEGES FOR ROLE inventory IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO inventory_app;
+ALTER DEFAULT PRIVILEGES FOR ROLE inventory IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO inventory_app;
+ALTER DEFAULT PRIVILEGES FOR ROLE inventory IN SCHEMA public GRANT EXECUTE ON FUNCTIONS TO inventory_app;
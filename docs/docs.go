@@ -22,64 +22,17 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/authorize": {
-            "post": {
-                "description": "Retrieve an JWT access token for supplied credentials",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Authorize",
-                "operationId": "authorize",
-                "parameters": [
-                    {
-                        "description": "Credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/auth.Credentials"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.TokenCredentials"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized (invalid credentials)",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/invoices": {
+        "/api-keys": {
             "get": {
-                "description": "List all Invoices",
+                "description": "List all API Keys, without the keys themselves",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "invoice"
+                    "api-key"
                 ],
-                "summary": "List Invoices",
-                "operationId": "all_invoices",
+                "summary": "List API Keys",
+                "operationId": "all_api_keys",
                 "parameters": [
                     {
                         "type": "integer",
@@ -89,7 +42,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "number of invoices per page",
+                        "description": "number of api keys per page",
                         "name": "page_size",
                         "in": "query"
                     }
@@ -100,12 +53,12 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/invoice.Invoice"
+                                "$ref": "#/definitions/apikey.APIKey"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden (not an admin)",
                         "schema": {
                             "type": "string"
                         }
@@ -119,7 +72,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create an Invoice",
+                "description": "Create an API Key for the X-API-Key header with scopes such as read, write, items:read or invoices:write, the response is the only time the key is returned",
                 "consumes": [
                     "application/json"
                 ],
@@ -127,18 +80,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "invoice"
+                    "api-key"
                 ],
-                "summary": "Create Invoice",
-                "operationId": "create_invoice",
+                "summary": "Create API Key",
+                "operationId": "create_api_key",
                 "parameters": [
                     {
-                        "description": "Create Invoice Request",
+                        "description": "Create API Key Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/invoice.CreateInvoiceRequest"
+                            "$ref": "#/definitions/apikey.CreateAPIKeyRequest"
                         }
                     }
                 ],
@@ -146,7 +99,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/invoice.Invoice"
+                            "$ref": "#/definitions/apikey.CreatedAPIKey"
                         }
                     },
                     "400": {
@@ -155,8 +108,8 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized (invalid credentials)",
+                    "403": {
+                        "description": "Forbidden (not an admin)",
                         "schema": {
                             "type": "string"
                         }
@@ -168,25 +121,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/invoices/user/{id}": {
+        "/api-keys/{id}": {
             "get": {
-                "description": "Get all Invoices for a specific User",
+                "description": "Get a specific API Key, without the key itself",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "invoice"
+                    "api-key"
                 ],
-                "summary": "Get Invoices For User",
-                "operationId": "get_all_for_user_invoice",
+                "summary": "Get API Key",
+                "operationId": "get_api_key",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/invoice.Invoice"
-                            }
+                            "$ref": "#/definitions/apikey.APIKey"
                         }
                     },
                     "400": {
@@ -195,6 +145,18 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden (not an admin)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -202,35 +164,22 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/invoices/{id}": {
-            "get": {
-                "description": "Get a specific Invoice",
+            },
+            "delete": {
+                "description": "Revoke an API Key, it is rejected from then on and kept for the audit",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "invoice"
-                ],
-                "summary": "Get Invoice",
-                "operationId": "get_invoice",
-                "parameters": [
-                    {
-                        "type": "boolean",
-                        "description": "return with Items (if there are any attached to the invoice)",
-                        "name": "withItems",
-                        "in": "query"
-                    }
+                    "api-key"
                 ],
+                "summary": "Revoke API Key",
+                "operationId": "revoke_api_key",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/invoice.Invoice"
-                            }
+                            "$ref": "#/definitions/apikey.APIKey"
                         }
                     },
                     "400": {
@@ -239,6 +188,18 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden (not an admin)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -246,9 +207,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an Invoice",
+            }
+        },
+        "/authorize": {
+            "post": {
+                "description": "Retrieve an JWT access token for supplied credentials",
                 "consumes": [
                     "application/json"
                 ],
@@ -256,18 +219,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "invoice"
+                    "auth"
                 ],
-                "summary": "Update Invoice",
-                "operationId": "update_invoice",
+                "summary": "Authorize",
+                "operationId": "authorize",
                 "parameters": [
                     {
-                        "description": "Update Invoice Request",
+                        "description": "Credentials",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/invoice.UpdateInvoiceRequest"
+                            "$ref": "#/definitions/auth.Credentials"
                         }
                     }
                 ],
@@ -275,7 +238,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/invoice.Invoice"
+                            "$ref": "#/definitions/auth.TokenCredentials"
                         }
                     },
                     "400": {
@@ -292,36 +255,48 @@ const docTemplate = `{
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Remove a specific Invoice",
+            }
+        },
+        "/exchange-rates": {
+            "get": {
+                "description": "List all Exchange Rates",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "invoice"
+                    "exchange-rate"
+                ],
+                "summary": "List Exchange Rates",
+                "operationId": "all_exchange_rates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "last seq id",
+                        "name": "last_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of rates per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Delete Invoice",
-                "operationId": "delete_invoice",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/commons.DeleteResult"
+                                "$ref": "#/definitions/currency.ExchangeRate"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -329,11 +304,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/invoices/{id}/items": {
+            },
             "post": {
-                "description": "Add Items to an Invoice",
+                "description": "Create an Exchange Rate effective from a given date",
                 "consumes": [
                     "application/json"
                 ],
@@ -341,29 +314,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "invoice"
+                    "exchange-rate"
                 ],
-                "summary": "Add Items to Invoice",
-                "operationId": "add_items_to_invoice",
+                "summary": "Create Exchange Rate",
+                "operationId": "create_exchange_rate",
                 "parameters": [
                     {
-                        "description": "Add Items to Invoice Request",
+                        "description": "Create Exchange Rate Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/invoice.ItemsToInvoiceRequest"
+                            "$ref": "#/definitions/currency.CreateExchangeRateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/invoice.ItemsToInvoiceResponse"
-                            }
+                            "$ref": "#/definitions/currency.ExchangeRate"
                         }
                     },
                     "400": {
@@ -372,34 +342,69 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (not an admin)",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
                     }
                 }
             }
         },
-        "/invoices/{id}/items/{itemId}": {
-            "delete": {
-                "description": "Remove a specific Item from a specific Invoice",
+        "/exchange-rates/convert": {
+            "get": {
+                "description": "Convert an amount into another currency (the base currency when 'to' is omitted) at the rate effective on a date",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "invoice"
+                    "exchange-rate"
+                ],
+                "summary": "Convert Amount",
+                "operationId": "convert_amount",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "decimal amount, e.g. 12.34",
+                        "name": "amount",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency of the amount",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "target currency, defaults to the base currency",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "effective date (YYYY-MM-DD), defaults to today",
+                        "name": "date",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Remove Item From Invoice",
-                "operationId": "remove_item_from_invoice",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/commons.DeleteResult"
-                            }
+                            "$ref": "#/definitions/commons.MoneyJson"
                         }
                     },
                     "400": {
@@ -417,39 +422,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/items": {
+        "/exchange-rates/{id}": {
             "get": {
-                "description": "List all Items",
+                "description": "Get a specific Exchange Rate",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "item"
-                ],
-                "summary": "List Items",
-                "operationId": "all_items",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "last seq id",
-                        "name": "last_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "number of items per page",
-                        "name": "page_size",
-                        "in": "query"
-                    }
+                    "exchange-rate"
                 ],
+                "summary": "Get Exchange Rate",
+                "operationId": "get_exchange_rate",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/item.Item"
-                            }
+                            "$ref": "#/definitions/currency.ExchangeRate"
                         }
                     },
                     "400": {
@@ -458,6 +446,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -466,8 +460,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create an Item",
+            "put": {
+                "description": "Update an Exchange Rate",
                 "consumes": [
                     "application/json"
                 ],
@@ -475,26 +469,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "item"
+                    "exchange-rate"
                 ],
-                "summary": "Create Item",
-                "operationId": "create_item",
+                "summary": "Update Exchange Rate",
+                "operationId": "update_exchange_rate",
                 "parameters": [
                     {
-                        "description": "Create Item Request",
+                        "description": "Update Exchange Rate Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/item.CreateItemRequest"
+                            "$ref": "#/definitions/currency.UpdateExchangeRateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/item.Item"
+                            "$ref": "#/definitions/currency.ExchangeRate"
                         }
                     },
                     "400": {
@@ -509,32 +503,39 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden (not an admin)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
                     }
                 }
-            }
-        },
-        "/items/{id}": {
-            "get": {
-                "description": "Get a specific Item",
+            },
+            "delete": {
+                "description": "Remove a specific Exchange Rate",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "item"
+                    "exchange-rate"
                 ],
-                "summary": "Get Item",
-                "operationId": "get_item",
+                "summary": "Delete Exchange Rate",
+                "operationId": "delete_exchange_rate",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/item.Item"
-                            }
+                            "$ref": "#/definitions/commons.DeleteResult"
                         }
                     },
                     "400": {
@@ -543,8 +544,8 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden (not an admin)",
                         "schema": {
                             "type": "string"
                         }
@@ -556,36 +557,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an Item",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/invoices": {
+            "get": {
+                "description": "List all Invoices",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "item"
+                    "invoice"
                 ],
-                "summary": "Update Item",
-                "operationId": "update_item",
+                "summary": "List Invoices",
+                "operationId": "all_invoices",
                 "parameters": [
                     {
-                        "description": "Update Item Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/item.UpdateItemRequest"
-                        }
+                        "type": "integer",
+                        "description": "last seq id",
+                        "name": "last_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of invoices per page",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "adds normalized_total, the total in this currency or with 'base' in the base currency",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/item.Item"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/invoice.Invoice"
+                            }
                         }
                     },
                     "400": {
@@ -594,36 +606,43 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized (invalid credentials)",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {}
                     }
                 }
             },
-            "delete": {
-                "description": "Remove a specific Item",
+            "post": {
+                "description": "Create an Invoice",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "item"
+                    "invoice"
+                ],
+                "summary": "Create Invoice",
+                "operationId": "create_invoice",
+                "parameters": [
+                    {
+                        "description": "Create Invoice Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/invoice.CreateInvoiceRequest"
+                        }
+                    }
                 ],
-                "summary": "Delete Item",
-                "operationId": "delete_item",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/commons.DeleteResult"
-                            }
+                            "$ref": "#/definitions/invoice.Invoice"
                         }
                     },
                     "400": {
@@ -632,58 +651,48 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "schema": {}
                     }
                 }
             }
         },
-        "/persons": {
+        "/invoices/by-number/{number}": {
             "get": {
-                "description": "List all Persons",
+                "description": "Get a specific Invoice by its invoice number, e.g. INV-2026-000123",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "person"
+                    "invoice"
                 ],
-                "summary": "List Persons",
-                "operationId": "all_persons",
+                "summary": "Get Invoice By Number",
+                "operationId": "get_invoice_by_number",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "last seq id",
-                        "name": "last_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "number of persons per page",
-                        "name": "page_size",
-                        "in": "query"
+                        "type": "string",
+                        "description": "invoice number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/person.Person"
-                            }
+                            "$ref": "#/definitions/invoice.Invoice"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "string"
                         }
@@ -695,36 +704,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create an Person",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/invoices/user/{id}": {
+            "get": {
+                "description": "Get all Invoices for a specific User",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "person"
+                    "invoice"
                 ],
-                "summary": "Create Person",
-                "operationId": "create_person",
+                "summary": "Get Invoices For User",
+                "operationId": "get_all_for_user_invoice",
                 "parameters": [
                     {
-                        "description": "Create Person Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/person.CreatePersonRequest"
-                        }
+                        "type": "string",
+                        "description": "adds normalized_total, the total in this currency or with 'base' in the base currency",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/person.Person"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/invoice.Invoice"
+                            }
                         }
                     },
                     "400": {
@@ -733,37 +741,41 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized (invalid credentials)",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {}
                     }
                 }
             }
         },
-        "/persons/{id}": {
+        "/invoices/{id}": {
             "get": {
-                "description": "Get a specific Person",
+                "description": "Get a specific Invoice",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "person"
+                    "invoice"
+                ],
+                "summary": "Get Invoice",
+                "operationId": "get_invoice",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "return with Items (if there are any attached to the invoice)",
+                        "name": "withItems",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get Person",
-                "operationId": "get_person",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/person.Person"
+                                "$ref": "#/definitions/invoice.Invoice"
                             }
                         }
                     },
@@ -773,12 +785,6 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -788,7 +794,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an Person",
+                "description": "Update an Invoice",
                 "consumes": [
                     "application/json"
                 ],
@@ -796,18 +802,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "person"
+                    "invoice"
                 ],
-                "summary": "Update Person",
-                "operationId": "update_person",
+                "summary": "Update Invoice",
+                "operationId": "update_invoice",
                 "parameters": [
                     {
-                        "description": "Update Person Request",
+                        "description": "Update Invoice Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/person.UpdatePersonRequest"
+                            "$ref": "#/definitions/invoice.UpdateInvoiceRequest"
                         }
                     }
                 ],
@@ -815,7 +821,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/person.Person"
+                            "$ref": "#/definitions/invoice.Invoice"
                         }
                     },
                     "400": {
@@ -837,15 +843,15 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Remove a specific Person",
+                "description": "Remove a specific Invoice",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "person"
+                    "invoice"
                 ],
-                "summary": "Delete Person",
-                "operationId": "delete_person",
+                "summary": "Delete Invoice",
+                "operationId": "delete_invoice",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -862,12 +868,6 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -876,197 +876,2962 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "auth.Credentials": {
-            "type": "object",
-            "properties": {
-                "client_id": {
-                    "type": "string"
-                },
-                "client_secret": {
-                    "type": "string"
-                }
+        },
+        "/invoices/{id}/discounts": {
+            "post": {
+                "description": "Take a percentage or a fixed amount off the whole Invoice, shared across its lines before tax",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Add Discount to Invoice",
+                "operationId": "add_invoice_discount",
+                "parameters": [
+                    {
+                        "description": "Invoice Discount Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/invoice.InvoiceDiscountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/invoice.Invoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices/{id}/issue": {
+            "post": {
+                "description": "Give an Invoice the next sequential invoice number, an Invoice that was already issued keeps its number",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Issue Invoice",
+                "operationId": "issue_invoice",
+                "parameters": [
+                    {
+                        "description": "Issue Invoice Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/invoice.IssueInvoiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/invoice.Invoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices/{id}/items": {
+            "post": {
+                "description": "Add Items to an Invoice",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Add Items to Invoice",
+                "operationId": "add_items_to_invoice",
+                "parameters": [
+                    {
+                        "description": "Add Items to Invoice Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/invoice.ItemsToInvoiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/invoice.ItemsToInvoiceResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices/{id}/items/{itemId}": {
+            "delete": {
+                "description": "Remove a specific Item from a specific Invoice",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Remove Item From Invoice",
+                "operationId": "remove_item_from_invoice",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/commons.DeleteResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices/{id}/promotions": {
+            "post": {
+                "description": "Redeem a promotion code against an Invoice, checking its validity window, usage limit and minimum order amount",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoice"
+                ],
+                "summary": "Apply Promotion Code to Invoice",
+                "operationId": "apply_promotion",
+                "parameters": [
+                    {
+                        "description": "Promotion Code Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/invoice.PromotionCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/invoice.Invoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/items": {
+            "get": {
+                "description": "List all Items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "item"
+                ],
+                "summary": "List Items",
+                "operationId": "all_items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "last seq id",
+                        "name": "last_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of items per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/item.Item"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create an Item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "item"
+                ],
+                "summary": "Create Item",
+                "operationId": "create_item",
+                "parameters": [
+                    {
+                        "description": "Create Item Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/item.CreateItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/item.Item"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/items/{id}": {
+            "get": {
+                "description": "Get a specific Item",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "item"
+                ],
+                "summary": "Get Item",
+                "operationId": "get_item",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/item.Item"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an Item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "item"
+                ],
+                "summary": "Update Item",
+                "operationId": "update_item",
+                "parameters": [
+                    {
+                        "description": "Update Item Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/item.UpdateItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/item.Item"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a specific Item",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "item"
+                ],
+                "summary": "Delete Item",
+                "operationId": "delete_item",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/commons.DeleteResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/persons": {
+            "get": {
+                "description": "List all Persons",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "person"
+                ],
+                "summary": "List Persons",
+                "operationId": "all_persons",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "last seq id",
+                        "name": "last_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of persons per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/person.Person"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create an Person",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "person"
+                ],
+                "summary": "Create Person",
+                "operationId": "create_person",
+                "parameters": [
+                    {
+                        "description": "Create Person Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/person.CreatePersonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/person.Person"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/persons/{id}": {
+            "get": {
+                "description": "Get a specific Person",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "person"
+                ],
+                "summary": "Get Person",
+                "operationId": "get_person",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/person.Person"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an Person",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "person"
+                ],
+                "summary": "Update Person",
+                "operationId": "update_person",
+                "parameters": [
+                    {
+                        "description": "Update Person Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/person.UpdatePersonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/person.Person"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a specific Person",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "person"
+                ],
+                "summary": "Delete Person",
+                "operationId": "delete_person",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/commons.DeleteResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "description": "List all Promotion codes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "List Promotions",
+                "operationId": "all_promotions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "last seq id",
+                        "name": "last_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of promotions per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/promotion.Promotion"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a Promotion code with a discount, validity window, usage limit and minimum order amount",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "Create Promotion",
+                "operationId": "create_promotion",
+                "parameters": [
+                    {
+                        "description": "Create Promotion Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/promotion.CreatePromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/promotion.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/promotions/{id}": {
+            "get": {
+                "description": "Get a specific Promotion",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "Get Promotion",
+                "operationId": "get_promotion",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/promotion.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a Promotion",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "Update Promotion",
+                "operationId": "update_promotion",
+                "parameters": [
+                    {
+                        "description": "Update Promotion Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/promotion.UpdatePromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/promotion.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a specific Promotion",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotion"
+                ],
+                "summary": "Delete Promotion",
+                "operationId": "delete_promotion",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/commons.DeleteResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-invoices": {
+            "get": {
+                "description": "List all Recurring Invoices",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-invoice"
+                ],
+                "summary": "List Recurring Invoices",
+                "operationId": "all_recurring_invoices",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "last seq id",
+                        "name": "last_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of recurring invoices per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/recurring.RecurringInvoice"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a Recurring Invoice template with items, quantities, a cadence (monthly, quarterly or yearly) and a start and optional end date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-invoice"
+                ],
+                "summary": "Create Recurring Invoice",
+                "operationId": "create_recurring_invoice",
+                "parameters": [
+                    {
+                        "description": "Create Recurring Invoice Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/recurring.CreateRecurringInvoiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/recurring.RecurringInvoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/recurring-invoices/{id}": {
+            "get": {
+                "description": "Get a specific Recurring Invoice",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-invoice"
+                ],
+                "summary": "Get Recurring Invoice",
+                "operationId": "get_recurring_invoice",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/recurring.RecurringInvoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a Recurring Invoice",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-invoice"
+                ],
+                "summary": "Update Recurring Invoice",
+                "operationId": "update_recurring_invoice",
+                "parameters": [
+                    {
+                        "description": "Update Recurring Invoice Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/recurring.UpdateRecurringInvoiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/recurring.RecurringInvoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a specific Recurring Invoice",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-invoice"
+                ],
+                "summary": "Delete Recurring Invoice",
+                "operationId": "delete_recurring_invoice",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/commons.DeleteResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-invoices/{id}/runs": {
+            "get": {
+                "description": "List the scheduler runs of a Recurring Invoice with the invoice created on each due date",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-invoice"
+                ],
+                "summary": "List Recurring Invoice Runs",
+                "operationId": "get_recurring_invoice_runs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/recurring.Run"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream": {
+            "get": {
+                "description": "Push the changes to persons, items and invoices as Server-Sent Events, or as WebSocket messages when the connection is upgraded. Every event carries an id, reconnecting with it in the Last-Event-ID header (or the last_event_id query parameter) replays the changes missed in between. A reset event is sent first when they can no longer be replayed.",
+                "produces": [
+                    "text/event-stream",
+                    "application/json"
+                ],
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Stream Changes",
+                "operationId": "stream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated topics: persons, items, invoices, all when empty",
+                        "name": "topics",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the last event received",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT, for clients that cannot set the Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/stream.Event"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-rates": {
+            "get": {
+                "description": "List all Tax Rates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rate"
+                ],
+                "summary": "List Tax Rates",
+                "operationId": "all_tax_rates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "last seq id",
+                        "name": "last_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of rates per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tax.TaxRate"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create the Tax Rate of a category of goods in a jurisdiction, effective from a given date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rate"
+                ],
+                "summary": "Create Tax Rate",
+                "operationId": "create_tax_rate",
+                "parameters": [
+                    {
+                        "description": "Create Tax Rate Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tax.CreateTaxRateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/tax.TaxRate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (not an admin)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/tax-rates/{id}": {
+            "get": {
+                "description": "Get a specific Tax Rate",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rate"
+                ],
+                "summary": "Get Tax Rate",
+                "operationId": "get_tax_rate",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tax.TaxRate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a Tax Rate",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rate"
+                ],
+                "summary": "Update Tax Rate",
+                "operationId": "update_tax_rate",
+                "parameters": [
+                    {
+                        "description": "Update Tax Rate Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tax.UpdateTaxRateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tax.TaxRate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (not an admin)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a specific Tax Rate",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rate"
+                ],
+                "summary": "Delete Tax Rate",
+                "operationId": "delete_tax_rate",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/commons.DeleteResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (not an admin)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenants": {
+            "get": {
+                "description": "List all Tenants",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tenant"
+                ],
+                "summary": "List Tenants",
+                "operationId": "all_tenants",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "last seq id",
+                        "name": "last_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of tenants per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tenant.Tenant"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (not an admin)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a Tenant, its users and API keys are then created with its tenant_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tenant"
+                ],
+                "summary": "Create Tenant",
+                "operationId": "create_tenant",
+                "parameters": [
+                    {
+                        "description": "Create Tenant Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tenant.CreateTenantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/tenant.Tenant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (not an admin)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/tenants/{id}": {
+            "get": {
+                "description": "Get a specific Tenant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tenant"
+                ],
+                "summary": "Get Tenant",
+                "operationId": "get_tenant",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tenant.Tenant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (not an admin)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "List all Webhook Subscriptions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "List Webhook Subscriptions",
+                "operationId": "all_webhooks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "last seq id",
+                        "name": "last_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of webhook subscriptions per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhook.Subscription"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register a webhook endpoint for the chosen event types, the response holds the HMAC secret deliveries are signed with and is the only time it is returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Create Webhook Subscription",
+                "operationId": "create_webhook",
+                "parameters": [
+                    {
+                        "description": "Create Webhook Subscription Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.CreateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Subscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/webhooks/dead-letters": {
+            "get": {
+                "description": "List the deliveries of all Webhook Subscriptions that were given up on after repeated failures",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "List Dead Letters",
+                "operationId": "get_webhook_dead_letters",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhook.Delivery"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "description": "Get a specific Webhook Subscription",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Get Webhook Subscription",
+                "operationId": "get_webhook",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Subscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update the url and event types of a Webhook Subscription or (de)activate it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Update Webhook Subscription",
+                "operationId": "update_webhook",
+                "parameters": [
+                    {
+                        "description": "Update Webhook Subscription Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.UpdateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Subscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid credentials)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a specific Webhook Subscription",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Delete Webhook Subscription",
+                "operationId": "delete_webhook",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/commons.DeleteResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "The delivery log of a Webhook Subscription, newest first, optionally only the deliveries with a status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "List Webhook Deliveries",
+                "operationId": "get_webhook_deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pending, delivered, failed or dead",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhook.Delivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "description": "Post a delivery to its Webhook Subscription again right away, including dead letters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Redeliver Webhook",
+                "operationId": "redeliver_webhook",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.Delivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apikey.APIKey": {
+            "type": "object",
+            "properties": {
+                "audit_info": {
+                    "$ref": "#/definitions/commons.AuditInfo"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked": {
+                    "type": "boolean"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "tenant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "apikey.CreateAPIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tenant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "apikey.CreatedAPIKey": {
+            "type": "object",
+            "properties": {
+                "audit_info": {
+                    "$ref": "#/definitions/commons.AuditInfo"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked": {
+                    "type": "boolean"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "tenant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.Credentials": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "client_secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.TokenCredentials": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "integer"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "commons.AuditInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "last_change_by": {
+                    "type": "string"
+                },
+                "last_update": {
+                    "type": "string"
+                }
+            }
+        },
+        "commons.DeleteResult": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "commons.MoneyJson": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "currency.CreateExchangeRateRequest": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "string"
+                },
+                "effective_date": {
+                    "type": "string"
+                },
+                "from_currency": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "string"
+                },
+                "to_currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "currency.ExchangeRate": {
+            "type": "object",
+            "properties": {
+                "audit_info": {
+                    "$ref": "#/definitions/commons.AuditInfo"
+                },
+                "effective_date": {
+                    "type": "string"
+                },
+                "from_currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "to_currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "currency.UpdateExchangeRateRequest": {
+            "type": "object",
+            "properties": {
+                "effective_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_changed_by": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "string"
+                }
+            }
+        },
+        "invoice.CreateInvoiceRequest": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "string"
+                },
+                "paid": {
+                    "type": "boolean"
+                },
+                "prices_include_tax": {
+                    "type": "boolean"
+                },
+                "tax_jurisdiction": {
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Total only gives the currency of the invoice, the amount is worked out from the lines.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/commons.MoneyJson"
+                        }
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "invoice.Invoice": {
+            "type": "object",
+            "properties": {
+                "audit_info": {
+                    "$ref": "#/definitions/commons.AuditInfo"
+                },
+                "discounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/invoice.InvoiceDiscount"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/item.Item"
+                    }
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/invoice.InvoiceLine"
+                    }
+                },
+                "normalized_total": {
+                    "description": "NormalizedTotal is the total converted into the currency a listing was asked for, see handlers.GetAllInvoices.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/commons.MoneyJson"
+                        }
+                    ]
+                },
+                "number": {
+                    "type": "string"
+                },
+                "paid": {
+                    "type": "boolean"
+                },
+                "prices_include_tax": {
+                    "type": "boolean"
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "tax": {
+                    "$ref": "#/definitions/tax.Breakdown"
+                },
+                "tax_jurisdiction": {
+                    "type": "string"
+                },
+                "total": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "user_id": {
+                    "type": "string"
+                }
             }
         },
-        "commons.AuditInfo": {
+        "invoice.InvoiceDiscount": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "amount": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount": {
+                    "$ref": "#/definitions/promotion.Discount"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "promotion_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "invoice.InvoiceDiscountRequest": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount": {
+                    "$ref": "#/definitions/promotion.Discount"
+                },
+                "invoice_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "invoice.InvoiceLine": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "$ref": "#/definitions/promotion.Discount"
+                },
+                "discount_amount": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "gross": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "item_id": {
+                    "type": "string"
+                },
+                "net": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "tax": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "tax_category": {
+                    "type": "string"
+                },
+                "tax_rate": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                }
+            }
+        },
+        "invoice.IssueInvoiceRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "issued_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "invoice.ItemsToInvoiceRequest": {
+            "type": "object",
+            "properties": {
+                "discounts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/promotion.Discount"
+                    }
+                },
+                "invoice_id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "quantities": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "invoice.ItemsToInvoiceResponse": {
+            "type": "object",
+            "properties": {
+                "invoice_id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "invoice.PromotionCodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "invoice_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "invoice.UpdateInvoiceRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "last_changed_by": {
+                    "type": "string"
+                },
+                "paid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "item.CreateItemRequest": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tax_category": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                }
+            }
+        },
+        "item.Item": {
+            "type": "object",
+            "properties": {
+                "audit_info": {
+                    "$ref": "#/definitions/commons.AuditInfo"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "tax_category": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                }
+            }
+        },
+        "item.UpdateItemRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_changed_by": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tax_category": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                }
+            }
+        },
+        "person.CreatePersonRequest": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "person.Person": {
+            "type": "object",
+            "properties": {
+                "audit_info": {
+                    "$ref": "#/definitions/commons.AuditInfo"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                }
+            }
+        },
+        "person.UpdatePersonRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_changed_by": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "promotion.CreatePromotionRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_by": {
                     "type": "string"
                 },
-                "created_by": {
+                "currency": {
                     "type": "string"
                 },
-                "last_change_by": {
+                "description": {
                     "type": "string"
                 },
-                "last_update": {
+                "discount": {
+                    "$ref": "#/definitions/promotion.Discount"
+                },
+                "max_uses": {
+                    "type": "integer"
+                },
+                "min_order_amount": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
                     "type": "string"
                 }
             }
         },
-        "commons.DeleteResult": {
+        "promotion.Discount": {
             "type": "object",
             "properties": {
-                "deleted": {
-                    "type": "boolean"
+                "type": {
+                    "$ref": "#/definitions/promotion.DiscountType"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "promotion.DiscountType": {
+            "type": "string",
+            "enum": [
+                "percentage",
+                "fixed"
+            ],
+            "x-enum-varnames": [
+                "Percentage",
+                "FixedAmount"
+            ]
+        },
+        "promotion.Promotion": {
+            "type": "object",
+            "properties": {
+                "audit_info": {
+                    "$ref": "#/definitions/commons.AuditInfo"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount": {
+                    "$ref": "#/definitions/promotion.Discount"
                 },
                 "id": {
                     "type": "string"
+                },
+                "max_uses": {
+                    "type": "integer"
+                },
+                "min_order_amount": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "times_used": {
+                    "type": "integer"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.TokenCredentials": {
+        "promotion.UpdatePromotionRequest": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_changed_by": {
+                    "type": "string"
+                },
+                "max_uses": {
                     "type": "integer"
                 },
-                "token": {
+                "min_order_amount": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
                     "type": "string"
                 }
             }
         },
-        "invoice.CreateInvoiceRequest": {
+        "recurring.Cadence": {
+            "type": "string",
+            "enum": [
+                "monthly",
+                "quarterly",
+                "yearly"
+            ],
+            "x-enum-varnames": [
+                "Monthly",
+                "Quarterly",
+                "Yearly"
+            ]
+        },
+        "recurring.CreateRecurringInvoiceRequest": {
             "type": "object",
             "properties": {
+                "cadence": {
+                    "$ref": "#/definitions/recurring.Cadence"
+                },
                 "created_by": {
                     "type": "string"
                 },
-                "paid": {
+                "currency": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recurring.RecurringInvoiceItem"
+                    }
+                },
+                "prices_include_tax": {
                     "type": "boolean"
                 },
-                "total": {
-                    "type": "number"
+                "start_date": {
+                    "type": "string"
+                },
+                "tax_jurisdiction": {
+                    "type": "string"
                 },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
-        "invoice.Invoice": {
+        "recurring.RecurringInvoice": {
             "type": "object",
             "properties": {
                 "audit_info": {
                     "$ref": "#/definitions/commons.AuditInfo"
                 },
+                "cadence": {
+                    "$ref": "#/definitions/recurring.Cadence"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/item.Item"
+                        "$ref": "#/definitions/recurring.RecurringInvoiceItem"
                     }
                 },
-                "paid": {
+                "next_run_date": {
+                    "type": "string"
+                },
+                "prices_include_tax": {
                     "type": "boolean"
                 },
                 "seq": {
                     "type": "integer"
                 },
-                "total": {
-                    "type": "number"
+                "start_date": {
+                    "type": "string"
+                },
+                "tax_jurisdiction": {
+                    "type": "string"
                 },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
-        "invoice.ItemsToInvoiceRequest": {
+        "recurring.RecurringInvoiceItem": {
             "type": "object",
             "properties": {
-                "invoice_id": {
+                "item_id": {
                     "type": "string"
                 },
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "quantity": {
+                    "type": "integer"
                 }
             }
         },
-        "invoice.ItemsToInvoiceResponse": {
+        "recurring.Run": {
             "type": "object",
             "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
                 "invoice_id": {
                     "type": "string"
                 },
+                "recurring_invoice_id": {
+                    "type": "string"
+                },
+                "run_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "recurring.UpdateRecurringInvoiceRequest": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
                 "items": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/recurring.RecurringInvoiceItem"
                     }
                 },
-                "success": {
-                    "type": "boolean"
+                "last_changed_by": {
+                    "type": "string"
                 }
             }
         },
-        "invoice.UpdateInvoiceRequest": {
+        "stream.Event": {
             "type": "object",
             "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
-                "last_changed_by": {
+                "occurred_at": {
                     "type": "string"
                 },
-                "paid": {
-                    "type": "boolean"
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "tax.Breakdown": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "subtotal": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "tax_total": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "taxes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.Summary"
+                    }
                 },
                 "total": {
-                    "type": "number"
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "undiscounted": {
+                    "$ref": "#/definitions/commons.MoneyJson"
                 }
             }
         },
-        "item.CreateItemRequest": {
+        "tax.CreateTaxRateRequest": {
             "type": "object",
             "properties": {
                 "created_by": {
                     "type": "string"
                 },
-                "description": {
+                "effective_date": {
                     "type": "string"
                 },
-                "name": {
+                "jurisdiction": {
                     "type": "string"
                 },
-                "unit_price": {
-                    "type": "number"
+                "percentage": {
+                    "type": "string"
+                },
+                "tax_category": {
+                    "type": "string"
                 }
             }
         },
-        "item.Item": {
+        "tax.Summary": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "net": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                },
+                "percentage": {
+                    "type": "string"
+                },
+                "tax": {
+                    "$ref": "#/definitions/commons.MoneyJson"
+                }
+            }
+        },
+        "tax.TaxRate": {
             "type": "object",
             "properties": {
                 "audit_info": {
                     "$ref": "#/definitions/commons.AuditInfo"
                 },
-                "description": {
+                "effective_date": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "jurisdiction": {
+                    "type": "string"
+                },
+                "percentage": {
                     "type": "string"
                 },
                 "seq": {
                     "type": "integer"
                 },
-                "unit_price": {
-                    "type": "number"
+                "tax_category": {
+                    "type": "string"
                 }
             }
         },
-        "item.UpdateItemRequest": {
+        "tax.UpdateTaxRateRequest": {
             "type": "object",
             "properties": {
-                "description": {
+                "effective_date": {
                     "type": "string"
                 },
                 "id": {
@@ -1075,53 +3840,134 @@ const docTemplate = `{
                 "last_changed_by": {
                     "type": "string"
                 },
+                "percentage": {
+                    "type": "string"
+                }
+            }
+        },
+        "tenant.CreateTenantRequest": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
+                }
+            }
+        },
+        "tenant.Tenant": {
+            "type": "object",
+            "properties": {
+                "audit_info": {
+                    "$ref": "#/definitions/commons.AuditInfo"
                 },
-                "unit_price": {
-                    "type": "number"
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
                 }
             }
         },
-        "person.CreatePersonRequest": {
+        "webhook.CreateSubscriptionRequest": {
             "type": "object",
             "properties": {
                 "created_by": {
                     "type": "string"
                 },
-                "email": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhook.Delivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "name": {
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscription_id": {
                     "type": "string"
                 }
             }
         },
-        "person.Person": {
+        "webhook.Subscription": {
             "type": "object",
             "properties": {
+                "active": {
+                    "type": "boolean"
+                },
                 "audit_info": {
                     "$ref": "#/definitions/commons.AuditInfo"
                 },
-                "email": {
-                    "type": "string"
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "secret": {
                     "type": "string"
                 },
                 "seq": {
                     "type": "integer"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "person.UpdatePersonRequest": {
+        "webhook.UpdateSubscriptionRequest": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "active": {
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "id": {
                     "type": "string"
@@ -1129,7 +3975,7 @@ const docTemplate = `{
                 "last_changed_by": {
                     "type": "string"
                 },
-                "name": {
+                "url": {
                     "type": "string"
                 }
             }
@@ -0,0 +1,53 @@
+package graphqlapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+	"inventory-service-go/commons"
+	"inventory-service-go/logging"
+)
+
+// Error is an error as the client sees it, with a code in its extensions as GraphQL clients commonly expect.
+type Error struct {
+	Message string
+	Code    string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code}
+}
+
+const (
+	CodeNotFound     = "NOT_FOUND"
+	CodeBadUserInput = "BAD_USER_INPUT"
+	CodeInternal     = "INTERNAL_SERVER_ERROR"
+)
+
+// resolverError maps a service error as commons.HandleServiceError does for the REST API: a missing row is NOT_FOUND,
+// bad input BAD_USER_INPUT and anything else is logged and reported without its details.
+func resolverError(ctx context.Context, err error) error {
+	var validationError *commons.ValidationError
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return &Error{Message: "not found", Code: CodeNotFound}
+	case errors.As(err, &validationError):
+		return &Error{Message: err.Error(), Code: CodeBadUserInput}
+	}
+	logging.FromContext(ctx).ErrorContext(ctx, "Unexpected service error", "error", err)
+	return &Error{Message: "internal error", Code: CodeInternal}
+}
+
+func parseId(field string, id graphql.ID) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.Nil, &Error{Message: "invalid " + field + ": " + string(id), Code: CodeBadUserInput}
+	}
+	return parsed, nil
+}
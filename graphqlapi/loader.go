@@ -0,0 +1,109 @@
+package graphqlapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// loader batches the keys loaded within wait of each other into a single fetch, as the dataloaders of other GraphQL
+// servers do, so resolving a field of every element of a list takes one query instead of one per element. It keeps
+// what it fetched, so a loader must not outlive its request.
+type loader[K comparable, V any] struct {
+	// fetch returns the values found, keys without a value are not found
+	fetch    func(ctx context.Context, keys []K) (map[K]V, error)
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending *batch[K, V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	found bool
+	err   error
+}
+
+type batch[K comparable, V any] struct {
+	keys       []K
+	results    []*result[V]
+	dispatched bool
+}
+
+func newLoader[K comparable, V any](wait time.Duration, maxBatch int, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		results:  map[K]*result[V]{},
+	}
+}
+
+// Load returns the value of key, waiting for the batch it ends up in.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, bool, error) {
+	l.mu.Lock()
+	r, ok := l.results[key]
+	if !ok {
+		r = &result[V]{done: make(chan struct{})}
+		l.results[key] = r
+		b := l.pending
+		if b == nil {
+			b = &batch[K, V]{}
+			l.pending = b
+			time.AfterFunc(l.wait, func() { l.dispatch(ctx, b) })
+		}
+		b.keys = append(b.keys, key)
+		b.results = append(b.results, r)
+		if len(b.keys) >= l.maxBatch {
+			l.pending = nil
+			go l.dispatch(ctx, b)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.value, r.found, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, false, ctx.Err()
+	}
+}
+
+// Clear forgets the value of key, after it was changed.
+func (l *loader[K, V]) Clear(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if r, ok := l.results[key]; ok {
+		select {
+		case <-r.done:
+			delete(l.results, key)
+		default:
+			// still being fetched, the waiting callers get what the fetch returns
+		}
+	}
+}
+
+func (l *loader[K, V]) dispatch(ctx context.Context, b *batch[K, V]) {
+	l.mu.Lock()
+	if l.pending == b {
+		l.pending = nil
+	}
+	if b.dispatched {
+		l.mu.Unlock()
+		return
+	}
+	b.dispatched = true
+	l.mu.Unlock()
+
+	values, err := l.fetch(ctx, b.keys)
+	for i, key := range b.keys {
+		r := b.results[i]
+		r.value, r.found = values[key]
+		r.err = err
+		close(r.done)
+	}
+}
@@ -0,0 +1,97 @@
+package graphqlapi
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingFetch doubles the keys it is asked for and records every batch.
+type countingFetch struct {
+	mu      sync.Mutex
+	batches [][]int
+	err     error
+}
+
+func (f *countingFetch) fetch(_ context.Context, keys []int) (map[int]int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	batch := append([]int{}, keys...)
+	sort.Ints(batch)
+	f.batches = append(f.batches, batch)
+	if f.err != nil {
+		return nil, f.err
+	}
+	values := map[int]int{}
+	for _, key := range keys {
+		if key >= 0 {
+			values[key] = key * 2
+		}
+	}
+	return values, nil
+}
+
+func loadAll(l *loader[int, int], keys ...int) {
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			_, _, _ = l.Load(context.Background(), key)
+		}(key)
+	}
+	wg.Wait()
+}
+
+func TestLoader_Batches(t *testing.T) {
+	f := &countingFetch{}
+	l := newLoader(10*time.Millisecond, 100, f.fetch)
+
+	loadAll(l, 1, 2, 3, 2, -1)
+	assert.Equal(t, [][]int{{-1, 1, 2, 3}}, f.batches)
+
+	value, found, err := l.Load(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 4, value)
+	_, found, err = l.Load(context.Background(), -1)
+	assert.NoError(t, err)
+	assert.False(t, found)
+	assert.Len(t, f.batches, 1, "loaded keys are kept")
+
+	l.Clear(2)
+	_, _, _ = l.Load(context.Background(), 2)
+	assert.Equal(t, []int{2}, f.batches[1])
+}
+
+func TestLoader_MaxBatch(t *testing.T) {
+	f := &countingFetch{}
+	l := newLoader(10*time.Millisecond, 2, f.fetch)
+
+	loadAll(l, 1, 2, 3)
+	assert.Len(t, f.batches, 2)
+	for _, batch := range f.batches {
+		assert.LessOrEqual(t, len(batch), 2)
+	}
+}
+
+func TestLoader_Error(t *testing.T) {
+	f := &countingFetch{err: errors.New("boom")}
+	l := newLoader(time.Millisecond, 100, f.fetch)
+
+	_, _, err := l.Load(context.Background(), 1)
+	assert.EqualError(t, err, "boom")
+}
+
+func TestLoader_Canceled(t *testing.T) {
+	l := newLoader(time.Hour, 100, (&countingFetch{}).fetch)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := l.Load(ctx, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+}
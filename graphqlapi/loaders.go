@@ -0,0 +1,78 @@
+package graphqlapi
+
+import (
+	gocontext "context"
+	"github.com/google/uuid"
+	"inventory-service-go/context"
+	"inventory-service-go/invoice"
+	"inventory-service-go/person"
+	"time"
+)
+
+const (
+	// loaderWait is how long a loader waits for more keys before it fetches.
+	loaderWait = 2 * time.Millisecond
+	// maxBatch bounds the keys of a fetch, and the resolvers running at once so a batch can fill up.
+	maxBatch = 100
+)
+
+// loaders are the loaders of a request, the relations of persons and invoices are resolved through them.
+type loaders struct {
+	persons        *loader[uuid.UUID, person.Person]
+	invoicesByUser *loader[uuid.UUID, []invoice.Invoice]
+	// invoices are the invoices with items, lines and tax
+	invoices *loader[uuid.UUID, invoice.Invoice]
+}
+
+type loadersKey struct{}
+
+func newLoaders(appContext context.ApplicationContext) *loaders {
+	persons := appContext.PersonService()
+	invoices := appContext.InvoiceService()
+	return &loaders{
+		persons: newLoader(loaderWait, maxBatch, func(ctx gocontext.Context, ids []uuid.UUID) (map[uuid.UUID]person.Person, error) {
+			found, err := persons.GetByIds(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uuid.UUID]person.Person, len(found))
+			for _, p := range found {
+				result[p.Id] = p
+			}
+			return result, nil
+		}),
+		invoicesByUser: newLoader(loaderWait, maxBatch, func(ctx gocontext.Context, userIds []uuid.UUID) (map[uuid.UUID][]invoice.Invoice, error) {
+			found, err := invoices.GetInvoicesForUsers(ctx, userIds)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uuid.UUID][]invoice.Invoice, len(userIds))
+			for _, userId := range userIds {
+				result[userId] = []invoice.Invoice{}
+			}
+			for _, i := range found {
+				result[i.UserId] = append(result[i.UserId], i)
+			}
+			return result, nil
+		}),
+		invoices: newLoader(loaderWait, maxBatch, func(ctx gocontext.Context, ids []uuid.UUID) (map[uuid.UUID]invoice.Invoice, error) {
+			found, err := invoices.GetInvoicesWithItems(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uuid.UUID]invoice.Invoice, len(found))
+			for _, i := range found {
+				result[i.Id] = i
+			}
+			return result, nil
+		}),
+	}
+}
+
+func withLoaders(ctx gocontext.Context, l *loaders) gocontext.Context {
+	return gocontext.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx gocontext.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,363 @@
+package graphqlapi
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+	"inventory-service-go/commons"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"inventory-service-go/person"
+	"inventory-service-go/promotion"
+	"strings"
+)
+
+type moneyInput struct {
+	Amount   string
+	Currency *string
+}
+
+// money reads an amount as the REST API does, in commons.DefaultCurrency when it has no currency.
+func (m moneyInput) money(ctx context.Context) (commons.Money, error) {
+	currency := commons.DefaultCurrency
+	if m.Currency != nil && *m.Currency != "" {
+		currency = *m.Currency
+	}
+	money, err := commons.ParseMoney(m.Amount, currency)
+	if err != nil {
+		return commons.Money{}, resolverError(ctx, err)
+	}
+	return money, nil
+}
+
+type discountInput struct {
+	Type  string
+	Value string
+}
+
+func (d discountInput) discount() promotion.Discount {
+	return promotion.Discount{Type: promotion.DiscountType(strings.ToLower(d.Type)), Value: d.Value}
+}
+
+func value[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+// invoiceChanged drops an invoice the loaders of the request may have read before the change.
+func invoiceChanged(ctx context.Context, i invoice.Invoice) {
+	l := loadersFrom(ctx)
+	l.invoices.Clear(i.Id)
+	l.invoicesByUser.Clear(i.UserId)
+}
+
+func (r *resolver) CreatePerson(ctx context.Context, args struct {
+	Input struct {
+		Name  string
+		Email string
+	}
+}) (*personResolver, error) {
+	p, err := r.appContext.PersonService().Create(ctx, person.CreatePersonRequest{
+		Name:      args.Input.Name,
+		Email:     args.Input.Email,
+		CreatedBy: actorFrom(ctx),
+	})
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	return &personResolver{p: *p}, nil
+}
+
+func (r *resolver) UpdatePerson(ctx context.Context, args struct {
+	Input struct {
+		ID    graphql.ID
+		Name  string
+		Email string
+	}
+}) (*personResolver, error) {
+	id, err := parseId("id", args.Input.ID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := r.appContext.PersonService().Update(ctx, person.UpdatePersonRequest{
+		Id:            id,
+		Name:          args.Input.Name,
+		Email:         args.Input.Email,
+		LastChangedBy: actorFrom(ctx),
+	})
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	loadersFrom(ctx).persons.Clear(id)
+	return &personResolver{p: *p}, nil
+}
+
+func (r *resolver) DeletePerson(ctx context.Context, args idArgs) (*deleteResultResolver, error) {
+	id, err := parseId("id", args.ID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.appContext.PersonService().DeleteByUuid(ctx, id)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	loadersFrom(ctx).persons.Clear(id)
+	return &deleteResultResolver{r: *result}, nil
+}
+
+func (r *resolver) CreateItem(ctx context.Context, args struct {
+	Input struct {
+		Name        string
+		Description string
+		UnitPrice   moneyInput
+		TaxCategory *string
+	}
+}) (*itemResolver, error) {
+	unitPrice, err := args.Input.UnitPrice.money(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i, err := r.appContext.ItemService().CreateItem(ctx, item.CreateItemRequest{
+		Name:        args.Input.Name,
+		Description: args.Input.Description,
+		UnitPrice:   unitPrice,
+		TaxCategory: value(args.Input.TaxCategory),
+		CreatedBy:   actorFrom(ctx),
+	})
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	return &itemResolver{i: *i}, nil
+}
+
+func (r *resolver) UpdateItem(ctx context.Context, args struct {
+	Input struct {
+		ID          graphql.ID
+		Name        string
+		Description string
+		UnitPrice   moneyInput
+		TaxCategory *string
+	}
+}) (*itemResolver, error) {
+	id, err := parseId("id", args.Input.ID)
+	if err != nil {
+		return nil, err
+	}
+	unitPrice, err := args.Input.UnitPrice.money(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i, err := r.appContext.ItemService().UpdateItem(ctx, item.UpdateItemRequest{
+		Id:            id,
+		Name:          args.Input.Name,
+		Description:   args.Input.Description,
+		UnitPrice:     unitPrice,
+		TaxCategory:   value(args.Input.TaxCategory),
+		LastChangedBy: actorFrom(ctx),
+	})
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	return &itemResolver{i: *i}, nil
+}
+
+func (r *resolver) DeleteItem(ctx context.Context, args idArgs) (*deleteResultResolver, error) {
+	id, err := parseId("id", args.ID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.appContext.ItemService().DeleteItem(ctx, id)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	return &deleteResultResolver{r: *result}, nil
+}
+
+func (r *resolver) CreateInvoice(ctx context.Context, args struct {
+	Input struct {
+		UserID           graphql.ID
+		Paid             *bool
+		Total            moneyInput
+		TaxJurisdiction  *string
+		PricesIncludeTax *bool
+	}
+}) (*invoiceResolver, error) {
+	userId, err := parseId("userId", args.Input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	total, err := args.Input.Total.money(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i, err := r.appContext.InvoiceService().CreateInvoice(ctx, invoice.CreateInvoiceRequest{
+		UserId:           userId,
+		Paid:             value(args.Input.Paid),
+		Total:            total,
+		TaxJurisdiction:  value(args.Input.TaxJurisdiction),
+		PricesIncludeTax: value(args.Input.PricesIncludeTax),
+		CreatedBy:        actorFrom(ctx),
+	})
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	invoiceChanged(ctx, i)
+	return &invoiceResolver{i: i}, nil
+}
+
+func (r *resolver) UpdateInvoice(ctx context.Context, args struct {
+	Input struct {
+		ID   graphql.ID
+		Paid bool
+	}
+}) (*invoiceResolver, error) {
+	id, err := parseId("id", args.Input.ID)
+	if err != nil {
+		return nil, err
+	}
+	i, err := r.appContext.InvoiceService().UpdateInvoice(ctx, invoice.UpdateInvoiceRequest{
+		Id:            id,
+		Paid:          args.Input.Paid,
+		LastChangedBy: actorFrom(ctx),
+	})
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	invoiceChanged(ctx, i)
+	return &invoiceResolver{i: i}, nil
+}
+
+func (r *resolver) DeleteInvoice(ctx context.Context, args idArgs) (*deleteResultResolver, error) {
+	id, err := parseId("id", args.ID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.appContext.InvoiceService().DeleteInvoice(ctx, id)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	loadersFrom(ctx).invoices.Clear(id)
+	return &deleteResultResolver{r: result}, nil
+}
+
+func (r *resolver) AddItemsToInvoice(ctx context.Context, args struct {
+	Input struct {
+		InvoiceID graphql.ID
+		Items     []struct {
+			ItemID   graphql.ID
+			Quantity *int32
+			Discount *discountInput
+		}
+	}
+}) (*itemsToInvoiceResolver, error) {
+	invoiceId, err := parseId("invoiceId", args.Input.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	request := invoice.ItemsToInvoiceRequest{InvoiceId: invoiceId}
+	for _, line := range args.Input.Items {
+		itemId, err := parseId("itemId", line.ItemID)
+		if err != nil {
+			return nil, err
+		}
+		request.Items = append(request.Items, itemId)
+		if line.Quantity != nil {
+			if request.Quantities == nil {
+				request.Quantities = map[uuid.UUID]int64{}
+			}
+			request.Quantities[itemId] = int64(*line.Quantity)
+		}
+		if line.Discount != nil {
+			if request.Discounts == nil {
+				request.Discounts = map[uuid.UUID]promotion.Discount{}
+			}
+			request.Discounts[itemId] = line.Discount.discount()
+		}
+	}
+	response, err := r.appContext.InvoiceService().AddItemsToInvoice(ctx, request)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	loadersFrom(ctx).invoices.Clear(invoiceId)
+	return &itemsToInvoiceResolver{r: response}, nil
+}
+
+func (r *resolver) RemoveItemFromInvoice(ctx context.Context, args struct {
+	InvoiceID graphql.ID
+	ItemID    graphql.ID
+}) (*itemsToInvoiceResolver, error) {
+	invoiceId, err := parseId("invoiceId", args.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	itemId, err := parseId("itemId", args.ItemID)
+	if err != nil {
+		return nil, err
+	}
+	response, err := r.appContext.InvoiceService().RemoveItemFromInvoice(ctx, invoice.SimpleInvoiceItem{InvoiceId: invoiceId, ItemId: itemId})
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	loadersFrom(ctx).invoices.Clear(invoiceId)
+	return &itemsToInvoiceResolver{r: response}, nil
+}
+
+func (r *resolver) AddInvoiceDiscount(ctx context.Context, args struct {
+	Input struct {
+		InvoiceID   graphql.ID
+		Discount    discountInput
+		Description *string
+	}
+}) (*invoiceResolver, error) {
+	invoiceId, err := parseId("invoiceId", args.Input.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	i, err := r.appContext.InvoiceService().AddDiscount(ctx, invoice.InvoiceDiscountRequest{
+		InvoiceId:   invoiceId,
+		Discount:    args.Input.Discount.discount(),
+		Description: value(args.Input.Description),
+		CreatedBy:   actorFrom(ctx),
+	})
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	invoiceChanged(ctx, i)
+	return &invoiceResolver{i: i}, nil
+}
+
+func (r *resolver) ApplyPromotion(ctx context.Context, args struct {
+	InvoiceID graphql.ID
+	Code      string
+}) (*invoiceResolver, error) {
+	invoiceId, err := parseId("invoiceId", args.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	i, err := r.appContext.InvoiceService().ApplyPromotion(ctx, invoice.PromotionCodeRequest{
+		InvoiceId: invoiceId,
+		Code:      args.Code,
+		CreatedBy: actorFrom(ctx),
+	})
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	invoiceChanged(ctx, i)
+	return &invoiceResolver{i: i}, nil
+}
+
+func (r *resolver) IssueInvoice(ctx context.Context, args idArgs) (*invoiceResolver, error) {
+	id, err := parseId("id", args.ID)
+	if err != nil {
+		return nil, err
+	}
+	i, err := r.appContext.InvoiceService().IssueInvoice(ctx, invoice.IssueInvoiceRequest{Id: id, IssuedBy: actorFrom(ctx)})
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	invoiceChanged(ctx, i)
+	return &invoiceResolver{i: i}, nil
+}
@@ -0,0 +1,122 @@
+package graphqlapi
+
+import (
+	gocontext "context"
+	"database/sql"
+	"errors"
+	"github.com/graph-gophers/graphql-go"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+)
+
+// defaultPageSize is the page size of a list given after but not first, as in the REST API.
+const defaultPageSize = 10
+
+// resolver is the root of the schema, the queries and mutations call the services of the application context.
+type resolver struct {
+	appContext context.ApplicationContext
+}
+
+type idArgs struct {
+	ID graphql.ID
+}
+
+type listArgs struct {
+	After *int32
+	First *int32
+}
+
+// pagination is nil, for everything, unless after or first is given.
+func (a listArgs) pagination() *commons.Pagination {
+	if a.After == nil && a.First == nil {
+		return nil
+	}
+	pagination := &commons.Pagination{PageSize: defaultPageSize}
+	if a.After != nil {
+		pagination.LastId = int(*a.After)
+	}
+	if a.First != nil {
+		pagination.PageSize = int(*a.First)
+	}
+	return pagination
+}
+
+func (r *resolver) Person(ctx gocontext.Context, args idArgs) (*personResolver, error) {
+	id, err := parseId("id", args.ID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := r.appContext.PersonService().GetById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	return &personResolver{p: *p}, nil
+}
+
+func (r *resolver) Persons(ctx gocontext.Context, args listArgs) ([]*personResolver, error) {
+	persons, err := r.appContext.PersonService().GetAll(ctx, args.pagination())
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	return personResolvers(persons), nil
+}
+
+func (r *resolver) Item(ctx gocontext.Context, args idArgs) (*itemResolver, error) {
+	id, err := parseId("id", args.ID)
+	if err != nil {
+		return nil, err
+	}
+	i, err := r.appContext.ItemService().GetItem(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	return &itemResolver{i: *i}, nil
+}
+
+func (r *resolver) Items(ctx gocontext.Context, args listArgs) ([]*itemResolver, error) {
+	items, err := r.appContext.ItemService().GetItems(ctx, args.pagination())
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	return itemResolvers(items), nil
+}
+
+func (r *resolver) Invoice(ctx gocontext.Context, args idArgs) (*invoiceResolver, error) {
+	id, err := parseId("id", args.ID)
+	if err != nil {
+		return nil, err
+	}
+	i, err := r.appContext.InvoiceService().GetInvoice(ctx, id, false)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	return &invoiceResolver{i: i}, nil
+}
+
+func (r *resolver) InvoiceByNumber(ctx gocontext.Context, args struct{ Number string }) (*invoiceResolver, error) {
+	i, err := r.appContext.InvoiceService().GetInvoiceByNumber(ctx, args.Number)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	return &invoiceResolver{i: i}, nil
+}
+
+func (r *resolver) Invoices(ctx gocontext.Context, args listArgs) ([]*invoiceResolver, error) {
+	invoices, err := r.appContext.InvoiceService().GetAllInvoices(ctx, args.pagination())
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	return invoiceResolvers(invoices), nil
+}
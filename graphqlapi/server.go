@@ -0,0 +1,68 @@
+// Package graphqlapi serves persons, items and invoices, and the relations between them, as a GraphQL schema. It goes
+// through the same services as the REST API, loading the relations of many objects at once.
+package graphqlapi
+
+import (
+	gocontext "context"
+	_ "embed"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+	"inventory-service-go/context"
+	"inventory-service-go/logging"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// maxDepth bounds the nesting of a query, persons and their invoices could otherwise be nested without end.
+const maxDepth = 8
+
+// Request is a GraphQL request as clients post it.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type Server struct {
+	appContext context.ApplicationContext
+	schema     *graphql.Schema
+}
+
+func NewServer(appContext context.ApplicationContext) *Server {
+	return &Server{
+		appContext: appContext,
+		schema: graphql.MustParseSchema(schema, &resolver{appContext: appContext},
+			graphql.UseFieldResolvers(),
+			graphql.MaxDepth(maxDepth),
+			graphql.MaxParallelism(maxBatch),
+			graphql.PanicHandler(panicHandler{})),
+	}
+}
+
+// Exec runs a request, with loaders of its own.
+func (s *Server) Exec(ctx gocontext.Context, request Request) *graphql.Response {
+	ctx = withLoaders(ctx, newLoaders(s.appContext))
+	return s.schema.Exec(ctx, request.Query, request.OperationName, request.Variables)
+}
+
+type actorKey struct{}
+
+// WithActor attributes the changes the mutations run with ctx make to actor, the user or API key of the request, the
+// clients cannot name one.
+func WithActor(ctx gocontext.Context, actor string) gocontext.Context {
+	return gocontext.WithValue(ctx, actorKey{}, actor)
+}
+
+func actorFrom(ctx gocontext.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// panicHandler logs a panic of a resolver and reports it without its details.
+type panicHandler struct{}
+
+func (panicHandler) MakePanicError(ctx gocontext.Context, value interface{}) *errors.QueryError {
+	logging.FromContext(ctx).ErrorContext(ctx, "Panic resolving a query", "panic", value)
+	return &errors.QueryError{Message: "internal error", Extensions: map[string]interface{}{"code": CodeInternal}}
+}
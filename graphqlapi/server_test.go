@@ -0,0 +1,224 @@
+package graphqlapi
+
+import (
+	gocontext "context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"inventory-service-go/person"
+	"inventory-service-go/promotion"
+	"testing"
+)
+
+type mocks struct {
+	persons  *person.MockPersonService
+	items    *item.MockItemService
+	invoices *invoice.MockInvoiceService
+}
+
+func newServer(t *testing.T) (*Server, mocks) {
+	controller := gomock.NewController(t)
+	m := mocks{
+		persons:  person.NewMockPersonService(controller),
+		items:    item.NewMockItemService(controller),
+		invoices: invoice.NewMockInvoiceService(controller),
+	}
+	return NewServer(context.MockApplicationContext(m.persons, m.items, m.invoices)), m
+}
+
+// exec runs the query and returns its data and errors as plain JSON values.
+func exec(t *testing.T, server *Server, query string, variables map[string]interface{}) (map[string]interface{}, []map[string]interface{}) {
+	response := server.Exec(WithActor(gocontext.Background(), "unit_test"), Request{Query: query, Variables: variables})
+	var data map[string]interface{}
+	if len(response.Data) > 0 {
+		if err := json.Unmarshal(response.Data, &data); err != nil {
+			t.Fatalf("unexpected data: %v", err)
+		}
+	}
+	var errs []map[string]interface{}
+	for _, err := range response.Errors {
+		errs = append(errs, map[string]interface{}{"message": err.Message, "extensions": err.Extensions})
+	}
+	return data, errs
+}
+
+func TestNewServer_Schema(t *testing.T) {
+	assert.NotPanics(t, func() { newServer(t) })
+}
+
+func TestInvoice_Nested(t *testing.T) {
+	server, m := newServer(t)
+	invoiceId, userId, itemId := uuid.New(), uuid.New(), uuid.New()
+	basic := invoice.Invoice{Seq: 1, Id: invoiceId, UserId: userId, Total: commons.NewMoney(2500, "EUR"), Items: []item.Item{}}
+	detailed := basic
+	detailed.Items = []item.Item{{Seq: 3, Id: itemId, Name: "widget", UnitPrice: commons.NewMoney(1250, "EUR")}}
+	detailed.Lines = []invoice.InvoiceLine{{ItemId: itemId, UnitPrice: commons.NewMoney(1250, "EUR"), Quantity: 2, Discount: &promotion.Discount{Type: promotion.Percentage, Value: "10"}}}
+
+	m.invoices.EXPECT().GetInvoice(gomock.Any(), invoiceId, false).Return(basic, nil)
+	m.persons.EXPECT().GetByIds(gomock.Any(), []uuid.UUID{userId}).Return([]person.Person{{Id: userId, Name: "Ann"}}, nil)
+	m.invoices.EXPECT().GetInvoicesWithItems(gomock.Any(), []uuid.UUID{invoiceId}).Return([]invoice.Invoice{detailed}, nil)
+
+	data, errs := exec(t, server, `query($id: ID!) {
+		invoice(id: $id) {
+			total { amount currency }
+			user { name }
+			items { name unitPrice { amount } }
+			lines { quantity discount { type value } }
+		}
+	}`, map[string]interface{}{"id": invoiceId.String()})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]interface{}{
+		"total": map[string]interface{}{"amount": "25.00", "currency": "EUR"},
+		"user":  map[string]interface{}{"name": "Ann"},
+		"items": []interface{}{map[string]interface{}{"name": "widget", "unitPrice": map[string]interface{}{"amount": "12.50"}}},
+		"lines": []interface{}{map[string]interface{}{"quantity": float64(2), "discount": map[string]interface{}{"type": "PERCENTAGE", "value": "10"}}},
+	}, data["invoice"])
+}
+
+func TestInvoices_BatchesUsers(t *testing.T) {
+	server, m := newServer(t)
+	ann, bob := uuid.New(), uuid.New()
+	var invoices []invoice.Invoice
+	for i := 0; i < 20; i++ {
+		userId := ann
+		if i%2 == 1 {
+			userId = bob
+		}
+		invoices = append(invoices, invoice.Invoice{Seq: i + 1, Id: uuid.New(), UserId: userId})
+	}
+	m.invoices.EXPECT().GetAllInvoices(gomock.Any(), nil).Return(invoices, nil)
+	m.persons.EXPECT().GetByIds(gomock.Any(), gomock.Any()).DoAndReturn(func(_ gocontext.Context, ids []uuid.UUID) ([]person.Person, error) {
+		assert.ElementsMatch(t, []uuid.UUID{ann, bob}, ids)
+		return []person.Person{{Id: ann, Name: "Ann"}}, nil
+	}).Times(1)
+
+	data, errs := exec(t, server, `{ invoices { seq user { name } } }`, nil)
+	assert.Empty(t, errs)
+	list := data["invoices"].([]interface{})
+	assert.Len(t, list, 20)
+	assert.Equal(t, map[string]interface{}{"name": "Ann"}, list[0].(map[string]interface{})["user"])
+	assert.Nil(t, list[1].(map[string]interface{})["user"], "a person that does not exist is null")
+}
+
+func TestPersons_Invoices(t *testing.T) {
+	server, m := newServer(t)
+	ann, bob := uuid.New(), uuid.New()
+	pagination := &commons.Pagination{LastId: 5, PageSize: 2}
+	m.persons.EXPECT().GetAll(gomock.Any(), pagination).Return([]person.Person{{Seq: 6, Id: ann}, {Seq: 7, Id: bob}}, nil)
+	m.invoices.EXPECT().GetInvoicesForUsers(gomock.Any(), gomock.Any()).
+		Return([]invoice.Invoice{{Seq: 1, UserId: ann}, {Seq: 2, UserId: ann}}, nil).Times(1)
+
+	data, errs := exec(t, server, `{ persons(after: 5, first: 2) { seq invoices { seq } } }`, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"seq": float64(6), "invoices": []interface{}{map[string]interface{}{"seq": float64(1)}, map[string]interface{}{"seq": float64(2)}}},
+		map[string]interface{}{"seq": float64(7), "invoices": []interface{}{}},
+	}, data["persons"])
+}
+
+func TestErrors(t *testing.T) {
+	server, m := newServer(t)
+	id := uuid.New()
+
+	m.persons.EXPECT().GetById(gomock.Any(), id).Return(&person.Person{}, sql.ErrNoRows)
+	data, errs := exec(t, server, `query($id: ID!) { person(id: $id) { name } }`, map[string]interface{}{"id": id.String()})
+	assert.Empty(t, errs)
+	assert.Nil(t, data["person"], "a missing person is null")
+
+	_, errs = exec(t, server, `{ person(id: "not-a-uuid") { name } }`, nil)
+	assert.Equal(t, CodeBadUserInput, errs[0]["extensions"].(map[string]interface{})["code"])
+
+	m.items.EXPECT().GetItem(gomock.Any(), id).Return(nil, errors.New("password authentication failed"))
+	_, errs = exec(t, server, `query($id: ID!) { item(id: $id) { name } }`, map[string]interface{}{"id": id.String()})
+	assert.Equal(t, "internal error", errs[0]["message"])
+	assert.Equal(t, CodeInternal, errs[0]["extensions"].(map[string]interface{})["code"])
+
+	m.persons.EXPECT().DeleteByUuid(gomock.Any(), id).Return(nil, sql.ErrNoRows)
+	_, errs = exec(t, server, `mutation($id: ID!) { deletePerson(id: $id) { deleted } }`, map[string]interface{}{"id": id.String()})
+	assert.Equal(t, CodeNotFound, errs[0]["extensions"].(map[string]interface{})["code"])
+}
+
+func TestMaxDepth(t *testing.T) {
+	server, _ := newServer(t)
+	_, errs := exec(t, server, `{ persons { invoices { user { invoices { user { invoices { user { invoices { seq } } } } } } } } }`, nil)
+	assert.NotEmpty(t, errs)
+}
+
+func TestMutation_CreateItem(t *testing.T) {
+	server, m := newServer(t)
+	m.items.EXPECT().CreateItem(gomock.Any(), item.CreateItemRequest{
+		Name:        "widget",
+		Description: "a widget",
+		UnitPrice:   commons.NewMoney(1250, commons.DefaultCurrency),
+		CreatedBy:   "unit_test",
+	}).Return(&item.Item{Id: uuid.New(), Name: "widget", UnitPrice: commons.NewMoney(1250, "USD")}, nil)
+
+	data, errs := exec(t, server, `mutation {
+		createItem(input: {name: "widget", description: "a widget", unitPrice: {amount: "12.50"}}) {
+			name unitPrice { amount currency }
+		}
+	}`, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]interface{}{"name": "widget", "unitPrice": map[string]interface{}{"amount": "12.50", "currency": "USD"}}, data["createItem"])
+
+	_, errs = exec(t, server, `mutation {
+		createItem(input: {name: "widget", description: "", unitPrice: {amount: "twelve"}}) { name }
+	}`, nil)
+	assert.Equal(t, CodeBadUserInput, errs[0]["extensions"].(map[string]interface{})["code"])
+
+	// the changes are attributed to the request, not to whom the client names
+	_, errs = exec(t, server, `mutation {
+		createItem(input: {name: "widget", description: "", unitPrice: {amount: "12.50"}, createdBy: "someone_else"}) { name }
+	}`, nil)
+	assert.NotEmpty(t, errs)
+}
+
+func TestMutation_AddItemsToInvoice(t *testing.T) {
+	server, m := newServer(t)
+	invoiceId, first, second := uuid.New(), uuid.New(), uuid.New()
+	m.invoices.EXPECT().AddItemsToInvoice(gomock.Any(), invoice.ItemsToInvoiceRequest{
+		InvoiceId:  invoiceId,
+		Items:      []uuid.UUID{first, second},
+		Quantities: map[uuid.UUID]int64{first: 3},
+		Discounts:  map[uuid.UUID]promotion.Discount{second: {Type: promotion.FixedAmount, Value: "1.50"}},
+	}).Return(invoice.ItemsToInvoiceResponse{InvoiceId: invoiceId, Items: []uuid.UUID{first, second}, Success: true}, nil)
+
+	data, errs := exec(t, server, `mutation($invoice: ID!, $first: ID!, $second: ID!) {
+		addItemsToInvoice(input: {invoiceId: $invoice, items: [{itemId: $first, quantity: 3}, {itemId: $second, discount: {type: FIXED, value: "1.50"}}]}) {
+			success items
+		}
+	}`, map[string]interface{}{"invoice": invoiceId.String(), "first": first.String(), "second": second.String()})
+	assert.Empty(t, errs)
+	assert.Equal(t, map[string]interface{}{"success": true, "items": []interface{}{first.String(), second.String()}}, data["addItemsToInvoice"])
+}
+
+func TestMutation_ClearsLoaders(t *testing.T) {
+	server, m := newServer(t)
+	invoiceId, userId := uuid.New(), uuid.New()
+	before := invoice.Invoice{Id: invoiceId, UserId: userId, Items: []item.Item{}}
+	after := invoice.Invoice{Id: invoiceId, UserId: userId, Items: []item.Item{{Id: uuid.New(), Name: "widget"}}}
+	gomock.InOrder(
+		m.invoices.EXPECT().GetInvoicesWithItems(gomock.Any(), []uuid.UUID{invoiceId}).Return([]invoice.Invoice{before}, nil),
+		m.invoices.EXPECT().IssueInvoice(gomock.Any(), invoice.IssueInvoiceRequest{Id: invoiceId, IssuedBy: "unit_test"}).Return(before, nil),
+		m.invoices.EXPECT().GetInvoicesWithItems(gomock.Any(), []uuid.UUID{invoiceId}).Return([]invoice.Invoice{after}, nil),
+	)
+
+	ctx := withLoaders(gocontext.Background(), newLoaders(server.appContext))
+	loaded, _, err := loadersFrom(ctx).invoices.Load(ctx, invoiceId)
+	assert.NoError(t, err)
+	assert.Equal(t, before, loaded)
+	r := &resolver{appContext: server.appContext}
+	_, err = r.IssueInvoice(WithActor(ctx, "unit_test"), idArgs{ID: graphql.ID(invoiceId.String())})
+	assert.NoError(t, err)
+	reloaded, _, err := loadersFrom(ctx).invoices.Load(ctx, invoiceId)
+	assert.NoError(t, err)
+	assert.Equal(t, after, reloaded, "an issued invoice is fetched again")
+}
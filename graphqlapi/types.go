@@ -0,0 +1,224 @@
+package graphqlapi
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+	"inventory-service-go/commons"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"inventory-service-go/person"
+	"inventory-service-go/promotion"
+	"inventory-service-go/tax"
+	"strings"
+)
+
+// Money, AuditInfo, TaxBreakdown and TaxSummary are resolved from the fields and methods of their Go types.
+
+func toId(id uuid.UUID) graphql.ID {
+	return graphql.ID(id.String())
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+type personResolver struct {
+	p person.Person
+}
+
+func (r *personResolver) Seq() int32                   { return int32(r.p.Seq) }
+func (r *personResolver) ID() graphql.ID               { return toId(r.p.Id) }
+func (r *personResolver) Name() string                 { return r.p.Name }
+func (r *personResolver) Email() string                { return r.p.Email }
+func (r *personResolver) AuditInfo() commons.AuditInfo { return r.p.AuditInfo }
+
+func (r *personResolver) Invoices(ctx context.Context) ([]*invoiceResolver, error) {
+	invoices, _, err := loadersFrom(ctx).invoicesByUser.Load(ctx, r.p.Id)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	return invoiceResolvers(invoices), nil
+}
+
+func personResolvers(persons []person.Person) []*personResolver {
+	result := make([]*personResolver, 0, len(persons))
+	for _, p := range persons {
+		result = append(result, &personResolver{p: p})
+	}
+	return result
+}
+
+type itemResolver struct {
+	i item.Item
+}
+
+func (r *itemResolver) Seq() int32                   { return int32(r.i.Seq) }
+func (r *itemResolver) ID() graphql.ID               { return toId(r.i.Id) }
+func (r *itemResolver) Name() string                 { return r.i.Name }
+func (r *itemResolver) Description() string          { return r.i.Description }
+func (r *itemResolver) UnitPrice() commons.Money     { return r.i.UnitPrice }
+func (r *itemResolver) TaxCategory() string          { return r.i.TaxCategory }
+func (r *itemResolver) AuditInfo() commons.AuditInfo { return r.i.AuditInfo }
+
+func itemResolvers(items []item.Item) []*itemResolver {
+	result := make([]*itemResolver, 0, len(items))
+	for _, i := range items {
+		result = append(result, &itemResolver{i: i})
+	}
+	return result
+}
+
+// invoiceResolver resolves the fields the invoices are listed with from the invoice it has, and loads the invoice with
+// its items, lines and tax for the others.
+type invoiceResolver struct {
+	i invoice.Invoice
+}
+
+func (r *invoiceResolver) Seq() int32                   { return int32(r.i.Seq) }
+func (r *invoiceResolver) ID() graphql.ID               { return toId(r.i.Id) }
+func (r *invoiceResolver) UserID() graphql.ID           { return toId(r.i.UserId) }
+func (r *invoiceResolver) Total() commons.Money         { return r.i.Total }
+func (r *invoiceResolver) TaxJurisdiction() string      { return r.i.TaxJurisdiction }
+func (r *invoiceResolver) PricesIncludeTax() bool       { return r.i.PricesIncludeTax }
+func (r *invoiceResolver) Paid() bool                   { return r.i.Paid }
+func (r *invoiceResolver) Number() *string              { return optional(r.i.Number) }
+func (r *invoiceResolver) IssuedAt() *string            { return optional(r.i.IssuedAt) }
+func (r *invoiceResolver) AuditInfo() commons.AuditInfo { return r.i.AuditInfo }
+
+func (r *invoiceResolver) User(ctx context.Context) (*personResolver, error) {
+	p, found, err := loadersFrom(ctx).persons.Load(ctx, r.i.UserId)
+	if err != nil {
+		return nil, resolverError(ctx, err)
+	}
+	if !found {
+		return nil, nil
+	}
+	return &personResolver{p: p}, nil
+}
+
+// details returns the invoice with its items, lines and tax, an invoice deleted in the meantime has none.
+func (r *invoiceResolver) details(ctx context.Context) (invoice.Invoice, error) {
+	detailed, found, err := loadersFrom(ctx).invoices.Load(ctx, r.i.Id)
+	if err != nil {
+		return invoice.Invoice{}, resolverError(ctx, err)
+	}
+	if !found {
+		return r.i, nil
+	}
+	return detailed, nil
+}
+
+func (r *invoiceResolver) Items(ctx context.Context) ([]*itemResolver, error) {
+	detailed, err := r.details(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return itemResolvers(detailed.Items), nil
+}
+
+func (r *invoiceResolver) Lines(ctx context.Context) ([]*lineResolver, error) {
+	detailed, err := r.details(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*lineResolver, 0, len(detailed.Lines))
+	for _, line := range detailed.Lines {
+		result = append(result, &lineResolver{l: line})
+	}
+	return result, nil
+}
+
+func (r *invoiceResolver) Discounts(ctx context.Context) ([]*invoiceDiscountResolver, error) {
+	detailed, err := r.details(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*invoiceDiscountResolver, 0, len(detailed.Discounts))
+	for _, discount := range detailed.Discounts {
+		result = append(result, &invoiceDiscountResolver{d: discount})
+	}
+	return result, nil
+}
+
+func (r *invoiceResolver) Tax(ctx context.Context) (*tax.Breakdown, error) {
+	detailed, err := r.details(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return detailed.Tax, nil
+}
+
+func invoiceResolvers(invoices []invoice.Invoice) []*invoiceResolver {
+	result := make([]*invoiceResolver, 0, len(invoices))
+	for _, i := range invoices {
+		result = append(result, &invoiceResolver{i: i})
+	}
+	return result
+}
+
+type lineResolver struct {
+	l invoice.InvoiceLine
+}
+
+func (r *lineResolver) ItemID() graphql.ID            { return toId(r.l.ItemId) }
+func (r *lineResolver) UnitPrice() commons.Money      { return r.l.UnitPrice }
+func (r *lineResolver) Quantity() int32               { return int32(r.l.Quantity) }
+func (r *lineResolver) DiscountAmount() commons.Money { return r.l.DiscountAmount }
+func (r *lineResolver) TaxCategory() string           { return r.l.TaxCategory }
+func (r *lineResolver) TaxRate() string               { return r.l.TaxRate }
+func (r *lineResolver) Net() commons.Money            { return r.l.Net }
+func (r *lineResolver) Tax() commons.Money            { return r.l.Tax }
+func (r *lineResolver) Gross() commons.Money          { return r.l.Gross }
+
+func (r *lineResolver) Discount() *discountResolver {
+	if r.l.Discount == nil {
+		return nil
+	}
+	return &discountResolver{d: *r.l.Discount}
+}
+
+// discountResolver resolves a discount, its type is the DiscountType enum value, the upper case promotion.DiscountType.
+type discountResolver struct {
+	d promotion.Discount
+}
+
+func (r *discountResolver) Type() string  { return strings.ToUpper(string(r.d.Type)) }
+func (r *discountResolver) Value() string { return r.d.Value }
+
+type invoiceDiscountResolver struct {
+	d invoice.InvoiceDiscount
+}
+
+func (r *invoiceDiscountResolver) ID() graphql.ID      { return toId(r.d.Id) }
+func (r *invoiceDiscountResolver) Code() *string       { return optional(r.d.Code) }
+func (r *invoiceDiscountResolver) Description() string { return r.d.Description }
+func (r *invoiceDiscountResolver) Discount() *discountResolver {
+	return &discountResolver{d: r.d.Discount}
+}
+func (r *invoiceDiscountResolver) Amount() commons.Money { return r.d.Amount }
+
+type deleteResultResolver struct {
+	r commons.DeleteResult
+}
+
+func (r *deleteResultResolver) ID() graphql.ID { return toId(r.r.Id) }
+func (r *deleteResultResolver) Deleted() bool  { return r.r.Deleted }
+
+type itemsToInvoiceResolver struct {
+	r invoice.ItemsToInvoiceResponse
+}
+
+func (r *itemsToInvoiceResolver) InvoiceID() graphql.ID { return toId(r.r.InvoiceId) }
+func (r *itemsToInvoiceResolver) Success() bool         { return r.r.Success }
+
+func (r *itemsToInvoiceResolver) Items() []graphql.ID {
+	result := make([]graphql.ID, 0, len(r.r.Items))
+	for _, id := range r.r.Items {
+		result = append(result, toId(id))
+	}
+	return result
+}
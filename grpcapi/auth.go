@@ -0,0 +1,86 @@
+package grpcapi
+
+import (
+	gocontext "context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"inventory-service-go/auth"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/logging"
+	"strings"
+)
+
+type claimsKey struct{}
+
+// Claims returns the claims of the token the call was made with.
+func Claims(ctx gocontext.Context) (*auth.Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*auth.Claims)
+	return claims, ok
+}
+
+// interceptors authenticate every call and give its context a request id and the logger, as the REST middlewares do.
+type interceptors struct {
+	appContext context.ApplicationContext
+}
+
+func (i interceptors) unary(ctx gocontext.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := i.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (i interceptors) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+func (i interceptors) authenticate(ctx gocontext.Context, method string) (gocontext.Context, error) {
+	ctx = logging.WithLogger(logging.WithRequestId(ctx, uuid.NewString()), i.appContext.Logger())
+	if strings.HasPrefix(method, "/grpc.reflection.") {
+		return ctx, nil
+	}
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	claims, err := i.appContext.AuthProvider().ValidateToken(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	// the calls see the data of the tenant of the token only, as with handlers.TenantScope
+	tenant, err := claims.TenantId()
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid tenant")
+	}
+	return gocontext.WithValue(commons.WithTenant(ctx, tenant), claimsKey{}, claims), nil
+}
+
+func bearerToken(ctx gocontext.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		scheme, token, found := strings.Cut(value, " ")
+		if found && strings.EqualFold(scheme, "Bearer") && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+// contextStream replaces the context of a stream, which grpc.ServerStream has no setter for.
+type contextStream struct {
+	grpc.ServerStream
+	ctx gocontext.Context
+}
+
+func (s *contextStream) Context() gocontext.Context {
+	return s.ctx
+}
@@ -0,0 +1,50 @@
+package grpcapi
+
+import (
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service-go/commons"
+	inventoryv1 "inventory-service-go/proto/inventory/v1"
+)
+
+func parseId(field string, id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid %s: %q", field, id)
+	}
+	return parsed, nil
+}
+
+func toMoney(m commons.Money) *inventoryv1.Money {
+	return &inventoryv1.Money{Amount: m.Amount(), Currency: m.Currency}
+}
+
+// fromMoney reads an amount as the REST API does, in commons.DefaultCurrency when it has no currency.
+func fromMoney(m *inventoryv1.Money) (commons.Money, error) {
+	if m == nil {
+		return commons.Money{}, nil
+	}
+	currency := m.GetCurrency()
+	if currency == "" {
+		currency = commons.DefaultCurrency
+	}
+	money, err := commons.ParseMoney(m.GetAmount(), currency)
+	if err != nil {
+		return commons.Money{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return money, nil
+}
+
+func toAuditInfo(a commons.AuditInfo) *inventoryv1.AuditInfo {
+	return &inventoryv1.AuditInfo{
+		CreatedBy:     a.CreatedBy,
+		CreatedAt:     a.CreatedAt,
+		LastChangedBy: a.LastChangedBy,
+		LastUpdate:    a.LastUpdate,
+	}
+}
+
+func toDeleteResult(r commons.DeleteResult) *inventoryv1.DeleteResult {
+	return &inventoryv1.DeleteResult{Id: r.Id.String(), Deleted: r.Deleted}
+}
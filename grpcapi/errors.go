@@ -0,0 +1,29 @@
+package grpcapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"inventory-service-go/commons"
+	"inventory-service-go/logging"
+)
+
+// statusError maps a service error onto a status as commons.HandleServiceError maps it onto an HTTP status: a missing
+// row is NotFound, bad input InvalidArgument and anything else is logged and reported as Internal.
+func statusError(ctx context.Context, err error) error {
+	var validationError *commons.ValidationError
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, "not found")
+	case errors.As(err, &validationError):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	logging.FromContext(ctx).ErrorContext(ctx, "Unexpected service error", "error", err)
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,254 @@
+package grpcapi
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"inventory-service-go/commons"
+	"inventory-service-go/invoice"
+	inventoryv1 "inventory-service-go/proto/inventory/v1"
+	"inventory-service-go/tax"
+)
+
+type invoiceServer struct {
+	inventoryv1.UnimplementedInvoiceServiceServer
+	invoices invoice.InvoiceService
+}
+
+func toInvoice(i invoice.Invoice) *inventoryv1.Invoice {
+	result := &inventoryv1.Invoice{
+		Seq:              int32(i.Seq),
+		Id:               i.Id.String(),
+		UserId:           i.UserId.String(),
+		Total:            toMoney(i.Total),
+		TaxJurisdiction:  i.TaxJurisdiction,
+		PricesIncludeTax: i.PricesIncludeTax,
+		Paid:             i.Paid,
+		Number:           i.Number,
+		IssuedAt:         i.IssuedAt,
+		Tax:              toTaxBreakdown(i.Tax),
+		AuditInfo:        toAuditInfo(i.AuditInfo),
+	}
+	for _, it := range i.Items {
+		result.Items = append(result.Items, toItem(it))
+	}
+	for _, line := range i.Lines {
+		result.Lines = append(result.Lines, &inventoryv1.InvoiceLine{
+			ItemId:         line.ItemId.String(),
+			UnitPrice:      toMoney(line.UnitPrice),
+			Quantity:       line.Quantity,
+			DiscountAmount: toMoney(line.DiscountAmount),
+			TaxCategory:    line.TaxCategory,
+			TaxRate:        line.TaxRate,
+			Net:            toMoney(line.Net),
+			Tax:            toMoney(line.Tax),
+			Gross:          toMoney(line.Gross),
+		})
+	}
+	return result
+}
+
+func toTaxBreakdown(b *tax.Breakdown) *inventoryv1.TaxBreakdown {
+	if b == nil {
+		return nil
+	}
+	result := &inventoryv1.TaxBreakdown{
+		Undiscounted: toMoney(b.Undiscounted),
+		Discount:     toMoney(b.Discount),
+		Subtotal:     toMoney(b.Subtotal),
+		TaxTotal:     toMoney(b.TaxTotal),
+		Total:        toMoney(b.Total),
+	}
+	for _, summary := range b.Taxes {
+		result.Taxes = append(result.Taxes, &inventoryv1.TaxSummary{
+			Category:   summary.Category,
+			Percentage: summary.Percentage,
+			Net:        toMoney(summary.Net),
+			Tax:        toMoney(summary.Tax),
+		})
+	}
+	return result
+}
+
+func toItemsToInvoiceResponse(r invoice.ItemsToInvoiceResponse) *inventoryv1.ItemsToInvoiceResponse {
+	result := &inventoryv1.ItemsToInvoiceResponse{InvoiceId: r.InvoiceId.String(), Success: r.Success}
+	for _, id := range r.Items {
+		result.Items = append(result.Items, id.String())
+	}
+	return result
+}
+
+func (s *invoiceServer) GetInvoice(ctx context.Context, req *inventoryv1.GetInvoiceRequest) (*inventoryv1.Invoice, error) {
+	id, err := parseId("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	i, err := s.invoices.GetInvoice(ctx, id, req.GetWithItems())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toInvoice(i), nil
+}
+
+func (s *invoiceServer) GetInvoiceByNumber(ctx context.Context, req *inventoryv1.GetInvoiceByNumberRequest) (*inventoryv1.Invoice, error) {
+	i, err := s.invoices.GetInvoiceByNumber(ctx, req.GetNumber())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toInvoice(i), nil
+}
+
+func (s *invoiceServer) ListInvoices(req *inventoryv1.ListInvoicesRequest, stream grpc.ServerStreamingServer[inventoryv1.Invoice]) error {
+	ctx := stream.Context()
+	if req.GetUserId() != "" {
+		return s.listInvoicesForUser(req, stream)
+	}
+	pagination := &commons.Pagination{LastId: int(req.GetAfterSeq()), PageSize: batchSize(req.GetBatchSize())}
+	for {
+		invoices, err := s.invoices.GetAllInvoices(ctx, pagination)
+		if err != nil {
+			return statusError(ctx, err)
+		}
+		for _, i := range invoices {
+			if err = stream.Send(toInvoice(i)); err != nil {
+				return err
+			}
+		}
+		if len(invoices) < pagination.PageSize {
+			return nil
+		}
+		pagination.LastId = invoices[len(invoices)-1].Seq
+	}
+}
+
+// listInvoicesForUser streams the invoices of a user, which the service only reads all at once.
+func (s *invoiceServer) listInvoicesForUser(req *inventoryv1.ListInvoicesRequest, stream grpc.ServerStreamingServer[inventoryv1.Invoice]) error {
+	ctx := stream.Context()
+	userId, err := parseId("user_id", req.GetUserId())
+	if err != nil {
+		return err
+	}
+	invoices, err := s.invoices.GetInvoicesForUser(ctx, userId)
+	if err != nil {
+		return statusError(ctx, err)
+	}
+	for _, i := range invoices {
+		if i.Seq <= int(req.GetAfterSeq()) {
+			continue
+		}
+		if err = stream.Send(toInvoice(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *invoiceServer) CreateInvoice(ctx context.Context, req *inventoryv1.CreateInvoiceRequest) (*inventoryv1.Invoice, error) {
+	userId, err := parseId("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	total, err := fromMoney(req.GetTotal())
+	if err != nil {
+		return nil, err
+	}
+	i, err := s.invoices.CreateInvoice(ctx, invoice.CreateInvoiceRequest{
+		UserId:           userId,
+		Paid:             req.GetPaid(),
+		Total:            total,
+		TaxJurisdiction:  req.GetTaxJurisdiction(),
+		PricesIncludeTax: req.GetPricesIncludeTax(),
+		CreatedBy:        req.GetCreatedBy(),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toInvoice(i), nil
+}
+
+func (s *invoiceServer) UpdateInvoice(ctx context.Context, req *inventoryv1.UpdateInvoiceRequest) (*inventoryv1.Invoice, error) {
+	id, err := parseId("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	// the total is worked out from the lines, the one of the request is ignored
+	i, err := s.invoices.UpdateInvoice(ctx, invoice.UpdateInvoiceRequest{
+		Id:            id,
+		Paid:          req.GetPaid(),
+		LastChangedBy: req.GetLastChangedBy(),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toInvoice(i), nil
+}
+
+func (s *invoiceServer) DeleteInvoice(ctx context.Context, req *inventoryv1.IdRequest) (*inventoryv1.DeleteResult, error) {
+	id, err := parseId("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.invoices.DeleteInvoice(ctx, id)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toDeleteResult(result), nil
+}
+
+func (s *invoiceServer) AddItemsToInvoice(ctx context.Context, req *inventoryv1.AddItemsToInvoiceRequest) (*inventoryv1.ItemsToInvoiceResponse, error) {
+	invoiceId, err := parseId("invoice_id", req.GetInvoiceId())
+	if err != nil {
+		return nil, err
+	}
+	request := invoice.ItemsToInvoiceRequest{InvoiceId: invoiceId}
+	for _, item := range req.GetItems() {
+		itemId, err := parseId("item id", item)
+		if err != nil {
+			return nil, err
+		}
+		request.Items = append(request.Items, itemId)
+	}
+	if len(req.GetQuantities()) > 0 {
+		request.Quantities = map[uuid.UUID]int64{}
+		for item, quantity := range req.GetQuantities() {
+			itemId, err := parseId("quantities item id", item)
+			if err != nil {
+				return nil, err
+			}
+			request.Quantities[itemId] = quantity
+		}
+	}
+	response, err := s.invoices.AddItemsToInvoice(ctx, request)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toItemsToInvoiceResponse(response), nil
+}
+
+func (s *invoiceServer) RemoveItemFromInvoice(ctx context.Context, req *inventoryv1.RemoveItemFromInvoiceRequest) (*inventoryv1.ItemsToInvoiceResponse, error) {
+	invoiceId, err := parseId("invoice_id", req.GetInvoiceId())
+	if err != nil {
+		return nil, err
+	}
+	itemId, err := parseId("item_id", req.GetItemId())
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.invoices.RemoveItemFromInvoice(ctx, invoice.SimpleInvoiceItem{InvoiceId: invoiceId, ItemId: itemId})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toItemsToInvoiceResponse(response), nil
+}
+
+func (s *invoiceServer) IssueInvoice(ctx context.Context, req *inventoryv1.IssueInvoiceRequest) (*inventoryv1.Invoice, error) {
+	id, err := parseId("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	i, err := s.invoices.IssueInvoice(ctx, invoice.IssueInvoiceRequest{Id: id, IssuedBy: req.GetIssuedBy()})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toInvoice(i), nil
+}
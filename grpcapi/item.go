@@ -0,0 +1,111 @@
+package grpcapi
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"inventory-service-go/commons"
+	"inventory-service-go/item"
+	inventoryv1 "inventory-service-go/proto/inventory/v1"
+)
+
+type itemServer struct {
+	inventoryv1.UnimplementedItemServiceServer
+	items item.ItemService
+}
+
+func toItem(i item.Item) *inventoryv1.Item {
+	return &inventoryv1.Item{
+		Seq:         int32(i.Seq),
+		Id:          i.Id.String(),
+		Name:        i.Name,
+		Description: i.Description,
+		UnitPrice:   toMoney(i.UnitPrice),
+		TaxCategory: i.TaxCategory,
+		AuditInfo:   toAuditInfo(i.AuditInfo),
+	}
+}
+
+func (s *itemServer) GetItem(ctx context.Context, req *inventoryv1.IdRequest) (*inventoryv1.Item, error) {
+	id, err := parseId("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	i, err := s.items.GetItem(ctx, id)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toItem(*i), nil
+}
+
+func (s *itemServer) ListItems(req *inventoryv1.ListRequest, stream grpc.ServerStreamingServer[inventoryv1.Item]) error {
+	ctx := stream.Context()
+	pagination := &commons.Pagination{LastId: int(req.GetAfterSeq()), PageSize: batchSize(req.GetBatchSize())}
+	for {
+		items, err := s.items.GetItems(ctx, pagination)
+		if err != nil {
+			return statusError(ctx, err)
+		}
+		for _, i := range items {
+			if err = stream.Send(toItem(i)); err != nil {
+				return err
+			}
+		}
+		if len(items) < pagination.PageSize {
+			return nil
+		}
+		pagination.LastId = items[len(items)-1].Seq
+	}
+}
+
+func (s *itemServer) CreateItem(ctx context.Context, req *inventoryv1.CreateItemRequest) (*inventoryv1.Item, error) {
+	unitPrice, err := fromMoney(req.GetUnitPrice())
+	if err != nil {
+		return nil, err
+	}
+	i, err := s.items.CreateItem(ctx, item.CreateItemRequest{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		UnitPrice:   unitPrice,
+		TaxCategory: req.GetTaxCategory(),
+		CreatedBy:   req.GetCreatedBy(),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toItem(*i), nil
+}
+
+func (s *itemServer) UpdateItem(ctx context.Context, req *inventoryv1.UpdateItemRequest) (*inventoryv1.Item, error) {
+	id, err := parseId("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	unitPrice, err := fromMoney(req.GetUnitPrice())
+	if err != nil {
+		return nil, err
+	}
+	i, err := s.items.UpdateItem(ctx, item.UpdateItemRequest{
+		Id:            id,
+		Name:          req.GetName(),
+		Description:   req.GetDescription(),
+		UnitPrice:     unitPrice,
+		TaxCategory:   req.GetTaxCategory(),
+		LastChangedBy: req.GetLastChangedBy(),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toItem(*i), nil
+}
+
+func (s *itemServer) DeleteItem(ctx context.Context, req *inventoryv1.IdRequest) (*inventoryv1.DeleteResult, error) {
+	id, err := parseId("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.items.DeleteItem(ctx, id)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toDeleteResult(*result), nil
+}
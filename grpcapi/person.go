@@ -0,0 +1,97 @@
+package grpcapi
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"inventory-service-go/commons"
+	"inventory-service-go/person"
+	inventoryv1 "inventory-service-go/proto/inventory/v1"
+)
+
+type personServer struct {
+	inventoryv1.UnimplementedPersonServiceServer
+	persons person.PersonService
+}
+
+func toPerson(p person.Person) *inventoryv1.Person {
+	return &inventoryv1.Person{
+		Seq:       int32(p.Seq),
+		Id:        p.Id.String(),
+		Name:      p.Name,
+		Email:     p.Email,
+		AuditInfo: toAuditInfo(p.AuditInfo),
+	}
+}
+
+func (s *personServer) GetPerson(ctx context.Context, req *inventoryv1.IdRequest) (*inventoryv1.Person, error) {
+	id, err := parseId("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.persons.GetById(ctx, id)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toPerson(*p), nil
+}
+
+func (s *personServer) ListPersons(req *inventoryv1.ListRequest, stream grpc.ServerStreamingServer[inventoryv1.Person]) error {
+	ctx := stream.Context()
+	pagination := &commons.Pagination{LastId: int(req.GetAfterSeq()), PageSize: batchSize(req.GetBatchSize())}
+	for {
+		persons, err := s.persons.GetAll(ctx, pagination)
+		if err != nil {
+			return statusError(ctx, err)
+		}
+		for _, p := range persons {
+			if err = stream.Send(toPerson(p)); err != nil {
+				return err
+			}
+		}
+		if len(persons) < pagination.PageSize {
+			return nil
+		}
+		pagination.LastId = persons[len(persons)-1].Seq
+	}
+}
+
+func (s *personServer) CreatePerson(ctx context.Context, req *inventoryv1.CreatePersonRequest) (*inventoryv1.Person, error) {
+	p, err := s.persons.Create(ctx, person.CreatePersonRequest{
+		Name:      req.GetName(),
+		Email:     req.GetEmail(),
+		CreatedBy: req.GetCreatedBy(),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toPerson(*p), nil
+}
+
+func (s *personServer) UpdatePerson(ctx context.Context, req *inventoryv1.UpdatePersonRequest) (*inventoryv1.Person, error) {
+	id, err := parseId("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.persons.Update(ctx, person.UpdatePersonRequest{
+		Id:            id,
+		Name:          req.GetName(),
+		Email:         req.GetEmail(),
+		LastChangedBy: req.GetLastChangedBy(),
+	})
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toPerson(*p), nil
+}
+
+func (s *personServer) DeletePerson(ctx context.Context, req *inventoryv1.IdRequest) (*inventoryv1.DeleteResult, error) {
+	id, err := parseId("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.persons.DeleteByUuid(ctx, id)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	return toDeleteResult(*result), nil
+}
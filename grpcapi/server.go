@@ -0,0 +1,38 @@
+// Package grpcapi serves persons, items and invoices over gRPC, next to the REST API and through the same services.
+package grpcapi
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"inventory-service-go/context"
+	inventoryv1 "inventory-service-go/proto/inventory/v1"
+)
+
+// defaultBatchSize is how many rows a list stream reads at a time when the client does not say.
+const defaultBatchSize = 100
+
+// maxBatchSize bounds the rows read at a time, whatever the client asks for.
+const maxBatchSize = 1000
+
+// NewServer returns a server with the person, item and invoice services registered. Every call needs the same bearer
+// token as the REST API, except for server reflection.
+func NewServer(appContext context.ApplicationContext, opts ...grpc.ServerOption) *grpc.Server {
+	interceptors := interceptors{appContext: appContext}
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(interceptors.unary),
+		grpc.ChainStreamInterceptor(interceptors.stream),
+	)
+	server := grpc.NewServer(opts...)
+	inventoryv1.RegisterPersonServiceServer(server, &personServer{persons: appContext.PersonService()})
+	inventoryv1.RegisterItemServiceServer(server, &itemServer{items: appContext.ItemService()})
+	inventoryv1.RegisterInvoiceServiceServer(server, &invoiceServer{invoices: appContext.InvoiceService()})
+	reflection.Register(server)
+	return server
+}
+
+func batchSize(requested int32) int {
+	if requested <= 0 {
+		return defaultBatchSize
+	}
+	return min(int(requested), maxBatchSize)
+}
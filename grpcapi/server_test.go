@@ -0,0 +1,240 @@
+package grpcapi
+
+import (
+	gocontext "context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"inventory-service-go/auth"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"inventory-service-go/person"
+	inventoryv1 "inventory-service-go/proto/inventory/v1"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+type mocks struct {
+	persons  *person.MockPersonService
+	items    *item.MockItemService
+	invoices *invoice.MockInvoiceService
+}
+
+// startServer serves the mocks over an in-memory connection and returns a client connection to it.
+func startServer(t *testing.T) (mocks, *grpc.ClientConn) {
+	controller := gomock.NewController(t)
+	m := mocks{
+		persons:  person.NewMockPersonService(controller),
+		items:    item.NewMockItemService(controller),
+		invoices: invoice.NewMockInvoiceService(controller),
+	}
+	server := NewServer(context.MockApplicationContext(m.persons, m.items, m.invoices))
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx gocontext.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return m, conn
+}
+
+// authorized returns a context carrying a token of the secret MockApplicationContext validates with.
+func authorized(t *testing.T) gocontext.Context {
+	token, err := auth.NewJwtAuthProvider("dummy_secret").IssueToken("foo", uuid.Nil, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return metadata.AppendToOutgoingContext(gocontext.Background(), "authorization", "Bearer "+token)
+}
+
+func TestAuthentication(t *testing.T) {
+	m, conn := startServer(t)
+	client := inventoryv1.NewPersonServiceClient(conn)
+	id := uuid.New()
+	otherSecret, err := auth.NewJwtAuthProvider("other_secret").IssueToken("foo", uuid.Nil, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		ctx  gocontext.Context
+		code codes.Code
+	}{
+		{"no token", gocontext.Background(), codes.Unauthenticated},
+		{"not a bearer token", metadata.AppendToOutgoingContext(gocontext.Background(), "authorization", "Basic Zm9vOmJhcg=="), codes.Unauthenticated},
+		{"wrong secret", metadata.AppendToOutgoingContext(gocontext.Background(), "authorization", "Bearer "+otherSecret), codes.Unauthenticated},
+		{"valid token", authorized(t), codes.OK},
+	}
+	m.persons.EXPECT().GetById(gomock.Any(), id).DoAndReturn(func(ctx gocontext.Context, id uuid.UUID) (*person.Person, error) {
+		// a token without a tenant is of the default tenant
+		tenant, _ := commons.TenantFrom(ctx)
+		assert.Equal(t, commons.DefaultTenant, tenant)
+		return &person.Person{Id: id}, nil
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetPerson(tt.ctx, &inventoryv1.IdRequest{Id: id.String()})
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+
+	t.Run("streams", func(t *testing.T) {
+		stream, err := client.ListPersons(gocontext.Background(), &inventoryv1.ListRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestStatusError(t *testing.T) {
+	m, conn := startServer(t)
+	client := inventoryv1.NewItemServiceClient(conn)
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"not found", sql.ErrNoRows, codes.NotFound},
+		{"validation", commons.ErrUnsupportedCurrency, codes.InvalidArgument},
+		{"deadline", gocontext.DeadlineExceeded, codes.DeadlineExceeded},
+		{"unexpected", errors.New("connection reset"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m.items.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(nil, tt.err)
+			_, err := client.GetItem(authorized(t), &inventoryv1.IdRequest{Id: uuid.NewString()})
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+
+	t.Run("internal errors are not leaked", func(t *testing.T) {
+		m.items.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(nil, errors.New("password authentication failed"))
+		_, err := client.GetItem(authorized(t), &inventoryv1.IdRequest{Id: uuid.NewString()})
+		assert.Equal(t, "internal error", status.Convert(err).Message())
+	})
+
+	t.Run("bad id", func(t *testing.T) {
+		_, err := client.GetItem(authorized(t), &inventoryv1.IdRequest{Id: "not-a-uuid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestListPersons(t *testing.T) {
+	m, conn := startServer(t)
+	client := inventoryv1.NewPersonServiceClient(conn)
+	gomock.InOrder(
+		m.persons.EXPECT().GetAll(gomock.Any(), &commons.Pagination{LastId: 5, PageSize: 2}).
+			Return([]person.Person{{Seq: 6, Name: "a"}, {Seq: 7, Name: "b"}}, nil),
+		m.persons.EXPECT().GetAll(gomock.Any(), &commons.Pagination{LastId: 7, PageSize: 2}).
+			Return([]person.Person{{Seq: 9, Name: "c"}}, nil),
+	)
+
+	stream, err := client.ListPersons(authorized(t), &inventoryv1.ListRequest{AfterSeq: 5, BatchSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for {
+		p, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+}
+
+func TestBatchSize(t *testing.T) {
+	assert.Equal(t, defaultBatchSize, batchSize(0))
+	assert.Equal(t, 10, batchSize(10))
+	assert.Equal(t, maxBatchSize, batchSize(maxBatchSize+1))
+}
+
+func TestCreateItem(t *testing.T) {
+	m, conn := startServer(t)
+	client := inventoryv1.NewItemServiceClient(conn)
+	m.items.EXPECT().CreateItem(gomock.Any(), item.CreateItemRequest{
+		Name:      "widget",
+		UnitPrice: commons.NewMoney(1250, commons.DefaultCurrency),
+		CreatedBy: "unit_test",
+	}).Return(&item.Item{Seq: 1, Id: uuid.New(), Name: "widget", UnitPrice: commons.NewMoney(1250, "USD")}, nil)
+
+	created, err := client.CreateItem(authorized(t), &inventoryv1.CreateItemRequest{
+		Name:      "widget",
+		UnitPrice: &inventoryv1.Money{Amount: "12.50"},
+		CreatedBy: "unit_test",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, "12.50", created.UnitPrice.Amount)
+	assert.Equal(t, "USD", created.UnitPrice.Currency)
+
+	_, err = client.CreateItem(authorized(t), &inventoryv1.CreateItemRequest{UnitPrice: &inventoryv1.Money{Amount: "twelve"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListInvoices_ForUser(t *testing.T) {
+	m, conn := startServer(t)
+	client := inventoryv1.NewInvoiceServiceClient(conn)
+	userId := uuid.New()
+	m.invoices.EXPECT().GetInvoicesForUser(gomock.Any(), userId).
+		Return([]invoice.Invoice{{Seq: 1, UserId: userId}, {Seq: 4, UserId: userId}}, nil)
+
+	stream, err := client.ListInvoices(authorized(t), &inventoryv1.ListInvoicesRequest{UserId: userId.String(), AfterSeq: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	received, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, int32(4), received.Seq)
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestAddItemsToInvoice(t *testing.T) {
+	m, conn := startServer(t)
+	client := inventoryv1.NewInvoiceServiceClient(conn)
+	invoiceId, itemId := uuid.New(), uuid.New()
+	m.invoices.EXPECT().AddItemsToInvoice(gomock.Any(), invoice.ItemsToInvoiceRequest{
+		InvoiceId:  invoiceId,
+		Items:      []uuid.UUID{itemId},
+		Quantities: map[uuid.UUID]int64{itemId: 3},
+	}).Return(invoice.ItemsToInvoiceResponse{InvoiceId: invoiceId, Items: []uuid.UUID{itemId}, Success: true}, nil)
+
+	response, err := client.AddItemsToInvoice(authorized(t), &inventoryv1.AddItemsToInvoiceRequest{
+		InvoiceId:  invoiceId.String(),
+		Items:      []string{itemId.String()},
+		Quantities: map[string]int64{itemId.String(): 3},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.True(t, response.Success)
+	assert.Equal(t, []string{itemId.String()}, response.Items)
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"inventory-service-go/apikey"
+	"inventory-service-go/auth"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// apiKeyContextKey holds the apikey.APIKey of a request made with one.
+const apiKeyContextKey = "api_key"
+
+// APIKeyAuth authenticates the requests with an X-API-Key header, the others are left to the JWT middleware. The key
+// needs a scope for the resource of the route, e.g. items:read or write for /api/v1/items. Its claims are those of a
+// token for apikey.APIKey.Actor, so the JWT middleware is skipped and the rate limit counts the key as a client.
+func APIKeyAuth(a context.ApplicationContext, skipper middleware.Skipper) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(auth.HeaderAPIKey)
+			if header == "" || (skipper != nil && skipper(c)) {
+				return next(c)
+			}
+			ctx := c.Request().Context()
+			key, err := a.APIKeyService().Authenticate(ctx, header)
+			if errors.Is(err, apikey.ErrUnauthorized) {
+				return c.String(http.StatusUnauthorized, "Unauthorized")
+			} else if err != nil {
+				return commons.HandleServiceError(c, err)
+			}
+			if !key.Allows(resource(c.Path()), !isRead(c.Request().Method)) {
+				return c.String(http.StatusForbidden, "Forbidden")
+			}
+			c.Set(apiKeyContextKey, key)
+			c.Set("user", &auth.Claims{Username: key.Actor(), Tenant: key.Tenant.String(), RegisteredClaims: jwt.RegisteredClaims{Subject: key.Id.String()}})
+			return next(c)
+		}
+	}
+}
+
+// resource is what the scopes of a route are about, the segment after the version for the REST API, e.g. items for
+// /api/v1/items/:id, and the first segment otherwise, e.g. graphql.
+func resource(route string) string {
+	segments := strings.Split(strings.TrimPrefix(route, "/"), "/")
+	if len(segments) > 2 && segments[0] == "api" {
+		return segments[2]
+	}
+	return segments[0]
+}
+
+func isRead(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// Binder binds as echo.DefaultBinder and attributes the requests made with an API key to the key: the audit fields of
+// the bound request, the string fields tagged audit:"actor" such as CreatedBy or IssuedBy, are set to
+// apikey.APIKey.Actor, whatever the client sent.
+type Binder struct {
+	echo.DefaultBinder
+}
+
+func (b *Binder) Bind(i interface{}, c echo.Context) error {
+	if err := b.DefaultBinder.Bind(i, c); err != nil {
+		return err
+	}
+	if key, ok := c.Get(apiKeyContextKey).(apikey.APIKey); ok {
+		attribute(reflect.ValueOf(i), key.Actor())
+	}
+	return nil
+}
+
+// attribute sets the audit fields of the struct v points to, or of the structs of the slice it points to.
+func attribute(v reflect.Value, actor string) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			attribute(v.Index(i).Addr(), actor)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Field(i); v.Type().Field(i).Tag.Get("audit") == "actor" && field.Kind() == reflect.String && field.CanSet() {
+				field.SetString(actor)
+			}
+		}
+	}
+}
+
+// APIKeyRoutes are for the admins only, signed in with a token. Keys cannot manage keys.
+func APIKeyRoutes(g *echo.Group, a context.ApplicationContext, admins []string) {
+	admin := adminOnly(admins)
+	g.GET("/api-keys", GetAllAPIKeys(a), admin)
+	g.GET("/api-keys/:id", GetAPIKey(a), admin)
+	g.POST("/api-keys", CreateAPIKey(a), admin)
+	g.DELETE("/api-keys/:id", RevokeAPIKey(a), admin)
+}
+
+func adminOnly(admins []string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			_, withKey := c.Get(apiKeyContextKey).(apikey.APIKey)
+			if claims, ok := c.Get("user").(*auth.Claims); !ok || withKey || !slices.Contains(admins, claims.Username) {
+				return c.String(http.StatusForbidden, "Forbidden")
+			}
+			return next(c)
+		}
+	}
+}
+
+// admin is the username of the admin making the request, see adminOnly.
+func admin(c echo.Context) string {
+	return c.Get("user").(*auth.Claims).Username
+}
+
+// GetAllAPIKeys
+//
+//		@Summary		List API Keys
+//		@Description	List all API Keys, without the keys themselves
+//		@Id				all_api_keys
+//		@Tags			api-key
+//		@Produce		json
+//		@Param			last_id		query		int	false	"last seq id"
+//	 	@Param			page_size 	query		int false 	"number of api keys per page"
+//		@Success		200	{array}		apikey.APIKey	"OK"
+//		@Failure		403	{string}	string			"Forbidden (not an admin)"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/api-keys [get]
+func GetAllAPIKeys(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		pagination := paginationFromRequest(c)
+		results, err := a.APIKeyService().GetAPIKeys(c.Request().Context(), pagination)
+		if err != nil {
+			return commons.HandleServiceError(c, err)
+		}
+		return c.JSON(http.StatusOK, results)
+	}
+}
+
+// GetAPIKey
+//
+//		@Summary		Get API Key
+//		@Description	Get a specific API Key, without the key itself
+//		@Id				get_api_key
+//		@Tags			api-key
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the api key requested"
+//		@Success		200	{object}	apikey.APIKey	"OK"
+//		@Failure		400	{string}	string 			"Bad Request"
+//		@Failure		403	{string}	string			"Forbidden (not an admin)"
+//		@Failure		404 {string} 	string			"Not Found"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/api-keys/{id} [get]
+func GetAPIKey(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.APIKeyService().GetAPIKey(c.Request().Context(), id)
+		if err != nil {
+			return commons.HandleServiceError(c, err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// CreateAPIKey
+//
+//		@Summary		Create API Key
+//		@Description	Create an API Key for the X-API-Key header with scopes such as read, write, items:read or invoices:write, the response is the only time the key is returned
+//		@ID				create_api_key
+//		@Tags			api-key
+//		@Accept			json
+//		@Produce		json
+//	    @Param 			request body 		apikey.CreateAPIKeyRequest	true 	"Create API Key Request"
+//		@Success		201		{object}	apikey.CreatedAPIKey		"Created"
+//		@Failure		400		{string}	string					"Bad Request"
+//		@Failure		403		{string}	string					"Forbidden (not an admin)"
+//		@Failure		500		{object}	error					"Internal Server Error"
+//		@Router			/api-keys [post]
+func CreateAPIKey(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request apikey.CreateAPIKeyRequest
+		if err := c.Bind(&request); err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		request.CreatedBy = admin(c)
+		result, err := a.APIKeyService().CreateAPIKey(c.Request().Context(), request)
+		if err != nil {
+			return commons.HandleServiceError(c, err)
+		}
+		return c.JSON(http.StatusCreated, result)
+	}
+}
+
+// RevokeAPIKey
+//
+//		@Summary		Revoke API Key
+//		@Description	Revoke an API Key, it is rejected from then on and kept for the audit
+//		@ID				revoke_api_key
+//		@Tags			api-key
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the api key to revoke"
+//		@Success		200	{object}	apikey.APIKey	"OK"
+//		@Failure		400	{string}	string 			"Bad Request"
+//		@Failure		403	{string}	string			"Forbidden (not an admin)"
+//		@Failure		404 {string} 	string			"Not Found"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/api-keys/{id} [delete]
+func RevokeAPIKey(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.APIKeyService().RevokeAPIKey(c.Request().Context(), id, admin(c))
+		if err != nil {
+			return commons.HandleServiceError(c, err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
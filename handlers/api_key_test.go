@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/apikey"
+	"inventory-service-go/auth"
+	"inventory-service-go/context"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAPIKeyRoutes(t *testing.T) {
+	mockApp := context.MockApplicationContext(nil, nil, nil)
+	e := echo.New()
+	t.Run("successful route registration", func(t *testing.T) {
+		APIKeyRoutes(e.Group("/test"), mockApp, nil)
+		routes := e.Routes()
+		assert.Equal(t, 4, len(routes))
+	})
+}
+
+// apiKeyServer serves the routes behind APIKeyAuth, the token of the user header stands in for the JWT middleware.
+func apiKeyServer(mockApp context.ApplicationContext, admins ...string) *echo.Echo {
+	e := echo.New()
+	e.Binder = &Binder{}
+	e.Use(APIKeyAuth(mockApp, func(c echo.Context) bool { return c.Path() == "/healthz" }))
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if user := c.Request().Header.Get("User"); user != "" && c.Get("user") == nil {
+				c.Set("user", &auth.Claims{Username: user})
+			}
+			return next(c)
+		}
+	})
+	g := e.Group("/api/v1")
+	APIKeyRoutes(g, mockApp, admins)
+	g.POST("/items", func(c echo.Context) error {
+		var request item.CreateItemRequest
+		if err := c.Bind(&request); err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, request)
+	})
+	g.GET("/items", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, c.Get("user"))
+	})
+	e.GET("/healthz", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	return e
+}
+
+func serveAPIKey(e *echo.Echo, method string, path string, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := apikey.NewMockAPIKeyService(controller)
+	e := apiKeyServer(context.MockApplicationContext(nil, nil, nil).WithAPIKeyService(mockService))
+	readOnly := apikey.APIKey{Id: uuid.New(), Name: "reporting", Scopes: []string{"items:read"}, Tenant: uuid.New()}
+	writer := apikey.APIKey{Id: uuid.New(), Name: "nightly", Scopes: []string{"write"}}
+
+	t.Run("without a key", func(t *testing.T) {
+		rec := serveAPIKey(e, http.MethodGet, "/api/v1/items", "", map[string]string{"User": "ann"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"username":"ann"`)
+	})
+	t.Run("invalid key", func(t *testing.T) {
+		mockService.EXPECT().Authenticate(gomock.Any(), "inv_revoked").Return(apikey.APIKey{}, apikey.ErrUnauthorized)
+		rec := serveAPIKey(e, http.MethodGet, "/api/v1/items", "", map[string]string{auth.HeaderAPIKey: "inv_revoked"})
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+	t.Run("service error", func(t *testing.T) {
+		mockService.EXPECT().Authenticate(gomock.Any(), "inv_key").Return(apikey.APIKey{}, errors.New("BOOM"))
+		rec := serveAPIKey(e, http.MethodGet, "/api/v1/items", "", map[string]string{auth.HeaderAPIKey: "inv_key"})
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+	t.Run("reads in its scope", func(t *testing.T) {
+		mockService.EXPECT().Authenticate(gomock.Any(), "inv_reporting").Return(readOnly, nil)
+		rec := serveAPIKey(e, http.MethodGet, "/api/v1/items", "", map[string]string{auth.HeaderAPIKey: "inv_reporting"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"username":"api-key:`+readOnly.Id.String()+`"`)
+		assert.Contains(t, rec.Body.String(), readOnly.Id.String())
+		assert.Contains(t, rec.Body.String(), `"tenant":"`+readOnly.Tenant.String()+`"`)
+	})
+	t.Run("writes outside its scope", func(t *testing.T) {
+		mockService.EXPECT().Authenticate(gomock.Any(), "inv_reporting").Return(readOnly, nil)
+		rec := serveAPIKey(e, http.MethodPost, "/api/v1/items", `{"name": "Widget"}`, map[string]string{auth.HeaderAPIKey: "inv_reporting"})
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("changes are attributed to the key", func(t *testing.T) {
+		mockService.EXPECT().Authenticate(gomock.Any(), "inv_nightly").Return(writer, nil)
+		rec := serveAPIKey(e, http.MethodPost, "/api/v1/items", `{"name": "Widget", "created_by": "someone else"}`, map[string]string{auth.HeaderAPIKey: "inv_nightly"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"created_by":"api-key:`+writer.Id.String()+`"`)
+	})
+	t.Run("changes with a token are not", func(t *testing.T) {
+		rec := serveAPIKey(e, http.MethodPost, "/api/v1/items", `{"name": "Widget", "created_by": "ann"}`, map[string]string{"User": "ann"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"created_by":"ann"`)
+	})
+	t.Run("skipped routes", func(t *testing.T) {
+		rec := serveAPIKey(e, http.MethodGet, "/healthz", "", map[string]string{auth.HeaderAPIKey: "inv_unchecked"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestAttribute(t *testing.T) {
+	type request struct {
+		CreatedBy     string `audit:"actor"`
+		LastChangedBy string `audit:"actor"`
+		IssuedBy      string `audit:"actor"`
+		Name          string
+	}
+	requests := []request{{Name: "a", CreatedBy: "x"}, {Name: "b", IssuedBy: "y"}}
+	attribute(reflect.ValueOf(&requests), "api-key:nightly")
+	assert.Equal(t, []request{
+		{Name: "a", CreatedBy: "api-key:nightly", LastChangedBy: "api-key:nightly", IssuedBy: "api-key:nightly"},
+		{Name: "b", CreatedBy: "api-key:nightly", LastChangedBy: "api-key:nightly", IssuedBy: "api-key:nightly"},
+	}, requests)
+	var none *request
+	attribute(reflect.ValueOf(none), "api-key:nightly")
+}
+
+func TestAPIKeyAuth_IssueInvoice(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockKeys := apikey.NewMockAPIKeyService(controller)
+	mockInvoices := invoice.NewMockInvoiceService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, mockInvoices).WithAPIKeyService(mockKeys)
+	e := apiKeyServer(mockApp)
+	InvoiceRoutes(e.Group("/api/v1"), mockApp)
+	writer := apikey.APIKey{Id: uuid.New(), Name: "nightly", Scopes: []string{"invoices:write"}}
+	id := uuid.New()
+
+	mockKeys.EXPECT().Authenticate(gomock.Any(), "inv_nightly").Return(writer, nil)
+	mockInvoices.EXPECT().IssueInvoice(gomock.Any(), invoice.IssueInvoiceRequest{Id: id, IssuedBy: writer.Actor()}).Return(invoice.Invoice{Id: id, Number: "INV-2026-000001"}, nil)
+	rec := serveAPIKey(e, http.MethodPost, "/api/v1/invoices/"+id.String()+"/issue", `{"issued_by": "someone else"}`, map[string]string{auth.HeaderAPIKey: "inv_nightly"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestAPIKeyAdmin(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := apikey.NewMockAPIKeyService(controller)
+	e := apiKeyServer(context.MockApplicationContext(nil, nil, nil).WithAPIKeyService(mockService), "admin")
+	id := uuid.New()
+	created := apikey.CreatedAPIKey{APIKey: apikey.APIKey{Id: id, Name: "nightly", Scopes: []string{"write"}}, Key: "inv_secret"}
+
+	t.Run("not an admin", func(t *testing.T) {
+		rec := serveAPIKey(e, http.MethodGet, "/api/v1/api-keys", "", map[string]string{"User": "ann"})
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("keys cannot manage keys", func(t *testing.T) {
+		mockService.EXPECT().Authenticate(gomock.Any(), "inv_nightly").Return(created.APIKey, nil)
+		rec := serveAPIKey(e, http.MethodPost, "/api/v1/api-keys", `{"name": "escalated", "scopes": ["write"]}`, map[string]string{auth.HeaderAPIKey: "inv_nightly"})
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("create", func(t *testing.T) {
+		mockService.EXPECT().CreateAPIKey(gomock.Any(), apikey.CreateAPIKeyRequest{Name: "nightly", Scopes: []string{"write"}, CreatedBy: "admin"}).Return(created, nil)
+		rec := serveAPIKey(e, http.MethodPost, "/api/v1/api-keys", `{"name": "nightly", "scopes": ["write"], "created_by": "someone else"}`, map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"key":"inv_secret"`)
+	})
+	t.Run("create invalid", func(t *testing.T) {
+		mockService.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Return(apikey.CreatedAPIKey{}, apikey.ErrInvalidAPIKey)
+		rec := serveAPIKey(e, http.MethodPost, "/api/v1/api-keys", `{"name": "nightly"}`, map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("list", func(t *testing.T) {
+		mockService.EXPECT().GetAPIKeys(gomock.Any(), nil).Return([]apikey.APIKey{created.APIKey}, nil)
+		rec := serveAPIKey(e, http.MethodGet, "/api/v1/api-keys", "", map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "inv_secret")
+	})
+	t.Run("get", func(t *testing.T) {
+		mockService.EXPECT().GetAPIKey(gomock.Any(), id).Return(apikey.APIKey{}, sql.ErrNoRows)
+		rec := serveAPIKey(e, http.MethodGet, "/api/v1/api-keys/"+id.String(), "", map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		rec = serveAPIKey(e, http.MethodGet, "/api/v1/api-keys/invalid", "", map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("revoke", func(t *testing.T) {
+		mockService.EXPECT().RevokeAPIKey(gomock.Any(), id, "admin").Return(apikey.APIKey{Id: id, Revoked: true}, nil)
+		rec := serveAPIKey(e, http.MethodDelete, "/api/v1/api-keys/"+id.String(), "", map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"revoked":true`)
+	})
+}
+
+func TestResource(t *testing.T) {
+	assert.Equal(t, "items", resource("/api/v1/items/:id"))
+	assert.Equal(t, "invoices", resource("/api/v2/invoices"))
+	assert.Equal(t, "graphql", resource("/graphql"))
+}
@@ -1,18 +1,15 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/labstack/echo/v4"
 	"inventory-service-go/auth"
+	"inventory-service-go/commons"
 	"inventory-service-go/context"
 	"net/http"
 	"time"
 )
 
-type TokenCredentials struct {
-	Token     string `json:"token"`
-	CreatedAt int64  `json:"createdAt"`
-}
-
 // Authorize
 //
 //		@Summary		Authorize
@@ -22,9 +19,10 @@ type TokenCredentials struct {
 //		@Accept			json
 //		@Produce		json
 //	    @Param 			request body 		auth.Credentials	true 	"Credentials"
-//		@Success		200		{object}	handlers.TokenCredentials	"OK"
+//		@Success		200		{object}	auth.TokenCredentials		"OK"
 //		@Failure		400		{string}	string					"Bad Request"
 //		@Failure		401		{string}	string					"Unauthorized (invalid credentials)"
+//		@Failure		500		{string}	string					"Internal Server Error"
 //		@Router			/authorize [post]
 func Authorize(appContext context.ApplicationContext) func(context2 echo.Context) error {
 	return func(c echo.Context) error {
@@ -33,11 +31,13 @@ func Authorize(appContext context.ApplicationContext) func(context2 echo.Context
 		if err := c.Bind(credentials); err != nil {
 			return c.String(http.StatusBadRequest, "Invalid request body")
 		}
-		token, err := appContext.AuthProvider().Authenticate(credentials.ClientId, credentials.ClientSecret)
-		if err != nil {
+		token, err := appContext.AuthProvider().Authenticate(c.Request().Context(), credentials.ClientId, credentials.ClientSecret)
+		if errors.Is(err, auth.ErrInvalidCredentials) {
 			return c.String(http.StatusUnauthorized, "Invalid client credentials")
+		} else if err != nil {
+			return commons.HandleServiceError(c, err)
 		} else {
-			return c.JSON(http.StatusOK, TokenCredentials{
+			return c.JSON(http.StatusOK, auth.TokenCredentials{
 				Token:     token,
 				CreatedAt: time.Now().Unix(),
 			})
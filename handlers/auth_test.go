@@ -22,7 +22,7 @@ func TestAuthorize(t *testing.T) {
 		name               string
 		credentials        *auth.Credentials
 		expectedHTTPStatus int
-		expectedResult     TokenCredentials
+		expectedResult     auth.TokenCredentials
 	}{
 		{
 			name:               "validCredentials",
@@ -72,7 +72,7 @@ func TestAuthorize(t *testing.T) {
 			if assert.NoError(t, Authorize(mockContext)(c)) {
 				assert.Equal(t, tc.expectedHTTPStatus, rec.Code)
 				if rec.Code == http.StatusOK {
-					var tokenCredentials TokenCredentials
+					var tokenCredentials auth.TokenCredentials
 					_ = json.Unmarshal(rec.Body.Bytes(), &tokenCredentials)
 					token := tokenCredentials.Token
 					assert.NotEmpty(t, token)
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"strings"
+	"time"
+)
+
+// V1Deprecated is when the routes of /api/v1 that /api/v2 replaces were deprecated.
+var V1Deprecated = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// Deprecated announces with the Deprecation header of RFC 9745 that a route is deprecated since the given time, and
+// links to its successor, the same path with prefix replaced by successor, e.g. /api/v1 by /api/v2.
+func Deprecated(since time.Time, prefix string, successor string) echo.MiddlewareFunc {
+	deprecation := fmt.Sprintf("@%d", since.Unix())
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			header.Set("Deprecation", deprecation)
+			if path := c.Request().URL.Path; strings.HasPrefix(path, prefix) {
+				header.Add("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, successor, strings.TrimPrefix(path, prefix)))
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeprecated(t *testing.T) {
+	e := echo.New()
+	since := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	e.GET("/api/v1/persons/:id", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, Deprecated(since, "/api/v1", "/api/v2"))
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/persons/42?withItems=true", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "@1792368000", rec.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v2/persons/42>; rel="successor-version"`, rec.Header().Get("Link"))
+}
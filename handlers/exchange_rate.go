@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/currency"
+	"net/http"
+	"time"
+)
+
+// ExchangeRateRoutes are read by every user, the rates are shared by all tenants so only the admins change them.
+func ExchangeRateRoutes(g *echo.Group, a context.ApplicationContext, admins []string) {
+	admin := adminOnly(admins)
+	g.GET("/exchange-rates", GetAllExchangeRates(a))
+	g.GET("/exchange-rates/convert", ConvertAmount(a))
+	g.GET("/exchange-rates/:id", GetExchangeRate(a))
+	g.POST("/exchange-rates", CreateExchangeRate(a), admin)
+	g.PUT("/exchange-rates/:id", UpdateExchangeRate(a), admin)
+	g.DELETE("/exchange-rates/:id", DeleteExchangeRate(a), admin)
+}
+
+// GetAllExchangeRates
+//
+//		@Summary		List Exchange Rates
+//		@Description	List all Exchange Rates
+//		@Id				all_exchange_rates
+//		@Tags			exchange-rate
+//		@Produce		json
+//		@Param			last_id		query		int	false	"last seq id"
+//	 	@Param			page_size 	query		int false 	"number of rates per page"
+//		@Success		200	{array}		currency.ExchangeRate	"OK"
+//		@Failure		500	{string}	string 					"Internal Server Error"
+//		@Router			/exchange-rates [get]
+func GetAllExchangeRates(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		pagination := paginationFromRequest(c)
+		results, err := a.ExchangeRateService().GetRates(pagination)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err)
+		}
+		return c.JSON(http.StatusOK, results)
+	}
+}
+
+// GetExchangeRate
+//
+//		@Summary		Get Exchange Rate
+//		@Description	Get a specific Exchange Rate
+//		@Id				get_exchange_rate
+//		@Tags			exchange-rate
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the exchange rate requested"
+//		@Success		200	{object}	currency.ExchangeRate	"OK"
+//		@Failure		400	{string}	string 					"Bad Request"
+//		@Failure		404 {string} 	string					"Not Found"
+//		@Failure		500	{string}	string 					"Internal Server Error"
+//		@Router			/exchange-rates/{id} [get]
+func GetExchangeRate(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.ExchangeRateService().GetRate(id)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// CreateExchangeRate
+//
+//		@Summary		Create Exchange Rate
+//		@Description	Create an Exchange Rate effective from a given date
+//		@ID				create_exchange_rate
+//		@Tags			exchange-rate
+//		@Accept			json
+//		@Produce		json
+//	    @Param 			request body 		currency.CreateExchangeRateRequest	true 	"Create Exchange Rate Request"
+//		@Success		201		{object}	currency.ExchangeRate					"Created"
+//		@Failure		400		{string}	string					"Bad Request"
+//		@Failure		401		{string}	string					"Unauthorized (invalid credentials)"
+//		@Failure		403		{string}	string					"Forbidden (not an admin)"
+//		@Failure		500		{object}	error					"Internal Server Error"
+//		@Router			/exchange-rates [post]
+func CreateExchangeRate(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request currency.CreateExchangeRateRequest
+		err := c.Bind(&request)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.ExchangeRateService().CreateRate(request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusCreated, result)
+	}
+}
+
+// UpdateExchangeRate
+//
+//		@Summary		Update Exchange Rate
+//		@Description	Update an Exchange Rate
+//		@ID				update_exchange_rate
+//		@Tags			exchange-rate
+//		@Accept			json
+//		@Produce		json
+//	    @Param 			request body 		currency.UpdateExchangeRateRequest	true 	"Update Exchange Rate Request"
+//		@Param			id	path			uuid.Uuid							true	"Exchange Rate Id"
+//		@Success		200		{object}	currency.ExchangeRate	"OK"
+//		@Failure		400		{string}	string					"Bad Request"
+//		@Failure		401		{string}	string					"Unauthorized (invalid credentials)"
+//		@Failure		403		{string}	string					"Forbidden (not an admin)"
+//		@Failure		404		{string}	string					"Not Found"
+//		@Failure		500		{object}	error					"Internal Server Error"
+//		@Router			/exchange-rates/{id} [put]
+func UpdateExchangeRate(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request currency.UpdateExchangeRateRequest
+		err := c.Bind(&request)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		if id != request.Id {
+			return c.JSON(http.StatusBadRequest, "id in path does not match id in body")
+		}
+		result, err := a.ExchangeRateService().UpdateRate(request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// DeleteExchangeRate
+//
+//		@Summary		Delete Exchange Rate
+//		@Description	Remove a specific Exchange Rate
+//		@Id				delete_exchange_rate
+//		@Tags			exchange-rate
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the exchange rate to be deleted"
+//		@Success		200	{object}	commons.DeleteResult	"OK"
+//		@Failure		400	{string}	string 					"Bad Request"
+//		@Failure		403	{string}	string 					"Forbidden (not an admin)"
+//		@Failure		500	{string}	string 					"Internal Server Error"
+//		@Router			/exchange-rates/{id} [delete]
+func DeleteExchangeRate(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.ExchangeRateService().DeleteRate(id)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// ConvertAmount
+//
+//		@Summary		Convert Amount
+//		@Description	Convert an amount into another currency (the base currency when 'to' is omitted) at the rate effective on a date
+//		@Id				convert_amount
+//		@Tags			exchange-rate
+//		@Produce		json
+//		@Param			amount		query		string	true	"decimal amount, e.g. 12.34"
+//		@Param			from		query		string	true	"currency of the amount"
+//		@Param			to			query		string	false	"target currency, defaults to the base currency"
+//		@Param			date		query		string	false	"effective date (YYYY-MM-DD), defaults to today"
+//		@Success		200	{object}	commons.Money		"OK"
+//		@Failure		400	{string}	string 				"Bad Request"
+//		@Failure		500	{string}	string 				"Internal Server Error"
+//		@Router			/exchange-rates/convert [get]
+func ConvertAmount(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		amount, err := commons.ParseMoney(c.QueryParam("amount"), c.QueryParam("from"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+		on := time.Now()
+		if dateParam := c.QueryParam("date"); dateParam != "" {
+			date, err := commons.ParseDate(dateParam)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, err.Error())
+			}
+			on = date.Time
+		}
+		service := a.ExchangeRateService()
+		to := c.QueryParam("to")
+		if to == "" {
+			to = service.BaseCurrency()
+		}
+		result, err := service.Convert(amount, to, on)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/currency"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExchangeRateRoutes(t *testing.T) {
+	mockApp := context.MockApplicationContext(nil, nil, nil)
+	e := echo.New()
+	t.Run("successful route registration", func(t *testing.T) {
+		ExchangeRateRoutes(e.Group("/test"), mockApp, nil)
+		routes := e.Routes()
+		assert.Equal(t, 6, len(routes))
+	})
+}
+
+func TestExchangeRateAdmin(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := currency.NewMockExchangeRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithExchangeRateService(mockService)
+	e := apiKeyServer(mockApp)
+	ExchangeRateRoutes(e.Group("/api/v1"), mockApp, []string{"admin"})
+	id := uuid.New()
+
+	t.Run("not an admin", func(t *testing.T) {
+		rec := serveAPIKey(e, http.MethodPost, "/api/v1/exchange-rates", `{"from_currency": "USD", "to_currency": "EUR", "rate": "0.92"}`, map[string]string{"User": "ann"})
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		rec = serveAPIKey(e, http.MethodDelete, "/api/v1/exchange-rates/"+id.String(), "", map[string]string{"User": "ann"})
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("reads for everyone", func(t *testing.T) {
+		mockService.EXPECT().GetRates(nil).Return([]currency.ExchangeRate{}, nil)
+		rec := serveAPIKey(e, http.MethodGet, "/api/v1/exchange-rates", "", map[string]string{"User": "ann"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("admin", func(t *testing.T) {
+		mockService.EXPECT().DeleteRate(id).Return(commons.DeleteResult{Id: id, Deleted: true}, nil)
+		rec := serveAPIKey(e, http.MethodDelete, "/api/v1/exchange-rates/"+id.String(), "", map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestGetAllExchangeRates(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := currency.NewMockExchangeRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithExchangeRateService(mockService)
+	expected := []currency.ExchangeRate{{Seq: 1, FromCurrency: "USD", ToCurrency: "EUR", Rate: "0.92", EffectiveDate: commons.NewDate(2026, time.January, 1)}}
+	tests := []struct {
+		name          string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful retrieval",
+			mockFunc: func() {
+				mockService.EXPECT().GetRates(nil).Return(expected, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "service error",
+			mockFunc: func() {
+				mockService.EXPECT().GetRates(nil).Return(nil, errors.New("BOOM"))
+			},
+			expectErrCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if assert.NoError(t, GetAllExchangeRates(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+				if tt.expectErrCode == http.StatusOK {
+					var body []currency.ExchangeRate
+					assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+					assert.Equal(t, expected, body)
+				}
+			}
+		})
+	}
+}
+
+func TestGetExchangeRate(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := currency.NewMockExchangeRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithExchangeRateService(mockService)
+	id := uuid.New()
+	tests := []struct {
+		name          string
+		id            string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful retrieval",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().GetRate(id).Return(currency.ExchangeRate{Id: id}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "not found",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().GetRate(id).Return(currency.ExchangeRate{}, sql.ErrNoRows)
+			},
+			expectErrCode: http.StatusNotFound,
+		},
+		{
+			name:          "bad id",
+			id:            "not-a-uuid",
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if assert.NoError(t, GetExchangeRate(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestCreateExchangeRate(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := currency.NewMockExchangeRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithExchangeRateService(mockService)
+	request := currency.CreateExchangeRateRequest{
+		FromCurrency:  "USD",
+		ToCurrency:    "EUR",
+		Rate:          "0.92",
+		EffectiveDate: commons.NewDate(2026, time.January, 1),
+		CreatedBy:     "unit test",
+	}
+	tests := []struct {
+		name          string
+		body          []byte
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful creation",
+			body: mustJson(request),
+			mockFunc: func() {
+				mockService.EXPECT().CreateRate(request).Return(currency.ExchangeRate{Seq: 1, Rate: "0.92"}, nil)
+			},
+			expectErrCode: http.StatusCreated,
+		},
+		{
+			name: "invalid rate",
+			body: mustJson(request),
+			mockFunc: func() {
+				mockService.EXPECT().CreateRate(request).Return(currency.ExchangeRate{}, currency.ErrInvalidRate)
+			},
+			expectErrCode: http.StatusBadRequest,
+		},
+		{
+			name:          "bad request",
+			body:          []byte("bad request"),
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if assert.NoError(t, CreateExchangeRate(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestUpdateExchangeRate(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := currency.NewMockExchangeRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithExchangeRateService(mockService)
+	id := uuid.New()
+	request := currency.UpdateExchangeRateRequest{Id: id, Rate: "0.93", EffectiveDate: commons.NewDate(2026, time.February, 1)}
+	tests := []struct {
+		name          string
+		id            string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful update",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().UpdateRate(request).Return(currency.ExchangeRate{Id: id, Rate: "0.93"}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name:          "id mismatch",
+			id:            uuid.NewString(),
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(mustJson(request)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if assert.NoError(t, UpdateExchangeRate(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteExchangeRate(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := currency.NewMockExchangeRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithExchangeRateService(mockService)
+	id := uuid.New()
+	mockService.EXPECT().DeleteRate(id).Return(commons.DeleteResult{Id: id, Deleted: true}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	if assert.NoError(t, DeleteExchangeRate(mockApp)(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestConvertAmount(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := currency.NewMockExchangeRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithExchangeRateService(mockService)
+	on := commons.NewDate(2026, time.March, 15)
+	tests := []struct {
+		name          string
+		query         string
+		mockFunc      func()
+		expectBody    commons.Money
+		expectErrCode int
+	}{
+		{
+			name:  "explicit target currency and date",
+			query: "?amount=10.99&from=USD&to=EUR&date=2026-03-15",
+			mockFunc: func() {
+				mockService.EXPECT().Convert(commons.NewMoney(1099, "USD"), "EUR", on.Time).Return(commons.NewMoney(1011, "EUR"), nil)
+			},
+			expectBody:    commons.NewMoney(1011, "EUR"),
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name:  "normalize to base currency",
+			query: "?amount=10&from=GBP",
+			mockFunc: func() {
+				mockService.EXPECT().BaseCurrency().Return("USD")
+				mockService.EXPECT().Convert(commons.NewMoney(1000, "GBP"), "USD", gomock.Any()).Return(commons.NewMoney(1270, "USD"), nil)
+			},
+			expectBody:    commons.NewMoney(1270, "USD"),
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name:  "no exchange rate",
+			query: "?amount=10&from=GBP&to=EUR",
+			mockFunc: func() {
+				mockService.EXPECT().Convert(commons.NewMoney(1000, "GBP"), "EUR", gomock.Any()).Return(commons.Money{}, currency.ErrNoExchangeRate)
+			},
+			expectErrCode: http.StatusBadRequest,
+		},
+		{
+			name:          "bad amount",
+			query:         "?amount=abc&from=USD",
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+		{
+			name:          "bad date",
+			query:         "?amount=1&from=USD&date=15/03/2026",
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if assert.NoError(t, ConvertAmount(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+				if tt.expectErrCode == http.StatusOK {
+					var body commons.Money
+					assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+					assert.Equal(t, tt.expectBody, body)
+				}
+			}
+		})
+	}
+}
+
+func mustJson(v any) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/apikey"
+	"inventory-service-go/auth"
+	"inventory-service-go/context"
+	"inventory-service-go/graphqlapi"
+	"net/http"
+)
+
+// GraphQLRoutes registers the GraphQL endpoint on the root of the server, behind the same JWT middleware as the API.
+// Its schema documents it, see graphqlapi, rather than the OpenAPI docs.
+func GraphQLRoutes(g *echo.Group, a context.ApplicationContext) {
+	g.POST("/graphql", GraphQL(graphqlapi.NewServer(a)))
+}
+
+// GraphQL runs a query or mutation. As usual for GraphQL, errors of the fields are reported in the errors of a 200 OK
+// response, only a request that cannot be read is a 400 Bad Request. The changes are attributed to the user of the
+// request. API keys are refused: their scopes are per resource, while a single query reads and changes many.
+func GraphQL(server *graphqlapi.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims, ok := c.Get("user").(*auth.Claims)
+		if !ok {
+			return c.String(http.StatusUnauthorized, "Unauthorized")
+		}
+		if _, withKey := c.Get(apiKeyContextKey).(apikey.APIKey); withKey {
+			return c.String(http.StatusForbidden, "Forbidden (API keys cannot be used with GraphQL)")
+		}
+		var request graphqlapi.Request
+		if err := c.Bind(&request); err != nil {
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+		if request.Query == "" {
+			return c.JSON(http.StatusBadRequest, "query is required")
+		}
+		return c.JSON(http.StatusOK, server.Exec(graphqlapi.WithActor(c.Request().Context(), claims.Username), request))
+	}
+}
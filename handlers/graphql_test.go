@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/apikey"
+	"inventory-service-go/auth"
+	"inventory-service-go/context"
+	"inventory-service-go/graphqlapi"
+	"inventory-service-go/item"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGraphQLRoutes(t *testing.T) {
+	mockApp := context.MockApplicationContext(nil, nil, nil)
+	e := echo.New()
+	GraphQLRoutes(e.Group("/test"), mockApp)
+	assert.Equal(t, 1, len(e.Routes()))
+}
+
+func TestGraphQL(t *testing.T) {
+	controller := gomock.NewController(t)
+	items := item.NewMockItemService(controller)
+	id := uuid.New()
+	items.EXPECT().GetItem(gomock.Any(), id).Return(&item.Item{Id: id, Name: "widget"}, nil)
+	server := graphqlapi.NewServer(context.MockApplicationContext(nil, items, nil))
+	tests := []struct {
+		name         string
+		body         string
+		expectCode   int
+		expectedBody string
+	}{
+		{"query", `{"query": "query($id: ID!) { item(id: $id) { name } }", "variables": {"id": "` + id.String() + `"}}`, http.StatusOK, `{"data": {"item": {"name": "widget"}}}`},
+		{"empty query", `{"query": ""}`, http.StatusBadRequest, `"query is required"`},
+		{"invalid body", `{"query": 1}`, http.StatusBadRequest, ""},
+	}
+	claims := &auth.Claims{Username: "unit_test"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", claims)
+			assert.Nil(t, GraphQL(server)(c))
+			assert.Equal(t, tt.expectCode, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGraphQL_Unauthenticated(t *testing.T) {
+	server := graphqlapi.NewServer(context.MockApplicationContext(nil, nil, nil))
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "{ items { name } }"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	assert.Nil(t, GraphQL(server)(echo.New().NewContext(req, rec)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestGraphQL_APIKey(t *testing.T) {
+	server := graphqlapi.NewServer(context.MockApplicationContext(nil, nil, nil))
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "{ items { name } }"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	key := apikey.APIKey{Id: uuid.New(), Name: "reporting", Scopes: []string{"read"}}
+	c.Set(apiKeyContextKey, key)
+	c.Set("user", &auth.Claims{Username: key.Actor()})
+	assert.Nil(t, GraphQL(server)(c))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
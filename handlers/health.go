@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/context"
+	"inventory-service-go/health"
+	"net/http"
+)
+
+// HealthRoutes registers the probes of the load balancer, on the root of the server rather than the API and outside of
+// the JWT middleware, see publicPaths in main.
+func HealthRoutes(g *echo.Group, a context.ApplicationContext) {
+	g.GET("/healthz", Liveness(a))
+	g.GET("/readyz", Readiness(a))
+}
+
+// Liveness reports that the process is up, no dependency is checked. Like /metrics the probes are not part of the
+// documented API, which is served under /api/v1.
+func Liveness(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, a.HealthChecker().Live())
+	}
+}
+
+// Readiness reports the checks of the dependencies, with 503 Service Unavailable when one is down or the service is
+// shutting down. A degraded dependency is reported with 200 OK.
+func Readiness(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		report := a.HealthChecker().Ready(c.Request().Context())
+		status := http.StatusOK
+		if report.Status == health.StatusDown {
+			status = http.StatusServiceUnavailable
+		}
+		return c.JSON(status, report)
+	}
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	ctx "context"
+	"encoding/json"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/context"
+	"inventory-service-go/health"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHealthRoutes(t *testing.T) {
+	mockApp := context.MockApplicationContext(nil, nil, nil)
+	e := echo.New()
+	t.Run("successful route registration", func(t *testing.T) {
+		HealthRoutes(e.Group("/test"), mockApp)
+		routes := e.Routes()
+		assert.Equal(t, 2, len(routes))
+	})
+}
+
+func TestLiveness(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Add("database", func(ctx.Context) health.Result { return health.Result{Status: health.StatusDown} })
+	checker.Shutdown()
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithHealthChecker(checker)
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/healthz", nil), rec)
+
+	assert.Nil(t, Liveness(mockApp)(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"up"}`, rec.Body.String())
+}
+
+func TestReadiness(t *testing.T) {
+	up := func(ctx.Context) health.Result { return health.Result{Status: health.StatusUp} }
+	degraded := func(ctx.Context) health.Result { return health.Result{Status: health.StatusDegraded} }
+	failing := func(ctx.Context) health.Result {
+		return health.Result{Status: health.StatusDown, Error: errors.New("BOOM").Error()}
+	}
+	tests := []struct {
+		name          string
+		checks        map[string]health.Check
+		shuttingDown  bool
+		expectErrCode int
+		expectStatus  health.Status
+	}{
+		{"ready", map[string]health.Check{"database": up, "pool": up}, false, http.StatusOK, health.StatusUp},
+		{"degraded", map[string]health.Check{"database": up, "pool": degraded}, false, http.StatusOK, health.StatusDegraded},
+		{"database down", map[string]health.Check{"database": failing, "pool": up}, false, http.StatusServiceUnavailable, health.StatusDown},
+		{"shutting down", map[string]health.Check{"database": up}, true, http.StatusServiceUnavailable, health.StatusDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := health.NewChecker(time.Second)
+			for name, check := range tt.checks {
+				checker.Add(name, check)
+			}
+			if tt.shuttingDown {
+				checker.Shutdown()
+			}
+			mockApp := context.MockApplicationContext(nil, nil, nil).WithHealthChecker(checker)
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/readyz", nil), rec)
+
+			assert.Nil(t, Readiness(mockApp)(c))
+			assert.Equal(t, tt.expectErrCode, rec.Code)
+			var report health.Report
+			assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &report))
+			assert.Equal(t, tt.expectStatus, report.Status)
+		})
+	}
+}
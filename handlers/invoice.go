@@ -1,22 +1,30 @@
 package handlers
 
 import (
+	"cmp"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"inventory-service-go/commons"
 	"inventory-service-go/context"
 	"inventory-service-go/invoice"
 	"net/http"
+	"strings"
+	"time"
 )
 
-func InvoiceRoutes(g *echo.Group, a context.ApplicationContext) {
-	g.POST("/invoices/:id/items", AddItemsToInvoice(a))
-	g.POST("/invoices", CreateInvoice(a))
-	g.DELETE("/invoices/:id", DeleteInvoice(a))
-	g.GET("/invoices", GetAllInvoices(a))
-	g.GET("/invoices/:id", GetInvoice(a))
-	g.GET("/invoices/user/:userId", GetAllInvoicesForUser(a))
-	g.DELETE("/invoices/:id/items/:itemId", RemoveItemFromInvoice(a))
-	g.PUT("/invoices/:id", UpdateInvoice(a))
+func InvoiceRoutes(g *echo.Group, a context.ApplicationContext, m ...echo.MiddlewareFunc) {
+	g.POST("/invoices/:id/items", AddItemsToInvoice(a), m...)
+	g.POST("/invoices", CreateInvoice(a), m...)
+	g.DELETE("/invoices/:id", DeleteInvoice(a), m...)
+	g.GET("/invoices", GetAllInvoices(a), m...)
+	g.GET("/invoices/:id", GetInvoice(a), m...)
+	g.GET("/invoices/by-number/:number", GetInvoiceByNumber(a), m...)
+	g.GET("/invoices/user/:userId", GetAllInvoicesForUser(a), m...)
+	g.DELETE("/invoices/:id/items/:itemId", RemoveItemFromInvoice(a), m...)
+	g.PUT("/invoices/:id", UpdateInvoice(a), m...)
+	g.POST("/invoices/:id/discounts", AddInvoiceDiscount(a), m...)
+	g.POST("/invoices/:id/promotions", ApplyPromotion(a), m...)
+	g.POST("/invoices/:id/issue", IssueInvoice(a), m...)
 }
 
 // GetAllInvoices
@@ -28,6 +36,7 @@ func InvoiceRoutes(g *echo.Group, a context.ApplicationContext) {
 //		@Produce		json
 //		@Param			last_id		query		int	false	"last seq id"
 //	 	@Param			page_size 	query		int false 	"number of invoices per page"
+//		@Param			currency	query		string	false	"adds normalized_total, the total in this currency or with 'base' in the base currency"
 //		@Success		200	{array}		invoice.Invoice 	"OK"
 //		@Failure		400	{string}	string 				"Bad Request"
 //		@Failure		500	{string}	string 				"Internal Server Error"
@@ -35,10 +44,13 @@ func InvoiceRoutes(g *echo.Group, a context.ApplicationContext) {
 func GetAllInvoices(a context.ApplicationContext) func(c echo.Context) error {
 	return func(c echo.Context) error {
 		pagination := paginationFromRequest(c)
-		results, err := a.InvoiceService().GetAllInvoices(pagination)
+		results, err := a.InvoiceService().GetAllInvoices(c.Request().Context(), pagination)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, err)
 		}
+		if err = normalizeTotals(a, results, c.QueryParam("currency")); err != nil {
+			return commons.HandleServiceError(c, err)
+		}
 		return c.JSON(http.StatusOK, results)
 	}
 }
@@ -64,9 +76,10 @@ func CreateInvoice(a context.ApplicationContext) func(c echo.Context) error {
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, err)
 		}
-		result, err := a.InvoiceService().CreateInvoice(request)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, err)
+		result, err := a.InvoiceService().CreateInvoice(c.Request().Context(), request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
 		}
 		return c.JSON(http.StatusOK, result)
 	}
@@ -102,9 +115,10 @@ func UpdateInvoice(a context.ApplicationContext) func(c echo.Context) error {
 		if id != request.Id {
 			return c.JSON(http.StatusBadRequest, "id in path does not match id in body")
 		}
-		result, err := a.InvoiceService().UpdateInvoice(request)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, err)
+		result, err := a.InvoiceService().UpdateInvoice(c.Request().Context(), request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
 		}
 		return c.JSON(http.StatusOK, result)
 	}
@@ -131,7 +145,7 @@ func GetInvoice(a context.ApplicationContext) func(c echo.Context) error {
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, err)
 		}
-		result, err := a.InvoiceService().GetInvoice(id, withItems)
+		result, err := a.InvoiceService().GetInvoice(c.Request().Context(), id, withItems)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, err)
 		}
@@ -158,7 +172,7 @@ func DeleteInvoice(a context.ApplicationContext) func(c echo.Context) error {
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, err)
 		}
-		results, err := a.InvoiceService().DeleteInvoice(id)
+		results, err := a.InvoiceService().DeleteInvoice(c.Request().Context(), id)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, err)
 		}
@@ -174,6 +188,7 @@ func DeleteInvoice(a context.ApplicationContext) func(c echo.Context) error {
 //		@Tags			invoice
 //		@Produce		json
 //	 	@Param			id				query		uuid.Uuid 	true 	"id of a user"
+//		@Param			currency		query		string		false	"adds normalized_total, the total in this currency or with 'base' in the base currency"
 //		@Success		200	{array}		invoice.Invoice 	"OK"
 //		@Failure		400	{string}	string 				"Bad Request"
 //		@Failure		500	{string}	string 				"Internal Server Error"
@@ -185,14 +200,46 @@ func GetAllInvoicesForUser(a context.ApplicationContext) func(c echo.Context) er
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, err)
 		}
-		results, err := a.InvoiceService().GetInvoicesForUser(userId)
+		results, err := a.InvoiceService().GetInvoicesForUser(c.Request().Context(), userId)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, err)
 		}
+		if err = normalizeTotals(a, results, c.QueryParam("currency")); err != nil {
+			return commons.HandleServiceError(c, err)
+		}
 		return c.JSON(http.StatusOK, results)
 	}
 }
 
+// baseCurrencyParam is the value of the currency query parameter normalizing the totals to the base currency.
+const baseCurrencyParam = "base"
+
+// normalizeTotals sets the NormalizedTotal of the invoices when a currency was asked for, converted at the rate of the
+// day the invoice was issued, or created if it has not been.
+func normalizeTotals(a context.ApplicationContext, invoices []invoice.Invoice, currency string) error {
+	if currency == "" {
+		return nil
+	}
+	rates := a.ExchangeRateService()
+	for i := range invoices {
+		on, err := time.Parse(time.RFC3339, cmp.Or(invoices[i].IssuedAt, invoices[i].AuditInfo.CreatedAt))
+		if err != nil {
+			on = time.Now()
+		}
+		var total commons.Money
+		if strings.EqualFold(currency, baseCurrencyParam) {
+			total, err = rates.Normalize(invoices[i].Total, on)
+		} else {
+			total, err = rates.Convert(invoices[i].Total, currency, on)
+		}
+		if err != nil {
+			return err
+		}
+		invoices[i].NormalizedTotal = &total
+	}
+	return nil
+}
+
 // AddItemsToInvoice
 //
 //		@Summary		Add Items to Invoice
@@ -214,9 +261,10 @@ func AddItemsToInvoice(a context.ApplicationContext) func(c echo.Context) error
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, err)
 		}
-		response, err := a.InvoiceService().AddItemsToInvoice(request)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, err)
+		response, err := a.InvoiceService().AddItemsToInvoice(c.Request().Context(), request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
 		}
 		return c.JSON(http.StatusOK, response)
 	}
@@ -247,10 +295,138 @@ func RemoveItemFromInvoice(a context.ApplicationContext) func(c echo.Context) er
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, err)
 		}
-		results, err := a.InvoiceService().RemoveItemFromInvoice(invoice.SimpleInvoiceItem{invoiceId, itemId})
+		results, err := a.InvoiceService().RemoveItemFromInvoice(c.Request().Context(), invoice.SimpleInvoiceItem{invoiceId, itemId})
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, err)
 		}
 		return c.JSON(http.StatusOK, results)
 	}
 }
+
+// AddInvoiceDiscount
+//
+//		@Summary		Add Discount to Invoice
+//		@Description	Take a percentage or a fixed amount off the whole Invoice, shared across its lines before tax
+//		@Id				add_invoice_discount
+//		@Tags			invoice
+//		@Accept			json
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the invoice"
+//	    @Param 			request body 	invoice.InvoiceDiscountRequest		true 	"Invoice Discount Request"
+//		@Success		200	{object}	invoice.Invoice 	"OK"
+//		@Failure		400	{string}	string 				"Bad Request"
+//		@Failure		404	{string}	string 				"Not Found"
+//		@Failure		500	{string}	string 				"Internal Server Error"
+//		@Router			/invoices/{id}/discounts [post]
+func AddInvoiceDiscount(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request invoice.InvoiceDiscountRequest
+		err := c.Bind(&request)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		request.InvoiceId, err = uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.InvoiceService().AddDiscount(c.Request().Context(), request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// ApplyPromotion
+//
+//		@Summary		Apply Promotion Code to Invoice
+//		@Description	Redeem a promotion code against an Invoice, checking its validity window, usage limit and minimum order amount
+//		@Id				apply_promotion
+//		@Tags			invoice
+//		@Accept			json
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the invoice"
+//	    @Param 			request body 	invoice.PromotionCodeRequest		true 	"Promotion Code Request"
+//		@Success		200	{object}	invoice.Invoice 	"OK"
+//		@Failure		400	{string}	string 				"Bad Request"
+//		@Failure		404	{string}	string 				"Not Found"
+//		@Failure		500	{string}	string 				"Internal Server Error"
+//		@Router			/invoices/{id}/promotions [post]
+func ApplyPromotion(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request invoice.PromotionCodeRequest
+		err := c.Bind(&request)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		request.InvoiceId, err = uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.InvoiceService().ApplyPromotion(c.Request().Context(), request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// IssueInvoice
+//
+//		@Summary		Issue Invoice
+//		@Description	Give an Invoice the next sequential invoice number, an Invoice that was already issued keeps its number
+//		@Id				issue_invoice
+//		@Tags			invoice
+//		@Accept			json
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the invoice"
+//	    @Param 			request body 	invoice.IssueInvoiceRequest		true 	"Issue Invoice Request"
+//		@Success		200	{object}	invoice.Invoice 	"OK"
+//		@Failure		400	{string}	string 				"Bad Request"
+//		@Failure		404	{string}	string 				"Not Found"
+//		@Failure		500	{string}	string 				"Internal Server Error"
+//		@Router			/invoices/{id}/issue [post]
+func IssueInvoice(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request invoice.IssueInvoiceRequest
+		err := c.Bind(&request)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		request.Id, err = uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.InvoiceService().IssueInvoice(c.Request().Context(), request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetInvoiceByNumber
+//
+//		@Summary		Get Invoice By Number
+//		@Description	Get a specific Invoice by its invoice number, e.g. INV-2026-000123
+//		@Id				get_invoice_by_number
+//		@Tags			invoice
+//		@Produce		json
+//	 	@Param			number			path		string 		true 	"invoice number"
+//		@Success		200	{object}	invoice.Invoice 	"OK"
+//		@Failure		404	{string}	string 				"Not Found"
+//		@Failure		500	{string}	string 				"Internal Server Error"
+//		@Router			/invoices/by-number/{number} [get]
+func GetInvoiceByNumber(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		result, err := a.InvoiceService().GetInvoiceByNumber(c.Request().Context(), c.Param("number"))
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"github.com/google/uuid"
@@ -10,10 +11,13 @@ import (
 	"go.uber.org/mock/gomock"
 	"inventory-service-go/commons"
 	"inventory-service-go/context"
+	"inventory-service-go/currency"
 	"inventory-service-go/invoice"
+	"inventory-service-go/promotion"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestInvoiceRoutes(t *testing.T) {
@@ -22,7 +26,7 @@ func TestInvoiceRoutes(t *testing.T) {
 	t.Run("successful route registration", func(t *testing.T) {
 		InvoiceRoutes(e.Group("/test"), mockApp)
 		routes := e.Routes()
-		assert.Equal(t, 8, len(routes))
+		assert.Equal(t, 12, len(routes))
 	})
 }
 
@@ -38,7 +42,7 @@ func TestGetAllInvoices(t *testing.T) {
 			Seq:       1,
 			Id:        uuid.UUID{},
 			UserId:    uuid.UUID{},
-			Total:     0,
+			Total:     commons.NewMoney(0, "USD"),
 			Paid:      false,
 			Items:     nil,
 			AuditInfo: commons.AuditInfo{},
@@ -47,7 +51,7 @@ func TestGetAllInvoices(t *testing.T) {
 			Seq:       2,
 			Id:        uuid.UUID{},
 			UserId:    uuid.UUID{},
-			Total:     0,
+			Total:     commons.NewMoney(0, "USD"),
 			Paid:      false,
 			Items:     nil,
 			AuditInfo: commons.AuditInfo{},
@@ -62,7 +66,7 @@ func TestGetAllInvoices(t *testing.T) {
 		{
 			name: "successful retrieval",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().GetAllInvoices(&paginationFixture).Return(expectedInvoices, nil)
+				mockService.EXPECT().GetAllInvoices(gomock.Any(), &paginationFixture).Return(expectedInvoices, nil)
 			},
 			expectBody:    expectedInvoices,
 			expectErrCode: http.StatusOK,
@@ -70,7 +74,7 @@ func TestGetAllInvoices(t *testing.T) {
 		{
 			name: "service error",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().GetAllInvoices(&paginationFixture).Return([]invoice.Invoice{}, errors.New("BOOM"))
+				mockService.EXPECT().GetAllInvoices(gomock.Any(), &paginationFixture).Return([]invoice.Invoice{}, errors.New("BOOM"))
 			},
 			expectBody:    nil,
 			expectErrCode: http.StatusInternalServerError,
@@ -99,6 +103,56 @@ func TestGetAllInvoices(t *testing.T) {
 	}
 }
 
+func TestGetAllInvoices_Currency(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockInvoiceService := invoice.NewMockInvoiceService(controller)
+	mockRates := currency.NewMockExchangeRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, mockInvoiceService).WithExchangeRateService(mockRates)
+	issued := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	invoices := func() []invoice.Invoice {
+		return []invoice.Invoice{
+			{Seq: 1, Total: commons.NewMoney(1000, "GBP"), IssuedAt: issued.Format(time.RFC3339)},
+			{Seq: 2, Total: commons.NewMoney(500, "USD"), AuditInfo: commons.AuditInfo{CreatedAt: issued.Format(time.RFC3339)}},
+		}
+	}
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+		rec := httptest.NewRecorder()
+		assert.NoError(t, GetAllInvoices(mockApp)(echo.New().NewContext(req, rec)))
+		return rec
+	}
+
+	t.Run("in a currency", func(t *testing.T) {
+		mockInvoiceService.EXPECT().GetAllInvoices(gomock.Any(), gomock.Any()).Return(invoices(), nil)
+		mockRates.EXPECT().Convert(commons.NewMoney(1000, "GBP"), "EUR", issued).Return(commons.NewMoney(1170, "EUR"), nil)
+		mockRates.EXPECT().Convert(commons.NewMoney(500, "USD"), "EUR", issued).Return(commons.NewMoney(460, "EUR"), nil)
+		rec := get("currency=EUR")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var body []invoice.Invoice
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+		assert.Equal(t, commons.NewMoney(1170, "EUR"), *body[0].NormalizedTotal)
+		assert.Equal(t, commons.NewMoney(460, "EUR"), *body[1].NormalizedTotal)
+	})
+	t.Run("in the base currency", func(t *testing.T) {
+		mockInvoiceService.EXPECT().GetAllInvoices(gomock.Any(), gomock.Any()).Return(invoices()[:1], nil)
+		mockRates.EXPECT().Normalize(commons.NewMoney(1000, "GBP"), issued).Return(commons.NewMoney(1270, "USD"), nil)
+		rec := get("currency=base")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"normalized_total":{"amount":"12.70","currency":"USD"}`)
+	})
+	t.Run("without a rate", func(t *testing.T) {
+		mockInvoiceService.EXPECT().GetAllInvoices(gomock.Any(), gomock.Any()).Return(invoices()[:1], nil)
+		mockRates.EXPECT().Convert(gomock.Any(), "EUR", issued).Return(commons.Money{}, currency.ErrNoExchangeRate)
+		assert.Equal(t, http.StatusBadRequest, get("currency=EUR").Code)
+	})
+	t.Run("without a currency", func(t *testing.T) {
+		mockInvoiceService.EXPECT().GetAllInvoices(gomock.Any(), gomock.Any()).Return(invoices(), nil)
+		rec := get("")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "normalized_total")
+	})
+}
+
 func TestCreateInvoice(t *testing.T) {
 	controller := gomock.NewController(t)
 	mockInvoiceService := invoice.NewMockInvoiceService(controller)
@@ -106,14 +160,14 @@ func TestCreateInvoice(t *testing.T) {
 	createInvoiceRequest := invoice.CreateInvoiceRequest{
 		UserId:    userId,
 		Paid:      false,
-		Total:     10.0,
+		Total:     commons.NewMoney(1000, "USD"),
 		CreatedBy: "unit test",
 	}
 	expectedInvoice := invoice.Invoice{
 		Seq:       1,
 		Id:        uuid.UUID{},
 		UserId:    userId,
-		Total:     10.0,
+		Total:     commons.NewMoney(1000, "USD"),
 		Paid:      false,
 		Items:     nil,
 		AuditInfo: commons.AuditInfo{},
@@ -129,7 +183,7 @@ func TestCreateInvoice(t *testing.T) {
 		{
 			name: "successful creation",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().CreateInvoice(createInvoiceRequest).Return(expectedInvoice, nil)
+				mockService.EXPECT().CreateInvoice(gomock.Any(), createInvoiceRequest).Return(expectedInvoice, nil)
 			},
 			inputBody:     createInvoiceRequest,
 			expectBody:    expectedInvoice,
@@ -138,7 +192,7 @@ func TestCreateInvoice(t *testing.T) {
 		{
 			name: "internal server error",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().CreateInvoice(createInvoiceRequest).Return(invoice.Invoice{}, errors.New("BOOM"))
+				mockService.EXPECT().CreateInvoice(gomock.Any(), createInvoiceRequest).Return(invoice.Invoice{}, errors.New("BOOM"))
 			},
 			inputBody:     createInvoiceRequest,
 			expectBody:    invoice.Invoice{},
@@ -147,7 +201,7 @@ func TestCreateInvoice(t *testing.T) {
 		{
 			name: "bad request",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().CreateInvoice(createInvoiceRequest).Times(0)
+				mockService.EXPECT().CreateInvoice(gomock.Any(), createInvoiceRequest).Times(0)
 			},
 			inputBody:     invoice.CreateInvoiceRequest{},
 			expectBody:    invoice.Invoice{},
@@ -192,14 +246,13 @@ func TestUpdateInvoice(t *testing.T) {
 	updateInvoiceRequest := invoice.UpdateInvoiceRequest{
 		Id:            id,
 		Paid:          true,
-		Total:         20.0,
 		LastChangedBy: "unit test",
 	}
 	expectedInvoice := invoice.Invoice{
 		Seq:       1,
 		Id:        id,
 		UserId:    userId,
-		Total:     20.0,
+		Total:     commons.NewMoney(2000, "USD"),
 		Paid:      true,
 		Items:     nil,
 		AuditInfo: commons.AuditInfo{},
@@ -217,7 +270,7 @@ func TestUpdateInvoice(t *testing.T) {
 		{
 			name: "successful update",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().UpdateInvoice(updateInvoiceRequest).Return(expectedInvoice, nil)
+				mockService.EXPECT().UpdateInvoice(gomock.Any(), updateInvoiceRequest).Return(expectedInvoice, nil)
 			},
 			nilBody:       false,
 			inputBody:     updateInvoiceRequest,
@@ -228,7 +281,7 @@ func TestUpdateInvoice(t *testing.T) {
 		{
 			name: "internal server error",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().UpdateInvoice(updateInvoiceRequest).Return(invoice.Invoice{}, errors.New("BOOM"))
+				mockService.EXPECT().UpdateInvoice(gomock.Any(), updateInvoiceRequest).Return(invoice.Invoice{}, errors.New("BOOM"))
 			},
 			nilBody:       false,
 			inputBody:     updateInvoiceRequest,
@@ -239,7 +292,7 @@ func TestUpdateInvoice(t *testing.T) {
 		{
 			name: "bad request: body",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().UpdateInvoice(updateInvoiceRequest).Times(0)
+				mockService.EXPECT().UpdateInvoice(gomock.Any(), updateInvoiceRequest).Times(0)
 			},
 			nilBody:       true,
 			inputBody:     invoice.UpdateInvoiceRequest{},
@@ -250,7 +303,7 @@ func TestUpdateInvoice(t *testing.T) {
 		{
 			name: "bad request: id",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().UpdateInvoice(updateInvoiceRequest).Times(0)
+				mockService.EXPECT().UpdateInvoice(gomock.Any(), updateInvoiceRequest).Times(0)
 			},
 			nilBody:       false,
 			inputBody:     updateInvoiceRequest,
@@ -261,7 +314,7 @@ func TestUpdateInvoice(t *testing.T) {
 		{
 			name: "bad request: mismatched ids",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().UpdateInvoice(updateInvoiceRequest).Times(0)
+				mockService.EXPECT().UpdateInvoice(gomock.Any(), updateInvoiceRequest).Times(0)
 			},
 			nilBody:       false,
 			inputBody:     updateInvoiceRequest,
@@ -309,7 +362,7 @@ func TestGetInvoice(t *testing.T) {
 		Seq:       1,
 		Id:        id,
 		UserId:    uuid.New(),
-		Total:     10.0,
+		Total:     commons.NewMoney(1000, "USD"),
 		Paid:      false,
 		Items:     nil,
 		AuditInfo: commons.AuditInfo{},
@@ -325,7 +378,7 @@ func TestGetInvoice(t *testing.T) {
 		{
 			name: "successful retrieval",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().GetInvoice(id, true).Return(expectedInvoice, nil)
+				mockService.EXPECT().GetInvoice(gomock.Any(), id, true).Return(expectedInvoice, nil)
 			},
 			paramId:        id.String(),
 			queryWithItems: "true",
@@ -335,7 +388,7 @@ func TestGetInvoice(t *testing.T) {
 		{
 			name: "internal server error",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().GetInvoice(id, true).Return(invoice.Invoice{}, errors.New("BOOM"))
+				mockService.EXPECT().GetInvoice(gomock.Any(), id, true).Return(invoice.Invoice{}, errors.New("BOOM"))
 			},
 			paramId:        id.String(),
 			queryWithItems: "true",
@@ -344,7 +397,7 @@ func TestGetInvoice(t *testing.T) {
 		{
 			name: "bad request: id",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().GetInvoice(id, true).Times(0)
+				mockService.EXPECT().GetInvoice(gomock.Any(), id, true).Times(0)
 			},
 			paramId:        "bad-id",
 			queryWithItems: "true",
@@ -394,7 +447,7 @@ func TestDeleteInvoice(t *testing.T) {
 		{
 			name: "successful deletion",
 			funcSetup: func() {
-				mockInvoiceService.EXPECT().DeleteInvoice(id).Return(expectedResults, nil)
+				mockInvoiceService.EXPECT().DeleteInvoice(gomock.Any(), id).Return(expectedResults, nil)
 			},
 			paramId:       id.String(),
 			expectErrCode: http.StatusOK,
@@ -402,7 +455,7 @@ func TestDeleteInvoice(t *testing.T) {
 		{
 			name: "internal server error",
 			funcSetup: func() {
-				mockInvoiceService.EXPECT().DeleteInvoice(id).Return(commons.DeleteResult{}, errors.New("BOOM"))
+				mockInvoiceService.EXPECT().DeleteInvoice(gomock.Any(), id).Return(commons.DeleteResult{}, errors.New("BOOM"))
 			},
 			paramId:       id.String(),
 			expectErrCode: http.StatusInternalServerError,
@@ -410,7 +463,7 @@ func TestDeleteInvoice(t *testing.T) {
 		{
 			name: "bad request: id",
 			funcSetup: func() {
-				mockInvoiceService.EXPECT().DeleteInvoice(id).Times(0)
+				mockInvoiceService.EXPECT().DeleteInvoice(gomock.Any(), id).Times(0)
 			},
 			paramId:       "bad-id",
 			expectErrCode: http.StatusBadRequest,
@@ -443,7 +496,7 @@ func TestGetAllInvoicesForUser(t *testing.T) {
 			Seq:       1,
 			Id:        uuid.UUID{},
 			UserId:    userId,
-			Total:     0,
+			Total:     commons.NewMoney(0, "USD"),
 			Paid:      false,
 			Items:     nil,
 			AuditInfo: commons.AuditInfo{},
@@ -452,7 +505,7 @@ func TestGetAllInvoicesForUser(t *testing.T) {
 			Seq:       2,
 			Id:        uuid.UUID{},
 			UserId:    userId,
-			Total:     0,
+			Total:     commons.NewMoney(0, "USD"),
 			Paid:      false,
 			Items:     nil,
 			AuditInfo: commons.AuditInfo{},
@@ -468,7 +521,7 @@ func TestGetAllInvoicesForUser(t *testing.T) {
 		{
 			name: "successful retrieval",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().GetInvoicesForUser(userId).Return(expectedInvoices, nil)
+				mockService.EXPECT().GetInvoicesForUser(gomock.Any(), userId).Return(expectedInvoices, nil)
 			},
 			paramUserId:   userId.String(),
 			expectBody:    expectedInvoices,
@@ -477,7 +530,7 @@ func TestGetAllInvoicesForUser(t *testing.T) {
 		{
 			name: "service error",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().GetInvoicesForUser(userId).Return([]invoice.Invoice{}, errors.New("BOOM"))
+				mockService.EXPECT().GetInvoicesForUser(gomock.Any(), userId).Return([]invoice.Invoice{}, errors.New("BOOM"))
 			},
 			paramUserId:   userId.String(),
 			expectErrCode: http.StatusInternalServerError,
@@ -485,7 +538,7 @@ func TestGetAllInvoicesForUser(t *testing.T) {
 		{
 			name: "bad request: userId",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().GetInvoicesForUser(userId).Times(0)
+				mockService.EXPECT().GetInvoicesForUser(gomock.Any(), userId).Times(0)
 			},
 			paramUserId:   "bad-id",
 			expectErrCode: http.StatusBadRequest,
@@ -538,7 +591,7 @@ func TestAddItemsToInvoice(t *testing.T) {
 		{
 			name: "successful addition",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().AddItemsToInvoice(addItemsRequest).Return(expectedResult, nil)
+				mockService.EXPECT().AddItemsToInvoice(gomock.Any(), addItemsRequest).Return(expectedResult, nil)
 			},
 			inputBody:     addItemsRequest,
 			expectBody:    expectedResult,
@@ -547,7 +600,7 @@ func TestAddItemsToInvoice(t *testing.T) {
 		{
 			name: "internal server error",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().AddItemsToInvoice(addItemsRequest).Return(invoice.ItemsToInvoiceResponse{}, errors.New("BOOM"))
+				mockService.EXPECT().AddItemsToInvoice(gomock.Any(), addItemsRequest).Return(invoice.ItemsToInvoiceResponse{}, errors.New("BOOM"))
 			},
 			inputBody:     addItemsRequest,
 			expectErrCode: http.StatusInternalServerError,
@@ -555,7 +608,7 @@ func TestAddItemsToInvoice(t *testing.T) {
 		{
 			name: "bad request: body missing",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().AddItemsToInvoice(addItemsRequest).Times(0)
+				mockService.EXPECT().AddItemsToInvoice(gomock.Any(), addItemsRequest).Times(0)
 			},
 			inputBody:     invoice.ItemsToInvoiceRequest{},
 			expectErrCode: http.StatusBadRequest,
@@ -609,7 +662,7 @@ func TestRemoveItemFromInvoice(t *testing.T) {
 		{
 			name: "successful removal",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().RemoveItemFromInvoice(invoice.SimpleInvoiceItem{invoiceId, itemId}).Return(expectedResult, nil)
+				mockService.EXPECT().RemoveItemFromInvoice(gomock.Any(), invoice.SimpleInvoiceItem{invoiceId, itemId}).Return(expectedResult, nil)
 			},
 			paramInvoiceId: invoiceId.String(),
 			paramItemId:    itemId.String(),
@@ -619,7 +672,7 @@ func TestRemoveItemFromInvoice(t *testing.T) {
 		{
 			name: "internal server error",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().RemoveItemFromInvoice(invoice.SimpleInvoiceItem{invoiceId, itemId}).Return(invoice.ItemsToInvoiceResponse{}, errors.New("BOOM"))
+				mockService.EXPECT().RemoveItemFromInvoice(gomock.Any(), invoice.SimpleInvoiceItem{invoiceId, itemId}).Return(invoice.ItemsToInvoiceResponse{}, errors.New("BOOM"))
 			},
 			paramInvoiceId: invoiceId.String(),
 			paramItemId:    itemId.String(),
@@ -628,7 +681,7 @@ func TestRemoveItemFromInvoice(t *testing.T) {
 		{
 			name: "bad request: invoiceId",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().RemoveItemFromInvoice(invoice.SimpleInvoiceItem{invoiceId, itemId}).Times(0)
+				mockService.EXPECT().RemoveItemFromInvoice(gomock.Any(), invoice.SimpleInvoiceItem{invoiceId, itemId}).Times(0)
 			},
 			paramInvoiceId: "bad-id",
 			paramItemId:    itemId.String(),
@@ -637,7 +690,7 @@ func TestRemoveItemFromInvoice(t *testing.T) {
 		{
 			name: "bad request: itemId",
 			mockFunc: func(mockService *invoice.MockInvoiceService) {
-				mockService.EXPECT().RemoveItemFromInvoice(invoice.SimpleInvoiceItem{invoiceId, itemId}).Times(0)
+				mockService.EXPECT().RemoveItemFromInvoice(gomock.Any(), invoice.SimpleInvoiceItem{invoiceId, itemId}).Times(0)
 			},
 			paramInvoiceId: invoiceId.String(),
 			paramItemId:    "bad-id",
@@ -667,3 +720,206 @@ func TestRemoveItemFromInvoice(t *testing.T) {
 		})
 	}
 }
+
+func TestAddInvoiceDiscount(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockInvoiceService := invoice.NewMockInvoiceService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, mockInvoiceService)
+	invoiceId := uuid.New()
+	request := invoice.InvoiceDiscountRequest{
+		InvoiceId:   invoiceId,
+		Discount:    promotion.Discount{Type: promotion.Percentage, Value: "10"},
+		Description: "loyalty",
+		CreatedBy:   "unit test",
+	}
+	tests := []struct {
+		name          string
+		id            string
+		body          []byte
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful discount",
+			id:   invoiceId.String(),
+			body: mustJson(request),
+			mockFunc: func() {
+				mockInvoiceService.EXPECT().AddDiscount(gomock.Any(), request).Return(invoice.Invoice{Id: invoiceId}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "invalid discount",
+			id:   invoiceId.String(),
+			body: mustJson(request),
+			mockFunc: func() {
+				mockInvoiceService.EXPECT().AddDiscount(gomock.Any(), request).Return(invoice.Invoice{}, promotion.ErrInvalidDiscount)
+			},
+			expectErrCode: http.StatusBadRequest,
+		},
+		{
+			name:          "bad id",
+			id:            "not-a-uuid",
+			body:          mustJson(request),
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if assert.NoError(t, AddInvoiceDiscount(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestApplyPromotion(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockInvoiceService := invoice.NewMockInvoiceService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, mockInvoiceService)
+	invoiceId := uuid.New()
+	request := invoice.PromotionCodeRequest{InvoiceId: invoiceId, Code: "SPRING", CreatedBy: "unit test"}
+	tests := []struct {
+		name          string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful promotion",
+			mockFunc: func() {
+				mockInvoiceService.EXPECT().ApplyPromotion(gomock.Any(), request).Return(invoice.Invoice{Id: invoiceId}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "promotion used up",
+			mockFunc: func() {
+				mockInvoiceService.EXPECT().ApplyPromotion(gomock.Any(), request).Return(invoice.Invoice{}, promotion.ErrPromotionExhausted)
+			},
+			expectErrCode: http.StatusBadRequest,
+		},
+		{
+			name: "invoice not found",
+			mockFunc: func() {
+				mockInvoiceService.EXPECT().ApplyPromotion(gomock.Any(), request).Return(invoice.Invoice{}, sql.ErrNoRows)
+			},
+			expectErrCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(mustJson(invoice.PromotionCodeRequest{Code: "SPRING", CreatedBy: "unit test"})))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(invoiceId.String())
+			if assert.NoError(t, ApplyPromotion(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestIssueInvoice(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockInvoiceService := invoice.NewMockInvoiceService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, mockInvoiceService)
+	invoiceId := uuid.New()
+	request := invoice.IssueInvoiceRequest{Id: invoiceId, IssuedBy: "unit test"}
+	tests := []struct {
+		name          string
+		id            string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful issue",
+			id:   invoiceId.String(),
+			mockFunc: func() {
+				mockInvoiceService.EXPECT().IssueInvoice(gomock.Any(), request).Return(invoice.Invoice{Id: invoiceId, Number: "INV-2026-000123"}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "invoice not found",
+			id:   invoiceId.String(),
+			mockFunc: func() {
+				mockInvoiceService.EXPECT().IssueInvoice(gomock.Any(), request).Return(invoice.Invoice{}, sql.ErrNoRows)
+			},
+			expectErrCode: http.StatusNotFound,
+		},
+		{
+			name:          "bad id",
+			id:            "not-a-uuid",
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(mustJson(invoice.IssueInvoiceRequest{IssuedBy: "unit test"})))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if assert.NoError(t, IssueInvoice(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestGetInvoiceByNumber(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockInvoiceService := invoice.NewMockInvoiceService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, mockInvoiceService)
+	tests := []struct {
+		name          string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful retrieval",
+			mockFunc: func() {
+				mockInvoiceService.EXPECT().GetInvoiceByNumber(gomock.Any(), "INV-2026-000123").Return(invoice.Invoice{Number: "INV-2026-000123"}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "not found",
+			mockFunc: func() {
+				mockInvoiceService.EXPECT().GetInvoiceByNumber(gomock.Any(), "INV-2026-000123").Return(invoice.Invoice{}, sql.ErrNoRows)
+			},
+			expectErrCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("number")
+			c.SetParamValues("INV-2026-000123")
+			if assert.NoError(t, GetInvoiceByNumber(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
@@ -9,12 +9,12 @@ import (
 	"net/http"
 )
 
-func ItemRoutes(p *echo.Group, appContext context.ApplicationContext) {
-	p.GET("/items", AllItems(appContext))
-	p.GET("/items/:id", GetItem(appContext))
-	p.POST("/items", CreateItem(appContext))
-	p.PUT("/items/:id", UpdateItem(appContext))
-	p.DELETE("/items/:id", DeleteItem(appContext))
+func ItemRoutes(p *echo.Group, appContext context.ApplicationContext, m ...echo.MiddlewareFunc) {
+	p.GET("/items", AllItems(appContext), m...)
+	p.GET("/items/:id", GetItem(appContext), m...)
+	p.POST("/items", CreateItem(appContext), m...)
+	p.PUT("/items/:id", UpdateItem(appContext), m...)
+	p.DELETE("/items/:id", DeleteItem(appContext), m...)
 }
 
 // AllItems
@@ -34,7 +34,7 @@ func AllItems(appContext context.ApplicationContext) func(c echo.Context) error
 	return func(c echo.Context) error {
 		pagination := paginationFromRequest(c)
 		itemService := appContext.ItemService()
-		items, err := itemService.GetItems(pagination)
+		items, err := itemService.GetItems(c.Request().Context(), pagination)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, err)
 		}
@@ -64,9 +64,10 @@ func CreateItem(appContext context.ApplicationContext) func(c echo.Context) erro
 			return c.JSON(http.StatusBadRequest, err)
 		}
 		itemService := appContext.ItemService()
-		results, err := itemService.CreateItem(createItemRequest)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, err)
+		results, err := itemService.CreateItem(c.Request().Context(), createItemRequest)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
 		}
 		return c.JSON(http.StatusCreated, results)
 	}
@@ -103,9 +104,10 @@ func UpdateItem(appContext context.ApplicationContext) func(c echo.Context) erro
 			return c.JSON(http.StatusBadRequest, "id in path does not match id in body")
 		}
 		itemService := appContext.ItemService()
-		results, err := itemService.UpdateItem(updateItemRequest)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, err)
+		results, err := itemService.UpdateItem(c.Request().Context(), updateItemRequest)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
 		}
 		return c.JSON(http.StatusOK, results)
 	}
@@ -132,7 +134,7 @@ func DeleteItem(appContext context.ApplicationContext) func(c echo.Context) erro
 			return c.JSON(http.StatusBadRequest, err)
 		}
 		itemService := appContext.ItemService()
-		results, err := itemService.DeleteItem(id)
+		results, err := itemService.DeleteItem(c.Request().Context(), id)
 		err2 := commons.HandleServiceError(c, err)
 		if err2 != nil {
 			return err2
@@ -162,7 +164,7 @@ func GetItem(appContext context.ApplicationContext) func(c echo.Context) error {
 			return c.JSON(http.StatusBadRequest, err)
 		}
 		itemService := appContext.ItemService()
-		results, err := itemService.GetItem(id)
+		results, err := itemService.GetItem(c.Request().Context(), id)
 		err2 := commons.HandleServiceError(c, err)
 		if err2 != nil {
 			return err2
@@ -58,12 +58,12 @@ func TestHandlers_AllItems(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.expectedStatusCode == http.StatusInternalServerError {
-				mockItemService.EXPECT().GetItems(nil).Return([]item.Item{}, errors.New("error"))
+				mockItemService.EXPECT().GetItems(gomock.Any(), nil).Return([]item.Item{}, errors.New("error"))
 			} else {
 				if tt.pagination == nil {
-					mockItemService.EXPECT().GetItems(nil).Return([]item.Item{}, nil)
+					mockItemService.EXPECT().GetItems(gomock.Any(), nil).Return([]item.Item{}, nil)
 				} else {
-					mockItemService.EXPECT().GetItems(tt.pagination).Return([]item.Item{}, nil)
+					mockItemService.EXPECT().GetItems(gomock.Any(), tt.pagination).Return([]item.Item{}, nil)
 				}
 			}
 			var target string
@@ -99,8 +99,8 @@ func TestHandlers_CreateItem(t *testing.T) {
 	}{
 		{
 			name:              "OK with a valid request",
-			createItemRequest: item.CreateItemRequest{Name: "TV", Description: "TV Description", UnitPrice: 10.99, CreatedBy: "Unit Test"},
-			expectedResults: item.Item{Name: "TV", Description: "TV Description", UnitPrice: 10.99, AuditInfo: commons.AuditInfo{
+			createItemRequest: item.CreateItemRequest{Name: "TV", Description: "TV Description", UnitPrice: commons.NewMoney(1099, "USD"), CreatedBy: "Unit Test"},
+			expectedResults: item.Item{Name: "TV", Description: "TV Description", UnitPrice: commons.NewMoney(1099, "USD"), AuditInfo: commons.AuditInfo{
 				CreatedBy:     "Unit Test",
 				CreatedAt:     now,
 				LastUpdate:    "Unit Test",
@@ -110,13 +110,13 @@ func TestHandlers_CreateItem(t *testing.T) {
 		},
 		{
 			name:               "Fail with an invalid request",
-			createItemRequest:  item.CreateItemRequest{Name: "", Description: "", UnitPrice: -1},
+			createItemRequest:  item.CreateItemRequest{Name: "", Description: "", UnitPrice: commons.NewMoney(-100, "USD")},
 			expectedResults:    item.Item{},
 			expectedStatusCode: http.StatusBadRequest,
 		},
 		{
 			name:               "Fail with Internal Server Error",
-			createItemRequest:  item.CreateItemRequest{Name: "", Description: "", UnitPrice: -1},
+			createItemRequest:  item.CreateItemRequest{Name: "", Description: "", UnitPrice: commons.NewMoney(-100, "USD")},
 			expectedResults:    item.Item{},
 			expectedStatusCode: http.StatusInternalServerError,
 		},
@@ -127,9 +127,9 @@ func TestHandlers_CreateItem(t *testing.T) {
 		mockApplicationContext := context.MockApplicationContext(nil, mockItemService, nil)
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.expectedStatusCode == http.StatusInternalServerError {
-				mockItemService.EXPECT().CreateItem(gomock.Any()).Return(nil, errors.New("error"))
+				mockItemService.EXPECT().CreateItem(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
 			} else if tt.expectedStatusCode == http.StatusCreated {
-				mockItemService.EXPECT().CreateItem(tt.createItemRequest).Return(&tt.expectedResults, nil)
+				mockItemService.EXPECT().CreateItem(gomock.Any(), tt.createItemRequest).Return(&tt.expectedResults, nil)
 			}
 			e := echo.New()
 			var req *http.Request
@@ -174,14 +174,14 @@ func TestHandlers_UpdateItem(t *testing.T) {
 				Id:            expectedUuid,
 				Name:          "Updated TV",
 				Description:   "Updated TV Description",
-				UnitPrice:     14.99,
+				UnitPrice:     commons.NewMoney(1499, "USD"),
 				LastChangedBy: "Unit Test",
 			},
 			expectedResults: item.Item{
 				Id:          expectedUuid,
 				Name:        "Updated TV",
 				Description: "Updated TV Description",
-				UnitPrice:   14.99,
+				UnitPrice:   commons.NewMoney(1499, "USD"),
 				AuditInfo: commons.AuditInfo{
 					CreatedBy:     "Unit Test",
 					CreatedAt:     now,
@@ -204,7 +204,7 @@ func TestHandlers_UpdateItem(t *testing.T) {
 				Id:            expectedUuid,
 				Name:          "TV",
 				Description:   "New TV Description",
-				UnitPrice:     19.99,
+				UnitPrice:     commons.NewMoney(1999, "USD"),
 				LastChangedBy: "Unit Test",
 			},
 			expectedResults:    item.Item{},
@@ -217,7 +217,7 @@ func TestHandlers_UpdateItem(t *testing.T) {
 				Id:            expectedUuid,
 				Name:          "TV",
 				Description:   "New TV Description",
-				UnitPrice:     19.99,
+				UnitPrice:     commons.NewMoney(1999, "USD"),
 				LastChangedBy: "Unit Test",
 			},
 			expectedResults:    item.Item{},
@@ -230,7 +230,7 @@ func TestHandlers_UpdateItem(t *testing.T) {
 				Id:            expectedUuid,
 				Name:          "Updated TV",
 				Description:   "Updated TV Description",
-				UnitPrice:     14.99,
+				UnitPrice:     commons.NewMoney(1499, "USD"),
 				LastChangedBy: "Unit Test",
 			},
 			expectedResults:    item.Item{},
@@ -244,9 +244,9 @@ func TestHandlers_UpdateItem(t *testing.T) {
 		mockApplicationContext := context.MockApplicationContext(nil, mockItemService, nil)
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.expectedStatusCode == http.StatusInternalServerError {
-				mockItemService.EXPECT().UpdateItem(gomock.Any()).Return(nil, errors.New("error"))
+				mockItemService.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
 			} else if tt.expectedStatusCode == http.StatusOK {
-				mockItemService.EXPECT().UpdateItem(tt.updateItemRequest).Return(&tt.expectedResults, nil)
+				mockItemService.EXPECT().UpdateItem(gomock.Any(), tt.updateItemRequest).Return(&tt.expectedResults, nil)
 			}
 			e := echo.New()
 			requestJson, err := json.Marshal(tt.updateItemRequest)
@@ -313,11 +313,11 @@ func TestHandlers_GetItem(t *testing.T) {
 		mockApplicationContext := context.MockApplicationContext(nil, mockItemService, nil)
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.expectedStatusCode == http.StatusInternalServerError {
-				mockItemService.EXPECT().GetItem(expectedUuid).Return(nil, errors.New("error"))
+				mockItemService.EXPECT().GetItem(gomock.Any(), expectedUuid).Return(nil, errors.New("error"))
 			} else if tt.expectedStatusCode == http.StatusNotFound {
-				mockItemService.EXPECT().GetItem(expectedUuid).Return(nil, sql.ErrNoRows)
+				mockItemService.EXPECT().GetItem(gomock.Any(), expectedUuid).Return(nil, sql.ErrNoRows)
 			} else if tt.expectedStatusCode == http.StatusOK {
-				mockItemService.EXPECT().GetItem(expectedUuid).Return(tt.expectedItem, nil)
+				mockItemService.EXPECT().GetItem(gomock.Any(), expectedUuid).Return(tt.expectedItem, nil)
 			}
 			e := echo.New()
 			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/%v", tt.id), nil)
@@ -377,11 +377,11 @@ func TestHandlers_DeleteItem(t *testing.T) {
 		mockApplicationContext := context.MockApplicationContext(nil, mockItemService, nil)
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.expectedStatusCode == http.StatusInternalServerError {
-				mockItemService.EXPECT().DeleteItem(expectedUuid).Return(nil, errors.New("error"))
+				mockItemService.EXPECT().DeleteItem(gomock.Any(), expectedUuid).Return(nil, errors.New("error"))
 			} else if tt.expectedStatusCode == http.StatusNotFound {
-				mockItemService.EXPECT().DeleteItem(expectedUuid).Return(nil, sql.ErrNoRows)
+				mockItemService.EXPECT().DeleteItem(gomock.Any(), expectedUuid).Return(nil, sql.ErrNoRows)
 			} else if tt.expectedStatusCode == http.StatusOK {
-				mockItemService.EXPECT().DeleteItem(expectedUuid).Return(&expectedResults, nil)
+				mockItemService.EXPECT().DeleteItem(gomock.Any(), expectedUuid).Return(&expectedResults, nil)
 			}
 			e := echo.New()
 			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/%v", tt.id), nil)
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/context"
+	"net/http"
+)
+
+// JWKSRoutes publishes the public keys of the tokens on the root of the server, where the verifiers look for them.
+// Like the probes it is not part of the documented API, see README.
+func JWKSRoutes(g *echo.Group, a context.ApplicationContext) {
+	g.GET("/.well-known/jwks.json", JWKS(a))
+}
+
+// JWKS are the keys the tokens are verified with by their kid, none while the tokens are signed with JWT_SECRET. A key
+// is published long before it signs, see auth.KeySet, so the verifiers may cache them for a while.
+func JWKS(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=300")
+		return c.JSON(http.StatusOK, a.AuthProvider().JWKS())
+	}
+}
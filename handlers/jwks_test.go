@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/auth"
+	"inventory-service-go/context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// keyedContext signs the tokens with a new Ed25519 key.
+func keyedContext(t *testing.T) (context.ApplicationContext, auth.SigningKey) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when generating a key", err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(private)
+	key, err := auth.ParseSigningKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), time.Time{})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing a key", err)
+	}
+	keys, err := auth.NewKeySet([]auth.SigningKey{key}, time.Hour)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating a key set", err)
+	}
+	provider := &auth.JwtAuthProvider{TokenTTL: time.Hour, Keys: keys}
+	return context.MockApplicationContext(nil, nil, nil).WithAuthProvider(provider), key
+}
+
+func TestJWKS(t *testing.T) {
+	get := func(t *testing.T, a context.ApplicationContext) auth.JWKS {
+		e := echo.New()
+		JWKSRoutes(e.Group(""), a)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "public, max-age=300", rec.Header().Get(echo.HeaderCacheControl))
+		var jwks auth.JWKS
+		if err := json.Unmarshal(rec.Body.Bytes(), &jwks); err != nil {
+			t.Fatalf("unexpected response %s", rec.Body.String())
+		}
+		return jwks
+	}
+
+	t.Run("signed with keys", func(t *testing.T) {
+		a, key := keyedContext(t)
+		jwks := get(t, a)
+		assert.Equal(t, []auth.JWK{key.JWK()}, jwks.Keys)
+		assert.Empty(t, jwks.Keys[0].N)
+	})
+	t.Run("signed with the secret", func(t *testing.T) {
+		jwks := get(t, context.MockApplicationContext(nil, nil, nil))
+		assert.NotNil(t, jwks.Keys)
+		assert.Empty(t, jwks.Keys)
+	})
+}
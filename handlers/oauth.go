@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"cmp"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/auth"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/logging"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// OAuthRoutes registers the client credentials grant of RFC 6749, token introspection of RFC 7662 and the metadata of
+// RFC 8414 on the root of the server, for the OAuth libraries that look for them there. They authenticate the clients
+// themselves, so they are public paths of the JWT middleware. issuer is the public URL of the server, see
+// config.OAuth. Like the probes they are not part of the documented API, see README.
+func OAuthRoutes(g *echo.Group, a context.ApplicationContext, issuer string) {
+	issuer = strings.TrimSuffix(issuer, "/")
+	g.POST("/oauth/token", OAuthToken(a))
+	g.POST("/oauth/introspect", OAuthIntrospect(a, issuer))
+	g.GET("/.well-known/oauth-authorization-server", OAuthMetadata(a, issuer))
+}
+
+// OAuthTokenResponse is the successful response of RFC 6749 section 5.1.
+type OAuthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// OAuthError is the error response of RFC 6749 section 5.2, Error is one of the OAuthError constants.
+type OAuthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+const (
+	OAuthInvalidRequest       = "invalid_request"
+	OAuthInvalidClient        = "invalid_client"
+	OAuthUnsupportedGrantType = "unsupported_grant_type"
+)
+
+// OAuthIntrospection is the response of RFC 7662 section 2.2, only Active is set for a token that is not.
+type OAuthIntrospection struct {
+	Active    bool   `json:"active"`
+	TokenType string `json:"token_type,omitempty"`
+	Username  string `json:"username,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	Issuer    string `json:"iss,omitempty"`
+	// Tenant is the tenant the token is scoped to, an extension of RFC 7662
+	Tenant string `json:"tenant,omitempty"`
+}
+
+// OAuthServerMetadata is the authorization server metadata of RFC 8414 section 2.
+type OAuthServerMetadata struct {
+	Issuer                                    string   `json:"issuer"`
+	TokenEndpoint                             string   `json:"token_endpoint"`
+	IntrospectionEndpoint                     string   `json:"introspection_endpoint"`
+	JWKSURI                                   string   `json:"jwks_uri,omitempty"`
+	GrantTypesSupported                       []string `json:"grant_types_supported"`
+	ResponseTypesSupported                    []string `json:"response_types_supported"`
+	TokenEndpointAuthMethodsSupported         []string `json:"token_endpoint_auth_methods_supported"`
+	IntrospectionEndpointAuthMethodsSupported []string `json:"introspection_endpoint_auth_methods_supported"`
+}
+
+// clientAuthMethods are how the clients authenticate to both endpoints, with HTTP Basic or the parameters of the form.
+var clientAuthMethods = []string{"client_secret_basic", "client_secret_post"}
+
+// OAuthToken issues tokens for the client credentials grant, the client id and secret being the username and client
+// secret of a service account. A requested scope is ignored, the tokens are not scoped.
+func OAuthToken(a context.ApplicationContext) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		// the token response must not be cached, RFC 6749 section 5.1
+		c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+		c.Response().Header().Set("Pragma", "no-cache")
+		credentials, basic, err := clientCredentials(c)
+		if errors.Is(err, errNoClient) {
+			return invalidClient(c, basic)
+		} else if err != nil {
+			return oauthError(c, http.StatusBadRequest, OAuthInvalidRequest, err.Error())
+		}
+		switch grantType := c.FormValue("grant_type"); grantType {
+		case "client_credentials":
+		case "":
+			return oauthError(c, http.StatusBadRequest, OAuthInvalidRequest, "grant_type is required")
+		default:
+			return oauthError(c, http.StatusBadRequest, OAuthUnsupportedGrantType, "only client_credentials is supported")
+		}
+		ctx := c.Request().Context()
+		tenant, err := a.AuthProvider().VerifyClient(ctx, credentials.ClientId, credentials.ClientSecret)
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			return invalidClient(c, basic)
+		}
+		var token string
+		if err == nil {
+			token, err = a.AuthProvider().IssueToken(credentials.ClientId, tenant, 0)
+		}
+		if err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "Unexpected error issuing a token", "error", err)
+			return c.JSON(http.StatusInternalServerError, OAuthError{Error: "server_error"})
+		}
+		response := OAuthTokenResponse{AccessToken: token, TokenType: "Bearer"}
+		if claims, err := a.AuthProvider().ValidateToken(ctx, token); err == nil && claims.ExpiresAt != nil {
+			response.ExpiresIn = int64(time.Until(claims.ExpiresAt.Time).Round(time.Second).Seconds())
+		}
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// OAuthIntrospect tells a client whether a token of its tenant is active and whom it was issued to, the tokens of
+// other tenants are not active for it. The client authenticates with its credentials, as for a token, or with a
+// token of its own, which is not part of the metadata as RFC 8414 has no name for it.
+func OAuthIntrospect(a context.ApplicationContext, issuer string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var callerTenant uuid.UUID
+		if bearer, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer "); ok {
+			claims, err := a.AuthProvider().ValidateToken(ctx, bearer)
+			if err == nil {
+				callerTenant, err = claims.TenantId()
+			}
+			if err != nil {
+				return invalidClient(c, false)
+			}
+		} else {
+			credentials, basic, err := clientCredentials(c)
+			if err != nil {
+				return invalidClient(c, basic)
+			}
+			callerTenant, err = a.AuthProvider().VerifyClient(ctx, credentials.ClientId, credentials.ClientSecret)
+			if errors.Is(err, auth.ErrInvalidCredentials) {
+				return invalidClient(c, basic)
+			} else if err != nil {
+				logging.FromContext(ctx).ErrorContext(ctx, "Unexpected error authenticating a client", "error", err)
+				return c.JSON(http.StatusInternalServerError, OAuthError{Error: "server_error"})
+			}
+			callerTenant = cmp.Or(callerTenant, commons.DefaultTenant)
+		}
+		token := c.FormValue("token")
+		if token == "" {
+			return oauthError(c, http.StatusBadRequest, OAuthInvalidRequest, "token is required")
+		}
+		claims, err := a.AuthProvider().ValidateToken(ctx, token)
+		var tenant uuid.UUID
+		if err == nil {
+			tenant, err = claims.TenantId()
+		}
+		if err != nil || tenant != callerTenant {
+			// expired, forged and unknown tokens are all just not active, RFC 7662 section 2.2, as are the tokens of
+			// other tenants, which the caller is not told about
+			return c.JSON(http.StatusOK, OAuthIntrospection{})
+		}
+		introspection := OAuthIntrospection{
+			Active:    true,
+			TokenType: "Bearer",
+			Username:  claims.Username,
+			Subject:   cmp.Or(claims.Subject, claims.Username),
+			Issuer:    issuer,
+			Tenant:    tenant.String(),
+		}
+		if claims.IssuedAt != nil {
+			introspection.IssuedAt = claims.IssuedAt.Unix()
+		}
+		if claims.ExpiresAt != nil {
+			introspection.ExpiresAt = claims.ExpiresAt.Unix()
+		}
+		return c.JSON(http.StatusOK, introspection)
+	}
+}
+
+// OAuthMetadata describes the endpoints for the OAuth libraries that discover them, and the keys of the tokens when
+// they are signed with keys rather than the secret.
+func OAuthMetadata(a context.ApplicationContext, issuer string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var jwksURI string
+		if len(a.AuthProvider().JWKS().Keys) > 0 {
+			jwksURI = issuer + "/.well-known/jwks.json"
+		}
+		return c.JSON(http.StatusOK, OAuthServerMetadata{
+			Issuer:                            issuer,
+			TokenEndpoint:                     issuer + "/oauth/token",
+			IntrospectionEndpoint:             issuer + "/oauth/introspect",
+			JWKSURI:                           jwksURI,
+			GrantTypesSupported:               []string{"client_credentials"},
+			ResponseTypesSupported:            []string{},
+			TokenEndpointAuthMethodsSupported: clientAuthMethods,
+			IntrospectionEndpointAuthMethodsSupported: clientAuthMethods,
+		})
+	}
+}
+
+// errNoClient is returned by clientCredentials when the client did not authenticate.
+var errNoClient = errors.New("client_id is required")
+
+// clientCredentials reads the credentials of the client from HTTP Basic, in which they are form encoded first (RFC
+// 6749 section 2.3.1), or from the form. basic tells which, a client may only use one of them.
+func clientCredentials(c echo.Context) (credentials auth.Credentials, basic bool, err error) {
+	id, secret, basic := c.Request().BasicAuth()
+	if basic {
+		if c.FormValue("client_id") != "" || c.FormValue("client_secret") != "" {
+			return credentials, basic, errors.New("the client must authenticate with one method only")
+		}
+		if credentials.ClientId, err = url.QueryUnescape(id); err != nil {
+			return credentials, basic, errors.New("malformed client_id")
+		}
+		if credentials.ClientSecret, err = url.QueryUnescape(secret); err != nil {
+			return credentials, basic, errors.New("malformed client_secret")
+		}
+	} else {
+		credentials.ClientId, credentials.ClientSecret = c.FormValue("client_id"), c.FormValue("client_secret")
+	}
+	if credentials.ClientId == "" {
+		return credentials, basic, errNoClient
+	}
+	return credentials, basic, nil
+}
+
+// invalidClient answers 401 with the challenge of the scheme the client used, RFC 6749 section 5.2.
+func invalidClient(c echo.Context, basic bool) error {
+	scheme := "Bearer"
+	if basic {
+		scheme = "Basic"
+	}
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, scheme+` realm="inventory-service"`)
+	return oauthError(c, http.StatusUnauthorized, OAuthInvalidClient, "client authentication failed")
+}
+
+func oauthError(c echo.Context, status int, code string, description string) error {
+	return c.JSON(status, OAuthError{Error: code, ErrorDescription: description})
+}
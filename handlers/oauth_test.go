@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	gocontext "context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestOAuthRoutes(t *testing.T) {
+	mockApp := context.MockApplicationContext(nil, nil, nil)
+	e := echo.New()
+	t.Run("successful route registration", func(t *testing.T) {
+		OAuthRoutes(e.Group(""), mockApp, "https://inventory.example.com")
+		routes := e.Routes()
+		assert.Equal(t, 3, len(routes))
+	})
+}
+
+func oauthServer(issuer string) *echo.Echo {
+	e := echo.New()
+	OAuthRoutes(e.Group(""), context.MockApplicationContext(nil, nil, nil), issuer)
+	return e
+}
+
+// serveForm posts form to path, with HTTP Basic when id is not empty.
+func serveForm(e *echo.Echo, path string, form url.Values, id string, secret string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	if id != "" {
+		req.SetBasicAuth(url.QueryEscape(id), url.QueryEscape(secret))
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestOAuthToken(t *testing.T) {
+	e := oauthServer("https://inventory.example.com")
+	tests := []struct {
+		name           string
+		form           url.Values
+		id             string
+		secret         string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "basic auth",
+			form:           url.Values{"grant_type": {"client_credentials"}, "scope": {"items:read"}},
+			id:             "foo",
+			secret:         "bar",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "form credentials",
+			form:           url.Values{"grant_type": {"client_credentials"}, "client_id": {"foo"}, "client_secret": {"bar"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "both methods",
+			form:           url.Values{"grant_type": {"client_credentials"}, "client_id": {"foo"}},
+			id:             "foo",
+			secret:         "bar",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  OAuthInvalidRequest,
+		},
+		{
+			name:           "no credentials",
+			form:           url.Values{"grant_type": {"client_credentials"}},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  OAuthInvalidClient,
+		},
+		{
+			name:           "no grant type",
+			form:           url.Values{},
+			id:             "foo",
+			secret:         "bar",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  OAuthInvalidRequest,
+		},
+		{
+			name:           "unsupported grant type",
+			form:           url.Values{"grant_type": {"password"}},
+			id:             "foo",
+			secret:         "bar",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  OAuthUnsupportedGrantType,
+		},
+		{
+			name:           "invalid client",
+			form:           url.Values{"grant_type": {"client_credentials"}},
+			id:             "foo",
+			secret:         "wrong",
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  OAuthInvalidClient,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serveForm(e, "/oauth/token", tc.form, tc.id, tc.secret)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl))
+			if tc.expectedError != "" {
+				var response OAuthError
+				if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+					t.Fatalf("unexpected response %s", rec.Body.String())
+				}
+				assert.Equal(t, tc.expectedError, response.Error)
+				return
+			}
+			var response OAuthTokenResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("unexpected response %s", rec.Body.String())
+			}
+			assert.NotEmpty(t, response.AccessToken)
+			assert.Equal(t, "Bearer", response.TokenType)
+			assert.InDelta(t, 12*60*60, response.ExpiresIn, 1)
+		})
+	}
+	t.Run("challenge of basic auth", func(t *testing.T) {
+		rec := serveForm(e, "/oauth/token", url.Values{"grant_type": {"client_credentials"}}, "foo", "wrong")
+		assert.Equal(t, `Basic realm="inventory-service"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+	})
+}
+
+func TestOAuthIntrospect(t *testing.T) {
+	e := oauthServer("https://inventory.example.com/")
+	token, err := context.MockApplicationContext(nil, nil, nil).AuthProvider().Authenticate(gocontext.Background(), "foo", "bar")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when issuing a token", err)
+	}
+	introspect := func(t *testing.T, rec *httptest.ResponseRecorder) OAuthIntrospection {
+		var response OAuthIntrospection
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("unexpected response %s", rec.Body.String())
+		}
+		return response
+	}
+
+	t.Run("active token", func(t *testing.T) {
+		rec := serveForm(e, "/oauth/introspect", url.Values{"token": {token}}, "foo", "bar")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		response := introspect(t, rec)
+		assert.True(t, response.Active)
+		assert.Equal(t, "foo", response.Username)
+		assert.Equal(t, "foo", response.Subject)
+		assert.Equal(t, "https://inventory.example.com", response.Issuer)
+		assert.Equal(t, commons.DefaultTenant.String(), response.Tenant)
+		assert.NotZero(t, response.ExpiresAt)
+	})
+	t.Run("with a token of the caller", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader(url.Values{"token": {token}}.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, introspect(t, rec).Active)
+	})
+	t.Run("token of another tenant", func(t *testing.T) {
+		other, err := context.MockApplicationContext(nil, nil, nil).AuthProvider().IssueToken("billing", uuid.New(), 0)
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when issuing a token", err)
+		}
+		rec := serveForm(e, "/oauth/introspect", url.Values{"token": {other}}, "foo", "bar")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `{"active":false}`, strings.TrimSpace(rec.Body.String()))
+
+		// nor can the other tenant introspect the tokens of the default tenant
+		req := httptest.NewRequest(http.MethodPost, "/oauth/introspect", strings.NewReader(url.Values{"token": {token}}.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+other)
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.False(t, introspect(t, rec).Active)
+	})
+	t.Run("inactive token", func(t *testing.T) {
+		rec := serveForm(e, "/oauth/introspect", url.Values{"token": {"forged"}}, "foo", "bar")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `{"active":false}`, strings.TrimSpace(rec.Body.String()))
+	})
+	t.Run("no token", func(t *testing.T) {
+		rec := serveForm(e, "/oauth/introspect", url.Values{}, "foo", "bar")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("unauthenticated caller", func(t *testing.T) {
+		rec := serveForm(e, "/oauth/introspect", url.Values{"token": {token}}, "", "")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		rec = serveForm(e, "/oauth/introspect", url.Values{"token": {token}}, "foo", "wrong")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, `Basic realm="inventory-service"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+	})
+}
+
+func TestOAuthMetadata(t *testing.T) {
+	get := func(t *testing.T, e *echo.Echo) OAuthServerMetadata {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/oauth-authorization-server", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var metadata OAuthServerMetadata
+		if err := json.Unmarshal(rec.Body.Bytes(), &metadata); err != nil {
+			t.Fatalf("unexpected response %s", rec.Body.String())
+		}
+		return metadata
+	}
+
+	metadata := get(t, oauthServer("https://inventory.example.com"))
+	assert.Equal(t, "https://inventory.example.com", metadata.Issuer)
+	assert.Equal(t, "https://inventory.example.com/oauth/token", metadata.TokenEndpoint)
+	assert.Equal(t, "https://inventory.example.com/oauth/introspect", metadata.IntrospectionEndpoint)
+	assert.Equal(t, []string{"client_credentials"}, metadata.GrantTypesSupported)
+	assert.Equal(t, []string{"client_secret_basic", "client_secret_post"}, metadata.TokenEndpointAuthMethodsSupported)
+	assert.Empty(t, metadata.JWKSURI)
+
+	// the keys of the tokens are published when they are signed with keys
+	a, _ := keyedContext(t)
+	e := echo.New()
+	OAuthRoutes(e.Group(""), a, "https://inventory.example.com")
+	metadata = get(t, e)
+	assert.Equal(t, "https://inventory.example.com/.well-known/jwks.json", metadata.JWKSURI)
+}
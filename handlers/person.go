@@ -31,12 +31,12 @@ func paginationFromRequest(c echo.Context) *commons.Pagination {
 	}
 }
 
-func PersonRoutes(p *echo.Group, appContext context.ApplicationContext) {
-	p.GET("/persons", GetAllPersons(appContext))
-	p.GET("/persons/:id", GetPersonById(appContext))
-	p.POST("/persons", CreatePerson(appContext))
-	p.PUT("/persons/:id", UpdatePerson(appContext))
-	p.DELETE("/persons/:id", DeletePerson(appContext))
+func PersonRoutes(p *echo.Group, appContext context.ApplicationContext, m ...echo.MiddlewareFunc) {
+	p.GET("/persons", GetAllPersons(appContext), m...)
+	p.GET("/persons/:id", GetPersonById(appContext), m...)
+	p.POST("/persons", CreatePerson(appContext), m...)
+	p.PUT("/persons/:id", UpdatePerson(appContext), m...)
+	p.DELETE("/persons/:id", DeletePerson(appContext), m...)
 }
 
 // GetAllPersons
@@ -56,7 +56,7 @@ func GetAllPersons(appContext context.ApplicationContext) func(c echo.Context) e
 	return func(c echo.Context) error {
 		pagination := paginationFromRequest(c)
 		personService := appContext.PersonService()
-		persons, err := personService.GetAll(pagination)
+		persons, err := personService.GetAll(c.Request().Context(), pagination)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, err)
 		}
@@ -85,7 +85,7 @@ func GetPersonById(appContext context.ApplicationContext) func(c echo.Context) e
 			return c.JSON(http.StatusBadRequest, err)
 		}
 		personService := appContext.PersonService()
-		p, err := personService.GetById(uuid)
+		p, err := personService.GetById(c.Request().Context(), uuid)
 		err2 := commons.HandleServiceError(c, err)
 		if err2 != nil {
 			return err2
@@ -115,7 +115,7 @@ func CreatePerson(appContext context.ApplicationContext) func(c echo.Context) er
 			return c.JSON(http.StatusBadRequest, err)
 		}
 		personService := appContext.PersonService()
-		results, err := personService.Create(createPersonRequest)
+		results, err := personService.Create(c.Request().Context(), createPersonRequest)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, err)
 		}
@@ -145,7 +145,7 @@ func UpdatePerson(appContext context.ApplicationContext) func(c echo.Context) er
 			return c.JSON(http.StatusBadRequest, err)
 		}
 		personService := appContext.PersonService()
-		results, err := personService.Update(updatePersonRequest)
+		results, err := personService.Update(c.Request().Context(), updatePersonRequest)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, err)
 		}
@@ -174,7 +174,7 @@ func DeletePerson(appContext context.ApplicationContext) func(c echo.Context) er
 			return c.JSON(http.StatusBadRequest, err)
 		}
 		personService := appContext.PersonService()
-		results, err := personService.DeleteByUuid(uuid)
+		results, err := personService.DeleteByUuid(c.Request().Context(), uuid)
 		err2 := commons.HandleServiceError(c, err)
 		if err2 != nil {
 			return err2
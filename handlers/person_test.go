@@ -77,9 +77,9 @@ func TestGetAll(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.expectedCode == http.StatusInternalServerError {
-				mockPersonService.EXPECT().GetAll(pagination).Return(nil, errors.New("Internal Error"))
+				mockPersonService.EXPECT().GetAll(gomock.Any(), pagination).Return(nil, errors.New("Internal Error"))
 			} else {
-				mockPersonService.EXPECT().GetAll(pagination).Return(expectedPersons, nil)
+				mockPersonService.EXPECT().GetAll(gomock.Any(), pagination).Return(expectedPersons, nil)
 			}
 			req := httptest.NewRequest(http.MethodGet, "/?last_id=0&page_size=10", nil)
 			rec := httptest.NewRecorder()
@@ -128,9 +128,9 @@ func TestGetById(t *testing.T) {
 		expectedPerson := personFixture()
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.expectedCode == http.StatusInternalServerError {
-				mockPersonService.EXPECT().GetById(gomock.Any()).Return(nil, errors.New("Internal Error"))
+				mockPersonService.EXPECT().GetById(gomock.Any(), gomock.Any()).Return(nil, errors.New("Internal Error"))
 			} else if tt.expectedCode == http.StatusOK {
-				mockPersonService.EXPECT().GetById(gomock.Any()).Return(&expectedPerson, nil)
+				mockPersonService.EXPECT().GetById(gomock.Any(), gomock.Any()).Return(&expectedPerson, nil)
 			}
 			uri := fmt.Sprintf("/%s", tt.uuid)
 			req := httptest.NewRequest(http.MethodGet, uri, nil)
@@ -186,9 +186,9 @@ func TestCreate(t *testing.T) {
 		expectedPerson := personFixture()
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.expectedCode == http.StatusInternalServerError {
-				mockPersonService.EXPECT().Create(gomock.Any()).Return(nil, errors.New("Internal Error"))
+				mockPersonService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("Internal Error"))
 			} else if tt.expectedCode == http.StatusCreated {
-				mockPersonService.EXPECT().Create(gomock.Any()).Return(&expectedPerson, nil)
+				mockPersonService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&expectedPerson, nil)
 			}
 			var requestBody []byte
 			if tt.expectedCode == http.StatusBadRequest {
@@ -251,9 +251,9 @@ func TestUpdate(t *testing.T) {
 		expectedPerson := personFixture()
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.expectedCode == http.StatusInternalServerError {
-				mockPersonService.EXPECT().Update(gomock.Any()).Return(nil, errors.New("Internal Error"))
+				mockPersonService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, errors.New("Internal Error"))
 			} else if tt.expectedCode == http.StatusOK {
-				mockPersonService.EXPECT().Update(gomock.Any()).Return(&expectedPerson, nil)
+				mockPersonService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&expectedPerson, nil)
 			}
 			var requestBody []byte
 			if tt.expectedCode == http.StatusBadRequest {
@@ -312,9 +312,9 @@ func TestDelete(t *testing.T) {
 		applicationContext := context.MockApplicationContext(mockPersonService, mockItemService, mockInvoiceService)
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.expectedCode == http.StatusInternalServerError {
-				mockPersonService.EXPECT().DeleteByUuid(gomock.Any()).Return(nil, errors.New("Internal Error"))
+				mockPersonService.EXPECT().DeleteByUuid(gomock.Any(), gomock.Any()).Return(nil, errors.New("Internal Error"))
 			} else if tt.expectedCode == http.StatusOK {
-				mockPersonService.EXPECT().DeleteByUuid(gomock.Any()).Return(&commons.DeleteResult{}, nil)
+				mockPersonService.EXPECT().DeleteByUuid(gomock.Any(), gomock.Any()).Return(&commons.DeleteResult{}, nil)
 			}
 			uri := fmt.Sprintf("/%s", tt.uuid)
 			req := httptest.NewRequest(http.MethodDelete, uri, nil)
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/promotion"
+	"net/http"
+)
+
+func PromotionRoutes(g *echo.Group, a context.ApplicationContext) {
+	g.GET("/promotions", GetAllPromotions(a))
+	g.GET("/promotions/:id", GetPromotion(a))
+	g.POST("/promotions", CreatePromotion(a))
+	g.PUT("/promotions/:id", UpdatePromotion(a))
+	g.DELETE("/promotions/:id", DeletePromotion(a))
+}
+
+// GetAllPromotions
+//
+//		@Summary		List Promotions
+//		@Description	List all Promotion codes
+//		@Id				all_promotions
+//		@Tags			promotion
+//		@Produce		json
+//		@Param			last_id		query		int	false	"last seq id"
+//	 	@Param			page_size 	query		int false 	"number of promotions per page"
+//		@Success		200	{array}		promotion.Promotion		"OK"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/promotions [get]
+func GetAllPromotions(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		pagination := paginationFromRequest(c)
+		results, err := a.PromotionService().GetPromotions(c.Request().Context(), pagination)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err)
+		}
+		return c.JSON(http.StatusOK, results)
+	}
+}
+
+// GetPromotion
+//
+//		@Summary		Get Promotion
+//		@Description	Get a specific Promotion
+//		@Id				get_promotion
+//		@Tags			promotion
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the promotion requested"
+//		@Success		200	{object}	promotion.Promotion		"OK"
+//		@Failure		400	{string}	string 			"Bad Request"
+//		@Failure		404 {string} 	string			"Not Found"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/promotions/{id} [get]
+func GetPromotion(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.PromotionService().GetPromotion(c.Request().Context(), id)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// CreatePromotion
+//
+//		@Summary		Create Promotion
+//		@Description	Create a Promotion code with a discount, validity window, usage limit and minimum order amount
+//		@ID				create_promotion
+//		@Tags			promotion
+//		@Accept			json
+//		@Produce		json
+//	    @Param 			request body 		promotion.CreatePromotionRequest	true 	"Create Promotion Request"
+//		@Success		201		{object}	promotion.Promotion				"Created"
+//		@Failure		400		{string}	string					"Bad Request"
+//		@Failure		401		{string}	string					"Unauthorized (invalid credentials)"
+//		@Failure		500		{object}	error					"Internal Server Error"
+//		@Router			/promotions [post]
+func CreatePromotion(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request promotion.CreatePromotionRequest
+		err := c.Bind(&request)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.PromotionService().CreatePromotion(c.Request().Context(), request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusCreated, result)
+	}
+}
+
+// UpdatePromotion
+//
+//		@Summary		Update Promotion
+//		@Description	Update a Promotion
+//		@ID				update_promotion
+//		@Tags			promotion
+//		@Accept			json
+//		@Produce		json
+//	    @Param 			request body 		promotion.UpdatePromotionRequest	true 	"Update Promotion Request"
+//		@Param			id	path			uuid.Uuid					true	"Promotion Id"
+//		@Success		200		{object}	promotion.Promotion				"OK"
+//		@Failure		400		{string}	string					"Bad Request"
+//		@Failure		401		{string}	string					"Unauthorized (invalid credentials)"
+//		@Failure		404		{string}	string					"Not Found"
+//		@Failure		500		{object}	error					"Internal Server Error"
+//		@Router			/promotions/{id} [put]
+func UpdatePromotion(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request promotion.UpdatePromotionRequest
+		err := c.Bind(&request)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		if id != request.Id {
+			return c.JSON(http.StatusBadRequest, "id in path does not match id in body")
+		}
+		result, err := a.PromotionService().UpdatePromotion(c.Request().Context(), request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// DeletePromotion
+//
+//		@Summary		Delete Promotion
+//		@Description	Remove a specific Promotion
+//		@Id				delete_promotion
+//		@Tags			promotion
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the promotion to be deleted"
+//		@Success		200	{object}	commons.DeleteResult	"OK"
+//		@Failure		400	{string}	string 					"Bad Request"
+//		@Failure		500	{string}	string 					"Internal Server Error"
+//		@Router			/promotions/{id} [delete]
+func DeletePromotion(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.PromotionService().DeletePromotion(c.Request().Context(), id)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/promotion"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPromotionRoutes(t *testing.T) {
+	mockApp := context.MockApplicationContext(nil, nil, nil)
+	e := echo.New()
+	t.Run("successful route registration", func(t *testing.T) {
+		PromotionRoutes(e.Group("/test"), mockApp)
+		routes := e.Routes()
+		assert.Equal(t, 5, len(routes))
+	})
+}
+
+func TestGetAllPromotions(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := promotion.NewMockPromotionService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithPromotionService(mockService)
+	expected := []promotion.Promotion{{Seq: 1, Code: "SPRING", Discount: promotion.Discount{Type: promotion.Percentage, Value: "10"}, Currency: "USD"}}
+	tests := []struct {
+		name          string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful retrieval",
+			mockFunc: func() {
+				mockService.EXPECT().GetPromotions(gomock.Any(), nil).Return(expected, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "service error",
+			mockFunc: func() {
+				mockService.EXPECT().GetPromotions(gomock.Any(), nil).Return(nil, errors.New("BOOM"))
+			},
+			expectErrCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if assert.NoError(t, GetAllPromotions(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestGetPromotion(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := promotion.NewMockPromotionService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithPromotionService(mockService)
+	id := uuid.New()
+	tests := []struct {
+		name          string
+		id            string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful retrieval",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().GetPromotion(gomock.Any(), id).Return(promotion.Promotion{Id: id}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "not found",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().GetPromotion(gomock.Any(), id).Return(promotion.Promotion{}, sql.ErrNoRows)
+			},
+			expectErrCode: http.StatusNotFound,
+		},
+		{
+			name:          "bad id",
+			id:            "not-a-uuid",
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if assert.NoError(t, GetPromotion(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestCreatePromotion(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := promotion.NewMockPromotionService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithPromotionService(mockService)
+	maxUses := int64(100)
+	request := promotion.CreatePromotionRequest{
+		Code:      "SPRING",
+		Discount:  promotion.Discount{Type: promotion.FixedAmount, Value: "5.00"},
+		Currency:  "USD",
+		ValidFrom: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+		MaxUses:   &maxUses,
+		CreatedBy: "unit test",
+	}
+	tests := []struct {
+		name          string
+		body          []byte
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful creation",
+			body: mustJson(request),
+			mockFunc: func() {
+				mockService.EXPECT().CreatePromotion(gomock.Any(), request).Return(promotion.Promotion{Seq: 1, Code: "SPRING"}, nil)
+			},
+			expectErrCode: http.StatusCreated,
+		},
+		{
+			name: "invalid discount",
+			body: mustJson(request),
+			mockFunc: func() {
+				mockService.EXPECT().CreatePromotion(gomock.Any(), request).Return(promotion.Promotion{}, promotion.ErrInvalidDiscount)
+			},
+			expectErrCode: http.StatusBadRequest,
+		},
+		{
+			name:          "bad request",
+			body:          []byte("bad request"),
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if assert.NoError(t, CreatePromotion(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestUpdatePromotion(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := promotion.NewMockPromotionService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithPromotionService(mockService)
+	id := uuid.New()
+	request := promotion.UpdatePromotionRequest{Id: id, Description: "spring sale", ValidFrom: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		name          string
+		id            string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful update",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().UpdatePromotion(gomock.Any(), request).Return(promotion.Promotion{Id: id, Description: "spring sale"}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name:          "id mismatch",
+			id:            uuid.NewString(),
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(mustJson(request)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if assert.NoError(t, UpdatePromotion(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestDeletePromotion(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := promotion.NewMockPromotionService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithPromotionService(mockService)
+	id := uuid.New()
+	mockService.EXPECT().DeletePromotion(gomock.Any(), id).Return(commons.DeleteResult{Id: id, Deleted: true}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	if assert.NoError(t, DeletePromotion(mockApp)(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/recurring"
+	"net/http"
+)
+
+func RecurringInvoiceRoutes(g *echo.Group, a context.ApplicationContext) {
+	g.GET("/recurring-invoices", GetAllRecurringInvoices(a))
+	g.GET("/recurring-invoices/:id", GetRecurringInvoice(a))
+	g.POST("/recurring-invoices", CreateRecurringInvoice(a))
+	g.PUT("/recurring-invoices/:id", UpdateRecurringInvoice(a))
+	g.DELETE("/recurring-invoices/:id", DeleteRecurringInvoice(a))
+	g.GET("/recurring-invoices/:id/runs", GetRecurringInvoiceRuns(a))
+}
+
+// GetAllRecurringInvoices
+//
+//		@Summary		List Recurring Invoices
+//		@Description	List all Recurring Invoices
+//		@Id				all_recurring_invoices
+//		@Tags			recurring-invoice
+//		@Produce		json
+//		@Param			last_id		query		int	false	"last seq id"
+//	 	@Param			page_size 	query		int false 	"number of recurring invoices per page"
+//		@Success		200	{array}		recurring.RecurringInvoice		"OK"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/recurring-invoices [get]
+func GetAllRecurringInvoices(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		pagination := paginationFromRequest(c)
+		results, err := a.RecurringInvoiceService().GetRecurringInvoices(c.Request().Context(), pagination)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err)
+		}
+		return c.JSON(http.StatusOK, results)
+	}
+}
+
+// GetRecurringInvoice
+//
+//		@Summary		Get Recurring Invoice
+//		@Description	Get a specific Recurring Invoice
+//		@Id				get_recurring_invoice
+//		@Tags			recurring-invoice
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the recurring invoice requested"
+//		@Success		200	{object}	recurring.RecurringInvoice		"OK"
+//		@Failure		400	{string}	string 			"Bad Request"
+//		@Failure		404 {string} 	string			"Not Found"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/recurring-invoices/{id} [get]
+func GetRecurringInvoice(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.RecurringInvoiceService().GetRecurringInvoice(c.Request().Context(), id)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// CreateRecurringInvoice
+//
+//		@Summary		Create Recurring Invoice
+//		@Description	Create a Recurring Invoice template with items, quantities, a cadence (monthly, quarterly or yearly) and a start and optional end date
+//		@ID				create_recurring_invoice
+//		@Tags			recurring-invoice
+//		@Accept			json
+//		@Produce		json
+//	    @Param 			request body 		recurring.CreateRecurringInvoiceRequest	true 	"Create Recurring Invoice Request"
+//		@Success		201		{object}	recurring.RecurringInvoice				"Created"
+//		@Failure		400		{string}	string					"Bad Request"
+//		@Failure		401		{string}	string					"Unauthorized (invalid credentials)"
+//		@Failure		500		{object}	error					"Internal Server Error"
+//		@Router			/recurring-invoices [post]
+func CreateRecurringInvoice(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request recurring.CreateRecurringInvoiceRequest
+		err := c.Bind(&request)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.RecurringInvoiceService().CreateRecurringInvoice(c.Request().Context(), request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusCreated, result)
+	}
+}
+
+// UpdateRecurringInvoice
+//
+//		@Summary		Update Recurring Invoice
+//		@Description	Update a Recurring Invoice
+//		@ID				update_recurring_invoice
+//		@Tags			recurring-invoice
+//		@Accept			json
+//		@Produce		json
+//	    @Param 			request body 		recurring.UpdateRecurringInvoiceRequest	true 	"Update Recurring Invoice Request"
+//		@Param			id	path			uuid.Uuid					true	"Recurring Invoice Id"
+//		@Success		200		{object}	recurring.RecurringInvoice				"OK"
+//		@Failure		400		{string}	string					"Bad Request"
+//		@Failure		401		{string}	string					"Unauthorized (invalid credentials)"
+//		@Failure		404		{string}	string					"Not Found"
+//		@Failure		500		{object}	error					"Internal Server Error"
+//		@Router			/recurring-invoices/{id} [put]
+func UpdateRecurringInvoice(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request recurring.UpdateRecurringInvoiceRequest
+		err := c.Bind(&request)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		if id != request.Id {
+			return c.JSON(http.StatusBadRequest, "id in path does not match id in body")
+		}
+		result, err := a.RecurringInvoiceService().UpdateRecurringInvoice(c.Request().Context(), request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// DeleteRecurringInvoice
+//
+//		@Summary		Delete Recurring Invoice
+//		@Description	Remove a specific Recurring Invoice
+//		@Id				delete_recurring_invoice
+//		@Tags			recurring-invoice
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the recurring invoice to be deleted"
+//		@Success		200	{object}	commons.DeleteResult	"OK"
+//		@Failure		400	{string}	string 					"Bad Request"
+//		@Failure		500	{string}	string 					"Internal Server Error"
+//		@Router			/recurring-invoices/{id} [delete]
+func DeleteRecurringInvoice(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.RecurringInvoiceService().DeleteRecurringInvoice(c.Request().Context(), id)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetRecurringInvoiceRuns
+//
+//		@Summary		List Recurring Invoice Runs
+//		@Description	List the scheduler runs of a Recurring Invoice with the invoice created on each due date
+//		@Id				get_recurring_invoice_runs
+//		@Tags			recurring-invoice
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the recurring invoice"
+//		@Success		200	{array}		recurring.Run	"OK"
+//		@Failure		400	{string}	string 			"Bad Request"
+//		@Failure		404 {string} 	string			"Not Found"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/recurring-invoices/{id}/runs [get]
+func GetRecurringInvoiceRuns(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		results, err := a.RecurringInvoiceService().GetRuns(c.Request().Context(), id)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, results)
+	}
+}
@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/recurring"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRecurringInvoiceRoutes(t *testing.T) {
+	mockApp := context.MockApplicationContext(nil, nil, nil)
+	e := echo.New()
+	t.Run("successful route registration", func(t *testing.T) {
+		RecurringInvoiceRoutes(e.Group("/test"), mockApp)
+		routes := e.Routes()
+		assert.Equal(t, 6, len(routes))
+	})
+}
+
+func TestGetAllRecurringInvoices(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := recurring.NewMockRecurringInvoiceService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithRecurringInvoiceService(mockService)
+	expected := []recurring.RecurringInvoice{{Seq: 1, Id: uuid.New(), Cadence: recurring.Monthly, Currency: "USD"}}
+	tests := []struct {
+		name          string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful retrieval",
+			mockFunc: func() {
+				mockService.EXPECT().GetRecurringInvoices(gomock.Any(), nil).Return(expected, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "service error",
+			mockFunc: func() {
+				mockService.EXPECT().GetRecurringInvoices(gomock.Any(), nil).Return(nil, errors.New("BOOM"))
+			},
+			expectErrCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if assert.NoError(t, GetAllRecurringInvoices(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestGetRecurringInvoice(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := recurring.NewMockRecurringInvoiceService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithRecurringInvoiceService(mockService)
+	id := uuid.New()
+	tests := []struct {
+		name          string
+		id            string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful retrieval",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().GetRecurringInvoice(gomock.Any(), id).Return(recurring.RecurringInvoice{Id: id}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "not found",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().GetRecurringInvoice(gomock.Any(), id).Return(recurring.RecurringInvoice{}, sql.ErrNoRows)
+			},
+			expectErrCode: http.StatusNotFound,
+		},
+		{
+			name:          "bad id",
+			id:            "not-a-uuid",
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if assert.NoError(t, GetRecurringInvoice(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestCreateRecurringInvoice(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := recurring.NewMockRecurringInvoiceService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithRecurringInvoiceService(mockService)
+	request := recurring.CreateRecurringInvoiceRequest{
+		UserId:    uuid.New(),
+		Currency:  "USD",
+		Items:     []recurring.RecurringInvoiceItem{{ItemId: uuid.New(), Quantity: 2}},
+		Cadence:   recurring.Monthly,
+		StartDate: commons.NewDate(2026, time.January, 31),
+		CreatedBy: "unit test",
+	}
+	tests := []struct {
+		name          string
+		body          []byte
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful creation",
+			body: mustJson(request),
+			mockFunc: func() {
+				mockService.EXPECT().CreateRecurringInvoice(gomock.Any(), request).Return(recurring.RecurringInvoice{Seq: 1, Cadence: recurring.Monthly}, nil)
+			},
+			expectErrCode: http.StatusCreated,
+		},
+		{
+			name: "invalid cadence",
+			body: mustJson(request),
+			mockFunc: func() {
+				mockService.EXPECT().CreateRecurringInvoice(gomock.Any(), request).Return(recurring.RecurringInvoice{}, recurring.ErrInvalidCadence)
+			},
+			expectErrCode: http.StatusBadRequest,
+		},
+		{
+			name:          "bad request",
+			body:          []byte("bad request"),
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if assert.NoError(t, CreateRecurringInvoice(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestUpdateRecurringInvoice(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := recurring.NewMockRecurringInvoiceService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithRecurringInvoiceService(mockService)
+	id := uuid.New()
+	endDate := commons.NewDate(2026, time.December, 31)
+	request := recurring.UpdateRecurringInvoiceRequest{Id: id, EndDate: &endDate, LastChangedBy: "unit test"}
+	tests := []struct {
+		name          string
+		id            string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful update",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().UpdateRecurringInvoice(gomock.Any(), request).Return(recurring.RecurringInvoice{Id: id, EndDate: &endDate}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name:          "id mismatch",
+			id:            uuid.NewString(),
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(mustJson(request)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if assert.NoError(t, UpdateRecurringInvoice(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteRecurringInvoice(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := recurring.NewMockRecurringInvoiceService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithRecurringInvoiceService(mockService)
+	id := uuid.New()
+	mockService.EXPECT().DeleteRecurringInvoice(gomock.Any(), id).Return(commons.DeleteResult{Id: id, Deleted: true}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	if assert.NoError(t, DeleteRecurringInvoice(mockApp)(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestGetRecurringInvoiceRuns(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := recurring.NewMockRecurringInvoiceService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithRecurringInvoiceService(mockService)
+	id := uuid.New()
+	tests := []struct {
+		name          string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful retrieval",
+			mockFunc: func() {
+				mockService.EXPECT().GetRuns(gomock.Any(), id).Return([]recurring.Run{{RecurringInvoiceId: id, Status: recurring.RunCompleted}}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "not found",
+			mockFunc: func() {
+				mockService.EXPECT().GetRuns(gomock.Any(), id).Return(nil, sql.ErrNoRows)
+			},
+			expectErrCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(id.String())
+			if assert.NoError(t, GetRecurringInvoiceRuns(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
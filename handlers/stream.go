@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/stream"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// heartbeatInterval keeps idle Server-Sent Events connections from being closed by proxies.
+const heartbeatInterval = 15 * time.Second
+
+// StreamRoutes takes the JWT middleware of the stream, it needs one that also reads the token from the access_token
+// query parameter as neither EventSource nor WebSocket clients in browsers can set headers, followed by TenantScope.
+func StreamRoutes(g *echo.Group, a context.ApplicationContext, m ...echo.MiddlewareFunc) {
+	g.GET("/stream", Stream(a), m...)
+}
+
+// Stream
+//
+//		@Summary		Stream Changes
+//		@Description	Push the changes to persons, items and invoices as Server-Sent Events, or as WebSocket messages when the connection is upgraded. Every event carries an id, reconnecting with it in the Last-Event-ID header (or the last_event_id query parameter) replays the changes missed in between. A reset event is sent first when they can no longer be replayed.
+//		@Id				stream
+//		@Tags			stream
+//		@Produce		text/event-stream
+//		@Produce		json
+//		@Param			topics			query		string	false	"comma separated topics: persons, items, invoices, all when empty"
+//		@Param			last_event_id	query		string	false	"id of the last event received"
+//	 	@Param			Last-Event-ID	header		string	false	"id of the last event received"
+//	 	@Param			access_token	query		string	false	"JWT, for clients that cannot set the Authorization header"
+//		@Success		200	{array}		stream.Event	"OK"
+//		@Failure		400	{string}	string 			"Bad Request"
+//		@Failure		401	{string}	string 			"Unauthorized"
+//		@Router			/stream [get]
+func Stream(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		topics, err := stream.ParseTopics(c.QueryParams()["topics"]...)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		lastEventId := c.Request().Header.Get("Last-Event-ID")
+		if lastEventId == "" {
+			lastEventId = c.QueryParam("last_event_id")
+		}
+		// only the changes of the tenant, none for a request without one
+		tenant, _ := commons.TenantFrom(c.Request().Context())
+		broker := a.StreamBroker()
+		subscription, replay, reset := broker.Subscribe(tenant, topics, lastEventId)
+		defer broker.Unsubscribe(subscription)
+		if reset {
+			replay = append([]stream.Event{broker.ResetEvent()}, replay...)
+		}
+		// the stream outlives the read and write timeouts of the server
+		controller := http.NewResponseController(c.Response())
+		_ = controller.SetReadDeadline(time.Time{})
+		_ = controller.SetWriteDeadline(time.Time{})
+		if strings.EqualFold(c.Request().Header.Get(echo.HeaderUpgrade), "websocket") {
+			return streamWebSocket(c, subscription, replay)
+		}
+		return streamEvents(c, subscription, replay)
+	}
+}
+
+func streamEvents(c echo.Context, subscription *stream.Subscription, replay []stream.Event) error {
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	for _, e := range replay {
+		if err := writeEvent(res, e); err != nil {
+			return nil
+		}
+	}
+	res.Flush()
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case e, ok := <-subscription.Events():
+			if !ok {
+				// fell behind, the client reconnects with its Last-Event-ID
+				return nil
+			}
+			if err := writeEvent(res, e); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+		case <-c.Request().Context().Done():
+			return nil
+		}
+		res.Flush()
+	}
+}
+
+func writeEvent(res *echo.Response, e stream.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	name := e.Topic
+	if e.Action == stream.ActionReset {
+		name = stream.ActionReset
+	}
+	if e.Id != "" {
+		if _, err = fmt.Fprintf(res, "id: %s\n", e.Id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(res, "event: %s\ndata: %s\n\n", name, data)
+	return err
+}
+
+func streamWebSocket(c echo.Context, subscription *stream.Subscription, replay []stream.Event) error {
+	server := websocket.Server{
+		// the origin is not checked, the connection is authenticated with the JWT
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+			closed := make(chan struct{})
+			go func() {
+				// nothing is expected from the client, reading only notices it went away
+				defer close(closed)
+				var message string
+				for websocket.Message.Receive(ws, &message) == nil {
+				}
+			}()
+			for _, e := range replay {
+				if websocket.JSON.Send(ws, e) != nil {
+					return
+				}
+			}
+			for {
+				select {
+				case e, ok := <-subscription.Events():
+					if !ok || websocket.JSON.Send(ws, e) != nil {
+						return
+					}
+				case <-closed:
+					return
+				case <-c.Request().Context().Done():
+					return
+				}
+			}
+		},
+	}
+	server.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
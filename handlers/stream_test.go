@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	ctx "context"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/websocket"
+	"inventory-service-go/auth"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"inventory-service-go/stream"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// publishedIds publishes the changes for the default tenant.
+func publishedIds(broker *stream.Broker, changes ...commons.Change) []string {
+	s, _, _ := broker.Subscribe(commons.DefaultTenant, stream.Topics, "")
+	defer broker.Unsubscribe(s)
+	var ids []string
+	for _, c := range changes {
+		c.Tenant = commons.DefaultTenant
+		broker.Publish(c)
+		ids = append(ids, (<-s.Events()).Id)
+	}
+	return ids
+}
+
+func TestStreamRoutes(t *testing.T) {
+	mockApp := context.MockApplicationContext(nil, nil, nil)
+	e := echo.New()
+	t.Run("successful route registration", func(t *testing.T) {
+		StreamRoutes(e.Group("/test"), mockApp)
+		routes := e.Routes()
+		assert.Equal(t, 1, len(routes))
+	})
+}
+
+func TestStream(t *testing.T) {
+	broker := stream.NewBroker(10)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithStreamBroker(broker)
+	itemId := uuid.New()
+	ids := publishedIds(broker,
+		commons.Change{Topic: item.ChangeTopic, Action: commons.ChangeCreated, Id: itemId, Data: item.Item{Id: itemId, Name: "Widget"}},
+		commons.Change{Topic: invoice.ChangeTopic, Action: commons.ChangeDeleted, Id: uuid.New()},
+		commons.Change{Topic: item.ChangeTopic, Action: commons.ChangeDeleted, Id: itemId},
+	)
+	tests := []struct {
+		name          string
+		target        string
+		lastEventId   string
+		tenant        uuid.UUID
+		expectErrCode int
+		expectBody    []string
+		notExpected   []string
+	}{
+		{
+			name:          "live only",
+			target:        "/",
+			expectErrCode: http.StatusOK,
+			notExpected:   []string{"id: "},
+		},
+		{
+			name:          "resumed from Last-Event-ID",
+			target:        "/",
+			lastEventId:   ids[0],
+			expectErrCode: http.StatusOK,
+			expectBody:    []string{"id: " + ids[1] + "\nevent: invoices\n", "id: " + ids[2] + "\nevent: items\n", `"action":"deleted"`},
+			notExpected:   []string{ids[0] + "\n", "event: reset"},
+		},
+		{
+			name:          "resumed by another tenant",
+			target:        "/",
+			lastEventId:   ids[0],
+			tenant:        uuid.New(),
+			expectErrCode: http.StatusOK,
+			notExpected:   []string{ids[1], ids[2]},
+		},
+		{
+			name:          "resumed with topic filter",
+			target:        "/?topics=items&last_event_id=" + ids[0],
+			expectErrCode: http.StatusOK,
+			expectBody:    []string{"id: " + ids[2] + "\n"},
+			notExpected:   []string{ids[1]},
+		},
+		{
+			name:          "reset after restart",
+			target:        "/",
+			lastEventId:   "0-1",
+			expectErrCode: http.StatusOK,
+			expectBody:    []string{"event: reset\ndata: {", `"action":"reset"`},
+		},
+		{
+			name:          "unknown topic",
+			target:        "/?topics=stock",
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			// the client is gone once the replay is written
+			tenant := tt.tenant
+			if tenant == uuid.Nil {
+				tenant = commons.DefaultTenant
+			}
+			cancelled, cancel := ctx.WithCancel(commons.WithTenant(ctx.Background(), tenant))
+			cancel()
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil).WithContext(cancelled)
+			if tt.lastEventId != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventId)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if assert.NoError(t, Stream(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+			if tt.expectErrCode == http.StatusOK {
+				assert.Equal(t, "text/event-stream", rec.Header().Get(echo.HeaderContentType))
+			}
+			for _, s := range tt.expectBody {
+				assert.Contains(t, rec.Body.String(), s)
+			}
+			for _, s := range tt.notExpected {
+				assert.NotContains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestStream_WebSocket(t *testing.T) {
+	broker := stream.NewBroker(10)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithStreamBroker(broker)
+	e := echo.New()
+	// stands in for the JWT middleware of the stream
+	StreamRoutes(e.Group(""), mockApp, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user", &auth.Claims{Username: "foo"})
+			return next(c)
+		}
+	}, TenantScope())
+	server := httptest.NewServer(e)
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/stream?topics=invoices&last_event_id="
+
+	invoiceId := uuid.New()
+	ids := publishedIds(broker,
+		commons.Change{Topic: invoice.ChangeTopic, Action: commons.ChangeCreated, Id: uuid.New()},
+		commons.Change{Topic: invoice.ChangeTopic, Action: commons.ChangeUpdated, Id: invoiceId},
+	)
+	ws, err := websocket.Dial(url+ids[0], "", server.URL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer ws.Close()
+
+	var replayed stream.Event
+	assert.NoError(t, websocket.JSON.Receive(ws, &replayed))
+	assert.Equal(t, ids[1], replayed.Id)
+	assert.Equal(t, invoiceId, replayed.EntityId)
+
+	// subscribed once the replay is received, changes of other topics and tenants are not sent
+	broker.Publish(commons.Change{Topic: item.ChangeTopic, Action: commons.ChangeCreated, Id: uuid.New(), Tenant: commons.DefaultTenant})
+	broker.Publish(commons.Change{Topic: invoice.ChangeTopic, Action: commons.ChangeCreated, Id: uuid.New(), Tenant: uuid.New()})
+	broker.Publish(commons.Change{Topic: invoice.ChangeTopic, Action: commons.ChangeDeleted, Id: invoiceId, Tenant: commons.DefaultTenant})
+	var live stream.Event
+	assert.NoError(t, websocket.JSON.Receive(ws, &live))
+	assert.Equal(t, invoice.ChangeTopic, live.Topic)
+	assert.Equal(t, commons.ChangeDeleted, live.Action)
+	assert.Equal(t, invoiceId, live.EntityId)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/tax"
+	"net/http"
+)
+
+// TaxRateRoutes are read by every user, the rates are shared by all tenants so only the admins change them.
+func TaxRateRoutes(g *echo.Group, a context.ApplicationContext, admins []string) {
+	admin := adminOnly(admins)
+	g.GET("/tax-rates", GetAllTaxRates(a))
+	g.GET("/tax-rates/:id", GetTaxRate(a))
+	g.POST("/tax-rates", CreateTaxRate(a), admin)
+	g.PUT("/tax-rates/:id", UpdateTaxRate(a), admin)
+	g.DELETE("/tax-rates/:id", DeleteTaxRate(a), admin)
+}
+
+// GetAllTaxRates
+//
+//		@Summary		List Tax Rates
+//		@Description	List all Tax Rates
+//		@Id				all_tax_rates
+//		@Tags			tax-rate
+//		@Produce		json
+//		@Param			last_id		query		int	false	"last seq id"
+//	 	@Param			page_size 	query		int false 	"number of rates per page"
+//		@Success		200	{array}		tax.TaxRate		"OK"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/tax-rates [get]
+func GetAllTaxRates(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		pagination := paginationFromRequest(c)
+		results, err := a.TaxRateService().GetTaxRates(pagination)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err)
+		}
+		return c.JSON(http.StatusOK, results)
+	}
+}
+
+// GetTaxRate
+//
+//		@Summary		Get Tax Rate
+//		@Description	Get a specific Tax Rate
+//		@Id				get_tax_rate
+//		@Tags			tax-rate
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the tax rate requested"
+//		@Success		200	{object}	tax.TaxRate		"OK"
+//		@Failure		400	{string}	string 			"Bad Request"
+//		@Failure		404 {string} 	string			"Not Found"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/tax-rates/{id} [get]
+func GetTaxRate(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.TaxRateService().GetTaxRate(id)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// CreateTaxRate
+//
+//		@Summary		Create Tax Rate
+//		@Description	Create the Tax Rate of a category of goods in a jurisdiction, effective from a given date
+//		@ID				create_tax_rate
+//		@Tags			tax-rate
+//		@Accept			json
+//		@Produce		json
+//	    @Param 			request body 		tax.CreateTaxRateRequest	true 	"Create Tax Rate Request"
+//		@Success		201		{object}	tax.TaxRate				"Created"
+//		@Failure		400		{string}	string					"Bad Request"
+//		@Failure		401		{string}	string					"Unauthorized (invalid credentials)"
+//		@Failure		403		{string}	string					"Forbidden (not an admin)"
+//		@Failure		500		{object}	error					"Internal Server Error"
+//		@Router			/tax-rates [post]
+func CreateTaxRate(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request tax.CreateTaxRateRequest
+		err := c.Bind(&request)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.TaxRateService().CreateTaxRate(request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusCreated, result)
+	}
+}
+
+// UpdateTaxRate
+//
+//		@Summary		Update Tax Rate
+//		@Description	Update a Tax Rate
+//		@ID				update_tax_rate
+//		@Tags			tax-rate
+//		@Accept			json
+//		@Produce		json
+//	    @Param 			request body 		tax.UpdateTaxRateRequest	true 	"Update Tax Rate Request"
+//		@Param			id	path			uuid.Uuid					true	"Tax Rate Id"
+//		@Success		200		{object}	tax.TaxRate				"OK"
+//		@Failure		400		{string}	string					"Bad Request"
+//		@Failure		401		{string}	string					"Unauthorized (invalid credentials)"
+//		@Failure		403		{string}	string					"Forbidden (not an admin)"
+//		@Failure		404		{string}	string					"Not Found"
+//		@Failure		500		{object}	error					"Internal Server Error"
+//		@Router			/tax-rates/{id} [put]
+func UpdateTaxRate(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request tax.UpdateTaxRateRequest
+		err := c.Bind(&request)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		if id != request.Id {
+			return c.JSON(http.StatusBadRequest, "id in path does not match id in body")
+		}
+		result, err := a.TaxRateService().UpdateTaxRate(request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// DeleteTaxRate
+//
+//		@Summary		Delete Tax Rate
+//		@Description	Remove a specific Tax Rate
+//		@Id				delete_tax_rate
+//		@Tags			tax-rate
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the tax rate to be deleted"
+//		@Success		200	{object}	commons.DeleteResult	"OK"
+//		@Failure		400	{string}	string 					"Bad Request"
+//		@Failure		403	{string}	string 					"Forbidden (not an admin)"
+//		@Failure		500	{string}	string 					"Internal Server Error"
+//		@Router			/tax-rates/{id} [delete]
+func DeleteTaxRate(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.TaxRateService().DeleteTaxRate(id)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/tax"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTaxRateRoutes(t *testing.T) {
+	mockApp := context.MockApplicationContext(nil, nil, nil)
+	e := echo.New()
+	t.Run("successful route registration", func(t *testing.T) {
+		TaxRateRoutes(e.Group("/test"), mockApp, nil)
+		routes := e.Routes()
+		assert.Equal(t, 5, len(routes))
+	})
+}
+
+func TestTaxRateAdmin(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := tax.NewMockTaxRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithTaxRateService(mockService)
+	e := apiKeyServer(mockApp)
+	TaxRateRoutes(e.Group("/api/v1"), mockApp, []string{"admin"})
+	id := uuid.New()
+
+	t.Run("not an admin", func(t *testing.T) {
+		rec := serveAPIKey(e, http.MethodPut, "/api/v1/tax-rates/"+id.String(), `{"percentage": "0"}`, map[string]string{"User": "ann"})
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		rec = serveAPIKey(e, http.MethodDelete, "/api/v1/tax-rates/"+id.String(), "", map[string]string{"User": "ann"})
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("reads for everyone", func(t *testing.T) {
+		mockService.EXPECT().GetTaxRates(nil).Return([]tax.TaxRate{}, nil)
+		rec := serveAPIKey(e, http.MethodGet, "/api/v1/tax-rates", "", map[string]string{"User": "ann"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("admin", func(t *testing.T) {
+		mockService.EXPECT().DeleteTaxRate(id).Return(commons.DeleteResult{Id: id, Deleted: true}, nil)
+		rec := serveAPIKey(e, http.MethodDelete, "/api/v1/tax-rates/"+id.String(), "", map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestGetAllTaxRates(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := tax.NewMockTaxRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithTaxRateService(mockService)
+	expected := []tax.TaxRate{{Seq: 1, Jurisdiction: "GB", TaxCategory: "standard", Percentage: "20", EffectiveDate: commons.NewDate(2026, time.January, 1)}}
+	tests := []struct {
+		name          string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful retrieval",
+			mockFunc: func() {
+				mockService.EXPECT().GetTaxRates(nil).Return(expected, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "service error",
+			mockFunc: func() {
+				mockService.EXPECT().GetTaxRates(nil).Return(nil, errors.New("BOOM"))
+			},
+			expectErrCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if assert.NoError(t, GetAllTaxRates(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestGetTaxRate(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := tax.NewMockTaxRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithTaxRateService(mockService)
+	id := uuid.New()
+	tests := []struct {
+		name          string
+		id            string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful retrieval",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().GetTaxRate(id).Return(tax.TaxRate{Id: id}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "not found",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().GetTaxRate(id).Return(tax.TaxRate{}, sql.ErrNoRows)
+			},
+			expectErrCode: http.StatusNotFound,
+		},
+		{
+			name:          "bad id",
+			id:            "not-a-uuid",
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if assert.NoError(t, GetTaxRate(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestCreateTaxRate(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := tax.NewMockTaxRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithTaxRateService(mockService)
+	request := tax.CreateTaxRateRequest{
+		Jurisdiction:  "GB",
+		TaxCategory:   "standard",
+		Percentage:    "20",
+		EffectiveDate: commons.NewDate(2026, time.January, 1),
+		CreatedBy:     "unit test",
+	}
+	tests := []struct {
+		name          string
+		body          []byte
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful creation",
+			body: mustJson(request),
+			mockFunc: func() {
+				mockService.EXPECT().CreateTaxRate(request).Return(tax.TaxRate{Seq: 1, Percentage: "20"}, nil)
+			},
+			expectErrCode: http.StatusCreated,
+		},
+		{
+			name: "invalid percentage",
+			body: mustJson(request),
+			mockFunc: func() {
+				mockService.EXPECT().CreateTaxRate(request).Return(tax.TaxRate{}, tax.ErrInvalidPercentage)
+			},
+			expectErrCode: http.StatusBadRequest,
+		},
+		{
+			name:          "bad request",
+			body:          []byte("bad request"),
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if assert.NoError(t, CreateTaxRate(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestUpdateTaxRate(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := tax.NewMockTaxRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithTaxRateService(mockService)
+	id := uuid.New()
+	request := tax.UpdateTaxRateRequest{Id: id, Percentage: "21", EffectiveDate: commons.NewDate(2026, time.July, 1)}
+	tests := []struct {
+		name          string
+		id            string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful update",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().UpdateTaxRate(request).Return(tax.TaxRate{Id: id, Percentage: "21"}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name:          "id mismatch",
+			id:            uuid.NewString(),
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(mustJson(request)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if assert.NoError(t, UpdateTaxRate(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteTaxRate(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := tax.NewMockTaxRateService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithTaxRateService(mockService)
+	id := uuid.New()
+	mockService.EXPECT().DeleteTaxRate(id).Return(commons.DeleteResult{Id: id, Deleted: true}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	if assert.NoError(t, DeleteTaxRate(mockApp)(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/auth"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/tenant"
+	"net/http"
+)
+
+// TenantScope scopes the queries of a request to the tenant of its token, see commons.TenantDB. It runs after the
+// middleware that authenticates the request, the requests without claims are public and run as the system.
+func TenantScope() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := c.Get("user").(*auth.Claims)
+			if !ok {
+				return next(c)
+			}
+			tenantId, err := claims.TenantId()
+			if err != nil {
+				return c.String(http.StatusUnauthorized, "Unauthorized")
+			}
+			req := c.Request()
+			c.SetRequest(req.WithContext(commons.WithTenant(req.Context(), tenantId)))
+			return next(c)
+		}
+	}
+}
+
+// TenantRoutes are for the admins only, signed in with a token, as are the API keys.
+func TenantRoutes(g *echo.Group, a context.ApplicationContext, admins []string) {
+	admin := adminOnly(admins)
+	g.GET("/tenants", GetAllTenants(a), admin)
+	g.GET("/tenants/:id", GetTenant(a), admin)
+	g.POST("/tenants", CreateTenant(a), admin)
+}
+
+// GetAllTenants
+//
+//		@Summary		List Tenants
+//		@Description	List all Tenants
+//		@Id				all_tenants
+//		@Tags			tenant
+//		@Produce		json
+//		@Param			last_id		query		int	false	"last seq id"
+//	 	@Param			page_size 	query		int false 	"number of tenants per page"
+//		@Success		200	{array}		tenant.Tenant	"OK"
+//		@Failure		403	{string}	string			"Forbidden (not an admin)"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/tenants [get]
+func GetAllTenants(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		pagination := paginationFromRequest(c)
+		results, err := a.TenantService().GetTenants(c.Request().Context(), pagination)
+		if err != nil {
+			return commons.HandleServiceError(c, err)
+		}
+		return c.JSON(http.StatusOK, results)
+	}
+}
+
+// GetTenant
+//
+//		@Summary		Get Tenant
+//		@Description	Get a specific Tenant
+//		@Id				get_tenant
+//		@Tags			tenant
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the tenant requested"
+//		@Success		200	{object}	tenant.Tenant	"OK"
+//		@Failure		400	{string}	string 			"Bad Request"
+//		@Failure		403	{string}	string			"Forbidden (not an admin)"
+//		@Failure		404 {string} 	string			"Not Found"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/tenants/{id} [get]
+func GetTenant(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.TenantService().GetTenant(c.Request().Context(), id)
+		if err != nil {
+			return commons.HandleServiceError(c, err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// CreateTenant
+//
+//		@Summary		Create Tenant
+//		@Description	Create a Tenant, its users and API keys are then created with its tenant_id
+//		@ID				create_tenant
+//		@Tags			tenant
+//		@Accept			json
+//		@Produce		json
+//	    @Param 			request body 		tenant.CreateTenantRequest	true 	"Create Tenant Request"
+//		@Success		201		{object}	tenant.Tenant				"Created"
+//		@Failure		400		{string}	string					"Bad Request"
+//		@Failure		403		{string}	string					"Forbidden (not an admin)"
+//		@Failure		500		{object}	error					"Internal Server Error"
+//		@Router			/tenants [post]
+func CreateTenant(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request tenant.CreateTenantRequest
+		if err := c.Bind(&request); err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		request.CreatedBy = admin(c)
+		result, err := a.TenantService().CreateTenant(c.Request().Context(), request)
+		if err != nil {
+			return commons.HandleServiceError(c, err)
+		}
+		return c.JSON(http.StatusCreated, result)
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/auth"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/tenant"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTenantRoutes(t *testing.T) {
+	mockApp := context.MockApplicationContext(nil, nil, nil)
+	e := echo.New()
+	t.Run("successful route registration", func(t *testing.T) {
+		TenantRoutes(e.Group("/test"), mockApp, nil)
+		routes := e.Routes()
+		assert.Equal(t, 3, len(routes))
+	})
+}
+
+func TestTenantScope(t *testing.T) {
+	acme := uuid.New()
+	tests := []struct {
+		name         string
+		claims       *auth.Claims
+		expectCode   int
+		expectTenant string
+	}{
+		{name: "public", expectCode: http.StatusOK, expectTenant: "system"},
+		{name: "without a tenant", claims: &auth.Claims{Username: "ann"}, expectCode: http.StatusOK, expectTenant: commons.DefaultTenant.String()},
+		{name: "with a tenant", claims: &auth.Claims{Username: "ann", Tenant: acme.String()}, expectCode: http.StatusOK, expectTenant: acme.String()},
+		{name: "invalid tenant", claims: &auth.Claims{Username: "ann", Tenant: "acme"}, expectCode: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+			if tt.claims != nil {
+				c.Set("user", tt.claims)
+			}
+			err := TenantScope()(func(c echo.Context) error {
+				if tenant, ok := commons.TenantFrom(c.Request().Context()); ok {
+					return c.String(http.StatusOK, tenant.String())
+				}
+				return c.String(http.StatusOK, "system")
+			})(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectCode, rec.Code)
+			if tt.expectTenant != "" {
+				assert.Equal(t, tt.expectTenant, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTenantAdmin(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := tenant.NewMockTenantService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithTenantService(mockService)
+	e := apiKeyServer(mockApp, "admin")
+	TenantRoutes(e.Group("/api/v1"), mockApp, []string{"admin"})
+	id := uuid.New()
+	acme := tenant.Tenant{Id: id, Name: "acme"}
+
+	t.Run("not an admin", func(t *testing.T) {
+		rec := serveAPIKey(e, http.MethodPost, "/api/v1/tenants", `{"name": "acme"}`, map[string]string{"User": "ann"})
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("create", func(t *testing.T) {
+		mockService.EXPECT().CreateTenant(gomock.Any(), tenant.CreateTenantRequest{Name: "acme", CreatedBy: "admin"}).Return(acme, nil)
+		rec := serveAPIKey(e, http.MethodPost, "/api/v1/tenants", `{"name": "acme", "created_by": "someone else"}`, map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), id.String())
+	})
+	t.Run("create invalid", func(t *testing.T) {
+		mockService.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(tenant.Tenant{}, tenant.ErrInvalidTenant)
+		rec := serveAPIKey(e, http.MethodPost, "/api/v1/tenants", `{"name": ""}`, map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("list", func(t *testing.T) {
+		mockService.EXPECT().GetTenants(gomock.Any(), nil).Return([]tenant.Tenant{acme}, nil)
+		rec := serveAPIKey(e, http.MethodGet, "/api/v1/tenants", "", map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"name":"acme"`)
+	})
+	t.Run("get", func(t *testing.T) {
+		mockService.EXPECT().GetTenant(gomock.Any(), id).Return(acme, nil)
+		rec := serveAPIKey(e, http.MethodGet, "/api/v1/tenants/"+id.String(), "", map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockService.EXPECT().GetTenant(gomock.Any(), id).Return(tenant.Tenant{}, sql.ErrNoRows)
+		rec = serveAPIKey(e, http.MethodGet, "/api/v1/tenants/"+id.String(), "", map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		rec = serveAPIKey(e, http.MethodGet, "/api/v1/tenants/invalid", "", map[string]string{"User": "admin"})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
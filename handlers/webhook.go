@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/webhook"
+	"net/http"
+)
+
+func WebhookRoutes(g *echo.Group, a context.ApplicationContext) {
+	g.GET("/webhooks", GetAllWebhooks(a))
+	g.GET("/webhooks/dead-letters", GetWebhookDeadLetters(a))
+	g.GET("/webhooks/:id", GetWebhook(a))
+	g.POST("/webhooks", CreateWebhook(a))
+	g.PUT("/webhooks/:id", UpdateWebhook(a))
+	g.DELETE("/webhooks/:id", DeleteWebhook(a))
+	g.GET("/webhooks/:id/deliveries", GetWebhookDeliveries(a))
+	g.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", RedeliverWebhook(a))
+}
+
+// GetAllWebhooks
+//
+//		@Summary		List Webhook Subscriptions
+//		@Description	List all Webhook Subscriptions
+//		@Id				all_webhooks
+//		@Tags			webhook
+//		@Produce		json
+//		@Param			last_id		query		int	false	"last seq id"
+//	 	@Param			page_size 	query		int false 	"number of webhook subscriptions per page"
+//		@Success		200	{array}		webhook.Subscription		"OK"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/webhooks [get]
+func GetAllWebhooks(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		pagination := paginationFromRequest(c)
+		results, err := a.WebhookService().GetSubscriptions(c.Request().Context(), pagination)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err)
+		}
+		return c.JSON(http.StatusOK, results)
+	}
+}
+
+// GetWebhook
+//
+//		@Summary		Get Webhook Subscription
+//		@Description	Get a specific Webhook Subscription
+//		@Id				get_webhook
+//		@Tags			webhook
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the webhook subscription requested"
+//		@Success		200	{object}	webhook.Subscription		"OK"
+//		@Failure		400	{string}	string 			"Bad Request"
+//		@Failure		404 {string} 	string			"Not Found"
+//		@Failure		500	{string}	string 			"Internal Server Error"
+//		@Router			/webhooks/{id} [get]
+func GetWebhook(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.WebhookService().GetSubscription(c.Request().Context(), id)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// CreateWebhook
+//
+//		@Summary		Create Webhook Subscription
+//		@Description	Register a webhook endpoint for the chosen event types, the response holds the HMAC secret deliveries are signed with and is the only time it is returned
+//		@ID				create_webhook
+//		@Tags			webhook
+//		@Accept			json
+//		@Produce		json
+//	    @Param 			request body 		webhook.CreateSubscriptionRequest	true 	"Create Webhook Subscription Request"
+//		@Success		201		{object}	webhook.Subscription				"Created"
+//		@Failure		400		{string}	string					"Bad Request"
+//		@Failure		401		{string}	string					"Unauthorized (invalid credentials)"
+//		@Failure		500		{object}	error					"Internal Server Error"
+//		@Router			/webhooks [post]
+func CreateWebhook(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request webhook.CreateSubscriptionRequest
+		err := c.Bind(&request)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.WebhookService().CreateSubscription(c.Request().Context(), request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusCreated, result)
+	}
+}
+
+// UpdateWebhook
+//
+//		@Summary		Update Webhook Subscription
+//		@Description	Update the url and event types of a Webhook Subscription or (de)activate it
+//		@ID				update_webhook
+//		@Tags			webhook
+//		@Accept			json
+//		@Produce		json
+//	    @Param 			request body 		webhook.UpdateSubscriptionRequest	true 	"Update Webhook Subscription Request"
+//		@Param			id	path			uuid.Uuid					true	"Webhook Subscription Id"
+//		@Success		200		{object}	webhook.Subscription				"OK"
+//		@Failure		400		{string}	string					"Bad Request"
+//		@Failure		401		{string}	string					"Unauthorized (invalid credentials)"
+//		@Failure		404		{string}	string					"Not Found"
+//		@Failure		500		{object}	error					"Internal Server Error"
+//		@Router			/webhooks/{id} [put]
+func UpdateWebhook(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		var request webhook.UpdateSubscriptionRequest
+		err := c.Bind(&request)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		if id != request.Id {
+			return c.JSON(http.StatusBadRequest, "id in path does not match id in body")
+		}
+		result, err := a.WebhookService().UpdateSubscription(c.Request().Context(), request)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// DeleteWebhook
+//
+//		@Summary		Delete Webhook Subscription
+//		@Description	Remove a specific Webhook Subscription
+//		@Id				delete_webhook
+//		@Tags			webhook
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the webhook subscription to be deleted"
+//		@Success		200	{object}	commons.DeleteResult	"OK"
+//		@Failure		400	{string}	string 					"Bad Request"
+//		@Failure		500	{string}	string 					"Internal Server Error"
+//		@Router			/webhooks/{id} [delete]
+func DeleteWebhook(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.WebhookService().DeleteSubscription(c.Request().Context(), id)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetWebhookDeliveries
+//
+//		@Summary		List Webhook Deliveries
+//		@Description	The delivery log of a Webhook Subscription, newest first, optionally only the deliveries with a status
+//		@Id				get_webhook_deliveries
+//		@Tags			webhook
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the webhook subscription"
+//	 	@Param			status			query		string 		false 	"pending, delivered, failed or dead"
+//		@Success		200	{array}		webhook.Delivery	"OK"
+//		@Failure		400	{string}	string 				"Bad Request"
+//		@Failure		404 {string} 	string				"Not Found"
+//		@Failure		500	{string}	string 				"Internal Server Error"
+//		@Router			/webhooks/{id}/deliveries [get]
+func GetWebhookDeliveries(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		results, err := a.WebhookService().GetDeliveries(c.Request().Context(), id, c.QueryParam("status"))
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, results)
+	}
+}
+
+// GetWebhookDeadLetters
+//
+//	@Summary		List Dead Letters
+//	@Description	List the deliveries of all Webhook Subscriptions that were given up on after repeated failures
+//	@Id				get_webhook_dead_letters
+//	@Tags			webhook
+//	@Produce		json
+//	@Success		200	{array}		webhook.Delivery	"OK"
+//	@Failure		500	{string}	string 				"Internal Server Error"
+//	@Router			/webhooks/dead-letters [get]
+func GetWebhookDeadLetters(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		results, err := a.WebhookService().GetDeadLetters(c.Request().Context())
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, err)
+		}
+		return c.JSON(http.StatusOK, results)
+	}
+}
+
+// RedeliverWebhook
+//
+//		@Summary		Redeliver Webhook
+//		@Description	Post a delivery to its Webhook Subscription again right away, including dead letters
+//		@Id				redeliver_webhook
+//		@Tags			webhook
+//		@Produce		json
+//	 	@Param			id				path		uuid.Uuid 	true 	"id of the webhook subscription"
+//	 	@Param			delivery_id		path		uuid.Uuid 	true 	"id of the delivery"
+//		@Success		200	{object}	webhook.Delivery	"OK"
+//		@Failure		400	{string}	string 				"Bad Request"
+//		@Failure		404 {string} 	string				"Not Found"
+//		@Failure		500	{string}	string 				"Internal Server Error"
+//		@Router			/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func RedeliverWebhook(a context.ApplicationContext) func(c echo.Context) error {
+	return func(c echo.Context) error {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		deliveryId, err := uuid.Parse(c.Param("delivery_id"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err)
+		}
+		result, err := a.WebhookService().Redeliver(c.Request().Context(), id, deliveryId)
+		err2 := commons.HandleServiceError(c, err)
+		if err2 != nil || err != nil {
+			return err2
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
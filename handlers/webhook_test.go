@@ -0,0 +1,344 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/context"
+	"inventory-service-go/outbox"
+	"inventory-service-go/webhook"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookRoutes(t *testing.T) {
+	mockApp := context.MockApplicationContext(nil, nil, nil)
+	e := echo.New()
+	t.Run("successful route registration", func(t *testing.T) {
+		WebhookRoutes(e.Group("/test"), mockApp)
+		routes := e.Routes()
+		assert.Equal(t, 8, len(routes))
+	})
+}
+
+func TestGetAllWebhooks(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := webhook.NewMockWebhookService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithWebhookService(mockService)
+	expected := []webhook.Subscription{{Seq: 1, Id: uuid.New(), Url: "https://erp.example.com/hooks", EventTypes: []string{outbox.InvoicePaid}}}
+	tests := []struct {
+		name          string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful retrieval",
+			mockFunc: func() {
+				mockService.EXPECT().GetSubscriptions(gomock.Any(), nil).Return(expected, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "service error",
+			mockFunc: func() {
+				mockService.EXPECT().GetSubscriptions(gomock.Any(), nil).Return(nil, errors.New("BOOM"))
+			},
+			expectErrCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if assert.NoError(t, GetAllWebhooks(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestGetWebhook(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := webhook.NewMockWebhookService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithWebhookService(mockService)
+	id := uuid.New()
+	tests := []struct {
+		name          string
+		id            string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful retrieval",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().GetSubscription(gomock.Any(), id).Return(webhook.Subscription{Id: id}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name: "not found",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().GetSubscription(gomock.Any(), id).Return(webhook.Subscription{}, sql.ErrNoRows)
+			},
+			expectErrCode: http.StatusNotFound,
+		},
+		{
+			name:          "bad id",
+			id:            "not-a-uuid",
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if assert.NoError(t, GetWebhook(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestCreateWebhook(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := webhook.NewMockWebhookService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithWebhookService(mockService)
+	request := webhook.CreateSubscriptionRequest{Url: "https://erp.example.com/hooks", EventTypes: []string{outbox.InvoicePaid}, CreatedBy: "unit test"}
+	tests := []struct {
+		name          string
+		body          []byte
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful creation",
+			body: mustJson(request),
+			mockFunc: func() {
+				mockService.EXPECT().CreateSubscription(gomock.Any(), request).Return(webhook.Subscription{Seq: 1, Secret: "whsec_test"}, nil)
+			},
+			expectErrCode: http.StatusCreated,
+		},
+		{
+			name: "invalid subscription",
+			body: mustJson(request),
+			mockFunc: func() {
+				mockService.EXPECT().CreateSubscription(gomock.Any(), request).Return(webhook.Subscription{}, webhook.ErrInvalidSubscription)
+			},
+			expectErrCode: http.StatusBadRequest,
+		},
+		{
+			name:          "bad request",
+			body:          []byte("bad request"),
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if assert.NoError(t, CreateWebhook(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := webhook.NewMockWebhookService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithWebhookService(mockService)
+	id := uuid.New()
+	request := webhook.UpdateSubscriptionRequest{Id: id, Url: "https://erp.example.com/hooks", Active: false, LastChangedBy: "unit test"}
+	tests := []struct {
+		name          string
+		id            string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name: "successful update",
+			id:   id.String(),
+			mockFunc: func() {
+				mockService.EXPECT().UpdateSubscription(gomock.Any(), request).Return(webhook.Subscription{Id: id}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name:          "id mismatch",
+			id:            uuid.NewString(),
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(mustJson(request)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			if assert.NoError(t, UpdateWebhook(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := webhook.NewMockWebhookService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithWebhookService(mockService)
+	id := uuid.New()
+	mockService.EXPECT().DeleteSubscription(gomock.Any(), id).Return(commons.DeleteResult{Id: id, Deleted: true}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id.String())
+	if assert.NoError(t, DeleteWebhook(mockApp)(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := webhook.NewMockWebhookService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithWebhookService(mockService)
+	id := uuid.New()
+	tests := []struct {
+		name          string
+		status        string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name:   "successful retrieval",
+			status: webhook.StatusDead,
+			mockFunc: func() {
+				mockService.EXPECT().GetDeliveries(gomock.Any(), id, webhook.StatusDead).Return([]webhook.Delivery{{SubscriptionId: id, Status: webhook.StatusDead}}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name:   "invalid status",
+			status: "lost",
+			mockFunc: func() {
+				mockService.EXPECT().GetDeliveries(gomock.Any(), id, "lost").Return(nil, webhook.ErrInvalidStatus)
+			},
+			expectErrCode: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			mockFunc: func() {
+				mockService.EXPECT().GetDeliveries(gomock.Any(), id, "").Return(nil, sql.ErrNoRows)
+			},
+			expectErrCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/?status="+tt.status, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(id.String())
+			if assert.NoError(t, GetWebhookDeliveries(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
+
+func TestGetWebhookDeadLetters(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := webhook.NewMockWebhookService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithWebhookService(mockService)
+	mockService.EXPECT().GetDeadLetters(gomock.Any()).Return([]webhook.Delivery{{Status: webhook.StatusDead}}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	if assert.NoError(t, GetWebhookDeadLetters(mockApp)(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := webhook.NewMockWebhookService(controller)
+	mockApp := context.MockApplicationContext(nil, nil, nil).WithWebhookService(mockService)
+	id, deliveryId := uuid.New(), uuid.New()
+	tests := []struct {
+		name          string
+		deliveryId    string
+		mockFunc      func()
+		expectErrCode int
+	}{
+		{
+			name:       "successful redelivery",
+			deliveryId: deliveryId.String(),
+			mockFunc: func() {
+				mockService.EXPECT().Redeliver(gomock.Any(), id, deliveryId).Return(webhook.Delivery{Id: deliveryId, Status: webhook.StatusDelivered}, nil)
+			},
+			expectErrCode: http.StatusOK,
+		},
+		{
+			name:       "not found",
+			deliveryId: deliveryId.String(),
+			mockFunc: func() {
+				mockService.EXPECT().Redeliver(gomock.Any(), id, deliveryId).Return(webhook.Delivery{}, sql.ErrNoRows)
+			},
+			expectErrCode: http.StatusNotFound,
+		},
+		{
+			name:          "bad delivery id",
+			deliveryId:    "not-a-uuid",
+			mockFunc:      func() {},
+			expectErrCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id", "delivery_id")
+			c.SetParamValues(id.String(), tt.deliveryId)
+			if assert.NoError(t, RedeliverWebhook(mockApp)(c)) {
+				assert.Equal(t, tt.expectErrCode, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	MigrationStatusQuery = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+	undefinedTable       = "42P01"
+)
+
+func down(err error) Result {
+	return Result{Status: StatusDown, Error: err.Error()}
+}
+
+// Database pings the database.
+func Database(db *sql.DB) Check {
+	return func(ctx context.Context) Result {
+		if err := db.PingContext(ctx); err != nil {
+			return down(err)
+		}
+		return Result{Status: StatusUp}
+	}
+}
+
+// Migrations checks that the schema is at the latest migration. A schema that is ahead is fine, it is the schema of a
+// newer release that is being rolled out. A database whose migrations are not tracked is reported as degraded.
+func Migrations(db *sqlx.DB, latest uint) Check {
+	return func(ctx context.Context) Result {
+		var status struct {
+			Version uint `db:"version"`
+			Dirty   bool `db:"dirty"`
+		}
+		err := db.GetContext(ctx, &status, MigrationStatusQuery)
+		var pgError *pgconn.PgError
+		if (errors.As(err, &pgError) && pgError.Code == undefinedTable) || errors.Is(err, sql.ErrNoRows) {
+			return Result{Status: StatusDegraded, Error: "migrations are not tracked in schema_migrations", Details: map[string]any{"expected": latest}}
+		}
+		if err != nil {
+			return down(err)
+		}
+		result := Result{Status: StatusUp, Details: map[string]any{"version": status.Version, "expected": latest, "dirty": status.Dirty}}
+		if status.Dirty {
+			result.Status = StatusDown
+			result.Error = fmt.Sprintf("migration %d failed half way", status.Version)
+		} else if status.Version < latest {
+			result.Status = StatusDown
+			result.Error = fmt.Sprintf("schema is at migration %d, %d is expected", status.Version, latest)
+		}
+		return result
+	}
+}
+
+// Pool reports how saturated the connection pool is, degraded when every connection is in use and requests have to
+// wait for one.
+func Pool(db *sql.DB) Check {
+	return func(context.Context) Result {
+		stats := db.Stats()
+		saturation := 0.0
+		if stats.MaxOpenConnections > 0 {
+			saturation = float64(stats.InUse) / float64(stats.MaxOpenConnections)
+		}
+		result := Result{Status: StatusUp, Details: map[string]any{
+			"open":             stats.OpenConnections,
+			"in_use":           stats.InUse,
+			"idle":             stats.Idle,
+			"max_open":         stats.MaxOpenConnections,
+			"saturation":       saturation,
+			"wait_count":       stats.WaitCount,
+			"wait_duration_ms": stats.WaitDuration.Milliseconds(),
+		}}
+		if saturation >= 1 {
+			result.Status = StatusDegraded
+			result.Error = "all connections are in use"
+		}
+		return result
+	}
+}
@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestDatabase(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	mock.ExpectPing()
+	mock.ExpectPing().WillReturnError(errors.New("BOOM"))
+
+	check := Database(db)
+	assert.Equal(t, StatusUp, check(context.Background()).Status)
+	assert.Equal(t, Result{Status: StatusDown, Error: "BOOM"}, check(context.Background()))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrations(t *testing.T) {
+	tests := []struct {
+		name       string
+		rows       *sqlmock.Rows
+		err        error
+		wantStatus Status
+	}{
+		{"latest", sqlmock.NewRows([]string{"version", "dirty"}).AddRow(8, false), nil, StatusUp},
+		{"ahead", sqlmock.NewRows([]string{"version", "dirty"}).AddRow(9, false), nil, StatusUp},
+		{"behind", sqlmock.NewRows([]string{"version", "dirty"}).AddRow(7, false), nil, StatusDown},
+		{"dirty", sqlmock.NewRows([]string{"version", "dirty"}).AddRow(8, true), nil, StatusDown},
+		{"untracked", nil, &pgconn.PgError{Code: undefinedTable}, StatusDegraded},
+		{"never migrated", sqlmock.NewRows([]string{"version", "dirty"}), nil, StatusDegraded},
+		{"error", nil, errors.New("BOOM"), StatusDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			query := mock.ExpectQuery(regexp.QuoteMeta(MigrationStatusQuery))
+			if tt.err != nil {
+				query.WillReturnError(tt.err)
+			} else {
+				query.WillReturnRows(tt.rows)
+			}
+
+			result := Migrations(sqlx.NewDb(db, "mockDb"), 8)(context.Background())
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantStatus == StatusUp, result.Error == "")
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPool(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	check := Pool(db)
+
+	result := check(context.Background())
+	assert.Equal(t, StatusUp, result.Status)
+	assert.Equal(t, 1, result.Details["max_open"])
+	assert.Equal(t, 0.0, result.Details["saturation"])
+
+	mock.ExpectPing()
+	conn, err := db.Conn(context.Background())
+	assert.Nil(t, err)
+	defer conn.Close()
+	result = check(context.Background())
+	assert.Equal(t, StatusDegraded, result.Status)
+	assert.Equal(t, 1.0, result.Details["saturation"])
+}
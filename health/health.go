@@ -0,0 +1,133 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTimeout bounds all readiness checks together, see config.Health.
+const DefaultTimeout = 2 * time.Second
+
+type Status string
+
+const (
+	StatusUp Status = "up"
+	// StatusDegraded is reported when a dependency needs attention but the service can still take requests, it
+	// does not fail readiness.
+	StatusDegraded Status = "degraded"
+	StatusDown     Status = "down"
+)
+
+// Result is the outcome of checking one dependency.
+type Result struct {
+	Status  Status         `json:"status"`
+	Error   string         `json:"error,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Check checks a dependency, giving up when the context is done.
+type Check func(ctx context.Context) Result
+
+// Report is the outcome of all checks, down when any is down.
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+// Checker runs the readiness checks of the dependencies. It reports the service as down once it is shutting down,
+// so the load balancer stops sending requests while the ones in flight are drained.
+type Checker struct {
+	timeout      time.Duration
+	mu           sync.RWMutex
+	names        []string
+	checks       map[string]Check
+	shuttingDown atomic.Bool
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+		checks:  map[string]Check{},
+	}
+}
+
+// Add registers the check under the name it is reported by, replacing an earlier one of the same name.
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.checks[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.checks[name] = check
+}
+
+// Shutdown fails readiness from now on.
+func (c *Checker) Shutdown() {
+	c.shuttingDown.Store(true)
+}
+
+func (c *Checker) ShuttingDown() bool {
+	return c.shuttingDown.Load()
+}
+
+// Live reports that the process is up, it does not check any dependency.
+func (c *Checker) Live() Report {
+	return Report{Status: StatusUp}
+}
+
+// Ready runs all checks concurrently within the timeout.
+func (c *Checker) Ready(ctx context.Context) Report {
+	if c.ShuttingDown() {
+		return Report{Status: StatusDown, Checks: map[string]Result{"shutdown": {Status: StatusDown, Error: "shutting down"}}}
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	c.mu.RLock()
+	names := append([]string(nil), c.names...)
+	checks := make([]Check, len(names))
+	for i, name := range names {
+		checks[i] = c.checks[name]
+	}
+	c.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]Result, len(names))}
+	for i, name := range names {
+		result := results[i]
+		report.Checks[name] = result
+		if result.Status == StatusDown {
+			report.Status = StatusDown
+		} else if result.Status == StatusDegraded && report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+// run fails the check when it does not return within the timeout, a check stuck in a driver call must not hang the
+// probe.
+func run(ctx context.Context, check Check) Result {
+	done := make(chan Result, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+	select {
+	case result := <-done:
+		return result
+	case <-ctx.Done():
+		return Result{Status: StatusDown, Error: ctx.Err().Error()}
+	}
+}
@@ -0,0 +1,72 @@
+package health
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func status(s Status) Check {
+	return func(context.Context) Result { return Result{Status: s} }
+}
+
+func TestChecker_Ready(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks map[string]Check
+		want   Status
+	}{
+		{"no checks", nil, StatusUp},
+		{"all up", map[string]Check{"a": status(StatusUp), "b": status(StatusUp)}, StatusUp},
+		{"degraded", map[string]Check{"a": status(StatusUp), "b": status(StatusDegraded)}, StatusDegraded},
+		{"down wins", map[string]Check{"a": status(StatusDown), "b": status(StatusDegraded)}, StatusDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(time.Second)
+			for name, check := range tt.checks {
+				checker.Add(name, check)
+			}
+			report := checker.Ready(context.Background())
+			assert.Equal(t, tt.want, report.Status)
+			assert.Len(t, report.Checks, len(tt.checks))
+		})
+	}
+}
+
+func TestChecker_Timeout(t *testing.T) {
+	checker := NewChecker(10 * time.Millisecond)
+	// a check that ignores its context
+	checker.Add("stuck", func(context.Context) Result {
+		time.Sleep(time.Second)
+		return Result{Status: StatusUp}
+	})
+	checker.Add("fast", status(StatusUp))
+
+	start := time.Now()
+	report := checker.Ready(context.Background())
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["stuck"].Error)
+	assert.Equal(t, StatusUp, report.Checks["fast"].Status)
+}
+
+func TestChecker_Add(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Add("a", status(StatusDown))
+	checker.Add("a", status(StatusUp))
+	report := checker.Ready(context.Background())
+	assert.Equal(t, StatusUp, report.Status)
+	assert.Len(t, report.Checks, 1)
+}
+
+func TestChecker_Shutdown(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Add("a", status(StatusUp))
+	assert.False(t, checker.ShuttingDown())
+	checker.Shutdown()
+	assert.True(t, checker.ShuttingDown())
+	assert.Equal(t, StatusDown, checker.Ready(context.Background()).Status)
+	assert.Equal(t, StatusUp, checker.Live().Status)
+}
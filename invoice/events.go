@@ -0,0 +1,77 @@
+package invoice
+
+import (
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/outbox"
+)
+
+const aggregateType = "invoice"
+
+type AddedLine struct {
+	ItemId    uuid.UUID     `json:"item_id"`
+	UnitPrice commons.Money `json:"unit_price"`
+	Quantity  int64         `json:"quantity"`
+	TaxRate   string        `json:"tax_rate"`
+}
+
+type ItemsAdded struct {
+	InvoiceId uuid.UUID   `json:"invoice_id"`
+	Lines     []AddedLine `json:"lines"`
+}
+
+type TotalCorrected struct {
+	InvoiceId uuid.UUID     `json:"invoice_id"`
+	Before    commons.Money `json:"before"`
+	After     commons.Money `json:"after"`
+}
+
+// createdEvents returns InvoiceCreated, followed by InvoicePaid for an invoice that is paid right away.
+func createdEvents(row InvoiceRow) ([]outbox.Event, error) {
+	created, err := outbox.NewEvent(outbox.InvoiceCreated, aggregateType, row.AltId, fromRow(row))
+	if err != nil {
+		return nil, err
+	}
+	if !row.Paid {
+		return []outbox.Event{created}, nil
+	}
+	paid, err := outbox.NewEvent(outbox.InvoicePaid, aggregateType, row.AltId, fromRow(row))
+	if err != nil {
+		return nil, err
+	}
+	return []outbox.Event{created, paid}, nil
+}
+
+// paidEvents returns InvoicePaid when the update marked the invoice as paid, none otherwise.
+func paidEvents(before InvoiceRow, after InvoiceRow) ([]outbox.Event, error) {
+	if before.Paid || !after.Paid {
+		return nil, nil
+	}
+	paid, err := outbox.NewEvent(outbox.InvoicePaid, aggregateType, after.AltId, fromRow(after))
+	if err != nil {
+		return nil, err
+	}
+	return []outbox.Event{paid}, nil
+}
+
+func itemsAddedEvent(lines []InvoiceItemLine) (outbox.Event, error) {
+	payload := ItemsAdded{Lines: make([]AddedLine, 0, len(lines))}
+	for _, line := range lines {
+		payload.InvoiceId = line.InvoiceId
+		payload.Lines = append(payload.Lines, AddedLine{
+			ItemId:    line.ItemId,
+			UnitPrice: withCurrency(line.UnitPrice, line.Currency),
+			Quantity:  line.Quantity,
+			TaxRate:   line.TaxRate,
+		})
+	}
+	return outbox.NewEvent(outbox.InvoiceItemsAdded, aggregateType, payload.InvoiceId, payload)
+}
+
+func totalCorrectedEvent(before InvoiceRow, after InvoiceRow) (outbox.Event, error) {
+	return outbox.NewEvent(outbox.InvoiceTotalCorrected, aggregateType, after.AltId, TotalCorrected{
+		InvoiceId: after.AltId,
+		Before:    withCurrency(before.Total, before.Currency),
+		After:     withCurrency(after.Total, after.Currency),
+	})
+}
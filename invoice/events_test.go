@@ -0,0 +1,47 @@
+package invoice
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/outbox"
+	"testing"
+)
+
+func TestPaidEvents(t *testing.T) {
+	id := uuid.New()
+	testCases := []struct {
+		name       string
+		before     bool
+		after      bool
+		wantEvents int
+	}{
+		{"Marked Paid", false, true, 1},
+		{"Already Paid", true, true, 0},
+		{"Still Unpaid", false, false, 0},
+		{"Marked Unpaid", true, false, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			events, err := paidEvents(InvoiceRow{AltId: id, Paid: tc.before}, InvoiceRow{AltId: id, Paid: tc.after})
+			assert.Nil(t, err)
+			assert.Len(t, events, tc.wantEvents)
+			for _, event := range events {
+				assert.Equal(t, outbox.InvoicePaid, event.Type)
+				assert.Equal(t, id, event.AggregateId)
+			}
+		})
+	}
+}
+
+func TestCreatedEvents(t *testing.T) {
+	id := uuid.New()
+	events, err := createdEvents(InvoiceRow{AltId: id})
+	assert.Nil(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, outbox.InvoiceCreated, events[0].Type)
+
+	events, err = createdEvents(InvoiceRow{AltId: id, Paid: true})
+	assert.Nil(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, outbox.InvoicePaid, events[1].Type)
+}
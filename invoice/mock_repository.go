@@ -10,8 +10,10 @@
 package invoice
 
 import (
+	context "context"
 	commons "inventory-service-go/commons"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -40,137 +42,262 @@ func (m *MockInvoiceRepository) EXPECT() *MockInvoiceRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddInvoiceDiscount mocks base method.
+func (m *MockInvoiceRepository) AddInvoiceDiscount(ctx context.Context, discount InvoiceDiscountRow, total TotalFunc) (InvoiceDiscountRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddInvoiceDiscount", ctx, discount, total)
+	ret0, _ := ret[0].(InvoiceDiscountRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddInvoiceDiscount indicates an expected call of AddInvoiceDiscount.
+func (mr *MockInvoiceRepositoryMockRecorder) AddInvoiceDiscount(ctx, discount, total any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddInvoiceDiscount", reflect.TypeOf((*MockInvoiceRepository)(nil).AddInvoiceDiscount), ctx, discount, total)
+}
+
 // AddItemsToInvoice mocks base method.
-func (m *MockInvoiceRepository) AddItemsToInvoice(request ItemsToInvoiceRequest) (ItemsToInvoiceResponse, error) {
+func (m *MockInvoiceRepository) AddItemsToInvoice(ctx context.Context, id uuid.UUID, lines []InvoiceItemLine, total TotalFunc) (ItemsToInvoiceResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddItemsToInvoice", request)
+	ret := m.ctrl.Call(m, "AddItemsToInvoice", ctx, id, lines, total)
 	ret0, _ := ret[0].(ItemsToInvoiceResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddItemsToInvoice indicates an expected call of AddItemsToInvoice.
-func (mr *MockInvoiceRepositoryMockRecorder) AddItemsToInvoice(request any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) AddItemsToInvoice(ctx, id, lines, total any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemsToInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).AddItemsToInvoice), ctx, id, lines, total)
+}
+
+// CorrectInvoiceTotal mocks base method.
+func (m *MockInvoiceRepository) CorrectInvoiceTotal(ctx context.Context, id uuid.UUID, total commons.Money) (InvoiceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CorrectInvoiceTotal", ctx, id, total)
+	ret0, _ := ret[0].(InvoiceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CorrectInvoiceTotal indicates an expected call of CorrectInvoiceTotal.
+func (mr *MockInvoiceRepositoryMockRecorder) CorrectInvoiceTotal(ctx, id, total any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemsToInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).AddItemsToInvoice), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CorrectInvoiceTotal", reflect.TypeOf((*MockInvoiceRepository)(nil).CorrectInvoiceTotal), ctx, id, total)
 }
 
 // CreateInvoice mocks base method.
-func (m *MockInvoiceRepository) CreateInvoice(request CreateInvoiceRequest) (InvoiceRow, error) {
+func (m *MockInvoiceRepository) CreateInvoice(ctx context.Context, request CreateInvoiceRequest, lines ...InvoiceItemLine) (InvoiceRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateInvoice", request)
+	varargs := []any{ctx, request}
+	for _, a := range lines {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateInvoice", varargs...)
 	ret0, _ := ret[0].(InvoiceRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateInvoice indicates an expected call of CreateInvoice.
-func (mr *MockInvoiceRepositoryMockRecorder) CreateInvoice(request any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) CreateInvoice(ctx, request any, lines ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).CreateInvoice), request)
+	varargs := append([]any{ctx, request}, lines...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).CreateInvoice), varargs...)
 }
 
 // DeleteInvoice mocks base method.
-func (m *MockInvoiceRepository) DeleteInvoice(id uuid.UUID) (commons.DeleteResult, error) {
+func (m *MockInvoiceRepository) DeleteInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteInvoice", id)
+	ret := m.ctrl.Call(m, "DeleteInvoice", ctx, id)
 	ret0, _ := ret[0].(commons.DeleteResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteInvoice indicates an expected call of DeleteInvoice.
-func (mr *MockInvoiceRepositoryMockRecorder) DeleteInvoice(id any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) DeleteInvoice(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).DeleteInvoice), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).DeleteInvoice), ctx, id)
 }
 
 // GetAll mocks base method.
-func (m *MockInvoiceRepository) GetAll(pagination *commons.Pagination) ([]InvoiceRow, error) {
+func (m *MockInvoiceRepository) GetAll(ctx context.Context, pagination *commons.Pagination) ([]InvoiceRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", pagination)
+	ret := m.ctrl.Call(m, "GetAll", ctx, pagination)
 	ret0, _ := ret[0].([]InvoiceRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockInvoiceRepositoryMockRecorder) GetAll(pagination any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) GetAll(ctx, pagination any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockInvoiceRepository)(nil).GetAll), pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockInvoiceRepository)(nil).GetAll), ctx, pagination)
 }
 
 // GetAllForUser mocks base method.
-func (m *MockInvoiceRepository) GetAllForUser(userId uuid.UUID) ([]InvoiceRow, error) {
+func (m *MockInvoiceRepository) GetAllForUser(ctx context.Context, userId uuid.UUID) ([]InvoiceRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllForUser", userId)
+	ret := m.ctrl.Call(m, "GetAllForUser", ctx, userId)
 	ret0, _ := ret[0].([]InvoiceRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllForUser indicates an expected call of GetAllForUser.
-func (mr *MockInvoiceRepositoryMockRecorder) GetAllForUser(userId any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) GetAllForUser(ctx, userId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllForUser", reflect.TypeOf((*MockInvoiceRepository)(nil).GetAllForUser), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllForUser", reflect.TypeOf((*MockInvoiceRepository)(nil).GetAllForUser), ctx, userId)
+}
+
+// GetAllForUsers mocks base method.
+func (m *MockInvoiceRepository) GetAllForUsers(ctx context.Context, userIds []uuid.UUID) ([]InvoiceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllForUsers", ctx, userIds)
+	ret0, _ := ret[0].([]InvoiceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllForUsers indicates an expected call of GetAllForUsers.
+func (mr *MockInvoiceRepositoryMockRecorder) GetAllForUsers(ctx, userIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllForUsers", reflect.TypeOf((*MockInvoiceRepository)(nil).GetAllForUsers), ctx, userIds)
+}
+
+// GetDiscountsForInvoices mocks base method.
+func (m *MockInvoiceRepository) GetDiscountsForInvoices(ctx context.Context, ids []uuid.UUID) ([]InvoiceDiscountRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiscountsForInvoices", ctx, ids)
+	ret0, _ := ret[0].([]InvoiceDiscountRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiscountsForInvoices indicates an expected call of GetDiscountsForInvoices.
+func (mr *MockInvoiceRepositoryMockRecorder) GetDiscountsForInvoices(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiscountsForInvoices", reflect.TypeOf((*MockInvoiceRepository)(nil).GetDiscountsForInvoices), ctx, ids)
 }
 
 // GetInvoice mocks base method.
-func (m *MockInvoiceRepository) GetInvoice(id uuid.UUID) (InvoiceRow, error) {
+func (m *MockInvoiceRepository) GetInvoice(ctx context.Context, id uuid.UUID) (InvoiceRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInvoice", id)
+	ret := m.ctrl.Call(m, "GetInvoice", ctx, id)
 	ret0, _ := ret[0].(InvoiceRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetInvoice indicates an expected call of GetInvoice.
-func (mr *MockInvoiceRepositoryMockRecorder) GetInvoice(id any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) GetInvoice(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).GetInvoice), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).GetInvoice), ctx, id)
+}
+
+// GetInvoiceByNumber mocks base method.
+func (m *MockInvoiceRepository) GetInvoiceByNumber(ctx context.Context, number string) (InvoiceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceByNumber", ctx, number)
+	ret0, _ := ret[0].(InvoiceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceByNumber indicates an expected call of GetInvoiceByNumber.
+func (mr *MockInvoiceRepositoryMockRecorder) GetInvoiceByNumber(ctx, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceByNumber", reflect.TypeOf((*MockInvoiceRepository)(nil).GetInvoiceByNumber), ctx, number)
+}
+
+// GetInvoiceDiscounts mocks base method.
+func (m *MockInvoiceRepository) GetInvoiceDiscounts(ctx context.Context, id uuid.UUID) ([]InvoiceDiscountRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceDiscounts", ctx, id)
+	ret0, _ := ret[0].([]InvoiceDiscountRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceDiscounts indicates an expected call of GetInvoiceDiscounts.
+func (mr *MockInvoiceRepositoryMockRecorder) GetInvoiceDiscounts(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceDiscounts", reflect.TypeOf((*MockInvoiceRepository)(nil).GetInvoiceDiscounts), ctx, id)
 }
 
 // GetInvoiceWithItems mocks base method.
-func (m *MockInvoiceRepository) GetInvoiceWithItems(id uuid.UUID) ([]InvoiceItemRow, error) {
+func (m *MockInvoiceRepository) GetInvoiceWithItems(ctx context.Context, id uuid.UUID) ([]InvoiceItemRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInvoiceWithItems", id)
+	ret := m.ctrl.Call(m, "GetInvoiceWithItems", ctx, id)
 	ret0, _ := ret[0].([]InvoiceItemRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetInvoiceWithItems indicates an expected call of GetInvoiceWithItems.
-func (mr *MockInvoiceRepositoryMockRecorder) GetInvoiceWithItems(id any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) GetInvoiceWithItems(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceWithItems", reflect.TypeOf((*MockInvoiceRepository)(nil).GetInvoiceWithItems), ctx, id)
+}
+
+// GetInvoicesWithItems mocks base method.
+func (m *MockInvoiceRepository) GetInvoicesWithItems(ctx context.Context, ids []uuid.UUID) ([]InvoiceItemRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoicesWithItems", ctx, ids)
+	ret0, _ := ret[0].([]InvoiceItemRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoicesWithItems indicates an expected call of GetInvoicesWithItems.
+func (mr *MockInvoiceRepositoryMockRecorder) GetInvoicesWithItems(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoicesWithItems", reflect.TypeOf((*MockInvoiceRepository)(nil).GetInvoicesWithItems), ctx, ids)
+}
+
+// IssueInvoice mocks base method.
+func (m *MockInvoiceRepository) IssueInvoice(ctx context.Context, request IssueInvoiceRequest, format NumberFormat, issuedAt time.Time) (InvoiceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueInvoice", ctx, request, format, issuedAt)
+	ret0, _ := ret[0].(InvoiceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueInvoice indicates an expected call of IssueInvoice.
+func (mr *MockInvoiceRepositoryMockRecorder) IssueInvoice(ctx, request, format, issuedAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceWithItems", reflect.TypeOf((*MockInvoiceRepository)(nil).GetInvoiceWithItems), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).IssueInvoice), ctx, request, format, issuedAt)
 }
 
 // RemoveItemFromInvoice mocks base method.
-func (m *MockInvoiceRepository) RemoveItemFromInvoice(request SimpleInvoiceItem) (ItemsToInvoiceResponse, error) {
+func (m *MockInvoiceRepository) RemoveItemFromInvoice(ctx context.Context, request SimpleInvoiceItem, total TotalFunc) (ItemsToInvoiceResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveItemFromInvoice", request)
+	ret := m.ctrl.Call(m, "RemoveItemFromInvoice", ctx, request, total)
 	ret0, _ := ret[0].(ItemsToInvoiceResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RemoveItemFromInvoice indicates an expected call of RemoveItemFromInvoice.
-func (mr *MockInvoiceRepositoryMockRecorder) RemoveItemFromInvoice(request any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) RemoveItemFromInvoice(ctx, request, total any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItemFromInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).RemoveItemFromInvoice), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItemFromInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).RemoveItemFromInvoice), ctx, request, total)
 }
 
 // UpdateInvoice mocks base method.
-func (m *MockInvoiceRepository) UpdateInvoice(request UpdateInvoiceRequest) (InvoiceRow, error) {
+func (m *MockInvoiceRepository) UpdateInvoice(ctx context.Context, request UpdateInvoiceRequest) (InvoiceRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateInvoice", request)
+	ret := m.ctrl.Call(m, "UpdateInvoice", ctx, request)
 	ret0, _ := ret[0].(InvoiceRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateInvoice indicates an expected call of UpdateInvoice.
-func (mr *MockInvoiceRepositoryMockRecorder) UpdateInvoice(request any) *gomock.Call {
+func (mr *MockInvoiceRepositoryMockRecorder) UpdateInvoice(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).UpdateInvoice), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvoice", reflect.TypeOf((*MockInvoiceRepository)(nil).UpdateInvoice), ctx, request)
 }
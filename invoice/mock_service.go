@@ -10,6 +10,7 @@
 package invoice
 
 import (
+	context "context"
 	commons "inventory-service-go/commons"
 	reflect "reflect"
 
@@ -40,122 +41,243 @@ func (m *MockInvoiceService) EXPECT() *MockInvoiceServiceMockRecorder {
 	return m.recorder
 }
 
+// AddDiscount mocks base method.
+func (m *MockInvoiceService) AddDiscount(ctx context.Context, request InvoiceDiscountRequest) (Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDiscount", ctx, request)
+	ret0, _ := ret[0].(Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDiscount indicates an expected call of AddDiscount.
+func (mr *MockInvoiceServiceMockRecorder) AddDiscount(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDiscount", reflect.TypeOf((*MockInvoiceService)(nil).AddDiscount), ctx, request)
+}
+
 // AddItemsToInvoice mocks base method.
-func (m *MockInvoiceService) AddItemsToInvoice(request ItemsToInvoiceRequest) (ItemsToInvoiceResponse, error) {
+func (m *MockInvoiceService) AddItemsToInvoice(ctx context.Context, request ItemsToInvoiceRequest) (ItemsToInvoiceResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddItemsToInvoice", request)
+	ret := m.ctrl.Call(m, "AddItemsToInvoice", ctx, request)
 	ret0, _ := ret[0].(ItemsToInvoiceResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddItemsToInvoice indicates an expected call of AddItemsToInvoice.
-func (mr *MockInvoiceServiceMockRecorder) AddItemsToInvoice(request any) *gomock.Call {
+func (mr *MockInvoiceServiceMockRecorder) AddItemsToInvoice(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemsToInvoice", reflect.TypeOf((*MockInvoiceService)(nil).AddItemsToInvoice), ctx, request)
+}
+
+// ApplyPromotion mocks base method.
+func (m *MockInvoiceService) ApplyPromotion(ctx context.Context, request PromotionCodeRequest) (Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPromotion", ctx, request)
+	ret0, _ := ret[0].(Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyPromotion indicates an expected call of ApplyPromotion.
+func (mr *MockInvoiceServiceMockRecorder) ApplyPromotion(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItemsToInvoice", reflect.TypeOf((*MockInvoiceService)(nil).AddItemsToInvoice), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPromotion", reflect.TypeOf((*MockInvoiceService)(nil).ApplyPromotion), ctx, request)
 }
 
 // CreateInvoice mocks base method.
-func (m *MockInvoiceService) CreateInvoice(invoice CreateInvoiceRequest) (Invoice, error) {
+func (m *MockInvoiceService) CreateInvoice(ctx context.Context, invoice CreateInvoiceRequest) (Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateInvoice", invoice)
+	ret := m.ctrl.Call(m, "CreateInvoice", ctx, invoice)
 	ret0, _ := ret[0].(Invoice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateInvoice indicates an expected call of CreateInvoice.
-func (mr *MockInvoiceServiceMockRecorder) CreateInvoice(invoice any) *gomock.Call {
+func (mr *MockInvoiceServiceMockRecorder) CreateInvoice(ctx, invoice any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoice", reflect.TypeOf((*MockInvoiceService)(nil).CreateInvoice), invoice)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoice", reflect.TypeOf((*MockInvoiceService)(nil).CreateInvoice), ctx, invoice)
+}
+
+// CreateInvoiceWithItems mocks base method.
+func (m *MockInvoiceService) CreateInvoiceWithItems(ctx context.Context, invoice CreateInvoiceRequest, items ItemsToInvoiceRequest) (Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvoiceWithItems", ctx, invoice, items)
+	ret0, _ := ret[0].(Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvoiceWithItems indicates an expected call of CreateInvoiceWithItems.
+func (mr *MockInvoiceServiceMockRecorder) CreateInvoiceWithItems(ctx, invoice, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoiceWithItems", reflect.TypeOf((*MockInvoiceService)(nil).CreateInvoiceWithItems), ctx, invoice, items)
 }
 
 // DeleteInvoice mocks base method.
-func (m *MockInvoiceService) DeleteInvoice(id uuid.UUID) (commons.DeleteResult, error) {
+func (m *MockInvoiceService) DeleteInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteInvoice", id)
+	ret := m.ctrl.Call(m, "DeleteInvoice", ctx, id)
 	ret0, _ := ret[0].(commons.DeleteResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteInvoice indicates an expected call of DeleteInvoice.
-func (mr *MockInvoiceServiceMockRecorder) DeleteInvoice(id any) *gomock.Call {
+func (mr *MockInvoiceServiceMockRecorder) DeleteInvoice(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvoice", reflect.TypeOf((*MockInvoiceService)(nil).DeleteInvoice), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvoice", reflect.TypeOf((*MockInvoiceService)(nil).DeleteInvoice), ctx, id)
 }
 
 // GetAllInvoices mocks base method.
-func (m *MockInvoiceService) GetAllInvoices(pagination *commons.Pagination) ([]Invoice, error) {
+func (m *MockInvoiceService) GetAllInvoices(ctx context.Context, pagination *commons.Pagination) ([]Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllInvoices", pagination)
+	ret := m.ctrl.Call(m, "GetAllInvoices", ctx, pagination)
 	ret0, _ := ret[0].([]Invoice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllInvoices indicates an expected call of GetAllInvoices.
-func (mr *MockInvoiceServiceMockRecorder) GetAllInvoices(pagination any) *gomock.Call {
+func (mr *MockInvoiceServiceMockRecorder) GetAllInvoices(ctx, pagination any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllInvoices", reflect.TypeOf((*MockInvoiceService)(nil).GetAllInvoices), pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllInvoices", reflect.TypeOf((*MockInvoiceService)(nil).GetAllInvoices), ctx, pagination)
 }
 
 // GetInvoice mocks base method.
-func (m *MockInvoiceService) GetInvoice(id uuid.UUID, withItems bool) (Invoice, error) {
+func (m *MockInvoiceService) GetInvoice(ctx context.Context, id uuid.UUID, withItems bool) (Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInvoice", id, withItems)
+	ret := m.ctrl.Call(m, "GetInvoice", ctx, id, withItems)
 	ret0, _ := ret[0].(Invoice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetInvoice indicates an expected call of GetInvoice.
-func (mr *MockInvoiceServiceMockRecorder) GetInvoice(id, withItems any) *gomock.Call {
+func (mr *MockInvoiceServiceMockRecorder) GetInvoice(ctx, id, withItems any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoice), ctx, id, withItems)
+}
+
+// GetInvoiceByNumber mocks base method.
+func (m *MockInvoiceService) GetInvoiceByNumber(ctx context.Context, number string) (Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceByNumber", ctx, number)
+	ret0, _ := ret[0].(Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceByNumber indicates an expected call of GetInvoiceByNumber.
+func (mr *MockInvoiceServiceMockRecorder) GetInvoiceByNumber(ctx, number any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoice), id, withItems)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceByNumber", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoiceByNumber), ctx, number)
 }
 
 // GetInvoicesForUser mocks base method.
-func (m *MockInvoiceService) GetInvoicesForUser(userId uuid.UUID) ([]Invoice, error) {
+func (m *MockInvoiceService) GetInvoicesForUser(ctx context.Context, userId uuid.UUID) ([]Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInvoicesForUser", userId)
+	ret := m.ctrl.Call(m, "GetInvoicesForUser", ctx, userId)
 	ret0, _ := ret[0].([]Invoice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetInvoicesForUser indicates an expected call of GetInvoicesForUser.
-func (mr *MockInvoiceServiceMockRecorder) GetInvoicesForUser(userId any) *gomock.Call {
+func (mr *MockInvoiceServiceMockRecorder) GetInvoicesForUser(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoicesForUser", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoicesForUser), ctx, userId)
+}
+
+// GetInvoicesForUsers mocks base method.
+func (m *MockInvoiceService) GetInvoicesForUsers(ctx context.Context, userIds []uuid.UUID) ([]Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoicesForUsers", ctx, userIds)
+	ret0, _ := ret[0].([]Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoicesForUsers indicates an expected call of GetInvoicesForUsers.
+func (mr *MockInvoiceServiceMockRecorder) GetInvoicesForUsers(ctx, userIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoicesForUsers", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoicesForUsers), ctx, userIds)
+}
+
+// GetInvoicesWithItems mocks base method.
+func (m *MockInvoiceService) GetInvoicesWithItems(ctx context.Context, ids []uuid.UUID) ([]Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoicesWithItems", ctx, ids)
+	ret0, _ := ret[0].([]Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoicesWithItems indicates an expected call of GetInvoicesWithItems.
+func (mr *MockInvoiceServiceMockRecorder) GetInvoicesWithItems(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoicesWithItems", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoicesWithItems), ctx, ids)
+}
+
+// IssueInvoice mocks base method.
+func (m *MockInvoiceService) IssueInvoice(ctx context.Context, request IssueInvoiceRequest) (Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueInvoice", ctx, request)
+	ret0, _ := ret[0].(Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueInvoice indicates an expected call of IssueInvoice.
+func (mr *MockInvoiceServiceMockRecorder) IssueInvoice(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueInvoice", reflect.TypeOf((*MockInvoiceService)(nil).IssueInvoice), ctx, request)
+}
+
+// RecalculateTotal mocks base method.
+func (m *MockInvoiceService) RecalculateTotal(ctx context.Context, id uuid.UUID) (Invoice, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecalculateTotal", ctx, id)
+	ret0, _ := ret[0].(Invoice)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RecalculateTotal indicates an expected call of RecalculateTotal.
+func (mr *MockInvoiceServiceMockRecorder) RecalculateTotal(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoicesForUser", reflect.TypeOf((*MockInvoiceService)(nil).GetInvoicesForUser), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecalculateTotal", reflect.TypeOf((*MockInvoiceService)(nil).RecalculateTotal), ctx, id)
 }
 
 // RemoveItemFromInvoice mocks base method.
-func (m *MockInvoiceService) RemoveItemFromInvoice(request SimpleInvoiceItem) (ItemsToInvoiceResponse, error) {
+func (m *MockInvoiceService) RemoveItemFromInvoice(ctx context.Context, request SimpleInvoiceItem) (ItemsToInvoiceResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveItemFromInvoice", request)
+	ret := m.ctrl.Call(m, "RemoveItemFromInvoice", ctx, request)
 	ret0, _ := ret[0].(ItemsToInvoiceResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RemoveItemFromInvoice indicates an expected call of RemoveItemFromInvoice.
-func (mr *MockInvoiceServiceMockRecorder) RemoveItemFromInvoice(request any) *gomock.Call {
+func (mr *MockInvoiceServiceMockRecorder) RemoveItemFromInvoice(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItemFromInvoice", reflect.TypeOf((*MockInvoiceService)(nil).RemoveItemFromInvoice), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItemFromInvoice", reflect.TypeOf((*MockInvoiceService)(nil).RemoveItemFromInvoice), ctx, request)
 }
 
 // UpdateInvoice mocks base method.
-func (m *MockInvoiceService) UpdateInvoice(invoice UpdateInvoiceRequest) (Invoice, error) {
+func (m *MockInvoiceService) UpdateInvoice(ctx context.Context, invoice UpdateInvoiceRequest) (Invoice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateInvoice", invoice)
+	ret := m.ctrl.Call(m, "UpdateInvoice", ctx, invoice)
 	ret0, _ := ret[0].(Invoice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateInvoice indicates an expected call of UpdateInvoice.
-func (mr *MockInvoiceServiceMockRecorder) UpdateInvoice(invoice any) *gomock.Call {
+func (mr *MockInvoiceServiceMockRecorder) UpdateInvoice(ctx, invoice any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvoice", reflect.TypeOf((*MockInvoiceService)(nil).UpdateInvoice), invoice)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvoice", reflect.TypeOf((*MockInvoiceService)(nil).UpdateInvoice), ctx, invoice)
 }
@@ -0,0 +1,38 @@
+package invoice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NumberFormat describes the human-readable numbers given to invoices when they are issued, e.g. INV-2026-000123.
+// Numbers are allocated from a gap-free counter per series, with a yearly reset every year starts a new series.
+type NumberFormat struct {
+	Prefix      string
+	YearlyReset bool
+	Padding     int
+}
+
+var DefaultNumberFormat = NumberFormat{Prefix: "INV", YearlyReset: true, Padding: 6}
+
+// Series is the counter an invoice issued at the given time draws its number from.
+func (f NumberFormat) Series(issuedAt time.Time) string {
+	parts := make([]string, 0, 2)
+	if f.Prefix != "" {
+		parts = append(parts, f.Prefix)
+	}
+	if f.YearlyReset {
+		parts = append(parts, strconv.Itoa(issuedAt.UTC().Year()))
+	}
+	return strings.Join(parts, "-")
+}
+
+func (f NumberFormat) Format(issuedAt time.Time, seq int64) string {
+	number := fmt.Sprintf("%0*d", f.Padding, seq)
+	if series := f.Series(issuedAt); series != "" {
+		return series + "-" + number
+	}
+	return number
+}
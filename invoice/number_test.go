@@ -0,0 +1,42 @@
+package invoice
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/config"
+	"testing"
+	"time"
+)
+
+func TestNumberFormat_Format(t *testing.T) {
+	issuedAt := time.Date(2026, time.March, 14, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		format         NumberFormat
+		seq            int64
+		expectedSeries string
+		expected       string
+	}{
+		{"Default", DefaultNumberFormat, 123, "INV-2026", "INV-2026-000123"},
+		{"No Yearly Reset", NumberFormat{Prefix: "INV", Padding: 6}, 123, "INV", "INV-000123"},
+		{"No Prefix", NumberFormat{YearlyReset: true, Padding: 4}, 7, "2026", "2026-0007"},
+		{"Number Only", NumberFormat{Padding: 3}, 7, "", "007"},
+		{"Outgrows Padding", NumberFormat{Prefix: "SO", Padding: 2}, 1234, "SO", "SO-1234"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedSeries, tt.format.Series(issuedAt))
+			assert.Equal(t, tt.expected, tt.format.Format(issuedAt, tt.seq))
+		})
+	}
+}
+
+func TestNumberFormat_Series_UsesUTCYear(t *testing.T) {
+	newYearsEve := time.Date(2026, time.December, 31, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+	assert.Equal(t, "INV-2027", DefaultNumberFormat.Series(newYearsEve))
+}
+
+func TestNewFromConfig(t *testing.T) {
+	assert.Equal(t, DefaultNumberFormat, NewInvoiceService(nil, nil, nil, nil, nil).numbers)
+	s := NewFromConfig(nil, nil, nil, nil, nil, config.InvoiceNumbers{Prefix: " ACME ", YearlyReset: false, Padding: 8})
+	assert.Equal(t, NumberFormat{Prefix: "ACME", YearlyReset: false, Padding: 8}, s.numbers)
+}
@@ -1,63 +1,130 @@
 package invoice
 
 import (
+	"context"
 	"database/sql"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"inventory-service-go/commons"
+	"inventory-service-go/metrics"
+	"inventory-service-go/outbox"
+	"inventory-service-go/promotion"
+	"inventory-service-go/tracing"
 	"time"
 )
 
 type InvoiceRow struct {
-	Id            int64     `db:"id"`
-	AltId         uuid.UUID `db:"alt_id"`
-	UserId        uuid.UUID `db:"user_id"`
-	Total         float64   `db:"total"`
-	Paid          bool      `db:"paid"`
-	CreatedBy     string    `db:"created_by"`
-	CreatedAt     time.Time `db:"created_at"`
-	LastChangedBy string    `db:"last_changed_by"`
-	LastUpdate    time.Time `db:"last_update"`
+	Id               int64          `db:"id"`
+	AltId            uuid.UUID      `db:"alt_id"`
+	UserId           uuid.UUID      `db:"user_id"`
+	Total            commons.Money  `db:"total"`
+	Currency         string         `db:"currency"`
+	TaxJurisdiction  string         `db:"tax_jurisdiction"`
+	PricesIncludeTax bool           `db:"prices_include_tax"`
+	Paid             bool           `db:"paid"`
+	Number           sql.NullString `db:"number"`
+	IssuedAt         sql.NullTime   `db:"issued_at"`
+	CreatedBy        string         `db:"created_by"`
+	CreatedAt        time.Time      `db:"created_at"`
+	LastChangedBy    string         `db:"last_changed_by"`
+	LastUpdate       time.Time      `db:"last_update"`
+	TenantId         uuid.UUID      `db:"tenant_id"`
 }
 
 type InvoiceItemRow struct {
-	Id                int64           `db:"id"`
-	AltId             uuid.UUID       `db:"alt_id"`
-	UserId            uuid.UUID       `db:"user_id"`
-	Total             float64         `db:"total"`
-	Paid              bool            `db:"paid"`
-	CreatedBy         string          `db:"created_by"`
-	CreatedAt         time.Time       `db:"created_at"`
-	LastChangedBy     string          `db:"last_changed_by"`
-	LastUpdate        time.Time       `db:"last_update"`
-	ItemSeqId         sql.NullInt64   `db:"item_seq"`
-	ItemAltId         uuid.UUID       `db:"item_alt_id"`
-	ItemName          sql.NullString  `db:"item_name"`
-	ItemDescription   sql.NullString  `db:"item_description"`
-	ItemUnitPrice     sql.NullFloat64 `db:"item_unit_price"`
-	ItemCreatedBy     sql.NullString  `db:"item_created_by"`
-	ItemCreatedAt     sql.NullTime    `db:"item_created_at"`
-	ItemLastChangedBy sql.NullString  `db:"item_last_changed_by"`
-	ItemLastUpdate    sql.NullTime    `db:"item_last_update"`
+	Id                int64             `db:"id"`
+	AltId             uuid.UUID         `db:"alt_id"`
+	UserId            uuid.UUID         `db:"user_id"`
+	Total             commons.Money     `db:"total"`
+	Currency          string            `db:"currency"`
+	TaxJurisdiction   string            `db:"tax_jurisdiction"`
+	PricesIncludeTax  bool              `db:"prices_include_tax"`
+	Paid              bool              `db:"paid"`
+	Number            sql.NullString    `db:"number"`
+	IssuedAt          sql.NullTime      `db:"issued_at"`
+	CreatedBy         string            `db:"created_by"`
+	CreatedAt         time.Time         `db:"created_at"`
+	LastChangedBy     string            `db:"last_changed_by"`
+	LastUpdate        time.Time         `db:"last_update"`
+	TenantId          uuid.UUID         `db:"tenant_id"`
+	ItemSeqId         sql.NullInt64     `db:"item_seq"`
+	ItemAltId         uuid.UUID         `db:"item_alt_id"`
+	ItemName          sql.NullString    `db:"item_name"`
+	ItemDescription   sql.NullString    `db:"item_description"`
+	ItemUnitPrice     commons.NullMoney `db:"item_unit_price"`
+	ItemCurrency      sql.NullString    `db:"item_currency"`
+	ItemTaxCategory   sql.NullString    `db:"item_tax_category"`
+	LineUnitPrice     commons.NullMoney `db:"line_unit_price"`
+	LineQuantity      sql.NullInt64     `db:"line_quantity"`
+	LineTaxCategory   sql.NullString    `db:"line_tax_category"`
+	LineTaxRate       sql.NullString    `db:"line_tax_rate"`
+	LineDiscountType  sql.NullString    `db:"line_discount_type"`
+	LineDiscountValue sql.NullString    `db:"line_discount_value"`
+	ItemCreatedBy     sql.NullString    `db:"item_created_by"`
+	ItemCreatedAt     sql.NullTime      `db:"item_created_at"`
+	ItemLastChangedBy sql.NullString    `db:"item_last_changed_by"`
+	ItemLastUpdate    sql.NullTime      `db:"item_last_update"`
 }
 
+// CreateInvoiceRequest creates an invoice with the id Id, a new one when it is not given. Clients cannot choose the
+// id, it lets a caller retrying a creation tell whether an earlier attempt went through.
 type CreateInvoiceRequest struct {
-	UserId    uuid.UUID `json:"user_id"`
-	Paid      bool      `json:"paid"`
-	Total     float64   `json:"total"`
-	CreatedBy string    `json:"created_by"`
+	Id     uuid.UUID `json:"-"`
+	UserId uuid.UUID `json:"user_id"`
+	Paid   bool      `json:"paid"`
+	// Total only gives the currency of the invoice, the amount is worked out from the lines.
+	Total            commons.Money `json:"total"`
+	TaxJurisdiction  string        `json:"tax_jurisdiction"`
+	PricesIncludeTax bool          `json:"prices_include_tax"`
+	CreatedBy        string        `json:"created_by" audit:"actor"`
 }
 
+// UpdateInvoiceRequest has no total, the total is worked out from the lines and discounts whenever they change.
 type UpdateInvoiceRequest struct {
 	Id            uuid.UUID `json:"id"`
 	Paid          bool      `json:"paid"`
-	Total         float64   `json:"total"`
-	LastChangedBy string    `json:"last_changed_by"`
+	LastChangedBy string    `json:"last_changed_by" audit:"actor"`
 }
 
 type ItemsToInvoiceRequest struct {
-	InvoiceId uuid.UUID   `json:"invoice_id"`
-	Items     []uuid.UUID `json:"items"`
+	InvoiceId  uuid.UUID                        `json:"invoice_id"`
+	Items      []uuid.UUID                      `json:"items"`
+	Discounts  map[uuid.UUID]promotion.Discount `json:"discounts,omitempty"`
+	Quantities map[uuid.UUID]int64              `json:"quantities,omitempty"`
+}
+
+// IssueInvoiceRequest gives an invoice its number, issuing an invoice that already has one keeps its number.
+type IssueInvoiceRequest struct {
+	Id       uuid.UUID `json:"id"`
+	IssuedBy string    `json:"issued_by" audit:"actor"`
+}
+
+// InvoiceDiscountRequest takes a discount off the whole invoice, a fixed amount is in the invoice currency.
+type InvoiceDiscountRequest struct {
+	InvoiceId   uuid.UUID          `json:"invoice_id"`
+	Discount    promotion.Discount `json:"discount"`
+	Description string             `json:"description"`
+	CreatedBy   string             `json:"created_by" audit:"actor"`
+}
+
+type PromotionCodeRequest struct {
+	InvoiceId uuid.UUID `json:"invoice_id"`
+	Code      string    `json:"code"`
+	CreatedBy string    `json:"created_by" audit:"actor"`
+}
+
+type InvoiceDiscountRow struct {
+	Id            int64          `db:"id"`
+	AltId         uuid.UUID      `db:"alt_id"`
+	InvoiceId     uuid.UUID      `db:"invoice_id"`
+	PromotionId   uuid.NullUUID  `db:"promotion_id"`
+	Code          sql.NullString `db:"code"`
+	Description   string         `db:"description"`
+	DiscountType  string         `db:"discount_type"`
+	DiscountValue string         `db:"discount_value"`
+	CreatedBy     string         `db:"created_by"`
+	CreatedAt     time.Time      `db:"created_at"`
+	TenantId      uuid.UUID      `db:"tenant_id"`
 }
 
 type SimpleInvoiceItem struct {
@@ -65,6 +132,20 @@ type SimpleInvoiceItem struct {
 	ItemId    uuid.UUID `db:"item_id" json:"item_id"`
 }
 
+// InvoiceItemLine is an item attached to an invoice with its unit price converted into the invoice currency and
+// the tax rate that applied to it at the time.
+type InvoiceItemLine struct {
+	InvoiceId     uuid.UUID      `db:"invoice_id"`
+	ItemId        uuid.UUID      `db:"item_id"`
+	UnitPrice     commons.Money  `db:"unit_price"`
+	Quantity      int64          `db:"quantity"`
+	Currency      string         `db:"currency"`
+	TaxCategory   string         `db:"tax_category"`
+	TaxRate       string         `db:"tax_rate"`
+	DiscountType  sql.NullString `db:"discount_type"`
+	DiscountValue sql.NullString `db:"discount_value"`
+}
+
 type ItemsToInvoiceResponse struct {
 	InvoiceId uuid.UUID   `json:"invoice_id"`
 	Items     []uuid.UUID `json:"items"`
@@ -82,53 +163,187 @@ func (r *ItemsToInvoiceRequest) ToSimpleInvoiceItems() []SimpleInvoiceItem {
 	return items
 }
 
+// TotalFunc works the total of an invoice out from all its lines and discounts.
+type TotalFunc func(rows []InvoiceItemRow, discounts []InvoiceDiscountRow) (commons.Money, error)
+
 type InvoiceRepository interface {
-	CreateInvoice(request CreateInvoiceRequest) (InvoiceRow, error)
-	UpdateInvoice(request UpdateInvoiceRequest) (InvoiceRow, error)
-	DeleteInvoice(id uuid.UUID) (commons.DeleteResult, error)
-	AddItemsToInvoice(request ItemsToInvoiceRequest) (ItemsToInvoiceResponse, error)
-	RemoveItemFromInvoice(request SimpleInvoiceItem) (ItemsToInvoiceResponse, error)
-	GetInvoice(id uuid.UUID) (InvoiceRow, error)
-	GetInvoiceWithItems(id uuid.UUID) ([]InvoiceItemRow, error)
-	GetAll(pagination *commons.Pagination) ([]InvoiceRow, error)
-	GetAllForUser(userId uuid.UUID) ([]InvoiceRow, error)
+	CreateInvoice(ctx context.Context, request CreateInvoiceRequest, lines ...InvoiceItemLine) (InvoiceRow, error)
+	UpdateInvoice(ctx context.Context, request UpdateInvoiceRequest) (InvoiceRow, error)
+	DeleteInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error)
+	AddItemsToInvoice(ctx context.Context, id uuid.UUID, lines []InvoiceItemLine, total TotalFunc) (ItemsToInvoiceResponse, error)
+	RemoveItemFromInvoice(ctx context.Context, request SimpleInvoiceItem, total TotalFunc) (ItemsToInvoiceResponse, error)
+	GetInvoice(ctx context.Context, id uuid.UUID) (InvoiceRow, error)
+	GetInvoiceWithItems(ctx context.Context, id uuid.UUID) ([]InvoiceItemRow, error)
+	GetAll(ctx context.Context, pagination *commons.Pagination) ([]InvoiceRow, error)
+	GetAllForUser(ctx context.Context, userId uuid.UUID) ([]InvoiceRow, error)
+	GetAllForUsers(ctx context.Context, userIds []uuid.UUID) ([]InvoiceRow, error)
+	GetInvoicesWithItems(ctx context.Context, ids []uuid.UUID) ([]InvoiceItemRow, error)
+	GetDiscountsForInvoices(ctx context.Context, ids []uuid.UUID) ([]InvoiceDiscountRow, error)
+	// CorrectInvoiceTotal stores a total that was worked out again, with InvoiceTotalCorrected. The totals of issued
+	// invoices are not changed, ErrInvoiceIssued.
+	CorrectInvoiceTotal(ctx context.Context, id uuid.UUID, total commons.Money) (InvoiceRow, error)
+	GetInvoiceDiscounts(ctx context.Context, id uuid.UUID) ([]InvoiceDiscountRow, error)
+	AddInvoiceDiscount(ctx context.Context, discount InvoiceDiscountRow, total TotalFunc) (InvoiceDiscountRow, error)
+	IssueInvoice(ctx context.Context, request IssueInvoiceRequest, format NumberFormat, issuedAt time.Time) (InvoiceRow, error)
+	GetInvoiceByNumber(ctx context.Context, number string) (InvoiceRow, error)
 }
 
 type InvoiceRepositoryImpl struct {
-	db *sqlx.DB
+	db *commons.TenantDB
 }
 
 func NewInvoiceRepository(db *sqlx.DB) *InvoiceRepositoryImpl {
-	return &InvoiceRepositoryImpl{db: db}
+	return &InvoiceRepositoryImpl{db: commons.NewTenantDB(db)}
 }
 
 const (
-	CreateQuery                = `INSERT INTO invoices (user_id, total, paid, created_by) VALUES ($1, $2, $3, $4) RETURNING *`
-	UpdateQuery                = `UPDATE invoices SET total = $2, paid = $3, last_changed_by = $4 WHERE alt_id = $1 RETURNING *`
-	DeleteQuery                = `DELETE FROM invoices WHERE alt_id = $1`
-	AddItemsToInvoiceQuery     = `INSERT INTO invoices_items (invoice_id, item_id) VALUES (:invoice_id, :item_id)`
+	CreateQuery                = `INSERT INTO invoices (alt_id, user_id, total, currency, tax_jurisdiction, prices_include_tax, paid, created_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *`
+	UpdateQuery                = `UPDATE invoices SET paid = $2, last_changed_by = $3 WHERE alt_id = $1 RETURNING *`
+	UpdateTotalQuery           = `UPDATE invoices SET total = $2 WHERE alt_id = $1 RETURNING *`
+	DeleteQuery                = `DELETE FROM invoices WHERE alt_id = $1 AND number IS NULL`
+	AddItemsToInvoiceQuery     = `INSERT INTO invoices_items (invoice_id, item_id, unit_price, quantity, currency, tax_category, tax_rate, discount_type, discount_value) VALUES (:invoice_id, :item_id, :unit_price, :quantity, :currency, :tax_category, :tax_rate, :discount_type, :discount_value)`
 	RemoveItemFromInvoiceQuery = `DELETE FROM invoices_items WHERE invoice_id = $1 AND item_id = $2`
 	GetInvoiceQuery            = `SELECT * FROM invoices WHERE alt_id = $1`
-	GetInvoiceWithItemsQuery   = `SELECT i.*, i2.id as item_seq, i2.alt_id as item_alt_id, i2.name as item_name, description as item_description, i2.unit_price as item_unit_price, i2.created_by as item_created_by, i2.created_at as item_created_at, i2.last_changed_by as item_last_changed_by, i2.last_update as item_last_update  FROM invoices i FULL OUTER JOIN invoices_items ii ON i.alt_id = ii.invoice_id FULL OUTER JOIN public.items i2 on i2.alt_id = ii.item_id WHERE i.alt_id = $1`
+	GetInvoiceWithItemsQuery   = `SELECT i.*, i2.id as item_seq, i2.alt_id as item_alt_id, i2.name as item_name, description as item_description, i2.unit_price as item_unit_price, i2.currency as item_currency, i2.tax_category as item_tax_category, ii.unit_price as line_unit_price, ii.quantity as line_quantity, ii.tax_category as line_tax_category, ii.tax_rate as line_tax_rate, ii.discount_type as line_discount_type, ii.discount_value as line_discount_value, i2.created_by as item_created_by, i2.created_at as item_created_at, i2.last_changed_by as item_last_changed_by, i2.last_update as item_last_update  FROM invoices i FULL OUTER JOIN invoices_items ii ON i.alt_id = ii.invoice_id FULL OUTER JOIN public.items i2 on i2.alt_id = ii.item_id WHERE i.alt_id = $1`
+	GetInvoicesWithItemsQuery  = `SELECT i.*, i2.id as item_seq, i2.alt_id as item_alt_id, i2.name as item_name, description as item_description, i2.unit_price as item_unit_price, i2.currency as item_currency, i2.tax_category as item_tax_category, ii.unit_price as line_unit_price, ii.quantity as line_quantity, ii.tax_category as line_tax_category, ii.tax_rate as line_tax_rate, ii.discount_type as line_discount_type, ii.discount_value as line_discount_value, i2.created_by as item_created_by, i2.created_at as item_created_at, i2.last_changed_by as item_last_changed_by, i2.last_update as item_last_update  FROM invoices i FULL OUTER JOIN invoices_items ii ON i.alt_id = ii.invoice_id FULL OUTER JOIN public.items i2 on i2.alt_id = ii.item_id WHERE i.alt_id = ANY($1) ORDER BY i.id`
 	GetAllQuery                = `SELECT * FROM invoices`
-	GetAllWithPaginationQuery  = `SELECT * FROM invoices WHERE id > $1 LIMIT $2`
+	GetAllWithPaginationQuery  = `SELECT * FROM invoices WHERE id > $1 ORDER BY id LIMIT $2`
 	GetAllForUserQuery         = `SELECT * FROM invoices WHERE user_id = $1`
+	GetAllForUsersQuery        = `SELECT * FROM invoices WHERE user_id = ANY($1) ORDER BY id`
+	GetInvoiceDiscountsQuery   = `SELECT * FROM invoice_discounts WHERE invoice_id = $1 ORDER BY id`
+	DiscountsForInvoicesQuery  = `SELECT * FROM invoice_discounts WHERE invoice_id = ANY($1) ORDER BY id`
+	AddInvoiceDiscountQuery    = `INSERT INTO invoice_discounts (invoice_id, promotion_id, code, description, discount_type, discount_value, created_by) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *`
+	RedeemPromotionQuery       = `UPDATE promotion_codes SET times_used = times_used + 1 WHERE alt_id = $1 AND (max_uses IS NULL OR times_used < max_uses)`
+	LockInvoiceQuery           = `SELECT * FROM invoices WHERE alt_id = $1 FOR UPDATE`
+	NextInvoiceNumberQuery     = `INSERT INTO invoice_number_series (series, last_value) VALUES ($1, 1) ON CONFLICT (tenant_id, series) DO UPDATE SET last_value = invoice_number_series.last_value + 1 RETURNING last_value`
+	IssueQuery                 = `UPDATE invoices SET number = $2, issued_at = $3, last_changed_by = $4 WHERE alt_id = $1 RETURNING *`
+	GetInvoiceByNumberQuery    = `SELECT * FROM invoices WHERE number = $1`
 )
 
-func (r *InvoiceRepositoryImpl) CreateInvoice(request CreateInvoiceRequest) (InvoiceRow, error) {
+// CreateInvoice writes the invoice with its lines, which have to be of the id of the request, and InvoiceCreated (and
+// InvoicePaid and InvoiceItemsAdded) to the outbox in one transaction.
+func (r *InvoiceRepositoryImpl) CreateInvoice(ctx context.Context, request CreateInvoiceRequest, lines ...InvoiceItemLine) (InvoiceRow, error) {
+	defer metrics.ObserveQuery("invoice", "CreateInvoice")()
+	if request.Id == uuid.Nil {
+		request.Id = uuid.New()
+	}
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return InvoiceRow{}, err
+	}
+	defer tx.Rollback()
 	var results = InvoiceRow{}
-	err := r.db.Get(&results, CreateQuery, request.UserId, request.Total, request.Paid, request.CreatedBy)
-	return results, err
+	err = tx.GetContext(tracing.Statement(ctx, "CreateQuery"), &results, CreateQuery, request.Id, request.UserId, request.Total, request.Total.Currency, request.TaxJurisdiction, request.PricesIncludeTax, request.Paid, request.CreatedBy)
+	if err != nil {
+		return InvoiceRow{}, err
+	}
+	events, err := createdEvents(results)
+	if err != nil {
+		return InvoiceRow{}, err
+	}
+	if len(lines) > 0 {
+		if _, err = tx.NamedExecContext(tracing.Statement(ctx, "AddItemsToInvoiceQuery"), AddItemsToInvoiceQuery, lines); err != nil {
+			return InvoiceRow{}, err
+		}
+		added, err := itemsAddedEvent(lines)
+		if err != nil {
+			return InvoiceRow{}, err
+		}
+		events = append(events, added)
+	}
+	if err = outbox.Append(ctx, tx, events...); err != nil {
+		return InvoiceRow{}, err
+	}
+	return results, tx.Commit()
 }
 
-func (r *InvoiceRepositoryImpl) UpdateInvoice(request UpdateInvoiceRequest) (InvoiceRow, error) {
+// UpdateInvoice locks the invoice to tell whether this update is the one marking it as paid, writing InvoicePaid to
+// the outbox in the same transaction if so.
+func (r *InvoiceRepositoryImpl) UpdateInvoice(ctx context.Context, request UpdateInvoiceRequest) (InvoiceRow, error) {
+	defer metrics.ObserveQuery("invoice", "UpdateInvoice")()
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return InvoiceRow{}, err
+	}
+	defer tx.Rollback()
+	var before = InvoiceRow{}
+	if err = tx.GetContext(tracing.Statement(ctx, "LockInvoiceQuery"), &before, LockInvoiceQuery, request.Id); err != nil {
+		return InvoiceRow{}, err
+	}
 	var results = InvoiceRow{}
-	err := r.db.Get(&results, UpdateQuery, request.Id, request.Total, request.Paid, request.LastChangedBy)
-	return results, err
+	if err = tx.GetContext(tracing.Statement(ctx, "UpdateQuery"), &results, UpdateQuery, request.Id, request.Paid, request.LastChangedBy); err != nil {
+		return InvoiceRow{}, err
+	}
+	events, err := paidEvents(before, results)
+	if err != nil {
+		return InvoiceRow{}, err
+	}
+	if err = outbox.Append(ctx, tx, events...); err != nil {
+		return InvoiceRow{}, err
+	}
+	return results, tx.Commit()
+}
+
+func (r *InvoiceRepositoryImpl) CorrectInvoiceTotal(ctx context.Context, id uuid.UUID, total commons.Money) (InvoiceRow, error) {
+	defer metrics.ObserveQuery("invoice", "CorrectInvoiceTotal")()
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return InvoiceRow{}, err
+	}
+	defer tx.Rollback()
+	before, err := lockDraft(ctx, tx, id)
+	if err != nil {
+		return InvoiceRow{}, err
+	}
+	var results = InvoiceRow{}
+	if err = tx.GetContext(tracing.Statement(ctx, "UpdateTotalQuery"), &results, UpdateTotalQuery, id, total); err != nil {
+		return InvoiceRow{}, err
+	}
+	event, err := totalCorrectedEvent(before, results)
+	if err != nil {
+		return InvoiceRow{}, err
+	}
+	if err = outbox.Append(ctx, tx, event); err != nil {
+		return InvoiceRow{}, err
+	}
+	return results, tx.Commit()
 }
 
-func (r *InvoiceRepositoryImpl) DeleteInvoice(id uuid.UUID) (commons.DeleteResult, error) {
-	result, err := r.db.Exec(DeleteQuery, id)
+// lockDraft locks the invoice until the end of the transaction. Issued invoices must not change anymore, they are
+// ErrInvoiceIssued.
+func lockDraft(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (InvoiceRow, error) {
+	var locked InvoiceRow
+	if err := tx.GetContext(tracing.Statement(ctx, "LockInvoiceQuery"), &locked, LockInvoiceQuery, id); err != nil {
+		return InvoiceRow{}, err
+	}
+	if locked.Number.Valid {
+		return InvoiceRow{}, ErrInvoiceIssued
+	}
+	return locked, nil
+}
+
+// storeTotal reads back all lines and discounts of the locked invoice and stores the total worked out from them.
+func storeTotal(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, total TotalFunc) error {
+	var rows []InvoiceItemRow
+	if err := tx.SelectContext(tracing.Statement(ctx, "GetInvoiceWithItemsQuery"), &rows, GetInvoiceWithItemsQuery, id); err != nil {
+		return err
+	}
+	var discounts []InvoiceDiscountRow
+	if err := tx.SelectContext(tracing.Statement(ctx, "GetInvoiceDiscountsQuery"), &discounts, GetInvoiceDiscountsQuery, id); err != nil {
+		return err
+	}
+	amount, err := total(rows, discounts)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(tracing.Statement(ctx, "UpdateTotalQuery"), UpdateTotalQuery, id, amount)
+	return err
+}
+
+// DeleteInvoice only deletes invoices that have not been issued, issued numbers must stay gap-free.
+func (r *InvoiceRepositoryImpl) DeleteInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	defer metrics.ObserveQuery("invoice", "DeleteInvoice")()
+	result, err := r.db.ExecContext(tracing.Statement(ctx, "DeleteQuery"), DeleteQuery, id)
 	if err != nil {
 		return commons.DeleteResult{}, err
 	}
@@ -139,26 +354,66 @@ func (r *InvoiceRepositoryImpl) DeleteInvoice(id uuid.UUID) (commons.DeleteResul
 	}, nil
 }
 
-func (r *InvoiceRepositoryImpl) AddItemsToInvoice(request ItemsToInvoiceRequest) (ItemsToInvoiceResponse, error) {
-	items := request.ToSimpleInvoiceItems()
-	_, err := r.db.NamedExec(AddItemsToInvoiceQuery, items)
+// AddItemsToInvoice writes the lines, the total works out from all lines of the invoice and InvoiceItemsAdded to the
+// outbox in one transaction. The invoice stays locked until then, so concurrent changes cannot leave a stale total.
+func (r *InvoiceRepositoryImpl) AddItemsToInvoice(ctx context.Context, id uuid.UUID, lines []InvoiceItemLine, total TotalFunc) (ItemsToInvoiceResponse, error) {
+	defer metrics.ObserveQuery("invoice", "AddItemsToInvoice")()
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return ItemsToInvoiceResponse{}, err
-	} else {
-		return ItemsToInvoiceResponse{
-			InvoiceId: request.InvoiceId,
-			Items:     request.Items,
-			Success:   true,
-		}, nil
 	}
+	defer tx.Rollback()
+	if _, err = lockDraft(ctx, tx, id); err != nil {
+		return ItemsToInvoiceResponse{}, err
+	}
+	if _, err = tx.NamedExecContext(tracing.Statement(ctx, "AddItemsToInvoiceQuery"), AddItemsToInvoiceQuery, lines); err != nil {
+		return ItemsToInvoiceResponse{}, err
+	}
+	if err = storeTotal(ctx, tx, id, total); err != nil {
+		return ItemsToInvoiceResponse{}, err
+	}
+	event, err := itemsAddedEvent(lines)
+	if err != nil {
+		return ItemsToInvoiceResponse{}, err
+	}
+	if err = outbox.Append(ctx, tx, event); err != nil {
+		return ItemsToInvoiceResponse{}, err
+	}
+	if err = tx.Commit(); err != nil {
+		return ItemsToInvoiceResponse{}, err
+	}
+	response := ItemsToInvoiceResponse{InvoiceId: id, Success: true}
+	for _, line := range lines {
+		response.Items = append(response.Items, line.ItemId)
+	}
+	return response, nil
 }
 
-func (r *InvoiceRepositoryImpl) RemoveItemFromInvoice(request SimpleInvoiceItem) (ItemsToInvoiceResponse, error) {
-	result, err := r.db.Exec(RemoveItemFromInvoiceQuery, request.InvoiceId, request.ItemId)
+// RemoveItemFromInvoice removes the line from the invoice, as long as it has not been issued, and stores the total
+// of the remaining lines in the same transaction.
+func (r *InvoiceRepositoryImpl) RemoveItemFromInvoice(ctx context.Context, request SimpleInvoiceItem, total TotalFunc) (ItemsToInvoiceResponse, error) {
+	defer metrics.ObserveQuery("invoice", "RemoveItemFromInvoice")()
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return ItemsToInvoiceResponse{}, err
+	}
+	defer tx.Rollback()
+	if _, err = lockDraft(ctx, tx, request.InvoiceId); err != nil {
+		return ItemsToInvoiceResponse{}, err
+	}
+	result, err := tx.ExecContext(tracing.Statement(ctx, "RemoveItemFromInvoiceQuery"), RemoveItemFromInvoiceQuery, request.InvoiceId, request.ItemId)
 	if err != nil {
 		return ItemsToInvoiceResponse{}, err
 	}
 	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected > 0 {
+		if err = storeTotal(ctx, tx, request.InvoiceId, total); err != nil {
+			return ItemsToInvoiceResponse{}, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return ItemsToInvoiceResponse{}, err
+	}
 	return ItemsToInvoiceResponse{
 		InvoiceId: request.InvoiceId,
 		Items:     []uuid.UUID{request.ItemId},
@@ -166,31 +421,134 @@ func (r *InvoiceRepositoryImpl) RemoveItemFromInvoice(request SimpleInvoiceItem)
 	}, nil
 }
 
-func (r *InvoiceRepositoryImpl) GetInvoice(id uuid.UUID) (InvoiceRow, error) {
+func (r *InvoiceRepositoryImpl) GetInvoice(ctx context.Context, id uuid.UUID) (InvoiceRow, error) {
+	defer metrics.ObserveQuery("invoice", "GetInvoice")()
 	var results = InvoiceRow{}
-	err := r.db.Get(&results, GetInvoiceQuery, id)
+	err := r.db.GetContext(tracing.Statement(ctx, "GetInvoiceQuery"), &results, GetInvoiceQuery, id)
 	return results, err
 }
 
-func (r *InvoiceRepositoryImpl) GetInvoiceWithItems(id uuid.UUID) ([]InvoiceItemRow, error) {
+func (r *InvoiceRepositoryImpl) GetInvoiceWithItems(ctx context.Context, id uuid.UUID) ([]InvoiceItemRow, error) {
+	defer metrics.ObserveQuery("invoice", "GetInvoiceWithItems")()
 	var results []InvoiceItemRow
-	err := r.db.Select(&results, GetInvoiceWithItemsQuery, id)
+	err := r.db.SelectContext(tracing.Statement(ctx, "GetInvoiceWithItemsQuery"), &results, GetInvoiceWithItemsQuery, id)
 	return results, err
 }
 
-func (r *InvoiceRepositoryImpl) GetAll(pagination *commons.Pagination) ([]InvoiceRow, error) {
+func (r *InvoiceRepositoryImpl) GetAll(ctx context.Context, pagination *commons.Pagination) ([]InvoiceRow, error) {
+	defer metrics.ObserveQuery("invoice", "GetAll")()
 	var results []InvoiceRow
 	var err error
 	if pagination == nil {
-		err = r.db.Select(&results, GetAllQuery)
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetAllQuery"), &results, GetAllQuery)
 	} else {
-		err = r.db.Select(&results, GetAllWithPaginationQuery, pagination.LastId, pagination.PageSize)
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetAllWithPaginationQuery"), &results, GetAllWithPaginationQuery, pagination.LastId, pagination.PageSize)
 	}
 	return results, err
 }
 
-func (r *InvoiceRepositoryImpl) GetAllForUser(userId uuid.UUID) ([]InvoiceRow, error) {
+func (r *InvoiceRepositoryImpl) GetAllForUser(ctx context.Context, userId uuid.UUID) ([]InvoiceRow, error) {
+	defer metrics.ObserveQuery("invoice", "GetAllForUser")()
+	var results []InvoiceRow
+	err := r.db.SelectContext(tracing.Statement(ctx, "GetAllForUserQuery"), &results, GetAllForUserQuery, userId)
+	return results, err
+}
+
+// GetAllForUsers reads the invoices of many users in a single query.
+func (r *InvoiceRepositoryImpl) GetAllForUsers(ctx context.Context, userIds []uuid.UUID) ([]InvoiceRow, error) {
+	defer metrics.ObserveQuery("invoice", "GetAllForUsers")()
 	var results []InvoiceRow
-	err := r.db.Select(&results, GetAllForUserQuery, userId)
+	err := r.db.SelectContext(tracing.Statement(ctx, "GetAllForUsersQuery"), &results, GetAllForUsersQuery, userIds)
+	return results, err
+}
+
+// GetInvoicesWithItems is GetInvoiceWithItems for many invoices in a single query, the rows of an invoice come one
+// after the other.
+func (r *InvoiceRepositoryImpl) GetInvoicesWithItems(ctx context.Context, ids []uuid.UUID) ([]InvoiceItemRow, error) {
+	defer metrics.ObserveQuery("invoice", "GetInvoicesWithItems")()
+	var results []InvoiceItemRow
+	err := r.db.SelectContext(tracing.Statement(ctx, "GetInvoicesWithItemsQuery"), &results, GetInvoicesWithItemsQuery, ids)
+	return results, err
+}
+
+func (r *InvoiceRepositoryImpl) GetDiscountsForInvoices(ctx context.Context, ids []uuid.UUID) ([]InvoiceDiscountRow, error) {
+	defer metrics.ObserveQuery("invoice", "GetDiscountsForInvoices")()
+	var results []InvoiceDiscountRow
+	err := r.db.SelectContext(tracing.Statement(ctx, "DiscountsForInvoicesQuery"), &results, DiscountsForInvoicesQuery, ids)
+	return results, err
+}
+
+func (r *InvoiceRepositoryImpl) GetInvoiceDiscounts(ctx context.Context, id uuid.UUID) ([]InvoiceDiscountRow, error) {
+	defer metrics.ObserveQuery("invoice", "GetInvoiceDiscounts")()
+	var results []InvoiceDiscountRow
+	err := r.db.SelectContext(tracing.Statement(ctx, "GetInvoiceDiscountsQuery"), &results, GetInvoiceDiscountsQuery, id)
+	return results, err
+}
+
+// AddInvoiceDiscount stores the discount, redeeming its promotion code (if any) in the same transaction so a code
+// is never used more often than allowed, and the total worked out with the discount. Issued invoices get no more
+// discounts.
+func (r *InvoiceRepositoryImpl) AddInvoiceDiscount(ctx context.Context, discount InvoiceDiscountRow, total TotalFunc) (InvoiceDiscountRow, error) {
+	defer metrics.ObserveQuery("invoice", "AddInvoiceDiscount")()
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return InvoiceDiscountRow{}, err
+	}
+	defer tx.Rollback()
+	if _, err = lockDraft(ctx, tx, discount.InvoiceId); err != nil {
+		return InvoiceDiscountRow{}, err
+	}
+	if discount.PromotionId.Valid {
+		result, err := tx.ExecContext(tracing.Statement(ctx, "RedeemPromotionQuery"), RedeemPromotionQuery, discount.PromotionId.UUID)
+		if err != nil {
+			return InvoiceDiscountRow{}, err
+		}
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+			return InvoiceDiscountRow{}, promotion.ErrPromotionExhausted
+		}
+	}
+	var results = InvoiceDiscountRow{}
+	err = tx.GetContext(tracing.Statement(ctx, "AddInvoiceDiscountQuery"), &results, AddInvoiceDiscountQuery, discount.InvoiceId, discount.PromotionId, discount.Code, discount.Description,
+		discount.DiscountType, discount.DiscountValue, discount.CreatedBy)
+	if err != nil {
+		return InvoiceDiscountRow{}, err
+	}
+	if err = storeTotal(ctx, tx, discount.InvoiceId, total); err != nil {
+		return InvoiceDiscountRow{}, err
+	}
+	return results, tx.Commit()
+}
+
+// IssueInvoice allocates the next number of the series in the same transaction that stores it on the invoice. The
+// series row stays locked until commit, so concurrent issues queue up and a rollback leaves no gap.
+func (r *InvoiceRepositoryImpl) IssueInvoice(ctx context.Context, request IssueInvoiceRequest, format NumberFormat, issuedAt time.Time) (InvoiceRow, error) {
+	defer metrics.ObserveQuery("invoice", "IssueInvoice")()
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return InvoiceRow{}, err
+	}
+	defer tx.Rollback()
+	var results = InvoiceRow{}
+	if err = tx.GetContext(tracing.Statement(ctx, "LockInvoiceQuery"), &results, LockInvoiceQuery, request.Id); err != nil {
+		return InvoiceRow{}, err
+	}
+	if results.Number.Valid {
+		return results, tx.Commit()
+	}
+	var seq int64
+	if err = tx.GetContext(tracing.Statement(ctx, "NextInvoiceNumberQuery"), &seq, NextInvoiceNumberQuery, format.Series(issuedAt)); err != nil {
+		return InvoiceRow{}, err
+	}
+	err = tx.GetContext(tracing.Statement(ctx, "IssueQuery"), &results, IssueQuery, request.Id, format.Format(issuedAt, seq), issuedAt, request.IssuedBy)
+	if err != nil {
+		return InvoiceRow{}, err
+	}
+	return results, tx.Commit()
+}
+
+func (r *InvoiceRepositoryImpl) GetInvoiceByNumber(ctx context.Context, number string) (InvoiceRow, error) {
+	defer metrics.ObserveQuery("invoice", "GetInvoiceByNumber")()
+	var results = InvoiceRow{}
+	err := r.db.GetContext(tracing.Statement(ctx, "GetInvoiceByNumberQuery"), &results, GetInvoiceByNumberQuery, number)
 	return results, err
 }
@@ -1,6 +1,8 @@
 package invoice
 
 import (
+	"context"
+	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
@@ -8,6 +10,9 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"inventory-service-go/commons"
+	"inventory-service-go/outbox"
+	"inventory-service-go/promotion"
+	"regexp"
 	"testing"
 	"time"
 )
@@ -30,7 +35,7 @@ func TestInvoiceRepositoryImpl_CreateInvoice(t *testing.T) {
 			request: CreateInvoiceRequest{
 				UserId:    uuid.New(),
 				Paid:      true,
-				Total:     123.45,
+				Total:     commons.NewMoney(12345, "USD"),
 				CreatedBy: "test_user",
 			},
 			rows: sqlmock.NewRows([]string{"id", "alt_id", "user_id", "paid", "total", "created_by", "created_at", "last_update", "last_changed_by"}).
@@ -42,7 +47,7 @@ func TestInvoiceRepositoryImpl_CreateInvoice(t *testing.T) {
 			request: CreateInvoiceRequest{
 				UserId:    uuid.New(),
 				Paid:      false,
-				Total:     0.0,
+				Total:     commons.NewMoney(0, "USD"),
 				CreatedBy: "test_user",
 			},
 			rows:    nil,
@@ -51,19 +56,27 @@ func TestInvoiceRepositoryImpl_CreateInvoice(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			mock.ExpectBegin()
 			if tc.wantErr && tc.rows == nil {
 				mock.ExpectQuery("INSERT INTO invoices").
-					WithArgs(tc.request.UserId, tc.request.Total, tc.request.Paid, tc.request.CreatedBy).
+					WithArgs(sqlmock.AnyArg(), tc.request.UserId, tc.request.Total, tc.request.Total.Currency, tc.request.TaxJurisdiction, tc.request.PricesIncludeTax, tc.request.Paid, tc.request.CreatedBy).
 					WillReturnError(errors.New("error"))
+				mock.ExpectRollback()
 			} else {
 				mock.ExpectQuery("INSERT INTO invoices").
-					WithArgs(tc.request.UserId, tc.request.Total, tc.request.Paid, tc.request.CreatedBy).
+					WithArgs(sqlmock.AnyArg(), tc.request.UserId, tc.request.Total, tc.request.Total.Currency, tc.request.TaxJurisdiction, tc.request.PricesIncludeTax, tc.request.Paid, tc.request.CreatedBy).
 					WillReturnRows(tc.rows)
+				for _, eventType := range []string{outbox.InvoiceCreated, outbox.InvoicePaid} {
+					mock.ExpectExec("INSERT INTO outbox").
+						WithArgs(sqlmock.AnyArg(), eventType, "invoice", newUuid, sqlmock.AnyArg(), sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+				}
+				mock.ExpectCommit()
 			}
 
 			r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
 
-			results, err := r.CreateInvoice(tc.request)
+			results, err := r.CreateInvoice(context.Background(), tc.request)
 			if tc.wantErr {
 				assert.NotNil(t, err)
 			} else {
@@ -72,10 +85,40 @@ func TestInvoiceRepositoryImpl_CreateInvoice(t *testing.T) {
 				assert.Equal(t, results.Id, int64(1))
 				assert.Equal(t, results.AltId, newUuid)
 			}
+			assert.Nil(t, mock.ExpectationsWereMet())
 		})
 	}
 }
 
+func TestInvoiceRepositoryImpl_CreateInvoice_WithLines(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	request := CreateInvoiceRequest{Id: uuid.New(), UserId: uuid.New(), Total: commons.NewMoney(1070, "EUR"), CreatedBy: "test_user"}
+	line := InvoiceItemLine{InvoiceId: request.Id, ItemId: uuid.New(), UnitPrice: commons.NewMoney(1000, "EUR"), Quantity: 1, Currency: "EUR", TaxCategory: "reduced", TaxRate: "7"}
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO invoices").
+		WithArgs(request.Id, request.UserId, request.Total, "EUR", "", false, false, "test_user").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "alt_id", "total", "currency"}).AddRow(1, request.Id, "10.70", "EUR"))
+	mock.ExpectExec("INSERT INTO invoices_items").
+		WithArgs(request.Id, line.ItemId, "10.00", 1, "EUR", "reduced", "7", nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	for _, eventType := range []string{outbox.InvoiceCreated, outbox.InvoiceItemsAdded} {
+		mock.ExpectExec("INSERT INTO outbox").
+			WithArgs(sqlmock.AnyArg(), eventType, "invoice", request.Id, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectCommit()
+
+	r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
+
+	row, err := r.CreateInvoice(context.Background(), request, line)
+	assert.Nil(t, err)
+	assert.Equal(t, request.Id, row.AltId)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
 func TestInvoiceRepositoryImpl_UpdateInvoice(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	now := time.Now()
@@ -94,7 +137,6 @@ func TestInvoiceRepositoryImpl_UpdateInvoice(t *testing.T) {
 			request: UpdateInvoiceRequest{
 				Id:            newUuid,
 				Paid:          true,
-				Total:         123.45,
 				LastChangedBy: "updated_user",
 			},
 			rows: sqlmock.NewRows([]string{"id", "alt_id", "user_id", "paid", "total", "created_by", "created_at", "last_update", "last_changed_by"}).
@@ -106,7 +148,6 @@ func TestInvoiceRepositoryImpl_UpdateInvoice(t *testing.T) {
 			request: UpdateInvoiceRequest{
 				Id:            newUuid,
 				Paid:          false,
-				Total:         0.0,
 				LastChangedBy: "update_failed_user",
 			},
 			rows:    nil,
@@ -115,19 +156,28 @@ func TestInvoiceRepositoryImpl_UpdateInvoice(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT \\* FROM invoices WHERE alt_id = \\$1 FOR UPDATE").
+				WithArgs(tc.request.Id).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "alt_id", "paid", "total"}).AddRow(1, newUuid, false, 123.45))
 			if tc.wantErr && tc.rows == nil {
 				mock.ExpectQuery("UPDATE invoices").
-					WithArgs(tc.request.Id, tc.request.Total, tc.request.Paid, tc.request.LastChangedBy).
+					WithArgs(tc.request.Id, tc.request.Paid, tc.request.LastChangedBy).
 					WillReturnError(errors.New("error"))
+				mock.ExpectRollback()
 			} else {
 				mock.ExpectQuery("UPDATE invoices").
-					WithArgs(tc.request.Id, tc.request.Total, tc.request.Paid, tc.request.LastChangedBy).
+					WithArgs(tc.request.Id, tc.request.Paid, tc.request.LastChangedBy).
 					WillReturnRows(tc.rows)
+				mock.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), outbox.InvoicePaid, "invoice", newUuid, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			}
 
 			r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
 
-			results, err := r.UpdateInvoice(tc.request)
+			results, err := r.UpdateInvoice(context.Background(), tc.request)
 			if tc.wantErr {
 				assert.NotNil(t, err)
 			} else {
@@ -136,10 +186,23 @@ func TestInvoiceRepositoryImpl_UpdateInvoice(t *testing.T) {
 				assert.Equal(t, results.Id, int64(1))
 				assert.Equal(t, results.AltId, newUuid)
 			}
+			assert.Nil(t, mock.ExpectationsWereMet())
 		})
 	}
 }
 
+// expectStoreTotal expects the lines (as many as given) and no discounts of the invoice to be read back and the total
+// to be stored.
+func expectStoreTotal(mock sqlmock.Sqlmock, id uuid.UUID, total commons.Money, lines int) {
+	rows := sqlmock.NewRows([]string{"id", "alt_id", "item_seq"})
+	for i := range lines {
+		rows.AddRow(1, id, i+1)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(GetInvoiceWithItemsQuery)).WithArgs(id).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(GetInvoiceDiscountsQuery)).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"id", "alt_id"}))
+	mock.ExpectExec(regexp.QuoteMeta(UpdateTotalQuery)).WithArgs(id, total).WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
 func TestInvoiceRepositoryImpl_AddItemsToInvoice(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	invoiceId := uuid.New()
@@ -148,76 +211,93 @@ func TestInvoiceRepositoryImpl_AddItemsToInvoice(t *testing.T) {
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
+	lines := []InvoiceItemLine{
+		{
+			InvoiceId:   invoiceId,
+			ItemId:      itemId1,
+			UnitPrice:   commons.NewMoney(1000, "EUR"),
+			Quantity:    1,
+			Currency:    "EUR",
+			TaxCategory: "standard",
+			TaxRate:     "20",
+		},
+		{
+			InvoiceId:     invoiceId,
+			ItemId:        itemId2,
+			UnitPrice:     commons.NewMoney(2550, "EUR"),
+			Quantity:      3,
+			Currency:      "EUR",
+			TaxCategory:   "reduced",
+			TaxRate:       "5",
+			DiscountType:  sql.NullString{String: "fixed", Valid: true},
+			DiscountValue: sql.NullString{String: "2.50", Valid: true},
+		},
+	}
 	testCases := []struct {
 		name    string
-		request ItemsToInvoiceRequest
-		items   []SimpleInvoiceItem
-		rows    *sqlmock.Rows
+		lines   []InvoiceItemLine
+		number  any
 		wantErr bool
 	}{
 		{
-			name: "Successful Items Addition",
-			request: ItemsToInvoiceRequest{
-				InvoiceId: invoiceId,
-				Items:     []uuid.UUID{itemId1, itemId2},
-			},
-			items: []SimpleInvoiceItem{
-				{
-					InvoiceId: invoiceId,
-					ItemId:    itemId1,
-				},
-				{
-					InvoiceId: invoiceId,
-					ItemId:    itemId2,
-				},
-			},
-			rows: sqlmock.NewRows([]string{"invoice_id", "item_id"}).
-				AddRow(invoiceId, itemId1).
-				AddRow(invoiceId, itemId2),
+			name:    "Successful Items Addition",
+			lines:   lines,
 			wantErr: false,
 		},
 		{
-			name: "Failed Items Addition",
-			request: ItemsToInvoiceRequest{
-				InvoiceId: uuid.New(),
-				Items:     []uuid.UUID{uuid.New(), uuid.New()},
-			},
-			items: []SimpleInvoiceItem{
-				{
-					InvoiceId: invoiceId,
-					ItemId:    itemId1,
-				},
-				{
-					InvoiceId: invoiceId,
-					ItemId:    itemId2,
-				},
-			},
-			rows:    nil,
+			name:    "Failed Items Addition",
+			lines:   lines,
+			wantErr: true,
+		},
+		{
+			name:    "Issued Invoice",
+			lines:   lines,
+			number:  "INV-2026-000001",
 			wantErr: true,
 		},
 	}
+	total := commons.NewMoney(8910, "EUR")
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			if tc.wantErr {
-				mock.ExpectExec("INSERT INTO invoices_items").
-					WithArgs(tc.items).
-					WillReturnError(errors.New("error"))
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT \\* FROM invoices WHERE alt_id = \\$1 FOR UPDATE").
+				WithArgs(invoiceId).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "alt_id", "number"}).AddRow(1, invoiceId, tc.number))
+			if tc.number != nil {
+				mock.ExpectRollback()
 			} else {
-				mock.ExpectExec("INSERT INTO invoices_items").
-					WithArgs(tc.items[0].InvoiceId, tc.items[0].ItemId, tc.items[1].InvoiceId, tc.items[1].ItemId).
-					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectation := mock.ExpectExec("INSERT INTO invoices_items").
+					WithArgs(invoiceId, itemId1, "10.00", 1, "EUR", "standard", "20", nil, nil, invoiceId, itemId2, "25.50", 3, "EUR", "reduced", "5", "fixed", "2.50")
+				if tc.wantErr {
+					expectation.WillReturnError(errors.New("error"))
+					mock.ExpectRollback()
+				} else {
+					expectation.WillReturnResult(sqlmock.NewResult(1, 2))
+					expectStoreTotal(mock, invoiceId, total, 2)
+					mock.ExpectExec("INSERT INTO outbox").
+						WithArgs(sqlmock.AnyArg(), outbox.InvoiceItemsAdded, "invoice", invoiceId, sqlmock.AnyArg(), sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectCommit()
+				}
 			}
 
 			r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
 
-			results, err := r.AddItemsToInvoice(tc.request)
-			if tc.wantErr {
+			results, err := r.AddItemsToInvoice(context.Background(), invoiceId, tc.lines, func(rows []InvoiceItemRow, discounts []InvoiceDiscountRow) (commons.Money, error) {
+				assert.Len(t, rows, 2)
+				assert.Empty(t, discounts)
+				return total, nil
+			})
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if tc.number != nil {
+				assert.ErrorIs(t, err, ErrInvoiceIssued)
+			} else if tc.wantErr {
 				assert.NotNil(t, err)
 			} else {
 				assert.Nil(t, err)
 				assert.NotNil(t, results)
-				assert.Equal(t, results.InvoiceId, tc.request.InvoiceId)
-				assert.Equal(t, results.Items, tc.request.Items)
+				assert.Equal(t, invoiceId, results.InvoiceId)
+				assert.Equal(t, []uuid.UUID{itemId1, itemId2}, results.Items)
 				assert.True(t, results.Success)
 			}
 		})
@@ -264,7 +344,7 @@ func TestInvoiceRepositoryImpl_GetInvoice(t *testing.T) {
 
 			r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
 
-			result, err := r.GetInvoice(tc.id)
+			result, err := r.GetInvoice(context.Background(), tc.id)
 			if tc.wantErr {
 				assert.NotNil(t, err)
 			} else {
@@ -293,9 +373,9 @@ func TestInvoiceRepositoryImpl_GetInvoiceWithItems(t *testing.T) {
 		{
 			name: "Successful Getting invoice with items",
 			id:   invoiceId,
-			rows: sqlmock.NewRows([]string{"id", "alt_id", "user_id", "total", "paid", "created_by", "created_at", "last_changed_by", "last_update", "item_seq", "item_name", "item_description", "item_unit_price", "item_created_by", "item_created_at", "item_last_changed_by", "item_last_update"}).
-				AddRow(1, invoiceId, userId, 100.0, true, "unit_test", now, "unit_test", now, 1, "Item1", "Item 1", 12.34, "unit_test", now, "unit_test", now).
-				AddRow(1, invoiceId, userId, 100.0, true, "unit_test", now, "unit_test", now, 2, "Item2", "Item 2", 56.78, "unit_test", now, "unit_test", now),
+			rows: sqlmock.NewRows([]string{"id", "alt_id", "user_id", "total", "currency", "paid", "created_by", "created_at", "last_changed_by", "last_update", "item_seq", "item_name", "item_description", "item_unit_price", "item_currency", "item_tax_category", "line_unit_price", "line_quantity", "line_tax_category", "line_tax_rate", "line_discount_type", "line_discount_value", "item_created_by", "item_created_at", "item_last_changed_by", "item_last_update"}).
+				AddRow(1, invoiceId, userId, 100.0, "EUR", true, "unit_test", now, "unit_test", now, 1, "Item1", "Item 1", 12.34, "EUR", "standard", 12.34, 1, "standard", "19.0000", nil, nil, "unit_test", now, "unit_test", now).
+				AddRow(1, invoiceId, userId, 100.0, "EUR", true, "unit_test", now, "unit_test", now, 2, "Item2", "Item 2", 56.78, "USD", "reduced", 52.24, 3, "reduced", "7.0000", "percentage", "10", "unit_test", now, "unit_test", now),
 			wantErr: false,
 		},
 		{
@@ -312,14 +392,14 @@ func TestInvoiceRepositoryImpl_GetInvoiceWithItems(t *testing.T) {
 					WithArgs(tc.id).
 					WillReturnError(errors.New("error"))
 			} else {
-				mock.ExpectQuery("SELECT i.*, i2.id as item_seq, i2.alt_id as item_alt_id, i2.name as item_name, description as item_description, i2.unit_price as item_unit_price, i2.created_by as item_created_by, i2.created_at as item_created_at, i2.last_changed_by as item_last_changed_by, i2.last_update as item_last_update FROM invoices i FULL OUTER JOIN invoices_items ii ON i.alt_id = ii.invoice_id FULL OUTER JOIN public.items i2 on i2.alt_id = ii.item_id WHERE i.alt_id = $1").
+				mock.ExpectQuery("SELECT i.*, i2.id as item_seq, i2.alt_id as item_alt_id, i2.name as item_name, description as item_description, i2.unit_price as item_unit_price, i2.currency as item_currency, i2.tax_category as item_tax_category, ii.unit_price as line_unit_price, ii.quantity as line_quantity, ii.tax_category as line_tax_category, ii.tax_rate as line_tax_rate, ii.discount_type as line_discount_type, ii.discount_value as line_discount_value, i2.created_by as item_created_by, i2.created_at as item_created_at, i2.last_changed_by as item_last_changed_by, i2.last_update as item_last_update FROM invoices i FULL OUTER JOIN invoices_items ii ON i.alt_id = ii.invoice_id FULL OUTER JOIN public.items i2 on i2.alt_id = ii.item_id WHERE i.alt_id = $1").
 					WithArgs(tc.id).
 					WillReturnRows(tc.rows)
 			}
 
 			r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
 
-			results, err := r.GetInvoiceWithItems(tc.id)
+			results, err := r.GetInvoiceWithItems(context.Background(), tc.id)
 			if tc.wantErr {
 				assert.NotNil(t, err)
 			} else {
@@ -329,6 +409,9 @@ func TestInvoiceRepositoryImpl_GetInvoiceWithItems(t *testing.T) {
 				assert.Equal(t, results[0].AltId, invoiceId)
 				assert.Equal(t, results[0].ItemName.String, "Item1")
 				assert.Equal(t, results[1].ItemName.String, "Item2")
+				assert.Equal(t, results[1].ItemCurrency.String, "USD")
+				assert.Equal(t, results[1].LineUnitPrice, commons.NullMoney{Money: commons.NewMoney(5224, commons.DefaultCurrency), Valid: true})
+				assert.Equal(t, results[1].LineQuantity, sql.NullInt64{Int64: 3, Valid: true})
 			}
 		})
 	}
@@ -386,7 +469,7 @@ func TestInvoiceRepositoryImpl_GetAll(t *testing.T) {
 					WillReturnRows(tc.rows)
 			}
 			r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
-			result, err := r.GetAll(tc.pagination)
+			result, err := r.GetAll(context.Background(), tc.pagination)
 			if tc.wantErr {
 				assert.NotNil(t, err)
 			} else {
@@ -439,7 +522,7 @@ func TestInvoiceRepositoryImpl_GetAllForUser(t *testing.T) {
 					WillReturnRows(tc.rows)
 			}
 			r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
-			result, err := r.GetAllForUser(userId)
+			result, err := r.GetAllForUser(context.Background(), userId)
 			if tc.wantErr {
 				assert.NotNil(t, err)
 			} else {
@@ -451,7 +534,7 @@ func TestInvoiceRepositoryImpl_GetAllForUser(t *testing.T) {
 	}
 }
 func TestInvoiceRepositoryImpl_RemoveItemFromInvoice(t *testing.T) {
-	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	db, mock, err := sqlmock.New()
 	invoiceId := uuid.New()
 	itemId := uuid.New()
 	if err != nil {
@@ -460,8 +543,9 @@ func TestInvoiceRepositoryImpl_RemoveItemFromInvoice(t *testing.T) {
 	testCases := []struct {
 		name    string
 		request SimpleInvoiceItem
+		number  any
 		rows    driver.Result
-		wantErr bool
+		wantErr error
 	}{
 		{
 			name: "Successful Removal of Item",
@@ -469,8 +553,7 @@ func TestInvoiceRepositoryImpl_RemoveItemFromInvoice(t *testing.T) {
 				InvoiceId: invoiceId,
 				ItemId:    itemId,
 			},
-			rows:    sqlmock.NewResult(1, 1),
-			wantErr: false,
+			rows: sqlmock.NewResult(1, 1),
 		},
 		{
 			name: "Failed Item Removal",
@@ -478,27 +561,49 @@ func TestInvoiceRepositoryImpl_RemoveItemFromInvoice(t *testing.T) {
 				InvoiceId: invoiceId,
 				ItemId:    itemId,
 			},
-			rows:    sqlmock.NewErrorResult(errors.New("error")),
-			wantErr: true,
+			wantErr: errors.New("error"),
+		},
+		{
+			name: "Issued Invoice",
+			request: SimpleInvoiceItem{
+				InvoiceId: invoiceId,
+				ItemId:    itemId,
+			},
+			number:  "INV-2026-000001",
+			wantErr: ErrInvoiceIssued,
 		},
 	}
+	total := commons.NewMoney(1000, "EUR")
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			if tc.wantErr {
-				mock.ExpectExec("DELETE FROM invoices_items WHERE invoice_id = $1 AND item_id = $2").
-					WithArgs(tc.request.InvoiceId, tc.request.ItemId).
-					WillReturnError(errors.New("error"))
-			} else {
-				mock.ExpectExec("DELETE FROM invoices_items WHERE invoice_id = $1 AND item_id = $2").
-					WithArgs(tc.request.InvoiceId, tc.request.ItemId).
-					WillReturnResult(tc.rows)
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(LockInvoiceQuery)).
+				WithArgs(tc.request.InvoiceId).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "alt_id", "number"}).AddRow(1, tc.request.InvoiceId, tc.number))
+			if tc.number == nil {
+				expectation := mock.ExpectExec(regexp.QuoteMeta(RemoveItemFromInvoiceQuery)).
+					WithArgs(tc.request.InvoiceId, tc.request.ItemId)
+				if tc.wantErr != nil {
+					expectation.WillReturnError(tc.wantErr)
+				} else {
+					expectation.WillReturnResult(tc.rows)
+					expectStoreTotal(mock, tc.request.InvoiceId, total, 1)
+					mock.ExpectCommit()
+				}
+			}
+			if tc.wantErr != nil {
+				mock.ExpectRollback()
 			}
 
 			r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
 
-			results, err := r.RemoveItemFromInvoice(tc.request)
-			if tc.wantErr {
-				assert.NotNil(t, err)
+			results, err := r.RemoveItemFromInvoice(context.Background(), tc.request, func(rows []InvoiceItemRow, discounts []InvoiceDiscountRow) (commons.Money, error) {
+				assert.Len(t, rows, 1)
+				return total, nil
+			})
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
 			} else {
 				assert.Nil(t, err)
 				assert.True(t, results.Success)
@@ -536,17 +641,17 @@ func TestInvoiceRepositoryImpl_DeleteInvoice(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.wantErr {
-				mock.ExpectExec("DELETE FROM invoices WHERE alt_id = $1").
+				mock.ExpectExec("DELETE FROM invoices WHERE alt_id = $1 AND number IS NULL").
 					WithArgs(tc.id).
 					WillReturnError(errors.New("error"))
 			} else {
-				mock.ExpectExec("DELETE FROM invoices WHERE alt_id = $1").
+				mock.ExpectExec("DELETE FROM invoices WHERE alt_id = $1 AND number IS NULL").
 					WithArgs(tc.id).
 					WillReturnResult(tc.rows)
 			}
 			r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
 
-			result, err := r.DeleteInvoice(tc.id)
+			result, err := r.DeleteInvoice(context.Background(), tc.id)
 			if tc.wantErr {
 				assert.NotNil(t, err)
 			} else {
@@ -557,3 +662,256 @@ func TestInvoiceRepositoryImpl_DeleteInvoice(t *testing.T) {
 		})
 	}
 }
+
+var invoiceDiscountColumns = []string{"id", "alt_id", "invoice_id", "promotion_id", "code", "description", "discount_type", "discount_value", "created_by", "created_at"}
+
+func TestInvoiceRepositoryImpl_AddInvoiceDiscount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	invoiceId := uuid.New()
+	promotionId := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	code := sql.NullString{String: "SPRING", Valid: true}
+	discount := InvoiceDiscountRow{InvoiceId: invoiceId, PromotionId: promotionId, Code: code, Description: "spring sale", DiscountType: "percentage", DiscountValue: "10", CreatedBy: "unit_test"}
+	total := commons.NewMoney(900, "EUR")
+	totalFunc := func(rows []InvoiceItemRow, discounts []InvoiceDiscountRow) (commons.Money, error) {
+		return total, nil
+	}
+	r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
+
+	expectLock := func(number any) {
+		mock.ExpectQuery("SELECT \\* FROM invoices WHERE alt_id = \\$1 FOR UPDATE").WithArgs(invoiceId).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "alt_id", "number"}).AddRow(1, invoiceId, number))
+	}
+
+	t.Run("Redeems The Code", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLock(nil)
+		mock.ExpectExec("UPDATE promotion_codes SET times_used = times_used \\+ 1").
+			WithArgs(promotionId.UUID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("INSERT INTO invoice_discounts").
+			WithArgs(invoiceId, promotionId, code, "spring sale", "percentage", "10", "unit_test").
+			WillReturnRows(sqlmock.NewRows(invoiceDiscountColumns).
+				AddRow(1, uuid.New(), invoiceId, promotionId.UUID, "SPRING", "spring sale", "percentage", "10.0000", "unit_test", now))
+		expectStoreTotal(mock, invoiceId, total, 1)
+		mock.ExpectCommit()
+
+		result, err := r.AddInvoiceDiscount(context.Background(), discount, totalFunc)
+		assert.NoError(t, err)
+		assert.Equal(t, promotionId, result.PromotionId)
+		assert.Equal(t, "10.0000", result.DiscountValue)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Code Used Up", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLock(nil)
+		mock.ExpectExec("UPDATE promotion_codes").
+			WithArgs(promotionId.UUID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := r.AddInvoiceDiscount(context.Background(), discount, totalFunc)
+		assert.ErrorIs(t, err, promotion.ErrPromotionExhausted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Manual Discount", func(t *testing.T) {
+		manual := InvoiceDiscountRow{InvoiceId: invoiceId, Description: "loyalty", DiscountType: "fixed", DiscountValue: "5", CreatedBy: "unit_test"}
+		mock.ExpectBegin()
+		expectLock(nil)
+		mock.ExpectQuery("INSERT INTO invoice_discounts").
+			WithArgs(invoiceId, nil, nil, "loyalty", "fixed", "5", "unit_test").
+			WillReturnRows(sqlmock.NewRows(invoiceDiscountColumns).
+				AddRow(2, uuid.New(), invoiceId, nil, nil, "loyalty", "fixed", "5.0000", "unit_test", now))
+		expectStoreTotal(mock, invoiceId, total, 1)
+		mock.ExpectCommit()
+
+		result, err := r.AddInvoiceDiscount(context.Background(), manual, totalFunc)
+		assert.NoError(t, err)
+		assert.False(t, result.PromotionId.Valid)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Issued Invoice", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLock("INV-2026-000001")
+		mock.ExpectRollback()
+
+		_, err := r.AddInvoiceDiscount(context.Background(), discount, totalFunc)
+		assert.ErrorIs(t, err, ErrInvoiceIssued)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestInvoiceRepositoryImpl_IssueInvoice(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	id := uuid.New()
+	issuedAt := time.Date(2026, time.March, 14, 9, 30, 0, 0, time.UTC)
+	request := IssueInvoiceRequest{Id: id, IssuedBy: "unit_test"}
+	columns := []string{"id", "alt_id", "number", "issued_at"}
+	r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
+
+	t.Run("Allocates The Next Number", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM invoices WHERE alt_id = \\$1 FOR UPDATE").
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, id, nil, nil))
+		mock.ExpectQuery("INSERT INTO invoice_number_series").
+			WithArgs("INV-2026").
+			WillReturnRows(sqlmock.NewRows([]string{"last_value"}).AddRow(123))
+		mock.ExpectQuery("UPDATE invoices SET number = \\$2").
+			WithArgs(id, "INV-2026-000123", issuedAt, "unit_test").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, id, "INV-2026-000123", issuedAt))
+		mock.ExpectCommit()
+
+		result, err := r.IssueInvoice(context.Background(), request, DefaultNumberFormat, issuedAt)
+		assert.NoError(t, err)
+		assert.Equal(t, sql.NullString{String: "INV-2026-000123", Valid: true}, result.Number)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Keeps An Issued Number", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM invoices WHERE alt_id = \\$1 FOR UPDATE").
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, id, "INV-2026-000123", issuedAt))
+		mock.ExpectCommit()
+
+		result, err := r.IssueInvoice(context.Background(), request, DefaultNumberFormat, issuedAt.AddDate(1, 0, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, "INV-2026-000123", result.Number.String)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rolls Back On Failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM invoices WHERE alt_id = \\$1 FOR UPDATE").
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, id, nil, nil))
+		mock.ExpectQuery("INSERT INTO invoice_number_series").
+			WithArgs("INV-2026").
+			WillReturnRows(sqlmock.NewRows([]string{"last_value"}).AddRow(124))
+		mock.ExpectQuery("UPDATE invoices SET number = \\$2").
+			WillReturnError(errors.New("error"))
+		mock.ExpectRollback()
+
+		_, err := r.IssueInvoice(context.Background(), request, DefaultNumberFormat, issuedAt)
+		assert.NotNil(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Invoice", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM invoices WHERE alt_id = \\$1 FOR UPDATE").
+			WithArgs(id).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := r.IssueInvoice(context.Background(), request, DefaultNumberFormat, issuedAt)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestInvoiceRepositoryImpl_GetInvoiceByNumber(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	id := uuid.New()
+	mock.ExpectQuery("SELECT \\* FROM invoices WHERE number = \\$1").
+		WithArgs("INV-2026-000123").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "alt_id", "number"}).AddRow(1, id, "INV-2026-000123"))
+
+	r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
+
+	result, err := r.GetInvoiceByNumber(context.Background(), "INV-2026-000123")
+	assert.NoError(t, err)
+	assert.Equal(t, id, result.AltId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// arrayConverter hands slices to the driver as they are, as pgx takes them for the ANY($1) queries.
+type arrayConverter struct{}
+
+func (arrayConverter) ConvertValue(v any) (driver.Value, error) {
+	if ids, ok := v.([]uuid.UUID); ok {
+		return ids, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+func TestInvoiceRepositoryImpl_BatchQueries(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual), sqlmock.ValueConverterOption(arrayConverter{}))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	r := NewInvoiceRepository(sqlx.NewDb(db, "mockDb"))
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	now := time.Now()
+
+	mock.ExpectQuery(GetAllForUsersQuery).WithArgs(ids).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "total", "created_by", "created_at", "last_update", "last_changed_by"}).
+			AddRow(1, ids[0], 12.34, "test_user", now, now, "test_user").
+			AddRow(2, ids[1], 43.21, "test_user", now, now, "test_user"))
+	invoices, err := r.GetAllForUsers(context.Background(), ids)
+	assert.NoError(t, err)
+	assert.Len(t, invoices, 2)
+
+	mock.ExpectQuery(GetInvoicesWithItemsQuery).WithArgs(ids).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "alt_id", "user_id", "total", "currency", "item_seq", "item_name"}).
+			AddRow(1, ids[0], uuid.New(), 12.34, "EUR", 1, "Item1").
+			AddRow(2, ids[1], uuid.New(), 43.21, "EUR", 1, "Item1"))
+	rows, err := r.GetInvoicesWithItems(context.Background(), ids)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	mock.ExpectQuery(DiscountsForInvoicesQuery).WithArgs(ids).WillReturnError(errors.New("error"))
+	_, err = r.GetDiscountsForInvoices(context.Background(), ids)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInvoiceRepositoryImpl_CorrectInvoiceTotal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	id := uuid.New()
+	total := commons.NewMoney(2000, "USD")
+	columns := []string{"id", "alt_id", "total", "currency", "number"}
+
+	t.Run("Stores The Total With InvoiceTotalCorrected", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM invoices WHERE alt_id = \\$1 FOR UPDATE").WithArgs(id).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, id, "15.00", "USD", nil))
+		mock.ExpectQuery("UPDATE invoices SET total = \\$2").WithArgs(id, total).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, id, "20.00", "USD", nil))
+		mock.ExpectExec("INSERT INTO outbox").
+			WithArgs(sqlmock.AnyArg(), outbox.InvoiceTotalCorrected, "invoice", id, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		row, err := NewInvoiceRepository(sqlx.NewDb(db, "mockDb")).CorrectInvoiceTotal(context.Background(), id, total)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2000), row.Total.MinorUnits)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Leaves An Issued Invoice Alone", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT \\* FROM invoices WHERE alt_id = \\$1 FOR UPDATE").WithArgs(id).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, id, "15.00", "USD", "INV-2026-000001"))
+		mock.ExpectRollback()
+
+		_, err := NewInvoiceRepository(sqlx.NewDb(db, "mockDb")).CorrectInvoiceTotal(context.Background(), id, total)
+		assert.ErrorIs(t, err, ErrInvoiceIssued)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
@@ -1,35 +1,100 @@
 package invoice
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
 	"github.com/google/uuid"
 	"inventory-service-go/commons"
+	"inventory-service-go/config"
+	"inventory-service-go/currency"
 	"inventory-service-go/item"
+	"inventory-service-go/metrics"
+	"inventory-service-go/promotion"
+	"inventory-service-go/tax"
+	"slices"
+	"strings"
 	"time"
 )
 
+var (
+	ErrNoItems                 = commons.NewValidationError("no items to add to invoice")
+	ErrInvalidQuantity         = commons.NewValidationError("quantity must be positive")
+	ErrPromotionAlreadyApplied = commons.NewValidationError("promotion code already applied to invoice")
+	ErrBelowMinimumOrder       = commons.NewValidationError("invoice is below the minimum order amount")
+	ErrInvoiceIssued           = commons.NewValidationError("invoice has been issued")
+)
+
 type Invoice struct {
-	Seq       int               `json:"seq"`
-	Id        uuid.UUID         `json:"id"`
-	UserId    uuid.UUID         `json:"user_id"`
-	Total     float64           `json:"total"`
-	Paid      bool              `json:"paid"`
-	Items     []item.Item       `json:"items"`
-	AuditInfo commons.AuditInfo `json:"audit_info"`
+	Seq              int               `json:"seq"`
+	Id               uuid.UUID         `json:"id"`
+	UserId           uuid.UUID         `json:"user_id"`
+	Total            commons.Money     `json:"total"`
+	TaxJurisdiction  string            `json:"tax_jurisdiction"`
+	PricesIncludeTax bool              `json:"prices_include_tax"`
+	Paid             bool              `json:"paid"`
+	Number           string            `json:"number,omitempty"`
+	IssuedAt         string            `json:"issued_at,omitempty"`
+	IssuedTime       time.Time         `json:"-"`
+	Items            []item.Item       `json:"items"`
+	Lines            []InvoiceLine     `json:"lines,omitempty"`
+	Discounts        []InvoiceDiscount `json:"discounts,omitempty"`
+	Tax              *tax.Breakdown    `json:"tax,omitempty"`
+	// NormalizedTotal is the total converted into the currency a listing was asked for, see handlers.GetAllInvoices.
+	NormalizedTotal *commons.Money    `json:"normalized_total,omitempty"`
+	AuditInfo       commons.AuditInfo `json:"audit_info"`
+}
+
+// InvoiceLine is an item as billed on the invoice, priced in the invoice currency with its tax worked out.
+// DiscountAmount is the line discount plus the line's share of the invoice discounts.
+type InvoiceLine struct {
+	ItemId         uuid.UUID           `json:"item_id"`
+	UnitPrice      commons.Money       `json:"unit_price"`
+	Quantity       int64               `json:"quantity"`
+	Discount       *promotion.Discount `json:"discount,omitempty"`
+	DiscountAmount commons.Money       `json:"discount_amount"`
+	TaxCategory    string              `json:"tax_category"`
+	TaxRate        string              `json:"tax_rate"`
+	tax.LineTax
+}
+
+// InvoiceDiscount is a discount on the whole invoice, either entered by hand or from a promotion code.
+type InvoiceDiscount struct {
+	Id          uuid.UUID          `json:"id"`
+	PromotionId *uuid.UUID         `json:"promotion_id,omitempty"`
+	Code        string             `json:"code,omitempty"`
+	Description string             `json:"description"`
+	Discount    promotion.Discount `json:"discount"`
+	Amount      commons.Money      `json:"amount"`
+}
+
+func withCurrency(amount commons.Money, currency string) commons.Money {
+	if currency != "" {
+		amount.Currency = currency
+	}
+	return amount
 }
 
 func fromRow(row InvoiceRow) Invoice {
 	return Invoice{
-		Seq:    int(row.Id),
-		Id:     row.AltId,
-		UserId: row.UserId,
-		Total:  row.Total,
-		Paid:   row.Paid,
-		Items:  []item.Item{},
+		Seq:              int(row.Id),
+		Id:               row.AltId,
+		UserId:           row.UserId,
+		Total:            withCurrency(row.Total, row.Currency),
+		TaxJurisdiction:  row.TaxJurisdiction,
+		PricesIncludeTax: row.PricesIncludeTax,
+		Paid:             row.Paid,
+		Number:           row.Number.String,
+		IssuedAt:         formatNullTime(row.IssuedAt),
+		IssuedTime:       row.IssuedAt.Time,
+		Items:            []item.Item{},
 		AuditInfo: commons.AuditInfo{
-			CreatedBy:     row.CreatedBy,
-			CreatedAt:     row.CreatedAt.Format(time.RFC3339),
-			LastChangedBy: row.LastChangedBy,
-			LastUpdate:    row.LastUpdate.Format(time.RFC3339),
+			CreatedBy:      row.CreatedBy,
+			CreatedAt:      row.CreatedAt.Format(time.RFC3339),
+			LastChangedBy:  row.LastChangedBy,
+			LastUpdate:     row.LastUpdate.Format(time.RFC3339),
+			CreatedTime:    row.CreatedAt,
+			LastUpdateTime: row.LastUpdate,
 		},
 	}
 }
@@ -44,75 +109,286 @@ func fromRows(results []InvoiceRow) []Invoice {
 
 func fromRowWithItems(row []InvoiceItemRow) Invoice {
 	var items []item.Item
+	var lines []InvoiceLine
 	for _, row := range row {
 		if row.ItemSeqId.Valid == false {
 			continue
 		}
+		if row.LineUnitPrice.Valid {
+			lines = append(lines, InvoiceLine{
+				ItemId:      row.ItemAltId,
+				UnitPrice:   withCurrency(row.LineUnitPrice.Money, row.Currency),
+				Quantity:    nullQuantity(row.LineQuantity),
+				TaxCategory: tax.NormalizeCategory(row.LineTaxCategory.String),
+				TaxRate:     nullPercentage(row.LineTaxRate),
+				Discount:    discountFromRow(row.LineDiscountType, row.LineDiscountValue),
+			})
+		}
 		items = append(items, item.Item{
 			Seq:         int(row.ItemSeqId.Int64),
 			Id:          row.ItemAltId,
 			Name:        row.ItemName.String,
 			Description: row.ItemDescription.String,
-			UnitPrice:   row.ItemUnitPrice.Float64,
+			UnitPrice:   withCurrency(row.ItemUnitPrice.Money, row.ItemCurrency.String),
+			TaxCategory: tax.NormalizeCategory(row.ItemTaxCategory.String),
 			AuditInfo: commons.AuditInfo{
-				CreatedBy:     row.ItemCreatedBy.String,
-				CreatedAt:     row.ItemCreatedAt.Time.Format(time.RFC3339),
-				LastUpdate:    row.ItemLastUpdate.Time.Format(time.RFC3339),
-				LastChangedBy: row.ItemLastChangedBy.String,
+				CreatedBy:      row.ItemCreatedBy.String,
+				CreatedAt:      row.ItemCreatedAt.Time.Format(time.RFC3339),
+				LastUpdate:     row.ItemLastUpdate.Time.Format(time.RFC3339),
+				LastChangedBy:  row.ItemLastChangedBy.String,
+				CreatedTime:    row.ItemCreatedAt.Time,
+				LastUpdateTime: row.ItemLastUpdate.Time,
 			},
 		})
 	}
 	return Invoice{
-		Seq:    int(row[0].Id),
-		Id:     row[0].AltId,
-		UserId: row[0].UserId,
-		Total:  row[0].Total,
-		Paid:   row[0].Paid,
-		Items:  items,
+		Seq:              int(row[0].Id),
+		Id:               row[0].AltId,
+		UserId:           row[0].UserId,
+		Total:            withCurrency(row[0].Total, row[0].Currency),
+		TaxJurisdiction:  row[0].TaxJurisdiction,
+		PricesIncludeTax: row[0].PricesIncludeTax,
+		Paid:             row[0].Paid,
+		Number:           row[0].Number.String,
+		IssuedAt:         formatNullTime(row[0].IssuedAt),
+		IssuedTime:       row[0].IssuedAt.Time,
+		Items:            items,
+		Lines:            lines,
 		AuditInfo: commons.AuditInfo{
-			CreatedBy:     row[0].CreatedBy,
-			CreatedAt:     row[0].CreatedAt.Format(time.RFC3339),
-			LastChangedBy: row[0].LastChangedBy,
-			LastUpdate:    row[0].LastUpdate.Format(time.RFC3339),
+			CreatedBy:      row[0].CreatedBy,
+			CreatedAt:      row[0].CreatedAt.Format(time.RFC3339),
+			LastChangedBy:  row[0].LastChangedBy,
+			LastUpdate:     row[0].LastUpdate.Format(time.RFC3339),
+			CreatedTime:    row[0].CreatedAt,
+			LastUpdateTime: row[0].LastUpdate,
 		},
 	}
 }
 
+func formatNullTime(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.Format(time.RFC3339)
+}
+
+func nullQuantity(quantity sql.NullInt64) int64 {
+	if !quantity.Valid || quantity.Int64 <= 0 {
+		return 1
+	}
+	return quantity.Int64
+}
+
+func nullPercentage(rate sql.NullString) string {
+	if !rate.Valid || rate.String == "" {
+		return tax.NoTax
+	}
+	return rate.String
+}
+
+func discountFromRow(discountType sql.NullString, discountValue sql.NullString) *promotion.Discount {
+	if !discountType.Valid || discountType.String == "" {
+		return nil
+	}
+	return &promotion.Discount{Type: promotion.DiscountType(discountType.String), Value: discountValue.String}
+}
+
+func discountsFromRows(rows []InvoiceDiscountRow) []InvoiceDiscount {
+	discounts := make([]InvoiceDiscount, 0, len(rows))
+	for _, row := range rows {
+		d := InvoiceDiscount{
+			Id:          row.AltId,
+			Code:        row.Code.String,
+			Description: row.Description,
+			Discount:    promotion.Discount{Type: promotion.DiscountType(row.DiscountType), Value: row.DiscountValue},
+		}
+		if row.PromotionId.Valid {
+			d.PromotionId = &row.PromotionId.UUID
+		}
+		discounts = append(discounts, d)
+	}
+	return discounts
+}
+
+// invoiceLine is the line as it is read back once written.
+func (line InvoiceItemLine) invoiceLine() InvoiceLine {
+	return InvoiceLine{
+		ItemId:      line.ItemId,
+		UnitPrice:   line.UnitPrice,
+		Quantity:    line.Quantity,
+		TaxCategory: line.TaxCategory,
+		TaxRate:     line.TaxRate,
+		Discount:    discountFromRow(line.DiscountType, line.DiscountValue),
+	}
+}
+
+// withTotals is the invoice of the rows with its discounts, lines and tax worked out.
+func withTotals(rows []InvoiceItemRow, discounts []InvoiceDiscountRow) (Invoice, error) {
+	invoice := fromRowWithItems(rows)
+	invoice.Discounts = discountsFromRows(discounts)
+	err := invoice.calculateTotals()
+	return invoice, err
+}
+
+// amount is the undiscounted price of the line, lines read back from the database are at least quantity one.
+func (line InvoiceLine) amount() (commons.Money, error) {
+	return line.UnitPrice.Mul(max(line.Quantity, 1))
+}
+
+// calculateTotals works out the discounts and tax of every line and the invoice breakdown.
+// Line discounts come off the line amount first, then the invoice discounts are applied in turn to what is left and
+// shared out across the lines pro rata so each line is taxed on what is actually charged for it.
+func (inv *Invoice) calculateTotals() error {
+	zero := commons.NewMoney(0, inv.Total.Currency)
+	breakdown := tax.Breakdown{Undiscounted: zero, Discount: zero, Subtotal: zero, TaxTotal: zero, Total: zero, Taxes: []tax.Summary{}}
+	charged := make([]commons.Money, len(inv.Lines))
+	weights := make([]int64, len(inv.Lines))
+	subtotal := zero
+	var err error
+	for i := range inv.Lines {
+		line := &inv.Lines[i]
+		line.DiscountAmount = commons.NewMoney(0, line.UnitPrice.Currency)
+		amount, err := line.amount()
+		if err != nil {
+			return err
+		}
+		if line.Discount != nil {
+			if line.DiscountAmount, err = line.Discount.AmountOff(amount); err != nil {
+				return err
+			}
+		}
+		if charged[i], err = amount.Sub(line.DiscountAmount); err != nil {
+			return err
+		}
+		weights[i] = max(charged[i].MinorUnits, 0)
+		if breakdown.Undiscounted, err = breakdown.Undiscounted.Add(amount); err != nil {
+			return err
+		}
+		if subtotal, err = subtotal.Add(charged[i]); err != nil {
+			return err
+		}
+	}
+	remaining := subtotal
+	for i := range inv.Discounts {
+		d := &inv.Discounts[i]
+		if d.Amount, err = d.Discount.AmountOff(remaining); err != nil {
+			return err
+		}
+		if remaining, err = remaining.Sub(d.Amount); err != nil {
+			return err
+		}
+	}
+	invoiceDiscount, err := subtotal.Sub(remaining)
+	if err != nil {
+		return err
+	}
+	shares, err := invoiceDiscount.Allocate(weights)
+	if err != nil {
+		return err
+	}
+	for i := range inv.Lines {
+		line := &inv.Lines[i]
+		if line.DiscountAmount, err = line.DiscountAmount.Add(shares[i]); err != nil {
+			return err
+		}
+		if charged[i], err = charged[i].Sub(shares[i]); err != nil {
+			return err
+		}
+		if line.LineTax, err = tax.CalculateLine(charged[i], 1, line.TaxRate, inv.PricesIncludeTax); err != nil {
+			return err
+		}
+		if err := breakdown.Add(line.TaxCategory, line.TaxRate, line.LineTax); err != nil {
+			return err
+		}
+	}
+	if breakdown.Discount, err = breakdown.Undiscounted.Sub(remaining); err != nil {
+		return err
+	}
+	inv.Tax = &breakdown
+	return nil
+}
+
+// ChangeTopic is the topic invoice changes are notified under.
+const ChangeTopic = "invoices"
+
 type InvoiceService interface {
-	GetInvoice(id uuid.UUID, withItems bool) (Invoice, error)
-	GetInvoicesForUser(userId uuid.UUID) ([]Invoice, error)
-	CreateInvoice(invoice CreateInvoiceRequest) (Invoice, error)
-	UpdateInvoice(invoice UpdateInvoiceRequest) (Invoice, error)
-	DeleteInvoice(id uuid.UUID) (commons.DeleteResult, error)
-	GetAllInvoices(pagination *commons.Pagination) ([]Invoice, error)
-	AddItemsToInvoice(request ItemsToInvoiceRequest) (ItemsToInvoiceResponse, error)
-	RemoveItemFromInvoice(request SimpleInvoiceItem) (ItemsToInvoiceResponse, error)
+	GetInvoice(ctx context.Context, id uuid.UUID, withItems bool) (Invoice, error)
+	GetInvoicesForUser(ctx context.Context, userId uuid.UUID) ([]Invoice, error)
+	// GetInvoicesForUsers returns the invoices of all the given users at once.
+	GetInvoicesForUsers(ctx context.Context, userIds []uuid.UUID) ([]Invoice, error)
+	// GetInvoicesWithItems returns the invoices as GetInvoice does with items, for many invoices at once. The ids that
+	// do not exist are left out.
+	GetInvoicesWithItems(ctx context.Context, ids []uuid.UUID) ([]Invoice, error)
+	CreateInvoice(ctx context.Context, invoice CreateInvoiceRequest) (Invoice, error)
+	// CreateInvoiceWithItems creates the invoice with the items attached as AddItemsToInvoice attaches them, and its
+	// total worked out from them, in one transaction. The total of the request is ignored.
+	CreateInvoiceWithItems(ctx context.Context, invoice CreateInvoiceRequest, items ItemsToInvoiceRequest) (Invoice, error)
+	UpdateInvoice(ctx context.Context, invoice UpdateInvoiceRequest) (Invoice, error)
+	DeleteInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error)
+	GetAllInvoices(ctx context.Context, pagination *commons.Pagination) ([]Invoice, error)
+	AddItemsToInvoice(ctx context.Context, request ItemsToInvoiceRequest) (ItemsToInvoiceResponse, error)
+	RemoveItemFromInvoice(ctx context.Context, request SimpleInvoiceItem) (ItemsToInvoiceResponse, error)
+	AddDiscount(ctx context.Context, request InvoiceDiscountRequest) (Invoice, error)
+	ApplyPromotion(ctx context.Context, request PromotionCodeRequest) (Invoice, error)
+	IssueInvoice(ctx context.Context, request IssueInvoiceRequest) (Invoice, error)
+	GetInvoiceByNumber(ctx context.Context, number string) (Invoice, error)
+	// RecalculateTotal works the total out again from the lines and discounts and stores it, with
+	// InvoiceTotalCorrected, when it differs from the stored one, which changed reports. The total of an issued invoice
+	// is only reported, with ErrInvoiceIssued, the invoice was sent with it.
+	RecalculateTotal(ctx context.Context, id uuid.UUID) (invoice Invoice, changed bool, err error)
 }
 
 type InvoiceServiceImpl struct {
-	repo InvoiceRepository
+	commons.ChangeHooks
+	repo        InvoiceRepository
+	itemService item.ItemService
+	converter   currency.Converter
+	rates       tax.RateProvider
+	promotions  promotion.Validator
+	numbers     NumberFormat
 }
 
-func NewInvoiceService(repo InvoiceRepository) *InvoiceServiceImpl {
+func NewInvoiceService(repo InvoiceRepository, itemService item.ItemService, converter currency.Converter, rates tax.RateProvider, promotions promotion.Validator) *InvoiceServiceImpl {
 	return &InvoiceServiceImpl{
-		repo: repo,
+		repo:        repo,
+		itemService: itemService,
+		converter:   converter,
+		rates:       rates,
+		promotions:  promotions,
+		numbers:     DefaultNumberFormat,
 	}
 }
 
-func (s *InvoiceServiceImpl) GetInvoice(id uuid.UUID, withItems bool) (Invoice, error) {
+// NewFromConfig is the service numbering the invoices it issues as the settings say.
+func NewFromConfig(repo InvoiceRepository, itemService item.ItemService, converter currency.Converter, rates tax.RateProvider, promotions promotion.Validator, numbers config.InvoiceNumbers) *InvoiceServiceImpl {
+	service := NewInvoiceService(repo, itemService, converter, rates, promotions)
+	service.numbers = NumberFormat{Prefix: strings.TrimSpace(numbers.Prefix), YearlyReset: numbers.YearlyReset, Padding: numbers.Padding}
+	return service
+}
+
+func (s *InvoiceServiceImpl) GetInvoice(ctx context.Context, id uuid.UUID, withItems bool) (Invoice, error) {
 	if withItems {
-		results, err := s.repo.GetInvoiceWithItems(id)
-		invoice := fromRowWithItems(results)
-		return invoice, err
+		results, err := s.repo.GetInvoiceWithItems(ctx, id)
+		if err != nil {
+			return Invoice{}, err
+		}
+		if len(results) == 0 {
+			return Invoice{}, sql.ErrNoRows
+		}
+		discounts, err := s.repo.GetInvoiceDiscounts(ctx, id)
+		if err != nil {
+			return Invoice{}, err
+		}
+		return withTotals(results, discounts)
 	} else {
-		results, err := s.repo.GetInvoice(id)
+		results, err := s.repo.GetInvoice(ctx, id)
 		invoice := fromRow(results)
 		return invoice, err
 	}
 }
 
-func (s *InvoiceServiceImpl) GetInvoicesForUser(userId uuid.UUID) ([]Invoice, error) {
-	invoices, err := s.repo.GetAllForUser(userId)
+func (s *InvoiceServiceImpl) GetInvoicesForUser(ctx context.Context, userId uuid.UUID) ([]Invoice, error) {
+	invoices, err := s.repo.GetAllForUser(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -120,32 +396,128 @@ func (s *InvoiceServiceImpl) GetInvoicesForUser(userId uuid.UUID) ([]Invoice, er
 	return result, nil
 }
 
-func (s *InvoiceServiceImpl) CreateInvoice(invoice CreateInvoiceRequest) (Invoice, error) {
-	invoiceRow, err := s.repo.CreateInvoice(invoice)
+func (s *InvoiceServiceImpl) GetInvoicesForUsers(ctx context.Context, userIds []uuid.UUID) ([]Invoice, error) {
+	invoices, err := s.repo.GetAllForUsers(ctx, userIds)
+	if err != nil {
+		return nil, err
+	}
+	return fromRows(invoices), nil
+}
+
+func (s *InvoiceServiceImpl) GetInvoicesWithItems(ctx context.Context, ids []uuid.UUID) ([]Invoice, error) {
+	rows, err := s.repo.GetInvoicesWithItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	discounts, err := s.repo.GetDiscountsForInvoices(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	discountsByInvoice := map[uuid.UUID][]InvoiceDiscountRow{}
+	for _, discount := range discounts {
+		discountsByInvoice[discount.InvoiceId] = append(discountsByInvoice[discount.InvoiceId], discount)
+	}
+	invoices := []Invoice{}
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && rows[end].AltId == rows[start].AltId {
+			end++
+		}
+		invoice := fromRowWithItems(rows[start:end])
+		invoice.Discounts = discountsFromRows(discountsByInvoice[invoice.Id])
+		if err = invoice.calculateTotals(); err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, invoice)
+		start = end
+	}
+	return invoices, nil
+}
+
+// CreateInvoice creates an empty invoice in the currency of the request. Its total only ever comes from its lines and
+// discounts, so the requested amount is not taken over.
+func (s *InvoiceServiceImpl) CreateInvoice(ctx context.Context, invoice CreateInvoiceRequest) (Invoice, error) {
+	invoiceCurrency, err := commons.NormalizeCurrency(invoice.Total.Currency)
+	if err != nil {
+		return Invoice{}, err
+	}
+	invoice.Total = commons.NewMoney(0, invoiceCurrency)
+	invoice.TaxJurisdiction = tax.NormalizeJurisdiction(invoice.TaxJurisdiction)
+	invoiceRow, err := s.repo.CreateInvoice(ctx, invoice)
+	if err != nil {
+		return Invoice{}, err
+	}
+	created := fromRow(invoiceRow)
+	metrics.InvoicesCreated.Inc()
+	s.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeCreated, Id: created.Id, Data: created})
+	return created, nil
+}
+
+func (s *InvoiceServiceImpl) CreateInvoiceWithItems(ctx context.Context, invoice CreateInvoiceRequest, items ItemsToInvoiceRequest) (Invoice, error) {
+	if len(items.Items) == 0 {
+		return Invoice{}, ErrNoItems
+	}
+	if err := validateLineDiscounts(items); err != nil {
+		return Invoice{}, err
+	}
+	if err := validateQuantities(items); err != nil {
+		return Invoice{}, err
+	}
+	invoiceCurrency, err := commons.NormalizeCurrency(invoice.Total.Currency)
+	if err != nil {
+		return Invoice{}, err
+	}
+	invoice.TaxJurisdiction = tax.NormalizeJurisdiction(invoice.TaxJurisdiction)
+	if invoice.Id == uuid.Nil {
+		invoice.Id = uuid.New()
+	}
+	items.InvoiceId = invoice.Id
+	lines, err := s.pricedLines(ctx, items, invoiceCurrency, invoice.TaxJurisdiction, time.Now())
+	if err != nil {
+		return Invoice{}, err
+	}
+	priced := Invoice{Total: commons.NewMoney(0, invoiceCurrency), PricesIncludeTax: invoice.PricesIncludeTax}
+	for _, line := range lines {
+		priced.Lines = append(priced.Lines, line.invoiceLine())
+	}
+	if err = priced.calculateTotals(); err != nil {
+		return Invoice{}, err
+	}
+	invoice.Total = priced.Tax.Total
+	invoiceRow, err := s.repo.CreateInvoice(ctx, invoice, lines...)
 	if err != nil {
 		return Invoice{}, err
 	}
-	return fromRow(invoiceRow), nil
+	created := fromRow(invoiceRow)
+	metrics.InvoicesCreated.Inc()
+	metrics.InvoiceItemsAttached.Add(float64(len(lines)))
+	s.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeCreated, Id: created.Id, Data: created})
+	return created, nil
 }
 
-func (s *InvoiceServiceImpl) UpdateInvoice(invoice UpdateInvoiceRequest) (Invoice, error) {
-	invoiceRow, err := s.repo.UpdateInvoice(invoice)
+func (s *InvoiceServiceImpl) UpdateInvoice(ctx context.Context, invoice UpdateInvoiceRequest) (Invoice, error) {
+	invoiceRow, err := s.repo.UpdateInvoice(ctx, invoice)
 	if err != nil {
 		return Invoice{}, err
 	}
-	return fromRow(invoiceRow), nil
+	updated := fromRow(invoiceRow)
+	s.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeUpdated, Id: updated.Id, Data: updated})
+	return updated, nil
 }
 
-func (s *InvoiceServiceImpl) DeleteInvoice(id uuid.UUID) (commons.DeleteResult, error) {
-	results, err := s.repo.DeleteInvoice(id)
+func (s *InvoiceServiceImpl) DeleteInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	results, err := s.repo.DeleteInvoice(ctx, id)
 	if err != nil {
 		return commons.DeleteResult{}, err
 	}
+	if results.Deleted {
+		s.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeDeleted, Id: id})
+	}
 	return results, nil
 }
 
-func (s *InvoiceServiceImpl) GetAllInvoices(pagination *commons.Pagination) ([]Invoice, error) {
-	results, err := s.repo.GetAll(pagination)
+func (s *InvoiceServiceImpl) GetAllInvoices(ctx context.Context, pagination *commons.Pagination) ([]Invoice, error) {
+	results, err := s.repo.GetAll(ctx, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -153,18 +525,244 @@ func (s *InvoiceServiceImpl) GetAllInvoices(pagination *commons.Pagination) ([]I
 	return invoices, nil
 }
 
-func (s *InvoiceServiceImpl) AddItemsToInvoice(request ItemsToInvoiceRequest) (ItemsToInvoiceResponse, error) {
-	results, err := s.repo.AddItemsToInvoice(request)
+// AddItemsToInvoice attaches the items at their current unit price, converted into the invoice currency, with the
+// tax rate of the invoice jurisdiction in effect today. The invoice total is recalculated in the same transaction.
+func (s *InvoiceServiceImpl) AddItemsToInvoice(ctx context.Context, request ItemsToInvoiceRequest) (ItemsToInvoiceResponse, error) {
+	if len(request.Items) == 0 {
+		return ItemsToInvoiceResponse{}, ErrNoItems
+	}
+	if err := validateLineDiscounts(request); err != nil {
+		return ItemsToInvoiceResponse{}, err
+	}
+	if err := validateQuantities(request); err != nil {
+		return ItemsToInvoiceResponse{}, err
+	}
+	invoiceRow, err := s.repo.GetInvoice(ctx, request.InvoiceId)
+	if err != nil {
+		return ItemsToInvoiceResponse{}, err
+	}
+	invoiceCurrency := withCurrency(invoiceRow.Total, invoiceRow.Currency).Currency
+	lines, err := s.pricedLines(ctx, request, invoiceCurrency, invoiceRow.TaxJurisdiction, time.Now())
 	if err != nil {
 		return ItemsToInvoiceResponse{}, err
 	}
-	return results, err
+	var updated Invoice
+	results, err := s.repo.AddItemsToInvoice(ctx, request.InvoiceId, lines, totalInto(&updated))
+	if err != nil {
+		return ItemsToInvoiceResponse{}, err
+	}
+	metrics.InvoiceItemsAttached.Add(float64(len(lines)))
+	s.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeUpdated, Id: request.InvoiceId, Data: updated})
+	return results, nil
+}
+
+func validateLineDiscounts(request ItemsToInvoiceRequest) error {
+	for itemId, discount := range request.Discounts {
+		if !slices.Contains(request.Items, itemId) {
+			return fmt.Errorf("%w: item %s is not being added", promotion.ErrInvalidDiscount, itemId)
+		}
+		if err := discount.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateQuantities(request ItemsToInvoiceRequest) error {
+	for itemId, quantity := range request.Quantities {
+		if !slices.Contains(request.Items, itemId) {
+			return fmt.Errorf("%w: item %s is not being added", ErrInvalidQuantity, itemId)
+		}
+		if quantity <= 0 {
+			return fmt.Errorf("%w: %d of item %s", ErrInvalidQuantity, quantity, itemId)
+		}
+	}
+	return nil
+}
+
+// totalInto is the TotalFunc of the changes to lines and discounts, it keeps the invoice it worked the total out for
+// so the change can be notified with it.
+func totalInto(updated *Invoice) TotalFunc {
+	return func(rows []InvoiceItemRow, discounts []InvoiceDiscountRow) (commons.Money, error) {
+		invoice, err := withTotals(rows, discounts)
+		if err != nil {
+			return commons.Money{}, err
+		}
+		invoice.Total = invoice.Tax.Total
+		*updated = invoice
+		return invoice.Total, nil
+	}
 }
 
-func (s *InvoiceServiceImpl) RemoveItemFromInvoice(request SimpleInvoiceItem) (ItemsToInvoiceResponse, error) {
-	results, err := s.repo.RemoveItemFromInvoice(request)
+func (s *InvoiceServiceImpl) RecalculateTotal(ctx context.Context, id uuid.UUID) (Invoice, bool, error) {
+	invoice, err := s.GetInvoice(ctx, id, true)
+	if err != nil {
+		return Invoice{}, false, err
+	}
+	if invoice.Total == invoice.Tax.Total {
+		return invoice, false, nil
+	}
+	if invoice.Number != "" {
+		return invoice, false, ErrInvoiceIssued
+	}
+	if _, err = s.repo.CorrectInvoiceTotal(ctx, id, invoice.Tax.Total); err != nil {
+		return Invoice{}, false, err
+	}
+	invoice.Total = invoice.Tax.Total
+	s.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeUpdated, Id: id, Data: invoice})
+	return invoice, true, nil
+}
+
+// AddDiscount takes a percentage or a fixed amount (in the invoice currency) off the whole invoice, as long as it has
+// not been issued.
+func (s *InvoiceServiceImpl) AddDiscount(ctx context.Context, request InvoiceDiscountRequest) (Invoice, error) {
+	if err := request.Discount.Validate(); err != nil {
+		return Invoice{}, err
+	}
+	row, err := s.repo.GetInvoice(ctx, request.InvoiceId)
+	if err != nil {
+		return Invoice{}, err
+	}
+	if row.Number.Valid {
+		return Invoice{}, ErrInvoiceIssued
+	}
+	var updated Invoice
+	_, err = s.repo.AddInvoiceDiscount(ctx, InvoiceDiscountRow{
+		InvoiceId:     request.InvoiceId,
+		Description:   request.Description,
+		DiscountType:  string(request.Discount.Type),
+		DiscountValue: request.Discount.Value,
+		CreatedBy:     request.CreatedBy,
+	}, totalInto(&updated))
+	if err != nil {
+		return Invoice{}, err
+	}
+	s.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeUpdated, Id: request.InvoiceId, Data: updated})
+	return updated, nil
+}
+
+// ApplyPromotion redeems a promotion code against the invoice. The minimum order amount is checked against the
+// undiscounted invoice lines and a fixed amount off is converted into the invoice currency.
+func (s *InvoiceServiceImpl) ApplyPromotion(ctx context.Context, request PromotionCodeRequest) (Invoice, error) {
+	now := time.Now()
+	p, err := s.promotions.ActivePromotion(ctx, request.Code, now)
+	if err != nil {
+		return Invoice{}, err
+	}
+	invoice, err := s.GetInvoice(ctx, request.InvoiceId, true)
+	if err != nil {
+		return Invoice{}, err
+	}
+	if invoice.Number != "" {
+		return Invoice{}, ErrInvoiceIssued
+	}
+	for _, d := range invoice.Discounts {
+		if d.PromotionId != nil && *d.PromotionId == p.Id {
+			return Invoice{}, fmt.Errorf("%w: %s", ErrPromotionAlreadyApplied, p.Code)
+		}
+	}
+	if p.MinOrderAmount != nil {
+		minOrderAmount, err := s.converter.Convert(*p.MinOrderAmount, invoice.Total.Currency, now)
+		if err != nil {
+			return Invoice{}, err
+		}
+		if invoice.Tax.Undiscounted.MinorUnits < minOrderAmount.MinorUnits {
+			return Invoice{}, fmt.Errorf("%w: minimum order amount is %s", ErrBelowMinimumOrder, minOrderAmount)
+		}
+	}
+	discount := p.Discount
+	if discount.Type == promotion.FixedAmount {
+		amount, err := commons.ParseMoney(discount.Value, p.Currency)
+		if err != nil {
+			return Invoice{}, err
+		}
+		if amount, err = s.converter.Convert(amount, invoice.Total.Currency, now); err != nil {
+			return Invoice{}, err
+		}
+		discount.Value = amount.Amount()
+	}
+	var updated Invoice
+	_, err = s.repo.AddInvoiceDiscount(ctx, InvoiceDiscountRow{
+		InvoiceId:     request.InvoiceId,
+		PromotionId:   uuid.NullUUID{UUID: p.Id, Valid: true},
+		Code:          sql.NullString{String: p.Code, Valid: true},
+		Description:   p.Description,
+		DiscountType:  string(discount.Type),
+		DiscountValue: discount.Value,
+		CreatedBy:     request.CreatedBy,
+	}, totalInto(&updated))
+	if err != nil {
+		return Invoice{}, err
+	}
+	s.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeUpdated, Id: request.InvoiceId, Data: updated})
+	return updated, nil
+}
+
+func (s *InvoiceServiceImpl) pricedLines(ctx context.Context, request ItemsToInvoiceRequest, invoiceCurrency string, jurisdiction string, on time.Time) ([]InvoiceItemLine, error) {
+	lines := make([]InvoiceItemLine, 0, len(request.Items))
+	for _, itemId := range request.Items {
+		i, err := s.itemService.GetItem(ctx, itemId)
+		if err != nil {
+			return nil, err
+		}
+		unitPrice, err := s.converter.Convert(i.UnitPrice, invoiceCurrency, on)
+		if err != nil {
+			return nil, err
+		}
+		category := tax.NormalizeCategory(i.TaxCategory)
+		percentage, err := s.rates.EffectivePercentage(jurisdiction, category, on)
+		if err != nil {
+			return nil, err
+		}
+		line := InvoiceItemLine{
+			InvoiceId:   request.InvoiceId,
+			ItemId:      itemId,
+			UnitPrice:   unitPrice,
+			Quantity:    1,
+			Currency:    unitPrice.Currency,
+			TaxCategory: category,
+			TaxRate:     percentage,
+		}
+		if quantity, ok := request.Quantities[itemId]; ok {
+			line.Quantity = quantity
+		}
+		if discount, ok := request.Discounts[itemId]; ok {
+			line.DiscountType = sql.NullString{String: string(discount.Type), Valid: true}
+			line.DiscountValue = sql.NullString{String: discount.Value, Valid: true}
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+func (s *InvoiceServiceImpl) RemoveItemFromInvoice(ctx context.Context, request SimpleInvoiceItem) (ItemsToInvoiceResponse, error) {
+	var updated Invoice
+	results, err := s.repo.RemoveItemFromInvoice(ctx, request, totalInto(&updated))
 	if err != nil {
 		return ItemsToInvoiceResponse{}, err
 	}
-	return results, err
+	if results.Success {
+		s.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeUpdated, Id: request.InvoiceId, Data: updated})
+	}
+	return results, nil
+}
+
+// IssueInvoice gives the invoice the next number of its series, numbers are allocated in UTC.
+func (s *InvoiceServiceImpl) IssueInvoice(ctx context.Context, request IssueInvoiceRequest) (Invoice, error) {
+	row, err := s.repo.IssueInvoice(ctx, request, s.numbers, time.Now().UTC())
+	if err != nil {
+		return Invoice{}, err
+	}
+	issued := fromRow(row)
+	metrics.InvoicesIssued.Inc()
+	s.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeUpdated, Id: issued.Id, Data: issued})
+	return issued, nil
+}
+
+func (s *InvoiceServiceImpl) GetInvoiceByNumber(ctx context.Context, number string) (Invoice, error) {
+	row, err := s.repo.GetInvoiceByNumber(ctx, strings.TrimSpace(number))
+	if err != nil {
+		return Invoice{}, err
+	}
+	return fromRow(row), nil
 }
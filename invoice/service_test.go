@@ -1,12 +1,18 @@
 package invoice
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"inventory-service-go/commons"
+	"inventory-service-go/currency"
+	"inventory-service-go/item"
+	"inventory-service-go/promotion"
+	"inventory-service-go/tax"
+	"slices"
 	"testing"
 	"time"
 )
@@ -18,7 +24,7 @@ func TestInvoiceService_GetInvoice(t *testing.T) {
 		Id:            1,
 		AltId:         invoiceUuid,
 		UserId:        uuid.New(),
-		Total:         10.0,
+		Total:         commons.NewMoney(1000, "USD"),
 		Paid:          false,
 		CreatedBy:     "Unit Test",
 		CreatedAt:     now,
@@ -30,7 +36,7 @@ func TestInvoiceService_GetInvoice(t *testing.T) {
 		Id:                1,
 		AltId:             invoiceUuid,
 		UserId:            uuid.New(),
-		Total:             10.0,
+		Total:             commons.NewMoney(1000, "USD"),
 		Paid:              false,
 		CreatedBy:         "Unit Test",
 		CreatedAt:         now,
@@ -40,7 +46,7 @@ func TestInvoiceService_GetInvoice(t *testing.T) {
 		ItemAltId:         uuid.New(),
 		ItemName:          sql.NullString{String: "Item 1", Valid: true},
 		ItemDescription:   sql.NullString{String: "Item 1 Description", Valid: true},
-		ItemUnitPrice:     sql.NullFloat64{Float64: 10.0, Valid: true},
+		ItemUnitPrice:     commons.NullMoney{Money: commons.NewMoney(1000, "USD"), Valid: true},
 		ItemCreatedBy:     sql.NullString{String: "Unit Test", Valid: true},
 		ItemCreatedAt:     sql.NullTime{Time: now, Valid: true},
 		ItemLastChangedBy: sql.NullString{String: "Unit Test", Valid: true},
@@ -51,7 +57,7 @@ func TestInvoiceService_GetInvoice(t *testing.T) {
 			Id:                1,
 			AltId:             invoiceUuid,
 			UserId:            uuid.New(),
-			Total:             10.0,
+			Total:             commons.NewMoney(1000, "USD"),
 			Paid:              false,
 			CreatedBy:         "Unit Test",
 			CreatedAt:         now,
@@ -61,15 +67,21 @@ func TestInvoiceService_GetInvoice(t *testing.T) {
 			ItemAltId:         uuid.Nil,
 			ItemName:          sql.NullString{Valid: false},
 			ItemDescription:   sql.NullString{Valid: false},
-			ItemUnitPrice:     sql.NullFloat64{Float64: 10.0, Valid: false},
+			ItemUnitPrice:     commons.NullMoney{Money: commons.NewMoney(1000, "USD"), Valid: false},
 			ItemCreatedBy:     sql.NullString{Valid: false},
 			ItemCreatedAt:     sql.NullTime{Valid: false},
 			ItemLastChangedBy: sql.NullString{Valid: false},
 			ItemLastUpdate:    sql.NullTime{Valid: false},
 		},
 	}
-	invoiceFixtureWithItems := fromRowWithItems(invoiceItemRowFixture)
-	invoiceFixtureWithNoItems := fromRowWithItems(invoiceItemRowWithNoItemsFixture)
+	withTax := func(rows []InvoiceItemRow) Invoice {
+		invoice := fromRowWithItems(rows)
+		invoice.Discounts = []InvoiceDiscount{}
+		assert.NoError(t, invoice.calculateTotals())
+		return invoice
+	}
+	invoiceFixtureWithItems := withTax(invoiceItemRowFixture)
+	invoiceFixtureWithNoItems := withTax(invoiceItemRowWithNoItemsFixture)
 	emptyInvoiceRowFixture := InvoiceRow{}
 	emptyInvoiceFixture := Invoice{}
 	testCases := []struct {
@@ -113,16 +125,18 @@ func TestInvoiceService_GetInvoice(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := NewMockInvoiceRepository(controller)
 			if tt.wantErr {
-				mockRepo.EXPECT().GetInvoice(invoiceUuid).Return(emptyInvoiceRowFixture, errors.New("Boom"))
+				mockRepo.EXPECT().GetInvoice(gomock.Any(), invoiceUuid).Return(emptyInvoiceRowFixture, errors.New("Boom"))
 			} else if tt.withItems && !tt.noItems {
-				mockRepo.EXPECT().GetInvoiceWithItems(invoiceUuid).Return(invoiceItemRowFixture, nil)
+				mockRepo.EXPECT().GetInvoiceWithItems(gomock.Any(), invoiceUuid).Return(invoiceItemRowFixture, nil)
+				mockRepo.EXPECT().GetInvoiceDiscounts(gomock.Any(), invoiceUuid).Return(nil, nil)
 			} else if tt.withItems && tt.noItems {
-				mockRepo.EXPECT().GetInvoiceWithItems(invoiceUuid).Return(invoiceItemRowWithNoItemsFixture, nil)
+				mockRepo.EXPECT().GetInvoiceWithItems(gomock.Any(), invoiceUuid).Return(invoiceItemRowWithNoItemsFixture, nil)
+				mockRepo.EXPECT().GetInvoiceDiscounts(gomock.Any(), invoiceUuid).Return(nil, nil)
 			} else {
-				mockRepo.EXPECT().GetInvoice(invoiceUuid).Return(invoiceRowFixture, nil)
+				mockRepo.EXPECT().GetInvoice(gomock.Any(), invoiceUuid).Return(invoiceRowFixture, nil)
 			}
-			service := NewInvoiceService(mockRepo)
-			results, err := service.GetInvoice(invoiceUuid, tt.withItems)
+			service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+			results, err := service.GetInvoice(context.Background(), invoiceUuid, tt.withItems)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("InvoiceService.GetInvoice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -143,7 +157,7 @@ func TestInvoiceService_GetInvoicesForUser(t *testing.T) {
 		Id:            1,
 		AltId:         uuid.New(),
 		UserId:        userId,
-		Total:         10.0,
+		Total:         commons.NewMoney(1000, "USD"),
 		Paid:          false,
 		CreatedBy:     "Unit Test",
 		CreatedAt:     time.Now(),
@@ -154,7 +168,7 @@ func TestInvoiceService_GetInvoicesForUser(t *testing.T) {
 		Id:            2,
 		AltId:         uuid.New(),
 		UserId:        userId,
-		Total:         15.0,
+		Total:         commons.NewMoney(1500, "USD"),
 		Paid:          false,
 		CreatedBy:     "Unit Test",
 		CreatedAt:     time.Now(),
@@ -176,7 +190,7 @@ func TestInvoiceService_GetInvoicesForUser(t *testing.T) {
 			name:   "Get All Invoices For User",
 			userId: userId,
 			mockFunc: func(mockRepo *MockInvoiceRepository, userId uuid.UUID) {
-				mockRepo.EXPECT().GetAllForUser(userId).Return([]InvoiceRow{invoiceRowFixture1, invoiceRowFixture2}, nil)
+				mockRepo.EXPECT().GetAllForUser(gomock.Any(), userId).Return([]InvoiceRow{invoiceRowFixture1, invoiceRowFixture2}, nil)
 			},
 			want:    invoicesFixture,
 			wantErr: false,
@@ -185,7 +199,7 @@ func TestInvoiceService_GetInvoicesForUser(t *testing.T) {
 			name:   "Get All Invoices For User - Error",
 			userId: userId,
 			mockFunc: func(mockRepo *MockInvoiceRepository, userId uuid.UUID) {
-				mockRepo.EXPECT().GetAllForUser(userId).Return(nil, errors.New("Boom"))
+				mockRepo.EXPECT().GetAllForUser(gomock.Any(), userId).Return(nil, errors.New("Boom"))
 			},
 			want:    emptyInvoicesFixture,
 			wantErr: true,
@@ -194,7 +208,7 @@ func TestInvoiceService_GetInvoicesForUser(t *testing.T) {
 			name:   "Get All Invoices For User No Invoices",
 			userId: emptyUuid,
 			mockFunc: func(mockRepo *MockInvoiceRepository, userId uuid.UUID) {
-				mockRepo.EXPECT().GetAllForUser(userId).Return(emptyInvoiceRowFixture, nil)
+				mockRepo.EXPECT().GetAllForUser(gomock.Any(), userId).Return(emptyInvoiceRowFixture, nil)
 			},
 			want:    emptyInvoicesFixture,
 			wantErr: false,
@@ -205,8 +219,8 @@ func TestInvoiceService_GetInvoicesForUser(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := NewMockInvoiceRepository(controller)
 			tt.mockFunc(mockRepo, tt.userId)
-			service := NewInvoiceService(mockRepo)
-			results, err := service.GetInvoicesForUser(tt.userId)
+			service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+			results, err := service.GetInvoicesForUser(context.Background(), tt.userId)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("InvoiceService.GetInvoice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -227,7 +241,7 @@ func TestInvoiceService_CreateInvoice(t *testing.T) {
 	createInvoiceRequest := CreateInvoiceRequest{
 		UserId:    userId,
 		Paid:      false,
-		Total:     0.0,
+		Total:     commons.NewMoney(0, "USD"),
 		CreatedBy: createdBy,
 	}
 
@@ -235,7 +249,7 @@ func TestInvoiceService_CreateInvoice(t *testing.T) {
 		Id:            1,
 		AltId:         uuid.New(),
 		UserId:        userId,
-		Total:         0.0,
+		Total:         commons.NewMoney(0, "USD"),
 		Paid:          false,
 		CreatedBy:     createdBy,
 		CreatedAt:     time.Now(),
@@ -257,7 +271,16 @@ func TestInvoiceService_CreateInvoice(t *testing.T) {
 			want:    invoice,
 			wantErr: false,
 			mockFunc: func(mockRepo *MockInvoiceRepository, request CreateInvoiceRequest) {
-				mockRepo.EXPECT().CreateInvoice(request).Return(invoiceRow, nil)
+				mockRepo.EXPECT().CreateInvoice(gomock.Any(), request).Return(invoiceRow, nil)
+			},
+		},
+		{
+			name:    "Create Invoice - Ignores The Requested Total",
+			request: CreateInvoiceRequest{UserId: userId, Total: commons.NewMoney(99999, "usd"), CreatedBy: createdBy},
+			want:    invoice,
+			wantErr: false,
+			mockFunc: func(mockRepo *MockInvoiceRepository, request CreateInvoiceRequest) {
+				mockRepo.EXPECT().CreateInvoice(gomock.Any(), createInvoiceRequest).Return(invoiceRow, nil)
 			},
 		},
 		{
@@ -266,7 +289,7 @@ func TestInvoiceService_CreateInvoice(t *testing.T) {
 			want:    Invoice{},
 			wantErr: true,
 			mockFunc: func(mockRepo *MockInvoiceRepository, request CreateInvoiceRequest) {
-				mockRepo.EXPECT().CreateInvoice(request).Return(InvoiceRow{}, errors.New("Repo Error"))
+				mockRepo.EXPECT().CreateInvoice(gomock.Any(), request).Return(InvoiceRow{}, errors.New("Repo Error"))
 			},
 		},
 	}
@@ -275,8 +298,8 @@ func TestInvoiceService_CreateInvoice(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := NewMockInvoiceRepository(controller)
 			tt.mockFunc(mockRepo, tt.request)
-			service := NewInvoiceService(mockRepo)
-			result, err := service.CreateInvoice(tt.request)
+			service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+			result, err := service.CreateInvoice(context.Background(), tt.request)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("InvoiceService.CreateInvoice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -290,12 +313,43 @@ func TestInvoiceService_CreateInvoice(t *testing.T) {
 	}
 }
 
+func TestInvoiceService_CreateInvoiceWithItems(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := NewMockInvoiceRepository(controller)
+	mockItemService := item.NewMockItemService(controller)
+	mockConverter := currency.NewMockConverter(controller)
+	mockRates := tax.NewMockRateProvider(controller)
+	service := NewInvoiceService(mockRepo, mockItemService, mockConverter, mockRates, nil)
+	invoiceId := uuid.New()
+	itemId := uuid.New()
+	i := item.Item{Id: itemId, UnitPrice: commons.NewMoney(1000, "EUR"), TaxCategory: "reduced"}
+	request := CreateInvoiceRequest{Id: invoiceId, UserId: uuid.New(), Total: commons.NewMoney(0, "eur"), TaxJurisdiction: "de", CreatedBy: "unit_test"}
+	items := ItemsToInvoiceRequest{Items: []uuid.UUID{itemId}, Quantities: map[uuid.UUID]int64{itemId: 2}}
+
+	mockItemService.EXPECT().GetItem(gomock.Any(), itemId).Return(&i, nil)
+	mockConverter.EXPECT().Convert(i.UnitPrice, "EUR", gomock.Any()).Return(i.UnitPrice, nil)
+	mockRates.EXPECT().EffectivePercentage("DE", "reduced", gomock.Any()).Return("7", nil)
+	line := InvoiceItemLine{InvoiceId: invoiceId, ItemId: itemId, UnitPrice: i.UnitPrice, Quantity: 2, Currency: "EUR", TaxCategory: "reduced", TaxRate: "7"}
+	// 2 x 10.00 at 7%
+	created := request
+	created.Total, created.TaxJurisdiction = commons.NewMoney(2140, "EUR"), "DE"
+	mockRepo.EXPECT().CreateInvoice(gomock.Any(), created, line).Return(InvoiceRow{Id: 1, AltId: invoiceId, Total: created.Total, Currency: "EUR"}, nil)
+
+	result, err := service.CreateInvoiceWithItems(context.Background(), request, items)
+	assert.NoError(t, err)
+	assert.Equal(t, invoiceId, result.Id)
+	assert.Equal(t, commons.NewMoney(2140, "EUR"), result.Total)
+
+	_, err = service.CreateInvoiceWithItems(context.Background(), request, ItemsToInvoiceRequest{})
+	assert.ErrorIs(t, err, ErrNoItems)
+}
+
 func TestInvoiceService_UpdateInvoice(t *testing.T) {
 	invoiceRow := InvoiceRow{
 		Id:            1,
 		AltId:         uuid.New(),
 		UserId:        uuid.New(),
-		Total:         10.0,
+		Total:         commons.NewMoney(1000, "USD"),
 		Paid:          true,
 		CreatedBy:     "Unit Test",
 		CreatedAt:     time.Now(),
@@ -307,7 +361,6 @@ func TestInvoiceService_UpdateInvoice(t *testing.T) {
 	updateInvoiceRequest := UpdateInvoiceRequest{
 		Id:            invoiceRow.AltId,
 		Paid:          true,
-		Total:         50.0,
 		LastChangedBy: "Unit Test Update",
 	}
 	emptyInvoice := Invoice{}
@@ -324,7 +377,7 @@ func TestInvoiceService_UpdateInvoice(t *testing.T) {
 			want:    invoice,
 			wantErr: false,
 			mockFunc: func(mockRepo *MockInvoiceRepository, request UpdateInvoiceRequest) {
-				mockRepo.EXPECT().UpdateInvoice(request).Return(invoiceRow, nil)
+				mockRepo.EXPECT().UpdateInvoice(gomock.Any(), request).Return(invoiceRow, nil)
 			},
 		},
 		{
@@ -333,7 +386,16 @@ func TestInvoiceService_UpdateInvoice(t *testing.T) {
 			want:    emptyInvoice,
 			wantErr: true,
 			mockFunc: func(mockRepo *MockInvoiceRepository, request UpdateInvoiceRequest) {
-				mockRepo.EXPECT().UpdateInvoice(request).Return(emptyInvoiceRow, errors.New("Repo Error"))
+				mockRepo.EXPECT().UpdateInvoice(gomock.Any(), request).Return(emptyInvoiceRow, errors.New("Repo Error"))
+			},
+		},
+		{
+			name:    "Update Invoice - Not Found",
+			request: updateInvoiceRequest,
+			want:    emptyInvoice,
+			wantErr: true,
+			mockFunc: func(mockRepo *MockInvoiceRepository, request UpdateInvoiceRequest) {
+				mockRepo.EXPECT().UpdateInvoice(gomock.Any(), request).Return(emptyInvoiceRow, sql.ErrNoRows)
 			},
 		},
 	}
@@ -342,8 +404,8 @@ func TestInvoiceService_UpdateInvoice(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := NewMockInvoiceRepository(controller)
 			tt.mockFunc(mockRepo, tt.request)
-			service := NewInvoiceService(mockRepo)
-			result, err := service.UpdateInvoice(tt.request)
+			service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+			result, err := service.UpdateInvoice(context.Background(), tt.request)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("InvoiceService.UpdateInvoice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -367,7 +429,7 @@ func TestInvoiceService_DeleteInvoice(t *testing.T) {
 		{
 			name: "Delete Invoice Successfully",
 			prepare: func(m *MockInvoiceRepository) {
-				m.EXPECT().DeleteInvoice(gomock.Any()).Return(commons.DeleteResult{Deleted: true}, nil).AnyTimes()
+				m.EXPECT().DeleteInvoice(gomock.Any(), gomock.Any()).Return(commons.DeleteResult{Deleted: true}, nil).AnyTimes()
 			},
 			want:      commons.DeleteResult{Deleted: true},
 			wantError: false,
@@ -375,7 +437,7 @@ func TestInvoiceService_DeleteInvoice(t *testing.T) {
 		{
 			name: "Delete Invoice - Repo Error",
 			prepare: func(m *MockInvoiceRepository) {
-				m.EXPECT().DeleteInvoice(gomock.Any()).Return(commons.DeleteResult{}, errors.New("Repo Error")).AnyTimes()
+				m.EXPECT().DeleteInvoice(gomock.Any(), gomock.Any()).Return(commons.DeleteResult{}, errors.New("Repo Error")).AnyTimes()
 			},
 			want:      commons.DeleteResult{},
 			wantError: true,
@@ -386,8 +448,8 @@ func TestInvoiceService_DeleteInvoice(t *testing.T) {
 			controller := gomock.NewController(t)
 			mockRepo := NewMockInvoiceRepository(controller)
 			tt.prepare(mockRepo)
-			service := NewInvoiceService(mockRepo)
-			result, err := service.DeleteInvoice(uuid.New())
+			service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+			result, err := service.DeleteInvoice(context.Background(), uuid.New())
 			if (err != nil) != tt.wantError {
 				t.Errorf("InvoiceService.DeleteInvoice() error = %v, wantErr %v", err, tt.wantError)
 			}
@@ -414,7 +476,7 @@ func TestInvoiceService_GetAllInvoices(t *testing.T) {
 			want:    make([]Invoice, 0),
 			wantErr: false,
 			mockFunc: func(mockRepo *MockInvoiceRepository) {
-				mockRepo.EXPECT().GetAll(pag).Return([]InvoiceRow{}, nil)
+				mockRepo.EXPECT().GetAll(gomock.Any(), pag).Return([]InvoiceRow{}, nil)
 			},
 		},
 		{
@@ -422,15 +484,15 @@ func TestInvoiceService_GetAllInvoices(t *testing.T) {
 			want:    make([]Invoice, 0),
 			wantErr: true,
 			mockFunc: func(mockRepo *MockInvoiceRepository) {
-				mockRepo.EXPECT().GetAll(pag).Return(nil, errors.New("Repo Error"))
+				mockRepo.EXPECT().GetAll(gomock.Any(), pag).Return(nil, errors.New("Repo Error"))
 			},
 		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc(mockRepo)
-			service := NewInvoiceService(mockRepo)
-			result, err := service.GetAllInvoices(pag)
+			service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+			result, err := service.GetAllInvoices(context.Background(), pag)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("InvoiceService.GetAllInvoices() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -447,25 +509,57 @@ func TestInvoiceService_GetAllInvoices(t *testing.T) {
 
 func TestInvoiceService_AddItemsToInvoice(t *testing.T) {
 	controller := gomock.NewController(t)
-	mockRepo := NewMockInvoiceRepository(controller)
 	invoiceUuid := uuid.New()
 	itemUuid1 := uuid.New()
 	itemUuid2 := uuid.New()
+	invoiceRow := InvoiceRow{Id: 1, AltId: invoiceUuid, Total: commons.NewMoney(0, "EUR"), Currency: "EUR", TaxJurisdiction: "DE"}
+	item1 := item.Item{Id: itemUuid1, UnitPrice: commons.NewMoney(1000, "EUR"), TaxCategory: "reduced"}
+	item2 := item.Item{Id: itemUuid2, UnitPrice: commons.NewMoney(1000, "USD")}
+	lines := []InvoiceItemLine{
+		{InvoiceId: invoiceUuid, ItemId: itemUuid1, UnitPrice: commons.NewMoney(1000, "EUR"), Quantity: 1, Currency: "EUR", TaxCategory: "reduced", TaxRate: "7"},
+		{InvoiceId: invoiceUuid, ItemId: itemUuid2, UnitPrice: commons.NewMoney(920, "EUR"), Quantity: 1, Currency: "EUR", TaxCategory: "standard", TaxRate: "19"},
+	}
+	linesRows := make([]InvoiceItemRow, len(lines))
+	for i, line := range lines {
+		linesRows[i] = InvoiceItemRow{
+			Id:              1,
+			AltId:           invoiceUuid,
+			Currency:        "EUR",
+			TaxJurisdiction: "DE",
+			ItemSeqId:       sql.NullInt64{Int64: int64(i + 1), Valid: true},
+			ItemAltId:       line.ItemId,
+			LineUnitPrice:   commons.NullMoney{Money: line.UnitPrice, Valid: true},
+			LineTaxCategory: sql.NullString{String: line.TaxCategory, Valid: true},
+			LineTaxRate:     sql.NullString{String: line.TaxRate, Valid: true},
+		}
+	}
 
 	testCases := []struct {
 		name     string
 		request  ItemsToInvoiceRequest
 		want     ItemsToInvoiceResponse
 		wantErr  bool
-		mockFunc func(mockRepo *MockInvoiceRepository, request ItemsToInvoiceRequest)
+		mockFunc func(mockRepo *MockInvoiceRepository, mockItemService *item.MockItemService, mockConverter *currency.MockConverter, mockRates *tax.MockRateProvider)
 	}{
 		{
 			name:    "Add Items To Invoice Successfully",
 			request: ItemsToInvoiceRequest{InvoiceId: invoiceUuid, Items: []uuid.UUID{itemUuid1, itemUuid2}},
 			want:    ItemsToInvoiceResponse{InvoiceId: invoiceUuid, Items: []uuid.UUID{itemUuid1, itemUuid2}, Success: true},
 			wantErr: false,
-			mockFunc: func(mockRepo *MockInvoiceRepository, request ItemsToInvoiceRequest) {
-				mockRepo.EXPECT().AddItemsToInvoice(request).Return(ItemsToInvoiceResponse{InvoiceId: invoiceUuid, Items: []uuid.UUID{itemUuid1, itemUuid2}, Success: true}, nil)
+			mockFunc: func(mockRepo *MockInvoiceRepository, mockItemService *item.MockItemService, mockConverter *currency.MockConverter, mockRates *tax.MockRateProvider) {
+				mockRepo.EXPECT().GetInvoice(gomock.Any(), invoiceUuid).Return(invoiceRow, nil)
+				mockItemService.EXPECT().GetItem(gomock.Any(), itemUuid1).Return(&item1, nil)
+				mockItemService.EXPECT().GetItem(gomock.Any(), itemUuid2).Return(&item2, nil)
+				mockConverter.EXPECT().Convert(item1.UnitPrice, "EUR", gomock.Any()).Return(commons.NewMoney(1000, "EUR"), nil)
+				mockRates.EXPECT().EffectivePercentage("DE", "reduced", gomock.Any()).Return("7", nil)
+				mockConverter.EXPECT().Convert(item2.UnitPrice, "EUR", gomock.Any()).Return(commons.NewMoney(920, "EUR"), nil)
+				mockRates.EXPECT().EffectivePercentage("DE", "standard", gomock.Any()).Return("19", nil)
+				mockRepo.EXPECT().AddItemsToInvoice(gomock.Any(), invoiceUuid, lines, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, _ []InvoiceItemLine, total TotalFunc) (ItemsToInvoiceResponse, error) {
+					amount, err := total(linesRows, nil)
+					// 10.00 at 7% plus 9.20 at 19% (1.748 rounds to 1.75)
+					assert.Equal(t, commons.NewMoney(2165, "EUR"), amount)
+					return ItemsToInvoiceResponse{InvoiceId: invoiceUuid, Items: []uuid.UUID{itemUuid1, itemUuid2}, Success: true}, err
+				})
 			},
 		},
 		{
@@ -473,17 +567,82 @@ func TestInvoiceService_AddItemsToInvoice(t *testing.T) {
 			request: ItemsToInvoiceRequest{InvoiceId: invoiceUuid, Items: []uuid.UUID{itemUuid1, itemUuid2}},
 			want:    ItemsToInvoiceResponse{},
 			wantErr: true,
-			mockFunc: func(mockRepo *MockInvoiceRepository, request ItemsToInvoiceRequest) {
-				mockRepo.EXPECT().AddItemsToInvoice(request).Return(ItemsToInvoiceResponse{}, errors.New("Repo Error"))
+			mockFunc: func(mockRepo *MockInvoiceRepository, mockItemService *item.MockItemService, mockConverter *currency.MockConverter, mockRates *tax.MockRateProvider) {
+				mockRepo.EXPECT().GetInvoice(gomock.Any(), invoiceUuid).Return(invoiceRow, nil)
+				mockItemService.EXPECT().GetItem(gomock.Any(), itemUuid1).Return(&item1, nil)
+				mockItemService.EXPECT().GetItem(gomock.Any(), itemUuid2).Return(&item2, nil)
+				mockConverter.EXPECT().Convert(item1.UnitPrice, "EUR", gomock.Any()).Return(commons.NewMoney(1000, "EUR"), nil)
+				mockRates.EXPECT().EffectivePercentage("DE", "reduced", gomock.Any()).Return("7", nil)
+				mockConverter.EXPECT().Convert(item2.UnitPrice, "EUR", gomock.Any()).Return(commons.NewMoney(920, "EUR"), nil)
+				mockRates.EXPECT().EffectivePercentage("DE", "standard", gomock.Any()).Return("19", nil)
+				mockRepo.EXPECT().AddItemsToInvoice(gomock.Any(), invoiceUuid, lines, gomock.Any()).Return(ItemsToInvoiceResponse{}, errors.New("Repo Error"))
+			},
+		},
+		{
+			name:    "Add Items To Invoice - Issued Invoice",
+			request: ItemsToInvoiceRequest{InvoiceId: invoiceUuid, Items: []uuid.UUID{itemUuid1}},
+			want:    ItemsToInvoiceResponse{},
+			wantErr: true,
+			mockFunc: func(mockRepo *MockInvoiceRepository, mockItemService *item.MockItemService, mockConverter *currency.MockConverter, mockRates *tax.MockRateProvider) {
+				mockRepo.EXPECT().GetInvoice(gomock.Any(), invoiceUuid).Return(invoiceRow, nil)
+				mockItemService.EXPECT().GetItem(gomock.Any(), itemUuid1).Return(&item1, nil)
+				mockConverter.EXPECT().Convert(item1.UnitPrice, "EUR", gomock.Any()).Return(commons.NewMoney(1000, "EUR"), nil)
+				mockRates.EXPECT().EffectivePercentage("DE", "reduced", gomock.Any()).Return("7", nil)
+				mockRepo.EXPECT().AddItemsToInvoice(gomock.Any(), invoiceUuid, lines[:1], gomock.Any()).Return(ItemsToInvoiceResponse{}, ErrInvoiceIssued)
+			},
+		},
+		{
+			name:    "Add Items To Invoice - No Exchange Rate",
+			request: ItemsToInvoiceRequest{InvoiceId: invoiceUuid, Items: []uuid.UUID{itemUuid2}},
+			want:    ItemsToInvoiceResponse{},
+			wantErr: true,
+			mockFunc: func(mockRepo *MockInvoiceRepository, mockItemService *item.MockItemService, mockConverter *currency.MockConverter, mockRates *tax.MockRateProvider) {
+				mockRepo.EXPECT().GetInvoice(gomock.Any(), invoiceUuid).Return(invoiceRow, nil)
+				mockItemService.EXPECT().GetItem(gomock.Any(), itemUuid2).Return(&item2, nil)
+				mockConverter.EXPECT().Convert(item2.UnitPrice, "EUR", gomock.Any()).Return(commons.Money{}, currency.ErrNoExchangeRate)
+			},
+		},
+		{
+			name:    "Add Items To Invoice - No Tax Rate",
+			request: ItemsToInvoiceRequest{InvoiceId: invoiceUuid, Items: []uuid.UUID{itemUuid1}},
+			want:    ItemsToInvoiceResponse{},
+			wantErr: true,
+			mockFunc: func(mockRepo *MockInvoiceRepository, mockItemService *item.MockItemService, mockConverter *currency.MockConverter, mockRates *tax.MockRateProvider) {
+				mockRepo.EXPECT().GetInvoice(gomock.Any(), invoiceUuid).Return(invoiceRow, nil)
+				mockItemService.EXPECT().GetItem(gomock.Any(), itemUuid1).Return(&item1, nil)
+				mockConverter.EXPECT().Convert(item1.UnitPrice, "EUR", gomock.Any()).Return(commons.NewMoney(1000, "EUR"), nil)
+				mockRates.EXPECT().EffectivePercentage("DE", "reduced", gomock.Any()).Return("", tax.ErrNoTaxRate)
+			},
+		},
+		{
+			name:    "Add Items To Invoice - Unknown Item",
+			request: ItemsToInvoiceRequest{InvoiceId: invoiceUuid, Items: []uuid.UUID{itemUuid1}},
+			want:    ItemsToInvoiceResponse{},
+			wantErr: true,
+			mockFunc: func(mockRepo *MockInvoiceRepository, mockItemService *item.MockItemService, mockConverter *currency.MockConverter, mockRates *tax.MockRateProvider) {
+				mockRepo.EXPECT().GetInvoice(gomock.Any(), invoiceUuid).Return(invoiceRow, nil)
+				mockItemService.EXPECT().GetItem(gomock.Any(), itemUuid1).Return(nil, sql.ErrNoRows)
+			},
+		},
+		{
+			name:    "Add Items To Invoice - No Items",
+			request: ItemsToInvoiceRequest{InvoiceId: invoiceUuid},
+			want:    ItemsToInvoiceResponse{},
+			wantErr: true,
+			mockFunc: func(mockRepo *MockInvoiceRepository, mockItemService *item.MockItemService, mockConverter *currency.MockConverter, mockRates *tax.MockRateProvider) {
 			},
 		},
 	}
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.mockFunc(mockRepo, tt.request)
-			service := NewInvoiceService(mockRepo)
-			result, err := service.AddItemsToInvoice(tt.request)
+			mockRepo := NewMockInvoiceRepository(controller)
+			mockItemService := item.NewMockItemService(controller)
+			mockConverter := currency.NewMockConverter(controller)
+			mockRates := tax.NewMockRateProvider(controller)
+			tt.mockFunc(mockRepo, mockItemService, mockConverter, mockRates)
+			service := NewInvoiceService(mockRepo, mockItemService, mockConverter, mockRates, nil)
+			result, err := service.AddItemsToInvoice(context.Background(), tt.request)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("InvoiceService.AddItemsToInvoice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -515,16 +674,29 @@ func TestInvoiceService_RemoveItemFromInvoice(t *testing.T) {
 			name:    "Remove Item From Invoice Successfully",
 			request: SimpleInvoiceItem{InvoiceId: invoiceUuid, ItemId: itemUuid},
 			mockFunc: func(mockRepo *MockInvoiceRepository) {
-				mockRepo.EXPECT().RemoveItemFromInvoice(gomock.Eq(SimpleInvoiceItem{InvoiceId: invoiceUuid, ItemId: itemUuid})).Return(ItemsToInvoiceResponse{InvoiceId: invoiceUuid, Items: []uuid.UUID{}, Success: true}, nil)
+				mockRepo.EXPECT().RemoveItemFromInvoice(gomock.Any(), gomock.Eq(SimpleInvoiceItem{InvoiceId: invoiceUuid, ItemId: itemUuid}), gomock.Any()).DoAndReturn(func(_ context.Context, _ SimpleInvoiceItem, total TotalFunc) (ItemsToInvoiceResponse, error) {
+					amount, err := total([]InvoiceItemRow{{Id: 1, AltId: invoiceUuid, Currency: "USD"}}, nil)
+					assert.Equal(t, commons.NewMoney(0, "USD"), amount)
+					return ItemsToInvoiceResponse{InvoiceId: invoiceUuid, Items: []uuid.UUID{}, Success: true}, err
+				})
 			},
 			want:    ItemsToInvoiceResponse{InvoiceId: invoiceUuid, Items: []uuid.UUID{}, Success: true},
 			wantErr: false,
 		},
+		{
+			name:    "Remove Item From Invoice - Issued Invoice",
+			request: SimpleInvoiceItem{InvoiceId: invoiceUuid, ItemId: itemUuid},
+			mockFunc: func(mockRepo *MockInvoiceRepository) {
+				mockRepo.EXPECT().RemoveItemFromInvoice(gomock.Any(), gomock.Eq(SimpleInvoiceItem{InvoiceId: invoiceUuid, ItemId: itemUuid}), gomock.Any()).Return(ItemsToInvoiceResponse{}, ErrInvoiceIssued)
+			},
+			want:    ItemsToInvoiceResponse{},
+			wantErr: true,
+		},
 		{
 			name:    "Remove Item From Invoice - Repo Error",
 			request: SimpleInvoiceItem{InvoiceId: invoiceUuid, ItemId: itemUuid},
 			mockFunc: func(mockRepo *MockInvoiceRepository) {
-				mockRepo.EXPECT().RemoveItemFromInvoice(gomock.Eq(SimpleInvoiceItem{InvoiceId: invoiceUuid, ItemId: itemUuid})).Return(ItemsToInvoiceResponse{}, errors.New("Repo Error"))
+				mockRepo.EXPECT().RemoveItemFromInvoice(gomock.Any(), gomock.Eq(SimpleInvoiceItem{InvoiceId: invoiceUuid, ItemId: itemUuid}), gomock.Any()).Return(ItemsToInvoiceResponse{}, errors.New("Repo Error"))
 			},
 			want:    ItemsToInvoiceResponse{},
 			wantErr: true,
@@ -533,8 +705,8 @@ func TestInvoiceService_RemoveItemFromInvoice(t *testing.T) {
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc(mockRepo)
-			service := NewInvoiceService(mockRepo)
-			result, err := service.RemoveItemFromInvoice(tt.request)
+			service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+			result, err := service.RemoveItemFromInvoice(context.Background(), tt.request)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("InvoiceService.RemoveItemFromInvoice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -547,3 +719,487 @@ func TestInvoiceService_RemoveItemFromInvoice(t *testing.T) {
 		})
 	}
 }
+
+func TestFromRowWithItems_Currency(t *testing.T) {
+	invoiceUuid := uuid.New()
+	itemUuid := uuid.New()
+	rows := []InvoiceItemRow{{
+		Id:            1,
+		AltId:         invoiceUuid,
+		Total:         commons.NewMoney(920, commons.DefaultCurrency),
+		Currency:      "EUR",
+		ItemSeqId:     sql.NullInt64{Int64: 1, Valid: true},
+		ItemAltId:     itemUuid,
+		ItemUnitPrice: commons.NullMoney{Money: commons.NewMoney(1000, commons.DefaultCurrency), Valid: true},
+		ItemCurrency:  sql.NullString{String: "USD", Valid: true},
+		LineUnitPrice: commons.NullMoney{Money: commons.NewMoney(920, commons.DefaultCurrency), Valid: true},
+	}}
+	result := fromRowWithItems(rows)
+	assert.Equal(t, commons.NewMoney(920, "EUR"), result.Total)
+	assert.Equal(t, commons.NewMoney(1000, "USD"), result.Items[0].UnitPrice)
+	assert.Equal(t, []InvoiceLine{{ItemId: itemUuid, UnitPrice: commons.NewMoney(920, "EUR"), Quantity: 1, TaxCategory: tax.DefaultCategory, TaxRate: tax.NoTax}}, result.Lines)
+}
+
+func TestInvoice_CalculateTax(t *testing.T) {
+	lines := func() []InvoiceLine {
+		return []InvoiceLine{
+			{UnitPrice: commons.NewMoney(1200, "GBP"), TaxCategory: "standard", TaxRate: "20"},
+			{UnitPrice: commons.NewMoney(1200, "GBP"), TaxCategory: "standard", TaxRate: "20"},
+			{UnitPrice: commons.NewMoney(500, "GBP"), TaxCategory: "zero", TaxRate: "0"},
+		}
+	}
+
+	exclusive := Invoice{Total: commons.NewMoney(0, "GBP"), Lines: lines()}
+	assert.NoError(t, exclusive.calculateTotals())
+	assert.Equal(t, tax.LineTax{Net: commons.NewMoney(1200, "GBP"), Tax: commons.NewMoney(240, "GBP"), Gross: commons.NewMoney(1440, "GBP")}, exclusive.Lines[0].LineTax)
+	assert.Equal(t, commons.NewMoney(2900, "GBP"), exclusive.Tax.Subtotal)
+	assert.Equal(t, commons.NewMoney(480, "GBP"), exclusive.Tax.TaxTotal)
+	assert.Equal(t, commons.NewMoney(3380, "GBP"), exclusive.Tax.Total)
+	assert.Equal(t, []tax.Summary{
+		{Category: "standard", Percentage: "20", Net: commons.NewMoney(2400, "GBP"), Tax: commons.NewMoney(480, "GBP")},
+		{Category: "zero", Percentage: "0", Net: commons.NewMoney(500, "GBP"), Tax: commons.NewMoney(0, "GBP")},
+	}, exclusive.Tax.Taxes)
+
+	inclusive := Invoice{Total: commons.NewMoney(0, "GBP"), PricesIncludeTax: true, Lines: lines()}
+	assert.NoError(t, inclusive.calculateTotals())
+	assert.Equal(t, tax.LineTax{Net: commons.NewMoney(1000, "GBP"), Tax: commons.NewMoney(200, "GBP"), Gross: commons.NewMoney(1200, "GBP")}, inclusive.Lines[0].LineTax)
+	assert.Equal(t, commons.NewMoney(2500, "GBP"), inclusive.Tax.Subtotal)
+	assert.Equal(t, commons.NewMoney(400, "GBP"), inclusive.Tax.TaxTotal)
+	assert.Equal(t, commons.NewMoney(2900, "GBP"), inclusive.Tax.Total)
+
+	empty := Invoice{Total: commons.NewMoney(0, "EUR")}
+	assert.NoError(t, empty.calculateTotals())
+	assert.Equal(t, commons.NewMoney(0, "EUR"), empty.Tax.Total)
+	assert.Empty(t, empty.Tax.Taxes)
+}
+
+func TestInvoice_CalculateTotals_Discounts(t *testing.T) {
+	invoice := Invoice{
+		Total: commons.NewMoney(0, "EUR"),
+		Lines: []InvoiceLine{
+			{UnitPrice: commons.NewMoney(10000, "EUR"), TaxCategory: "standard", TaxRate: "20", Discount: &promotion.Discount{Type: promotion.Percentage, Value: "10"}},
+			{UnitPrice: commons.NewMoney(5000, "EUR"), TaxCategory: "zero", TaxRate: "0"},
+		},
+		Discounts: []InvoiceDiscount{
+			{Discount: promotion.Discount{Type: promotion.FixedAmount, Value: "14.00"}},
+			{Discount: promotion.Discount{Type: promotion.Percentage, Value: "10"}},
+		},
+	}
+	assert.NoError(t, invoice.calculateTotals())
+
+	// 140.00 after line discounts, less 14.00 and then 10% of 126.00, shared 9:5 across the lines
+	assert.Equal(t, commons.NewMoney(1400, "EUR"), invoice.Discounts[0].Amount)
+	assert.Equal(t, commons.NewMoney(1260, "EUR"), invoice.Discounts[1].Amount)
+	assert.Equal(t, commons.NewMoney(2710, "EUR"), invoice.Lines[0].DiscountAmount)
+	assert.Equal(t, commons.NewMoney(950, "EUR"), invoice.Lines[1].DiscountAmount)
+	assert.Equal(t, tax.LineTax{Net: commons.NewMoney(7290, "EUR"), Tax: commons.NewMoney(1458, "EUR"), Gross: commons.NewMoney(8748, "EUR")}, invoice.Lines[0].LineTax)
+	assert.Equal(t, commons.NewMoney(15000, "EUR"), invoice.Tax.Undiscounted)
+	assert.Equal(t, commons.NewMoney(3660, "EUR"), invoice.Tax.Discount)
+	assert.Equal(t, commons.NewMoney(11340, "EUR"), invoice.Tax.Subtotal)
+	assert.Equal(t, commons.NewMoney(1458, "EUR"), invoice.Tax.TaxTotal)
+	assert.Equal(t, commons.NewMoney(12798, "EUR"), invoice.Tax.Total)
+
+	// a fixed discount never takes the invoice below zero
+	invoice.PricesIncludeTax = true
+	invoice.Discounts = []InvoiceDiscount{{Discount: promotion.Discount{Type: promotion.FixedAmount, Value: "500"}}}
+	assert.NoError(t, invoice.calculateTotals())
+	assert.Equal(t, commons.NewMoney(14000, "EUR"), invoice.Discounts[0].Amount)
+	assert.Equal(t, commons.NewMoney(0, "EUR"), invoice.Tax.Total)
+	assert.Equal(t, commons.NewMoney(15000, "EUR"), invoice.Tax.Discount)
+}
+
+func TestInvoiceService_AddDiscount(t *testing.T) {
+	controller := gomock.NewController(t)
+	invoiceUuid := uuid.New()
+	invoiceRow := InvoiceRow{Id: 1, AltId: invoiceUuid, Total: commons.NewMoney(0, "USD"), Currency: "USD"}
+	itemRows := []InvoiceItemRow{{
+		Id:            1,
+		AltId:         invoiceUuid,
+		Currency:      "USD",
+		ItemSeqId:     sql.NullInt64{Int64: 1, Valid: true},
+		ItemAltId:     uuid.New(),
+		LineUnitPrice: commons.NullMoney{Money: commons.NewMoney(2000, "USD"), Valid: true},
+	}}
+	request := InvoiceDiscountRequest{InvoiceId: invoiceUuid, Discount: promotion.Discount{Type: promotion.FixedAmount, Value: "5"}, Description: "loyalty", CreatedBy: "unit_test"}
+	discountRow := InvoiceDiscountRow{Id: 1, AltId: uuid.New(), InvoiceId: invoiceUuid, Description: "loyalty", DiscountType: "fixed", DiscountValue: "5"}
+
+	t.Run("Add Discount Successfully", func(t *testing.T) {
+		mockRepo := NewMockInvoiceRepository(controller)
+		mockRepo.EXPECT().GetInvoice(gomock.Any(), invoiceUuid).Return(invoiceRow, nil)
+		mockRepo.EXPECT().AddInvoiceDiscount(gomock.Any(), InvoiceDiscountRow{InvoiceId: invoiceUuid, Description: "loyalty", DiscountType: "fixed", DiscountValue: "5", CreatedBy: "unit_test"}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ InvoiceDiscountRow, total TotalFunc) (InvoiceDiscountRow, error) {
+				amount, err := total(itemRows, []InvoiceDiscountRow{discountRow})
+				assert.Equal(t, commons.NewMoney(1500, "USD"), amount)
+				return discountRow, err
+			})
+		service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+		result, err := service.AddDiscount(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, commons.NewMoney(1500, "USD"), result.Total)
+		assert.Equal(t, commons.NewMoney(500, "USD"), result.Discounts[0].Amount)
+	})
+
+	t.Run("Invalid Discount", func(t *testing.T) {
+		service := NewInvoiceService(NewMockInvoiceRepository(controller), nil, nil, nil, nil)
+		_, err := service.AddDiscount(context.Background(), InvoiceDiscountRequest{InvoiceId: invoiceUuid, Discount: promotion.Discount{Type: promotion.Percentage, Value: "150"}})
+		assert.ErrorIs(t, err, promotion.ErrInvalidDiscount)
+	})
+
+	t.Run("Unknown Invoice", func(t *testing.T) {
+		mockRepo := NewMockInvoiceRepository(controller)
+		mockRepo.EXPECT().GetInvoice(gomock.Any(), invoiceUuid).Return(InvoiceRow{}, sql.ErrNoRows)
+		service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+		_, err := service.AddDiscount(context.Background(), request)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("Issued Invoice", func(t *testing.T) {
+		issued := invoiceRow
+		issued.Number = sql.NullString{String: "INV-2026-000001", Valid: true}
+		mockRepo := NewMockInvoiceRepository(controller)
+		mockRepo.EXPECT().GetInvoice(gomock.Any(), invoiceUuid).Return(issued, nil)
+		service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+		_, err := service.AddDiscount(context.Background(), request)
+		assert.ErrorIs(t, err, ErrInvoiceIssued)
+	})
+
+	t.Run("Issued While Adding", func(t *testing.T) {
+		mockRepo := NewMockInvoiceRepository(controller)
+		mockRepo.EXPECT().GetInvoice(gomock.Any(), invoiceUuid).Return(invoiceRow, nil)
+		mockRepo.EXPECT().AddInvoiceDiscount(gomock.Any(), gomock.Any(), gomock.Any()).Return(InvoiceDiscountRow{}, ErrInvoiceIssued)
+		service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+		_, err := service.AddDiscount(context.Background(), request)
+		assert.ErrorIs(t, err, ErrInvoiceIssued)
+	})
+}
+
+func TestInvoiceService_RecalculateTotal(t *testing.T) {
+	controller := gomock.NewController(t)
+	invoiceUuid := uuid.New()
+	itemRows := func(total int64) []InvoiceItemRow {
+		return []InvoiceItemRow{{
+			Id:            1,
+			AltId:         invoiceUuid,
+			Total:         commons.NewMoney(total, "USD"),
+			Currency:      "USD",
+			ItemSeqId:     sql.NullInt64{Int64: 1, Valid: true},
+			ItemAltId:     uuid.New(),
+			LineUnitPrice: commons.NullMoney{Money: commons.NewMoney(2000, "USD"), Valid: true},
+		}}
+	}
+
+	t.Run("Stores A Drifted Total", func(t *testing.T) {
+		mockRepo := NewMockInvoiceRepository(controller)
+		mockRepo.EXPECT().GetInvoiceWithItems(gomock.Any(), invoiceUuid).Return(itemRows(1500), nil)
+		mockRepo.EXPECT().GetInvoiceDiscounts(gomock.Any(), invoiceUuid).Return(nil, nil)
+		mockRepo.EXPECT().CorrectInvoiceTotal(gomock.Any(), invoiceUuid, commons.NewMoney(2000, "USD")).Return(InvoiceRow{}, nil)
+		service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+		var notified []commons.Change
+		service.OnChange(func(change commons.Change) { notified = append(notified, change) })
+		result, changed, err := service.RecalculateTotal(context.Background(), invoiceUuid)
+		assert.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, commons.NewMoney(2000, "USD"), result.Total)
+		assert.Len(t, notified, 1)
+	})
+
+	t.Run("Leaves A Correct Total Alone", func(t *testing.T) {
+		mockRepo := NewMockInvoiceRepository(controller)
+		mockRepo.EXPECT().GetInvoiceWithItems(gomock.Any(), invoiceUuid).Return(itemRows(2000), nil)
+		mockRepo.EXPECT().GetInvoiceDiscounts(gomock.Any(), invoiceUuid).Return(nil, nil)
+		service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+		_, changed, err := service.RecalculateTotal(context.Background(), invoiceUuid)
+		assert.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("Only Reports The Total Of An Issued Invoice", func(t *testing.T) {
+		issued := itemRows(1500)
+		issued[0].Number = sql.NullString{String: "INV-2026-000001", Valid: true}
+		mockRepo := NewMockInvoiceRepository(controller)
+		mockRepo.EXPECT().GetInvoiceWithItems(gomock.Any(), invoiceUuid).Return(issued, nil)
+		mockRepo.EXPECT().GetInvoiceDiscounts(gomock.Any(), invoiceUuid).Return(nil, nil)
+		service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+		result, changed, err := service.RecalculateTotal(context.Background(), invoiceUuid)
+		assert.ErrorIs(t, err, ErrInvoiceIssued)
+		assert.False(t, changed)
+		assert.Equal(t, commons.NewMoney(1500, "USD"), result.Total)
+		assert.Equal(t, commons.NewMoney(2000, "USD"), result.Tax.Total)
+	})
+
+	t.Run("Unknown Invoice", func(t *testing.T) {
+		mockRepo := NewMockInvoiceRepository(controller)
+		mockRepo.EXPECT().GetInvoiceWithItems(gomock.Any(), invoiceUuid).Return(nil, nil)
+		service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+		_, _, err := service.RecalculateTotal(context.Background(), invoiceUuid)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestInvoiceService_ApplyPromotion(t *testing.T) {
+	invoiceUuid := uuid.New()
+	promotionUuid := uuid.New()
+	minOrderAmount := commons.NewMoney(2500, "USD")
+	promo := promotion.Promotion{
+		Id:             promotionUuid,
+		Code:           "SPRING",
+		Description:    "spring sale",
+		Discount:       promotion.Discount{Type: promotion.FixedAmount, Value: "5.00"},
+		Currency:       "USD",
+		MinOrderAmount: &minOrderAmount,
+	}
+	itemRows := []InvoiceItemRow{{
+		Id:            1,
+		AltId:         invoiceUuid,
+		Currency:      "EUR",
+		ItemSeqId:     sql.NullInt64{Int64: 1, Valid: true},
+		ItemAltId:     uuid.New(),
+		LineUnitPrice: commons.NullMoney{Money: commons.NewMoney(3000, "EUR"), Valid: true},
+	}}
+	applied := InvoiceDiscountRow{
+		Id:            1,
+		AltId:         uuid.New(),
+		InvoiceId:     invoiceUuid,
+		PromotionId:   uuid.NullUUID{UUID: promotionUuid, Valid: true},
+		Code:          sql.NullString{String: "SPRING", Valid: true},
+		Description:   "spring sale",
+		DiscountType:  "fixed",
+		DiscountValue: "4.60",
+	}
+	request := PromotionCodeRequest{InvoiceId: invoiceUuid, Code: "spring", CreatedBy: "unit_test"}
+	testCases := []struct {
+		name     string
+		wantErr  error
+		mockFunc func(mockRepo *MockInvoiceRepository, mockConverter *currency.MockConverter, mockPromotions *promotion.MockValidator)
+	}{
+		{
+			name: "Apply Promotion Successfully",
+			mockFunc: func(mockRepo *MockInvoiceRepository, mockConverter *currency.MockConverter, mockPromotions *promotion.MockValidator) {
+				mockPromotions.EXPECT().ActivePromotion(gomock.Any(), "spring", gomock.Any()).Return(promo, nil)
+				mockRepo.EXPECT().GetInvoiceWithItems(gomock.Any(), invoiceUuid).Return(itemRows, nil)
+				mockRepo.EXPECT().GetInvoiceDiscounts(gomock.Any(), invoiceUuid).Return(nil, nil)
+				mockConverter.EXPECT().Convert(minOrderAmount, "EUR", gomock.Any()).Return(commons.NewMoney(2300, "EUR"), nil)
+				mockConverter.EXPECT().Convert(commons.NewMoney(500, "USD"), "EUR", gomock.Any()).Return(commons.NewMoney(460, "EUR"), nil)
+				discount := applied
+				discount.Id, discount.AltId, discount.CreatedBy = 0, uuid.Nil, "unit_test"
+				mockRepo.EXPECT().AddInvoiceDiscount(gomock.Any(), discount, gomock.Any()).DoAndReturn(func(_ context.Context, _ InvoiceDiscountRow, total TotalFunc) (InvoiceDiscountRow, error) {
+					amount, err := total(itemRows, []InvoiceDiscountRow{applied})
+					assert.Equal(t, commons.NewMoney(2540, "EUR"), amount)
+					return applied, err
+				})
+			},
+		},
+		{
+			name:    "Unknown Code",
+			wantErr: promotion.ErrUnknownPromotion,
+			mockFunc: func(mockRepo *MockInvoiceRepository, mockConverter *currency.MockConverter, mockPromotions *promotion.MockValidator) {
+				mockPromotions.EXPECT().ActivePromotion(gomock.Any(), "spring", gomock.Any()).Return(promotion.Promotion{}, promotion.ErrUnknownPromotion)
+			},
+		},
+		{
+			name:    "Already Applied",
+			wantErr: ErrPromotionAlreadyApplied,
+			mockFunc: func(mockRepo *MockInvoiceRepository, mockConverter *currency.MockConverter, mockPromotions *promotion.MockValidator) {
+				mockPromotions.EXPECT().ActivePromotion(gomock.Any(), "spring", gomock.Any()).Return(promo, nil)
+				mockRepo.EXPECT().GetInvoiceWithItems(gomock.Any(), invoiceUuid).Return(itemRows, nil)
+				mockRepo.EXPECT().GetInvoiceDiscounts(gomock.Any(), invoiceUuid).Return([]InvoiceDiscountRow{applied}, nil)
+			},
+		},
+		{
+			name:    "Issued Invoice",
+			wantErr: ErrInvoiceIssued,
+			mockFunc: func(mockRepo *MockInvoiceRepository, mockConverter *currency.MockConverter, mockPromotions *promotion.MockValidator) {
+				issued := slices.Clone(itemRows)
+				issued[0].Number = sql.NullString{String: "INV-2026-000001", Valid: true}
+				mockPromotions.EXPECT().ActivePromotion(gomock.Any(), "spring", gomock.Any()).Return(promo, nil)
+				mockRepo.EXPECT().GetInvoiceWithItems(gomock.Any(), invoiceUuid).Return(issued, nil)
+				mockRepo.EXPECT().GetInvoiceDiscounts(gomock.Any(), invoiceUuid).Return(nil, nil)
+			},
+		},
+		{
+			name:    "Below Minimum Order",
+			wantErr: ErrBelowMinimumOrder,
+			mockFunc: func(mockRepo *MockInvoiceRepository, mockConverter *currency.MockConverter, mockPromotions *promotion.MockValidator) {
+				mockPromotions.EXPECT().ActivePromotion(gomock.Any(), "spring", gomock.Any()).Return(promo, nil)
+				mockRepo.EXPECT().GetInvoiceWithItems(gomock.Any(), invoiceUuid).Return(itemRows, nil)
+				mockRepo.EXPECT().GetInvoiceDiscounts(gomock.Any(), invoiceUuid).Return(nil, nil)
+				mockConverter.EXPECT().Convert(minOrderAmount, "EUR", gomock.Any()).Return(commons.NewMoney(3001, "EUR"), nil)
+			},
+		},
+		{
+			name:    "Used Up",
+			wantErr: promotion.ErrPromotionExhausted,
+			mockFunc: func(mockRepo *MockInvoiceRepository, mockConverter *currency.MockConverter, mockPromotions *promotion.MockValidator) {
+				mockPromotions.EXPECT().ActivePromotion(gomock.Any(), "spring", gomock.Any()).Return(promo, nil)
+				mockRepo.EXPECT().GetInvoiceWithItems(gomock.Any(), invoiceUuid).Return(itemRows, nil)
+				mockRepo.EXPECT().GetInvoiceDiscounts(gomock.Any(), invoiceUuid).Return(nil, nil)
+				mockConverter.EXPECT().Convert(minOrderAmount, "EUR", gomock.Any()).Return(commons.NewMoney(2300, "EUR"), nil)
+				mockConverter.EXPECT().Convert(commons.NewMoney(500, "USD"), "EUR", gomock.Any()).Return(commons.NewMoney(460, "EUR"), nil)
+				mockRepo.EXPECT().AddInvoiceDiscount(gomock.Any(), gomock.Any(), gomock.Any()).Return(InvoiceDiscountRow{}, promotion.ErrPromotionExhausted)
+			},
+		},
+	}
+	controller := gomock.NewController(t)
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := NewMockInvoiceRepository(controller)
+			mockConverter := currency.NewMockConverter(controller)
+			mockPromotions := promotion.NewMockValidator(controller)
+			tt.mockFunc(mockRepo, mockConverter, mockPromotions)
+			service := NewInvoiceService(mockRepo, nil, mockConverter, nil, mockPromotions)
+			result, err := service.ApplyPromotion(context.Background(), request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, commons.NewMoney(2540, "EUR"), result.Total)
+				assert.Equal(t, "SPRING", result.Discounts[0].Code)
+				assert.Equal(t, commons.NewMoney(460, "EUR"), result.Tax.Discount)
+			}
+		})
+	}
+}
+
+func TestInvoiceService_AddItemsToInvoice_InvalidLineDiscount(t *testing.T) {
+	controller := gomock.NewController(t)
+	service := NewInvoiceService(NewMockInvoiceRepository(controller), nil, nil, nil, nil)
+	itemUuid := uuid.New()
+
+	_, err := service.AddItemsToInvoice(context.Background(), ItemsToInvoiceRequest{InvoiceId: uuid.New(), Items: []uuid.UUID{itemUuid},
+		Discounts: map[uuid.UUID]promotion.Discount{uuid.New(): {Type: promotion.Percentage, Value: "10"}}})
+	assert.ErrorIs(t, err, promotion.ErrInvalidDiscount)
+
+	_, err = service.AddItemsToInvoice(context.Background(), ItemsToInvoiceRequest{InvoiceId: uuid.New(), Items: []uuid.UUID{itemUuid},
+		Discounts: map[uuid.UUID]promotion.Discount{itemUuid: {Type: "bogof", Value: "1"}}})
+	assert.ErrorIs(t, err, promotion.ErrInvalidDiscount)
+}
+
+func TestInvoiceService_IssueInvoice(t *testing.T) {
+	controller := gomock.NewController(t)
+	id := uuid.New()
+	issuedAt := time.Date(2026, time.March, 14, 9, 30, 0, 0, time.UTC)
+	row := InvoiceRow{Id: 1, AltId: id, Currency: "USD", Number: sql.NullString{String: "ACME-00000042", Valid: true}, IssuedAt: sql.NullTime{Time: issuedAt, Valid: true}}
+	request := IssueInvoiceRequest{Id: id, IssuedBy: "unit_test"}
+
+	t.Run("Issue Invoice Successfully", func(t *testing.T) {
+		mockRepo := NewMockInvoiceRepository(controller)
+		service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+		service.numbers = NumberFormat{Prefix: "ACME", Padding: 8}
+		mockRepo.EXPECT().IssueInvoice(gomock.Any(), request, service.numbers, gomock.Any()).Return(row, nil)
+		result, err := service.IssueInvoice(context.Background(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, "ACME-00000042", result.Number)
+		assert.Equal(t, "2026-03-14T09:30:00Z", result.IssuedAt)
+	})
+
+	t.Run("Unknown Invoice", func(t *testing.T) {
+		mockRepo := NewMockInvoiceRepository(controller)
+		mockRepo.EXPECT().IssueInvoice(gomock.Any(), request, DefaultNumberFormat, gomock.Any()).Return(InvoiceRow{}, sql.ErrNoRows)
+		service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+		_, err := service.IssueInvoice(context.Background(), request)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestInvoiceService_GetInvoiceByNumber(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := NewMockInvoiceRepository(controller)
+	id := uuid.New()
+	mockRepo.EXPECT().GetInvoiceByNumber(gomock.Any(), "INV-2026-000123").Return(InvoiceRow{Id: 1, AltId: id, Number: sql.NullString{String: "INV-2026-000123", Valid: true}}, nil)
+	mockRepo.EXPECT().GetInvoiceByNumber(gomock.Any(), "INV-2026-999999").Return(InvoiceRow{}, sql.ErrNoRows)
+	service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+
+	result, err := service.GetInvoiceByNumber(context.Background(), " INV-2026-000123 ")
+	assert.NoError(t, err)
+	assert.Equal(t, id, result.Id)
+
+	_, err = service.GetInvoiceByNumber(context.Background(), "INV-2026-999999")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestInvoice_CalculateTotals_Quantities(t *testing.T) {
+	invoice := Invoice{
+		Total: commons.NewMoney(0, "EUR"),
+		Lines: []InvoiceLine{
+			{UnitPrice: commons.NewMoney(1000, "EUR"), Quantity: 3, TaxCategory: "reduced", TaxRate: "7", Discount: &promotion.Discount{Type: promotion.FixedAmount, Value: "5"}},
+			{UnitPrice: commons.NewMoney(920, "EUR"), Quantity: 1, TaxCategory: "standard", TaxRate: "19"},
+		},
+	}
+	assert.NoError(t, invoice.calculateTotals())
+
+	// a fixed line discount comes off the line amount, not every unit
+	assert.Equal(t, commons.NewMoney(500, "EUR"), invoice.Lines[0].DiscountAmount)
+	assert.Equal(t, tax.LineTax{Net: commons.NewMoney(2500, "EUR"), Tax: commons.NewMoney(175, "EUR"), Gross: commons.NewMoney(2675, "EUR")}, invoice.Lines[0].LineTax)
+	assert.Equal(t, commons.NewMoney(3920, "EUR"), invoice.Tax.Undiscounted)
+	assert.Equal(t, commons.NewMoney(3770, "EUR"), invoice.Tax.Total)
+}
+
+func TestInvoiceService_AddItemsToInvoice_InvalidQuantity(t *testing.T) {
+	controller := gomock.NewController(t)
+	service := NewInvoiceService(NewMockInvoiceRepository(controller), nil, nil, nil, nil)
+	itemUuid := uuid.New()
+
+	_, err := service.AddItemsToInvoice(context.Background(), ItemsToInvoiceRequest{InvoiceId: uuid.New(), Items: []uuid.UUID{itemUuid},
+		Quantities: map[uuid.UUID]int64{itemUuid: 0}})
+	assert.ErrorIs(t, err, ErrInvalidQuantity)
+
+	_, err = service.AddItemsToInvoice(context.Background(), ItemsToInvoiceRequest{InvoiceId: uuid.New(), Items: []uuid.UUID{itemUuid},
+		Quantities: map[uuid.UUID]int64{uuid.New(): 2}})
+	assert.ErrorIs(t, err, ErrInvalidQuantity)
+}
+
+func TestInvoiceService_GetInvoicesForUsers(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := NewMockInvoiceRepository(controller)
+	service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+	userIds := []uuid.UUID{uuid.New(), uuid.New()}
+	rows := []InvoiceRow{{Id: 1, AltId: uuid.New(), UserId: userIds[0]}, {Id: 2, AltId: uuid.New(), UserId: userIds[1]}}
+
+	mockRepo.EXPECT().GetAllForUsers(gomock.Any(), userIds).Return(rows, nil)
+	invoices, err := service.GetInvoicesForUsers(context.Background(), userIds)
+	assert.NoError(t, err)
+	assert.Equal(t, fromRows(rows), invoices)
+
+	mockRepo.EXPECT().GetAllForUsers(gomock.Any(), userIds).Return(nil, errors.New("Boom"))
+	_, err = service.GetInvoicesForUsers(context.Background(), userIds)
+	assert.Error(t, err)
+}
+
+func TestInvoiceService_GetInvoicesWithItems(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := NewMockInvoiceRepository(controller)
+	service := NewInvoiceService(mockRepo, nil, nil, nil, nil)
+	first, second := uuid.New(), uuid.New()
+	itemRow := func(seq int64, invoiceId uuid.UUID, itemSeq int64) InvoiceItemRow {
+		return InvoiceItemRow{
+			Id:            seq,
+			AltId:         invoiceId,
+			Total:         commons.NewMoney(2000, "USD"),
+			ItemSeqId:     sql.NullInt64{Int64: itemSeq, Valid: true},
+			ItemAltId:     uuid.New(),
+			ItemUnitPrice: commons.NullMoney{Money: commons.NewMoney(1000, "USD"), Valid: true},
+			LineUnitPrice: commons.NullMoney{Money: commons.NewMoney(1000, "USD"), Valid: true},
+		}
+	}
+	rows := []InvoiceItemRow{itemRow(1, first, 1), itemRow(1, first, 2), itemRow(2, second, 3)}
+	discount := InvoiceDiscountRow{AltId: uuid.New(), InvoiceId: second, DiscountType: "percentage", DiscountValue: "10"}
+
+	mockRepo.EXPECT().GetInvoicesWithItems(gomock.Any(), []uuid.UUID{first, second}).Return(rows, nil)
+	mockRepo.EXPECT().GetDiscountsForInvoices(gomock.Any(), []uuid.UUID{first, second}).Return([]InvoiceDiscountRow{discount}, nil)
+	invoices, err := service.GetInvoicesWithItems(context.Background(), []uuid.UUID{first, second})
+	assert.NoError(t, err)
+	if assert.Len(t, invoices, 2) {
+		assert.Equal(t, first, invoices[0].Id)
+		assert.Len(t, invoices[0].Items, 2)
+		assert.Len(t, invoices[0].Lines, 2)
+		assert.Empty(t, invoices[0].Discounts)
+		assert.Equal(t, second, invoices[1].Id)
+		assert.Len(t, invoices[1].Items, 1)
+		assert.Len(t, invoices[1].Discounts, 1)
+		assert.Equal(t, "9.00", invoices[1].Tax.Total.Amount())
+	}
+
+	mockRepo.EXPECT().GetInvoicesWithItems(gomock.Any(), gomock.Any()).Return(nil, errors.New("Boom"))
+	_, err = service.GetInvoicesWithItems(context.Background(), []uuid.UUID{first})
+	assert.Error(t, err)
+}
@@ -0,0 +1,132 @@
+package invoice
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"inventory-service-go/commons"
+	"inventory-service-go/tracing"
+)
+
+// ProvideInvoiceService traces the calls of the service.
+func ProvideInvoiceService(service *InvoiceServiceImpl) InvoiceService {
+	return NewTracingInvoiceService(service)
+}
+
+// TracingInvoiceService gives every call of the decorated service a span of its own.
+type TracingInvoiceService struct {
+	service InvoiceService
+}
+
+func NewTracingInvoiceService(service InvoiceService) *TracingInvoiceService {
+	return &TracingInvoiceService{
+		service: service,
+	}
+}
+
+func invoiceId(id uuid.UUID) attribute.KeyValue {
+	return attribute.String("invoice.id", id.String())
+}
+
+// OnChange observes the changes of the decorated service.
+func (s *TracingInvoiceService) OnChange(hook commons.ChangeHook) {
+	if notifier, ok := s.service.(commons.ChangeNotifier); ok {
+		notifier.OnChange(hook)
+	}
+}
+
+func (s *TracingInvoiceService) GetInvoice(ctx context.Context, id uuid.UUID, withItems bool) (invoice Invoice, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.GetInvoice", invoiceId(id), attribute.Bool("invoice.with_items", withItems))
+	defer func() { tracing.End(span, err) }()
+	return s.service.GetInvoice(ctx, id, withItems)
+}
+
+func (s *TracingInvoiceService) GetInvoicesForUser(ctx context.Context, userId uuid.UUID) (invoices []Invoice, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.GetInvoicesForUser", attribute.String("user.id", userId.String()))
+	defer func() { tracing.End(span, err) }()
+	return s.service.GetInvoicesForUser(ctx, userId)
+}
+
+func (s *TracingInvoiceService) GetInvoicesForUsers(ctx context.Context, userIds []uuid.UUID) (invoices []Invoice, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.GetInvoicesForUsers", attribute.Int("user.count", len(userIds)))
+	defer func() { tracing.End(span, err) }()
+	return s.service.GetInvoicesForUsers(ctx, userIds)
+}
+
+func (s *TracingInvoiceService) GetInvoicesWithItems(ctx context.Context, ids []uuid.UUID) (invoices []Invoice, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.GetInvoicesWithItems", attribute.Int("invoice.count", len(ids)))
+	defer func() { tracing.End(span, err) }()
+	return s.service.GetInvoicesWithItems(ctx, ids)
+}
+
+func (s *TracingInvoiceService) CreateInvoice(ctx context.Context, request CreateInvoiceRequest) (invoice Invoice, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.CreateInvoice")
+	defer func() { tracing.End(span, err) }()
+	return s.service.CreateInvoice(ctx, request)
+}
+
+func (s *TracingInvoiceService) CreateInvoiceWithItems(ctx context.Context, request CreateInvoiceRequest, items ItemsToInvoiceRequest) (invoice Invoice, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.CreateInvoiceWithItems", attribute.Int("invoice.items", len(items.Items)))
+	defer func() { tracing.End(span, err) }()
+	return s.service.CreateInvoiceWithItems(ctx, request, items)
+}
+
+func (s *TracingInvoiceService) UpdateInvoice(ctx context.Context, request UpdateInvoiceRequest) (invoice Invoice, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.UpdateInvoice", invoiceId(request.Id))
+	defer func() { tracing.End(span, err) }()
+	return s.service.UpdateInvoice(ctx, request)
+}
+
+func (s *TracingInvoiceService) DeleteInvoice(ctx context.Context, id uuid.UUID) (result commons.DeleteResult, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.DeleteInvoice", invoiceId(id))
+	defer func() { tracing.End(span, err) }()
+	return s.service.DeleteInvoice(ctx, id)
+}
+
+func (s *TracingInvoiceService) GetAllInvoices(ctx context.Context, pagination *commons.Pagination) (invoices []Invoice, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.GetAllInvoices")
+	defer func() { tracing.End(span, err) }()
+	return s.service.GetAllInvoices(ctx, pagination)
+}
+
+func (s *TracingInvoiceService) AddItemsToInvoice(ctx context.Context, request ItemsToInvoiceRequest) (response ItemsToInvoiceResponse, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.AddItemsToInvoice", invoiceId(request.InvoiceId), attribute.Int("invoice.items", len(request.Items)))
+	defer func() { tracing.End(span, err) }()
+	return s.service.AddItemsToInvoice(ctx, request)
+}
+
+func (s *TracingInvoiceService) RemoveItemFromInvoice(ctx context.Context, request SimpleInvoiceItem) (response ItemsToInvoiceResponse, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.RemoveItemFromInvoice", invoiceId(request.InvoiceId), attribute.String("item.id", request.ItemId.String()))
+	defer func() { tracing.End(span, err) }()
+	return s.service.RemoveItemFromInvoice(ctx, request)
+}
+
+func (s *TracingInvoiceService) AddDiscount(ctx context.Context, request InvoiceDiscountRequest) (invoice Invoice, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.AddDiscount", invoiceId(request.InvoiceId))
+	defer func() { tracing.End(span, err) }()
+	return s.service.AddDiscount(ctx, request)
+}
+
+func (s *TracingInvoiceService) ApplyPromotion(ctx context.Context, request PromotionCodeRequest) (invoice Invoice, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.ApplyPromotion", invoiceId(request.InvoiceId))
+	defer func() { tracing.End(span, err) }()
+	return s.service.ApplyPromotion(ctx, request)
+}
+
+func (s *TracingInvoiceService) IssueInvoice(ctx context.Context, request IssueInvoiceRequest) (invoice Invoice, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.IssueInvoice", invoiceId(request.Id))
+	defer func() { tracing.End(span, err) }()
+	return s.service.IssueInvoice(ctx, request)
+}
+
+func (s *TracingInvoiceService) GetInvoiceByNumber(ctx context.Context, number string) (invoice Invoice, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.GetInvoiceByNumber", attribute.String("invoice.number", number))
+	defer func() { tracing.End(span, err) }()
+	return s.service.GetInvoiceByNumber(ctx, number)
+}
+
+func (s *TracingInvoiceService) RecalculateTotal(ctx context.Context, id uuid.UUID) (invoice Invoice, changed bool, err error) {
+	ctx, span := tracing.Start(ctx, "InvoiceService.RecalculateTotal", invoiceId(id))
+	defer func() { tracing.End(span, err) }()
+	return s.service.RecalculateTotal(ctx, id)
+}
@@ -0,0 +1,50 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"testing"
+)
+
+func TestTracingInvoiceService(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer provider.Shutdown(context.Background())
+
+	controller := gomock.NewController(t)
+	mockService := NewMockInvoiceService(controller)
+	service := NewTracingInvoiceService(mockService)
+	id := uuid.New()
+	var spanContext trace.SpanContext
+	mockService.EXPECT().GetInvoice(gomock.Any(), id, true).DoAndReturn(func(ctx context.Context, id uuid.UUID, withItems bool) (Invoice, error) {
+		spanContext = trace.SpanContextFromContext(ctx)
+		return Invoice{Id: id}, nil
+	})
+	mockService.EXPECT().DeleteInvoice(gomock.Any(), id).Return(commons.DeleteResult{}, errors.New("BOOM"))
+
+	got, err := service.GetInvoice(context.Background(), id, true)
+	assert.Nil(t, err)
+	assert.Equal(t, id, got.Id)
+	_, err = service.DeleteInvoice(context.Background(), id)
+	assert.NotNil(t, err)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "InvoiceService.GetInvoice", spans[0].Name())
+		// the decorated service runs within the span, so its SQL statements are children of it
+		assert.Equal(t, spans[0].SpanContext().SpanID(), spanContext.SpanID())
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+		assert.Equal(t, "InvoiceService.DeleteInvoice", spans[1].Name())
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+	}
+}
@@ -5,16 +5,40 @@ package invoice
 
 import (
 	"github.com/google/wire"
+	"inventory-service-go/cache"
 	"inventory-service-go/commons"
+	"inventory-service-go/config"
+	"inventory-service-go/currency"
+	"inventory-service-go/item"
+	"inventory-service-go/promotion"
+	"inventory-service-go/tax"
 )
 
-func InitializeInvoiceService() (InvoiceService, error) {
+func InitializeInvoiceService(items cache.Config, numbers config.InvoiceNumbers) (InvoiceService, error) {
 	wire.Build(
-		NewInvoiceService,
+		ProvideInvoiceService,
+		NewFromConfig,
 		NewInvoiceRepository,
+		item.ProvideItemService,
+		item.NewItemService,
+		item.NewItemRepository,
+		currency.NewExchangeRateService,
+		currency.NewExchangeRateRepository,
+		tax.NewTaxRateService,
+		tax.NewTaxRateRepository,
+		promotion.NewPromotionService,
+		promotion.NewPromotionRepository,
+		cache.GetBus,
 		commons.GetDB,
-		wire.Bind(new(InvoiceService), new(*InvoiceServiceImpl)),
 		wire.Bind(new(InvoiceRepository), new(*InvoiceRepositoryImpl)),
+		wire.Bind(new(item.ItemRepository), new(*item.ItemRepositoryImpl)),
+		wire.Bind(new(cache.Invalidator), new(*cache.Bus)),
+		wire.Bind(new(currency.ExchangeRateRepository), new(*currency.ExchangeRateRepositoryImpl)),
+		wire.Bind(new(currency.Converter), new(*currency.ExchangeRateServiceImpl)),
+		wire.Bind(new(tax.TaxRateRepository), new(*tax.TaxRateRepositoryImpl)),
+		wire.Bind(new(tax.RateProvider), new(*tax.TaxRateServiceImpl)),
+		wire.Bind(new(promotion.PromotionRepository), new(*promotion.PromotionRepositoryImpl)),
+		wire.Bind(new(promotion.Validator), new(*promotion.PromotionServiceImpl)),
 	)
 	return nil, nil
 }
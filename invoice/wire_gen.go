@@ -7,14 +7,31 @@
 package invoice
 
 import (
+	"inventory-service-go/cache"
 	"inventory-service-go/commons"
+	"inventory-service-go/config"
+	"inventory-service-go/currency"
+	"inventory-service-go/item"
+	"inventory-service-go/promotion"
+	"inventory-service-go/tax"
 )
 
 // Injectors from wire.go:
 
-func InitializeInvoiceService() (InvoiceService, error) {
+func InitializeInvoiceService(items cache.Config, numbers config.InvoiceNumbers) (InvoiceService, error) {
 	db := commons.GetDB()
 	invoiceRepositoryImpl := NewInvoiceRepository(db)
-	invoiceServiceImpl := NewInvoiceService(invoiceRepositoryImpl)
-	return invoiceServiceImpl, nil
+	itemRepositoryImpl := item.NewItemRepository(db)
+	itemServiceImpl := item.NewItemService(itemRepositoryImpl)
+	bus := cache.GetBus(db)
+	itemService := item.ProvideItemService(itemServiceImpl, bus, items)
+	exchangeRateRepositoryImpl := currency.NewExchangeRateRepository(db)
+	exchangeRateServiceImpl := currency.NewExchangeRateService(exchangeRateRepositoryImpl)
+	taxRateRepositoryImpl := tax.NewTaxRateRepository(db)
+	taxRateServiceImpl := tax.NewTaxRateService(taxRateRepositoryImpl)
+	promotionRepositoryImpl := promotion.NewPromotionRepository(db)
+	promotionServiceImpl := promotion.NewPromotionService(promotionRepositoryImpl)
+	invoiceServiceImpl := NewFromConfig(invoiceRepositoryImpl, itemService, exchangeRateServiceImpl, taxRateServiceImpl, promotionServiceImpl, numbers)
+	invoiceService := ProvideInvoiceService(invoiceServiceImpl)
+	return invoiceService, nil
 }
@@ -0,0 +1,97 @@
+package item
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-service-go/cache"
+	"inventory-service-go/commons"
+	"inventory-service-go/logging"
+	"sync"
+)
+
+// CacheName names the item cache in invalidations and stats.
+const CacheName = "items"
+
+var (
+	itemCache     *cache.TenantLRU[Item]
+	itemCacheOnce sync.Once
+)
+
+// ProvideItemService puts the read-through cache in front of the service unless its Size is 0, and traces the
+// calls in front of the cache, so cache hits are traced too. Every item service of the process shares the one cache.
+func ProvideItemService(service *ItemServiceImpl, invalidator cache.Invalidator, settings cache.Config) ItemService {
+	if !settings.Enabled() {
+		return NewTracingItemService(service)
+	}
+	itemCacheOnce.Do(func() {
+		itemCache = cache.NewTenantLRU[Item](CacheName, settings)
+		invalidator.Register(CacheName, func(key string) {
+			if id, err := uuid.Parse(key); err == nil {
+				itemCache.Remove(id)
+			}
+		}, itemCache.Purge)
+	})
+	return NewTracingItemService(NewCachingItemService(service, itemCache, invalidator))
+}
+
+// CachingItemService answers GetItem from the cache and invalidates an item on every replica when it is updated or
+// deleted.
+type CachingItemService struct {
+	service     ItemService
+	cache       *cache.TenantLRU[Item]
+	invalidator cache.Invalidator
+}
+
+func NewCachingItemService(service ItemService, cache *cache.TenantLRU[Item], invalidator cache.Invalidator) *CachingItemService {
+	return &CachingItemService{
+		service:     service,
+		cache:       cache,
+		invalidator: invalidator,
+	}
+}
+
+// OnChange observes the changes of the decorated service.
+func (s *CachingItemService) OnChange(hook commons.ChangeHook) {
+	if notifier, ok := s.service.(commons.ChangeNotifier); ok {
+		notifier.OnChange(hook)
+	}
+}
+
+func (s *CachingItemService) CreateItem(ctx context.Context, request CreateItemRequest) (*Item, error) {
+	return s.service.CreateItem(ctx, request)
+}
+
+func (s *CachingItemService) UpdateItem(ctx context.Context, request UpdateItemRequest) (*Item, error) {
+	defer s.invalidate(ctx, request.Id)
+	return s.service.UpdateItem(ctx, request)
+}
+
+func (s *CachingItemService) DeleteItem(ctx context.Context, id uuid.UUID) (*commons.DeleteResult, error) {
+	defer s.invalidate(ctx, id)
+	return s.service.DeleteItem(ctx, id)
+}
+
+func (s *CachingItemService) GetItem(ctx context.Context, id uuid.UUID) (*Item, error) {
+	if i, ok := s.cache.Get(ctx, id); ok {
+		return &i, nil
+	}
+	generation := s.cache.Generation()
+	i, err := s.service.GetItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.AddSince(ctx, id, *i, generation)
+	return i, nil
+}
+
+func (s *CachingItemService) GetItems(ctx context.Context, pagination *commons.Pagination) ([]Item, error) {
+	return s.service.GetItems(ctx, pagination)
+}
+
+// invalidate also runs when the change failed, it may have been written anyway.
+func (s *CachingItemService) invalidate(ctx context.Context, id uuid.UUID) {
+	s.cache.Remove(id)
+	if err := s.invalidator.Invalidate(CacheName, id.String()); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "Error invalidating the item on the other replicas", "item_id", id, "error", err)
+	}
+}
@@ -0,0 +1,103 @@
+package item
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/cache"
+	"inventory-service-go/commons"
+	"testing"
+)
+
+func newCachingItemService(t *testing.T) (*CachingItemService, *MockItemService, *cache.MockInvalidator) {
+	controller := gomock.NewController(t)
+	mockService := NewMockItemService(controller)
+	mockInvalidator := cache.NewMockInvalidator(controller)
+	c := cache.NewTenantLRU[Item](CacheName, cache.Config{Size: 10, TTL: cache.DefaultTTL})
+	return NewCachingItemService(mockService, c, mockInvalidator), mockService, mockInvalidator
+}
+
+func TestProvideItemService(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockInvalidator := cache.NewMockInvalidator(controller)
+	service := NewItemService(nil)
+
+	plain := ProvideItemService(service, mockInvalidator, cache.Config{})
+	assert.IsType(t, &TracingItemService{}, plain)
+	assert.Same(t, service, plain.(*TracingItemService).service)
+
+	mockInvalidator.EXPECT().Register(CacheName, gomock.Any(), gomock.Any()).Times(1)
+	cached := ProvideItemService(service, mockInvalidator, cache.Config{Size: 10, TTL: cache.DefaultTTL})
+	assert.IsType(t, &CachingItemService{}, cached.(*TracingItemService).service)
+	// the cache is shared
+	ProvideItemService(service, mockInvalidator, cache.Config{Size: 10, TTL: cache.DefaultTTL})
+	_, ok := cached.(commons.ChangeNotifier)
+	assert.True(t, ok)
+}
+
+func TestCachingItemService_GetItem(t *testing.T) {
+	service, mockService, _ := newCachingItemService(t)
+	expected := &Item{Id: uuid.New(), Name: "Widget"}
+	mockService.EXPECT().GetItem(gomock.Any(), expected.Id).Return(expected, nil).Times(1)
+
+	for i := 0; i < 2; i++ {
+		got, err := service.GetItem(context.Background(), expected.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	}
+	assert.Equal(t, uint64(1), service.cache.Stats().Hits)
+	assert.Equal(t, uint64(1), service.cache.Stats().Misses)
+
+	missing := uuid.New()
+	mockService.EXPECT().GetItem(gomock.Any(), missing).Return(nil, sql.ErrNoRows).Times(2)
+	for i := 0; i < 2; i++ {
+		_, err := service.GetItem(context.Background(), missing)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	}
+}
+
+func TestCachingItemService_UpdateItem(t *testing.T) {
+	service, mockService, mockInvalidator := newCachingItemService(t)
+	cached := Item{Id: uuid.New(), Name: "Widget"}
+	service.cache.Add(context.Background(), cached.Id, cached)
+	request := UpdateItemRequest{Id: cached.Id, Name: "Gadget"}
+	updated := &Item{Id: cached.Id, Name: "Gadget"}
+	mockService.EXPECT().UpdateItem(gomock.Any(), request).Return(updated, nil)
+	mockInvalidator.EXPECT().Invalidate(CacheName, cached.Id.String()).Return(nil)
+
+	got, err := service.UpdateItem(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, updated, got)
+	_, ok := service.cache.Get(context.Background(), cached.Id)
+	assert.False(t, ok)
+}
+
+func TestCachingItemService_DeleteItem(t *testing.T) {
+	service, mockService, mockInvalidator := newCachingItemService(t)
+	cached := Item{Id: uuid.New(), Name: "Widget"}
+	service.cache.Add(context.Background(), cached.Id, cached)
+	mockService.EXPECT().DeleteItem(gomock.Any(), cached.Id).Return(nil, errors.New("BOOM"))
+	// still removed locally when the other replicas cannot be told
+	mockInvalidator.EXPECT().Invalidate(CacheName, cached.Id.String()).Return(errors.New("connection refused"))
+
+	_, err := service.DeleteItem(context.Background(), cached.Id)
+	assert.Error(t, err)
+	_, ok := service.cache.Get(context.Background(), cached.Id)
+	assert.False(t, ok)
+}
+
+func TestCachingItemService_PassThrough(t *testing.T) {
+	service, mockService, _ := newCachingItemService(t)
+	pagination := &commons.Pagination{LastId: 0, PageSize: 10}
+	request := CreateItemRequest{Name: "Widget"}
+	mockService.EXPECT().GetItems(gomock.Any(), pagination).Return([]Item{}, nil)
+	mockService.EXPECT().CreateItem(gomock.Any(), request).Return(&Item{}, nil)
+
+	_, err := service.GetItems(context.Background(), pagination)
+	assert.NoError(t, err)
+	_, err = service.CreateItem(context.Background(), request)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,32 @@
+package item
+
+import (
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/outbox"
+)
+
+const aggregateType = "item"
+
+type PriceChange struct {
+	Id       uuid.UUID     `json:"id"`
+	OldPrice commons.Money `json:"old_price"`
+	NewPrice commons.Money `json:"new_price"`
+}
+
+func itemCreatedEvent(row ItemRow) (outbox.Event, error) {
+	return outbox.NewEvent(outbox.ItemCreated, aggregateType, row.AltId, itemFromRow(row))
+}
+
+// priceChangedEvents returns an ItemPriceChanged event when the unit price or its currency changed, none otherwise.
+func priceChangedEvents(before ItemRow, after ItemRow) ([]outbox.Event, error) {
+	oldPrice, newPrice := itemFromRow(before).UnitPrice, itemFromRow(after).UnitPrice
+	if oldPrice == newPrice {
+		return nil, nil
+	}
+	event, err := outbox.NewEvent(outbox.ItemPriceChanged, aggregateType, after.AltId, PriceChange{Id: after.AltId, OldPrice: oldPrice, NewPrice: newPrice})
+	if err != nil {
+		return nil, err
+	}
+	return []outbox.Event{event}, nil
+}
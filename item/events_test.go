@@ -0,0 +1,51 @@
+package item
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"inventory-service-go/outbox"
+	"testing"
+)
+
+func TestPriceChangedEvents(t *testing.T) {
+	id := uuid.New()
+	before := ItemRow{AltId: id, Name: "widget", UnitPrice: commons.NewMoney(1000, commons.DefaultCurrency), Currency: "EUR"}
+	testCases := []struct {
+		name       string
+		after      ItemRow
+		wantChange *PriceChange
+	}{
+		{
+			name:  "Renamed Only",
+			after: ItemRow{AltId: id, Name: "gadget", UnitPrice: commons.NewMoney(1000, commons.DefaultCurrency), Currency: "EUR"},
+		},
+		{
+			name:       "New Price",
+			after:      ItemRow{AltId: id, Name: "widget", UnitPrice: commons.NewMoney(1200, commons.DefaultCurrency), Currency: "EUR"},
+			wantChange: &PriceChange{Id: id, OldPrice: commons.NewMoney(1000, "EUR"), NewPrice: commons.NewMoney(1200, "EUR")},
+		},
+		{
+			name:       "New Currency",
+			after:      ItemRow{AltId: id, Name: "widget", UnitPrice: commons.NewMoney(1000, commons.DefaultCurrency), Currency: "GBP"},
+			wantChange: &PriceChange{Id: id, OldPrice: commons.NewMoney(1000, "EUR"), NewPrice: commons.NewMoney(1000, "GBP")},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			events, err := priceChangedEvents(before, tc.after)
+			assert.Nil(t, err)
+			if tc.wantChange == nil {
+				assert.Empty(t, events)
+				return
+			}
+			assert.Len(t, events, 1)
+			assert.Equal(t, outbox.ItemPriceChanged, events[0].Type)
+			assert.Equal(t, id, events[0].AggregateId)
+			var change PriceChange
+			assert.Nil(t, json.Unmarshal(events[0].Payload, &change))
+			assert.Equal(t, *tc.wantChange, change)
+		})
+	}
+}
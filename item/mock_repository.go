@@ -10,6 +10,7 @@
 package item
 
 import (
+	context "context"
 	commons "inventory-service-go/commons"
 	reflect "reflect"
 
@@ -41,76 +42,76 @@ func (m *MockItemRepository) EXPECT() *MockItemRepositoryMockRecorder {
 }
 
 // CreateItem mocks base method.
-func (m *MockItemRepository) CreateItem(request CreateItemRequest) (ItemRow, error) {
+func (m *MockItemRepository) CreateItem(ctx context.Context, request CreateItemRequest) (ItemRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateItem", request)
+	ret := m.ctrl.Call(m, "CreateItem", ctx, request)
 	ret0, _ := ret[0].(ItemRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateItem indicates an expected call of CreateItem.
-func (mr *MockItemRepositoryMockRecorder) CreateItem(request any) *gomock.Call {
+func (mr *MockItemRepositoryMockRecorder) CreateItem(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockItemRepository)(nil).CreateItem), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockItemRepository)(nil).CreateItem), ctx, request)
 }
 
 // DeleteItem mocks base method.
-func (m *MockItemRepository) DeleteItem(id uuid.UUID) (commons.DeleteResult, error) {
+func (m *MockItemRepository) DeleteItem(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteItem", id)
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, id)
 	ret0, _ := ret[0].(commons.DeleteResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteItem indicates an expected call of DeleteItem.
-func (mr *MockItemRepositoryMockRecorder) DeleteItem(id any) *gomock.Call {
+func (mr *MockItemRepositoryMockRecorder) DeleteItem(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockItemRepository)(nil).DeleteItem), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockItemRepository)(nil).DeleteItem), ctx, id)
 }
 
 // GetItem mocks base method.
-func (m *MockItemRepository) GetItem(id uuid.UUID) (ItemRow, error) {
+func (m *MockItemRepository) GetItem(ctx context.Context, id uuid.UUID) (ItemRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItem", id)
+	ret := m.ctrl.Call(m, "GetItem", ctx, id)
 	ret0, _ := ret[0].(ItemRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetItem indicates an expected call of GetItem.
-func (mr *MockItemRepositoryMockRecorder) GetItem(id any) *gomock.Call {
+func (mr *MockItemRepositoryMockRecorder) GetItem(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockItemRepository)(nil).GetItem), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockItemRepository)(nil).GetItem), ctx, id)
 }
 
 // GetItems mocks base method.
-func (m *MockItemRepository) GetItems(pagination *commons.Pagination) ([]ItemRow, error) {
+func (m *MockItemRepository) GetItems(ctx context.Context, pagination *commons.Pagination) ([]ItemRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItems", pagination)
+	ret := m.ctrl.Call(m, "GetItems", ctx, pagination)
 	ret0, _ := ret[0].([]ItemRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetItems indicates an expected call of GetItems.
-func (mr *MockItemRepositoryMockRecorder) GetItems(pagination any) *gomock.Call {
+func (mr *MockItemRepositoryMockRecorder) GetItems(ctx, pagination any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockItemRepository)(nil).GetItems), pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockItemRepository)(nil).GetItems), ctx, pagination)
 }
 
 // UpdateItem mocks base method.
-func (m *MockItemRepository) UpdateItem(request UpdateItemRequest) (ItemRow, error) {
+func (m *MockItemRepository) UpdateItem(ctx context.Context, request UpdateItemRequest) (ItemRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateItem", request)
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, request)
 	ret0, _ := ret[0].(ItemRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateItem indicates an expected call of UpdateItem.
-func (mr *MockItemRepositoryMockRecorder) UpdateItem(request any) *gomock.Call {
+func (mr *MockItemRepositoryMockRecorder) UpdateItem(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockItemRepository)(nil).UpdateItem), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockItemRepository)(nil).UpdateItem), ctx, request)
 }
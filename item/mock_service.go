@@ -10,6 +10,7 @@
 package item
 
 import (
+	context "context"
 	commons "inventory-service-go/commons"
 	reflect "reflect"
 
@@ -41,76 +42,76 @@ func (m *MockItemService) EXPECT() *MockItemServiceMockRecorder {
 }
 
 // CreateItem mocks base method.
-func (m *MockItemService) CreateItem(request CreateItemRequest) (*Item, error) {
+func (m *MockItemService) CreateItem(ctx context.Context, request CreateItemRequest) (*Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateItem", request)
+	ret := m.ctrl.Call(m, "CreateItem", ctx, request)
 	ret0, _ := ret[0].(*Item)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateItem indicates an expected call of CreateItem.
-func (mr *MockItemServiceMockRecorder) CreateItem(request any) *gomock.Call {
+func (mr *MockItemServiceMockRecorder) CreateItem(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockItemService)(nil).CreateItem), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItem", reflect.TypeOf((*MockItemService)(nil).CreateItem), ctx, request)
 }
 
 // DeleteItem mocks base method.
-func (m *MockItemService) DeleteItem(id uuid.UUID) (*commons.DeleteResult, error) {
+func (m *MockItemService) DeleteItem(ctx context.Context, id uuid.UUID) (*commons.DeleteResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteItem", id)
+	ret := m.ctrl.Call(m, "DeleteItem", ctx, id)
 	ret0, _ := ret[0].(*commons.DeleteResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteItem indicates an expected call of DeleteItem.
-func (mr *MockItemServiceMockRecorder) DeleteItem(id any) *gomock.Call {
+func (mr *MockItemServiceMockRecorder) DeleteItem(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockItemService)(nil).DeleteItem), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockItemService)(nil).DeleteItem), ctx, id)
 }
 
 // GetItem mocks base method.
-func (m *MockItemService) GetItem(id uuid.UUID) (*Item, error) {
+func (m *MockItemService) GetItem(ctx context.Context, id uuid.UUID) (*Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItem", id)
+	ret := m.ctrl.Call(m, "GetItem", ctx, id)
 	ret0, _ := ret[0].(*Item)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetItem indicates an expected call of GetItem.
-func (mr *MockItemServiceMockRecorder) GetItem(id any) *gomock.Call {
+func (mr *MockItemServiceMockRecorder) GetItem(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockItemService)(nil).GetItem), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItem", reflect.TypeOf((*MockItemService)(nil).GetItem), ctx, id)
 }
 
 // GetItems mocks base method.
-func (m *MockItemService) GetItems(pagination *commons.Pagination) ([]Item, error) {
+func (m *MockItemService) GetItems(ctx context.Context, pagination *commons.Pagination) ([]Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItems", pagination)
+	ret := m.ctrl.Call(m, "GetItems", ctx, pagination)
 	ret0, _ := ret[0].([]Item)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetItems indicates an expected call of GetItems.
-func (mr *MockItemServiceMockRecorder) GetItems(pagination any) *gomock.Call {
+func (mr *MockItemServiceMockRecorder) GetItems(ctx, pagination any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockItemService)(nil).GetItems), pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockItemService)(nil).GetItems), ctx, pagination)
 }
 
 // UpdateItem mocks base method.
-func (m *MockItemService) UpdateItem(request UpdateItemRequest) (*Item, error) {
+func (m *MockItemService) UpdateItem(ctx context.Context, request UpdateItemRequest) (*Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateItem", request)
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, request)
 	ret0, _ := ret[0].(*Item)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateItem indicates an expected call of UpdateItem.
-func (mr *MockItemServiceMockRecorder) UpdateItem(request any) *gomock.Call {
+func (mr *MockItemServiceMockRecorder) UpdateItem(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockItemService)(nil).UpdateItem), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockItemService)(nil).UpdateItem), ctx, request)
 }
@@ -1,96 +1,149 @@
 package item
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"inventory-service-go/commons"
+	"inventory-service-go/metrics"
+	"inventory-service-go/outbox"
+	"inventory-service-go/tracing"
+	"time"
 )
 
 type ItemRow struct {
-	Id            int64     `db:"id"`
-	AltId         uuid.UUID `db:"alt_id"`
-	Name          string    `db:"name"`
-	Description   string    `db:"description"`
-	UnitPrice     float64   `db:"unit_price"`
-	CreatedBy     string    `db:"created_by"`
-	CreatedAt     string    `db:"created_at"`
-	LastChangedBy string    `db:"last_changed_by"`
-	LastUpdate    string    `db:"last_update"`
+	Id            int64         `db:"id"`
+	AltId         uuid.UUID     `db:"alt_id"`
+	Name          string        `db:"name"`
+	Description   string        `db:"description"`
+	UnitPrice     commons.Money `db:"unit_price"`
+	Currency      string        `db:"currency"`
+	TaxCategory   string        `db:"tax_category"`
+	CreatedBy     string        `db:"created_by"`
+	CreatedAt     time.Time     `db:"created_at"`
+	LastChangedBy string        `db:"last_changed_by"`
+	LastUpdate    time.Time     `db:"last_update"`
+	TenantId      uuid.UUID     `db:"tenant_id"`
 }
 
 type CreateItemRequest struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	UnitPrice   float64 `json:"unit_price"`
-	CreatedBy   string  `json:"created_by"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	UnitPrice   commons.Money `json:"unit_price"`
+	TaxCategory string        `json:"tax_category"`
+	CreatedBy   string        `json:"created_by" audit:"actor"`
 }
 
 type UpdateItemRequest struct {
-	Id            uuid.UUID `json:"id"`
-	Name          string    `json:"name"`
-	Description   string    `json:"description"`
-	UnitPrice     float64   `json:"unit_price"`
-	LastChangedBy string    `json:"last_changed_by"`
+	Id            uuid.UUID     `json:"id"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description"`
+	UnitPrice     commons.Money `json:"unit_price"`
+	TaxCategory   string        `json:"tax_category"`
+	LastChangedBy string        `json:"last_changed_by" audit:"actor"`
 }
 
 const (
-	CREATE_STATEMENT              = "INSERT INTO items (name, description, unit_price, created_by, last_changed_by) VALUES ($1, $2, $3, $4, $4) returning *"
-	UPDATE_STATEMENT              = "UPDATE items SET name = $1, description = $2, unit_price = $3, last_changed_by = $4 WHERE alt_id = $5 returning *"
+	CREATE_STATEMENT              = "INSERT INTO items (name, description, unit_price, currency, tax_category, created_by, last_changed_by) VALUES ($1, $2, $3, $4, $5, $6, $6) returning *"
+	UPDATE_STATEMENT              = "UPDATE items SET name = $1, description = $2, unit_price = $3, currency = $4, tax_category = $5, last_changed_by = $6 WHERE alt_id = $7 returning *"
 	GET_BY_ID_QUERY               = "SELECT * FROM items WHERE alt_id = $1"
+	LOCK_BY_ID_QUERY              = "SELECT * FROM items WHERE alt_id = $1 FOR UPDATE"
 	GET_ALL_QUERY                 = "SELECT * FROM items"
-	GET_ALL_QUERY_WITH_PAGINATION = "SELECT * FROM items WHERE id > $1 LIMIT $2"
+	GET_ALL_QUERY_WITH_PAGINATION = "SELECT * FROM items WHERE id > $1 ORDER BY id LIMIT $2"
 	DELETE_BY_ID_QUERY            = "DELETE FROM items WHERE alt_id = $1"
 )
 
 type ItemRepository interface {
-	CreateItem(request CreateItemRequest) (ItemRow, error)
-	UpdateItem(request UpdateItemRequest) (ItemRow, error)
-	GetItem(id uuid.UUID) (ItemRow, error)
-	GetItems(pagination *commons.Pagination) ([]ItemRow, error)
-	DeleteItem(id uuid.UUID) (commons.DeleteResult, error)
+	CreateItem(ctx context.Context, request CreateItemRequest) (ItemRow, error)
+	UpdateItem(ctx context.Context, request UpdateItemRequest) (ItemRow, error)
+	GetItem(ctx context.Context, id uuid.UUID) (ItemRow, error)
+	GetItems(ctx context.Context, pagination *commons.Pagination) ([]ItemRow, error)
+	DeleteItem(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error)
 }
 
 type ItemRepositoryImpl struct {
-	db *sqlx.DB
+	db *commons.TenantDB
 }
 
 func NewItemRepository(db *sqlx.DB) *ItemRepositoryImpl {
 	return &ItemRepositoryImpl{
-		db: db,
+		db: commons.NewTenantDB(db),
 	}
 }
 
-func (r *ItemRepositoryImpl) CreateItem(request CreateItemRequest) (ItemRow, error) {
+// CreateItem writes the ItemCreated event to the outbox in the same transaction.
+func (r *ItemRepositoryImpl) CreateItem(ctx context.Context, request CreateItemRequest) (ItemRow, error) {
+	defer metrics.ObserveQuery("item", "CreateItem")()
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return ItemRow{}, err
+	}
+	defer tx.Rollback()
 	var item ItemRow
-	err := r.db.Get(&item, CREATE_STATEMENT, request.Name, request.Description, request.UnitPrice, request.CreatedBy)
-	return item, err
+	err = tx.GetContext(tracing.Statement(ctx, "CREATE_STATEMENT"), &item, CREATE_STATEMENT, request.Name, request.Description, request.UnitPrice, request.UnitPrice.Currency, request.TaxCategory, request.CreatedBy)
+	if err != nil {
+		return ItemRow{}, err
+	}
+	event, err := itemCreatedEvent(item)
+	if err != nil {
+		return ItemRow{}, err
+	}
+	if err = outbox.Append(ctx, tx, event); err != nil {
+		return ItemRow{}, err
+	}
+	return item, tx.Commit()
 }
 
-func (r *ItemRepositoryImpl) UpdateItem(request UpdateItemRequest) (ItemRow, error) {
+// UpdateItem locks the item to compare the old price with the new one, writing ItemPriceChanged to the outbox in
+// the same transaction when it changed.
+func (r *ItemRepositoryImpl) UpdateItem(ctx context.Context, request UpdateItemRequest) (ItemRow, error) {
+	defer metrics.ObserveQuery("item", "UpdateItem")()
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return ItemRow{}, err
+	}
+	defer tx.Rollback()
+	var before ItemRow
+	if err = tx.GetContext(tracing.Statement(ctx, "LOCK_BY_ID_QUERY"), &before, LOCK_BY_ID_QUERY, request.Id); err != nil {
+		return ItemRow{}, err
+	}
 	var item ItemRow
-	err := r.db.Get(&item, UPDATE_STATEMENT, request.Name, request.Description, request.UnitPrice, request.LastChangedBy, request.Id)
-	return item, err
+	err = tx.GetContext(tracing.Statement(ctx, "UPDATE_STATEMENT"), &item, UPDATE_STATEMENT, request.Name, request.Description, request.UnitPrice, request.UnitPrice.Currency, request.TaxCategory, request.LastChangedBy, request.Id)
+	if err != nil {
+		return ItemRow{}, err
+	}
+	events, err := priceChangedEvents(before, item)
+	if err != nil {
+		return ItemRow{}, err
+	}
+	if err = outbox.Append(ctx, tx, events...); err != nil {
+		return ItemRow{}, err
+	}
+	return item, tx.Commit()
 }
 
-func (r *ItemRepositoryImpl) GetItem(id uuid.UUID) (ItemRow, error) {
+func (r *ItemRepositoryImpl) GetItem(ctx context.Context, id uuid.UUID) (ItemRow, error) {
+	defer metrics.ObserveQuery("item", "GetItem")()
 	var item ItemRow
-	err := r.db.Get(&item, GET_BY_ID_QUERY, id)
+	err := r.db.GetContext(tracing.Statement(ctx, "GET_BY_ID_QUERY"), &item, GET_BY_ID_QUERY, id)
 	return item, err
 }
 
-func (r *ItemRepositoryImpl) GetItems(pagination *commons.Pagination) ([]ItemRow, error) {
+func (r *ItemRepositoryImpl) GetItems(ctx context.Context, pagination *commons.Pagination) ([]ItemRow, error) {
+	defer metrics.ObserveQuery("item", "GetItems")()
 	var items []ItemRow
 	if pagination == nil {
-		err := r.db.Select(&items, GET_ALL_QUERY)
+		err := r.db.SelectContext(tracing.Statement(ctx, "GET_ALL_QUERY"), &items, GET_ALL_QUERY)
 		return items, err
 	} else {
-		err := r.db.Select(&items, GET_ALL_QUERY_WITH_PAGINATION, pagination.LastId, pagination.PageSize)
+		err := r.db.SelectContext(tracing.Statement(ctx, "GET_ALL_QUERY_WITH_PAGINATION"), &items, GET_ALL_QUERY_WITH_PAGINATION, pagination.LastId, pagination.PageSize)
 		return items, err
 	}
 }
 
-func (r *ItemRepositoryImpl) DeleteItem(id uuid.UUID) (commons.DeleteResult, error) {
-	sqlResults := r.db.MustExec(DELETE_BY_ID_QUERY, id)
+func (r *ItemRepositoryImpl) DeleteItem(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	defer metrics.ObserveQuery("item", "DeleteItem")()
+	sqlResults := r.db.MustExecContext(tracing.Statement(ctx, "DELETE_BY_ID_QUERY"), DELETE_BY_ID_QUERY, id)
 	rowsAffected, _ := sqlResults.RowsAffected()
 	result := commons.DeleteResult{
 		Id:      id,
@@ -1,11 +1,13 @@
 package item
 
 import (
+	"context"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"inventory-service-go/commons"
+	"inventory-service-go/outbox"
 	"testing"
 	"time"
 )
@@ -19,22 +21,27 @@ func TestItemRepository_CreateItem(t *testing.T) {
 	itemtest := ItemRow{
 		Name:        "TestItem",
 		Description: "This is a test item",
-		UnitPrice:   10.0,
+		UnitPrice:   commons.NewMoney(1000, "USD"),
 		CreatedBy:   "testUser",
 	}
 
 	newUuid := uuid.NewString()
 
 	rows := sqlmock.NewRows([]string{"id", "alt_id", "name", "description", "unit_price", "created_by", "created_at", "last_changed_by", "last_update"}).
-		AddRow(1, newUuid, itemtest.Name, itemtest.Description, itemtest.UnitPrice, itemtest.CreatedBy, time.Now(), itemtest.CreatedBy, time.Now())
+		AddRow(1, newUuid, itemtest.Name, itemtest.Description, itemtest.UnitPrice.Amount(), itemtest.CreatedBy, time.Now(), itemtest.CreatedBy, time.Now())
 
+	mock.ExpectBegin()
 	mock.ExpectQuery("^INSERT INTO items (.+) VALUES (.+)$").
-		WithArgs(itemtest.Name, itemtest.Description, itemtest.UnitPrice, itemtest.CreatedBy).
+		WithArgs(itemtest.Name, itemtest.Description, itemtest.UnitPrice, itemtest.UnitPrice.Currency, itemtest.TaxCategory, itemtest.CreatedBy).
 		WillReturnRows(rows)
+	mock.ExpectExec("INSERT INTO outbox").
+		WithArgs(sqlmock.AnyArg(), outbox.ItemCreated, "item", newUuid, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	itemRepo := NewItemRepository(sqlx.NewDb(db, ""))
 	request := CreateItemRequest{Name: itemtest.Name, Description: itemtest.Description, UnitPrice: itemtest.UnitPrice, CreatedBy: itemtest.CreatedBy}
-	resultItem, err := itemRepo.CreateItem(request)
+	resultItem, err := itemRepo.CreateItem(context.Background(), request)
 	if err != nil {
 		t.Errorf("error was not expected when creating item: %s", err)
 	}
@@ -45,6 +52,7 @@ func TestItemRepository_CreateItem(t *testing.T) {
 	assert.Equal(t, itemtest.UnitPrice, resultItem.UnitPrice)
 	assert.Equal(t, itemtest.CreatedBy, resultItem.CreatedBy)
 	assert.Equal(t, itemtest.CreatedBy, resultItem.LastChangedBy)
+	assert.Nil(t, mock.ExpectationsWereMet())
 }
 
 func TestItemRepository_UpdateItem(t *testing.T) {
@@ -57,7 +65,7 @@ func TestItemRepository_UpdateItem(t *testing.T) {
 		AltId:       uuid.New(),
 		Name:        "TestItem",
 		Description: "This is a test item",
-		UnitPrice:   20.0,
+		UnitPrice:   commons.NewMoney(2000, "USD"),
 		CreatedBy:   "testUser",
 	}
 
@@ -65,17 +73,27 @@ func TestItemRepository_UpdateItem(t *testing.T) {
 		AltId:         itemtest.AltId,
 		Name:          "UpdatedTestItem",
 		Description:   "This is an updated test item",
-		UnitPrice:     22.0,
+		UnitPrice:     commons.NewMoney(2200, "USD"),
 		LastChangedBy: "testUser2",
 	}
 
-	updateQuery := "UPDATE items SET name = \\$1, description = \\$2, unit_price = \\$3, last_changed_by = \\$4 WHERE alt_id = \\$5 returning *"
+	updateQuery := "UPDATE items SET name = \\$1, description = \\$2, unit_price = \\$3, currency = \\$4, tax_category = \\$5, last_changed_by = \\$6 WHERE alt_id = \\$7 returning *"
 
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM items WHERE alt_id = \\$1 FOR UPDATE").
+		WithArgs(itemtest.AltId).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "alt_id", "name", "description", "unit_price", "created_by", "created_at", "last_changed_by", "last_update"}).
+				AddRow(1, itemtest.AltId, itemtest.Name, itemtest.Description, itemtest.UnitPrice.Amount(), itemtest.CreatedBy, time.Now(), itemtest.CreatedBy, time.Now()))
 	mock.ExpectQuery(updateQuery).
-		WithArgs(itemtestUpd.Name, itemtestUpd.Description, itemtestUpd.UnitPrice, itemtestUpd.LastChangedBy, itemtest.AltId).
+		WithArgs(itemtestUpd.Name, itemtestUpd.Description, itemtestUpd.UnitPrice, itemtestUpd.UnitPrice.Currency, itemtestUpd.TaxCategory, itemtestUpd.LastChangedBy, itemtest.AltId).
 		WillReturnRows(
 			sqlmock.NewRows([]string{"id", "alt_id", "name", "description", "unit_price", "created_by", "created_at", "last_changed_by", "last_update"}).
-				AddRow(1, itemtest.AltId, itemtestUpd.Name, itemtestUpd.Description, itemtestUpd.UnitPrice, itemtest.CreatedBy, time.Now(), itemtestUpd.LastChangedBy, time.Now()))
+				AddRow(1, itemtest.AltId, itemtestUpd.Name, itemtestUpd.Description, itemtestUpd.UnitPrice.Amount(), itemtest.CreatedBy, time.Now(), itemtestUpd.LastChangedBy, time.Now()))
+	mock.ExpectExec("INSERT INTO outbox").
+		WithArgs(sqlmock.AnyArg(), outbox.ItemPriceChanged, "item", itemtest.AltId, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	itemRepo := NewItemRepository(sqlx.NewDb(db, ""))
 
@@ -87,7 +105,7 @@ func TestItemRepository_UpdateItem(t *testing.T) {
 		LastChangedBy: itemtestUpd.LastChangedBy,
 	}
 
-	row, err := itemRepo.UpdateItem(request)
+	row, err := itemRepo.UpdateItem(context.Background(), request)
 	if err != nil {
 		t.Errorf("error was not expected when updating item: %s", err)
 	} else {
@@ -114,12 +132,13 @@ func TestItemRepository_GetItem(t *testing.T) {
 		AltId:       uuid.New(),
 		Name:        "GetTestItem",
 		Description: "This is a test item for get",
-		UnitPrice:   30.0,
+		UnitPrice:   commons.NewMoney(3000, "USD"),
+		Currency:    "EUR",
 		CreatedBy:   "getTestUser",
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "alt_id", "name", "description", "unit_price", "created_by", "created_at", "last_changed_by", "last_update"}).
-		AddRow(1, itemtest.AltId, itemtest.Name, itemtest.Description, itemtest.UnitPrice, itemtest.CreatedBy, time.Now(), itemtest.CreatedBy, time.Now())
+	rows := sqlmock.NewRows([]string{"id", "alt_id", "name", "description", "unit_price", "currency", "created_by", "created_at", "last_changed_by", "last_update"}).
+		AddRow(1, itemtest.AltId, itemtest.Name, itemtest.Description, itemtest.UnitPrice.Amount(), itemtest.Currency, itemtest.CreatedBy, time.Now(), itemtest.CreatedBy, time.Now())
 
 	mock.ExpectQuery("^SELECT (.+) FROM items WHERE alt_id = \\$1$").
 		WithArgs(itemtest.AltId).
@@ -127,7 +146,7 @@ func TestItemRepository_GetItem(t *testing.T) {
 
 	itemRepo := NewItemRepository(sqlx.NewDb(db, ""))
 
-	resultItem, err := itemRepo.GetItem(itemtest.AltId)
+	resultItem, err := itemRepo.GetItem(context.Background(), itemtest.AltId)
 	if err != nil {
 		t.Errorf("error was not expected when getting item: %s", err)
 	} else {
@@ -140,6 +159,7 @@ func TestItemRepository_GetItem(t *testing.T) {
 	assert.Equal(t, itemtest.AltId, resultItem.AltId)
 	assert.Equal(t, itemtest.Description, resultItem.Description)
 	assert.Equal(t, itemtest.UnitPrice, resultItem.UnitPrice)
+	assert.Equal(t, itemtest.Currency, resultItem.Currency)
 	assert.Equal(t, itemtest.CreatedBy, resultItem.CreatedBy)
 }
 
@@ -153,7 +173,7 @@ func TestItemRepositoryImpl_GetItems(t *testing.T) {
 		AltId:       uuid.New(),
 		Name:        "TestItem1",
 		Description: "This is a test item 1",
-		UnitPrice:   10.0,
+		UnitPrice:   commons.NewMoney(1000, "USD"),
 		CreatedBy:   "testUser",
 	}
 
@@ -161,19 +181,19 @@ func TestItemRepositoryImpl_GetItems(t *testing.T) {
 		AltId:       uuid.New(),
 		Name:        "TestItem2",
 		Description: "This is a test item 2",
-		UnitPrice:   15.0,
+		UnitPrice:   commons.NewMoney(1500, "USD"),
 		CreatedBy:   "testUser",
 	}
 
 	rows := sqlmock.NewRows([]string{"alt_id", "name", "description", "unit_price", "created_by", "created_at", "last_changed_by", "last_update"}).
-		AddRow(itemtest1.AltId, itemtest1.Name, itemtest1.Description, itemtest1.UnitPrice, itemtest1.CreatedBy, time.Now(), itemtest1.CreatedBy, time.Now()).
-		AddRow(itemtest2.AltId, itemtest2.Name, itemtest2.Description, itemtest2.UnitPrice, itemtest2.CreatedBy, time.Now(), itemtest2.CreatedBy, time.Now())
+		AddRow(itemtest1.AltId, itemtest1.Name, itemtest1.Description, itemtest1.UnitPrice.Amount(), itemtest1.CreatedBy, time.Now(), itemtest1.CreatedBy, time.Now()).
+		AddRow(itemtest2.AltId, itemtest2.Name, itemtest2.Description, itemtest2.UnitPrice.Amount(), itemtest2.CreatedBy, time.Now(), itemtest2.CreatedBy, time.Now())
 
 	mock.ExpectQuery("^SELECT (.+) FROM items$").
 		WillReturnRows(rows)
 
 	itemRepo := NewItemRepository(sqlx.NewDb(db, ""))
-	items, err := itemRepo.GetItems(nil)
+	items, err := itemRepo.GetItems(context.Background(), nil)
 	if err != nil {
 		t.Errorf("error was not expected when getting items: %s", err)
 	} else {
@@ -206,7 +226,7 @@ func TestItemRepositoryImpl_GetAllWithPagination(t *testing.T) {
 		AltId:       uuid.New(),
 		Name:        "TestItem1",
 		Description: "This is a test item 1",
-		UnitPrice:   10.0,
+		UnitPrice:   commons.NewMoney(1000, "USD"),
 		CreatedBy:   "testUser",
 	}
 
@@ -214,14 +234,14 @@ func TestItemRepositoryImpl_GetAllWithPagination(t *testing.T) {
 		AltId:       uuid.New(),
 		Name:        "TestItem2",
 		Description: "This is a test item 2",
-		UnitPrice:   15.0,
+		UnitPrice:   commons.NewMoney(1500, "USD"),
 		CreatedBy:   "testUser",
 	}
 
 	rows := sqlmock.NewRows([]string{"alt_id", "name", "description", "unit_price", "created_by", "created_at", "last_changed_by", "last_update"}).
-		AddRow(itemtest2.AltId, itemtest2.Name, itemtest2.Description, itemtest2.UnitPrice, itemtest2.CreatedBy, time.Now(), itemtest2.CreatedBy, time.Now())
+		AddRow(itemtest2.AltId, itemtest2.Name, itemtest2.Description, itemtest2.UnitPrice.Amount(), itemtest2.CreatedBy, time.Now(), itemtest2.CreatedBy, time.Now())
 
-	mock.ExpectQuery("^SELECT (.+) FROM items WHERE id > \\$1 LIMIT \\$2$").
+	mock.ExpectQuery("^SELECT (.+) FROM items WHERE id > \\$1 ORDER BY id LIMIT \\$2$").
 		WillReturnRows(rows)
 
 	itemRepo := NewItemRepository(sqlx.NewDb(db, ""))
@@ -229,7 +249,7 @@ func TestItemRepositoryImpl_GetAllWithPagination(t *testing.T) {
 		LastId:   int(itemtest1.Id),
 		PageSize: 1,
 	}
-	items, err := itemRepo.GetItems(&pagination)
+	items, err := itemRepo.GetItems(context.Background(), &pagination)
 	if err != nil {
 		t.Errorf("error was not expected when getting items: %s", err)
 	} else {
@@ -275,7 +295,7 @@ func TestItemRepositoryImpl_DeleteItem(t *testing.T) {
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			}
 
-			results, _ := itemRepo.DeleteItem(tt.id)
+			results, _ := itemRepo.DeleteItem(context.Background(), tt.id)
 			if !tt.wantErr && !results.Deleted {
 				t.Errorf("DeleteItem() error: Results were not deleted")
 			} else if tt.wantErr && results.Deleted {
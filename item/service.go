@@ -1,8 +1,12 @@
 package item
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"inventory-service-go/commons"
+	"inventory-service-go/metrics"
+	"inventory-service-go/tax"
+	"time"
 )
 
 type Item struct {
@@ -10,35 +14,46 @@ type Item struct {
 	Id          uuid.UUID         `json:"id"`
 	Name        string            `json:"name"`
 	Description string            `json:"description"`
-	UnitPrice   float64           `json:"unit_price"`
+	UnitPrice   commons.Money     `json:"unit_price"`
+	TaxCategory string            `json:"tax_category"`
 	AuditInfo   commons.AuditInfo `json:"audit_info"`
 }
 
 func itemFromRow(row ItemRow) Item {
+	if row.Currency != "" {
+		row.UnitPrice.Currency = row.Currency
+	}
 	return Item{
 		Seq:         int(row.Id),
 		Id:          row.AltId,
 		Name:        row.Name,
 		Description: row.Description,
 		UnitPrice:   row.UnitPrice,
+		TaxCategory: row.TaxCategory,
 		AuditInfo: commons.AuditInfo{
-			CreatedBy:     row.CreatedBy,
-			CreatedAt:     row.CreatedAt,
-			LastUpdate:    row.LastUpdate,
-			LastChangedBy: row.LastChangedBy,
+			CreatedBy:      row.CreatedBy,
+			CreatedAt:      row.CreatedAt.Format(time.RFC3339Nano),
+			LastUpdate:     row.LastUpdate.Format(time.RFC3339Nano),
+			LastChangedBy:  row.LastChangedBy,
+			CreatedTime:    row.CreatedAt,
+			LastUpdateTime: row.LastUpdate,
 		},
 	}
 }
 
+// ChangeTopic is the topic item changes are notified under.
+const ChangeTopic = "items"
+
 type ItemService interface {
-	CreateItem(request CreateItemRequest) (*Item, error)
-	UpdateItem(request UpdateItemRequest) (*Item, error)
-	DeleteItem(id uuid.UUID) (*commons.DeleteResult, error)
-	GetItem(id uuid.UUID) (*Item, error)
-	GetItems(pagination *commons.Pagination) ([]Item, error)
+	CreateItem(ctx context.Context, request CreateItemRequest) (*Item, error)
+	UpdateItem(ctx context.Context, request UpdateItemRequest) (*Item, error)
+	DeleteItem(ctx context.Context, id uuid.UUID) (*commons.DeleteResult, error)
+	GetItem(ctx context.Context, id uuid.UUID) (*Item, error)
+	GetItems(ctx context.Context, pagination *commons.Pagination) ([]Item, error)
 }
 
 type ItemServiceImpl struct {
+	commons.ChangeHooks
 	repo ItemRepository
 }
 
@@ -48,34 +63,52 @@ func NewItemService(repo ItemRepository) *ItemServiceImpl {
 	}
 }
 
-func (s *ItemServiceImpl) CreateItem(request CreateItemRequest) (*Item, error) {
-	row, err := s.repo.CreateItem(request)
+func (s *ItemServiceImpl) CreateItem(ctx context.Context, request CreateItemRequest) (*Item, error) {
+	currency, err := commons.NormalizeCurrency(request.UnitPrice.Currency)
+	if err != nil {
+		return nil, err
+	}
+	request.UnitPrice.Currency = currency
+	request.TaxCategory = tax.NormalizeCategory(request.TaxCategory)
+	row, err := s.repo.CreateItem(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 	i := itemFromRow(row)
+	metrics.ItemsCreated.Inc()
+	s.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeCreated, Id: i.Id, Data: i})
 	return &i, nil
 }
 
-func (s *ItemServiceImpl) UpdateItem(request UpdateItemRequest) (*Item, error) {
-	row, err := s.repo.UpdateItem(request)
+func (s *ItemServiceImpl) UpdateItem(ctx context.Context, request UpdateItemRequest) (*Item, error) {
+	currency, err := commons.NormalizeCurrency(request.UnitPrice.Currency)
+	if err != nil {
+		return nil, err
+	}
+	request.UnitPrice.Currency = currency
+	request.TaxCategory = tax.NormalizeCategory(request.TaxCategory)
+	row, err := s.repo.UpdateItem(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 	i := itemFromRow(row)
+	s.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeUpdated, Id: i.Id, Data: i})
 	return &i, nil
 }
 
-func (s *ItemServiceImpl) DeleteItem(id uuid.UUID) (*commons.DeleteResult, error) {
-	r, err := s.repo.DeleteItem(id)
+func (s *ItemServiceImpl) DeleteItem(ctx context.Context, id uuid.UUID) (*commons.DeleteResult, error) {
+	r, err := s.repo.DeleteItem(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if r.Deleted {
+		s.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeDeleted, Id: id})
+	}
 	return &r, nil
 }
 
-func (s *ItemServiceImpl) GetItem(id uuid.UUID) (*Item, error) {
-	row, err := s.repo.GetItem(id)
+func (s *ItemServiceImpl) GetItem(ctx context.Context, id uuid.UUID) (*Item, error) {
+	row, err := s.repo.GetItem(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -83,8 +116,8 @@ func (s *ItemServiceImpl) GetItem(id uuid.UUID) (*Item, error) {
 	return &i, nil
 }
 
-func (s *ItemServiceImpl) GetItems(pagination *commons.Pagination) ([]Item, error) {
-	rows, err := s.repo.GetItems(pagination)
+func (s *ItemServiceImpl) GetItems(ctx context.Context, pagination *commons.Pagination) ([]Item, error) {
+	rows, err := s.repo.GetItems(ctx, pagination)
 	if err != nil {
 		return nil, err
 	}
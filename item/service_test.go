@@ -1,6 +1,7 @@
 package item
 
 import (
+	"context"
 	"errors"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -14,6 +15,8 @@ func TestItemService_CreateItem(t *testing.T) {
 	defer controller.Finish()
 	mockRepo := NewMockItemRepository(controller)
 	service := NewItemService(mockRepo)
+	var changes []commons.Change
+	service.OnChange(func(change commons.Change) { changes = append(changes, change) })
 
 	tests := []struct {
 		name            string
@@ -24,14 +27,14 @@ func TestItemService_CreateItem(t *testing.T) {
 	}{
 		{
 			name:            "ValidRequest",
-			givenRequest:    CreateItemRequest{Name: "item1", Description: "description1", UnitPrice: 100.00, CreatedBy: "testuser"},
-			mockReturnValue: ItemRow{Id: 1, AltId: uuid.New(), Name: "item1", Description: "description1", UnitPrice: 100.00, CreatedBy: "testuser"},
+			givenRequest:    CreateItemRequest{Name: "item1", Description: "description1", UnitPrice: commons.NewMoney(10000, "USD"), TaxCategory: "standard", CreatedBy: "testuser"},
+			mockReturnValue: ItemRow{Id: 1, AltId: uuid.New(), Name: "item1", Description: "description1", UnitPrice: commons.NewMoney(10000, "USD"), TaxCategory: "standard", CreatedBy: "testuser"},
 			mockError:       nil,
 			expectedError:   nil,
 		},
 		{
 			name:            "RepoError",
-			givenRequest:    CreateItemRequest{Name: "item1", Description: "description1", UnitPrice: 100.00, CreatedBy: "testuser"},
+			givenRequest:    CreateItemRequest{Name: "item1", Description: "description1", UnitPrice: commons.NewMoney(10000, "USD"), TaxCategory: "standard", CreatedBy: "testuser"},
 			mockReturnValue: ItemRow{},
 			mockError:       errors.New("DB Error"),
 			expectedError:   errors.New("DB Error"),
@@ -40,17 +43,20 @@ func TestItemService_CreateItem(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockRepo.EXPECT().CreateItem(tt.givenRequest).Return(tt.mockReturnValue, tt.mockError)
+			mockRepo.EXPECT().CreateItem(gomock.Any(), tt.givenRequest).Return(tt.mockReturnValue, tt.mockError)
 
-			newItem, err := service.CreateItem(tt.givenRequest)
+			changes = nil
+			newItem, err := service.CreateItem(context.Background(), tt.givenRequest)
 
 			if tt.mockError != nil {
 				assert.Nil(t, newItem)
 				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Empty(t, changes)
 			} else {
 				mockValue := itemFromRow(tt.mockReturnValue)
 				assert.Equal(t, &mockValue, newItem)
 				assert.Nil(t, err)
+				assert.Equal(t, []commons.Change{{Topic: ChangeTopic, Action: commons.ChangeCreated, Id: mockValue.Id, Data: mockValue, Tenant: commons.DefaultTenant}}, changes)
 			}
 		})
 	}
@@ -71,14 +77,14 @@ func TestItemService_UpdateItem(t *testing.T) {
 	}{
 		{
 			name:            "ValidRequest",
-			givenRequest:    UpdateItemRequest{Id: uuid.New(), Name: "item1", Description: "description1", UnitPrice: 100.00, LastChangedBy: "testuser"},
-			mockReturnValue: ItemRow{Id: 1, AltId: uuid.New(), Name: "item1", Description: "description1", UnitPrice: 100.00, CreatedBy: "testuser"},
+			givenRequest:    UpdateItemRequest{Id: uuid.New(), Name: "item1", Description: "description1", UnitPrice: commons.NewMoney(10000, "USD"), TaxCategory: "standard", LastChangedBy: "testuser"},
+			mockReturnValue: ItemRow{Id: 1, AltId: uuid.New(), Name: "item1", Description: "description1", UnitPrice: commons.NewMoney(10000, "USD"), TaxCategory: "standard", CreatedBy: "testuser"},
 			mockError:       nil,
 			expectedError:   nil,
 		},
 		{
 			name:            "RepoError",
-			givenRequest:    UpdateItemRequest{Id: uuid.New(), Name: "item1", Description: "description1", UnitPrice: 100.00, LastChangedBy: "testuser"},
+			givenRequest:    UpdateItemRequest{Id: uuid.New(), Name: "item1", Description: "description1", UnitPrice: commons.NewMoney(10000, "USD"), TaxCategory: "standard", LastChangedBy: "testuser"},
 			mockReturnValue: ItemRow{},
 			mockError:       errors.New("DB Error"),
 			expectedError:   errors.New("DB Error"),
@@ -87,9 +93,9 @@ func TestItemService_UpdateItem(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockRepo.EXPECT().UpdateItem(tt.givenRequest).Return(tt.mockReturnValue, tt.mockError)
+			mockRepo.EXPECT().UpdateItem(gomock.Any(), tt.givenRequest).Return(tt.mockReturnValue, tt.mockError)
 
-			newItem, err := service.UpdateItem(tt.givenRequest)
+			newItem, err := service.UpdateItem(context.Background(), tt.givenRequest)
 
 			if tt.mockError != nil {
 				assert.Nil(t, newItem)
@@ -141,9 +147,9 @@ func TestItemService_DeleteItem(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockRepo.EXPECT().DeleteItem(tt.givenId).Return(tt.mockReturnValue, tt.mockError)
+			mockRepo.EXPECT().DeleteItem(gomock.Any(), tt.givenId).Return(tt.mockReturnValue, tt.mockError)
 
-			deleteResult, err := service.DeleteItem(tt.givenId)
+			deleteResult, err := service.DeleteItem(context.Background(), tt.givenId)
 
 			if tt.mockError != nil {
 				assert.Nil(t, deleteResult)
@@ -172,7 +178,7 @@ func TestItemService_GetItem(t *testing.T) {
 		{
 			name:            "ValidRequest",
 			givenId:         uuid.New(),
-			mockReturnValue: ItemRow{Id: 1, AltId: uuid.New(), Name: "item1", Description: "description1", UnitPrice: 100.00, CreatedBy: "testuser"},
+			mockReturnValue: ItemRow{Id: 1, AltId: uuid.New(), Name: "item1", Description: "description1", UnitPrice: commons.NewMoney(10000, "USD"), TaxCategory: "standard", CreatedBy: "testuser"},
 			mockError:       nil,
 			expectedError:   nil,
 		},
@@ -187,9 +193,9 @@ func TestItemService_GetItem(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockRepo.EXPECT().GetItem(tt.givenId).Return(tt.mockReturnValue, tt.mockError)
+			mockRepo.EXPECT().GetItem(gomock.Any(), tt.givenId).Return(tt.mockReturnValue, tt.mockError)
 
-			newItem, err := service.GetItem(tt.givenId)
+			newItem, err := service.GetItem(context.Background(), tt.givenId)
 
 			if tt.mockError != nil {
 				assert.Nil(t, newItem)
@@ -219,7 +225,7 @@ func TestItemService_GetItems(t *testing.T) {
 		{
 			name:            "ValidRequest",
 			givenRequest:    &commons.Pagination{LastId: 1, PageSize: 10},
-			mockReturnValue: []ItemRow{{Id: 1, AltId: uuid.New(), Name: "item1", Description: "description1", UnitPrice: 100.00, CreatedBy: "testuser"}},
+			mockReturnValue: []ItemRow{{Id: 1, AltId: uuid.New(), Name: "item1", Description: "description1", UnitPrice: commons.NewMoney(10000, "USD"), CreatedBy: "testuser"}},
 			mockError:       nil,
 			expectedError:   nil,
 		},
@@ -241,9 +247,9 @@ func TestItemService_GetItems(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockRepo.EXPECT().GetItems(tt.givenRequest).Return(tt.mockReturnValue, tt.mockError)
+			mockRepo.EXPECT().GetItems(gomock.Any(), tt.givenRequest).Return(tt.mockReturnValue, tt.mockError)
 
-			items, err := service.GetItems(tt.givenRequest)
+			items, err := service.GetItems(context.Background(), tt.givenRequest)
 
 			if tt.mockError != nil {
 				assert.Empty(t, items)
@@ -259,3 +265,19 @@ func TestItemService_GetItems(t *testing.T) {
 		})
 	}
 }
+
+func TestItemService_UnsupportedCurrency(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := NewMockItemRepository(controller)
+	service := NewItemService(mockRepo)
+
+	_, err := service.CreateItem(context.Background(), CreateItemRequest{Name: "item1", UnitPrice: commons.NewMoney(100, "JPY")})
+	assert.ErrorIs(t, err, commons.ErrUnsupportedCurrency)
+	_, err = service.UpdateItem(context.Background(), UpdateItemRequest{Id: uuid.New(), Name: "item1", UnitPrice: commons.NewMoney(100, "JPY")})
+	assert.ErrorIs(t, err, commons.ErrUnsupportedCurrency)
+}
+
+func TestItemFromRow_Currency(t *testing.T) {
+	row := ItemRow{Id: 1, AltId: uuid.New(), UnitPrice: commons.NewMoney(1099, commons.DefaultCurrency), Currency: "GBP"}
+	assert.Equal(t, commons.NewMoney(1099, "GBP"), itemFromRow(row).UnitPrice)
+}
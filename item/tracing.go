@@ -0,0 +1,57 @@
+package item
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"inventory-service-go/commons"
+	"inventory-service-go/tracing"
+)
+
+// TracingItemService gives every call of the decorated service a span of its own.
+type TracingItemService struct {
+	service ItemService
+}
+
+func NewTracingItemService(service ItemService) *TracingItemService {
+	return &TracingItemService{
+		service: service,
+	}
+}
+
+// OnChange observes the changes of the decorated service.
+func (s *TracingItemService) OnChange(hook commons.ChangeHook) {
+	if notifier, ok := s.service.(commons.ChangeNotifier); ok {
+		notifier.OnChange(hook)
+	}
+}
+
+func (s *TracingItemService) CreateItem(ctx context.Context, request CreateItemRequest) (i *Item, err error) {
+	ctx, span := tracing.Start(ctx, "ItemService.CreateItem")
+	defer func() { tracing.End(span, err) }()
+	return s.service.CreateItem(ctx, request)
+}
+
+func (s *TracingItemService) UpdateItem(ctx context.Context, request UpdateItemRequest) (i *Item, err error) {
+	ctx, span := tracing.Start(ctx, "ItemService.UpdateItem", attribute.String("item.id", request.Id.String()))
+	defer func() { tracing.End(span, err) }()
+	return s.service.UpdateItem(ctx, request)
+}
+
+func (s *TracingItemService) DeleteItem(ctx context.Context, id uuid.UUID) (r *commons.DeleteResult, err error) {
+	ctx, span := tracing.Start(ctx, "ItemService.DeleteItem", attribute.String("item.id", id.String()))
+	defer func() { tracing.End(span, err) }()
+	return s.service.DeleteItem(ctx, id)
+}
+
+func (s *TracingItemService) GetItem(ctx context.Context, id uuid.UUID) (i *Item, err error) {
+	ctx, span := tracing.Start(ctx, "ItemService.GetItem", attribute.String("item.id", id.String()))
+	defer func() { tracing.End(span, err) }()
+	return s.service.GetItem(ctx, id)
+}
+
+func (s *TracingItemService) GetItems(ctx context.Context, pagination *commons.Pagination) (items []Item, err error) {
+	ctx, span := tracing.Start(ctx, "ItemService.GetItems")
+	defer func() { tracing.End(span, err) }()
+	return s.service.GetItems(ctx, pagination)
+}
@@ -5,15 +5,18 @@ package item
 
 import (
 	"github.com/google/wire"
+	"inventory-service-go/cache"
 	"inventory-service-go/commons"
 )
 
-func InitializeItemService() (ItemService, error) {
+func InitializeItemService(settings cache.Config) (ItemService, error) {
 	wire.Build(
+		ProvideItemService,
 		NewItemService,
 		NewItemRepository,
+		cache.GetBus,
 		commons.GetDB,
-		wire.Bind(new(ItemService), new(*ItemServiceImpl)),
+		wire.Bind(new(cache.Invalidator), new(*cache.Bus)),
 		wire.Bind(new(ItemRepository), new(*ItemRepositoryImpl)),
 	)
 	return nil, nil
@@ -7,14 +7,17 @@
 package item
 
 import (
+	"inventory-service-go/cache"
 	"inventory-service-go/commons"
 )
 
 // Injectors from wire.go:
 
-func InitializeItemService() (ItemService, error) {
+func InitializeItemService(settings cache.Config) (ItemService, error) {
 	db := commons.GetDB()
 	itemRepositoryImpl := NewItemRepository(db)
 	itemServiceImpl := NewItemService(itemRepositoryImpl)
-	return itemServiceImpl, nil
+	bus := cache.GetBus(db)
+	itemService := ProvideItemService(itemServiceImpl, bus, settings)
+	return itemService, nil
 }
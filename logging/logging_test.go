@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var result []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log line %q is not JSON: %v", line, err)
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+func TestNew_Redacts(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+	logger.Info("login",
+		"Authorization", "Bearer eyJhbGciOi",
+		"password", "hunter2",
+		slog.Group("request", "api_token", "s3cret", "header", "basic dXNlcjpwYXNz"),
+		"user", "ann",
+	)
+
+	entry := lines(t, &buf)[0]
+	assert.Equal(t, Redacted, entry["Authorization"])
+	assert.Equal(t, Redacted, entry["password"])
+	assert.Equal(t, map[string]any{"api_token": Redacted, "header": Redacted}, entry["request"])
+	assert.Equal(t, "ann", entry["user"])
+	assert.NotContains(t, buf.String(), "eyJhbGciOi")
+}
+
+func TestNew_Context(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo).With("component", "test")
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+	ctx, span := provider.Tracer("test").Start(WithRequestId(context.Background(), "req-1"), "span")
+	defer span.End()
+
+	logger.InfoContext(ctx, "with request")
+	logger.Info("without request")
+
+	entries := lines(t, &buf)
+	assert.Equal(t, "req-1", entries[0]["request_id"])
+	assert.Equal(t, span.SpanContext().TraceID().String(), entries[0]["trace_id"])
+	assert.Equal(t, "test", entries[0]["component"])
+	assert.NotContains(t, entries[1], "request_id")
+	assert.NotContains(t, entries[1], "trace_id")
+}
+
+func TestNew_Level(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelWarn)
+	logger.Info("dropped")
+	logger.Warn("kept")
+	entries := lines(t, &buf)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "kept", entries[0]["msg"])
+	assert.Equal(t, "WARN", entries[0]["level"])
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		level   string
+		want    slog.Level
+		wantErr bool
+	}{
+		{"", slog.LevelInfo, false},
+		{"debug", slog.LevelDebug, false},
+		{"WARN", slog.LevelWarn, false},
+		{"error", slog.LevelError, false},
+		{"chatty", slog.LevelInfo, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			got, err := ParseLevel(tt.level)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Same(t, slog.Default(), FromContext(context.Background()))
+	logger := New(&bytes.Buffer{}, slog.LevelInfo)
+	assert.Same(t, logger, FromContext(WithLogger(context.Background(), logger)))
+	assert.Equal(t, "", RequestId(context.Background()))
+}
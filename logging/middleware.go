@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// maxRequestIdLength bounds the request ids taken from the client.
+const maxRequestIdLength = 128
+
+// Middleware gives every request an id, the X-Request-Id of the client when it sent a usable one, and returns it in
+// the X-Request-Id response header. The request context carries the id and the logger, so every line logged with it
+// names the request. Every request is logged once it is handled.
+func Middleware(logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			req := c.Request()
+			id := req.Header.Get(echo.HeaderXRequestID)
+			if !validRequestId(id) {
+				id = uuid.NewString()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, id)
+			ctx := WithLogger(WithRequestId(req.Context(), id), logger)
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			status := c.Response().Status
+			level := slog.LevelInfo
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("route", c.Path()),
+				slog.String("path", req.URL.Path),
+				slog.String("remote_ip", c.RealIP()),
+			}
+			if err != nil {
+				// the error is only written to the response by the error handler, after the middlewares
+				var httpError *echo.HTTPError
+				if errors.As(err, &httpError) {
+					status = httpError.Code
+				} else if status == http.StatusOK || status == 0 {
+					status = http.StatusInternalServerError
+				}
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			attrs = append(attrs, slog.Int("status", status), slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000))
+			logger.LogAttrs(ctx, level, "request", attrs...)
+			return err
+		}
+	}
+}
+
+// validRequestId accepts short, printable ASCII ids.
+func validRequestId(id string) bool {
+	if id == "" || len(id) > maxRequestIdLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
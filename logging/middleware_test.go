@@ -0,0 +1,72 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+	e := echo.New()
+	e.Use(Middleware(logger))
+	e.GET("/items/:id", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		FromContext(ctx).InfoContext(ctx, "handling", "authorization", c.Request().Header.Get(echo.HeaderAuthorization))
+		return c.String(http.StatusOK, RequestId(ctx))
+	})
+	e.GET("/boom", func(c echo.Context) error { return errors.New("BOOM") })
+
+	tests := []struct {
+		name      string
+		path      string
+		requestId string
+		reused    bool
+	}{
+		{"generated", "/items/1", "", false},
+		{"from the client", "/items/1", "client-42", true},
+		{"unusable", "/items/1", "bad id\n", false},
+		{"too long", "/items/1", strings.Repeat("x", maxRequestIdLength+1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer s3cret")
+			if tt.requestId != "" {
+				req.Header.Set(echo.HeaderXRequestID, tt.requestId)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			id := rec.Header().Get(echo.HeaderXRequestID)
+			assert.NotEmpty(t, id)
+			assert.Equal(t, tt.reused, id == tt.requestId)
+			assert.Equal(t, id, rec.Body.String())
+			entries := lines(t, &buf)
+			if assert.Len(t, entries, 2) {
+				assert.Equal(t, id, entries[0]["request_id"])
+				assert.Equal(t, Redacted, entries[0]["authorization"])
+				assert.Equal(t, "request", entries[1]["msg"])
+				assert.Equal(t, id, entries[1]["request_id"])
+				assert.Equal(t, "/items/:id", entries[1]["route"])
+				assert.Equal(t, float64(http.StatusOK), entries[1]["status"])
+			}
+			assert.NotContains(t, buf.String(), "s3cret")
+		})
+	}
+
+	buf.Reset()
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/boom", nil))
+	entry := lines(t, &buf)[0]
+	assert.Equal(t, "ERROR", entry["level"])
+	assert.Equal(t, float64(http.StatusInternalServerError), entry["status"])
+	assert.Equal(t, "BOOM", entry["error"])
+}
@@ -1,19 +1,49 @@
 package main
 
 import (
-	"fmt"
+	gocontext "context"
+	"errors"
+	"flag"
 	"github.com/joho/godotenv"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/mvrilo/go-redoc"
 	echoredoc "github.com/mvrilo/go-redoc/echo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"inventory-service-go/apiv2"
+	"inventory-service-go/commons"
+	"inventory-service-go/config"
 	"inventory-service-go/context"
+	"inventory-service-go/grpcapi"
 	"inventory-service-go/handlers"
+	"inventory-service-go/logging"
+	"inventory-service-go/metrics"
+	"inventory-service-go/outbox"
+	"inventory-service-go/ratelimit"
+	"inventory-service-go/recurring"
+	"inventory-service-go/tracing"
+	"inventory-service-go/webhook"
+	"io/fs"
 	"log"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
 	"slices"
+	"syscall"
+	"time"
 )
 
+// publicPaths are not behind the JWT middleware. /metrics is protected by METRICS_TOKEN instead unless it is made
+// public, see config.Metrics, /api/v1/stream by a JWT middleware of its own and the OAuth endpoints authenticate the
+// clients themselves.
+var publicPaths = []string{"/api/v1/authorize", "", "/docs", "/docs/swagger.json", "/docs/v2", "/redoc.standalone.js.map", "/api/v1/stream", "/metrics", "/healthz", "/readyz", "/oauth/token", "/oauth/introspect", "/.well-known/oauth-authorization-server", "/.well-known/jwks.json"}
+
+// signInPaths take credentials, they are rate limited by IP although public.
+var signInPaths = []string{"/api/v1/authorize", "/oauth/token", "/oauth/introspect"}
+
 // @title Inventory Service API
 // @version 1.0
 // @description This is an implementation of the imventory-service in Go.
@@ -27,42 +57,217 @@ import (
 // @host localhost:8080
 // @BasePath /api/v1
 func main() {
-	err := godotenv.Load()
+	// the .env file is optional, the settings can also come from the environment, a config file or the flags
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Fatalf("Error loading .env file: %v", err)
+	}
+	cfg, err := config.Load(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Error loading the configuration: %v", err)
+	}
+	level, err := logging.ParseLevel(cfg.Logging.Level)
 	if err != nil {
-		log.Fatalf("Error loading .env file")
+		log.Fatalf("Error configuring logging: %v", err)
+	}
+	logger := logging.New(os.Stdout, level)
+	// also turns the log package and the default logger of the background workers into JSON
+	slog.SetDefault(logger)
+	shutdownTracing, err := tracing.Setup(gocontext.Background(), tracing.Settings(cfg.Tracing))
+	if err != nil {
+		log.Fatalf("Error configuring tracing: %v", err)
+	}
+	commons.ConfigureDB(commons.DBConfig(cfg.Database))
+	// the tenants are only isolated by the row-level security policies, which do not hold for such a role
+	if bypasses, err := commons.BypassesRLS(gocontext.Background(), commons.GetDB()); err != nil {
+		log.Fatalf("Error checking the row-level security of the database role: %v", err)
+	} else if bypasses {
+		log.Fatalf("The database role is a superuser or has BYPASSRLS, connect with a role bound by row-level security")
 	}
 	e := echo.New()
-	appContext := context.NewApplicationContext()
+	// the client IP is taken from X-Forwarded-For only when a proxy on a private network sent it, a client could
+	// otherwise send its own to get around the rate limit of /api/v1/authorize
+	e.IPExtractor = echo.ExtractIPFromXFFHeader()
+	// attributes the changes made with an API key to the key
+	e.Binder = &handlers.Binder{}
+	appContext := context.NewApplicationContext(cfg).WithLogger(logger)
+	jwtConfig := echojwt.Config{
+		Skipper: func(c echo.Context) bool {
+			// requests made with an API key are authenticated by handlers.APIKeyAuth
+			return slices.Contains(publicPaths, c.Path()) || c.Get("user") != nil
+		},
+		// the same validation as the gRPC API, see grpcapi, which selects the key by the kid of the token when they are
+		// signed with keys
+		ParseTokenFunc: func(c echo.Context, token string) (interface{}, error) {
+			return appContext.AuthProvider().ValidateToken(c.Request().Context(), token)
+		},
+		ErrorHandler: func(c echo.Context, err error) error {
+			ctx := c.Request().Context()
+			logging.FromContext(ctx).WarnContext(ctx, "Unauthorized request", "error", err)
+			return c.String(401, "Unauthorized")
+		},
+	}
+	// the stream takes the same token, also from the query as EventSource and browser WebSocket clients cannot set
+	// the Authorization header
+	streamJwtConfig := jwtConfig
+	streamJwtConfig.Skipper = nil
+	streamJwtConfig.TokenLookup = "header:Authorization:Bearer ,query:access_token"
+
 	apiV1 := e.Group("/api/v1")
 	apiV1.POST("/authorize", handlers.Authorize(appContext))
-	handlers.PersonRoutes(apiV1, appContext)
-	handlers.ItemRoutes(apiV1, appContext)
-	handlers.InvoiceRoutes(apiV1, appContext)
+	// v2 replaces these, the rest of v1 is still current
+	deprecated := handlers.Deprecated(handlers.V1Deprecated, "/api/v1", "/api/v2")
+	handlers.PersonRoutes(apiV1, appContext, deprecated)
+	handlers.ItemRoutes(apiV1, appContext, deprecated)
+	handlers.InvoiceRoutes(apiV1, appContext, deprecated)
+	handlers.ExchangeRateRoutes(apiV1, appContext, cfg.Tenants.Admins)
+	handlers.TaxRateRoutes(apiV1, appContext, cfg.Tenants.Admins)
+	handlers.PromotionRoutes(apiV1, appContext)
+	handlers.RecurringInvoiceRoutes(apiV1, appContext)
+	handlers.WebhookRoutes(apiV1, appContext)
+	handlers.APIKeyRoutes(apiV1, appContext, cfg.APIKeys.Admins)
+	handlers.TenantRoutes(apiV1, appContext, cfg.Tenants.Admins)
+	handlers.StreamRoutes(apiV1, appContext, echojwt.WithConfig(streamJwtConfig), handlers.TenantScope())
+	apiv2.Routes(e.Group("/api/v2"), appContext)
+	handlers.HealthRoutes(e.Group(""), appContext)
+	// the issuer is not taken from the request, a client could send any Host
+	if cfg.OAuth.Issuer != "" {
+		handlers.OAuthRoutes(e.Group(""), appContext, cfg.OAuth.IssuerURL())
+	}
+	handlers.JWKSRoutes(e.Group(""), appContext)
+	handlers.GraphQLRoutes(e.Group(""), appContext)
+	metricsAuth := []echo.MiddlewareFunc{metrics.TokenAuth(cfg.Metrics.Token)}
+	if cfg.Metrics.Public {
+		metricsAuth = nil
+	}
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()), metricsAuth...)
+	metrics.RegisterDB(commons.GetDB().DB, "inventory")
+
+	scheduler := recurring.NewScheduler(appContext.RecurringInvoiceService(), cfg.Recurring.Interval)
+	scheduler.Start()
+
+	relay, err := outbox.InitializeRelay(cfg.Outbox)
+	if err != nil {
+		log.Fatalf("Error configuring the outbox relay: %v", err)
+	}
+	relay.AddSink(appContext.WebhookService())
+	relay.Start()
+
+	store := ratelimit.Store(ratelimit.NewMemoryStore())
+	if cfg.RateLimit.Store == "postgres" {
+		store = ratelimit.NewPostgresStore(commons.GetDB())
+	}
+	limiter := ratelimit.New(cfg.RateLimit, store)
+
+	dispatcher := webhook.NewDispatcher(appContext.WebhookService(), cfg.Webhooks.DispatchInterval)
+	dispatcher.Start()
 
 	//middlewares
-	e.Use(middleware.CORS())
-	e.Use(middleware.Logger())
+	e.Use(tracing.Middleware())
+	e.Use(metrics.Middleware())
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{AllowOrigins: cfg.CORS.AllowOrigins, ExposeHeaders: []string{"Deprecation", "Link", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"}}))
+	e.Use(logging.Middleware(appContext.Logger()))
 	e.Use(middleware.Recover())
 	e.Use(echoredoc.New(doc()))
-	e.Use(echojwt.WithConfig(echojwt.Config{
-		Skipper: func(c echo.Context) bool {
-			pathsToSkip := []string{"/api/v1/authorize", "", "/docs", "/docs/swagger.json", "/redoc.standalone.js.map"}
-			log.Printf("Path: '%s'", c.Path())
-			log.Printf("Will Skip: %v", slices.Contains(pathsToSkip, c.Path()))
-			return slices.Contains(pathsToSkip, c.Path())
-		},
-		SigningMethod: "HS256",
-		SigningKey:    appContext.AuthProvider().GetSecret(),
-		ErrorHandler: func(c echo.Context, err error) error {
-			fmt.Printf("Authorization header: %v\n", c.Request().Header.Get("Authorization"))
-			fmt.Printf("JWT error: %v\n", err)
-			return c.String(401, "Unauthorized")
-		},
+	e.Use(echoredoc.New(docV2()))
+	e.Use(handlers.APIKeyAuth(appContext, func(c echo.Context) bool {
+		return slices.Contains(publicPaths, c.Path())
 	}))
+	e.Use(echojwt.WithConfig(jwtConfig))
+	e.Use(handlers.TenantScope())
+	// after the JWT middleware, which tells who the client is; the sign ins are limited by IP
+	e.Use(limiter.Middleware(func(c echo.Context) bool {
+		return !slices.Contains(signInPaths, c.Path()) && slices.Contains(publicPaths, c.Path())
+	}))
+
 	// Start the server
-	err = e.Start(":8080")
-	if err != nil {
-		return
+	server := e.Server
+	if cfg.TLS.Enabled() {
+		server = e.TLSServer
+	}
+	server.ReadTimeout = cfg.Server.ReadTimeout
+	server.ReadHeaderTimeout = cfg.Server.ReadHeaderTimeout
+	server.WriteTimeout = cfg.Server.WriteTimeout
+	server.IdleTimeout = cfg.Server.IdleTimeout
+	// streams only end on their own when the client goes away
+	server.RegisterOnShutdown(appContext.StreamBroker().Close)
+	// one for each of the REST and the gRPC server
+	serverErrors := make(chan error, 2)
+	go func() {
+		if cfg.TLS.Enabled() {
+			serverErrors <- e.StartTLS(cfg.Server.Address, cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		} else {
+			serverErrors <- e.Start(cfg.Server.Address)
+		}
+	}()
+
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Address != "" {
+		var opts []grpc.ServerOption
+		if cfg.TLS.Enabled() {
+			creds, err := credentials.NewServerTLSFromFile(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+			if err != nil {
+				log.Fatalf("Error loading the TLS certificate: %v", err)
+			}
+			opts = append(opts, grpc.Creds(creds))
+		}
+		listener, err := net.Listen("tcp", cfg.GRPC.Address)
+		if err != nil {
+			log.Fatalf("Error listening for gRPC: %v", err)
+		}
+		grpcServer = grpcapi.NewServer(appContext, opts...)
+		go func() {
+			logger.Info("Serving gRPC", "address", listener.Addr().String())
+			serverErrors <- grpcServer.Serve(listener)
+		}()
+	}
+
+	signals, stop := signal.NotifyContext(gocontext.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	select {
+	case err = <-serverErrors:
+		logger.Error("Error running the server", "error", err)
+	case <-signals.Done():
+		// readiness fails first, so the load balancer stops sending requests before the server stops taking them
+		logger.Info("Shutting down")
+		appContext.HealthChecker().Shutdown()
+		time.Sleep(cfg.Server.ShutdownDelay)
+		ctx, cancel := gocontext.WithTimeout(gocontext.Background(), cfg.Server.ShutdownTimeout)
+		if err = e.Shutdown(ctx); err != nil {
+			logger.Error("Error draining the requests in flight", "error", err)
+		}
+		if grpcServer != nil {
+			stopGRPC(ctx, grpcServer)
+		}
+		cancel()
+	}
+
+	dispatcher.Stop()
+	relay.Stop()
+	scheduler.Stop()
+	if err = commons.CloseDB(); err != nil {
+		logger.Error("Error closing the database pool", "error", err)
+	}
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), 5*time.Second)
+	defer cancel()
+	if err = shutdownTracing(ctx); err != nil {
+		logger.Error("Error flushing the traces", "error", err)
+	}
+}
+
+// stopGRPC lets the calls in flight finish until ctx is done and then cancels the ones left, list streams included.
+func stopGRPC(ctx gocontext.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
 	}
 }
 
@@ -75,3 +280,14 @@ func doc() redoc.Redoc {
 		DocsPath:    "/docs",
 	}
 }
+
+// docV2 has a spec path of its own, the redoc middleware serves its spec for every path ending in the spec path.
+func docV2() redoc.Redoc {
+	return redoc.Redoc{
+		Title:       "Inventory Service API v2",
+		Description: "Version 2 of the inventory-service API",
+		SpecFile:    "./docs/v2/v2_swagger.json",
+		SpecPath:    "/swagger-v2.json",
+		DocsPath:    "/docs/v2",
+	}
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"inventory-service-go/cache"
+)
+
+var (
+	cacheHits      = prometheus.NewDesc(namespace+"_cache_hits_total", "Lookups answered from the cache.", []string{"cache"}, nil)
+	cacheMisses    = prometheus.NewDesc(namespace+"_cache_misses_total", "Lookups the cache could not answer.", []string{"cache"}, nil)
+	cacheEvictions = prometheus.NewDesc(namespace+"_cache_evictions_total", "Entries evicted to make room.", []string{"cache"}, nil)
+	cacheEntries   = prometheus.NewDesc(namespace+"_cache_entries", "Entries in the cache.", []string{"cache"}, nil)
+)
+
+// cacheCollector reads the counters the caches keep themselves, see cache.AllStats.
+type cacheCollector struct{}
+
+func (cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheHits
+	ch <- cacheMisses
+	ch <- cacheEvictions
+	ch <- cacheEntries
+}
+
+// Collect adds up the caches of the same name, a name must only be reported once.
+func (cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	byName := map[string]cache.Stats{}
+	for _, s := range cache.AllStats() {
+		stats := byName[s.Name]
+		stats.Name = s.Name
+		stats.Hits += s.Hits
+		stats.Misses += s.Misses
+		stats.Evictions += s.Evictions
+		stats.Size += s.Size
+		byName[s.Name] = stats
+	}
+	for _, stats := range byName {
+		ch <- prometheus.MustNewConstMetric(cacheHits, prometheus.CounterValue, float64(stats.Hits), stats.Name)
+		ch <- prometheus.MustNewConstMetric(cacheMisses, prometheus.CounterValue, float64(stats.Misses), stats.Name)
+		ch <- prometheus.MustNewConstMetric(cacheEvictions, prometheus.CounterValue, float64(stats.Evictions), stats.Name)
+		ch <- prometheus.MustNewConstMetric(cacheEntries, prometheus.GaugeValue, float64(stats.Size), stats.Name)
+	}
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"database/sql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"time"
+)
+
+const namespace = "inventory"
+
+// Registry holds the metrics of the service, the Go runtime and the process. It is not the global registry so
+// libraries cannot add to /metrics behind our back.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "repository_query_duration_seconds",
+		Help:      "Latency of the repository methods, including their transactions.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"repository", "method"})
+
+	InvoicesCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "invoices_created_total",
+		Help:      "Invoices created, including those of recurring invoices.",
+	})
+	InvoicesIssued = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "invoices_issued_total",
+		Help:      "Invoices given a number.",
+	})
+	InvoiceItemsAttached = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "invoice_items_attached_total",
+		Help:      "Item lines attached to invoices.",
+	})
+	ItemsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "items_created_total",
+		Help:      "Items created.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		queryDuration,
+		InvoicesCreated,
+		InvoicesIssued,
+		InvoiceItemsAttached,
+		ItemsCreated,
+		cacheCollector{},
+	)
+}
+
+// RegisterDB exposes the connection pool stats of the database: open, in-use and idle connections and how often and
+// how long requests waited for one.
+func RegisterDB(db *sql.DB, name string) {
+	Registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveQuery times a repository method, it is deferred at the start of the method:
+//
+//	defer metrics.ObserveQuery("item", "GetItem")()
+func ObserveQuery(repository string, method string) func() {
+	start := time.Now()
+	return func() {
+		queryDuration.WithLabelValues(repository, method).Observe(time.Since(start).Seconds())
+	}
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
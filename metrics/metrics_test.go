@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/cache"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func scrape(t *testing.T) string {
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(Middleware())
+	e.GET("/items/:id", func(c echo.Context) error { return c.String(http.StatusOK, "OK") })
+	e.GET("/boom", func(c echo.Context) error { return errors.New("BOOM") })
+	e.GET("/missing", func(c echo.Context) error { return echo.ErrNotFound })
+
+	for _, path := range []string{"/items/1", "/items/2", "/boom", "/missing", "/nowhere"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	body := scrape(t)
+	assert.Contains(t, body, `inventory_http_requests_total{method="GET",route="/items/:id",status="200"} 2`)
+	assert.Contains(t, body, `inventory_http_requests_total{method="GET",route="/boom",status="500"} 1`)
+	assert.Contains(t, body, `inventory_http_requests_total{method="GET",route="/missing",status="404"} 1`)
+	assert.Contains(t, body, `route="unmatched",status="404"`)
+	assert.Contains(t, body, `inventory_http_request_duration_seconds_count{method="GET",route="/items/:id",status="200"} 2`)
+}
+
+func TestTokenAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		header     string
+		expectCode int
+	}{
+		{"no token configured", "", "Bearer ", http.StatusUnauthorized},
+		{"valid token", "s3cret", "Bearer s3cret", http.StatusOK},
+		{"missing token", "s3cret", "", http.StatusUnauthorized},
+		{"wrong token", "s3cret", "Bearer guess", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.GET("/metrics", echo.WrapHandler(Handler()), TokenAuth(tt.token))
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tt.expectCode, rec.Code)
+		})
+	}
+}
+
+func TestObserveQuery(t *testing.T) {
+	ObserveQuery("item", "GetItem")()
+	assert.Contains(t, scrape(t), `inventory_repository_query_duration_seconds_count{method="GetItem",repository="item"} 1`)
+}
+
+func TestBusinessCounters(t *testing.T) {
+	InvoicesCreated.Inc()
+	InvoiceItemsAttached.Add(3)
+	body := scrape(t)
+	assert.Contains(t, body, "inventory_invoices_created_total 1")
+	assert.Contains(t, body, "inventory_invoice_items_attached_total 3")
+	assert.Contains(t, body, "inventory_items_created_total 0")
+}
+
+func TestCacheCollector(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		c := cache.NewLRU[string, int]("metrics_test", cache.Config{Size: 10, TTL: cache.DefaultTTL})
+		c.Add("a", 1)
+		c.Get("a")
+		c.Get("b")
+	}
+	body := scrape(t)
+	assert.Contains(t, body, `inventory_cache_hits_total{cache="metrics_test"} 2`)
+	assert.Contains(t, body, `inventory_cache_misses_total{cache="metrics_test"} 2`)
+	assert.Contains(t, body, `inventory_cache_entries{cache="metrics_test"} 2`)
+}
+
+func TestRegisterDB(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	RegisterDB(db, "test")
+	body := scrape(t)
+	for _, name := range []string{"go_sql_open_connections", "go_sql_in_use_connections", "go_sql_idle_connections", "go_sql_wait_count_total", "go_sql_wait_duration_seconds_total"} {
+		assert.Contains(t, body, name+`{db_name="test"}`)
+	}
+}
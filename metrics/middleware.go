@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Middleware counts and times every request by its route, not its path, so ids do not blow up the label values.
+// Requests that match no route are counted as "unmatched".
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			status := c.Response().Status
+			if err != nil {
+				// the error is only written to the response by the error handler, after the middlewares
+				var httpError *echo.HTTPError
+				if errors.As(err, &httpError) {
+					status = httpError.Code
+				} else if status == http.StatusOK || status == 0 {
+					status = http.StatusInternalServerError
+				}
+			}
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+			labels := []string{c.Request().Method, route, strconv.Itoa(status)}
+			httpRequests.WithLabelValues(labels...).Inc()
+			httpDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
+
+// TokenAuth protects /metrics, which the JWT middleware skips as scrapers cannot log in. An empty token lets no one
+// in, the endpoint is only served without a token when config.Metrics is made public.
+func TokenAuth(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			given := []byte(c.Request().Header.Get(echo.HeaderAuthorization))
+			if token == "" || subtle.ConstantTimeCompare(given, []byte("Bearer "+token)) != 1 {
+				return c.String(http.StatusUnauthorized, "Unauthorized")
+			}
+			return next(c)
+		}
+	}
+}
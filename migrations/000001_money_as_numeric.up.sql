@@ -0,0 +1,3 @@
+-- prices and totals are exact decimals, mapped to commons.Money
+ALTER TABLE items ALTER COLUMN unit_price TYPE numeric(12, 2) USING round(unit_price::numeric, 2);
+ALTER TABLE invoices ALTER COLUMN total TYPE numeric(12, 2) USING round(total::numeric, 2);
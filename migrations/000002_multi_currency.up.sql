@@ -0,0 +1,23 @@
+-- items and invoices are priced in EUR, GBP or USD
+ALTER TABLE items ADD COLUMN currency char(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE invoices ADD COLUMN currency char(3) NOT NULL DEFAULT 'USD';
+
+-- the unit price of an attached item, converted into the invoice currency when it was attached
+ALTER TABLE invoices_items ADD COLUMN unit_price numeric(12, 2);
+ALTER TABLE invoices_items ADD COLUMN currency char(3);
+UPDATE invoices_items ii SET unit_price = i.unit_price, currency = i.currency FROM items i WHERE i.alt_id = ii.item_id;
+
+CREATE TABLE exchange_rates
+(
+    id              serial PRIMARY KEY,
+    alt_id          uuid          NOT NULL DEFAULT gen_random_uuid() UNIQUE,
+    from_currency   char(3)       NOT NULL,
+    to_currency     char(3)       NOT NULL,
+    rate            numeric(18, 8) NOT NULL CHECK (rate > 0),
+    effective_date  date          NOT NULL,
+    created_by      varchar(255)  NOT NULL,
+    created_at      timestamptz   NOT NULL DEFAULT now(),
+    last_changed_by varchar(255)  NOT NULL,
+    last_update     timestamptz   NOT NULL DEFAULT now(),
+    UNIQUE (from_currency, to_currency, effective_date)
+);
@@ -0,0 +1,25 @@
+-- items are taxed according to their category, e.g. standard, reduced or zero
+ALTER TABLE items ADD COLUMN tax_category varchar(64) NOT NULL DEFAULT 'standard';
+
+-- invoices are taxed in a jurisdiction, unit prices either include the tax or have it added on top
+ALTER TABLE invoices ADD COLUMN tax_jurisdiction varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE invoices ADD COLUMN prices_include_tax boolean NOT NULL DEFAULT false;
+
+-- the category and percentage that applied to an attached item when it was attached
+ALTER TABLE invoices_items ADD COLUMN tax_category varchar(64) NOT NULL DEFAULT 'standard';
+ALTER TABLE invoices_items ADD COLUMN tax_rate numeric(7, 4) NOT NULL DEFAULT 0;
+
+CREATE TABLE tax_rates
+(
+    id              serial PRIMARY KEY,
+    alt_id          uuid         NOT NULL DEFAULT gen_random_uuid() UNIQUE,
+    jurisdiction    varchar(64)  NOT NULL,
+    tax_category    varchar(64)  NOT NULL,
+    percentage      numeric(7, 4) NOT NULL CHECK (percentage >= 0),
+    effective_date  date         NOT NULL,
+    created_by      varchar(255) NOT NULL,
+    created_at      timestamptz  NOT NULL DEFAULT now(),
+    last_changed_by varchar(255) NOT NULL,
+    last_update     timestamptz  NOT NULL DEFAULT now(),
+    UNIQUE (jurisdiction, tax_category, effective_date)
+);
@@ -0,0 +1,39 @@
+-- a line discount taken off the unit price of an attached item, either a percentage or a fixed amount
+ALTER TABLE invoices_items ADD COLUMN discount_type varchar(16);
+ALTER TABLE invoices_items ADD COLUMN discount_value numeric(12, 4);
+
+CREATE TABLE promotion_codes
+(
+    id               serial PRIMARY KEY,
+    alt_id           uuid          NOT NULL DEFAULT gen_random_uuid() UNIQUE,
+    code             varchar(64)   NOT NULL UNIQUE,
+    description      varchar(255)  NOT NULL DEFAULT '',
+    discount_type    varchar(16)   NOT NULL,
+    discount_value   numeric(12, 4) NOT NULL CHECK (discount_value > 0),
+    currency         char(3)       NOT NULL,
+    min_order_amount numeric(12, 2) CHECK (min_order_amount >= 0),
+    valid_from       timestamptz   NOT NULL DEFAULT now(),
+    valid_until      timestamptz CHECK (valid_until > valid_from),
+    max_uses         integer CHECK (max_uses > 0),
+    times_used       integer       NOT NULL DEFAULT 0 CHECK (max_uses IS NULL OR times_used <= max_uses),
+    created_by       varchar(255)  NOT NULL,
+    created_at       timestamptz   NOT NULL DEFAULT now(),
+    last_changed_by  varchar(255)  NOT NULL,
+    last_update      timestamptz   NOT NULL DEFAULT now()
+);
+
+-- discounts on the invoice as a whole, shared across its lines before tax
+CREATE TABLE invoice_discounts
+(
+    id             serial PRIMARY KEY,
+    alt_id         uuid          NOT NULL DEFAULT gen_random_uuid() UNIQUE,
+    invoice_id     uuid          NOT NULL REFERENCES invoices (alt_id) ON DELETE CASCADE,
+    promotion_id   uuid REFERENCES promotion_codes (alt_id),
+    code           varchar(64),
+    description    varchar(255)  NOT NULL DEFAULT '',
+    discount_type  varchar(16)   NOT NULL,
+    discount_value numeric(12, 4) NOT NULL CHECK (discount_value > 0),
+    created_by     varchar(255)  NOT NULL,
+    created_at     timestamptz   NOT NULL DEFAULT now(),
+    UNIQUE (invoice_id, promotion_id)
+);
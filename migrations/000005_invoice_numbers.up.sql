@@ -0,0 +1,11 @@
+-- invoices get a human-readable number such as INV-2026-000123 when they are issued
+ALTER TABLE invoices ADD COLUMN number varchar(64) UNIQUE;
+ALTER TABLE invoices ADD COLUMN issued_at timestamptz;
+
+-- the last number allocated in every series, e.g. INV-2026, locked while an invoice is being issued so numbers
+-- are allocated without gaps
+CREATE TABLE invoice_number_series
+(
+    series     varchar(64) PRIMARY KEY,
+    last_value bigint      NOT NULL CHECK (last_value > 0)
+);
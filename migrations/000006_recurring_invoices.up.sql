@@ -0,0 +1,47 @@
+-- an attached item can be billed more than once on the same invoice
+ALTER TABLE invoices_items ADD COLUMN quantity integer NOT NULL DEFAULT 1 CHECK (quantity > 0);
+
+-- a template invoices are created from on every due date, next_occurrence counts the due dates from start_date so
+-- that month-end schedules do not drift
+CREATE TABLE recurring_invoices
+(
+    id                 serial PRIMARY KEY,
+    alt_id             uuid         NOT NULL DEFAULT gen_random_uuid() UNIQUE,
+    user_id            uuid         NOT NULL,
+    currency           char(3)      NOT NULL,
+    tax_jurisdiction   varchar(64)  NOT NULL DEFAULT '',
+    prices_include_tax boolean      NOT NULL DEFAULT false,
+    cadence            varchar(16)  NOT NULL CHECK (cadence IN ('monthly', 'quarterly', 'yearly')),
+    start_date         date         NOT NULL,
+    end_date           date CHECK (end_date >= start_date),
+    next_occurrence    integer      NOT NULL DEFAULT 0,
+    next_run_date      date         NOT NULL,
+    created_by         varchar(255) NOT NULL,
+    created_at         timestamptz  NOT NULL DEFAULT now(),
+    last_changed_by    varchar(255) NOT NULL,
+    last_update        timestamptz  NOT NULL DEFAULT now()
+);
+CREATE INDEX recurring_invoices_next_run_date_idx ON recurring_invoices (next_run_date);
+
+CREATE TABLE recurring_invoice_items
+(
+    recurring_invoice_id uuid    NOT NULL REFERENCES recurring_invoices (alt_id) ON DELETE CASCADE,
+    item_id              uuid    NOT NULL,
+    quantity             integer NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (recurring_invoice_id, item_id)
+);
+
+-- one run per template and due date, claimed before the invoice is created so a date is never invoiced twice
+CREATE TABLE recurring_invoice_runs
+(
+    id                   serial PRIMARY KEY,
+    alt_id               uuid        NOT NULL DEFAULT gen_random_uuid() UNIQUE,
+    recurring_invoice_id uuid        NOT NULL REFERENCES recurring_invoices (alt_id) ON DELETE CASCADE,
+    run_date             date        NOT NULL,
+    invoice_id           uuid REFERENCES invoices (alt_id) ON DELETE SET NULL,
+    status               varchar(16) NOT NULL,
+    error                text,
+    created_at           timestamptz NOT NULL DEFAULT now(),
+    last_update          timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (recurring_invoice_id, run_date)
+);
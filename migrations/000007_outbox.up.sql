@@ -0,0 +1,17 @@
+-- domain events written in the same transaction as the change they describe, the relay delivers them to the sinks
+-- and retries failed deliveries with a growing delay
+CREATE TABLE outbox
+(
+    id              bigserial PRIMARY KEY,
+    alt_id          uuid         NOT NULL UNIQUE,
+    event_type      varchar(64)  NOT NULL,
+    aggregate_type  varchar(32)  NOT NULL,
+    aggregate_id    uuid         NOT NULL,
+    payload         jsonb        NOT NULL,
+    occurred_at     timestamptz  NOT NULL DEFAULT now(),
+    attempts        integer      NOT NULL DEFAULT 0,
+    last_error      text,
+    next_attempt_at timestamptz  NOT NULL DEFAULT now(),
+    delivered_at    timestamptz
+);
+CREATE INDEX outbox_pending_idx ON outbox (next_attempt_at, id) WHERE delivered_at IS NULL;
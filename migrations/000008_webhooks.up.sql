@@ -0,0 +1,42 @@
+-- partner endpoints events are posted to, signed with the secret of the subscription
+CREATE TABLE webhook_subscriptions
+(
+    id              serial PRIMARY KEY,
+    alt_id          uuid          NOT NULL DEFAULT gen_random_uuid() UNIQUE,
+    url             varchar(2048) NOT NULL,
+    secret          varchar(128)  NOT NULL,
+    active          boolean       NOT NULL DEFAULT true,
+    created_by      varchar(255)  NOT NULL,
+    created_at      timestamptz   NOT NULL DEFAULT now(),
+    last_changed_by varchar(255)  NOT NULL,
+    last_update     timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE TABLE webhook_subscription_events
+(
+    subscription_id uuid        NOT NULL REFERENCES webhook_subscriptions (alt_id) ON DELETE CASCADE,
+    event_type      varchar(64) NOT NULL,
+    PRIMARY KEY (subscription_id, event_type)
+);
+CREATE INDEX webhook_subscription_events_event_type_idx ON webhook_subscription_events (event_type);
+
+-- one event to be posted to one subscription, the delivery log and dead-letter list (status dead) in one
+CREATE TABLE webhook_deliveries
+(
+    id               bigserial PRIMARY KEY,
+    alt_id           uuid        NOT NULL DEFAULT gen_random_uuid() UNIQUE,
+    subscription_id  uuid        NOT NULL REFERENCES webhook_subscriptions (alt_id) ON DELETE CASCADE,
+    event_id         uuid        NOT NULL,
+    event_type       varchar(64) NOT NULL,
+    payload          jsonb       NOT NULL,
+    status           varchar(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed', 'dead')),
+    attempts         integer     NOT NULL DEFAULT 0,
+    last_status_code integer,
+    last_error       text,
+    next_attempt_at  timestamptz NOT NULL DEFAULT now(),
+    delivered_at     timestamptz,
+    created_at       timestamptz NOT NULL DEFAULT now(),
+    last_update      timestamptz NOT NULL DEFAULT now(),
+    UNIQUE (subscription_id, event_id)
+);
+CREATE INDEX webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at, id) WHERE status IN ('pending', 'failed');
@@ -0,0 +1,14 @@
+-- the accounts that can sign in, service accounts have no password and are only given tokens by inventoryctl
+CREATE TABLE users
+(
+    id              serial PRIMARY KEY,
+    alt_id          uuid         NOT NULL DEFAULT gen_random_uuid() UNIQUE,
+    username        varchar(255) NOT NULL UNIQUE,
+    password_hash   varchar(255) NOT NULL DEFAULT '',
+    service_account boolean      NOT NULL DEFAULT false,
+    disabled_at     timestamptz,
+    created_by      varchar(255) NOT NULL,
+    created_at      timestamptz  NOT NULL DEFAULT now(),
+    last_changed_by varchar(255) NOT NULL,
+    last_update     timestamptz  NOT NULL DEFAULT now()
+);
@@ -0,0 +1,20 @@
+-- the token buckets of the rate limit, shared by the replicas when RATE_LIMIT_STORE is postgres, see ratelimit
+CREATE TABLE rate_limit_buckets
+(
+    key        varchar(512)     PRIMARY KEY,
+    tokens     double precision NOT NULL,
+    -- whether the last request took a token
+    allowed    boolean          NOT NULL,
+    updated_at timestamptz      NOT NULL DEFAULT now()
+);
+
+CREATE INDEX rate_limit_buckets_updated_at ON rate_limit_buckets (updated_at);
+
+-- the requests of every client a day (UTC), for the daily quotas
+CREATE TABLE rate_limit_quotas
+(
+    key   varchar(512) NOT NULL,
+    day   date         NOT NULL,
+    count integer      NOT NULL,
+    PRIMARY KEY (key, day)
+);
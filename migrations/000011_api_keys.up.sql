@@ -0,0 +1,19 @@
+-- the long-lived keys of machine clients, sent in the X-API-Key header, only the SHA-256 of a key is stored
+CREATE TABLE api_keys
+(
+    id              serial PRIMARY KEY,
+    alt_id          uuid          NOT NULL DEFAULT gen_random_uuid() UNIQUE,
+    name            varchar(255)  NOT NULL,
+    -- the start of the key, to tell the keys apart
+    prefix          varchar(16)   NOT NULL,
+    key_hash        char(64)      NOT NULL UNIQUE,
+    -- separated by spaces, as the scopes of OAuth
+    scopes          varchar(1024) NOT NULL,
+    expires_at      timestamptz,
+    last_used_at    timestamptz,
+    revoked_at      timestamptz,
+    created_by      varchar(255)  NOT NULL,
+    created_at      timestamptz   NOT NULL DEFAULT now(),
+    last_changed_by varchar(255)  NOT NULL,
+    last_update     timestamptz   NOT NULL DEFAULT now()
+);
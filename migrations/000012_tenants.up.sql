@@ -0,0 +1,61 @@
+-- the organisations sharing the service, every one only sees its own data
+CREATE TABLE tenants
+(
+    id              serial PRIMARY KEY,
+    alt_id          uuid         NOT NULL DEFAULT gen_random_uuid() UNIQUE,
+    name            varchar(255) NOT NULL UNIQUE,
+    created_by      varchar(255) NOT NULL,
+    created_at      timestamptz  NOT NULL DEFAULT now(),
+    last_changed_by varchar(255) NOT NULL,
+    last_update     timestamptz  NOT NULL DEFAULT now()
+);
+
+-- owns the data of before the tenants, see commons.DefaultTenant
+INSERT INTO tenants (alt_id, name, created_by, last_changed_by)
+VALUES ('00000000-0000-0000-0000-000000000001', 'default', 'migration', 'migration');
+
+-- the tenant a transaction was scoped to with commons.SetTenantQuery, NULL outside of one
+CREATE FUNCTION current_tenant() RETURNS uuid
+    LANGUAGE sql STABLE
+AS $$ SELECT nullif(current_setting('app.tenant_id', true), '')::uuid $$;
+
+-- a transaction scoped to a tenant sees the rows of the tenant, the background workers and inventoryctl are not
+-- scoped and see every row
+CREATE FUNCTION tenant_visible(tenant uuid) RETURNS boolean
+    LANGUAGE sql STABLE
+AS $$ SELECT current_tenant() IS NULL OR tenant = current_tenant() $$;
+
+-- the existing rows go to the default tenant, new ones to the tenant of the transaction or, written by the system, to
+-- the default tenant. The policies are forced so
+-- that they also hold for the owner of the tables, only superusers and roles with BYPASSRLS are not bound by them.
+-- Tax and exchange rates are shared by the tenants, as are the rate limits.
+DO
+$$
+    DECLARE
+        t text;
+    BEGIN
+        FOREACH t IN ARRAY ARRAY ['persons', 'items', 'invoices', 'invoices_items', 'invoice_discounts',
+            'invoice_number_series', 'promotion_codes', 'recurring_invoices', 'recurring_invoice_items',
+            'recurring_invoice_runs', 'outbox', 'webhook_subscriptions', 'webhook_subscription_events',
+            'webhook_deliveries', 'users', 'api_keys']
+            LOOP
+                EXECUTE format('ALTER TABLE %I ADD COLUMN tenant_id uuid NOT NULL DEFAULT %L REFERENCES tenants (alt_id)',
+                               t, '00000000-0000-0000-0000-000000000001');
+                EXECUTE format('ALTER TABLE %I ALTER COLUMN tenant_id SET DEFAULT coalesce(current_tenant(), %L)',
+                               t, '00000000-0000-0000-0000-000000000001');
+                EXECUTE format('CREATE INDEX %I ON %I (tenant_id)', t || '_tenant_id_idx', t);
+                EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+                EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+                EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (tenant_visible(tenant_id)) WITH CHECK (tenant_visible(tenant_id))', t);
+            END LOOP;
+    END
+$$;
+
+-- numbers and codes only have to be unique within a tenant, every tenant numbers its invoices from 1. Usernames stay
+-- unique across the tenants, the tenant of a sign in is that of the user.
+ALTER TABLE invoices DROP CONSTRAINT invoices_number_key;
+ALTER TABLE invoices ADD CONSTRAINT invoices_tenant_id_number_key UNIQUE (tenant_id, number);
+ALTER TABLE invoice_number_series DROP CONSTRAINT invoice_number_series_pkey;
+ALTER TABLE invoice_number_series ADD PRIMARY KEY (tenant_id, series);
+ALTER TABLE promotion_codes DROP CONSTRAINT promotion_codes_code_key;
+ALTER TABLE promotion_codes ADD CONSTRAINT promotion_codes_tenant_id_code_key UNIQUE (tenant_id, code);
@@ -0,0 +1,6 @@
+-- a transaction that was neither scoped to a tenant nor set to run as the system sees no rows and cannot write any,
+-- instead of seeing every tenant. The system is what commons.SetSystemQuery sets, the background workers and
+-- inventoryctl without -tenant run as it.
+CREATE OR REPLACE FUNCTION tenant_visible(tenant uuid) RETURNS boolean
+    LANGUAGE sql STABLE
+AS $$ SELECT coalesce(tenant = current_tenant(), false) OR coalesce(current_setting('app.system', true), '') = 'on' $$;
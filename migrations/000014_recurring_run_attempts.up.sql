@@ -0,0 +1,9 @@
+-- a run is claimed again when it failed or when it stayed pending past its lease, its scheduler having died, until it
+-- has had recurring.MaxRunAttempts. The invoice of a run is created with planned_invoice_id, so a retry can tell
+-- whether the attempt before it created the invoice after all.
+ALTER TABLE recurring_invoice_runs
+    ADD COLUMN attempts           int         NOT NULL DEFAULT 1,
+    ADD COLUMN claimed_at         timestamptz NOT NULL DEFAULT now(),
+    ADD COLUMN planned_invoice_id uuid        NOT NULL DEFAULT gen_random_uuid();
+
+CREATE INDEX recurring_invoice_runs_retry_idx ON recurring_invoice_runs (claimed_at) WHERE status <> 'completed';
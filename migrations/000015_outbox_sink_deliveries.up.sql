@@ -0,0 +1,10 @@
+-- the sinks an event has been delivered to, so a retry only goes to the sinks that failed. The relay claims the events
+-- it delivers by moving their next_attempt_at past a lease instead of keeping them locked, the rows are only written by
+-- it, running as the system, and hold no data of the tenants.
+CREATE TABLE outbox_sink_deliveries
+(
+    event_id     bigint      NOT NULL REFERENCES outbox (id) ON DELETE CASCADE,
+    sink         varchar(64) NOT NULL,
+    delivered_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (event_id, sink)
+);
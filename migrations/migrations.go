@@ -0,0 +1,50 @@
+// Package migrations holds the schema changes of this project, applied in the order of their versions by
+// golang-migrate or Up, which both record the applied version in schema_migrations.
+package migrations
+
+import (
+	"cmp"
+	"embed"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// Migration is one schema change, File is its name in the embedded files.
+type Migration struct {
+	Version uint
+	File    string
+}
+
+// All returns the migrations in the order they are applied in.
+func All() ([]Migration, error) {
+	names, err := fs.Glob(files, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+	all := make([]Migration, 0, len(names))
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s is not prefixed with its version: %w", name, err)
+		}
+		all = append(all, Migration{Version: uint(version), File: name})
+	}
+	slices.SortFunc(all, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	return all, nil
+}
+
+// Latest returns the version of the newest migration, the version schema_migrations is at once all are applied.
+func Latest() (uint, error) {
+	all, err := All()
+	if err != nil || len(all) == 0 {
+		return 0, err
+	}
+	return all[len(all)-1].Version, nil
+}
@@ -0,0 +1,12 @@
+package migrations
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLatest(t *testing.T) {
+	latest, err := Latest()
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, latest, uint(8))
+}
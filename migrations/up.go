@@ -0,0 +1,107 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	CreateVersionTableQuery = `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`
+	VersionQuery            = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+	ClearVersionQuery       = `DELETE FROM schema_migrations`
+	SetVersionQuery         = `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`
+	LockQuery               = `SELECT pg_advisory_lock($1)`
+	UnlockQuery             = `SELECT pg_advisory_unlock($1)`
+)
+
+// lockId keeps two processes from migrating at the same time, it is only shared with other runs of Up.
+const lockId = 7_402_117_531
+
+var ErrDirty = errors.New("schema_migrations is dirty, a migration failed half way and has to be fixed by hand")
+
+// Status is the version the database is at, zero when no migration was applied yet.
+type Status struct {
+	Version uint `db:"version"`
+	Dirty   bool `db:"dirty"`
+}
+
+type execQueryer interface {
+	sqlx.ExecerContext
+	sqlx.QueryerContext
+}
+
+// CurrentStatus reads schema_migrations, creating it when it does not exist yet.
+func CurrentStatus(ctx context.Context, db execQueryer) (Status, error) {
+	if _, err := db.ExecContext(ctx, CreateVersionTableQuery); err != nil {
+		return Status{}, err
+	}
+	var status Status
+	if err := sqlx.GetContext(ctx, db, &status, VersionQuery); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return Status{}, err
+	}
+	return status, nil
+}
+
+// Up applies the migrations newer than the version of the database and returns the versions it applied. Every
+// migration runs in a transaction together with recording its version, a failed one is rolled back completely.
+func Up(ctx context.Context, db *sqlx.DB) ([]uint, error) {
+	all, err := All()
+	if err != nil {
+		return nil, err
+	}
+	// the advisory lock belongs to the connection, so everything runs on the one that took it
+	conn, err := db.Connx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if _, err = conn.ExecContext(ctx, LockQuery, lockId); err != nil {
+		return nil, err
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), UnlockQuery, lockId)
+
+	status, err := CurrentStatus(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	if status.Dirty {
+		return nil, fmt.Errorf("%w: version %d", ErrDirty, status.Version)
+	}
+	var applied []uint
+	for _, m := range all {
+		if m.Version <= status.Version {
+			continue
+		}
+		if err = apply(ctx, conn, m); err != nil {
+			return applied, fmt.Errorf("migration %s: %w", m.File, err)
+		}
+		applied = append(applied, m.Version)
+	}
+	return applied, nil
+}
+
+func apply(ctx context.Context, conn *sqlx.Conn, m Migration) error {
+	statements, err := files.ReadFile(m.File)
+	if err != nil {
+		return err
+	}
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	// without arguments the whole file is sent as one simple query, so it may hold several statements
+	if _, err = tx.ExecContext(ctx, string(statements)); err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, ClearVersionQuery); err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, SetVersionQuery, m.Version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
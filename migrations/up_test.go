@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	all, err := All()
+	assert.Nil(t, err)
+	latest, err := Latest()
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), all[0].Version)
+	assert.Equal(t, latest, all[len(all)-1].Version)
+	for i := 1; i < len(all); i++ {
+		assert.Less(t, all[i-1].Version, all[i].Version)
+	}
+}
+
+func TestUp(t *testing.T) {
+	latest, err := Latest()
+	assert.Nil(t, err)
+	testCases := []struct {
+		name    string
+		version uint
+		dirty   bool
+		wantErr error
+	}{
+		{"Applies The Newer Migrations", latest - 1, false, nil},
+		{"Up To Date", latest, false, nil},
+		{"Dirty", latest - 1, true, ErrDirty},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			mock.ExpectExec("SELECT pg_advisory_lock").WithArgs(lockId).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").
+				WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(tc.version, tc.dirty))
+			if !tc.dirty && tc.version < latest {
+				mock.ExpectBegin()
+				// the newest migration, whatever it holds
+				mock.ExpectExec(".+").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM schema_migrations").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(latest).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+			mock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(lockId).WillReturnResult(sqlmock.NewResult(0, 0))
+
+			applied, err := Up(context.Background(), sqlx.NewDb(db, "mockDb"))
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			if !tc.dirty && tc.version < latest {
+				assert.Equal(t, []uint{latest}, applied)
+			} else {
+				assert.Empty(t, applied)
+			}
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
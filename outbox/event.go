@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// The domain events written to the outbox.
+const (
+	ItemCreated       = "ItemCreated"
+	ItemPriceChanged  = "ItemPriceChanged"
+	InvoiceCreated    = "InvoiceCreated"
+	InvoiceItemsAdded = "InvoiceItemsAdded"
+	InvoicePaid       = "InvoicePaid"
+	// InvoiceTotalCorrected is written when a drifted total was worked out again, see invoice.RecalculateTotal
+	InvoiceTotalCorrected = "InvoiceTotalCorrected"
+	PersonDeleted         = "PersonDeleted"
+)
+
+// EventTypes lists every domain event, e.g. for subscribers choosing which ones they receive.
+var EventTypes = []string{ItemCreated, ItemPriceChanged, InvoiceCreated, InvoiceItemsAdded, InvoicePaid, InvoiceTotalCorrected, PersonDeleted}
+
+// Event is a change to an aggregate (an item, invoice or person). Seq is its position in the outbox and Tenant the
+// tenant of the aggregate, both are only set once the event has been written. Id stays the same across redeliveries
+// so sinks can drop duplicates.
+type Event struct {
+	Seq           int64           `json:"seq,omitempty"`
+	Tenant        uuid.UUID       `json:"tenant_id"`
+	Id            uuid.UUID       `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateId   uuid.UUID       `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+func NewEvent(eventType string, aggregateType string, aggregateId uuid.UUID, payload any) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		Id:            uuid.New(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateId:   aggregateId,
+		Payload:       data,
+		OccurredAt:    time.Now().UTC(),
+	}, nil
+}
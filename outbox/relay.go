@@ -0,0 +1,111 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inventory-service-go/commons"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultInterval  = 5 * time.Second
+	DefaultBatchSize = 100
+	// Lease is how long the events of a batch are claimed for. A batch taking longer may be claimed by another relay
+	// as well, which the at least once delivery allows for.
+	Lease = 5 * time.Minute
+)
+
+// Relay delivers the events written to the outbox to every sink, polling on every tick of the interval.
+type Relay struct {
+	repo      OutboxRepository
+	sinks     []Sink
+	interval  time.Duration
+	batchSize int
+	stop      chan struct{}
+	done      sync.WaitGroup
+}
+
+func NewRelay(repo OutboxRepository, sinks []Sink, interval time.Duration) *Relay {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Relay{repo: repo, sinks: sinks, interval: interval, batchSize: DefaultBatchSize}
+}
+
+// AddSink delivers the events to one more sink, one that is not in the settings. It must be called
+// before the relay is started.
+func (r *Relay) AddSink(sink Sink) {
+	r.sinks = append(r.sinks, sink)
+}
+
+func (r *Relay) Start() {
+	r.stop = make(chan struct{})
+	r.done.Add(1)
+	go func() {
+		defer r.done.Done()
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			r.RunOnce()
+			select {
+			case <-ticker.C:
+			case <-r.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop waits for a delivery in progress to finish.
+func (r *Relay) Stop() {
+	close(r.stop)
+	r.done.Wait()
+}
+
+// RunOnce delivers batches until the outbox has no more events due.
+func (r *Relay) RunOnce() {
+	ctx := commons.AsSystem(context.Background())
+	for {
+		rows, err := r.repo.ClaimPending(ctx, r.batchSize, time.Now().Add(Lease))
+		if err != nil {
+			slog.Error("Error relaying outbox events", "error", err)
+			return
+		}
+		for _, row := range rows {
+			if err = r.deliver(ctx, row); err != nil {
+				slog.Error("Error relaying outbox events", "error", err)
+				return
+			}
+		}
+		if len(rows) < r.batchSize {
+			return
+		}
+	}
+}
+
+// deliver publishes the event to the sinks it has not been delivered to yet, recording every sink it reaches, so a
+// retry after a failed sink only goes to the sinks that failed.
+func (r *Relay) deliver(ctx context.Context, row EventRow) error {
+	event := row.Event()
+	var errs []error
+	for _, sink := range r.sinks {
+		if row.DeliveredToSink(sink.Name()) {
+			continue
+		}
+		if err := sink.Publish(event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+		if err := r.repo.MarkSinkDelivered(ctx, row.Id, sink.Name()); err != nil {
+			return err
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		slog.Error("Error publishing event", "event_id", event.Id, "event_type", event.Type, "error", err)
+		return r.repo.MarkFailed(ctx, row.Id, err.Error(), time.Now().Add(backoff(row.Attempts)))
+	}
+	return r.repo.MarkDelivered(ctx, row.Id)
+}
@@ -0,0 +1,66 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestRelay_RunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := NewMockOutboxRepository(ctrl)
+	mockSink := NewMockSink(ctrl)
+	mockSink.EXPECT().Name().Return("log").AnyTimes()
+	relay := NewRelay(mockRepo, []Sink{mockSink}, time.Minute)
+	relay.batchSize = 2
+	first, second, third := EventRow{Id: 1, EventType: InvoiceCreated}, EventRow{Id: 2, EventType: InvoicePaid}, EventRow{Id: 3, Attempts: 3}
+
+	gomock.InOrder(
+		mockRepo.EXPECT().ClaimPending(gomock.Any(), 2, gomock.Any()).Return([]EventRow{first, second}, nil),
+		mockSink.EXPECT().Publish(first.Event()).Return(nil),
+		mockRepo.EXPECT().MarkSinkDelivered(gomock.Any(), int64(1), "log").Return(nil),
+		mockRepo.EXPECT().MarkDelivered(gomock.Any(), int64(1)).Return(nil),
+		mockSink.EXPECT().Publish(second.Event()).Return(nil),
+		mockRepo.EXPECT().MarkSinkDelivered(gomock.Any(), int64(2), "log").Return(nil),
+		mockRepo.EXPECT().MarkDelivered(gomock.Any(), int64(2)).Return(nil),
+		mockRepo.EXPECT().ClaimPending(gomock.Any(), 2, gomock.Any()).Return([]EventRow{third}, nil),
+		mockSink.EXPECT().Publish(third.Event()).Return(errors.New("BOOM")),
+		mockRepo.EXPECT().MarkFailed(gomock.Any(), int64(3), "log: BOOM", gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, _ string, retryAt time.Time) error {
+			assert.WithinDuration(t, time.Now().Add(backoff(3)), retryAt, time.Second)
+			return nil
+		}),
+	)
+
+	relay.RunOnce()
+}
+
+func TestRelay_RunOnce_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := NewMockOutboxRepository(ctrl)
+	relay := NewRelay(mockRepo, nil, 0)
+	assert.Equal(t, DefaultInterval, relay.interval)
+
+	mockRepo.EXPECT().ClaimPending(gomock.Any(), DefaultBatchSize, gomock.Any()).Return(nil, errors.New("BOOM"))
+	relay.RunOnce()
+}
+
+func TestRelay_RetriesOnlyTheFailedSinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := NewMockOutboxRepository(ctrl)
+	delivered, failing, working := NewMockSink(ctrl), NewMockSink(ctrl), NewMockSink(ctrl)
+	delivered.EXPECT().Name().Return("log").AnyTimes()
+	failing.EXPECT().Name().Return("http").AnyTimes()
+	working.EXPECT().Name().Return("webhooks").AnyTimes()
+	relay := NewRelay(mockRepo, []Sink{delivered, failing, working}, time.Minute)
+	row := EventRow{Id: 7, EventType: PersonDeleted, DeliveredTo: "log"}
+
+	failing.EXPECT().Publish(row.Event()).Return(errors.New("BOOM"))
+	working.EXPECT().Publish(row.Event()).Return(nil)
+	mockRepo.EXPECT().MarkSinkDelivered(gomock.Any(), int64(7), "webhooks").Return(nil)
+	mockRepo.EXPECT().MarkFailed(gomock.Any(), int64(7), "http: BOOM", gomock.Any()).Return(nil)
+
+	assert.Nil(t, relay.deliver(context.Background(), row))
+}
@@ -0,0 +1,133 @@
+package outbox
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"inventory-service-go/commons"
+	"inventory-service-go/metrics"
+	"inventory-service-go/tracing"
+	"slices"
+	"strings"
+	"time"
+)
+
+type EventRow struct {
+	Id            int64          `db:"id"`
+	AltId         uuid.UUID      `db:"alt_id"`
+	EventType     string         `db:"event_type"`
+	AggregateType string         `db:"aggregate_type"`
+	AggregateId   uuid.UUID      `db:"aggregate_id"`
+	Payload       []byte         `db:"payload"`
+	OccurredAt    time.Time      `db:"occurred_at"`
+	Attempts      int            `db:"attempts"`
+	LastError     sql.NullString `db:"last_error"`
+	NextAttemptAt time.Time      `db:"next_attempt_at"`
+	DeliveredAt   sql.NullTime   `db:"delivered_at"`
+	TenantId      uuid.UUID      `db:"tenant_id"`
+	// DeliveredTo are the comma separated names of the sinks the event has already been delivered to.
+	DeliveredTo string `db:"delivered_to"`
+}
+
+func (row EventRow) Event() Event {
+	return Event{
+		Seq:           row.Id,
+		Tenant:        row.TenantId,
+		Id:            row.AltId,
+		Type:          row.EventType,
+		AggregateType: row.AggregateType,
+		AggregateId:   row.AggregateId,
+		Payload:       row.Payload,
+		OccurredAt:    row.OccurredAt,
+	}
+}
+
+// DeliveredToSink tells whether the event has already been delivered to the sink of the name.
+func (row EventRow) DeliveredToSink(name string) bool {
+	return row.DeliveredTo != "" && slices.Contains(strings.Split(row.DeliveredTo, ","), name)
+}
+
+const (
+	AppendQuery = `INSERT INTO outbox (alt_id, event_type, aggregate_type, aggregate_id, payload, occurred_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	ClaimQuery  = `UPDATE outbox o SET next_attempt_at = $2
+		WHERE o.id IN (SELECT id FROM outbox WHERE delivered_at IS NULL AND next_attempt_at <= now() ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED)
+		RETURNING o.*, coalesce((SELECT string_agg(d.sink, ',') FROM outbox_sink_deliveries d WHERE d.event_id = o.id), '') AS delivered_to`
+	MarkSinkDeliveredQuery = `INSERT INTO outbox_sink_deliveries (event_id, sink) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	MarkDeliveredQuery     = `UPDATE outbox SET attempts = attempts + 1, last_error = NULL, delivered_at = now() WHERE id = $1`
+	MarkFailedQuery        = `UPDATE outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1`
+)
+
+// MaxBackoff caps the wait between two attempts to deliver the same event.
+const MaxBackoff = time.Hour
+
+// Append writes the events in the caller's transaction, so they are only ever delivered if the change they
+// describe has been committed. They belong to the tenant the transaction is scoped to.
+func Append(ctx context.Context, tx sqlx.ExecerContext, events ...Event) error {
+	for _, event := range events {
+		_, err := tx.ExecContext(tracing.Statement(ctx, "AppendQuery"), AppendQuery, event.Id, event.Type, event.AggregateType, event.AggregateId, []byte(event.Payload), event.OccurredAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backoff doubles the wait after every failed attempt, starting at a second.
+func backoff(attempts int) time.Duration {
+	if attempts >= 12 {
+		return MaxBackoff
+	}
+	return min(time.Second<<attempts, MaxBackoff)
+}
+
+// The events of every tenant are delivered, ctx has to run as the system, see commons.AsSystem.
+type OutboxRepository interface {
+	// ClaimPending leases the oldest undelivered events that are due until leasedUntil, relays running in several
+	// instances then skip them. An event whose relay dies before it is marked is claimed again once the lease is over.
+	ClaimPending(ctx context.Context, limit int, leasedUntil time.Time) ([]EventRow, error)
+	// MarkSinkDelivered records that the event reached the sink of the name, a retry then leaves the sink out.
+	MarkSinkDelivered(ctx context.Context, id int64, sink string) error
+	// MarkDelivered records that the event reached every sink.
+	MarkDelivered(ctx context.Context, id int64) error
+	// MarkFailed schedules another attempt at retryAt.
+	MarkFailed(ctx context.Context, id int64, lastError string, retryAt time.Time) error
+}
+
+type OutboxRepositoryImpl struct {
+	db *commons.TenantDB
+}
+
+func NewOutboxRepository(db *sqlx.DB) *OutboxRepositoryImpl {
+	return &OutboxRepositoryImpl{db: commons.NewTenantDB(db)}
+}
+
+// ClaimPending commits the lease right away, the events are not kept locked while they are delivered.
+func (r *OutboxRepositoryImpl) ClaimPending(ctx context.Context, limit int, leasedUntil time.Time) ([]EventRow, error) {
+	defer metrics.ObserveQuery("outbox", "ClaimPending")()
+	var rows []EventRow
+	if err := r.db.SelectContext(tracing.Statement(ctx, "ClaimQuery"), &rows, ClaimQuery, limit, leasedUntil); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(rows, func(a, b EventRow) int { return cmp.Compare(a.Id, b.Id) })
+	return rows, nil
+}
+
+func (r *OutboxRepositoryImpl) MarkSinkDelivered(ctx context.Context, id int64, sink string) error {
+	defer metrics.ObserveQuery("outbox", "MarkSinkDelivered")()
+	_, err := r.db.ExecContext(tracing.Statement(ctx, "MarkSinkDeliveredQuery"), MarkSinkDeliveredQuery, id, sink)
+	return err
+}
+
+func (r *OutboxRepositoryImpl) MarkDelivered(ctx context.Context, id int64) error {
+	defer metrics.ObserveQuery("outbox", "MarkDelivered")()
+	_, err := r.db.ExecContext(tracing.Statement(ctx, "MarkDeliveredQuery"), MarkDeliveredQuery, id)
+	return err
+}
+
+func (r *OutboxRepositoryImpl) MarkFailed(ctx context.Context, id int64, lastError string, retryAt time.Time) error {
+	defer metrics.ObserveQuery("outbox", "MarkFailed")()
+	_, err := r.db.ExecContext(tracing.Statement(ctx, "MarkFailedQuery"), MarkFailedQuery, id, lastError, retryAt)
+	return err
+}
@@ -0,0 +1,93 @@
+package outbox
+
+import (
+	"context"
+	"database/sql/driver"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+var outboxColumns = []string{"id", "alt_id", "event_type", "aggregate_type", "aggregate_id", "payload", "occurred_at", "attempts", "last_error", "next_attempt_at", "delivered_at", "delivered_to"}
+
+func TestAppend(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	aggregateId := uuid.New()
+	event, err := NewEvent(ItemCreated, "item", aggregateId, map[string]string{"name": "widget"})
+	assert.Nil(t, err)
+	mock.ExpectExec("INSERT INTO outbox").
+		WithArgs(event.Id, ItemCreated, "item", aggregateId, []byte(`{"name":"widget"}`), event.OccurredAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	assert.Nil(t, Append(context.Background(), sqlx.NewDb(db, "mockDb"), event))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepositoryImpl_ClaimPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	leasedUntil := now.Add(Lease)
+	first, second := uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT set_config\\('app.system', 'on', true\\)").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("UPDATE outbox o SET next_attempt_at = \\$2").
+		WithArgs(10, leasedUntil).
+		WillReturnRows(sqlmock.NewRows(outboxColumns).
+			AddRow(2, second, InvoicePaid, "invoice", uuid.New(), []byte(`{}`), now, 3, "BOOM", leasedUntil, nil, "log,webhooks").
+			AddRow(1, first, InvoiceCreated, "invoice", uuid.New(), []byte(`{}`), now, 0, nil, leasedUntil, nil, ""))
+	mock.ExpectCommit()
+
+	r := NewOutboxRepository(sqlx.NewDb(db, "mockDb"))
+
+	rows, err := r.ClaimPending(commons.AsSystem(context.Background()), 10, leasedUntil)
+	assert.Nil(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, first, rows[0].Event().Id)
+	assert.False(t, rows[0].DeliveredToSink("log"))
+	assert.Equal(t, second, rows[1].Event().Id)
+	assert.True(t, rows[1].DeliveredToSink("webhooks"))
+	assert.False(t, rows[1].DeliveredToSink("http"))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepositoryImpl_Mark(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	retryAt := time.Now().Add(time.Minute)
+	expectExec := func(query string, args ...driver.Value) {
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT set_config\\('app.system', 'on', true\\)").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(query).WithArgs(args...).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+	expectExec("INSERT INTO outbox_sink_deliveries \\(event_id, sink\\) VALUES \\(\\$1, \\$2\\)", 1, "log")
+	expectExec("UPDATE outbox SET attempts = attempts \\+ 1, last_error = NULL, delivered_at = now\\(\\)", 1)
+	expectExec("UPDATE outbox SET attempts = attempts \\+ 1, last_error = \\$2, next_attempt_at = \\$3", 2, "BOOM", retryAt)
+
+	r := NewOutboxRepository(sqlx.NewDb(db, "mockDb"))
+	ctx := commons.AsSystem(context.Background())
+
+	assert.Nil(t, r.MarkSinkDelivered(ctx, 1, "log"))
+	assert.Nil(t, r.MarkDelivered(ctx, 1))
+	assert.Nil(t, r.MarkFailed(ctx, 2, "BOOM", retryAt))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, backoff(0))
+	assert.Equal(t, 8*time.Second, backoff(3))
+	assert.Equal(t, MaxBackoff, backoff(12))
+	assert.Equal(t, MaxBackoff, backoff(100))
+}
@@ -0,0 +1,122 @@
+package outbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"inventory-service-go/config"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sink is where the relay delivers events to. Delivery is at least once, a sink may see the same event (by Id) again
+// after a failed attempt.
+type Sink interface {
+	// Name tells the sinks apart in the record of the deliveries, it must stay the same across restarts.
+	Name() string
+	Publish(event Event) error
+}
+
+type LogSink struct{}
+
+func (LogSink) Name() string {
+	return "log"
+}
+
+func (LogSink) Publish(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	slog.Info("Event", "event", json.RawMessage(data))
+	return nil
+}
+
+// FileSink appends every event as a line of JSON.
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+func (s *FileSink) Name() string {
+	return "file"
+}
+
+func (s *FileSink) Publish(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// HTTPSink posts every event as JSON, anything but a 2xx response is retried.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSink(url string) *HTTPSink {
+	return &HTTPSink{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *HTTPSink) Name() string {
+	return "http"
+}
+
+func (s *HTTPSink) Publish(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	response, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%s responded with %s", s.url, response.Status)
+	}
+	return nil
+}
+
+// SinksFromConfig are the sinks of the settings, log, file, which appends to the File, and http, which posts to the
+// HTTPURL.
+func SinksFromConfig(settings config.Outbox) ([]Sink, error) {
+	var sinks []Sink
+	for _, name := range settings.Sinks {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "log":
+			sinks = append(sinks, LogSink{})
+		case "file":
+			if settings.File == "" {
+				return nil, fmt.Errorf("a file must be set to use the file sink")
+			}
+			sinks = append(sinks, NewFileSink(settings.File))
+		case "http":
+			if settings.HTTPURL == "" {
+				return nil, fmt.Errorf("an http url must be set to use the http sink")
+			}
+			sinks = append(sinks, NewHTTPSink(settings.HTTPURL))
+		default:
+			return nil, fmt.Errorf("unknown outbox sink %q", name)
+		}
+	}
+	return sinks, nil
+}
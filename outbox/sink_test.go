@@ -0,0 +1,96 @@
+package outbox
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/config"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testEvent(t *testing.T) Event {
+	event, err := NewEvent(PersonDeleted, "person", uuid.New(), map[string]string{"id": "42"})
+	assert.Nil(t, err)
+	return event
+}
+
+func TestFileSink_Publish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink := NewFileSink(path)
+	first, second := testEvent(t), testEvent(t)
+	assert.Nil(t, sink.Publish(first))
+	assert.Nil(t, sink.Publish(second))
+
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+	var read Event
+	assert.Nil(t, json.Unmarshal([]byte(lines[1]), &read))
+	assert.Equal(t, second.Id, read.Id)
+	assert.Equal(t, PersonDeleted, read.Type)
+}
+
+func TestHTTPSink_Publish(t *testing.T) {
+	event := testEvent(t)
+	testCases := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"Accepted", http.StatusAccepted, false},
+		{"Server Error", http.StatusBadGateway, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				var received Event
+				assert.Nil(t, json.Unmarshal(body, &received))
+				assert.Equal(t, event.Id, received.Id)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			err := NewHTTPSink(server.URL).Publish(event)
+			if tc.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestSinksFromConfig(t *testing.T) {
+	testCases := []struct {
+		name      string
+		sinks     []string
+		httpUrl   string
+		wantCount int
+		wantErr   bool
+	}{
+		{"No Sinks", nil, "", 0, false},
+		{"All Sinks", []string{"log", " file", "HTTP"}, "http://localhost:9000/events", 3, false},
+		{"Http Without Url", []string{"http"}, "", 0, true},
+		{"Unknown Sink", []string{"kafka"}, "", 0, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sinks, err := SinksFromConfig(config.Outbox{Sinks: tc.sinks, File: "outbox.jsonl", HTTPURL: tc.httpUrl})
+			if tc.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Len(t, sinks, tc.wantCount)
+		})
+	}
+}
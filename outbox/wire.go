@@ -0,0 +1,22 @@
+//go:build wireinject
+// +build wireinject
+
+package outbox
+
+import (
+	"github.com/google/wire"
+	"inventory-service-go/commons"
+	"inventory-service-go/config"
+)
+
+func InitializeRelay(settings config.Outbox) (*Relay, error) {
+	wire.Build(
+		NewRelay,
+		NewOutboxRepository,
+		SinksFromConfig,
+		wire.FieldsOf(new(config.Outbox), "RelayInterval"),
+		commons.GetDB,
+		wire.Bind(new(OutboxRepository), new(*OutboxRepositoryImpl)),
+	)
+	return nil, nil
+}
@@ -0,0 +1,122 @@
+package person
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-service-go/cache"
+	"inventory-service-go/commons"
+	"inventory-service-go/logging"
+	"sync"
+)
+
+// CacheName names the person cache in invalidations and stats.
+const CacheName = "persons"
+
+var (
+	personCache     *cache.TenantLRU[Person]
+	personCacheOnce sync.Once
+)
+
+// ProvidePersonService puts the read-through cache in front of the service unless its Size is 0, and traces
+// the calls in front of the cache, so cache hits are traced too.
+func ProvidePersonService(service *PersonServiceImpl, invalidator cache.Invalidator, settings cache.Config) PersonService {
+	if !settings.Enabled() {
+		return NewTracingPersonService(service)
+	}
+	personCacheOnce.Do(func() {
+		personCache = cache.NewTenantLRU[Person](CacheName, settings)
+		invalidator.Register(CacheName, func(key string) {
+			if id, err := uuid.Parse(key); err == nil {
+				personCache.Remove(id)
+			}
+		}, personCache.Purge)
+	})
+	return NewTracingPersonService(NewCachingPersonService(service, personCache, invalidator))
+}
+
+// CachingPersonService answers GetById from the cache and invalidates a person on every replica when it is updated
+// or deleted.
+type CachingPersonService struct {
+	service     PersonService
+	cache       *cache.TenantLRU[Person]
+	invalidator cache.Invalidator
+}
+
+func NewCachingPersonService(service PersonService, cache *cache.TenantLRU[Person], invalidator cache.Invalidator) *CachingPersonService {
+	return &CachingPersonService{
+		service:     service,
+		cache:       cache,
+		invalidator: invalidator,
+	}
+}
+
+// OnChange observes the changes of the decorated service.
+func (s *CachingPersonService) OnChange(hook commons.ChangeHook) {
+	if notifier, ok := s.service.(commons.ChangeNotifier); ok {
+		notifier.OnChange(hook)
+	}
+}
+
+func (s *CachingPersonService) GetAll(ctx context.Context, pagination *commons.Pagination) ([]Person, error) {
+	return s.service.GetAll(ctx, pagination)
+}
+
+func (s *CachingPersonService) GetById(ctx context.Context, id uuid.UUID) (*Person, error) {
+	if p, ok := s.cache.Get(ctx, id); ok {
+		return &p, nil
+	}
+	generation := s.cache.Generation()
+	p, err := s.service.GetById(ctx, id)
+	if err != nil {
+		return p, err
+	}
+	s.cache.AddSince(ctx, id, *p, generation)
+	return p, nil
+}
+
+// GetByIds answers what it can from the cache and reads the rest at once.
+func (s *CachingPersonService) GetByIds(ctx context.Context, ids []uuid.UUID) ([]Person, error) {
+	var persons []Person
+	var missing []uuid.UUID
+	for _, id := range ids {
+		if p, ok := s.cache.Get(ctx, id); ok {
+			persons = append(persons, p)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return persons, nil
+	}
+	generation := s.cache.Generation()
+	found, err := s.service.GetByIds(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range found {
+		s.cache.AddSince(ctx, p.Id, p, generation)
+	}
+	return append(persons, found...), nil
+}
+
+func (s *CachingPersonService) Create(ctx context.Context, request CreatePersonRequest) (*Person, error) {
+	return s.service.Create(ctx, request)
+}
+
+func (s *CachingPersonService) Update(ctx context.Context, request UpdatePersonRequest) (*Person, error) {
+	defer s.invalidate(ctx, request.Id)
+	return s.service.Update(ctx, request)
+}
+
+func (s *CachingPersonService) DeleteByUuid(ctx context.Context, uuid uuid.UUID) (*commons.DeleteResult, error) {
+	defer s.invalidate(ctx, uuid)
+	return s.service.DeleteByUuid(ctx, uuid)
+}
+
+// invalidate also runs when the change failed, it may have been written anyway.
+func (s *CachingPersonService) invalidate(ctx context.Context, id uuid.UUID) {
+	s.cache.Remove(id)
+	if err := s.invalidator.Invalidate(CacheName, id.String()); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "Error invalidating the person on the other replicas", "person_id", id, "error", err)
+	}
+}
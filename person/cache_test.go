@@ -0,0 +1,91 @@
+package person
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/cache"
+	"inventory-service-go/commons"
+	"testing"
+)
+
+func newCachingPersonService(t *testing.T) (*CachingPersonService, *MockPersonService, *cache.MockInvalidator) {
+	controller := gomock.NewController(t)
+	mockService := NewMockPersonService(controller)
+	mockInvalidator := cache.NewMockInvalidator(controller)
+	c := cache.NewTenantLRU[Person](CacheName, cache.Config{Size: 10, TTL: cache.DefaultTTL})
+	return NewCachingPersonService(mockService, c, mockInvalidator), mockService, mockInvalidator
+}
+
+func TestProvidePersonService(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockInvalidator := cache.NewMockInvalidator(controller)
+	service := NewPersonService(nil)
+
+	plain := ProvidePersonService(service, mockInvalidator, cache.Config{})
+	assert.IsType(t, &TracingPersonService{}, plain)
+	assert.Same(t, service, plain.(*TracingPersonService).service)
+
+	mockInvalidator.EXPECT().Register(CacheName, gomock.Any(), gomock.Any()).Times(1)
+	cached := ProvidePersonService(service, mockInvalidator, cache.Config{Size: 10, TTL: cache.DefaultTTL})
+	assert.IsType(t, &CachingPersonService{}, cached.(*TracingPersonService).service)
+	ProvidePersonService(service, mockInvalidator, cache.Config{Size: 10, TTL: cache.DefaultTTL})
+}
+
+func TestCachingPersonService_GetById(t *testing.T) {
+	service, mockService, _ := newCachingPersonService(t)
+	expected := &Person{Id: uuid.New(), Name: "Ann", Email: "ann@example.com"}
+	mockService.EXPECT().GetById(gomock.Any(), expected.Id).Return(expected, nil).Times(1)
+
+	for i := 0; i < 2; i++ {
+		got, err := service.GetById(context.Background(), expected.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, got)
+	}
+
+	missing := uuid.New()
+	mockService.EXPECT().GetById(gomock.Any(), missing).Return(&Person{}, sql.ErrNoRows).Times(2)
+	for i := 0; i < 2; i++ {
+		_, err := service.GetById(context.Background(), missing)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	}
+}
+
+func TestCachingPersonService_Invalidation(t *testing.T) {
+	service, mockService, mockInvalidator := newCachingPersonService(t)
+	cached := Person{Id: uuid.New(), Name: "Ann"}
+	request := UpdatePersonRequest{Id: cached.Id, Name: "Anne"}
+	mockService.EXPECT().Update(gomock.Any(), request).Return(&Person{Id: cached.Id, Name: "Anne"}, nil)
+	mockService.EXPECT().DeleteByUuid(gomock.Any(), cached.Id).Return(&commons.DeleteResult{Id: cached.Id, Deleted: true}, nil)
+	mockInvalidator.EXPECT().Invalidate(CacheName, cached.Id.String()).Return(nil).Times(2)
+
+	service.cache.Add(context.Background(), cached.Id, cached)
+	_, err := service.Update(context.Background(), request)
+	assert.NoError(t, err)
+	_, ok := service.cache.Get(context.Background(), cached.Id)
+	assert.False(t, ok)
+
+	service.cache.Add(context.Background(), cached.Id, cached)
+	_, err = service.DeleteByUuid(context.Background(), cached.Id)
+	assert.NoError(t, err)
+	_, ok = service.cache.Get(context.Background(), cached.Id)
+	assert.False(t, ok)
+}
+
+func TestCachingPersonService_GetByIds(t *testing.T) {
+	service, mockService, _ := newCachingPersonService(t)
+	cached := Person{Id: uuid.New(), Name: "Ann"}
+	uncached := Person{Id: uuid.New(), Name: "Bob"}
+	service.cache.Add(context.Background(), cached.Id, cached)
+	mockService.EXPECT().GetByIds(gomock.Any(), []uuid.UUID{uncached.Id}).Return([]Person{uncached}, nil).Times(1)
+
+	got, err := service.GetByIds(context.Background(), []uuid.UUID{cached.Id, uncached.Id})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []Person{cached, uncached}, got)
+
+	got, err = service.GetByIds(context.Background(), []uuid.UUID{cached.Id, uncached.Id})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []Person{cached, uncached}, got)
+}
@@ -0,0 +1,16 @@
+package person
+
+import (
+	"github.com/google/uuid"
+	"inventory-service-go/outbox"
+)
+
+const aggregateType = "person"
+
+type DeletedPerson struct {
+	Id uuid.UUID `json:"id"`
+}
+
+func personDeletedEvent(id uuid.UUID) (outbox.Event, error) {
+	return outbox.NewEvent(outbox.PersonDeleted, aggregateType, id, DeletedPerson{Id: id})
+}
@@ -10,6 +10,7 @@
 package person
 
 import (
+	context "context"
 	commons "inventory-service-go/commons"
 	reflect "reflect"
 
@@ -41,91 +42,91 @@ func (m *MockPersonRepository) EXPECT() *MockPersonRepositoryMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockPersonRepository) Create(request CreatePersonRequest) (PersonRow, error) {
+func (m *MockPersonRepository) Create(ctx context.Context, request CreatePersonRequest) (PersonRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", request)
+	ret := m.ctrl.Call(m, "Create", ctx, request)
 	ret0, _ := ret[0].(PersonRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockPersonRepositoryMockRecorder) Create(request any) *gomock.Call {
+func (mr *MockPersonRepositoryMockRecorder) Create(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPersonRepository)(nil).Create), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPersonRepository)(nil).Create), ctx, request)
 }
 
 // DeleteByUuid mocks base method.
-func (m *MockPersonRepository) DeleteByUuid(uuid uuid.UUID) (commons.DeleteResult, error) {
+func (m *MockPersonRepository) DeleteByUuid(ctx context.Context, uuid uuid.UUID) (commons.DeleteResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByUuid", uuid)
+	ret := m.ctrl.Call(m, "DeleteByUuid", ctx, uuid)
 	ret0, _ := ret[0].(commons.DeleteResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteByUuid indicates an expected call of DeleteByUuid.
-func (mr *MockPersonRepositoryMockRecorder) DeleteByUuid(uuid any) *gomock.Call {
+func (mr *MockPersonRepositoryMockRecorder) DeleteByUuid(ctx, uuid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUuid", reflect.TypeOf((*MockPersonRepository)(nil).DeleteByUuid), uuid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUuid", reflect.TypeOf((*MockPersonRepository)(nil).DeleteByUuid), ctx, uuid)
 }
 
 // GetAll mocks base method.
-func (m *MockPersonRepository) GetAll(pagination *commons.Pagination) ([]PersonRow, error) {
+func (m *MockPersonRepository) GetAll(ctx context.Context, pagination *commons.Pagination) ([]PersonRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", pagination)
+	ret := m.ctrl.Call(m, "GetAll", ctx, pagination)
 	ret0, _ := ret[0].([]PersonRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockPersonRepositoryMockRecorder) GetAll(pagination any) *gomock.Call {
+func (mr *MockPersonRepositoryMockRecorder) GetAll(ctx, pagination any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPersonRepository)(nil).GetAll), pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPersonRepository)(nil).GetAll), ctx, pagination)
 }
 
-// GetById mocks base method.
-func (m *MockPersonRepository) GetById(id int) (PersonRow, error) {
+// GetByUuid mocks base method.
+func (m *MockPersonRepository) GetByUuid(ctx context.Context, uuid uuid.UUID) (PersonRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", id)
+	ret := m.ctrl.Call(m, "GetByUuid", ctx, uuid)
 	ret0, _ := ret[0].(PersonRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetById indicates an expected call of GetById.
-func (mr *MockPersonRepositoryMockRecorder) GetById(id any) *gomock.Call {
+// GetByUuid indicates an expected call of GetByUuid.
+func (mr *MockPersonRepositoryMockRecorder) GetByUuid(ctx, uuid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPersonRepository)(nil).GetById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUuid", reflect.TypeOf((*MockPersonRepository)(nil).GetByUuid), ctx, uuid)
 }
 
-// GetByUuid mocks base method.
-func (m *MockPersonRepository) GetByUuid(uuid uuid.UUID) (PersonRow, error) {
+// GetByUuids mocks base method.
+func (m *MockPersonRepository) GetByUuids(ctx context.Context, uuids []uuid.UUID) ([]PersonRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByUuid", uuid)
-	ret0, _ := ret[0].(PersonRow)
+	ret := m.ctrl.Call(m, "GetByUuids", ctx, uuids)
+	ret0, _ := ret[0].([]PersonRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByUuid indicates an expected call of GetByUuid.
-func (mr *MockPersonRepositoryMockRecorder) GetByUuid(uuid any) *gomock.Call {
+// GetByUuids indicates an expected call of GetByUuids.
+func (mr *MockPersonRepositoryMockRecorder) GetByUuids(ctx, uuids any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUuid", reflect.TypeOf((*MockPersonRepository)(nil).GetByUuid), uuid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUuids", reflect.TypeOf((*MockPersonRepository)(nil).GetByUuids), ctx, uuids)
 }
 
 // Update mocks base method.
-func (m *MockPersonRepository) Update(request UpdatePersonRequest) (PersonRow, error) {
+func (m *MockPersonRepository) Update(ctx context.Context, request UpdatePersonRequest) (PersonRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", request)
+	ret := m.ctrl.Call(m, "Update", ctx, request)
 	ret0, _ := ret[0].(PersonRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockPersonRepositoryMockRecorder) Update(request any) *gomock.Call {
+func (mr *MockPersonRepositoryMockRecorder) Update(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPersonRepository)(nil).Update), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPersonRepository)(nil).Update), ctx, request)
 }
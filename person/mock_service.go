@@ -10,6 +10,7 @@
 package person
 
 import (
+	context "context"
 	commons "inventory-service-go/commons"
 	reflect "reflect"
 
@@ -41,76 +42,91 @@ func (m *MockPersonService) EXPECT() *MockPersonServiceMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockPersonService) Create(request CreatePersonRequest) (*Person, error) {
+func (m *MockPersonService) Create(ctx context.Context, request CreatePersonRequest) (*Person, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", request)
+	ret := m.ctrl.Call(m, "Create", ctx, request)
 	ret0, _ := ret[0].(*Person)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockPersonServiceMockRecorder) Create(request any) *gomock.Call {
+func (mr *MockPersonServiceMockRecorder) Create(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPersonService)(nil).Create), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPersonService)(nil).Create), ctx, request)
 }
 
 // DeleteByUuid mocks base method.
-func (m *MockPersonService) DeleteByUuid(uuid uuid.UUID) (*commons.DeleteResult, error) {
+func (m *MockPersonService) DeleteByUuid(ctx context.Context, uuid uuid.UUID) (*commons.DeleteResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByUuid", uuid)
+	ret := m.ctrl.Call(m, "DeleteByUuid", ctx, uuid)
 	ret0, _ := ret[0].(*commons.DeleteResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteByUuid indicates an expected call of DeleteByUuid.
-func (mr *MockPersonServiceMockRecorder) DeleteByUuid(uuid any) *gomock.Call {
+func (mr *MockPersonServiceMockRecorder) DeleteByUuid(ctx, uuid any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUuid", reflect.TypeOf((*MockPersonService)(nil).DeleteByUuid), uuid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUuid", reflect.TypeOf((*MockPersonService)(nil).DeleteByUuid), ctx, uuid)
 }
 
 // GetAll mocks base method.
-func (m *MockPersonService) GetAll(pagination *commons.Pagination) ([]Person, error) {
+func (m *MockPersonService) GetAll(ctx context.Context, pagination *commons.Pagination) ([]Person, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", pagination)
+	ret := m.ctrl.Call(m, "GetAll", ctx, pagination)
 	ret0, _ := ret[0].([]Person)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockPersonServiceMockRecorder) GetAll(pagination any) *gomock.Call {
+func (mr *MockPersonServiceMockRecorder) GetAll(ctx, pagination any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPersonService)(nil).GetAll), pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPersonService)(nil).GetAll), ctx, pagination)
 }
 
 // GetById mocks base method.
-func (m *MockPersonService) GetById(id uuid.UUID) (*Person, error) {
+func (m *MockPersonService) GetById(ctx context.Context, id uuid.UUID) (*Person, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", id)
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
 	ret0, _ := ret[0].(*Person)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetById indicates an expected call of GetById.
-func (mr *MockPersonServiceMockRecorder) GetById(id any) *gomock.Call {
+func (mr *MockPersonServiceMockRecorder) GetById(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPersonService)(nil).GetById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockPersonService)(nil).GetById), ctx, id)
+}
+
+// GetByIds mocks base method.
+func (m *MockPersonService) GetByIds(ctx context.Context, ids []uuid.UUID) ([]Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, ids)
+	ret0, _ := ret[0].([]Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockPersonServiceMockRecorder) GetByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockPersonService)(nil).GetByIds), ctx, ids)
 }
 
 // Update mocks base method.
-func (m *MockPersonService) Update(request UpdatePersonRequest) (*Person, error) {
+func (m *MockPersonService) Update(ctx context.Context, request UpdatePersonRequest) (*Person, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", request)
+	ret := m.ctrl.Call(m, "Update", ctx, request)
 	ret0, _ := ret[0].(*Person)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockPersonServiceMockRecorder) Update(request any) *gomock.Call {
+func (mr *MockPersonServiceMockRecorder) Update(ctx, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPersonService)(nil).Update), request)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPersonService)(nil).Update), ctx, request)
 }
@@ -1,9 +1,13 @@
 package person
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"inventory-service-go/commons"
+	"inventory-service-go/metrics"
+	"inventory-service-go/outbox"
+	"inventory-service-go/tracing"
 	"time"
 )
 
@@ -17,50 +21,53 @@ type PersonRow struct {
 	CreatedAt     time.Time `db:"created_at"`
 	LastUpdate    time.Time `db:"last_update"`
 	LastChangedBy string    `db:"last_changed_by"`
+	TenantId      uuid.UUID `db:"tenant_id"`
 }
 
 type CreatePersonRequest struct {
 	Name      string `json:"name"`
 	Email     string `json:"email"`
-	CreatedBy string `json:"created_by"`
+	CreatedBy string `json:"created_by" audit:"actor"`
 }
 
 type UpdatePersonRequest struct {
 	Id            uuid.UUID `json:"id"`
 	Name          string    `json:"name"`
 	Email         string    `json:"email"`
-	LastChangedBy string    `json:"last_changed_by"`
+	LastChangedBy string    `json:"last_changed_by" audit:"actor"`
 }
 
 // PersonRepository Interface for PersonRepository
 type PersonRepository interface {
-	GetAll(pagination *commons.Pagination) ([]PersonRow, error)
-	GetByUuid(uuid uuid.UUID) (PersonRow, error)
-	Create(request CreatePersonRequest) (PersonRow, error)
-	Update(request UpdatePersonRequest) (PersonRow, error)
-	DeleteByUuid(uuid uuid.UUID) (commons.DeleteResult, error)
+	GetAll(ctx context.Context, pagination *commons.Pagination) ([]PersonRow, error)
+	GetByUuid(ctx context.Context, uuid uuid.UUID) (PersonRow, error)
+	GetByUuids(ctx context.Context, uuids []uuid.UUID) ([]PersonRow, error)
+	Create(ctx context.Context, request CreatePersonRequest) (PersonRow, error)
+	Update(ctx context.Context, request UpdatePersonRequest) (PersonRow, error)
+	DeleteByUuid(ctx context.Context, uuid uuid.UUID) (commons.DeleteResult, error)
 }
 
 type PersonRepositoryImpl struct {
-	db *sqlx.DB
+	db *commons.TenantDB
 }
 
 func NewPersonRepository(db *sqlx.DB) *PersonRepositoryImpl {
 	return &PersonRepositoryImpl{
-		db: db,
+		db: commons.NewTenantDB(db),
 	}
 }
 
-func (p *PersonRepositoryImpl) GetAll(pagination *commons.Pagination) ([]PersonRow, error) {
+func (p *PersonRepositoryImpl) GetAll(ctx context.Context, pagination *commons.Pagination) ([]PersonRow, error) {
+	defer metrics.ObserveQuery("person", "GetAll")()
 	// uses sqlx to query the persons table and retrive all rows
 	var persons []PersonRow
 	if pagination != nil {
-		err := p.db.Select(&persons, "SELECT * FROM persons WHERE id > $1 LIMIT $2", pagination.LastId, pagination.PageSize)
+		err := p.db.SelectContext(tracing.Statement(ctx, "GetPersonsPage"), &persons, "SELECT * FROM persons WHERE id > $1 ORDER BY id LIMIT $2", pagination.LastId, pagination.PageSize)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		err := p.db.Select(&persons, "SELECT * FROM persons")
+		err := p.db.SelectContext(tracing.Statement(ctx, "GetPersons"), &persons, "SELECT * FROM persons")
 		if err != nil {
 			return nil, err
 		}
@@ -68,46 +75,79 @@ func (p *PersonRepositoryImpl) GetAll(pagination *commons.Pagination) ([]PersonR
 	return persons, nil
 }
 
-func (p *PersonRepositoryImpl) GetByUuid(uuid uuid.UUID) (PersonRow, error) {
+func (p *PersonRepositoryImpl) GetByUuid(ctx context.Context, uuid uuid.UUID) (PersonRow, error) {
+	defer metrics.ObserveQuery("person", "GetByUuid")()
 	// uses sqlx to query the persons table and retrieve a single row by uuid
 	var person PersonRow
-	err := p.db.Get(&person, "SELECT * FROM persons WHERE alt_id = $1", uuid)
+	err := p.db.GetContext(tracing.Statement(ctx, "GetPersonByUuid"), &person, "SELECT * FROM persons WHERE alt_id = $1", uuid)
 	if err != nil {
 		return PersonRow{}, err
 	}
 	return person, nil
 }
 
-func (p *PersonRepositoryImpl) Create(request CreatePersonRequest) (PersonRow, error) {
+// GetByUuids returns the persons with the given ids in a single query, leaving out the ids that do not exist.
+func (p *PersonRepositoryImpl) GetByUuids(ctx context.Context, uuids []uuid.UUID) ([]PersonRow, error) {
+	defer metrics.ObserveQuery("person", "GetByUuids")()
+	var persons []PersonRow
+	err := p.db.SelectContext(tracing.Statement(ctx, "GetPersonsByUuids"), &persons, "SELECT * FROM persons WHERE alt_id = ANY($1)", uuids)
+	if err != nil {
+		return nil, err
+	}
+	return persons, nil
+}
+
+func (p *PersonRepositoryImpl) Create(ctx context.Context, request CreatePersonRequest) (PersonRow, error) {
+	defer metrics.ObserveQuery("person", "Create")()
 	// uses sqlx to insert a new row into the persons table
 	var person PersonRow
-	err := p.db.Get(&person, "INSERT INTO persons (name, email, created_by) VALUES ($1, $2, $3) RETURNING *", request.Name, request.Email, request.CreatedBy)
+	err := p.db.GetContext(tracing.Statement(ctx, "CreatePerson"), &person, "INSERT INTO persons (name, email, created_by) VALUES ($1, $2, $3) RETURNING *", request.Name, request.Email, request.CreatedBy)
 	if err != nil {
 		return PersonRow{}, err
 	}
 	return person, nil
 }
 
-func (p *PersonRepositoryImpl) Update(request UpdatePersonRequest) (PersonRow, error) {
+func (p *PersonRepositoryImpl) Update(ctx context.Context, request UpdatePersonRequest) (PersonRow, error) {
+	defer metrics.ObserveQuery("person", "Update")()
 	// uses sqlx to update a row in the persons table
 	var person PersonRow
-	err := p.db.Get(&person, "UPDATE persons SET name = $1, email = $2, last_changed_by = $3, last_update = $4 WHERE alt_id = $5 RETURNING *", request.Name, request.Email, request.LastChangedBy, time.Now(), request.Id)
+	err := p.db.GetContext(tracing.Statement(ctx, "UpdatePerson"), &person, "UPDATE persons SET name = $1, email = $2, last_changed_by = $3, last_update = $4 WHERE alt_id = $5 RETURNING *", request.Name, request.Email, request.LastChangedBy, time.Now(), request.Id)
 	if err != nil {
 		return PersonRow{}, err
 	}
 	return person, nil
 }
 
-func (p *PersonRepositoryImpl) DeleteByUuid(uuid uuid.UUID) (commons.DeleteResult, error) {
-	// uses sqlx to delete a row from the persons table by uuid
-	sqlResults := p.db.MustExec("DELETE FROM persons WHERE alt_id = $1", uuid)
+func (p *PersonRepositoryImpl) DeleteByUuid(ctx context.Context, uuid uuid.UUID) (commons.DeleteResult, error) {
+	defer metrics.ObserveQuery("person", "DeleteByUuid")()
+	// uses sqlx to delete a row from the persons table by uuid, writing PersonDeleted to the outbox in the same
+	// transaction when there was a row to delete
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return commons.DeleteResult{}, err
+	}
+	defer tx.Rollback()
+	sqlResults, err := tx.ExecContext(tracing.Statement(ctx, "DeletePerson"), "DELETE FROM persons WHERE alt_id = $1", uuid)
+	if err != nil {
+		return commons.DeleteResult{}, err
+	}
 	rowsAffected, err := sqlResults.RowsAffected()
 	if err != nil {
 		return commons.DeleteResult{}, err
 	}
+	if rowsAffected > 0 {
+		event, err := personDeletedEvent(uuid)
+		if err != nil {
+			return commons.DeleteResult{}, err
+		}
+		if err = outbox.Append(ctx, tx, event); err != nil {
+			return commons.DeleteResult{}, err
+		}
+	}
 	result := commons.DeleteResult{
 		Id:      uuid,
 		Deleted: rowsAffected > 0,
 	}
-	return result, nil
+	return result, tx.Commit()
 }
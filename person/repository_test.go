@@ -1,12 +1,15 @@
 package person
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"inventory-service-go/commons"
+	"inventory-service-go/outbox"
 	"testing"
 )
 
@@ -38,7 +41,7 @@ func TestPersonRepositoryImpl_GetAll(t *testing.T) {
 			prepare: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "name"}).
 					AddRow(1, "test name")
-				mock.ExpectQuery("SELECT \\* FROM persons WHERE id > \\$1 LIMIT \\$2").
+				mock.ExpectQuery("SELECT \\* FROM persons WHERE id > \\$1 ORDER BY id LIMIT \\$2").
 					WithArgs(1, 10).WillReturnRows(rows)
 			},
 			wantErr: false,
@@ -71,7 +74,7 @@ func TestPersonRepositoryImpl_GetAll(t *testing.T) {
 				},
 			},
 			prepare: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("SELECT \\* FROM persons WHERE id > \\? LIMIT \\?").
+				mock.ExpectQuery("SELECT \\* FROM persons WHERE id > \\? ORDER BY id LIMIT \\?").
 					WithArgs(1, 10).WillReturnError(errors.New("test error"))
 			},
 			wantErr: true,
@@ -100,9 +103,9 @@ func TestPersonRepositoryImpl_GetAll(t *testing.T) {
 			tt.prepare(mock)
 			tt.fields.db = sqlx.NewDb(db, "sqlmock")
 			p := &PersonRepositoryImpl{
-				db: tt.fields.db,
+				db: commons.NewTenantDB(tt.fields.db),
 			}
-			_, err := p.GetAll(tt.args.pagination)
+			_, err := p.GetAll(context.Background(), tt.args.pagination)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("PersonRepositoryImpl.GetAll() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -166,9 +169,9 @@ func TestPersonRepositoryImpl_GetByUuid(t *testing.T) {
 			tt.prepare(mock)
 			tt.fields.db = sqlx.NewDb(db, "sqlmock")
 			p := &PersonRepositoryImpl{
-				db: tt.fields.db,
+				db: commons.NewTenantDB(tt.fields.db),
 			}
-			_, err := p.GetByUuid(tt.args.uuid)
+			_, err := p.GetByUuid(context.Background(), tt.args.uuid)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("PersonRepositoryImpl.GetByUuid() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -239,9 +242,9 @@ func TestPersonRepositoryImpl_Create(t *testing.T) {
 			tt.prepare(mock)
 			tt.fields.db = sqlx.NewDb(db, "sqlmock")
 			p := &PersonRepositoryImpl{
-				db: tt.fields.db,
+				db: commons.NewTenantDB(tt.fields.db),
 			}
-			_, err := p.Create(tt.args.request)
+			_, err := p.Create(context.Background(), tt.args.request)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("PersonRepositoryImpl.Create() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -274,8 +277,13 @@ func TestPersonRepositoryImpl_DeleteByUuid(t *testing.T) {
 				uuid: testUuid,
 			},
 			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
 				mock.ExpectExec("DELETE FROM persons WHERE alt_id = \\$1").
 					WithArgs("2b1b425e-dee2-4227-8d94-f470a0ce0cd0").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO outbox").
+					WithArgs(sqlmock.AnyArg(), outbox.PersonDeleted, "person", "2b1b425e-dee2-4227-8d94-f470a0ce0cd0", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			},
 			wantErr: false,
 		},
@@ -288,8 +296,10 @@ func TestPersonRepositoryImpl_DeleteByUuid(t *testing.T) {
 				uuid: testUuid,
 			},
 			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
 				mock.ExpectExec("DELETE FROM persons WHERE alt_id = \\$1").
 					WithArgs("2b1b425e-dee2-4227-8d94-f470a0ce0cd0").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
 			},
 			wantErr: true,
 		},
@@ -303,12 +313,15 @@ func TestPersonRepositoryImpl_DeleteByUuid(t *testing.T) {
 			tt.prepare(mock)
 			tt.fields.db = sqlx.NewDb(db, "sqlmock")
 			p := &PersonRepositoryImpl{
-				db: tt.fields.db,
+				db: commons.NewTenantDB(tt.fields.db),
 			}
-			results, _ := p.DeleteByUuid(tt.args.uuid)
+			results, _ := p.DeleteByUuid(context.Background(), tt.args.uuid)
 			if tt.wantErr && results.Deleted != false {
 				t.Errorf("PersonRepositoryImpl.DeleteByUuid() = %v, want %v", results.Deleted, true)
 			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unfulfilled expectations: %s", err)
+			}
 		})
 	}
 }
@@ -378,9 +391,9 @@ func TestPersonRepositoryImpl_Update(t *testing.T) {
 			tt.prepare(mock)
 			tt.fields.db = sqlx.NewDb(db, "sqlmock")
 			p := &PersonRepositoryImpl{
-				db: tt.fields.db,
+				db: commons.NewTenantDB(tt.fields.db),
 			}
-			_, err := p.Update(tt.args.request)
+			_, err := p.Update(context.Background(), tt.args.request)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("PersonRepositoryImpl.Update() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -410,3 +423,61 @@ func TestNewPersonRepository(t *testing.T) {
 		})
 	}
 }
+
+// arrayConverter hands slices to the driver as they are, as pgx takes them for the ANY($1) queries.
+type arrayConverter struct{}
+
+func (arrayConverter) ConvertValue(v any) (driver.Value, error) {
+	if ids, ok := v.([]uuid.UUID); ok {
+		return ids, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+func TestPersonRepositoryImpl_GetByUuids(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	tests := []struct {
+		name    string
+		prepare func(mock sqlmock.Sqlmock)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "alt_id", "name"}).
+					AddRow(1, ids[0].String(), "first").
+					AddRow(2, ids[1].String(), "second")
+				mock.ExpectQuery("SELECT \\* FROM persons WHERE alt_id = ANY\\(\\$1\\)").WithArgs(ids).WillReturnRows(rows)
+			},
+			want: 2,
+		},
+		{
+			name: "DB error",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM persons WHERE alt_id = ANY\\(\\$1\\)").WithArgs(ids).WillReturnError(errors.New("test error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New(sqlmock.ValueConverterOption(arrayConverter{}))
+			defer func(db *sql.DB) {
+				_ = db.Close()
+			}(db)
+			tt.prepare(mock)
+			p := &PersonRepositoryImpl{
+				db: commons.NewTenantDB(sqlx.NewDb(db, "sqlmock")),
+			}
+			got, err := p.GetByUuids(context.Background(), ids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PersonRepositoryImpl.GetByUuids() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("PersonRepositoryImpl.GetByUuids() returned %d rows, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package person
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"inventory-service-go/commons"
 )
@@ -20,23 +21,31 @@ func (*Person) FromRow(row PersonRow) Person {
 		Name:  row.Name,
 		Email: row.Email,
 		AuditInfo: commons.AuditInfo{
-			CreatedBy:     row.CreatedBy,
-			CreatedAt:     row.CreatedAt.String(),
-			LastUpdate:    row.LastUpdate.String(),
-			LastChangedBy: row.LastChangedBy,
+			CreatedBy:      row.CreatedBy,
+			CreatedAt:      row.CreatedAt.String(),
+			LastUpdate:     row.LastUpdate.String(),
+			LastChangedBy:  row.LastChangedBy,
+			CreatedTime:    row.CreatedAt,
+			LastUpdateTime: row.LastUpdate,
 		},
 	}
 }
 
+// ChangeTopic is the topic person changes are notified under.
+const ChangeTopic = "persons"
+
 type PersonService interface {
-	GetAll(pagination *commons.Pagination) ([]Person, error)
-	GetById(id uuid.UUID) (*Person, error)
-	Create(request CreatePersonRequest) (*Person, error)
-	Update(request UpdatePersonRequest) (*Person, error)
-	DeleteByUuid(uuid uuid.UUID) (*commons.DeleteResult, error)
+	GetAll(ctx context.Context, pagination *commons.Pagination) ([]Person, error)
+	GetById(ctx context.Context, id uuid.UUID) (*Person, error)
+	// GetByIds returns the persons with the given ids, in no particular order, leaving out the ids that do not exist.
+	GetByIds(ctx context.Context, ids []uuid.UUID) ([]Person, error)
+	Create(ctx context.Context, request CreatePersonRequest) (*Person, error)
+	Update(ctx context.Context, request UpdatePersonRequest) (*Person, error)
+	DeleteByUuid(ctx context.Context, uuid uuid.UUID) (*commons.DeleteResult, error)
 }
 
 type PersonServiceImpl struct {
+	commons.ChangeHooks
 	repo PersonRepository
 }
 
@@ -46,8 +55,8 @@ func NewPersonService(repo PersonRepository) *PersonServiceImpl {
 	}
 }
 
-func (p *PersonServiceImpl) GetAll(pagination *commons.Pagination) ([]Person, error) {
-	persons, err := p.repo.GetAll(pagination)
+func (p *PersonServiceImpl) GetAll(ctx context.Context, pagination *commons.Pagination) ([]Person, error) {
+	persons, err := p.repo.GetAll(ctx, pagination)
 	if err != nil {
 		return nil, err
 	}
@@ -61,8 +70,8 @@ func (p *PersonServiceImpl) GetAll(pagination *commons.Pagination) ([]Person, er
 	return result, nil
 }
 
-func (p *PersonServiceImpl) GetById(id uuid.UUID) (*Person, error) {
-	row, err := p.repo.GetByUuid(id)
+func (p *PersonServiceImpl) GetById(ctx context.Context, id uuid.UUID) (*Person, error) {
+	row, err := p.repo.GetByUuid(ctx, id)
 	p2 := Person{}
 	if err != nil {
 		return &p2, err
@@ -71,30 +80,48 @@ func (p *PersonServiceImpl) GetById(id uuid.UUID) (*Person, error) {
 	return &person, nil
 }
 
-func (p *PersonServiceImpl) Create(request CreatePersonRequest) (*Person, error) {
-	row, err := p.repo.Create(request)
+func (p *PersonServiceImpl) GetByIds(ctx context.Context, ids []uuid.UUID) ([]Person, error) {
+	rows, err := p.repo.GetByUuids(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Person, 0, len(rows))
+	for _, row := range rows {
+		p2 := Person{}
+		result = append(result, p2.FromRow(row))
+	}
+	return result, nil
+}
+
+func (p *PersonServiceImpl) Create(ctx context.Context, request CreatePersonRequest) (*Person, error) {
+	row, err := p.repo.Create(ctx, request)
 	p2 := Person{}
 	if err != nil {
 		return &p2, err
 	}
 	person := p2.FromRow(row)
+	p.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeCreated, Id: person.Id, Data: person})
 	return &person, nil
 }
 
-func (p *PersonServiceImpl) Update(request UpdatePersonRequest) (*Person, error) {
-	row, err := p.repo.Update(request)
+func (p *PersonServiceImpl) Update(ctx context.Context, request UpdatePersonRequest) (*Person, error) {
+	row, err := p.repo.Update(ctx, request)
 	p2 := Person{}
 	if err != nil {
 		return &p2, err
 	}
 	person := p2.FromRow(row)
+	p.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeUpdated, Id: person.Id, Data: person})
 	return &person, nil
 }
 
-func (p *PersonServiceImpl) DeleteByUuid(uuid uuid.UUID) (*commons.DeleteResult, error) {
-	deleteResults, err := p.repo.DeleteByUuid(uuid)
+func (p *PersonServiceImpl) DeleteByUuid(ctx context.Context, uuid uuid.UUID) (*commons.DeleteResult, error) {
+	deleteResults, err := p.repo.DeleteByUuid(ctx, uuid)
 	if err != nil {
 		return nil, err
 	}
+	if deleteResults.Deleted {
+		p.Notify(ctx, commons.Change{Topic: ChangeTopic, Action: commons.ChangeDeleted, Id: uuid})
+	}
 	return &deleteResults, nil
 }
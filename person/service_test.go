@@ -1,6 +1,7 @@
 package person
 
 import (
+	"context"
 	"errors"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -37,10 +38,12 @@ func TestFromRow(t *testing.T) {
 				Name:  "John Doe",
 				Email: "johndoe@example.com",
 				AuditInfo: commons.AuditInfo{
-					CreatedBy:     "admin",
-					CreatedAt:     now.String(),
-					LastUpdate:    now.String(),
-					LastChangedBy: "admin",
+					CreatedBy:      "admin",
+					CreatedAt:      now.String(),
+					LastUpdate:     now.String(),
+					LastChangedBy:  "admin",
+					CreatedTime:    now,
+					LastUpdateTime: now,
 				},
 			},
 		},
@@ -108,12 +111,12 @@ func TestGetAll(t *testing.T) {
 	}
 	for _, tt := range tests {
 		if tt.wantErr {
-			mockRepo.EXPECT().GetAll(pagination).Return(nil, errors.New("error"))
+			mockRepo.EXPECT().GetAll(gomock.Any(), pagination).Return(nil, errors.New("error"))
 		} else {
-			mockRepo.EXPECT().GetAll(pagination).Return(tt.expected, nil)
+			mockRepo.EXPECT().GetAll(gomock.Any(), pagination).Return(tt.expected, nil)
 		}
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := personService.GetAll(pagination)
+			got, err := personService.GetAll(context.Background(), pagination)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("PersonServiceImpl.GetAll() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -153,12 +156,12 @@ func TestGetById(t *testing.T) {
 	}
 	for _, tt := range tests {
 		if tt.wantErr {
-			mockRepo.EXPECT().GetByUuid(tt.expected.AltId).Return(PersonRow{}, errors.New("error"))
+			mockRepo.EXPECT().GetByUuid(gomock.Any(), tt.expected.AltId).Return(PersonRow{}, errors.New("error"))
 		} else {
-			mockRepo.EXPECT().GetByUuid(tt.expected.AltId).Return(tt.expected, nil)
+			mockRepo.EXPECT().GetByUuid(gomock.Any(), tt.expected.AltId).Return(tt.expected, nil)
 		}
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := personService.GetById(tt.expected.AltId)
+			got, err := personService.GetById(context.Background(), tt.expected.AltId)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("PersonServiceImpl.GetById() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -202,12 +205,12 @@ func TestCreate(t *testing.T) {
 	}
 	for _, tt := range tests {
 		if tt.wantErr {
-			mockRepo.EXPECT().Create(createRequest).Return(PersonRow{}, errors.New("error"))
+			mockRepo.EXPECT().Create(gomock.Any(), createRequest).Return(PersonRow{}, errors.New("error"))
 		} else {
-			mockRepo.EXPECT().Create(createRequest).Return(tt.expected, nil)
+			mockRepo.EXPECT().Create(gomock.Any(), createRequest).Return(tt.expected, nil)
 		}
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := personService.Create(createRequest)
+			got, err := personService.Create(context.Background(), createRequest)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("PersonServiceImpl.Create() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -252,12 +255,12 @@ func TestUpdate(t *testing.T) {
 	}
 	for _, tt := range tests {
 		if tt.wantErr {
-			mockRepo.EXPECT().Update(updateRequest).Return(PersonRow{}, errors.New("error"))
+			mockRepo.EXPECT().Update(gomock.Any(), updateRequest).Return(PersonRow{}, errors.New("error"))
 		} else {
-			mockRepo.EXPECT().Update(updateRequest).Return(tt.expected, nil)
+			mockRepo.EXPECT().Update(gomock.Any(), updateRequest).Return(tt.expected, nil)
 		}
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := personService.Update(updateRequest)
+			got, err := personService.Update(context.Background(), updateRequest)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("PersonServiceImpl.Update() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -295,12 +298,12 @@ func TestDeleteByUuid(t *testing.T) {
 		mockRepo := NewMockPersonRepository(controller)
 		personService := NewPersonService(mockRepo)
 		if tt.wantErr {
-			mockRepo.EXPECT().DeleteByUuid(rowFixture.AltId).Return(commons.DeleteResult{}, errors.New("error"))
+			mockRepo.EXPECT().DeleteByUuid(gomock.Any(), rowFixture.AltId).Return(commons.DeleteResult{}, errors.New("error"))
 		} else {
-			mockRepo.EXPECT().DeleteByUuid(rowFixture.AltId).Return(tt.expected, nil)
+			mockRepo.EXPECT().DeleteByUuid(gomock.Any(), rowFixture.AltId).Return(tt.expected, nil)
 		}
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := personService.DeleteByUuid(rowFixture.AltId)
+			got, err := personService.DeleteByUuid(context.Background(), rowFixture.AltId)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("PersonServiceImpl.DeleteByUuid() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -315,3 +318,20 @@ func TestDeleteByUuid(t *testing.T) {
 		})
 	}
 }
+
+func TestGetByIds(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := NewMockPersonRepository(controller)
+	personService := NewPersonService(mockRepo)
+	row := personRowFixture()
+	missing := uuid.New()
+
+	mockRepo.EXPECT().GetByUuids(gomock.Any(), []uuid.UUID{row.AltId, missing}).Return([]PersonRow{row}, nil)
+	got, err := personService.GetByIds(context.Background(), []uuid.UUID{row.AltId, missing})
+	assert.NoError(t, err)
+	assert.Equal(t, []Person{personFixture(row)}, got)
+
+	mockRepo.EXPECT().GetByUuids(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+	_, err = personService.GetByIds(context.Background(), []uuid.UUID{row.AltId})
+	assert.Error(t, err)
+}
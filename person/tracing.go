@@ -0,0 +1,63 @@
+package person
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"inventory-service-go/commons"
+	"inventory-service-go/tracing"
+)
+
+// TracingPersonService gives every call of the decorated service a span of its own.
+type TracingPersonService struct {
+	service PersonService
+}
+
+func NewTracingPersonService(service PersonService) *TracingPersonService {
+	return &TracingPersonService{
+		service: service,
+	}
+}
+
+// OnChange observes the changes of the decorated service.
+func (s *TracingPersonService) OnChange(hook commons.ChangeHook) {
+	if notifier, ok := s.service.(commons.ChangeNotifier); ok {
+		notifier.OnChange(hook)
+	}
+}
+
+func (s *TracingPersonService) GetAll(ctx context.Context, pagination *commons.Pagination) (persons []Person, err error) {
+	ctx, span := tracing.Start(ctx, "PersonService.GetAll")
+	defer func() { tracing.End(span, err) }()
+	return s.service.GetAll(ctx, pagination)
+}
+
+func (s *TracingPersonService) GetById(ctx context.Context, id uuid.UUID) (p *Person, err error) {
+	ctx, span := tracing.Start(ctx, "PersonService.GetById", attribute.String("person.id", id.String()))
+	defer func() { tracing.End(span, err) }()
+	return s.service.GetById(ctx, id)
+}
+
+func (s *TracingPersonService) GetByIds(ctx context.Context, ids []uuid.UUID) (persons []Person, err error) {
+	ctx, span := tracing.Start(ctx, "PersonService.GetByIds", attribute.Int("person.count", len(ids)))
+	defer func() { tracing.End(span, err) }()
+	return s.service.GetByIds(ctx, ids)
+}
+
+func (s *TracingPersonService) Create(ctx context.Context, request CreatePersonRequest) (p *Person, err error) {
+	ctx, span := tracing.Start(ctx, "PersonService.Create")
+	defer func() { tracing.End(span, err) }()
+	return s.service.Create(ctx, request)
+}
+
+func (s *TracingPersonService) Update(ctx context.Context, request UpdatePersonRequest) (p *Person, err error) {
+	ctx, span := tracing.Start(ctx, "PersonService.Update", attribute.String("person.id", request.Id.String()))
+	defer func() { tracing.End(span, err) }()
+	return s.service.Update(ctx, request)
+}
+
+func (s *TracingPersonService) DeleteByUuid(ctx context.Context, uuid uuid.UUID) (r *commons.DeleteResult, err error) {
+	ctx, span := tracing.Start(ctx, "PersonService.DeleteByUuid", attribute.String("person.id", uuid.String()))
+	defer func() { tracing.End(span, err) }()
+	return s.service.DeleteByUuid(ctx, uuid)
+}
@@ -5,16 +5,19 @@ package person
 
 import (
 	"github.com/google/wire"
+	"inventory-service-go/cache"
 	"inventory-service-go/commons"
 )
 
-func InitializePersonService() (PersonService, error) {
+func InitializePersonService(settings cache.Config) (PersonService, error) {
 	wire.Build(
+		ProvidePersonService,
 		NewPersonService,
 		NewPersonRepository,
+		cache.GetBus,
 		commons.GetDB,
 		wire.Bind(new(PersonRepository), new(*PersonRepositoryImpl)),
-		wire.Bind(new(PersonService), new(*PersonServiceImpl)),
+		wire.Bind(new(cache.Invalidator), new(*cache.Bus)),
 	)
 	return nil, nil
 }
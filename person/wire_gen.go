@@ -7,14 +7,17 @@
 package person
 
 import (
+	"inventory-service-go/cache"
 	"inventory-service-go/commons"
 )
 
 // Injectors from wire.go:
 
-func InitializePersonService() (PersonService, error) {
+func InitializePersonService(settings cache.Config) (PersonService, error) {
 	db := commons.GetDB()
 	personRepositoryImpl := NewPersonRepository(db)
 	personServiceImpl := NewPersonService(personRepositoryImpl)
-	return personServiceImpl, nil
+	bus := cache.GetBus(db)
+	personService := ProvidePersonService(personServiceImpl, bus, settings)
+	return personService, nil
 }
@@ -0,0 +1,67 @@
+package promotion
+
+import (
+	"fmt"
+	"inventory-service-go/commons"
+	"math/big"
+)
+
+type DiscountType string
+
+const (
+	Percentage  DiscountType = "percentage"
+	FixedAmount DiscountType = "fixed"
+)
+
+var ErrInvalidDiscount = commons.NewValidationError("invalid discount")
+
+var hundred = big.NewRat(100, 1)
+
+// Discount takes either a percentage ("10" is 10%) or a fixed amount ("5.00") off an amount.
+type Discount struct {
+	Type  DiscountType `json:"type"`
+	Value string       `json:"value"`
+}
+
+func (d Discount) Validate() error {
+	value, ok := new(big.Rat).SetString(d.Value)
+	if !ok || value.Sign() <= 0 {
+		return fmt.Errorf("%w: %q is not a positive number", ErrInvalidDiscount, d.Value)
+	}
+	switch d.Type {
+	case Percentage:
+		if value.Cmp(hundred) > 0 {
+			return fmt.Errorf("%w: more than 100%%", ErrInvalidDiscount)
+		}
+	case FixedAmount:
+		if _, err := commons.ParseMoney(d.Value, ""); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidDiscount, err)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidDiscount, d.Type)
+	}
+	return nil
+}
+
+// AmountOff works out how much the discount takes off amount, a fixed amount is in the currency of amount and never
+// takes off more than the amount itself.
+func (d Discount) AmountOff(amount commons.Money) (commons.Money, error) {
+	if err := d.Validate(); err != nil {
+		return commons.Money{}, err
+	}
+	var off commons.Money
+	var err error
+	if d.Type == Percentage {
+		value, _ := new(big.Rat).SetString(d.Value)
+		off, err = amount.MulRat(value.Quo(value, hundred))
+	} else {
+		off, err = commons.ParseMoney(d.Value, amount.Currency)
+	}
+	if err != nil {
+		return commons.Money{}, err
+	}
+	if off.MinorUnits > amount.MinorUnits {
+		off.MinorUnits = max(amount.MinorUnits, 0)
+	}
+	return off, nil
+}
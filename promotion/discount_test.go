@@ -0,0 +1,40 @@
+package promotion
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"testing"
+)
+
+func TestDiscount_AmountOff(t *testing.T) {
+	tests := []struct {
+		name     string
+		discount Discount
+		amount   commons.Money
+		expected commons.Money
+		wantErr  bool
+	}{
+		{"Percentage", Discount{Percentage, "10"}, commons.NewMoney(2500, "EUR"), commons.NewMoney(250, "EUR"), false},
+		{"Percentage Rounds Half Up", Discount{Percentage, "12.5"}, commons.NewMoney(1004, "EUR"), commons.NewMoney(126, "EUR"), false},
+		{"Full Percentage", Discount{Percentage, "100"}, commons.NewMoney(999, "USD"), commons.NewMoney(999, "USD"), false},
+		{"Fixed Amount", Discount{FixedAmount, "5.00"}, commons.NewMoney(2500, "USD"), commons.NewMoney(500, "USD"), false},
+		{"Fixed Amount In Currency Of Amount", Discount{FixedAmount, "2"}, commons.NewMoney(9000, "GBP"), commons.NewMoney(200, "GBP"), false},
+		{"Fixed Amount Capped", Discount{FixedAmount, "50"}, commons.NewMoney(2500, "USD"), commons.NewMoney(2500, "USD"), false},
+		{"Over 100 Percent", Discount{Percentage, "101"}, commons.NewMoney(2500, "USD"), commons.Money{}, true},
+		{"Zero", Discount{Percentage, "0"}, commons.NewMoney(2500, "USD"), commons.Money{}, true},
+		{"Negative", Discount{FixedAmount, "-5"}, commons.NewMoney(2500, "USD"), commons.Money{}, true},
+		{"Garbage", Discount{FixedAmount, "five"}, commons.NewMoney(2500, "USD"), commons.Money{}, true},
+		{"Unknown Type", Discount{"bogof", "1"}, commons.NewMoney(2500, "USD"), commons.Money{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.discount.AmountOff(tt.amount)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidDiscount)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source repository.go -destination mock_repository.go -package promotion
+//
+
+// Package promotion is a generated GoMock package.
+package promotion
+
+import (
+	context "context"
+	commons "inventory-service-go/commons"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPromotionRepository is a mock of PromotionRepository interface.
+type MockPromotionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionRepositoryMockRecorder
+}
+
+// MockPromotionRepositoryMockRecorder is the mock recorder for MockPromotionRepository.
+type MockPromotionRepositoryMockRecorder struct {
+	mock *MockPromotionRepository
+}
+
+// NewMockPromotionRepository creates a new mock instance.
+func NewMockPromotionRepository(ctrl *gomock.Controller) *MockPromotionRepository {
+	mock := &MockPromotionRepository{ctrl: ctrl}
+	mock.recorder = &MockPromotionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionRepository) EXPECT() *MockPromotionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePromotion mocks base method.
+func (m *MockPromotionRepository) CreatePromotion(ctx context.Context, request CreatePromotionRequest) (PromotionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", ctx, request)
+	ret0, _ := ret[0].(PromotionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockPromotionRepositoryMockRecorder) CreatePromotion(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockPromotionRepository)(nil).CreatePromotion), ctx, request)
+}
+
+// DeletePromotion mocks base method.
+func (m *MockPromotionRepository) DeletePromotion(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePromotion", ctx, id)
+	ret0, _ := ret[0].(commons.DeleteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePromotion indicates an expected call of DeletePromotion.
+func (mr *MockPromotionRepositoryMockRecorder) DeletePromotion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePromotion", reflect.TypeOf((*MockPromotionRepository)(nil).DeletePromotion), ctx, id)
+}
+
+// GetPromotion mocks base method.
+func (m *MockPromotionRepository) GetPromotion(ctx context.Context, id uuid.UUID) (PromotionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotion", ctx, id)
+	ret0, _ := ret[0].(PromotionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotion indicates an expected call of GetPromotion.
+func (mr *MockPromotionRepositoryMockRecorder) GetPromotion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotion", reflect.TypeOf((*MockPromotionRepository)(nil).GetPromotion), ctx, id)
+}
+
+// GetPromotionByCode mocks base method.
+func (m *MockPromotionRepository) GetPromotionByCode(ctx context.Context, code string) (PromotionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotionByCode", ctx, code)
+	ret0, _ := ret[0].(PromotionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotionByCode indicates an expected call of GetPromotionByCode.
+func (mr *MockPromotionRepositoryMockRecorder) GetPromotionByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionByCode", reflect.TypeOf((*MockPromotionRepository)(nil).GetPromotionByCode), ctx, code)
+}
+
+// GetPromotions mocks base method.
+func (m *MockPromotionRepository) GetPromotions(ctx context.Context, pagination *commons.Pagination) ([]PromotionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotions", ctx, pagination)
+	ret0, _ := ret[0].([]PromotionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotions indicates an expected call of GetPromotions.
+func (mr *MockPromotionRepositoryMockRecorder) GetPromotions(ctx, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotions", reflect.TypeOf((*MockPromotionRepository)(nil).GetPromotions), ctx, pagination)
+}
+
+// UpdatePromotion mocks base method.
+func (m *MockPromotionRepository) UpdatePromotion(ctx context.Context, request UpdatePromotionRequest) (PromotionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePromotion", ctx, request)
+	ret0, _ := ret[0].(PromotionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePromotion indicates an expected call of UpdatePromotion.
+func (mr *MockPromotionRepositoryMockRecorder) UpdatePromotion(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePromotion", reflect.TypeOf((*MockPromotionRepository)(nil).UpdatePromotion), ctx, request)
+}
@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source service.go -destination mock_service.go -package promotion
+//
+
+// Package promotion is a generated GoMock package.
+package promotion
+
+import (
+	context "context"
+	commons "inventory-service-go/commons"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockValidator is a mock of Validator interface.
+type MockValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockValidatorMockRecorder
+}
+
+// MockValidatorMockRecorder is the mock recorder for MockValidator.
+type MockValidatorMockRecorder struct {
+	mock *MockValidator
+}
+
+// NewMockValidator creates a new mock instance.
+func NewMockValidator(ctrl *gomock.Controller) *MockValidator {
+	mock := &MockValidator{ctrl: ctrl}
+	mock.recorder = &MockValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValidator) EXPECT() *MockValidatorMockRecorder {
+	return m.recorder
+}
+
+// ActivePromotion mocks base method.
+func (m *MockValidator) ActivePromotion(ctx context.Context, code string, on time.Time) (Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivePromotion", ctx, code, on)
+	ret0, _ := ret[0].(Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivePromotion indicates an expected call of ActivePromotion.
+func (mr *MockValidatorMockRecorder) ActivePromotion(ctx, code, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivePromotion", reflect.TypeOf((*MockValidator)(nil).ActivePromotion), ctx, code, on)
+}
+
+// MockPromotionService is a mock of PromotionService interface.
+type MockPromotionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionServiceMockRecorder
+}
+
+// MockPromotionServiceMockRecorder is the mock recorder for MockPromotionService.
+type MockPromotionServiceMockRecorder struct {
+	mock *MockPromotionService
+}
+
+// NewMockPromotionService creates a new mock instance.
+func NewMockPromotionService(ctrl *gomock.Controller) *MockPromotionService {
+	mock := &MockPromotionService{ctrl: ctrl}
+	mock.recorder = &MockPromotionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionService) EXPECT() *MockPromotionServiceMockRecorder {
+	return m.recorder
+}
+
+// ActivePromotion mocks base method.
+func (m *MockPromotionService) ActivePromotion(ctx context.Context, code string, on time.Time) (Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivePromotion", ctx, code, on)
+	ret0, _ := ret[0].(Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivePromotion indicates an expected call of ActivePromotion.
+func (mr *MockPromotionServiceMockRecorder) ActivePromotion(ctx, code, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivePromotion", reflect.TypeOf((*MockPromotionService)(nil).ActivePromotion), ctx, code, on)
+}
+
+// CreatePromotion mocks base method.
+func (m *MockPromotionService) CreatePromotion(ctx context.Context, request CreatePromotionRequest) (Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", ctx, request)
+	ret0, _ := ret[0].(Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockPromotionServiceMockRecorder) CreatePromotion(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockPromotionService)(nil).CreatePromotion), ctx, request)
+}
+
+// DeletePromotion mocks base method.
+func (m *MockPromotionService) DeletePromotion(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePromotion", ctx, id)
+	ret0, _ := ret[0].(commons.DeleteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePromotion indicates an expected call of DeletePromotion.
+func (mr *MockPromotionServiceMockRecorder) DeletePromotion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePromotion", reflect.TypeOf((*MockPromotionService)(nil).DeletePromotion), ctx, id)
+}
+
+// GetPromotion mocks base method.
+func (m *MockPromotionService) GetPromotion(ctx context.Context, id uuid.UUID) (Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotion", ctx, id)
+	ret0, _ := ret[0].(Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotion indicates an expected call of GetPromotion.
+func (mr *MockPromotionServiceMockRecorder) GetPromotion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotion", reflect.TypeOf((*MockPromotionService)(nil).GetPromotion), ctx, id)
+}
+
+// GetPromotions mocks base method.
+func (m *MockPromotionService) GetPromotions(ctx context.Context, pagination *commons.Pagination) ([]Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotions", ctx, pagination)
+	ret0, _ := ret[0].([]Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotions indicates an expected call of GetPromotions.
+func (mr *MockPromotionServiceMockRecorder) GetPromotions(ctx, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotions", reflect.TypeOf((*MockPromotionService)(nil).GetPromotions), ctx, pagination)
+}
+
+// UpdatePromotion mocks base method.
+func (m *MockPromotionService) UpdatePromotion(ctx context.Context, request UpdatePromotionRequest) (Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePromotion", ctx, request)
+	ret0, _ := ret[0].(Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePromotion indicates an expected call of UpdatePromotion.
+func (mr *MockPromotionServiceMockRecorder) UpdatePromotion(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePromotion", reflect.TypeOf((*MockPromotionService)(nil).UpdatePromotion), ctx, request)
+}
@@ -0,0 +1,143 @@
+package promotion
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"inventory-service-go/commons"
+	"inventory-service-go/metrics"
+	"inventory-service-go/tracing"
+	"time"
+)
+
+type PromotionRow struct {
+	Id             int64             `db:"id"`
+	AltId          uuid.UUID         `db:"alt_id"`
+	Code           string            `db:"code"`
+	Description    string            `db:"description"`
+	DiscountType   string            `db:"discount_type"`
+	DiscountValue  string            `db:"discount_value"`
+	Currency       string            `db:"currency"`
+	MinOrderAmount commons.NullMoney `db:"min_order_amount"`
+	ValidFrom      time.Time         `db:"valid_from"`
+	ValidUntil     sql.NullTime      `db:"valid_until"`
+	MaxUses        sql.NullInt64     `db:"max_uses"`
+	TimesUsed      int64             `db:"times_used"`
+	CreatedBy      string            `db:"created_by"`
+	CreatedAt      time.Time         `db:"created_at"`
+	LastChangedBy  string            `db:"last_changed_by"`
+	LastUpdate     time.Time         `db:"last_update"`
+	TenantId       uuid.UUID         `db:"tenant_id"`
+}
+
+type CreatePromotionRequest struct {
+	Code           string         `json:"code"`
+	Description    string         `json:"description"`
+	Discount       Discount       `json:"discount"`
+	Currency       string         `json:"currency"`
+	MinOrderAmount *commons.Money `json:"min_order_amount,omitempty"`
+	ValidFrom      time.Time      `json:"valid_from"`
+	ValidUntil     *time.Time     `json:"valid_until,omitempty"`
+	MaxUses        *int64         `json:"max_uses,omitempty"`
+	CreatedBy      string         `json:"created_by" audit:"actor"`
+}
+
+type UpdatePromotionRequest struct {
+	Id             uuid.UUID      `json:"id"`
+	Description    string         `json:"description"`
+	MinOrderAmount *commons.Money `json:"min_order_amount,omitempty"`
+	ValidFrom      time.Time      `json:"valid_from"`
+	ValidUntil     *time.Time     `json:"valid_until,omitempty"`
+	MaxUses        *int64         `json:"max_uses,omitempty"`
+	LastChangedBy  string         `json:"last_changed_by" audit:"actor"`
+}
+
+const (
+	CreatePromotionQuery            = `INSERT INTO promotion_codes (code, description, discount_type, discount_value, currency, min_order_amount, valid_from, valid_until, max_uses, created_by, last_changed_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10) RETURNING *`
+	UpdatePromotionQuery            = `UPDATE promotion_codes SET description = $2, min_order_amount = $3, valid_from = $4, valid_until = $5, max_uses = $6, last_changed_by = $7, last_update = now() WHERE alt_id = $1 RETURNING *`
+	DeletePromotionQuery            = `DELETE FROM promotion_codes WHERE alt_id = $1`
+	GetPromotionQuery               = `SELECT * FROM promotion_codes WHERE alt_id = $1`
+	GetPromotionByCodeQuery         = `SELECT * FROM promotion_codes WHERE code = $1`
+	GetAllPromotionsQuery           = `SELECT * FROM promotion_codes ORDER BY id`
+	GetAllPromotionsPaginationQuery = `SELECT * FROM promotion_codes WHERE id > $1 ORDER BY id LIMIT $2`
+)
+
+type PromotionRepository interface {
+	CreatePromotion(ctx context.Context, request CreatePromotionRequest) (PromotionRow, error)
+	UpdatePromotion(ctx context.Context, request UpdatePromotionRequest) (PromotionRow, error)
+	DeletePromotion(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error)
+	GetPromotion(ctx context.Context, id uuid.UUID) (PromotionRow, error)
+	GetPromotionByCode(ctx context.Context, code string) (PromotionRow, error)
+	GetPromotions(ctx context.Context, pagination *commons.Pagination) ([]PromotionRow, error)
+}
+
+type PromotionRepositoryImpl struct {
+	db *commons.TenantDB
+}
+
+func NewPromotionRepository(db *sqlx.DB) *PromotionRepositoryImpl {
+	return &PromotionRepositoryImpl{db: commons.NewTenantDB(db)}
+}
+
+func nullMoney(m *commons.Money) commons.NullMoney {
+	if m == nil {
+		return commons.NullMoney{}
+	}
+	return commons.NullMoney{Money: *m, Valid: true}
+}
+
+func (r *PromotionRepositoryImpl) CreatePromotion(ctx context.Context, request CreatePromotionRequest) (PromotionRow, error) {
+	defer metrics.ObserveQuery("promotion", "CreatePromotion")()
+	var row PromotionRow
+	err := r.db.GetContext(tracing.Statement(ctx, "CreatePromotionQuery"), &row, CreatePromotionQuery, request.Code, request.Description, request.Discount.Type, request.Discount.Value, request.Currency,
+		nullMoney(request.MinOrderAmount), request.ValidFrom, request.ValidUntil, request.MaxUses, request.CreatedBy)
+	return row, err
+}
+
+func (r *PromotionRepositoryImpl) UpdatePromotion(ctx context.Context, request UpdatePromotionRequest) (PromotionRow, error) {
+	defer metrics.ObserveQuery("promotion", "UpdatePromotion")()
+	var row PromotionRow
+	err := r.db.GetContext(tracing.Statement(ctx, "UpdatePromotionQuery"), &row, UpdatePromotionQuery, request.Id, request.Description, nullMoney(request.MinOrderAmount), request.ValidFrom,
+		request.ValidUntil, request.MaxUses, request.LastChangedBy)
+	return row, err
+}
+
+func (r *PromotionRepositoryImpl) DeletePromotion(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	defer metrics.ObserveQuery("promotion", "DeletePromotion")()
+	result, err := r.db.ExecContext(tracing.Statement(ctx, "DeletePromotionQuery"), DeletePromotionQuery, id)
+	if err != nil {
+		return commons.DeleteResult{}, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return commons.DeleteResult{
+		Id:      id,
+		Deleted: rowsAffected > 0,
+	}, nil
+}
+
+func (r *PromotionRepositoryImpl) GetPromotion(ctx context.Context, id uuid.UUID) (PromotionRow, error) {
+	defer metrics.ObserveQuery("promotion", "GetPromotion")()
+	var row PromotionRow
+	err := r.db.GetContext(tracing.Statement(ctx, "GetPromotionQuery"), &row, GetPromotionQuery, id)
+	return row, err
+}
+
+func (r *PromotionRepositoryImpl) GetPromotionByCode(ctx context.Context, code string) (PromotionRow, error) {
+	defer metrics.ObserveQuery("promotion", "GetPromotionByCode")()
+	var row PromotionRow
+	err := r.db.GetContext(tracing.Statement(ctx, "GetPromotionByCodeQuery"), &row, GetPromotionByCodeQuery, code)
+	return row, err
+}
+
+func (r *PromotionRepositoryImpl) GetPromotions(ctx context.Context, pagination *commons.Pagination) ([]PromotionRow, error) {
+	defer metrics.ObserveQuery("promotion", "GetPromotions")()
+	var rows []PromotionRow
+	var err error
+	if pagination == nil {
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetAllPromotionsQuery"), &rows, GetAllPromotionsQuery)
+	} else {
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetAllPromotionsPaginationQuery"), &rows, GetAllPromotionsPaginationQuery, pagination.LastId, pagination.PageSize)
+	}
+	return rows, err
+}
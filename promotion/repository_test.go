@@ -0,0 +1,130 @@
+package promotion
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+var promotionColumns = []string{"id", "alt_id", "code", "description", "discount_type", "discount_value", "currency", "min_order_amount", "valid_from", "valid_until", "max_uses", "times_used", "created_by", "created_at", "last_changed_by", "last_update"}
+
+func TestPromotionRepositoryImpl_CreatePromotion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	newUuid := uuid.New()
+	minOrderAmount := commons.NewMoney(2500, "USD")
+	maxUses := int64(100)
+	request := CreatePromotionRequest{
+		Code:           "SPRING",
+		Description:    "spring sale",
+		Discount:       Discount{Type: FixedAmount, Value: "5.00"},
+		Currency:       "USD",
+		MinOrderAmount: &minOrderAmount,
+		ValidFrom:      now,
+		MaxUses:        &maxUses,
+		CreatedBy:      "unit_test",
+	}
+	testCases := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"Successful Promotion Creation", false},
+		{"Failed Promotion Creation", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expectation := mock.ExpectQuery("INSERT INTO promotion_codes").
+				WithArgs("SPRING", "spring sale", "fixed", "5.00", "USD", "25.00", now, nil, maxUses, "unit_test")
+			if tc.wantErr {
+				expectation.WillReturnError(errors.New("error"))
+			} else {
+				expectation.WillReturnRows(sqlmock.NewRows(promotionColumns).
+					AddRow(1, newUuid, "SPRING", "spring sale", "fixed", "5.0000", "USD", "25.00", now, nil, maxUses, 0, "unit_test", now, "unit_test", now))
+			}
+
+			r := NewPromotionRepository(sqlx.NewDb(db, "mockDb"))
+
+			result, err := r.CreatePromotion(context.Background(), request)
+			if tc.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, newUuid, result.AltId)
+				assert.Equal(t, commons.NullMoney{Money: commons.NewMoney(2500, commons.DefaultCurrency), Valid: true}, result.MinOrderAmount)
+				assert.False(t, result.ValidUntil.Valid)
+				assert.Equal(t, sql.NullInt64{Int64: 100, Valid: true}, result.MaxUses)
+			}
+		})
+	}
+}
+
+func TestPromotionRepositoryImpl_GetPromotionByCode(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	mock.ExpectQuery("SELECT \\* FROM promotion_codes WHERE code = \\$1").
+		WithArgs("SPRING").
+		WillReturnRows(sqlmock.NewRows(promotionColumns).
+			AddRow(1, uuid.New(), "SPRING", "", "percentage", "10.0000", "EUR", nil, now, now.Add(time.Hour), nil, 3, "unit_test", now, "unit_test", now))
+	mock.ExpectQuery("SELECT \\* FROM promotion_codes WHERE code = \\$1").
+		WithArgs("WINTER").
+		WillReturnError(sql.ErrNoRows)
+
+	r := NewPromotionRepository(sqlx.NewDb(db, "mockDb"))
+
+	result, err := r.GetPromotionByCode(context.Background(), "SPRING")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), result.TimesUsed)
+	assert.False(t, result.MinOrderAmount.Valid)
+	assert.True(t, result.ValidUntil.Valid)
+
+	_, err = r.GetPromotionByCode(context.Background(), "WINTER")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPromotionRepositoryImpl_GetPromotions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	mock.ExpectQuery("SELECT \\* FROM promotion_codes WHERE id > \\$1 ORDER BY id LIMIT \\$2").
+		WithArgs(0, 10).
+		WillReturnRows(sqlmock.NewRows(promotionColumns).
+			AddRow(1, uuid.New(), "SPRING", "", "percentage", "10.0000", "EUR", nil, now, nil, nil, 0, "unit_test", now, "unit_test", now))
+
+	r := NewPromotionRepository(sqlx.NewDb(db, "mockDb"))
+
+	result, err := r.GetPromotions(context.Background(), &commons.Pagination{LastId: 0, PageSize: 10})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPromotionRepositoryImpl_DeletePromotion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	id := uuid.New()
+	mock.ExpectExec("DELETE FROM promotion_codes").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := NewPromotionRepository(sqlx.NewDb(db, "mockDb"))
+
+	result, err := r.DeletePromotion(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, commons.DeleteResult{Id: id, Deleted: true}, result)
+}
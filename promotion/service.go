@@ -0,0 +1,221 @@
+package promotion
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidPromotion   = commons.NewValidationError("invalid promotion")
+	ErrUnknownPromotion   = commons.NewValidationError("unknown promotion code")
+	ErrPromotionInactive  = commons.NewValidationError("promotion code is not valid at this time")
+	ErrPromotionExhausted = commons.NewValidationError("promotion code has been used up")
+)
+
+type Promotion struct {
+	Seq            int               `json:"seq"`
+	Id             uuid.UUID         `json:"id"`
+	Code           string            `json:"code"`
+	Description    string            `json:"description"`
+	Discount       Discount          `json:"discount"`
+	Currency       string            `json:"currency"`
+	MinOrderAmount *commons.Money    `json:"min_order_amount,omitempty"`
+	ValidFrom      time.Time         `json:"valid_from"`
+	ValidUntil     *time.Time        `json:"valid_until,omitempty"`
+	MaxUses        *int64            `json:"max_uses,omitempty"`
+	TimesUsed      int64             `json:"times_used"`
+	AuditInfo      commons.AuditInfo `json:"audit_info"`
+}
+
+func fromRow(row PromotionRow) Promotion {
+	p := Promotion{
+		Seq:         int(row.Id),
+		Id:          row.AltId,
+		Code:        row.Code,
+		Description: row.Description,
+		Discount:    Discount{Type: DiscountType(row.DiscountType), Value: row.DiscountValue},
+		Currency:    row.Currency,
+		ValidFrom:   row.ValidFrom,
+		TimesUsed:   row.TimesUsed,
+		AuditInfo: commons.AuditInfo{
+			CreatedBy:      row.CreatedBy,
+			CreatedAt:      row.CreatedAt.Format(time.RFC3339),
+			LastChangedBy:  row.LastChangedBy,
+			LastUpdate:     row.LastUpdate.Format(time.RFC3339),
+			CreatedTime:    row.CreatedAt,
+			LastUpdateTime: row.LastUpdate,
+		},
+	}
+	if row.MinOrderAmount.Valid {
+		minOrderAmount := row.MinOrderAmount.Money
+		minOrderAmount.Currency = row.Currency
+		p.MinOrderAmount = &minOrderAmount
+	}
+	if row.ValidUntil.Valid {
+		p.ValidUntil = &row.ValidUntil.Time
+	}
+	if row.MaxUses.Valid {
+		p.MaxUses = &row.MaxUses.Int64
+	}
+	return p
+}
+
+// ActiveOn reports whether the code may be used at the given time, ValidUntil is exclusive.
+func (p Promotion) ActiveOn(on time.Time) bool {
+	return !on.Before(p.ValidFrom) && (p.ValidUntil == nil || on.Before(*p.ValidUntil))
+}
+
+// Exhausted reports whether the code has reached its usage limit.
+func (p Promotion) Exhausted() bool {
+	return p.MaxUses != nil && p.TimesUsed >= *p.MaxUses
+}
+
+// Validator resolves a promotion code that may be applied at the given time.
+type Validator interface {
+	ActivePromotion(ctx context.Context, code string, on time.Time) (Promotion, error)
+}
+
+type PromotionService interface {
+	Validator
+	CreatePromotion(ctx context.Context, request CreatePromotionRequest) (Promotion, error)
+	UpdatePromotion(ctx context.Context, request UpdatePromotionRequest) (Promotion, error)
+	DeletePromotion(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error)
+	GetPromotion(ctx context.Context, id uuid.UUID) (Promotion, error)
+	GetPromotions(ctx context.Context, pagination *commons.Pagination) ([]Promotion, error)
+}
+
+type PromotionServiceImpl struct {
+	repo PromotionRepository
+}
+
+func NewPromotionService(repo PromotionRepository) *PromotionServiceImpl {
+	return &PromotionServiceImpl{repo: repo}
+}
+
+// NormalizeCode upper cases and trims a code, codes are matched case insensitively.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func validateWindow(validFrom time.Time, validUntil *time.Time, maxUses *int64) error {
+	if validUntil != nil && !validUntil.After(validFrom) {
+		return fmt.Errorf("%w: valid_until must be after valid_from", ErrInvalidPromotion)
+	}
+	if maxUses != nil && *maxUses <= 0 {
+		return fmt.Errorf("%w: max_uses must be positive", ErrInvalidPromotion)
+	}
+	return nil
+}
+
+func validateMinOrderAmount(minOrderAmount *commons.Money, currency string) error {
+	if minOrderAmount == nil {
+		return nil
+	}
+	if minOrderAmount.Currency != currency {
+		return fmt.Errorf("%w: promotion is in %s, minimum order amount is in %s", commons.ErrCurrencyMismatch, currency, minOrderAmount.Currency)
+	}
+	if minOrderAmount.IsNegative() {
+		return fmt.Errorf("%w: negative minimum order amount", ErrInvalidPromotion)
+	}
+	return nil
+}
+
+func (s *PromotionServiceImpl) CreatePromotion(ctx context.Context, request CreatePromotionRequest) (Promotion, error) {
+	request.Code = NormalizeCode(request.Code)
+	if request.Code == "" {
+		return Promotion{}, fmt.Errorf("%w: code is required", ErrInvalidPromotion)
+	}
+	if err := request.Discount.Validate(); err != nil {
+		return Promotion{}, err
+	}
+	currency, err := commons.NormalizeCurrency(request.Currency)
+	if err != nil {
+		return Promotion{}, err
+	}
+	request.Currency = currency
+	if request.ValidFrom.IsZero() {
+		request.ValidFrom = time.Now()
+	}
+	if err := validateWindow(request.ValidFrom, request.ValidUntil, request.MaxUses); err != nil {
+		return Promotion{}, err
+	}
+	if err := validateMinOrderAmount(request.MinOrderAmount, request.Currency); err != nil {
+		return Promotion{}, err
+	}
+	row, err := s.repo.CreatePromotion(ctx, request)
+	if err != nil {
+		return Promotion{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *PromotionServiceImpl) UpdatePromotion(ctx context.Context, request UpdatePromotionRequest) (Promotion, error) {
+	existing, err := s.repo.GetPromotion(ctx, request.Id)
+	if err != nil {
+		return Promotion{}, err
+	}
+	if request.ValidFrom.IsZero() {
+		request.ValidFrom = existing.ValidFrom
+	}
+	if err := validateWindow(request.ValidFrom, request.ValidUntil, request.MaxUses); err != nil {
+		return Promotion{}, err
+	}
+	if err := validateMinOrderAmount(request.MinOrderAmount, existing.Currency); err != nil {
+		return Promotion{}, err
+	}
+	row, err := s.repo.UpdatePromotion(ctx, request)
+	if err != nil {
+		return Promotion{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *PromotionServiceImpl) DeletePromotion(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	return s.repo.DeletePromotion(ctx, id)
+}
+
+func (s *PromotionServiceImpl) GetPromotion(ctx context.Context, id uuid.UUID) (Promotion, error) {
+	row, err := s.repo.GetPromotion(ctx, id)
+	if err != nil {
+		return Promotion{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *PromotionServiceImpl) GetPromotions(ctx context.Context, pagination *commons.Pagination) ([]Promotion, error) {
+	rows, err := s.repo.GetPromotions(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+	promotions := make([]Promotion, len(rows))
+	for i, row := range rows {
+		promotions[i] = fromRow(row)
+	}
+	return promotions, nil
+}
+
+// ActivePromotion looks up a code and checks its validity window and usage limit. The limit is enforced again
+// when the code is redeemed as concurrent redemptions may race past this check.
+func (s *PromotionServiceImpl) ActivePromotion(ctx context.Context, code string, on time.Time) (Promotion, error) {
+	code = NormalizeCode(code)
+	row, err := s.repo.GetPromotionByCode(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Promotion{}, fmt.Errorf("%w: %q", ErrUnknownPromotion, code)
+	} else if err != nil {
+		return Promotion{}, err
+	}
+	p := fromRow(row)
+	if !p.ActiveOn(on) {
+		return Promotion{}, fmt.Errorf("%w: %s", ErrPromotionInactive, code)
+	}
+	if p.Exhausted() {
+		return Promotion{}, fmt.Errorf("%w: %s", ErrPromotionExhausted, code)
+	}
+	return p, nil
+}
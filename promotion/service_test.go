@@ -0,0 +1,215 @@
+package promotion
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+func TestPromotionService_CreatePromotion(t *testing.T) {
+	now := time.Now()
+	validFrom := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	validUntil := validFrom.AddDate(0, 1, 0)
+	minOrderAmount := commons.NewMoney(2500, "USD")
+	row := PromotionRow{
+		Id:             1,
+		AltId:          uuid.New(),
+		Code:           "SPRING",
+		DiscountType:   "fixed",
+		DiscountValue:  "5.0000",
+		Currency:       "USD",
+		MinOrderAmount: commons.NullMoney{Money: commons.NewMoney(2500, commons.DefaultCurrency), Valid: true},
+		ValidFrom:      validFrom,
+		ValidUntil:     sql.NullTime{Time: validUntil, Valid: true},
+		CreatedBy:      "unit_test",
+		CreatedAt:      now,
+		LastChangedBy:  "unit_test",
+		LastUpdate:     now,
+	}
+	request := CreatePromotionRequest{
+		Code:           " spring ",
+		Discount:       Discount{Type: FixedAmount, Value: "5.00"},
+		Currency:       "usd",
+		MinOrderAmount: &minOrderAmount,
+		ValidFrom:      validFrom,
+		ValidUntil:     &validUntil,
+		CreatedBy:      "unit_test",
+	}
+	testCases := []struct {
+		name     string
+		request  func() CreatePromotionRequest
+		wantErr  error
+		mockFunc func(mockRepo *MockPromotionRepository)
+	}{
+		{
+			name:    "Create Promotion Successfully",
+			request: func() CreatePromotionRequest { return request },
+			mockFunc: func(mockRepo *MockPromotionRepository) {
+				normalized := request
+				normalized.Code, normalized.Currency = "SPRING", "USD"
+				mockRepo.EXPECT().CreatePromotion(gomock.Any(), normalized).Return(row, nil)
+			},
+		},
+		{
+			name:     "No Code",
+			request:  func() CreatePromotionRequest { r := request; r.Code = " "; return r },
+			wantErr:  ErrInvalidPromotion,
+			mockFunc: func(mockRepo *MockPromotionRepository) {},
+		},
+		{
+			name:     "Invalid Discount",
+			request:  func() CreatePromotionRequest { r := request; r.Discount.Value = "0"; return r },
+			wantErr:  ErrInvalidDiscount,
+			mockFunc: func(mockRepo *MockPromotionRepository) {},
+		},
+		{
+			name:     "Ends Before It Starts",
+			request:  func() CreatePromotionRequest { r := request; r.ValidUntil = &validFrom; return r },
+			wantErr:  ErrInvalidPromotion,
+			mockFunc: func(mockRepo *MockPromotionRepository) {},
+		},
+		{
+			name: "Minimum Order In Another Currency",
+			request: func() CreatePromotionRequest {
+				r := request
+				r.MinOrderAmount = &commons.Money{MinorUnits: 2500, Currency: "EUR"}
+				return r
+			},
+			wantErr:  commons.ErrCurrencyMismatch,
+			mockFunc: func(mockRepo *MockPromotionRepository) {},
+		},
+	}
+	controller := gomock.NewController(t)
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := NewMockPromotionRepository(controller)
+			tt.mockFunc(mockRepo)
+			service := NewPromotionService(mockRepo)
+			result, err := service.CreatePromotion(context.Background(), tt.request())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, fromRow(row), result)
+				assert.Equal(t, minOrderAmount, *result.MinOrderAmount)
+			}
+		})
+	}
+}
+
+func TestPromotionService_ActivePromotion(t *testing.T) {
+	validFrom := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	validUntil := validFrom.AddDate(0, 1, 0)
+	row := PromotionRow{
+		Id:            1,
+		AltId:         uuid.New(),
+		Code:          "SPRING",
+		DiscountType:  "percentage",
+		DiscountValue: "10",
+		Currency:      "USD",
+		ValidFrom:     validFrom,
+		ValidUntil:    sql.NullTime{Time: validUntil, Valid: true},
+		MaxUses:       sql.NullInt64{Int64: 2, Valid: true},
+		TimesUsed:     1,
+	}
+	exhausted := row
+	exhausted.TimesUsed = 2
+	testCases := []struct {
+		name     string
+		on       time.Time
+		wantErr  error
+		mockFunc func(mockRepo *MockPromotionRepository)
+	}{
+		{
+			name: "Active",
+			on:   validFrom,
+			mockFunc: func(mockRepo *MockPromotionRepository) {
+				mockRepo.EXPECT().GetPromotionByCode(gomock.Any(), "SPRING").Return(row, nil)
+			},
+		},
+		{
+			name:    "Not Yet Started",
+			on:      validFrom.Add(-time.Second),
+			wantErr: ErrPromotionInactive,
+			mockFunc: func(mockRepo *MockPromotionRepository) {
+				mockRepo.EXPECT().GetPromotionByCode(gomock.Any(), "SPRING").Return(row, nil)
+			},
+		},
+		{
+			name:    "Expired",
+			on:      validUntil,
+			wantErr: ErrPromotionInactive,
+			mockFunc: func(mockRepo *MockPromotionRepository) {
+				mockRepo.EXPECT().GetPromotionByCode(gomock.Any(), "SPRING").Return(row, nil)
+			},
+		},
+		{
+			name:    "Used Up",
+			on:      validFrom,
+			wantErr: ErrPromotionExhausted,
+			mockFunc: func(mockRepo *MockPromotionRepository) {
+				mockRepo.EXPECT().GetPromotionByCode(gomock.Any(), "SPRING").Return(exhausted, nil)
+			},
+		},
+		{
+			name:    "Unknown",
+			on:      validFrom,
+			wantErr: ErrUnknownPromotion,
+			mockFunc: func(mockRepo *MockPromotionRepository) {
+				mockRepo.EXPECT().GetPromotionByCode(gomock.Any(), "SPRING").Return(PromotionRow{}, sql.ErrNoRows)
+			},
+		},
+	}
+	controller := gomock.NewController(t)
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := NewMockPromotionRepository(controller)
+			tt.mockFunc(mockRepo)
+			service := NewPromotionService(mockRepo)
+			result, err := service.ActivePromotion(context.Background(), "spring", tt.on)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, Discount{Type: Percentage, Value: "10"}, result.Discount)
+			}
+		})
+	}
+}
+
+func TestPromotionService_UpdatePromotion(t *testing.T) {
+	validFrom := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	existing := PromotionRow{Id: 1, AltId: uuid.New(), Code: "SPRING", Currency: "USD", ValidFrom: validFrom}
+	controller := gomock.NewController(t)
+
+	t.Run("Keeps Start When Not Given", func(t *testing.T) {
+		mockRepo := NewMockPromotionRepository(controller)
+		request := UpdatePromotionRequest{Id: existing.AltId, Description: "spring sale", LastChangedBy: "unit_test"}
+		expected := request
+		expected.ValidFrom = validFrom
+		mockRepo.EXPECT().GetPromotion(gomock.Any(), existing.AltId).Return(existing, nil)
+		mockRepo.EXPECT().UpdatePromotion(gomock.Any(), expected).Return(existing, nil)
+		_, err := NewPromotionService(mockRepo).UpdatePromotion(context.Background(), request)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo := NewMockPromotionRepository(controller)
+		mockRepo.EXPECT().GetPromotion(gomock.Any(), existing.AltId).Return(PromotionRow{}, sql.ErrNoRows)
+		_, err := NewPromotionService(mockRepo).UpdatePromotion(context.Background(), UpdatePromotionRequest{Id: existing.AltId})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("Invalid Usage Limit", func(t *testing.T) {
+		mockRepo := NewMockPromotionRepository(controller)
+		maxUses := int64(0)
+		mockRepo.EXPECT().GetPromotion(gomock.Any(), existing.AltId).Return(existing, nil)
+		_, err := NewPromotionService(mockRepo).UpdatePromotion(context.Background(), UpdatePromotionRequest{Id: existing.AltId, MaxUses: &maxUses})
+		assert.ErrorIs(t, err, ErrInvalidPromotion)
+	})
+}
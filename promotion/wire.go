@@ -0,0 +1,20 @@
+//go:build wireinject
+// +build wireinject
+
+package promotion
+
+import (
+	"github.com/google/wire"
+	"inventory-service-go/commons"
+)
+
+func InitializePromotionService() (PromotionService, error) {
+	wire.Build(
+		NewPromotionService,
+		NewPromotionRepository,
+		commons.GetDB,
+		wire.Bind(new(PromotionRepository), new(*PromotionRepositoryImpl)),
+		wire.Bind(new(PromotionService), new(*PromotionServiceImpl)),
+	)
+	return nil, nil
+}
@@ -0,0 +1,134 @@
+package ratelimit
+
+import (
+	"cmp"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"inventory-service-go/auth"
+	"inventory-service-go/config"
+	"inventory-service-go/logging"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type group struct {
+	prefix string
+	limit  Limit
+}
+
+// Limiter applies the limits of the settings to the requests, see Middleware.
+type Limiter struct {
+	store Store
+	now   func() time.Time
+	// groups are sorted by the length of their prefix, longest first, the last is the default with an empty prefix
+	groups []group
+	quota  int
+	quotas map[string]int
+}
+
+func New(settings config.RateLimit, store Store) *Limiter {
+	groups := make([]group, 0, len(settings.Groups)+1)
+	for prefix, limit := range settings.Groups {
+		groups = append(groups, group{prefix: prefix, limit: Limit(limit)})
+	}
+	slices.SortFunc(groups, func(a, b group) int {
+		return cmp.Or(cmp.Compare(len(b.prefix), len(a.prefix)), strings.Compare(a.prefix, b.prefix))
+	})
+	groups = append(groups, group{limit: Limit(settings.Default)})
+	return &Limiter{store: store, now: time.Now, groups: groups, quota: settings.DailyQuota, quotas: settings.Quotas}
+}
+
+// group returns the group of the route.
+func (l *Limiter) group(route string) group {
+	for _, g := range l.groups {
+		if strings.HasPrefix(route, g.prefix) {
+			return g
+		}
+	}
+	return l.groups[len(l.groups)-1]
+}
+
+// quotaOf returns the daily quota of the client, 0 when it has none.
+func (l *Limiter) quotaOf(client string) int {
+	if quota, ok := l.quotas[client]; ok {
+		return quota
+	}
+	return l.quota
+}
+
+// Client returns who the request is limited as, the user of its token or, before it has one, its IP. It has to run
+// after the JWT middleware.
+func Client(c echo.Context) (name string, authenticated bool) {
+	if claims, ok := c.Get("user").(*auth.Claims); ok {
+		if claims.Username != "" {
+			return claims.Username, true
+		}
+		if claims.Subject != "" {
+			return claims.Subject, true
+		}
+	}
+	return c.RealIP(), false
+}
+
+// Middleware takes a token from the bucket the client has in the group of the route and counts the request against
+// the daily quota of an authenticated client. It answers 429 Too Many Requests with Retry-After when either is used
+// up, and announces the bucket, or the quota once it is used up, in the RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset headers of the IETF draft. When the store fails the request is let through, an outage of the limits
+// should not become one of the API.
+func (l *Limiter) Middleware(skipper middleware.Skipper) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper != nil && skipper(c) {
+				return next(c)
+			}
+			ctx := c.Request().Context()
+			client, authenticated := Client(c)
+			if g := l.group(c.Path()); g.limit.Rate > 0 {
+				bucket, err := l.store.Take(ctx, g.prefix+" "+client, g.limit)
+				if err != nil {
+					logging.FromContext(ctx).WarnContext(ctx, "Error taking a rate limit token", "error", err)
+					return next(c)
+				}
+				setHeaders(c, g.limit.Burst, int(bucket.Tokens), g.limit.untilFull(bucket.Tokens))
+				if !bucket.Allowed {
+					return tooManyRequests(c, g.limit.untilNext(bucket.Tokens), "rate limit exceeded")
+				}
+			}
+			if quota := l.quotaOf(client); authenticated && quota > 0 {
+				now := l.now()
+				count, err := l.store.Count(ctx, client, day(now))
+				if err != nil {
+					logging.FromContext(ctx).WarnContext(ctx, "Error counting a request against the daily quota", "error", err)
+					return next(c)
+				}
+				if count > quota {
+					untilTomorrow := day(now).Add(24 * time.Hour).Sub(now)
+					setHeaders(c, quota, 0, untilTomorrow)
+					return tooManyRequests(c, untilTomorrow, "daily quota exceeded")
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+func setHeaders(c echo.Context, limit int, remaining int, reset time.Duration) {
+	header := c.Response().Header()
+	header.Set("RateLimit-Limit", strconv.Itoa(limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(remaining))
+	header.Set("RateLimit-Reset", seconds(reset))
+}
+
+func tooManyRequests(c echo.Context, retryAfter time.Duration, message string) error {
+	c.Response().Header().Set(echo.HeaderRetryAfter, seconds(retryAfter))
+	return echo.NewHTTPError(http.StatusTooManyRequests, message)
+}
+
+// seconds rounds up, so a client waiting that long is not turned away again.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(max(d, 0).Seconds())))
+}
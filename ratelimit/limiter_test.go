@@ -0,0 +1,176 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/auth"
+	"inventory-service-go/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// serve routes the requests through the limiter, the user header stands in for the token.
+func serve(limiter *Limiter) *echo.Echo {
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if user := c.Request().Header.Get("User"); user != "" {
+				c.Set("user", &auth.Claims{Username: user})
+			}
+			return next(c)
+		}
+	})
+	e.Use(limiter.Middleware(func(c echo.Context) bool { return c.Path() == "/healthz" }))
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.POST("/api/v1/authorize", ok)
+	e.GET("/api/v1/items", ok)
+	e.GET("/api/v1/invoices", ok)
+	e.GET("/api/v1/invoices/:id", ok)
+	e.GET("/healthz", ok)
+	return e
+}
+
+func request(e *echo.Echo, method string, path string, user string, ip string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = ip + ":1234"
+	if user != "" {
+		req.Header.Set("User", user)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func newLimiter(c *clock, settings config.RateLimit) *Limiter {
+	limiter := New(settings, newMemoryStore(c))
+	limiter.now = c.Now
+	return limiter
+}
+
+func TestLimiter_Middleware(t *testing.T) {
+	c := &clock{now: time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)}
+	e := serve(newLimiter(c, config.RateLimit{
+		Default: config.Limit{Rate: 60, Burst: 2},
+		Groups: map[string]config.Limit{
+			"/api/v1/authorize": {Rate: 6, Burst: 1},
+			"/api/v1/invoices":  {},
+		},
+	}))
+
+	rec := request(e, http.MethodGet, "/api/v1/items", "ann", "192.0.2.1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Reset"))
+	rec = request(e, http.MethodGet, "/api/v1/items", "ann", "192.0.2.1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2", rec.Header().Get("RateLimit-Reset"))
+
+	rec = request(e, http.MethodGet, "/api/v1/items", "ann", "192.0.2.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get(echo.HeaderRetryAfter))
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.Contains(t, rec.Body.String(), "rate limit exceeded")
+
+	// clients are told apart by their token, not their IP
+	rec = request(e, http.MethodGet, "/api/v1/items", "bob", "192.0.2.1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// a group without a rate is not limited
+	for range 5 {
+		rec = request(e, http.MethodGet, "/api/v1/invoices/42", "ann", "192.0.2.1")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+	}
+	// skipped routes neither
+	rec = request(e, http.MethodGet, "/healthz", "", "192.0.2.1")
+	assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+
+	// authorize is limited by IP in a group of its own
+	rec = request(e, http.MethodPost, "/api/v1/authorize", "", "192.0.2.1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+	rec = request(e, http.MethodPost, "/api/v1/authorize", "", "192.0.2.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "10", rec.Header().Get(echo.HeaderRetryAfter))
+	rec = request(e, http.MethodPost, "/api/v1/authorize", "", "192.0.2.2")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	c.now = c.now.Add(time.Second)
+	rec = request(e, http.MethodGet, "/api/v1/items", "ann", "192.0.2.1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestLimiter_DailyQuota(t *testing.T) {
+	c := &clock{now: time.Date(2026, time.October, 19, 23, 0, 0, 0, time.UTC)}
+	e := serve(newLimiter(c, config.RateLimit{
+		DailyQuota: 2,
+		Quotas:     map[string]int{"reporting": 3, "batch": 0},
+	}))
+
+	for range 2 {
+		assert.Equal(t, http.StatusOK, request(e, http.MethodGet, "/api/v1/items", "ann", "192.0.2.1").Code)
+	}
+	rec := request(e, http.MethodGet, "/api/v1/items", "ann", "192.0.2.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "3600", rec.Header().Get(echo.HeaderRetryAfter))
+	assert.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "3600", rec.Header().Get("RateLimit-Reset"))
+	assert.Contains(t, rec.Body.String(), "daily quota exceeded")
+
+	// the quota of a client overrides the default, 0 is none
+	for range 3 {
+		assert.Equal(t, http.StatusOK, request(e, http.MethodGet, "/api/v1/items", "reporting", "192.0.2.1").Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, request(e, http.MethodGet, "/api/v1/items", "reporting", "192.0.2.1").Code)
+	for range 5 {
+		assert.Equal(t, http.StatusOK, request(e, http.MethodGet, "/api/v1/items", "batch", "192.0.2.1").Code)
+	}
+	// requests without a token have no quota
+	for range 5 {
+		assert.Equal(t, http.StatusOK, request(e, http.MethodPost, "/api/v1/authorize", "", "192.0.2.1").Code)
+	}
+
+	// a new day, a new quota
+	c.now = c.now.Add(time.Hour)
+	assert.Equal(t, http.StatusOK, request(e, http.MethodGet, "/api/v1/items", "ann", "192.0.2.1").Code)
+}
+
+// failingStore fails every call.
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Limit) (Bucket, error) {
+	return Bucket{}, errors.New("error")
+}
+
+func (failingStore) Count(context.Context, string, time.Time) (int, error) {
+	return 0, errors.New("error")
+}
+
+func TestLimiter_StoreFailure(t *testing.T) {
+	e := serve(New(config.RateLimit{Default: config.Limit{Rate: 1, Burst: 1}, DailyQuota: 1}, failingStore{}))
+	for range 3 {
+		rec := request(e, http.MethodGet, "/api/v1/items", "ann", "192.0.2.1")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestNew_Groups(t *testing.T) {
+	limiter := New(config.RateLimit{
+		Default: config.Limit{Rate: 1, Burst: 1},
+		Groups: map[string]config.Limit{
+			"/api/v1":          {Rate: 2, Burst: 2},
+			"/api/v1/invoices": {Rate: 3, Burst: 3},
+		},
+	}, NewMemoryStore())
+	assert.Equal(t, Limit{Rate: 3, Burst: 3}, limiter.group("/api/v1/invoices/:id").limit)
+	assert.Equal(t, Limit{Rate: 2, Burst: 2}, limiter.group("/api/v1/items").limit)
+	assert.Equal(t, group{limit: Limit{Rate: 1, Burst: 1}}, limiter.group("/graphql"))
+}
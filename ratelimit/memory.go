@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the stores drop the buckets that are full again and the counts of the days before.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// full is when the bucket is full again, a full bucket is the same as none
+	full time.Time
+}
+
+type count struct {
+	day   time.Time
+	count int
+}
+
+// MemoryStore keeps the buckets and quotas of this replica, every replica limits on its own.
+type MemoryStore struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	buckets map[string]*bucket
+	counts  map[string]count
+	swept   time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{now: time.Now, buckets: map[string]*bucket{}, counts: map[string]count{}}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Bucket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	s.sweep(now)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+	b.tokens = limit.refill(b.tokens, now.Sub(b.updated))
+	b.updated = now
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	b.full = now.Add(limit.untilFull(b.tokens))
+	return Bucket{Allowed: allowed, Tokens: b.tokens}, nil
+}
+
+func (s *MemoryStore) Count(_ context.Context, key string, day time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.counts[key]
+	if !c.day.Equal(day) {
+		c = count{day: day}
+	}
+	c.count++
+	s.counts[key] = c
+	return c.count, nil
+}
+
+// sweep keeps the memory of the store bounded by the clients of the last day.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.swept) < sweepInterval {
+		return
+	}
+	s.swept = now
+	for key, b := range s.buckets {
+		if !b.full.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+	today := day(now)
+	for key, c := range s.counts {
+		if c.day.Before(today) {
+			delete(s.counts, key)
+		}
+	}
+}
+
+// day is the date of t in UTC, the quotas are counted by.
+func day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
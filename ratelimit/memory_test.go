@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// clock is a time the tests move on by hand.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func newMemoryStore(c *clock) *MemoryStore {
+	store := NewMemoryStore()
+	store.now = c.Now
+	return store
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	c := &clock{now: time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)}
+	store := newMemoryStore(c)
+	limit := Limit{Rate: 60, Burst: 3}
+	ctx := context.Background()
+
+	for i := 2; i >= 0; i-- {
+		bucket, err := store.Take(ctx, "ann", limit)
+		assert.NoError(t, err)
+		assert.Equal(t, Bucket{Allowed: true, Tokens: float64(i)}, bucket)
+	}
+	bucket, _ := store.Take(ctx, "ann", limit)
+	assert.False(t, bucket.Allowed)
+
+	// other keys have buckets of their own
+	bucket, _ = store.Take(ctx, "bob", limit)
+	assert.True(t, bucket.Allowed)
+
+	// a token a second
+	c.now = c.now.Add(500 * time.Millisecond)
+	bucket, _ = store.Take(ctx, "ann", limit)
+	assert.False(t, bucket.Allowed)
+	assert.InDelta(t, 0.5, bucket.Tokens, 1e-9)
+	c.now = c.now.Add(500 * time.Millisecond)
+	bucket, _ = store.Take(ctx, "ann", limit)
+	assert.True(t, bucket.Allowed)
+	assert.InDelta(t, 0, bucket.Tokens, 1e-9)
+
+	// never more than the burst
+	c.now = c.now.Add(time.Hour)
+	bucket, _ = store.Take(ctx, "ann", limit)
+	assert.Equal(t, Bucket{Allowed: true, Tokens: 2}, bucket)
+}
+
+func TestMemoryStore_Count(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	today := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	for i := 1; i <= 3; i++ {
+		count, err := store.Count(ctx, "ann", today)
+		assert.NoError(t, err)
+		assert.Equal(t, i, count)
+	}
+	count, _ := store.Count(ctx, "bob", today)
+	assert.Equal(t, 1, count)
+	count, _ = store.Count(ctx, "ann", today.Add(24*time.Hour))
+	assert.Equal(t, 1, count)
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	c := &clock{now: time.Date(2026, time.October, 19, 23, 59, 0, 0, time.UTC)}
+	store := newMemoryStore(c)
+	ctx := context.Background()
+	_, _ = store.Take(ctx, "ann", Limit{Rate: 60, Burst: 3})
+	_, _ = store.Take(ctx, "bob", Limit{Rate: 1, Burst: 3})
+	_, _ = store.Take(ctx, "bob", Limit{Rate: 1, Burst: 3})
+	_, _ = store.Count(ctx, "ann", day(c.now))
+
+	// ann's bucket is full again after a second, bob's only after two minutes
+	c.now = c.now.Add(sweepInterval)
+	_, _ = store.Take(ctx, "carl", Limit{Rate: 60, Burst: 3})
+	assert.NotContains(t, store.buckets, "ann")
+	assert.Contains(t, store.buckets, "bob")
+	assert.Contains(t, store.buckets, "carl")
+	// yesterday's counts are gone
+	assert.Empty(t, store.counts)
+}
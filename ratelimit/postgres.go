@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"inventory-service-go/logging"
+	"inventory-service-go/metrics"
+	"inventory-service-go/tracing"
+	"sync"
+	"time"
+)
+
+const (
+	// TakeQuery refills the bucket by the time since it was last updated, by the clock of the database so the replicas
+	// agree, and takes a token when it holds a whole one. $2 is the burst, $3 the rate a second.
+	TakeQuery = `INSERT INTO rate_limit_buckets AS b (key, tokens, allowed) VALUES ($1, $2::float8 - 1, true)
+ON CONFLICT (key) DO UPDATE SET
+    tokens = CASE WHEN least($2::float8, b.tokens + extract(epoch FROM now() - b.updated_at)::float8 * $3::float8) >= 1
+        THEN least($2::float8, b.tokens + extract(epoch FROM now() - b.updated_at)::float8 * $3::float8) - 1
+        ELSE least($2::float8, b.tokens + extract(epoch FROM now() - b.updated_at)::float8 * $3::float8) END,
+    allowed = least($2::float8, b.tokens + extract(epoch FROM now() - b.updated_at)::float8 * $3::float8) >= 1,
+    updated_at = now()
+RETURNING allowed, tokens`
+	CountQuery = `INSERT INTO rate_limit_quotas (key, day, count) VALUES ($1, $2, 1)
+ON CONFLICT (key, day) DO UPDATE SET count = rate_limit_quotas.count + 1 RETURNING count`
+	// buckets idle for a day are full again for every sensible limit
+	PurgeBucketsQuery = `DELETE FROM rate_limit_buckets WHERE updated_at < now() - interval '1 day'`
+	PurgeQuotasQuery  = `DELETE FROM rate_limit_quotas WHERE day < $1`
+)
+
+// PostgresStore keeps the buckets and quotas in the database, shared by all replicas. A request costs a query, and
+// one more when the quota is counted.
+type PostgresStore struct {
+	db     *sqlx.DB
+	now    func() time.Time
+	mu     sync.Mutex
+	purged time.Time
+}
+
+func NewPostgresStore(db *sqlx.DB) *PostgresStore {
+	return &PostgresStore{db: db, now: time.Now}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, limit Limit) (Bucket, error) {
+	s.purge(ctx)
+	defer metrics.ObserveQuery("ratelimit", "Take")()
+	var bucket Bucket
+	err := s.db.QueryRowxContext(tracing.Statement(ctx, "TakeQuery"), TakeQuery, key, limit.Burst, float64(limit.Rate)/60).
+		Scan(&bucket.Allowed, &bucket.Tokens)
+	return bucket, err
+}
+
+func (s *PostgresStore) Count(ctx context.Context, key string, day time.Time) (int, error) {
+	defer metrics.ObserveQuery("ratelimit", "Count")()
+	var count int
+	err := s.db.GetContext(tracing.Statement(ctx, "CountQuery"), &count, CountQuery, key, day)
+	return count, err
+}
+
+// purge deletes the buckets that are full again and the counts of the days before, at most every sweepInterval by
+// each replica.
+func (s *PostgresStore) purge(ctx context.Context) {
+	s.mu.Lock()
+	now := s.now()
+	due := now.Sub(s.purged) >= sweepInterval
+	if due {
+		s.purged = now
+	}
+	s.mu.Unlock()
+	if !due {
+		return
+	}
+	defer metrics.ObserveQuery("ratelimit", "Purge")()
+	if _, err := s.db.ExecContext(tracing.Statement(ctx, "PurgeBucketsQuery"), PurgeBucketsQuery); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "Error purging the rate limit buckets", "error", err)
+	}
+	if _, err := s.db.ExecContext(tracing.Statement(ctx, "PurgeQuotasQuery"), PurgeQuotasQuery, day(now)); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "Error purging the rate limit quotas", "error", err)
+	}
+}
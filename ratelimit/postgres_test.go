@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPostgresStore_Take(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	c := &clock{now: time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)}
+	store := NewPostgresStore(sqlx.NewDb(db, "mockDb"))
+	store.now = c.Now
+	ctx := context.Background()
+
+	// the first take of a replica purges, a failing purge does not fail it
+	mock.ExpectExec("DELETE FROM rate_limit_buckets").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("DELETE FROM rate_limit_quotas").WithArgs(day(c.now)).WillReturnError(errors.New("error"))
+	mock.ExpectQuery("INSERT INTO rate_limit_buckets").WithArgs("/api/v1/items ann", 10, 2.0).
+		WillReturnRows(sqlmock.NewRows([]string{"allowed", "tokens"}).AddRow(true, 4.5))
+	bucket, err := store.Take(ctx, "/api/v1/items ann", Limit{Rate: 120, Burst: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, Bucket{Allowed: true, Tokens: 4.5}, bucket)
+
+	c.now = c.now.Add(time.Second)
+	mock.ExpectQuery("INSERT INTO rate_limit_buckets").WithArgs("/api/v1/items ann", 10, 2.0).
+		WillReturnRows(sqlmock.NewRows([]string{"allowed", "tokens"}).AddRow(false, 0.25))
+	bucket, err = store.Take(ctx, "/api/v1/items ann", Limit{Rate: 120, Burst: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, Bucket{Tokens: 0.25}, bucket)
+
+	mock.ExpectQuery("INSERT INTO rate_limit_buckets").WillReturnError(errors.New("error"))
+	_, err = store.Take(ctx, "/api/v1/items ann", Limit{Rate: 120, Burst: 10})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_Count(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	store := NewPostgresStore(sqlx.NewDb(db, "mockDb"))
+	today := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("INSERT INTO rate_limit_quotas").WithArgs("ann", today).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+	count, err := store.Count(context.Background(), "ann", today)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, count)
+
+	mock.ExpectQuery("INSERT INTO rate_limit_quotas").WillReturnError(errors.New("error"))
+	_, err = store.Count(context.Background(), "ann", today)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
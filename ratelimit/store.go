@@ -0,0 +1,46 @@
+// Package ratelimit limits the requests of every client with a token bucket per route group and, optionally, a daily
+// quota. The buckets and the quotas are kept by a Store, in memory for a single replica or in Postgres to share them
+// between the replicas.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit is a token bucket holding at most Burst tokens, refilled with Rate tokens a minute. Every request takes a
+// token, a Rate of 0 does not limit.
+type Limit struct {
+	Rate  int
+	Burst int
+}
+
+// refill is the tokens of a bucket elapsed after it held tokens.
+func (l Limit) refill(tokens float64, elapsed time.Duration) float64 {
+	return min(float64(l.Burst), tokens+elapsed.Minutes()*float64(l.Rate))
+}
+
+// untilFull is how long a bucket holding tokens takes to be full again.
+func (l Limit) untilFull(tokens float64) time.Duration {
+	return time.Duration((float64(l.Burst) - tokens) / float64(l.Rate) * float64(time.Minute))
+}
+
+// untilNext is how long a bucket holding tokens takes to hold a whole one.
+func (l Limit) untilNext(tokens float64) time.Duration {
+	return time.Duration((1 - tokens) / float64(l.Rate) * float64(time.Minute))
+}
+
+// Bucket is a token bucket after a request.
+type Bucket struct {
+	// Allowed is whether the request took a token.
+	Allowed bool
+	// Tokens is what is left of the bucket.
+	Tokens float64
+}
+
+type Store interface {
+	// Take takes a token from the bucket of key, created full.
+	Take(ctx context.Context, key string, limit Limit) (Bucket, error)
+	// Count counts a request of key on day, a date in UTC, and returns the requests of key that day so far.
+	Count(ctx context.Context, key string, day time.Time) (int, error)
+}
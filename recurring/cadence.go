@@ -0,0 +1,47 @@
+package recurring
+
+import (
+	"fmt"
+	"inventory-service-go/commons"
+	"time"
+)
+
+type Cadence string
+
+const (
+	Monthly   Cadence = "monthly"
+	Quarterly Cadence = "quarterly"
+	Yearly    Cadence = "yearly"
+)
+
+var ErrInvalidCadence = commons.NewValidationError("cadence must be monthly, quarterly or yearly")
+
+func (c Cadence) months() (int, error) {
+	switch c {
+	case Monthly:
+		return 1, nil
+	case Quarterly:
+		return 3, nil
+	case Yearly:
+		return 12, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCadence, c)
+	}
+}
+
+func (c Cadence) Validate() error {
+	_, err := c.months()
+	return err
+}
+
+// Occurrence is the nth due date counting from start (the 0th), always computed from start so that a schedule
+// starting on the 31st falls on the last day of shorter months and is back on the 31st afterwards.
+func (c Cadence) Occurrence(start commons.Date, n int) (commons.Date, error) {
+	months, err := c.months()
+	if err != nil {
+		return commons.Date{}, err
+	}
+	firstOfMonth := time.Date(start.Year(), start.Month()+time.Month(months*n), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	return commons.NewDate(firstOfMonth.Year(), firstOfMonth.Month(), min(start.Day(), lastDay)), nil
+}
@@ -0,0 +1,38 @@
+package recurring
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+func TestCadence_Occurrence(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cadence Cadence
+		start   commons.Date
+		n       int
+		want    commons.Date
+		wantErr error
+	}{
+		{"Monthly Start", Monthly, commons.NewDate(2026, time.January, 31), 0, commons.NewDate(2026, time.January, 31), nil},
+		{"Monthly Clamped To Month End", Monthly, commons.NewDate(2026, time.January, 31), 1, commons.NewDate(2026, time.February, 28), nil},
+		{"Monthly Back On The 31st", Monthly, commons.NewDate(2026, time.January, 31), 2, commons.NewDate(2026, time.March, 31), nil},
+		{"Monthly Leap Year", Monthly, commons.NewDate(2028, time.January, 30), 1, commons.NewDate(2028, time.February, 29), nil},
+		{"Quarterly Across Year End", Quarterly, commons.NewDate(2026, time.November, 15), 1, commons.NewDate(2027, time.February, 15), nil},
+		{"Yearly From Leap Day", Yearly, commons.NewDate(2028, time.February, 29), 1, commons.NewDate(2029, time.February, 28), nil},
+		{"Invalid Cadence", Cadence("weekly"), commons.NewDate(2026, time.January, 1), 1, commons.Date{}, ErrInvalidCadence},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.cadence.Occurrence(tc.start, tc.n)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
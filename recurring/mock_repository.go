@@ -0,0 +1,224 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source repository.go -destination mock_repository.go -package recurring
+//
+
+// Package recurring is a generated GoMock package.
+package recurring
+
+import (
+	context "context"
+	sql "database/sql"
+	commons "inventory-service-go/commons"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecurringInvoiceRepository is a mock of RecurringInvoiceRepository interface.
+type MockRecurringInvoiceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecurringInvoiceRepositoryMockRecorder
+}
+
+// MockRecurringInvoiceRepositoryMockRecorder is the mock recorder for MockRecurringInvoiceRepository.
+type MockRecurringInvoiceRepositoryMockRecorder struct {
+	mock *MockRecurringInvoiceRepository
+}
+
+// NewMockRecurringInvoiceRepository creates a new mock instance.
+func NewMockRecurringInvoiceRepository(ctrl *gomock.Controller) *MockRecurringInvoiceRepository {
+	mock := &MockRecurringInvoiceRepository{ctrl: ctrl}
+	mock.recorder = &MockRecurringInvoiceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecurringInvoiceRepository) EXPECT() *MockRecurringInvoiceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AdvanceRecurringInvoice mocks base method.
+func (m *MockRecurringInvoiceRepository) AdvanceRecurringInvoice(ctx context.Context, id uuid.UUID, nextOccurrence int, nextRunDate commons.Date) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceRecurringInvoice", ctx, id, nextOccurrence, nextRunDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdvanceRecurringInvoice indicates an expected call of AdvanceRecurringInvoice.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) AdvanceRecurringInvoice(ctx, id, nextOccurrence, nextRunDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceRecurringInvoice", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).AdvanceRecurringInvoice), ctx, id, nextOccurrence, nextRunDate)
+}
+
+// ClaimRun mocks base method.
+func (m *MockRecurringInvoiceRepository) ClaimRun(ctx context.Context, id uuid.UUID, runDate commons.Date, staleBefore time.Time) (RunRow, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimRun", ctx, id, runDate, staleBefore)
+	ret0, _ := ret[0].(RunRow)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ClaimRun indicates an expected call of ClaimRun.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) ClaimRun(ctx, id, runDate, staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimRun", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).ClaimRun), ctx, id, runDate, staleBefore)
+}
+
+// CompleteRun mocks base method.
+func (m *MockRecurringInvoiceRepository) CompleteRun(ctx context.Context, runId uuid.UUID, invoiceId uuid.NullUUID, status string, runErr sql.NullString) (RunRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRun", ctx, runId, invoiceId, status, runErr)
+	ret0, _ := ret[0].(RunRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteRun indicates an expected call of CompleteRun.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) CompleteRun(ctx, runId, invoiceId, status, runErr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRun", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).CompleteRun), ctx, runId, invoiceId, status, runErr)
+}
+
+// CreateRecurringInvoice mocks base method.
+func (m *MockRecurringInvoiceRepository) CreateRecurringInvoice(ctx context.Context, request CreateRecurringInvoiceRequest) (RecurringInvoiceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurringInvoice", ctx, request)
+	ret0, _ := ret[0].(RecurringInvoiceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurringInvoice indicates an expected call of CreateRecurringInvoice.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) CreateRecurringInvoice(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurringInvoice", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).CreateRecurringInvoice), ctx, request)
+}
+
+// DeleteRecurringInvoice mocks base method.
+func (m *MockRecurringInvoiceRepository) DeleteRecurringInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecurringInvoice", ctx, id)
+	ret0, _ := ret[0].(commons.DeleteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRecurringInvoice indicates an expected call of DeleteRecurringInvoice.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) DeleteRecurringInvoice(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecurringInvoice", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).DeleteRecurringInvoice), ctx, id)
+}
+
+// GetDueRecurringInvoices mocks base method.
+func (m *MockRecurringInvoiceRepository) GetDueRecurringInvoices(ctx context.Context, on commons.Date) ([]RecurringInvoiceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueRecurringInvoices", ctx, on)
+	ret0, _ := ret[0].([]RecurringInvoiceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueRecurringInvoices indicates an expected call of GetDueRecurringInvoices.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) GetDueRecurringInvoices(ctx, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueRecurringInvoices", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).GetDueRecurringInvoices), ctx, on)
+}
+
+// GetRecurringInvoice mocks base method.
+func (m *MockRecurringInvoiceRepository) GetRecurringInvoice(ctx context.Context, id uuid.UUID) (RecurringInvoiceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringInvoice", ctx, id)
+	ret0, _ := ret[0].(RecurringInvoiceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringInvoice indicates an expected call of GetRecurringInvoice.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) GetRecurringInvoice(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringInvoice", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).GetRecurringInvoice), ctx, id)
+}
+
+// GetRecurringInvoiceItems mocks base method.
+func (m *MockRecurringInvoiceRepository) GetRecurringInvoiceItems(ctx context.Context, id uuid.UUID) ([]RecurringInvoiceItemRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringInvoiceItems", ctx, id)
+	ret0, _ := ret[0].([]RecurringInvoiceItemRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringInvoiceItems indicates an expected call of GetRecurringInvoiceItems.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) GetRecurringInvoiceItems(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringInvoiceItems", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).GetRecurringInvoiceItems), ctx, id)
+}
+
+// GetRecurringInvoices mocks base method.
+func (m *MockRecurringInvoiceRepository) GetRecurringInvoices(ctx context.Context, pagination *commons.Pagination) ([]RecurringInvoiceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringInvoices", ctx, pagination)
+	ret0, _ := ret[0].([]RecurringInvoiceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringInvoices indicates an expected call of GetRecurringInvoices.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) GetRecurringInvoices(ctx, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringInvoices", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).GetRecurringInvoices), ctx, pagination)
+}
+
+// GetRetryableRuns mocks base method.
+func (m *MockRecurringInvoiceRepository) GetRetryableRuns(ctx context.Context, staleBefore time.Time) ([]RunRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRetryableRuns", ctx, staleBefore)
+	ret0, _ := ret[0].([]RunRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRetryableRuns indicates an expected call of GetRetryableRuns.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) GetRetryableRuns(ctx, staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetryableRuns", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).GetRetryableRuns), ctx, staleBefore)
+}
+
+// GetRuns mocks base method.
+func (m *MockRecurringInvoiceRepository) GetRuns(ctx context.Context, id uuid.UUID) ([]RunRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuns", ctx, id)
+	ret0, _ := ret[0].([]RunRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuns indicates an expected call of GetRuns.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) GetRuns(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuns", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).GetRuns), ctx, id)
+}
+
+// UpdateRecurringInvoice mocks base method.
+func (m *MockRecurringInvoiceRepository) UpdateRecurringInvoice(ctx context.Context, request UpdateRecurringInvoiceRequest) (RecurringInvoiceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecurringInvoice", ctx, request)
+	ret0, _ := ret[0].(RecurringInvoiceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRecurringInvoice indicates an expected call of UpdateRecurringInvoice.
+func (mr *MockRecurringInvoiceRepositoryMockRecorder) UpdateRecurringInvoice(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecurringInvoice", reflect.TypeOf((*MockRecurringInvoiceRepository)(nil).UpdateRecurringInvoice), ctx, request)
+}
@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source service.go -destination mock_service.go -package recurring
+//
+
+// Package recurring is a generated GoMock package.
+package recurring
+
+import (
+	context "context"
+	commons "inventory-service-go/commons"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRecurringInvoiceService is a mock of RecurringInvoiceService interface.
+type MockRecurringInvoiceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecurringInvoiceServiceMockRecorder
+}
+
+// MockRecurringInvoiceServiceMockRecorder is the mock recorder for MockRecurringInvoiceService.
+type MockRecurringInvoiceServiceMockRecorder struct {
+	mock *MockRecurringInvoiceService
+}
+
+// NewMockRecurringInvoiceService creates a new mock instance.
+func NewMockRecurringInvoiceService(ctrl *gomock.Controller) *MockRecurringInvoiceService {
+	mock := &MockRecurringInvoiceService{ctrl: ctrl}
+	mock.recorder = &MockRecurringInvoiceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecurringInvoiceService) EXPECT() *MockRecurringInvoiceServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateRecurringInvoice mocks base method.
+func (m *MockRecurringInvoiceService) CreateRecurringInvoice(ctx context.Context, request CreateRecurringInvoiceRequest) (RecurringInvoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurringInvoice", ctx, request)
+	ret0, _ := ret[0].(RecurringInvoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurringInvoice indicates an expected call of CreateRecurringInvoice.
+func (mr *MockRecurringInvoiceServiceMockRecorder) CreateRecurringInvoice(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurringInvoice", reflect.TypeOf((*MockRecurringInvoiceService)(nil).CreateRecurringInvoice), ctx, request)
+}
+
+// DeleteRecurringInvoice mocks base method.
+func (m *MockRecurringInvoiceService) DeleteRecurringInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecurringInvoice", ctx, id)
+	ret0, _ := ret[0].(commons.DeleteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRecurringInvoice indicates an expected call of DeleteRecurringInvoice.
+func (mr *MockRecurringInvoiceServiceMockRecorder) DeleteRecurringInvoice(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecurringInvoice", reflect.TypeOf((*MockRecurringInvoiceService)(nil).DeleteRecurringInvoice), ctx, id)
+}
+
+// GetRecurringInvoice mocks base method.
+func (m *MockRecurringInvoiceService) GetRecurringInvoice(ctx context.Context, id uuid.UUID) (RecurringInvoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringInvoice", ctx, id)
+	ret0, _ := ret[0].(RecurringInvoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringInvoice indicates an expected call of GetRecurringInvoice.
+func (mr *MockRecurringInvoiceServiceMockRecorder) GetRecurringInvoice(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringInvoice", reflect.TypeOf((*MockRecurringInvoiceService)(nil).GetRecurringInvoice), ctx, id)
+}
+
+// GetRecurringInvoices mocks base method.
+func (m *MockRecurringInvoiceService) GetRecurringInvoices(ctx context.Context, pagination *commons.Pagination) ([]RecurringInvoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringInvoices", ctx, pagination)
+	ret0, _ := ret[0].([]RecurringInvoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringInvoices indicates an expected call of GetRecurringInvoices.
+func (mr *MockRecurringInvoiceServiceMockRecorder) GetRecurringInvoices(ctx, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringInvoices", reflect.TypeOf((*MockRecurringInvoiceService)(nil).GetRecurringInvoices), ctx, pagination)
+}
+
+// GetRuns mocks base method.
+func (m *MockRecurringInvoiceService) GetRuns(ctx context.Context, id uuid.UUID) ([]Run, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuns", ctx, id)
+	ret0, _ := ret[0].([]Run)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuns indicates an expected call of GetRuns.
+func (mr *MockRecurringInvoiceServiceMockRecorder) GetRuns(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuns", reflect.TypeOf((*MockRecurringInvoiceService)(nil).GetRuns), ctx, id)
+}
+
+// RunDue mocks base method.
+func (m *MockRecurringInvoiceService) RunDue(ctx context.Context, on time.Time) ([]Run, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunDue", ctx, on)
+	ret0, _ := ret[0].([]Run)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunDue indicates an expected call of RunDue.
+func (mr *MockRecurringInvoiceServiceMockRecorder) RunDue(ctx, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDue", reflect.TypeOf((*MockRecurringInvoiceService)(nil).RunDue), ctx, on)
+}
+
+// UpdateRecurringInvoice mocks base method.
+func (m *MockRecurringInvoiceService) UpdateRecurringInvoice(ctx context.Context, request UpdateRecurringInvoiceRequest) (RecurringInvoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecurringInvoice", ctx, request)
+	ret0, _ := ret[0].(RecurringInvoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRecurringInvoice indicates an expected call of UpdateRecurringInvoice.
+func (mr *MockRecurringInvoiceServiceMockRecorder) UpdateRecurringInvoice(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecurringInvoice", reflect.TypeOf((*MockRecurringInvoiceService)(nil).UpdateRecurringInvoice), ctx, request)
+}
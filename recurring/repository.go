@@ -0,0 +1,269 @@
+package recurring
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"inventory-service-go/commons"
+	"inventory-service-go/metrics"
+	"inventory-service-go/tracing"
+	"time"
+)
+
+type RecurringInvoiceRow struct {
+	Id               int64        `db:"id"`
+	AltId            uuid.UUID    `db:"alt_id"`
+	UserId           uuid.UUID    `db:"user_id"`
+	Currency         string       `db:"currency"`
+	TaxJurisdiction  string       `db:"tax_jurisdiction"`
+	PricesIncludeTax bool         `db:"prices_include_tax"`
+	Cadence          string       `db:"cadence"`
+	StartDate        commons.Date `db:"start_date"`
+	EndDate          sql.NullTime `db:"end_date"`
+	NextOccurrence   int          `db:"next_occurrence"`
+	NextRunDate      commons.Date `db:"next_run_date"`
+	CreatedBy        string       `db:"created_by"`
+	CreatedAt        time.Time    `db:"created_at"`
+	LastChangedBy    string       `db:"last_changed_by"`
+	LastUpdate       time.Time    `db:"last_update"`
+	TenantId         uuid.UUID    `db:"tenant_id"`
+}
+
+// RecurringInvoiceItemRow is an item billed on every invoice created from the template.
+type RecurringInvoiceItemRow struct {
+	RecurringInvoiceId uuid.UUID `db:"recurring_invoice_id"`
+	ItemId             uuid.UUID `db:"item_id"`
+	Quantity           int64     `db:"quantity"`
+	TenantId           uuid.UUID `db:"tenant_id"`
+}
+
+// RunRow records the invoice created for one due date, the unique due date per template is what keeps re-runs
+// from creating duplicates. The invoice is created with PlannedInvoiceId, InvoiceId is set once it was.
+type RunRow struct {
+	Id                 int64          `db:"id"`
+	AltId              uuid.UUID      `db:"alt_id"`
+	RecurringInvoiceId uuid.UUID      `db:"recurring_invoice_id"`
+	RunDate            commons.Date   `db:"run_date"`
+	InvoiceId          uuid.NullUUID  `db:"invoice_id"`
+	Status             string         `db:"status"`
+	Error              sql.NullString `db:"error"`
+	CreatedAt          time.Time      `db:"created_at"`
+	LastUpdate         time.Time      `db:"last_update"`
+	TenantId           uuid.UUID      `db:"tenant_id"`
+	Attempts           int            `db:"attempts"`
+	ClaimedAt          time.Time      `db:"claimed_at"`
+	PlannedInvoiceId   uuid.UUID      `db:"planned_invoice_id"`
+}
+
+type RecurringInvoiceItem struct {
+	ItemId   uuid.UUID `json:"item_id"`
+	Quantity int64     `json:"quantity"`
+}
+
+type CreateRecurringInvoiceRequest struct {
+	UserId           uuid.UUID              `json:"user_id"`
+	Currency         string                 `json:"currency"`
+	TaxJurisdiction  string                 `json:"tax_jurisdiction"`
+	PricesIncludeTax bool                   `json:"prices_include_tax"`
+	Items            []RecurringInvoiceItem `json:"items"`
+	Cadence          Cadence                `json:"cadence"`
+	StartDate        commons.Date           `json:"start_date"`
+	EndDate          *commons.Date          `json:"end_date,omitempty"`
+	CreatedBy        string                 `json:"created_by" audit:"actor"`
+}
+
+// UpdateRecurringInvoiceRequest changes what future invoices bill and when the schedule ends, the cadence and start
+// date are fixed as past due dates were computed from them.
+type UpdateRecurringInvoiceRequest struct {
+	Id            uuid.UUID              `json:"id"`
+	Items         []RecurringInvoiceItem `json:"items"`
+	EndDate       *commons.Date          `json:"end_date,omitempty"`
+	LastChangedBy string                 `json:"last_changed_by" audit:"actor"`
+}
+
+const (
+	CreateRecurringInvoiceQuery            = `INSERT INTO recurring_invoices (user_id, currency, tax_jurisdiction, prices_include_tax, cadence, start_date, end_date, next_run_date, created_by, last_changed_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $6, $8, $8) RETURNING *`
+	UpdateRecurringInvoiceQuery            = `UPDATE recurring_invoices SET end_date = $2, last_changed_by = $3, last_update = now() WHERE alt_id = $1 RETURNING *`
+	DeleteRecurringInvoiceQuery            = `DELETE FROM recurring_invoices WHERE alt_id = $1`
+	GetRecurringInvoiceQuery               = `SELECT * FROM recurring_invoices WHERE alt_id = $1`
+	GetAllRecurringInvoicesQuery           = `SELECT * FROM recurring_invoices ORDER BY id`
+	GetAllRecurringInvoicesPaginationQuery = `SELECT * FROM recurring_invoices WHERE id > $1 ORDER BY id LIMIT $2`
+	GetDueRecurringInvoicesQuery           = `SELECT * FROM recurring_invoices WHERE next_run_date <= $1 AND (end_date IS NULL OR next_run_date <= end_date) ORDER BY id`
+	AdvanceRecurringInvoiceQuery           = `UPDATE recurring_invoices SET next_occurrence = $2, next_run_date = $3 WHERE alt_id = $1 AND next_occurrence < $2`
+	AddRecurringInvoiceItemsQuery          = `INSERT INTO recurring_invoice_items (recurring_invoice_id, item_id, quantity) VALUES (:recurring_invoice_id, :item_id, :quantity)`
+	DeleteRecurringInvoiceItemsQuery       = `DELETE FROM recurring_invoice_items WHERE recurring_invoice_id = $1`
+	GetRecurringInvoiceItemsQuery          = `SELECT * FROM recurring_invoice_items WHERE recurring_invoice_id = $1 ORDER BY item_id`
+	ClaimRunQuery                          = `INSERT INTO recurring_invoice_runs (recurring_invoice_id, run_date, status) VALUES ($1, $2, 'pending') ON CONFLICT (recurring_invoice_id, run_date) DO UPDATE SET status = 'pending', error = NULL, attempts = recurring_invoice_runs.attempts + 1, claimed_at = now(), last_update = now() WHERE recurring_invoice_runs.status <> 'completed' AND recurring_invoice_runs.claimed_at < $3 AND recurring_invoice_runs.attempts < $4 RETURNING *`
+	CompleteRunQuery                       = `UPDATE recurring_invoice_runs SET invoice_id = $2, status = $3, error = $4, last_update = now() WHERE alt_id = $1 RETURNING *`
+	GetRunsQuery                           = `SELECT * FROM recurring_invoice_runs WHERE recurring_invoice_id = $1 ORDER BY run_date`
+	GetRetryableRunsQuery                  = `SELECT * FROM recurring_invoice_runs WHERE status <> 'completed' AND claimed_at < $1 AND attempts < $2 ORDER BY id`
+)
+
+type RecurringInvoiceRepository interface {
+	CreateRecurringInvoice(ctx context.Context, request CreateRecurringInvoiceRequest) (RecurringInvoiceRow, error)
+	UpdateRecurringInvoice(ctx context.Context, request UpdateRecurringInvoiceRequest) (RecurringInvoiceRow, error)
+	DeleteRecurringInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error)
+	GetRecurringInvoice(ctx context.Context, id uuid.UUID) (RecurringInvoiceRow, error)
+	GetRecurringInvoices(ctx context.Context, pagination *commons.Pagination) ([]RecurringInvoiceRow, error)
+	GetRecurringInvoiceItems(ctx context.Context, id uuid.UUID) ([]RecurringInvoiceItemRow, error)
+	GetDueRecurringInvoices(ctx context.Context, on commons.Date) ([]RecurringInvoiceRow, error)
+	AdvanceRecurringInvoice(ctx context.Context, id uuid.UUID, nextOccurrence int, nextRunDate commons.Date) error
+	ClaimRun(ctx context.Context, id uuid.UUID, runDate commons.Date, staleBefore time.Time) (RunRow, bool, error)
+	CompleteRun(ctx context.Context, runId uuid.UUID, invoiceId uuid.NullUUID, status string, runErr sql.NullString) (RunRow, error)
+	GetRuns(ctx context.Context, id uuid.UUID) ([]RunRow, error)
+	// GetRetryableRuns returns the runs that failed or stayed pending, claimed before staleBefore, of every template.
+	GetRetryableRuns(ctx context.Context, staleBefore time.Time) ([]RunRow, error)
+}
+
+type RecurringInvoiceRepositoryImpl struct {
+	db *commons.TenantDB
+}
+
+func NewRecurringInvoiceRepository(db *sqlx.DB) *RecurringInvoiceRepositoryImpl {
+	return &RecurringInvoiceRepositoryImpl{db: commons.NewTenantDB(db)}
+}
+
+func nullDate(d *commons.Date) sql.NullTime {
+	if d == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: d.Time, Valid: true}
+}
+
+func itemRows(id uuid.UUID, items []RecurringInvoiceItem) []RecurringInvoiceItemRow {
+	rows := make([]RecurringInvoiceItemRow, len(items))
+	for i, item := range items {
+		rows[i] = RecurringInvoiceItemRow{RecurringInvoiceId: id, ItemId: item.ItemId, Quantity: item.Quantity}
+	}
+	return rows
+}
+
+func (r *RecurringInvoiceRepositoryImpl) CreateRecurringInvoice(ctx context.Context, request CreateRecurringInvoiceRequest) (RecurringInvoiceRow, error) {
+	defer metrics.ObserveQuery("recurring", "CreateRecurringInvoice")()
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return RecurringInvoiceRow{}, err
+	}
+	defer tx.Rollback()
+	var row RecurringInvoiceRow
+	err = tx.GetContext(tracing.Statement(ctx, "CreateRecurringInvoiceQuery"), &row, CreateRecurringInvoiceQuery, request.UserId, request.Currency, request.TaxJurisdiction, request.PricesIncludeTax,
+		string(request.Cadence), request.StartDate, nullDate(request.EndDate), request.CreatedBy)
+	if err != nil {
+		return RecurringInvoiceRow{}, err
+	}
+	if _, err = tx.NamedExecContext(tracing.Statement(ctx, "AddRecurringInvoiceItemsQuery"), AddRecurringInvoiceItemsQuery, itemRows(row.AltId, request.Items)); err != nil {
+		return RecurringInvoiceRow{}, err
+	}
+	return row, tx.Commit()
+}
+
+// UpdateRecurringInvoice replaces the items of the template, unless none are given.
+func (r *RecurringInvoiceRepositoryImpl) UpdateRecurringInvoice(ctx context.Context, request UpdateRecurringInvoiceRequest) (RecurringInvoiceRow, error) {
+	defer metrics.ObserveQuery("recurring", "UpdateRecurringInvoice")()
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return RecurringInvoiceRow{}, err
+	}
+	defer tx.Rollback()
+	var row RecurringInvoiceRow
+	if err = tx.GetContext(tracing.Statement(ctx, "UpdateRecurringInvoiceQuery"), &row, UpdateRecurringInvoiceQuery, request.Id, nullDate(request.EndDate), request.LastChangedBy); err != nil {
+		return RecurringInvoiceRow{}, err
+	}
+	if len(request.Items) > 0 {
+		if _, err = tx.ExecContext(tracing.Statement(ctx, "DeleteRecurringInvoiceItemsQuery"), DeleteRecurringInvoiceItemsQuery, request.Id); err != nil {
+			return RecurringInvoiceRow{}, err
+		}
+		if _, err = tx.NamedExecContext(tracing.Statement(ctx, "AddRecurringInvoiceItemsQuery"), AddRecurringInvoiceItemsQuery, itemRows(request.Id, request.Items)); err != nil {
+			return RecurringInvoiceRow{}, err
+		}
+	}
+	return row, tx.Commit()
+}
+
+func (r *RecurringInvoiceRepositoryImpl) DeleteRecurringInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	defer metrics.ObserveQuery("recurring", "DeleteRecurringInvoice")()
+	result, err := r.db.ExecContext(tracing.Statement(ctx, "DeleteRecurringInvoiceQuery"), DeleteRecurringInvoiceQuery, id)
+	if err != nil {
+		return commons.DeleteResult{}, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return commons.DeleteResult{
+		Id:      id,
+		Deleted: rowsAffected > 0,
+	}, nil
+}
+
+func (r *RecurringInvoiceRepositoryImpl) GetRecurringInvoice(ctx context.Context, id uuid.UUID) (RecurringInvoiceRow, error) {
+	defer metrics.ObserveQuery("recurring", "GetRecurringInvoice")()
+	var row RecurringInvoiceRow
+	err := r.db.GetContext(tracing.Statement(ctx, "GetRecurringInvoiceQuery"), &row, GetRecurringInvoiceQuery, id)
+	return row, err
+}
+
+func (r *RecurringInvoiceRepositoryImpl) GetRecurringInvoices(ctx context.Context, pagination *commons.Pagination) ([]RecurringInvoiceRow, error) {
+	defer metrics.ObserveQuery("recurring", "GetRecurringInvoices")()
+	var rows []RecurringInvoiceRow
+	var err error
+	if pagination == nil {
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetAllRecurringInvoicesQuery"), &rows, GetAllRecurringInvoicesQuery)
+	} else {
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetAllRecurringInvoicesPaginationQuery"), &rows, GetAllRecurringInvoicesPaginationQuery, pagination.LastId, pagination.PageSize)
+	}
+	return rows, err
+}
+
+func (r *RecurringInvoiceRepositoryImpl) GetRecurringInvoiceItems(ctx context.Context, id uuid.UUID) ([]RecurringInvoiceItemRow, error) {
+	defer metrics.ObserveQuery("recurring", "GetRecurringInvoiceItems")()
+	var rows []RecurringInvoiceItemRow
+	err := r.db.SelectContext(tracing.Statement(ctx, "GetRecurringInvoiceItemsQuery"), &rows, GetRecurringInvoiceItemsQuery, id)
+	return rows, err
+}
+
+func (r *RecurringInvoiceRepositoryImpl) GetDueRecurringInvoices(ctx context.Context, on commons.Date) ([]RecurringInvoiceRow, error) {
+	defer metrics.ObserveQuery("recurring", "GetDueRecurringInvoices")()
+	var rows []RecurringInvoiceRow
+	err := r.db.SelectContext(tracing.Statement(ctx, "GetDueRecurringInvoicesQuery"), &rows, GetDueRecurringInvoicesQuery, on)
+	return rows, err
+}
+
+// AdvanceRecurringInvoice moves the schedule on to its next due date, never backwards when schedulers overlap.
+func (r *RecurringInvoiceRepositoryImpl) AdvanceRecurringInvoice(ctx context.Context, id uuid.UUID, nextOccurrence int, nextRunDate commons.Date) error {
+	defer metrics.ObserveQuery("recurring", "AdvanceRecurringInvoice")()
+	_, err := r.db.ExecContext(tracing.Statement(ctx, "AdvanceRecurringInvoiceQuery"), AdvanceRecurringInvoiceQuery, id, nextOccurrence, nextRunDate)
+	return err
+}
+
+// ClaimRun records a pending run for the due date, reporting false when the date has already been claimed. A run that
+// failed, or stayed pending, and was claimed before staleBefore is claimed again, up to MaxRunAttempts times.
+func (r *RecurringInvoiceRepositoryImpl) ClaimRun(ctx context.Context, id uuid.UUID, runDate commons.Date, staleBefore time.Time) (RunRow, bool, error) {
+	defer metrics.ObserveQuery("recurring", "ClaimRun")()
+	var row RunRow
+	err := r.db.GetContext(tracing.Statement(ctx, "ClaimRunQuery"), &row, ClaimRunQuery, id, runDate, staleBefore, MaxRunAttempts)
+	if errors.Is(err, sql.ErrNoRows) {
+		return RunRow{}, false, nil
+	}
+	return row, err == nil, err
+}
+
+func (r *RecurringInvoiceRepositoryImpl) CompleteRun(ctx context.Context, runId uuid.UUID, invoiceId uuid.NullUUID, status string, runErr sql.NullString) (RunRow, error) {
+	defer metrics.ObserveQuery("recurring", "CompleteRun")()
+	var row RunRow
+	err := r.db.GetContext(tracing.Statement(ctx, "CompleteRunQuery"), &row, CompleteRunQuery, runId, invoiceId, status, runErr)
+	return row, err
+}
+
+func (r *RecurringInvoiceRepositoryImpl) GetRuns(ctx context.Context, id uuid.UUID) ([]RunRow, error) {
+	defer metrics.ObserveQuery("recurring", "GetRuns")()
+	var rows []RunRow
+	err := r.db.SelectContext(tracing.Statement(ctx, "GetRunsQuery"), &rows, GetRunsQuery, id)
+	return rows, err
+}
+
+func (r *RecurringInvoiceRepositoryImpl) GetRetryableRuns(ctx context.Context, staleBefore time.Time) ([]RunRow, error) {
+	defer metrics.ObserveQuery("recurring", "GetRetryableRuns")()
+	var rows []RunRow
+	err := r.db.SelectContext(tracing.Statement(ctx, "GetRetryableRunsQuery"), &rows, GetRetryableRunsQuery, staleBefore, MaxRunAttempts)
+	return rows, err
+}
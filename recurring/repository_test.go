@@ -0,0 +1,205 @@
+package recurring
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+var recurringInvoiceColumns = []string{"id", "alt_id", "user_id", "currency", "tax_jurisdiction", "prices_include_tax", "cadence", "start_date", "end_date", "next_occurrence", "next_run_date", "created_by", "created_at", "last_changed_by", "last_update"}
+
+var runColumns = []string{"id", "alt_id", "recurring_invoice_id", "run_date", "invoice_id", "status", "error", "created_at", "last_update", "attempts", "claimed_at", "planned_invoice_id"}
+
+func TestRecurringInvoiceRepositoryImpl_CreateRecurringInvoice(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	newUuid := uuid.New()
+	userId := uuid.New()
+	itemId := uuid.New()
+	startDate := commons.NewDate(2026, time.January, 31)
+	request := CreateRecurringInvoiceRequest{
+		UserId:    userId,
+		Currency:  "USD",
+		Items:     []RecurringInvoiceItem{{ItemId: itemId, Quantity: 2}},
+		Cadence:   Monthly,
+		StartDate: startDate,
+		CreatedBy: "unit_test",
+	}
+	testCases := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"Successful Recurring Invoice Creation", false},
+		{"Failed Recurring Invoice Creation", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			expectation := mock.ExpectQuery("INSERT INTO recurring_invoices").
+				WithArgs(userId, "USD", "", false, "monthly", "2026-01-31", nil, "unit_test")
+			if tc.wantErr {
+				expectation.WillReturnError(errors.New("error"))
+				mock.ExpectRollback()
+			} else {
+				expectation.WillReturnRows(sqlmock.NewRows(recurringInvoiceColumns).
+					AddRow(1, newUuid, userId, "USD", "", false, "monthly", startDate.Time, nil, 0, startDate.Time, "unit_test", now, "unit_test", now))
+				mock.ExpectExec("INSERT INTO recurring_invoice_items").
+					WithArgs(newUuid, itemId, int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			r := NewRecurringInvoiceRepository(sqlx.NewDb(db, "mockDb"))
+
+			result, err := r.CreateRecurringInvoice(context.Background(), request)
+			if tc.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, newUuid, result.AltId)
+				assert.Equal(t, startDate, result.NextRunDate)
+				assert.False(t, result.EndDate.Valid)
+			}
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRecurringInvoiceRepositoryImpl_GetDueRecurringInvoices(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	startDate := commons.NewDate(2026, time.January, 31)
+	mock.ExpectQuery("SELECT \\* FROM recurring_invoices WHERE next_run_date <= \\$1").
+		WithArgs("2026-03-01").
+		WillReturnRows(sqlmock.NewRows(recurringInvoiceColumns).
+			AddRow(1, uuid.New(), uuid.New(), "USD", "", false, "monthly", startDate.Time, nil, 1, "2026-02-28", "unit_test", now, "unit_test", now))
+
+	r := NewRecurringInvoiceRepository(sqlx.NewDb(db, "mockDb"))
+
+	rows, err := r.GetDueRecurringInvoices(context.Background(), commons.NewDate(2026, time.March, 1))
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, commons.NewDate(2026, time.February, 28), rows[0].NextRunDate)
+	assert.Equal(t, 1, rows[0].NextOccurrence)
+}
+
+func TestRecurringInvoiceRepositoryImpl_ClaimRun(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	id := uuid.New()
+	runId := uuid.New()
+	runDate := commons.NewDate(2026, time.February, 28)
+	staleBefore := now.Add(-RunLease)
+	testCases := []struct {
+		name        string
+		mockFunc    func()
+		wantClaimed bool
+		wantErr     bool
+	}{
+		{
+			name: "Claimed",
+			mockFunc: func() {
+				mock.ExpectQuery("INSERT INTO recurring_invoice_runs").
+					WithArgs(id, "2026-02-28", staleBefore, MaxRunAttempts).
+					WillReturnRows(sqlmock.NewRows(runColumns).
+						AddRow(1, runId, id, runDate.Time, nil, RunPending, nil, now, now, 1, now, uuid.New()))
+			},
+			wantClaimed: true,
+		},
+		{
+			name: "Already Claimed",
+			mockFunc: func() {
+				mock.ExpectQuery("INSERT INTO recurring_invoice_runs").
+					WithArgs(id, "2026-02-28", staleBefore, MaxRunAttempts).
+					WillReturnRows(sqlmock.NewRows(runColumns))
+			},
+		},
+		{
+			name: "Database Error",
+			mockFunc: func() {
+				mock.ExpectQuery("INSERT INTO recurring_invoice_runs").
+					WithArgs(id, "2026-02-28", staleBefore, MaxRunAttempts).
+					WillReturnError(errors.New("error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockFunc()
+			r := NewRecurringInvoiceRepository(sqlx.NewDb(db, "mockDb"))
+
+			run, claimed, err := r.ClaimRun(context.Background(), id, runDate, staleBefore)
+			assert.Equal(t, tc.wantClaimed, claimed)
+			if tc.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+			if tc.wantClaimed {
+				assert.Equal(t, runId, run.AltId)
+				assert.Equal(t, RunPending, run.Status)
+			}
+		})
+	}
+}
+
+func TestRecurringInvoiceRepositoryImpl_CompleteRun(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	id := uuid.New()
+	runId := uuid.New()
+	invoiceId := uuid.New()
+	mock.ExpectQuery("UPDATE recurring_invoice_runs").
+		WithArgs(runId, uuid.NullUUID{UUID: invoiceId, Valid: true}, RunCompleted, sql.NullString{}).
+		WillReturnRows(sqlmock.NewRows(runColumns).
+			AddRow(1, runId, id, "2026-02-28", invoiceId, RunCompleted, nil, now, now, 1, now, invoiceId))
+
+	r := NewRecurringInvoiceRepository(sqlx.NewDb(db, "mockDb"))
+
+	run, err := r.CompleteRun(context.Background(), runId, uuid.NullUUID{UUID: invoiceId, Valid: true}, RunCompleted, sql.NullString{})
+	assert.Nil(t, err)
+	assert.Equal(t, uuid.NullUUID{UUID: invoiceId, Valid: true}, run.InvoiceId)
+	assert.Equal(t, RunCompleted, run.Status)
+}
+
+func TestRecurringInvoiceRepositoryImpl_GetRetryableRuns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	staleBefore := now.Add(-RunLease)
+	runId := uuid.New()
+	mock.ExpectQuery("SELECT \\* FROM recurring_invoice_runs").
+		WithArgs(staleBefore, MaxRunAttempts).
+		WillReturnRows(sqlmock.NewRows(runColumns).
+			AddRow(1, runId, uuid.New(), "2026-02-28", nil, RunFailed, "error", now, now, 2, staleBefore, uuid.New()))
+
+	r := NewRecurringInvoiceRepository(sqlx.NewDb(db, "mockDb"))
+
+	runs, err := r.GetRetryableRuns(context.Background(), staleBefore)
+	assert.Nil(t, err)
+	assert.Len(t, runs, 1)
+	assert.Equal(t, runId, runs[0].AltId)
+	assert.Equal(t, 2, runs[0].Attempts)
+}
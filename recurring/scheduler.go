@@ -0,0 +1,64 @@
+package recurring
+
+import (
+	"context"
+	"inventory-service-go/commons"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const DefaultInterval = time.Hour
+
+// Scheduler runs the due recurring invoices in-process, once when started to catch up after downtime and then on
+// every tick of the interval.
+type Scheduler struct {
+	service  RecurringInvoiceService
+	interval time.Duration
+	now      func() time.Time
+	stop     chan struct{}
+	done     sync.WaitGroup
+}
+
+func NewScheduler(service RecurringInvoiceService, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Scheduler{service: service, interval: interval, now: time.Now}
+}
+
+func (s *Scheduler) Start() {
+	s.stop = make(chan struct{})
+	s.done.Add(1)
+	go func() {
+		defer s.done.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			s.RunOnce()
+			select {
+			case <-ticker.C:
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop waits for a run in progress to finish.
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.done.Wait()
+}
+
+func (s *Scheduler) RunOnce() {
+	runs, err := s.service.RunDue(commons.AsSystem(context.Background()), s.now())
+	for _, run := range runs {
+		if run.Status == RunFailed {
+			slog.Warn("Recurring invoice failed", "recurring_invoice_id", run.RecurringInvoiceId, "run_date", run.RunDate, "error", run.Error)
+		}
+	}
+	if err != nil {
+		slog.Error("Error running recurring invoices", "error", err)
+	}
+}
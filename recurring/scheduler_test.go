@@ -0,0 +1,41 @@
+package recurring
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestNewScheduler_DefaultInterval(t *testing.T) {
+	s := NewScheduler(nil, 0)
+	assert.Equal(t, DefaultInterval, s.interval)
+}
+
+func TestScheduler_RunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := NewMockRecurringInvoiceService(ctrl)
+	now := time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC)
+	s := NewScheduler(mockService, time.Minute)
+	s.now = func() time.Time { return now }
+
+	mockService.EXPECT().RunDue(gomock.Any(), now).Return([]Run{{Status: RunFailed, Error: "BOOM"}}, errors.New("BOOM"))
+	s.RunOnce()
+}
+
+func TestScheduler_StartStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := NewMockRecurringInvoiceService(ctrl)
+	s := NewScheduler(mockService, time.Hour)
+	ran := make(chan struct{})
+	mockService.EXPECT().RunDue(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, time.Time) ([]Run, error) {
+		close(ran)
+		return nil, nil
+	})
+
+	s.Start()
+	<-ran
+	s.Stop()
+}
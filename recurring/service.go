@@ -0,0 +1,377 @@
+package recurring
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"inventory-service-go/tax"
+	"time"
+)
+
+const (
+	RunPending   = "pending"
+	RunCompleted = "completed"
+	RunFailed    = "failed"
+)
+
+const (
+	// RunLease is how long a run stays claimed. A run still pending after it is taken to have died with its
+	// scheduler, a failed one is tried again after it.
+	RunLease = 15 * time.Minute
+	// MaxRunAttempts is how often a run is tried before it is left failed.
+	MaxRunAttempts = 5
+)
+
+var ErrInvalidRecurringInvoice = commons.NewValidationError("invalid recurring invoice")
+
+// RecurringInvoice is a template invoices are created from on every due date between StartDate and EndDate.
+// NextRunDate is empty once the schedule has ended.
+type RecurringInvoice struct {
+	Seq              int                    `json:"seq"`
+	Id               uuid.UUID              `json:"id"`
+	UserId           uuid.UUID              `json:"user_id"`
+	Currency         string                 `json:"currency"`
+	TaxJurisdiction  string                 `json:"tax_jurisdiction"`
+	PricesIncludeTax bool                   `json:"prices_include_tax"`
+	Items            []RecurringInvoiceItem `json:"items"`
+	Cadence          Cadence                `json:"cadence"`
+	StartDate        commons.Date           `json:"start_date"`
+	EndDate          *commons.Date          `json:"end_date,omitempty"`
+	NextRunDate      *commons.Date          `json:"next_run_date,omitempty"`
+	AuditInfo        commons.AuditInfo      `json:"audit_info"`
+}
+
+type Run struct {
+	Id                 uuid.UUID    `json:"id"`
+	RecurringInvoiceId uuid.UUID    `json:"recurring_invoice_id"`
+	RunDate            commons.Date `json:"run_date"`
+	InvoiceId          *uuid.UUID   `json:"invoice_id,omitempty"`
+	Status             string       `json:"status"`
+	Error              string       `json:"error,omitempty"`
+	Attempts           int          `json:"attempts"`
+	CreatedAt          string       `json:"created_at"`
+}
+
+func fromRow(row RecurringInvoiceRow, items []RecurringInvoiceItemRow) RecurringInvoice {
+	r := RecurringInvoice{
+		Seq:              int(row.Id),
+		Id:               row.AltId,
+		UserId:           row.UserId,
+		Currency:         row.Currency,
+		TaxJurisdiction:  row.TaxJurisdiction,
+		PricesIncludeTax: row.PricesIncludeTax,
+		Items:            []RecurringInvoiceItem{},
+		Cadence:          Cadence(row.Cadence),
+		StartDate:        row.StartDate,
+		AuditInfo: commons.AuditInfo{
+			CreatedBy:      row.CreatedBy,
+			CreatedAt:      row.CreatedAt.Format(time.RFC3339),
+			LastChangedBy:  row.LastChangedBy,
+			LastUpdate:     row.LastUpdate.Format(time.RFC3339),
+			CreatedTime:    row.CreatedAt,
+			LastUpdateTime: row.LastUpdate,
+		},
+	}
+	for _, i := range items {
+		r.Items = append(r.Items, RecurringInvoiceItem{ItemId: i.ItemId, Quantity: i.Quantity})
+	}
+	if row.EndDate.Valid {
+		endDate := commons.DateOf(row.EndDate.Time)
+		r.EndDate = &endDate
+	}
+	if r.EndDate == nil || !row.NextRunDate.After(r.EndDate.Time) {
+		nextRunDate := row.NextRunDate
+		r.NextRunDate = &nextRunDate
+	}
+	return r
+}
+
+func runFromRow(row RunRow) Run {
+	run := Run{
+		Id:                 row.AltId,
+		RecurringInvoiceId: row.RecurringInvoiceId,
+		RunDate:            row.RunDate,
+		Status:             row.Status,
+		Error:              row.Error.String,
+		Attempts:           row.Attempts,
+		CreatedAt:          row.CreatedAt.Format(time.RFC3339),
+	}
+	if row.InvoiceId.Valid {
+		run.InvoiceId = &row.InvoiceId.UUID
+	}
+	return run
+}
+
+type RecurringInvoiceService interface {
+	CreateRecurringInvoice(ctx context.Context, request CreateRecurringInvoiceRequest) (RecurringInvoice, error)
+	UpdateRecurringInvoice(ctx context.Context, request UpdateRecurringInvoiceRequest) (RecurringInvoice, error)
+	DeleteRecurringInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error)
+	GetRecurringInvoice(ctx context.Context, id uuid.UUID) (RecurringInvoice, error)
+	GetRecurringInvoices(ctx context.Context, pagination *commons.Pagination) ([]RecurringInvoice, error)
+	GetRuns(ctx context.Context, id uuid.UUID) ([]Run, error)
+	// RunDue creates the invoices of every due date up to and including the given day that has not been run yet, and
+	// retries the runs that failed or died, see RunLease. Run as the system it runs the templates of every tenant, each
+	// scoped to its tenant.
+	RunDue(ctx context.Context, on time.Time) ([]Run, error)
+}
+
+type RecurringInvoiceServiceImpl struct {
+	repo        RecurringInvoiceRepository
+	invoices    invoice.InvoiceService
+	itemService item.ItemService
+}
+
+func NewRecurringInvoiceService(repo RecurringInvoiceRepository, invoices invoice.InvoiceService, itemService item.ItemService) *RecurringInvoiceServiceImpl {
+	return &RecurringInvoiceServiceImpl{
+		repo:        repo,
+		invoices:    invoices,
+		itemService: itemService,
+	}
+}
+
+// OnChange observes the invoices created from the templates, they are created by an invoice service of its own.
+func (s *RecurringInvoiceServiceImpl) OnChange(hook commons.ChangeHook) {
+	if notifier, ok := s.invoices.(commons.ChangeNotifier); ok {
+		notifier.OnChange(hook)
+	}
+}
+
+func (s *RecurringInvoiceServiceImpl) validateItems(ctx context.Context, items []RecurringInvoiceItem) error {
+	if len(items) == 0 {
+		return fmt.Errorf("%w: no items", ErrInvalidRecurringInvoice)
+	}
+	seen := make(map[uuid.UUID]bool, len(items))
+	for _, i := range items {
+		if i.Quantity <= 0 {
+			return fmt.Errorf("%w: %w", ErrInvalidRecurringInvoice, invoice.ErrInvalidQuantity)
+		}
+		if seen[i.ItemId] {
+			return fmt.Errorf("%w: item %s is listed twice", ErrInvalidRecurringInvoice, i.ItemId)
+		}
+		seen[i.ItemId] = true
+		if _, err := s.itemService.GetItem(ctx, i.ItemId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateEndDate(start commons.Date, end *commons.Date) error {
+	if end != nil && end.Before(start.Time) {
+		return fmt.Errorf("%w: end_date is before start_date", ErrInvalidRecurringInvoice)
+	}
+	return nil
+}
+
+func (s *RecurringInvoiceServiceImpl) CreateRecurringInvoice(ctx context.Context, request CreateRecurringInvoiceRequest) (RecurringInvoice, error) {
+	if err := request.Cadence.Validate(); err != nil {
+		return RecurringInvoice{}, err
+	}
+	currency, err := commons.NormalizeCurrency(request.Currency)
+	if err != nil {
+		return RecurringInvoice{}, err
+	}
+	request.Currency = currency
+	request.TaxJurisdiction = tax.NormalizeJurisdiction(request.TaxJurisdiction)
+	if request.StartDate.IsZero() {
+		request.StartDate = commons.DateOf(time.Now().UTC())
+	}
+	if err := validateEndDate(request.StartDate, request.EndDate); err != nil {
+		return RecurringInvoice{}, err
+	}
+	if err := s.validateItems(ctx, request.Items); err != nil {
+		return RecurringInvoice{}, err
+	}
+	row, err := s.repo.CreateRecurringInvoice(ctx, request)
+	if err != nil {
+		return RecurringInvoice{}, err
+	}
+	return fromRow(row, itemRows(row.AltId, request.Items)), nil
+}
+
+func (s *RecurringInvoiceServiceImpl) UpdateRecurringInvoice(ctx context.Context, request UpdateRecurringInvoiceRequest) (RecurringInvoice, error) {
+	existing, err := s.repo.GetRecurringInvoice(ctx, request.Id)
+	if err != nil {
+		return RecurringInvoice{}, err
+	}
+	if err := validateEndDate(existing.StartDate, request.EndDate); err != nil {
+		return RecurringInvoice{}, err
+	}
+	if len(request.Items) > 0 {
+		if err := s.validateItems(ctx, request.Items); err != nil {
+			return RecurringInvoice{}, err
+		}
+	}
+	if _, err = s.repo.UpdateRecurringInvoice(ctx, request); err != nil {
+		return RecurringInvoice{}, err
+	}
+	return s.GetRecurringInvoice(ctx, request.Id)
+}
+
+func (s *RecurringInvoiceServiceImpl) DeleteRecurringInvoice(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	return s.repo.DeleteRecurringInvoice(ctx, id)
+}
+
+func (s *RecurringInvoiceServiceImpl) GetRecurringInvoice(ctx context.Context, id uuid.UUID) (RecurringInvoice, error) {
+	row, err := s.repo.GetRecurringInvoice(ctx, id)
+	if err != nil {
+		return RecurringInvoice{}, err
+	}
+	items, err := s.repo.GetRecurringInvoiceItems(ctx, id)
+	if err != nil {
+		return RecurringInvoice{}, err
+	}
+	return fromRow(row, items), nil
+}
+
+func (s *RecurringInvoiceServiceImpl) GetRecurringInvoices(ctx context.Context, pagination *commons.Pagination) ([]RecurringInvoice, error) {
+	rows, err := s.repo.GetRecurringInvoices(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]RecurringInvoice, 0, len(rows))
+	for _, row := range rows {
+		items, err := s.repo.GetRecurringInvoiceItems(ctx, row.AltId)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, fromRow(row, items))
+	}
+	return results, nil
+}
+
+func (s *RecurringInvoiceServiceImpl) GetRuns(ctx context.Context, id uuid.UUID) ([]Run, error) {
+	if _, err := s.repo.GetRecurringInvoice(ctx, id); err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.GetRuns(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]Run, 0, len(rows))
+	for _, row := range rows {
+		runs = append(runs, runFromRow(row))
+	}
+	return runs, nil
+}
+
+func (s *RecurringInvoiceServiceImpl) RunDue(ctx context.Context, on time.Time) ([]Run, error) {
+	today := commons.DateOf(on.UTC())
+	staleBefore := on.Add(-RunLease)
+	due, err := s.repo.GetDueRecurringInvoices(ctx, today)
+	if err != nil {
+		return nil, err
+	}
+	runs := []Run{}
+	for _, row := range due {
+		created, err := s.runRecurringInvoice(commons.WithTenant(ctx, row.TenantId), row, today, staleBefore)
+		runs = append(runs, created...)
+		if err != nil {
+			return runs, err
+		}
+	}
+	retryable, err := s.repo.GetRetryableRuns(ctx, staleBefore)
+	if err != nil {
+		return runs, err
+	}
+	for _, run := range retryable {
+		retried, claimed, err := s.retryRun(commons.WithTenant(ctx, run.TenantId), run, staleBefore)
+		if err != nil {
+			return runs, err
+		}
+		if claimed {
+			runs = append(runs, runFromRow(retried))
+		}
+	}
+	return runs, nil
+}
+
+// retryRun claims the run again and creates its invoice, reporting false when another scheduler claimed it first.
+func (s *RecurringInvoiceServiceImpl) retryRun(ctx context.Context, run RunRow, staleBefore time.Time) (RunRow, bool, error) {
+	row, err := s.repo.GetRecurringInvoice(ctx, run.RecurringInvoiceId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return RunRow{}, false, nil
+	}
+	if err != nil {
+		return RunRow{}, false, err
+	}
+	items, err := s.repo.GetRecurringInvoiceItems(ctx, row.AltId)
+	if err != nil {
+		return RunRow{}, false, err
+	}
+	run, claimed, err := s.repo.ClaimRun(ctx, row.AltId, run.RunDate, staleBefore)
+	if err != nil || !claimed {
+		return RunRow{}, false, err
+	}
+	run, err = s.createInvoice(ctx, row, items, run)
+	return run, err == nil, err
+}
+
+// runRecurringInvoice catches up on every due date that has passed, each date is claimed before its invoice is
+// created so a date is never invoiced twice, even when schedulers overlap or a previous run died half-way.
+func (s *RecurringInvoiceServiceImpl) runRecurringInvoice(ctx context.Context, row RecurringInvoiceRow, today commons.Date, staleBefore time.Time) ([]Run, error) {
+	items, err := s.repo.GetRecurringInvoiceItems(ctx, row.AltId)
+	if err != nil {
+		return nil, err
+	}
+	cadence := Cadence(row.Cadence)
+	var runs []Run
+	for n := row.NextOccurrence; ; n++ {
+		runDate, err := cadence.Occurrence(row.StartDate, n)
+		if err != nil {
+			return runs, err
+		}
+		if runDate.After(today.Time) || (row.EndDate.Valid && runDate.After(row.EndDate.Time)) {
+			return runs, s.repo.AdvanceRecurringInvoice(ctx, row.AltId, n, runDate)
+		}
+		run, claimed, err := s.repo.ClaimRun(ctx, row.AltId, runDate, staleBefore)
+		if err != nil {
+			return runs, err
+		}
+		if !claimed {
+			continue
+		}
+		if run, err = s.createInvoice(ctx, row, items, run); err != nil {
+			return runs, err
+		}
+		runs = append(runs, runFromRow(run))
+	}
+}
+
+// createInvoice records a failure to create the invoice on the run rather than returning it, so the remaining due
+// dates and templates still get their invoices. The invoice and its lines are created in one transaction, with the
+// planned id of the run so that a retry finds the invoice an earlier attempt created but did not record.
+func (s *RecurringInvoiceServiceImpl) createInvoice(ctx context.Context, row RecurringInvoiceRow, items []RecurringInvoiceItemRow, run RunRow) (RunRow, error) {
+	invoiceId := uuid.NullUUID{UUID: run.PlannedInvoiceId, Valid: true}
+	if run.Attempts > 1 {
+		_, err := s.invoices.GetInvoice(ctx, run.PlannedInvoiceId, false)
+		if err == nil {
+			return s.repo.CompleteRun(ctx, run.AltId, invoiceId, RunCompleted, sql.NullString{})
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return s.repo.CompleteRun(ctx, run.AltId, uuid.NullUUID{}, RunFailed, sql.NullString{String: err.Error(), Valid: true})
+		}
+	}
+	request := invoice.ItemsToInvoiceRequest{Quantities: map[uuid.UUID]int64{}}
+	for _, i := range items {
+		request.Items = append(request.Items, i.ItemId)
+		request.Quantities[i.ItemId] = i.Quantity
+	}
+	_, err := s.invoices.CreateInvoiceWithItems(ctx, invoice.CreateInvoiceRequest{
+		Id:               run.PlannedInvoiceId,
+		UserId:           row.UserId,
+		Total:            commons.NewMoney(0, row.Currency),
+		TaxJurisdiction:  row.TaxJurisdiction,
+		PricesIncludeTax: row.PricesIncludeTax,
+		CreatedBy:        row.CreatedBy,
+	}, request)
+	if err != nil {
+		return s.repo.CompleteRun(ctx, run.AltId, uuid.NullUUID{}, RunFailed, sql.NullString{String: err.Error(), Valid: true})
+	}
+	return s.repo.CompleteRun(ctx, run.AltId, invoiceId, RunCompleted, sql.NullString{})
+}
@@ -0,0 +1,294 @@
+package recurring
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"testing"
+	"time"
+)
+
+func TestRecurringInvoiceService_CreateRecurringInvoice(t *testing.T) {
+	now := time.Now()
+	userId := uuid.New()
+	itemId := uuid.New()
+	startDate := commons.NewDate(2026, time.January, 31)
+	endDate := commons.NewDate(2026, time.December, 31)
+	row := RecurringInvoiceRow{
+		Id:            1,
+		AltId:         uuid.New(),
+		UserId:        userId,
+		Currency:      "USD",
+		Cadence:       "monthly",
+		StartDate:     startDate,
+		EndDate:       sql.NullTime{Time: endDate.Time, Valid: true},
+		NextRunDate:   startDate,
+		CreatedBy:     "unit_test",
+		CreatedAt:     now,
+		LastChangedBy: "unit_test",
+		LastUpdate:    now,
+	}
+	request := CreateRecurringInvoiceRequest{
+		UserId:    userId,
+		Currency:  "usd",
+		Items:     []RecurringInvoiceItem{{ItemId: itemId, Quantity: 2}},
+		Cadence:   Monthly,
+		StartDate: startDate,
+		EndDate:   &endDate,
+		CreatedBy: "unit_test",
+	}
+	testCases := []struct {
+		name     string
+		request  func() CreateRecurringInvoiceRequest
+		wantErr  error
+		mockFunc func(mockRepo *MockRecurringInvoiceRepository, mockItems *item.MockItemService)
+	}{
+		{
+			name:    "Create Recurring Invoice Successfully",
+			request: func() CreateRecurringInvoiceRequest { return request },
+			mockFunc: func(mockRepo *MockRecurringInvoiceRepository, mockItems *item.MockItemService) {
+				normalized := request
+				normalized.Currency = "USD"
+				mockItems.EXPECT().GetItem(gomock.Any(), itemId).Return(&item.Item{Id: itemId}, nil)
+				mockRepo.EXPECT().CreateRecurringInvoice(gomock.Any(), normalized).Return(row, nil)
+			},
+		},
+		{
+			name:     "Invalid Cadence",
+			request:  func() CreateRecurringInvoiceRequest { r := request; r.Cadence = "weekly"; return r },
+			wantErr:  ErrInvalidCadence,
+			mockFunc: func(*MockRecurringInvoiceRepository, *item.MockItemService) {},
+		},
+		{
+			name: "End Date Before Start Date",
+			request: func() CreateRecurringInvoiceRequest {
+				r := request
+				before := commons.NewDate(2025, time.December, 31)
+				r.EndDate = &before
+				return r
+			},
+			wantErr:  ErrInvalidRecurringInvoice,
+			mockFunc: func(*MockRecurringInvoiceRepository, *item.MockItemService) {},
+		},
+		{
+			name:     "No Items",
+			request:  func() CreateRecurringInvoiceRequest { r := request; r.Items = nil; return r },
+			wantErr:  ErrInvalidRecurringInvoice,
+			mockFunc: func(*MockRecurringInvoiceRepository, *item.MockItemService) {},
+		},
+		{
+			name: "Invalid Quantity",
+			request: func() CreateRecurringInvoiceRequest {
+				r := request
+				r.Items = []RecurringInvoiceItem{{ItemId: itemId, Quantity: 0}}
+				return r
+			},
+			wantErr:  invoice.ErrInvalidQuantity,
+			mockFunc: func(*MockRecurringInvoiceRepository, *item.MockItemService) {},
+		},
+		{
+			name:    "Unknown Item",
+			request: func() CreateRecurringInvoiceRequest { return request },
+			wantErr: sql.ErrNoRows,
+			mockFunc: func(mockRepo *MockRecurringInvoiceRepository, mockItems *item.MockItemService) {
+				mockItems.EXPECT().GetItem(gomock.Any(), itemId).Return(nil, sql.ErrNoRows)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := NewMockRecurringInvoiceRepository(ctrl)
+			mockItems := item.NewMockItemService(ctrl)
+			tc.mockFunc(mockRepo, mockItems)
+			s := NewRecurringInvoiceService(mockRepo, invoice.NewMockInvoiceService(ctrl), mockItems)
+
+			result, err := s.CreateRecurringInvoice(context.Background(), tc.request())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, row.AltId, result.Id)
+			assert.Equal(t, []RecurringInvoiceItem{{ItemId: itemId, Quantity: 2}}, result.Items)
+			assert.Equal(t, &startDate, result.NextRunDate)
+			assert.Equal(t, &endDate, result.EndDate)
+		})
+	}
+}
+
+func TestRecurringInvoiceService_RunDue(t *testing.T) {
+	userId := uuid.New()
+	itemId := uuid.New()
+	startDate := commons.NewDate(2026, time.January, 31)
+	row := RecurringInvoiceRow{
+		Id:          1,
+		AltId:       uuid.New(),
+		UserId:      userId,
+		Currency:    "USD",
+		Cadence:     "monthly",
+		StartDate:   startDate,
+		NextRunDate: startDate,
+		CreatedBy:   "unit_test",
+	}
+	items := []RecurringInvoiceItemRow{{RecurringInvoiceId: row.AltId, ItemId: itemId, Quantity: 3}}
+	createRequest := func(claimed RunRow) invoice.CreateInvoiceRequest {
+		return invoice.CreateInvoiceRequest{Id: claimed.PlannedInvoiceId, UserId: userId, Total: commons.NewMoney(0, "USD"), CreatedBy: "unit_test"}
+	}
+	itemsRequest := invoice.ItemsToInvoiceRequest{Items: []uuid.UUID{itemId}, Quantities: map[uuid.UUID]int64{itemId: 3}}
+	today := time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC)
+	staleBefore := today.Add(-RunLease)
+	january, february := commons.NewDate(2026, time.January, 31), commons.NewDate(2026, time.February, 28)
+	march := commons.NewDate(2026, time.March, 31)
+
+	run := func(runDate commons.Date) RunRow {
+		return RunRow{AltId: uuid.New(), RecurringInvoiceId: row.AltId, RunDate: runDate, Status: RunPending, Attempts: 1, PlannedInvoiceId: uuid.New()}
+	}
+	expectInvoice := func(mockInvoices *invoice.MockInvoiceService, claimed RunRow) {
+		mockInvoices.EXPECT().CreateInvoiceWithItems(gomock.Any(), createRequest(claimed), itemsRequest).Return(invoice.Invoice{Id: claimed.PlannedInvoiceId}, nil)
+	}
+	complete := func(mockRepo *MockRecurringInvoiceRepository, claimed RunRow, invoiceId uuid.UUID, status string, runErr sql.NullString) {
+		completed := claimed
+		completed.InvoiceId, completed.Status, completed.Error = uuid.NullUUID{UUID: invoiceId, Valid: invoiceId != uuid.Nil}, status, runErr
+		mockRepo.EXPECT().CompleteRun(gomock.Any(), claimed.AltId, completed.InvoiceId, status, runErr).Return(completed, nil)
+	}
+
+	testCases := []struct {
+		name       string
+		mockFunc   func(mockRepo *MockRecurringInvoiceRepository, mockInvoices *invoice.MockInvoiceService)
+		wantStatus []string
+		wantErr    bool
+	}{
+		{
+			name: "Catches Up On Every Missed Due Date",
+			mockFunc: func(mockRepo *MockRecurringInvoiceRepository, mockInvoices *invoice.MockInvoiceService) {
+				mockRepo.EXPECT().GetDueRecurringInvoices(gomock.Any(), commons.NewDate(2026, time.March, 5)).Return([]RecurringInvoiceRow{row}, nil)
+				mockRepo.EXPECT().GetRecurringInvoiceItems(gomock.Any(), row.AltId).Return(items, nil)
+				for _, runDate := range []commons.Date{january, february} {
+					claimed := run(runDate)
+					mockRepo.EXPECT().ClaimRun(gomock.Any(), row.AltId, runDate, staleBefore).Return(claimed, true, nil)
+					expectInvoice(mockInvoices, claimed)
+					complete(mockRepo, claimed, claimed.PlannedInvoiceId, RunCompleted, sql.NullString{})
+				}
+				mockRepo.EXPECT().AdvanceRecurringInvoice(gomock.Any(), row.AltId, 2, march).Return(nil)
+				mockRepo.EXPECT().GetRetryableRuns(gomock.Any(), staleBefore).Return(nil, nil)
+			},
+			wantStatus: []string{RunCompleted, RunCompleted},
+		},
+		{
+			name: "Skips Due Dates Already Claimed",
+			mockFunc: func(mockRepo *MockRecurringInvoiceRepository, mockInvoices *invoice.MockInvoiceService) {
+				mockRepo.EXPECT().GetDueRecurringInvoices(gomock.Any(), commons.NewDate(2026, time.March, 5)).Return([]RecurringInvoiceRow{row}, nil)
+				mockRepo.EXPECT().GetRecurringInvoiceItems(gomock.Any(), row.AltId).Return(items, nil)
+				mockRepo.EXPECT().ClaimRun(gomock.Any(), row.AltId, january, staleBefore).Return(RunRow{}, false, nil)
+				claimed := run(february)
+				mockRepo.EXPECT().ClaimRun(gomock.Any(), row.AltId, february, staleBefore).Return(claimed, true, nil)
+				expectInvoice(mockInvoices, claimed)
+				complete(mockRepo, claimed, claimed.PlannedInvoiceId, RunCompleted, sql.NullString{})
+				mockRepo.EXPECT().AdvanceRecurringInvoice(gomock.Any(), row.AltId, 2, march).Return(nil)
+				mockRepo.EXPECT().GetRetryableRuns(gomock.Any(), staleBefore).Return(nil, nil)
+			},
+			wantStatus: []string{RunCompleted},
+		},
+		{
+			name: "Records Failed Invoice Creation",
+			mockFunc: func(mockRepo *MockRecurringInvoiceRepository, mockInvoices *invoice.MockInvoiceService) {
+				ended := row
+				ended.EndDate = sql.NullTime{Time: january.Time, Valid: true}
+				mockRepo.EXPECT().GetDueRecurringInvoices(gomock.Any(), commons.NewDate(2026, time.March, 5)).Return([]RecurringInvoiceRow{ended}, nil)
+				mockRepo.EXPECT().GetRecurringInvoiceItems(gomock.Any(), row.AltId).Return(items, nil)
+				claimed := run(january)
+				mockRepo.EXPECT().ClaimRun(gomock.Any(), row.AltId, january, staleBefore).Return(claimed, true, nil)
+				mockInvoices.EXPECT().CreateInvoiceWithItems(gomock.Any(), createRequest(claimed), itemsRequest).Return(invoice.Invoice{}, errors.New("BOOM"))
+				complete(mockRepo, claimed, uuid.Nil, RunFailed, sql.NullString{String: "BOOM", Valid: true})
+				mockRepo.EXPECT().AdvanceRecurringInvoice(gomock.Any(), row.AltId, 1, february).Return(nil)
+				mockRepo.EXPECT().GetRetryableRuns(gomock.Any(), staleBefore).Return(nil, nil)
+			},
+			wantStatus: []string{RunFailed},
+		},
+		{
+			name: "Retries Failed Run",
+			mockFunc: func(mockRepo *MockRecurringInvoiceRepository, mockInvoices *invoice.MockInvoiceService) {
+				failed := run(january)
+				failed.Status, failed.TenantId = RunFailed, uuid.New()
+				mockRepo.EXPECT().GetDueRecurringInvoices(gomock.Any(), commons.NewDate(2026, time.March, 5)).Return(nil, nil)
+				mockRepo.EXPECT().GetRetryableRuns(gomock.Any(), staleBefore).Return([]RunRow{failed}, nil)
+				mockRepo.EXPECT().GetRecurringInvoice(gomock.Any(), row.AltId).Return(row, nil)
+				mockRepo.EXPECT().GetRecurringInvoiceItems(gomock.Any(), row.AltId).Return(items, nil)
+				claimed := failed
+				claimed.Status, claimed.Attempts = RunPending, 2
+				mockRepo.EXPECT().ClaimRun(gomock.Any(), row.AltId, january, staleBefore).Return(claimed, true, nil)
+				mockInvoices.EXPECT().GetInvoice(gomock.Any(), claimed.PlannedInvoiceId, false).Return(invoice.Invoice{}, sql.ErrNoRows)
+				expectInvoice(mockInvoices, claimed)
+				complete(mockRepo, claimed, claimed.PlannedInvoiceId, RunCompleted, sql.NullString{})
+			},
+			wantStatus: []string{RunCompleted},
+		},
+		{
+			name: "Completes Retried Run Whose Invoice Exists",
+			mockFunc: func(mockRepo *MockRecurringInvoiceRepository, mockInvoices *invoice.MockInvoiceService) {
+				stale := run(january)
+				mockRepo.EXPECT().GetDueRecurringInvoices(gomock.Any(), commons.NewDate(2026, time.March, 5)).Return(nil, nil)
+				mockRepo.EXPECT().GetRetryableRuns(gomock.Any(), staleBefore).Return([]RunRow{stale}, nil)
+				mockRepo.EXPECT().GetRecurringInvoice(gomock.Any(), row.AltId).Return(row, nil)
+				mockRepo.EXPECT().GetRecurringInvoiceItems(gomock.Any(), row.AltId).Return(items, nil)
+				claimed := stale
+				claimed.Attempts = 2
+				mockRepo.EXPECT().ClaimRun(gomock.Any(), row.AltId, january, staleBefore).Return(claimed, true, nil)
+				mockInvoices.EXPECT().GetInvoice(gomock.Any(), claimed.PlannedInvoiceId, false).Return(invoice.Invoice{Id: claimed.PlannedInvoiceId}, nil)
+				complete(mockRepo, claimed, claimed.PlannedInvoiceId, RunCompleted, sql.NullString{})
+			},
+			wantStatus: []string{RunCompleted},
+		},
+		{
+			name: "Claim Error",
+			mockFunc: func(mockRepo *MockRecurringInvoiceRepository, mockInvoices *invoice.MockInvoiceService) {
+				mockRepo.EXPECT().GetDueRecurringInvoices(gomock.Any(), commons.NewDate(2026, time.March, 5)).Return([]RecurringInvoiceRow{row}, nil)
+				mockRepo.EXPECT().GetRecurringInvoiceItems(gomock.Any(), row.AltId).Return(items, nil)
+				mockRepo.EXPECT().ClaimRun(gomock.Any(), row.AltId, january, staleBefore).Return(RunRow{}, false, errors.New("BOOM"))
+			},
+			wantStatus: []string{},
+			wantErr:    true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := NewMockRecurringInvoiceRepository(ctrl)
+			mockInvoices := invoice.NewMockInvoiceService(ctrl)
+			tc.mockFunc(mockRepo, mockInvoices)
+			s := NewRecurringInvoiceService(mockRepo, mockInvoices, item.NewMockItemService(ctrl))
+
+			runs, err := s.RunDue(context.Background(), today)
+			if tc.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+			statuses := []string{}
+			for _, r := range runs {
+				statuses = append(statuses, r.Status)
+			}
+			assert.Equal(t, tc.wantStatus, statuses)
+		})
+	}
+}
+
+func TestFromRow_EndedSchedule(t *testing.T) {
+	startDate := commons.NewDate(2026, time.January, 31)
+	row := RecurringInvoiceRow{
+		Cadence:     "monthly",
+		StartDate:   startDate,
+		EndDate:     sql.NullTime{Time: startDate.Time, Valid: true},
+		NextRunDate: commons.NewDate(2026, time.February, 28),
+	}
+	result := fromRow(row, nil)
+	assert.Nil(t, result.NextRunDate)
+	assert.Equal(t, []RecurringInvoiceItem{}, result.Items)
+}
@@ -0,0 +1,49 @@
+//go:build wireinject
+// +build wireinject
+
+package recurring
+
+import (
+	"github.com/google/wire"
+	"inventory-service-go/cache"
+	"inventory-service-go/commons"
+	"inventory-service-go/config"
+	"inventory-service-go/currency"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"inventory-service-go/promotion"
+	"inventory-service-go/tax"
+)
+
+func InitializeRecurringInvoiceService(items cache.Config, numbers config.InvoiceNumbers) (RecurringInvoiceService, error) {
+	wire.Build(
+		NewRecurringInvoiceService,
+		NewRecurringInvoiceRepository,
+		invoice.ProvideInvoiceService,
+		invoice.NewFromConfig,
+		invoice.NewInvoiceRepository,
+		item.ProvideItemService,
+		item.NewItemService,
+		item.NewItemRepository,
+		currency.NewExchangeRateService,
+		currency.NewExchangeRateRepository,
+		tax.NewTaxRateService,
+		tax.NewTaxRateRepository,
+		promotion.NewPromotionService,
+		promotion.NewPromotionRepository,
+		cache.GetBus,
+		commons.GetDB,
+		wire.Bind(new(RecurringInvoiceRepository), new(*RecurringInvoiceRepositoryImpl)),
+		wire.Bind(new(RecurringInvoiceService), new(*RecurringInvoiceServiceImpl)),
+		wire.Bind(new(invoice.InvoiceRepository), new(*invoice.InvoiceRepositoryImpl)),
+		wire.Bind(new(item.ItemRepository), new(*item.ItemRepositoryImpl)),
+		wire.Bind(new(cache.Invalidator), new(*cache.Bus)),
+		wire.Bind(new(currency.ExchangeRateRepository), new(*currency.ExchangeRateRepositoryImpl)),
+		wire.Bind(new(currency.Converter), new(*currency.ExchangeRateServiceImpl)),
+		wire.Bind(new(tax.TaxRateRepository), new(*tax.TaxRateRepositoryImpl)),
+		wire.Bind(new(tax.RateProvider), new(*tax.TaxRateServiceImpl)),
+		wire.Bind(new(promotion.PromotionRepository), new(*promotion.PromotionRepositoryImpl)),
+		wire.Bind(new(promotion.Validator), new(*promotion.PromotionServiceImpl)),
+	)
+	return nil, nil
+}
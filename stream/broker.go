@@ -0,0 +1,223 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"inventory-service-go/person"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBufferSize is how many recent events are kept to resume a stream from its Last-Event-ID.
+	DefaultBufferSize = 1024
+	// subscriberBuffer is how far a subscriber may fall behind before it is dropped, the client reconnects and
+	// resumes from the buffer.
+	subscriberBuffer = 64
+)
+
+// ActionReset tells the client it missed changes that can no longer be replayed and has to reload.
+const ActionReset = "reset"
+
+var (
+	Topics = []string{person.ChangeTopic, item.ChangeTopic, invoice.ChangeTopic}
+
+	ErrUnknownTopic = commons.NewValidationError("topic must be persons, items or invoices")
+)
+
+// Event is a change as it is pushed to the clients. Id is only meaningful to Last-Event-ID, it restarts with the
+// process. Tenant is only delivered to the subscribers of the tenant.
+type Event struct {
+	Id         string          `json:"id,omitempty"`
+	Topic      string          `json:"topic,omitempty"`
+	Action     string          `json:"action"`
+	EntityId   uuid.UUID       `json:"entity_id"`
+	Data       json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Tenant     uuid.UUID       `json:"-"`
+}
+
+// Subscription receives the events of its tenant and topics until it is unsubscribed or falls too far behind, in which case
+// Events is closed.
+type Subscription struct {
+	tenant uuid.UUID
+	topics []string
+	events chan Event
+}
+
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) wants(e Event) bool {
+	return e.Tenant == s.tenant && slices.Contains(s.topics, e.Topic)
+}
+
+// Broker fans the changes of the services out to the stream subscribers and keeps the most recent ones to replay.
+type Broker struct {
+	mu          sync.Mutex
+	epoch       string
+	seq         uint64
+	size        int
+	buffer      []Event
+	subscribers map[*Subscription]struct{}
+	now         func() time.Time
+}
+
+func NewBroker(size int) *Broker {
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+	return &Broker{
+		// the epoch keeps ids handed out before a restart from resuming against the new sequence
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		size:        size,
+		subscribers: map[*Subscription]struct{}{},
+		now:         time.Now,
+	}
+}
+
+// Observe publishes the changes of every service that notifies them.
+func (b *Broker) Observe(services ...any) {
+	for _, service := range services {
+		if notifier, ok := service.(commons.ChangeNotifier); ok {
+			notifier.OnChange(b.Publish)
+		}
+	}
+}
+
+// ParseTopics reads a comma separated list of topics, all topics when it is empty.
+func ParseTopics(values ...string) ([]string, error) {
+	var topics []string
+	for _, value := range values {
+		for _, topic := range strings.Split(value, ",") {
+			topic = strings.ToLower(strings.TrimSpace(topic))
+			if topic == "" || slices.Contains(topics, topic) {
+				continue
+			}
+			if !slices.Contains(Topics, topic) {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownTopic, topic)
+			}
+			topics = append(topics, topic)
+		}
+	}
+	if len(topics) == 0 {
+		return Topics, nil
+	}
+	return topics, nil
+}
+
+// Publish is a commons.ChangeHook.
+func (b *Broker) Publish(change commons.Change) {
+	var data json.RawMessage
+	if change.Data != nil {
+		var err error
+		if data, err = json.Marshal(change.Data); err != nil {
+			slog.Error("Error encoding the change", "topic", change.Topic, "id", change.Id, "error", err)
+			return
+		}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.seq++
+	e := Event{
+		Id:         b.eventId(b.seq),
+		Topic:      change.Topic,
+		Action:     change.Action,
+		EntityId:   change.Id,
+		Data:       data,
+		OccurredAt: b.now().UTC(),
+		Tenant:     change.Tenant,
+	}
+	if len(b.buffer) == b.size {
+		b.buffer = slices.Delete(b.buffer, 0, 1)
+	}
+	b.buffer = append(b.buffer, e)
+	for s := range b.subscribers {
+		if !s.wants(e) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			b.drop(s)
+		}
+	}
+}
+
+// Subscribe starts delivering the events of the tenant in the topics. The buffered events after lastEventId are returned to be
+// sent first, reset is set when some of them were already dropped from the buffer or lastEventId is from before a
+// restart.
+func (b *Broker) Subscribe(tenant uuid.UUID, topics []string, lastEventId string) (s *Subscription, replay []Event, reset bool) {
+	s = &Subscription{tenant: tenant, topics: topics, events: make(chan Event, subscriberBuffer)}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[s] = struct{}{}
+	if lastEventId == "" {
+		return s, nil, false
+	}
+	seq, ok := b.parseEventId(lastEventId)
+	if !ok || seq > b.seq {
+		return s, nil, true
+	}
+	reset = len(b.buffer) > 0 && b.seqOf(b.buffer[0]) > seq+1
+	for _, e := range b.buffer {
+		if b.seqOf(e) > seq && s.wants(e) {
+			replay = append(replay, e)
+		}
+	}
+	return s, replay, reset
+}
+
+func (b *Broker) Unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscribers[s]; ok {
+		b.drop(s)
+	}
+}
+
+// Close ends every subscription, on shutdown, as the server does not wait for the streams to end on their own.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subscribers {
+		b.drop(s)
+	}
+}
+
+func (b *Broker) drop(s *Subscription) {
+	delete(b.subscribers, s)
+	close(s.events)
+}
+
+func (b *Broker) eventId(seq uint64) string {
+	return b.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+func (b *Broker) parseEventId(id string) (uint64, bool) {
+	epoch, seq, found := strings.Cut(id, "-")
+	if !found || epoch != b.epoch {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	return n, err == nil
+}
+
+func (b *Broker) seqOf(e Event) uint64 {
+	n, _ := b.parseEventId(e.Id)
+	return n
+}
+
+// ResetEvent is sent to clients whose Last-Event-ID can no longer be resumed from.
+func (b *Broker) ResetEvent() Event {
+	return Event{Action: ActionReset, OccurredAt: b.now().UTC()}
+}
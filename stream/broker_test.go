@@ -0,0 +1,132 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"inventory-service-go/invoice"
+	"inventory-service-go/item"
+	"inventory-service-go/person"
+	"testing"
+)
+
+var tenant = uuid.New()
+
+func change(topic string) commons.Change {
+	return commons.Change{Topic: topic, Action: commons.ChangeUpdated, Id: uuid.New(), Data: map[string]string{"name": topic}, Tenant: tenant}
+}
+
+func TestParseTopics(t *testing.T) {
+	topics, err := ParseTopics()
+	assert.NoError(t, err)
+	assert.Equal(t, Topics, topics)
+
+	topics, err = ParseTopics("Items, invoices", "items")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{item.ChangeTopic, invoice.ChangeTopic}, topics)
+
+	_, err = ParseTopics("items,stock")
+	assert.True(t, errors.Is(err, ErrUnknownTopic))
+}
+
+func TestBroker_Publish(t *testing.T) {
+	b := NewBroker(10)
+	items, _, _ := b.Subscribe(tenant, []string{item.ChangeTopic}, "")
+	all, _, _ := b.Subscribe(tenant, Topics, "")
+	other, _, _ := b.Subscribe(uuid.New(), Topics, "")
+
+	b.Publish(change(person.ChangeTopic))
+	c := change(item.ChangeTopic)
+	b.Publish(c)
+
+	e := <-items.Events()
+	assert.Equal(t, item.ChangeTopic, e.Topic)
+	assert.Equal(t, commons.ChangeUpdated, e.Action)
+	assert.Equal(t, c.Id, e.EntityId)
+	assert.JSONEq(t, `{"name":"items"}`, string(e.Data))
+	assert.Len(t, items.Events(), 0)
+	assert.Len(t, all.Events(), 2)
+	assert.Len(t, other.Events(), 0)
+
+	b.Unsubscribe(items)
+	_, ok := <-items.Events()
+	assert.False(t, ok)
+	b.Unsubscribe(items)
+}
+
+func TestBroker_Publish_DropsSlowSubscriber(t *testing.T) {
+	b := NewBroker(10)
+	s, _, _ := b.Subscribe(tenant, Topics, "")
+	for i := 0; i <= subscriberBuffer; i++ {
+		b.Publish(change(item.ChangeTopic))
+	}
+	received := 0
+	for range s.Events() {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received)
+}
+
+func TestBroker_Close(t *testing.T) {
+	b := NewBroker(10)
+	s1, _, _ := b.Subscribe(tenant, Topics, "")
+	s2, _, _ := b.Subscribe(tenant, []string{item.ChangeTopic}, "")
+	b.Close()
+	for _, s := range []*Subscription{s1, s2} {
+		_, ok := <-s.Events()
+		assert.False(t, ok)
+	}
+	// unsubscribing a closed subscription is a no-op
+	b.Unsubscribe(s1)
+}
+
+func TestBroker_Subscribe_Resume(t *testing.T) {
+	b := NewBroker(3)
+	var ids []string
+	for _, topic := range []string{item.ChangeTopic, person.ChangeTopic, item.ChangeTopic, item.ChangeTopic} {
+		b.Publish(change(topic))
+		ids = append(ids, b.buffer[len(b.buffer)-1].Id)
+	}
+
+	tests := []struct {
+		name        string
+		topics      []string
+		lastEventId string
+		want        []string
+		wantReset   bool
+	}{
+		{"No Last-Event-ID", Topics, "", nil, false},
+		{"Resumed", Topics, ids[1], ids[2:], false},
+		{"Resumed Filtered", []string{person.ChangeTopic}, ids[1], nil, false},
+		{"Up To Date", Topics, ids[3], nil, false},
+		{"Dropped From Buffer", Topics, b.eventId(0), ids[1:], true},
+		{"Before Restart", Topics, "0-1", nil, true},
+		{"Malformed", Topics, "foo", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, replay, reset := b.Subscribe(tenant, tt.topics, tt.lastEventId)
+			defer b.Unsubscribe(s)
+			var got []string
+			for _, e := range replay {
+				got = append(got, e.Id)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantReset, reset)
+		})
+	}
+}
+
+func TestBroker_Observe(t *testing.T) {
+	b := NewBroker(10)
+	service := item.NewItemService(nil)
+	b.Observe(service, "not a notifier")
+	service.Notify(commons.WithTenant(context.Background(), tenant), change(item.ChangeTopic))
+	assert.Len(t, b.buffer, 1)
+	assert.Equal(t, tenant, b.buffer[0].Tenant)
+	// by the system
+	service.Notify(context.Background(), change(item.ChangeTopic))
+	assert.Equal(t, commons.DefaultTenant, b.buffer[1].Tenant)
+}
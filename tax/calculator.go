@@ -0,0 +1,111 @@
+package tax
+
+import (
+	"fmt"
+	"inventory-service-go/commons"
+	"math/big"
+)
+
+const DefaultCategory = "standard"
+
+var (
+	ErrInvalidPercentage = commons.NewValidationError("invalid tax percentage")
+	ErrNoTaxRate         = commons.NewValidationError("no tax rate")
+	ErrNoJurisdiction    = commons.NewValidationError("tax jurisdiction is required")
+)
+
+var hundred = big.NewRat(100, 1)
+
+// ParsePercentage parses a percentage such as "20" or "7.5" into the fraction applied to amounts.
+func ParsePercentage(percentage string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(percentage)
+	if !ok || r.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPercentage, percentage)
+	}
+	return r.Quo(r, hundred), nil
+}
+
+// LineTax is the tax breakdown of a single invoice line.
+type LineTax struct {
+	Net   commons.Money `json:"net"`
+	Tax   commons.Money `json:"tax"`
+	Gross commons.Money `json:"gross"`
+}
+
+// CalculateLine works out net, tax and gross for quantity units at unitPrice. With inclusive pricing the unit price
+// already contains the tax, otherwise tax is added on top. Rounding happens once per line.
+func CalculateLine(unitPrice commons.Money, quantity int64, percentage string, inclusive bool) (LineTax, error) {
+	rate, err := ParsePercentage(percentage)
+	if err != nil {
+		return LineTax{}, err
+	}
+	amount, err := unitPrice.Mul(quantity)
+	if err != nil {
+		return LineTax{}, err
+	}
+	if inclusive {
+		net, err := amount.MulRat(new(big.Rat).Inv(new(big.Rat).Add(big.NewRat(1, 1), rate)))
+		if err != nil {
+			return LineTax{}, err
+		}
+		tax, err := amount.Sub(net)
+		if err != nil {
+			return LineTax{}, err
+		}
+		return LineTax{Net: net, Tax: tax, Gross: amount}, nil
+	}
+	tax, err := amount.MulRat(rate)
+	if err != nil {
+		return LineTax{}, err
+	}
+	gross, err := amount.Add(tax)
+	if err != nil {
+		return LineTax{}, err
+	}
+	return LineTax{Net: amount, Tax: tax, Gross: gross}, nil
+}
+
+// Summary is the tax collected for one category and percentage across an invoice.
+type Summary struct {
+	Category   string        `json:"category"`
+	Percentage string        `json:"percentage"`
+	Net        commons.Money `json:"net"`
+	Tax        commons.Money `json:"tax"`
+}
+
+// Breakdown is the invoice level roll up of its line taxes. Undiscounted is the sum of the line prices before any
+// discounts and Discount is what the discounts took off, both in the same terms as the prices (i.e. including tax
+// when prices include tax). Subtotal, TaxTotal and Total are after discounts.
+type Breakdown struct {
+	Undiscounted commons.Money `json:"undiscounted"`
+	Discount     commons.Money `json:"discount"`
+	Subtotal     commons.Money `json:"subtotal"`
+	TaxTotal     commons.Money `json:"tax_total"`
+	Total        commons.Money `json:"total"`
+	Taxes        []Summary     `json:"taxes"`
+}
+
+// Add rolls a line into the breakdown.
+func (b *Breakdown) Add(category string, percentage string, line LineTax) error {
+	var err error
+	if b.Subtotal, err = b.Subtotal.Add(line.Net); err != nil {
+		return err
+	}
+	if b.TaxTotal, err = b.TaxTotal.Add(line.Tax); err != nil {
+		return err
+	}
+	if b.Total, err = b.Total.Add(line.Gross); err != nil {
+		return err
+	}
+	for i := range b.Taxes {
+		if b.Taxes[i].Category == category && b.Taxes[i].Percentage == percentage {
+			if b.Taxes[i].Net, err = b.Taxes[i].Net.Add(line.Net); err != nil {
+				return err
+			}
+			b.Taxes[i].Tax, err = b.Taxes[i].Tax.Add(line.Tax)
+			return err
+		}
+	}
+	b.Taxes = append(b.Taxes, Summary{Category: category, Percentage: percentage, Net: line.Net, Tax: line.Tax})
+	return nil
+}
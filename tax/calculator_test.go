@@ -0,0 +1,57 @@
+package tax
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"testing"
+)
+
+func TestCalculateLine(t *testing.T) {
+	tests := []struct {
+		name       string
+		unitPrice  commons.Money
+		quantity   int64
+		percentage string
+		inclusive  bool
+		expected   LineTax
+		wantErr    bool
+	}{
+		{"Exclusive", commons.NewMoney(1000, "EUR"), 3, "19", false, LineTax{commons.NewMoney(3000, "EUR"), commons.NewMoney(570, "EUR"), commons.NewMoney(3570, "EUR")}, false},
+		{"Exclusive Rounds Half Up", commons.NewMoney(15, "USD"), 1, "10", false, LineTax{commons.NewMoney(15, "USD"), commons.NewMoney(2, "USD"), commons.NewMoney(17, "USD")}, false},
+		{"Exclusive Fractional Rate", commons.NewMoney(1000, "USD"), 1, "7.25", false, LineTax{commons.NewMoney(1000, "USD"), commons.NewMoney(73, "USD"), commons.NewMoney(1073, "USD")}, false},
+		{"Inclusive", commons.NewMoney(1200, "GBP"), 1, "20", true, LineTax{commons.NewMoney(1000, "GBP"), commons.NewMoney(200, "GBP"), commons.NewMoney(1200, "GBP")}, false},
+		{"Inclusive Rounds Once Per Line", commons.NewMoney(999, "EUR"), 3, "19", true, LineTax{commons.NewMoney(2518, "EUR"), commons.NewMoney(479, "EUR"), commons.NewMoney(2997, "EUR")}, false},
+		{"Zero Rate", commons.NewMoney(500, "USD"), 2, "0", true, LineTax{commons.NewMoney(1000, "USD"), commons.NewMoney(0, "USD"), commons.NewMoney(1000, "USD")}, false},
+		{"Negative Rate", commons.NewMoney(500, "USD"), 1, "-5", false, LineTax{}, true},
+		{"Garbage Rate", commons.NewMoney(500, "USD"), 1, "five", false, LineTax{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CalculateLine(tt.unitPrice, tt.quantity, tt.percentage, tt.inclusive)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPercentage)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestBreakdown_Add(t *testing.T) {
+	var b Breakdown
+	assert.NoError(t, b.Add("standard", "20", LineTax{commons.NewMoney(1000, "GBP"), commons.NewMoney(200, "GBP"), commons.NewMoney(1200, "GBP")}))
+	assert.NoError(t, b.Add("zero", "0", LineTax{commons.NewMoney(500, "GBP"), commons.NewMoney(0, "GBP"), commons.NewMoney(500, "GBP")}))
+	assert.NoError(t, b.Add("standard", "20", LineTax{commons.NewMoney(250, "GBP"), commons.NewMoney(50, "GBP"), commons.NewMoney(300, "GBP")}))
+
+	assert.Equal(t, commons.NewMoney(1750, "GBP"), b.Subtotal)
+	assert.Equal(t, commons.NewMoney(250, "GBP"), b.TaxTotal)
+	assert.Equal(t, commons.NewMoney(2000, "GBP"), b.Total)
+	assert.Equal(t, []Summary{
+		{Category: "standard", Percentage: "20", Net: commons.NewMoney(1250, "GBP"), Tax: commons.NewMoney(250, "GBP")},
+		{Category: "zero", Percentage: "0", Net: commons.NewMoney(500, "GBP"), Tax: commons.NewMoney(0, "GBP")},
+	}, b.Taxes)
+
+	err := b.Add("standard", "20", LineTax{commons.NewMoney(100, "EUR"), commons.NewMoney(20, "EUR"), commons.NewMoney(120, "EUR")})
+	assert.ErrorIs(t, err, commons.ErrCurrencyMismatch)
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source repository.go -destination mock_repository.go -package tax
+//
+
+// Package tax is a generated GoMock package.
+package tax
+
+import (
+	commons "inventory-service-go/commons"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaxRateRepository is a mock of TaxRateRepository interface.
+type MockTaxRateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxRateRepositoryMockRecorder
+}
+
+// MockTaxRateRepositoryMockRecorder is the mock recorder for MockTaxRateRepository.
+type MockTaxRateRepositoryMockRecorder struct {
+	mock *MockTaxRateRepository
+}
+
+// NewMockTaxRateRepository creates a new mock instance.
+func NewMockTaxRateRepository(ctrl *gomock.Controller) *MockTaxRateRepository {
+	mock := &MockTaxRateRepository{ctrl: ctrl}
+	mock.recorder = &MockTaxRateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxRateRepository) EXPECT() *MockTaxRateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTaxRate mocks base method.
+func (m *MockTaxRateRepository) CreateTaxRate(request CreateTaxRateRequest) (TaxRateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxRate", request)
+	ret0, _ := ret[0].(TaxRateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaxRate indicates an expected call of CreateTaxRate.
+func (mr *MockTaxRateRepositoryMockRecorder) CreateTaxRate(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxRate", reflect.TypeOf((*MockTaxRateRepository)(nil).CreateTaxRate), request)
+}
+
+// DeleteTaxRate mocks base method.
+func (m *MockTaxRateRepository) DeleteTaxRate(id uuid.UUID) (commons.DeleteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaxRate", id)
+	ret0, _ := ret[0].(commons.DeleteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTaxRate indicates an expected call of DeleteTaxRate.
+func (mr *MockTaxRateRepositoryMockRecorder) DeleteTaxRate(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaxRate", reflect.TypeOf((*MockTaxRateRepository)(nil).DeleteTaxRate), id)
+}
+
+// GetEffectiveTaxRate mocks base method.
+func (m *MockTaxRateRepository) GetEffectiveTaxRate(jurisdiction, category string, on commons.Date) (TaxRateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectiveTaxRate", jurisdiction, category, on)
+	ret0, _ := ret[0].(TaxRateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffectiveTaxRate indicates an expected call of GetEffectiveTaxRate.
+func (mr *MockTaxRateRepositoryMockRecorder) GetEffectiveTaxRate(jurisdiction, category, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectiveTaxRate", reflect.TypeOf((*MockTaxRateRepository)(nil).GetEffectiveTaxRate), jurisdiction, category, on)
+}
+
+// GetTaxRate mocks base method.
+func (m *MockTaxRateRepository) GetTaxRate(id uuid.UUID) (TaxRateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRate", id)
+	ret0, _ := ret[0].(TaxRateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRate indicates an expected call of GetTaxRate.
+func (mr *MockTaxRateRepositoryMockRecorder) GetTaxRate(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRate", reflect.TypeOf((*MockTaxRateRepository)(nil).GetTaxRate), id)
+}
+
+// GetTaxRates mocks base method.
+func (m *MockTaxRateRepository) GetTaxRates(pagination *commons.Pagination) ([]TaxRateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRates", pagination)
+	ret0, _ := ret[0].([]TaxRateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRates indicates an expected call of GetTaxRates.
+func (mr *MockTaxRateRepositoryMockRecorder) GetTaxRates(pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRates", reflect.TypeOf((*MockTaxRateRepository)(nil).GetTaxRates), pagination)
+}
+
+// UpdateTaxRate mocks base method.
+func (m *MockTaxRateRepository) UpdateTaxRate(request UpdateTaxRateRequest) (TaxRateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaxRate", request)
+	ret0, _ := ret[0].(TaxRateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaxRate indicates an expected call of UpdateTaxRate.
+func (mr *MockTaxRateRepositoryMockRecorder) UpdateTaxRate(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaxRate", reflect.TypeOf((*MockTaxRateRepository)(nil).UpdateTaxRate), request)
+}
@@ -0,0 +1,170 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source service.go -destination mock_service.go -package tax
+//
+
+// Package tax is a generated GoMock package.
+package tax
+
+import (
+	commons "inventory-service-go/commons"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRateProvider is a mock of RateProvider interface.
+type MockRateProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateProviderMockRecorder
+}
+
+// MockRateProviderMockRecorder is the mock recorder for MockRateProvider.
+type MockRateProviderMockRecorder struct {
+	mock *MockRateProvider
+}
+
+// NewMockRateProvider creates a new mock instance.
+func NewMockRateProvider(ctrl *gomock.Controller) *MockRateProvider {
+	mock := &MockRateProvider{ctrl: ctrl}
+	mock.recorder = &MockRateProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateProvider) EXPECT() *MockRateProviderMockRecorder {
+	return m.recorder
+}
+
+// EffectivePercentage mocks base method.
+func (m *MockRateProvider) EffectivePercentage(jurisdiction, category string, on time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EffectivePercentage", jurisdiction, category, on)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EffectivePercentage indicates an expected call of EffectivePercentage.
+func (mr *MockRateProviderMockRecorder) EffectivePercentage(jurisdiction, category, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EffectivePercentage", reflect.TypeOf((*MockRateProvider)(nil).EffectivePercentage), jurisdiction, category, on)
+}
+
+// MockTaxRateService is a mock of TaxRateService interface.
+type MockTaxRateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxRateServiceMockRecorder
+}
+
+// MockTaxRateServiceMockRecorder is the mock recorder for MockTaxRateService.
+type MockTaxRateServiceMockRecorder struct {
+	mock *MockTaxRateService
+}
+
+// NewMockTaxRateService creates a new mock instance.
+func NewMockTaxRateService(ctrl *gomock.Controller) *MockTaxRateService {
+	mock := &MockTaxRateService{ctrl: ctrl}
+	mock.recorder = &MockTaxRateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxRateService) EXPECT() *MockTaxRateServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateTaxRate mocks base method.
+func (m *MockTaxRateService) CreateTaxRate(request CreateTaxRateRequest) (TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxRate", request)
+	ret0, _ := ret[0].(TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaxRate indicates an expected call of CreateTaxRate.
+func (mr *MockTaxRateServiceMockRecorder) CreateTaxRate(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxRate", reflect.TypeOf((*MockTaxRateService)(nil).CreateTaxRate), request)
+}
+
+// DeleteTaxRate mocks base method.
+func (m *MockTaxRateService) DeleteTaxRate(id uuid.UUID) (commons.DeleteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaxRate", id)
+	ret0, _ := ret[0].(commons.DeleteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTaxRate indicates an expected call of DeleteTaxRate.
+func (mr *MockTaxRateServiceMockRecorder) DeleteTaxRate(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaxRate", reflect.TypeOf((*MockTaxRateService)(nil).DeleteTaxRate), id)
+}
+
+// EffectivePercentage mocks base method.
+func (m *MockTaxRateService) EffectivePercentage(jurisdiction, category string, on time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EffectivePercentage", jurisdiction, category, on)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EffectivePercentage indicates an expected call of EffectivePercentage.
+func (mr *MockTaxRateServiceMockRecorder) EffectivePercentage(jurisdiction, category, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EffectivePercentage", reflect.TypeOf((*MockTaxRateService)(nil).EffectivePercentage), jurisdiction, category, on)
+}
+
+// GetTaxRate mocks base method.
+func (m *MockTaxRateService) GetTaxRate(id uuid.UUID) (TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRate", id)
+	ret0, _ := ret[0].(TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRate indicates an expected call of GetTaxRate.
+func (mr *MockTaxRateServiceMockRecorder) GetTaxRate(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRate", reflect.TypeOf((*MockTaxRateService)(nil).GetTaxRate), id)
+}
+
+// GetTaxRates mocks base method.
+func (m *MockTaxRateService) GetTaxRates(pagination *commons.Pagination) ([]TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRates", pagination)
+	ret0, _ := ret[0].([]TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRates indicates an expected call of GetTaxRates.
+func (mr *MockTaxRateServiceMockRecorder) GetTaxRates(pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRates", reflect.TypeOf((*MockTaxRateService)(nil).GetTaxRates), pagination)
+}
+
+// UpdateTaxRate mocks base method.
+func (m *MockTaxRateService) UpdateTaxRate(request UpdateTaxRateRequest) (TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaxRate", request)
+	ret0, _ := ret[0].(TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaxRate indicates an expected call of UpdateTaxRate.
+func (mr *MockTaxRateServiceMockRecorder) UpdateTaxRate(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaxRate", reflect.TypeOf((*MockTaxRateService)(nil).UpdateTaxRate), request)
+}
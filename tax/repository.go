@@ -0,0 +1,117 @@
+package tax
+
+import (
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"inventory-service-go/commons"
+	"inventory-service-go/metrics"
+	"time"
+)
+
+type TaxRateRow struct {
+	Id            int64        `db:"id"`
+	AltId         uuid.UUID    `db:"alt_id"`
+	Jurisdiction  string       `db:"jurisdiction"`
+	TaxCategory   string       `db:"tax_category"`
+	Percentage    string       `db:"percentage"`
+	EffectiveDate commons.Date `db:"effective_date"`
+	CreatedBy     string       `db:"created_by"`
+	CreatedAt     time.Time    `db:"created_at"`
+	LastChangedBy string       `db:"last_changed_by"`
+	LastUpdate    time.Time    `db:"last_update"`
+}
+
+type CreateTaxRateRequest struct {
+	Jurisdiction  string       `json:"jurisdiction"`
+	TaxCategory   string       `json:"tax_category"`
+	Percentage    string       `json:"percentage"`
+	EffectiveDate commons.Date `json:"effective_date"`
+	CreatedBy     string       `json:"created_by" audit:"actor"`
+}
+
+type UpdateTaxRateRequest struct {
+	Id            uuid.UUID    `json:"id"`
+	Percentage    string       `json:"percentage"`
+	EffectiveDate commons.Date `json:"effective_date"`
+	LastChangedBy string       `json:"last_changed_by" audit:"actor"`
+}
+
+const (
+	CreateTaxRateQuery            = `INSERT INTO tax_rates (jurisdiction, tax_category, percentage, effective_date, created_by, last_changed_by) VALUES ($1, $2, $3, $4, $5, $5) RETURNING *`
+	UpdateTaxRateQuery            = `UPDATE tax_rates SET percentage = $2, effective_date = $3, last_changed_by = $4, last_update = now() WHERE alt_id = $1 RETURNING *`
+	DeleteTaxRateQuery            = `DELETE FROM tax_rates WHERE alt_id = $1`
+	GetTaxRateQuery               = `SELECT * FROM tax_rates WHERE alt_id = $1`
+	GetAllTaxRatesQuery           = `SELECT * FROM tax_rates ORDER BY id`
+	GetAllTaxRatesPaginationQuery = `SELECT * FROM tax_rates WHERE id > $1 ORDER BY id LIMIT $2`
+	GetEffectiveTaxRateQuery      = `SELECT * FROM tax_rates WHERE jurisdiction = $1 AND tax_category = $2 AND effective_date <= $3 ORDER BY effective_date DESC LIMIT 1`
+)
+
+type TaxRateRepository interface {
+	CreateTaxRate(request CreateTaxRateRequest) (TaxRateRow, error)
+	UpdateTaxRate(request UpdateTaxRateRequest) (TaxRateRow, error)
+	DeleteTaxRate(id uuid.UUID) (commons.DeleteResult, error)
+	GetTaxRate(id uuid.UUID) (TaxRateRow, error)
+	GetTaxRates(pagination *commons.Pagination) ([]TaxRateRow, error)
+	GetEffectiveTaxRate(jurisdiction string, category string, on commons.Date) (TaxRateRow, error)
+}
+
+type TaxRateRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewTaxRateRepository(db *sqlx.DB) *TaxRateRepositoryImpl {
+	return &TaxRateRepositoryImpl{db: db}
+}
+
+func (r *TaxRateRepositoryImpl) CreateTaxRate(request CreateTaxRateRequest) (TaxRateRow, error) {
+	defer metrics.ObserveQuery("tax", "CreateTaxRate")()
+	var row TaxRateRow
+	err := r.db.Get(&row, CreateTaxRateQuery, request.Jurisdiction, request.TaxCategory, request.Percentage, request.EffectiveDate, request.CreatedBy)
+	return row, err
+}
+
+func (r *TaxRateRepositoryImpl) UpdateTaxRate(request UpdateTaxRateRequest) (TaxRateRow, error) {
+	defer metrics.ObserveQuery("tax", "UpdateTaxRate")()
+	var row TaxRateRow
+	err := r.db.Get(&row, UpdateTaxRateQuery, request.Id, request.Percentage, request.EffectiveDate, request.LastChangedBy)
+	return row, err
+}
+
+func (r *TaxRateRepositoryImpl) DeleteTaxRate(id uuid.UUID) (commons.DeleteResult, error) {
+	defer metrics.ObserveQuery("tax", "DeleteTaxRate")()
+	result, err := r.db.Exec(DeleteTaxRateQuery, id)
+	if err != nil {
+		return commons.DeleteResult{}, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return commons.DeleteResult{
+		Id:      id,
+		Deleted: rowsAffected > 0,
+	}, nil
+}
+
+func (r *TaxRateRepositoryImpl) GetTaxRate(id uuid.UUID) (TaxRateRow, error) {
+	defer metrics.ObserveQuery("tax", "GetTaxRate")()
+	var row TaxRateRow
+	err := r.db.Get(&row, GetTaxRateQuery, id)
+	return row, err
+}
+
+func (r *TaxRateRepositoryImpl) GetTaxRates(pagination *commons.Pagination) ([]TaxRateRow, error) {
+	defer metrics.ObserveQuery("tax", "GetTaxRates")()
+	var rows []TaxRateRow
+	var err error
+	if pagination == nil {
+		err = r.db.Select(&rows, GetAllTaxRatesQuery)
+	} else {
+		err = r.db.Select(&rows, GetAllTaxRatesPaginationQuery, pagination.LastId, pagination.PageSize)
+	}
+	return rows, err
+}
+
+func (r *TaxRateRepositoryImpl) GetEffectiveTaxRate(jurisdiction string, category string, on commons.Date) (TaxRateRow, error) {
+	defer metrics.ObserveQuery("tax", "GetEffectiveTaxRate")()
+	var row TaxRateRow
+	err := r.db.Get(&row, GetEffectiveTaxRateQuery, jurisdiction, category, on)
+	return row, err
+}
@@ -0,0 +1,155 @@
+package tax
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+var taxRateColumns = []string{"id", "alt_id", "jurisdiction", "tax_category", "percentage", "effective_date", "created_by", "created_at", "last_changed_by", "last_update"}
+
+func TestTaxRateRepositoryImpl_CreateTaxRate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	newUuid := uuid.New()
+	request := CreateTaxRateRequest{
+		Jurisdiction:  "GB",
+		TaxCategory:   "standard",
+		Percentage:    "20",
+		EffectiveDate: commons.NewDate(2026, time.January, 1),
+		CreatedBy:     "unit_test",
+	}
+	testCases := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"Successful Tax Rate Creation", false},
+		{"Failed Tax Rate Creation", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expectation := mock.ExpectQuery("INSERT INTO tax_rates").
+				WithArgs(request.Jurisdiction, request.TaxCategory, request.Percentage, "2026-01-01", request.CreatedBy)
+			if tc.wantErr {
+				expectation.WillReturnError(errors.New("error"))
+			} else {
+				expectation.WillReturnRows(sqlmock.NewRows(taxRateColumns).
+					AddRow(1, newUuid, "GB", "standard", "20.0000", "2026-01-01", "unit_test", now, "unit_test", now))
+			}
+
+			r := NewTaxRateRepository(sqlx.NewDb(db, "mockDb"))
+
+			result, err := r.CreateTaxRate(request)
+			if tc.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, newUuid, result.AltId)
+				assert.Equal(t, "20.0000", result.Percentage)
+				assert.Equal(t, request.EffectiveDate, result.EffectiveDate)
+			}
+		})
+	}
+}
+
+func TestTaxRateRepositoryImpl_UpdateTaxRate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	request := UpdateTaxRateRequest{
+		Id:            uuid.New(),
+		Percentage:    "21",
+		EffectiveDate: commons.NewDate(2026, time.July, 1),
+		LastChangedBy: "unit_test",
+	}
+	mock.ExpectQuery("UPDATE tax_rates").
+		WithArgs(request.Id, request.Percentage, "2026-07-01", request.LastChangedBy).
+		WillReturnRows(sqlmock.NewRows(taxRateColumns).
+			AddRow(1, request.Id, "GB", "standard", "21.0000", "2026-07-01", "unit_test", now, "unit_test", now))
+
+	r := NewTaxRateRepository(sqlx.NewDb(db, "mockDb"))
+
+	result, err := r.UpdateTaxRate(request)
+	assert.Nil(t, err)
+	assert.Equal(t, request.Id, result.AltId)
+	assert.Equal(t, request.EffectiveDate, result.EffectiveDate)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestTaxRateRepositoryImpl_DeleteTaxRate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	id := uuid.New()
+	r := NewTaxRateRepository(sqlx.NewDb(db, "mockDb"))
+
+	mock.ExpectExec("DELETE FROM tax_rates").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+	result, err := r.DeleteTaxRate(id)
+	assert.Nil(t, err)
+	assert.Equal(t, commons.DeleteResult{Id: id, Deleted: true}, result)
+
+	mock.ExpectExec("DELETE FROM tax_rates").WithArgs(id).WillReturnError(errors.New("error"))
+	_, err = r.DeleteTaxRate(id)
+	assert.NotNil(t, err)
+}
+
+func TestTaxRateRepositoryImpl_GetTaxRates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(taxRateColumns).
+			AddRow(1, uuid.New(), "GB", "standard", "20", now, "unit_test", now, "unit_test", now).
+			AddRow(2, uuid.New(), "GB", "reduced", "5", now, "unit_test", now, "unit_test", now)
+	}
+	r := NewTaxRateRepository(sqlx.NewDb(db, "mockDb"))
+
+	mock.ExpectQuery("SELECT \\* FROM tax_rates ORDER BY id").WillReturnRows(rows())
+	results, err := r.GetTaxRates(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(results))
+
+	mock.ExpectQuery("SELECT \\* FROM tax_rates WHERE id > \\$1").WithArgs(0, 10).WillReturnRows(rows())
+	results, err = r.GetTaxRates(&commons.Pagination{LastId: 0, PageSize: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(results))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestTaxRateRepositoryImpl_GetEffectiveTaxRate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	id := uuid.New()
+	on := commons.NewDate(2026, time.March, 15)
+	mock.ExpectQuery("SELECT \\* FROM tax_rates WHERE jurisdiction = \\$1 AND tax_category = \\$2 AND effective_date <= \\$3").
+		WithArgs("GB", "standard", "2026-03-15").
+		WillReturnRows(sqlmock.NewRows(taxRateColumns).
+			AddRow(1, id, "GB", "standard", "20", "2026-01-01", "unit_test", now, "unit_test", now))
+
+	r := NewTaxRateRepository(sqlx.NewDb(db, "mockDb"))
+
+	result, err := r.GetEffectiveTaxRate("GB", "standard", on)
+	assert.Nil(t, err)
+	assert.Equal(t, id, result.AltId)
+	assert.Equal(t, commons.NewDate(2026, time.January, 1), result.EffectiveDate)
+
+	mock.ExpectQuery("SELECT \\* FROM tax_rates WHERE alt_id = \\$1").WithArgs(id).WillReturnError(errors.New("error"))
+	_, err = r.GetTaxRate(id)
+	assert.NotNil(t, err)
+}
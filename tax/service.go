@@ -0,0 +1,147 @@
+package tax
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"strings"
+	"time"
+)
+
+// NoTax is the percentage applied to invoices without a tax jurisdiction.
+const NoTax = "0"
+
+type TaxRate struct {
+	Seq           int               `json:"seq"`
+	Id            uuid.UUID         `json:"id"`
+	Jurisdiction  string            `json:"jurisdiction"`
+	TaxCategory   string            `json:"tax_category"`
+	Percentage    string            `json:"percentage"`
+	EffectiveDate commons.Date      `json:"effective_date"`
+	AuditInfo     commons.AuditInfo `json:"audit_info"`
+}
+
+func fromRow(row TaxRateRow) TaxRate {
+	return TaxRate{
+		Seq:           int(row.Id),
+		Id:            row.AltId,
+		Jurisdiction:  row.Jurisdiction,
+		TaxCategory:   row.TaxCategory,
+		Percentage:    row.Percentage,
+		EffectiveDate: row.EffectiveDate,
+		AuditInfo: commons.AuditInfo{
+			CreatedBy:      row.CreatedBy,
+			CreatedAt:      row.CreatedAt.Format(time.RFC3339),
+			LastChangedBy:  row.LastChangedBy,
+			LastUpdate:     row.LastUpdate.Format(time.RFC3339),
+			CreatedTime:    row.CreatedAt,
+			LastUpdateTime: row.LastUpdate,
+		},
+	}
+}
+
+// RateProvider looks up the tax percentage for a category of goods in a jurisdiction on a given day.
+type RateProvider interface {
+	EffectivePercentage(jurisdiction string, category string, on time.Time) (string, error)
+}
+
+type TaxRateService interface {
+	RateProvider
+	CreateTaxRate(request CreateTaxRateRequest) (TaxRate, error)
+	UpdateTaxRate(request UpdateTaxRateRequest) (TaxRate, error)
+	DeleteTaxRate(id uuid.UUID) (commons.DeleteResult, error)
+	GetTaxRate(id uuid.UUID) (TaxRate, error)
+	GetTaxRates(pagination *commons.Pagination) ([]TaxRate, error)
+}
+
+type TaxRateServiceImpl struct {
+	repo TaxRateRepository
+}
+
+func NewTaxRateService(repo TaxRateRepository) *TaxRateServiceImpl {
+	return &TaxRateServiceImpl{repo: repo}
+}
+
+// NormalizeJurisdiction upper cases the jurisdiction code, e.g. "gb" or "us-ca".
+func NormalizeJurisdiction(jurisdiction string) string {
+	return strings.ToUpper(strings.TrimSpace(jurisdiction))
+}
+
+// NormalizeCategory lower cases the category, an empty category means DefaultCategory.
+func NormalizeCategory(category string) string {
+	category = strings.ToLower(strings.TrimSpace(category))
+	if category == "" {
+		return DefaultCategory
+	}
+	return category
+}
+
+func (s *TaxRateServiceImpl) CreateTaxRate(request CreateTaxRateRequest) (TaxRate, error) {
+	request.Jurisdiction = NormalizeJurisdiction(request.Jurisdiction)
+	if request.Jurisdiction == "" {
+		return TaxRate{}, ErrNoJurisdiction
+	}
+	request.TaxCategory = NormalizeCategory(request.TaxCategory)
+	if _, err := ParsePercentage(request.Percentage); err != nil {
+		return TaxRate{}, err
+	}
+	row, err := s.repo.CreateTaxRate(request)
+	if err != nil {
+		return TaxRate{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *TaxRateServiceImpl) UpdateTaxRate(request UpdateTaxRateRequest) (TaxRate, error) {
+	if _, err := ParsePercentage(request.Percentage); err != nil {
+		return TaxRate{}, err
+	}
+	row, err := s.repo.UpdateTaxRate(request)
+	if err != nil {
+		return TaxRate{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *TaxRateServiceImpl) DeleteTaxRate(id uuid.UUID) (commons.DeleteResult, error) {
+	return s.repo.DeleteTaxRate(id)
+}
+
+func (s *TaxRateServiceImpl) GetTaxRate(id uuid.UUID) (TaxRate, error) {
+	row, err := s.repo.GetTaxRate(id)
+	if err != nil {
+		return TaxRate{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *TaxRateServiceImpl) GetTaxRates(pagination *commons.Pagination) ([]TaxRate, error) {
+	rows, err := s.repo.GetTaxRates(pagination)
+	if err != nil {
+		return nil, err
+	}
+	rates := make([]TaxRate, len(rows))
+	for i, row := range rows {
+		rates[i] = fromRow(row)
+	}
+	return rates, nil
+}
+
+// EffectivePercentage returns NoTax when there is no jurisdiction, and ErrNoTaxRate when the jurisdiction has no
+// rate for the category on that day.
+func (s *TaxRateServiceImpl) EffectivePercentage(jurisdiction string, category string, on time.Time) (string, error) {
+	jurisdiction = NormalizeJurisdiction(jurisdiction)
+	if jurisdiction == "" {
+		return NoTax, nil
+	}
+	category = NormalizeCategory(category)
+	row, err := s.repo.GetEffectiveTaxRate(jurisdiction, category, commons.DateOf(on))
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: %s in %s on %s", ErrNoTaxRate, category, jurisdiction, commons.DateOf(on))
+	} else if err != nil {
+		return "", err
+	}
+	return row.Percentage, nil
+}
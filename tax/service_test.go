@@ -0,0 +1,172 @@
+package tax
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+func TestTaxRateService_CreateTaxRate(t *testing.T) {
+	now := time.Now()
+	row := TaxRateRow{
+		Id:            1,
+		AltId:         uuid.New(),
+		Jurisdiction:  "GB",
+		TaxCategory:   "standard",
+		Percentage:    "20",
+		EffectiveDate: commons.NewDate(2026, time.January, 1),
+		CreatedBy:     "unit_test",
+		CreatedAt:     now,
+		LastChangedBy: "unit_test",
+		LastUpdate:    now,
+	}
+	testCases := []struct {
+		name     string
+		request  CreateTaxRateRequest
+		want     TaxRate
+		wantErr  error
+		mockFunc func(mockRepo *MockTaxRateRepository)
+	}{
+		{
+			name:    "Create Tax Rate Successfully",
+			request: CreateTaxRateRequest{Jurisdiction: " gb ", Percentage: "20", EffectiveDate: row.EffectiveDate, CreatedBy: "unit_test"},
+			want:    fromRow(row),
+			mockFunc: func(mockRepo *MockTaxRateRepository) {
+				mockRepo.EXPECT().CreateTaxRate(CreateTaxRateRequest{Jurisdiction: "GB", TaxCategory: "standard", Percentage: "20", EffectiveDate: row.EffectiveDate, CreatedBy: "unit_test"}).Return(row, nil)
+			},
+		},
+		{
+			name:     "No Jurisdiction",
+			request:  CreateTaxRateRequest{Percentage: "20"},
+			wantErr:  ErrNoJurisdiction,
+			mockFunc: func(mockRepo *MockTaxRateRepository) {},
+		},
+		{
+			name:     "Invalid Percentage",
+			request:  CreateTaxRateRequest{Jurisdiction: "GB", Percentage: "-20"},
+			wantErr:  ErrInvalidPercentage,
+			mockFunc: func(mockRepo *MockTaxRateRepository) {},
+		},
+	}
+	controller := gomock.NewController(t)
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := NewMockTaxRateRepository(controller)
+			tt.mockFunc(mockRepo)
+			service := NewTaxRateService(mockRepo)
+			result, err := service.CreateTaxRate(tt.request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, result)
+			}
+		})
+	}
+}
+
+func TestTaxRateService_UpdateTaxRate(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := NewMockTaxRateRepository(controller)
+	service := NewTaxRateService(mockRepo)
+	request := UpdateTaxRateRequest{Id: uuid.New(), Percentage: "21", EffectiveDate: commons.NewDate(2026, time.July, 1)}
+
+	mockRepo.EXPECT().UpdateTaxRate(request).Return(TaxRateRow{Id: 1, AltId: request.Id, Percentage: "21"}, nil)
+	result, err := service.UpdateTaxRate(request)
+	assert.NoError(t, err)
+	assert.Equal(t, request.Id, result.Id)
+
+	request.Percentage = "twenty"
+	_, err = service.UpdateTaxRate(request)
+	assert.ErrorIs(t, err, ErrInvalidPercentage)
+}
+
+func TestTaxRateService_GetTaxRates(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := NewMockTaxRateRepository(controller)
+	service := NewTaxRateService(mockRepo)
+	id := uuid.New()
+
+	mockRepo.EXPECT().GetTaxRates(nil).Return([]TaxRateRow{{Id: 1, AltId: id}}, nil)
+	results, err := service.GetTaxRates(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, id, results[0].Id)
+
+	mockRepo.EXPECT().GetTaxRates(nil).Return(nil, errors.New("Repo Error"))
+	_, err = service.GetTaxRates(nil)
+	assert.Error(t, err)
+
+	mockRepo.EXPECT().GetTaxRate(id).Return(TaxRateRow{}, sql.ErrNoRows)
+	_, err = service.GetTaxRate(id)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	mockRepo.EXPECT().DeleteTaxRate(id).Return(commons.DeleteResult{Id: id, Deleted: true}, nil)
+	deleted, err := service.DeleteTaxRate(id)
+	assert.NoError(t, err)
+	assert.True(t, deleted.Deleted)
+}
+
+func TestTaxRateService_EffectivePercentage(t *testing.T) {
+	on := time.Date(2026, time.March, 15, 13, 30, 0, 0, time.UTC)
+	day := commons.NewDate(2026, time.March, 15)
+	testCases := []struct {
+		name         string
+		jurisdiction string
+		category     string
+		want         string
+		wantErr      error
+		mockFunc     func(mockRepo *MockTaxRateRepository)
+	}{
+		{
+			name:     "No Jurisdiction",
+			want:     NoTax,
+			mockFunc: func(mockRepo *MockTaxRateRepository) {},
+		},
+		{
+			name:         "Default Category",
+			jurisdiction: "gb",
+			want:         "20",
+			mockFunc: func(mockRepo *MockTaxRateRepository) {
+				mockRepo.EXPECT().GetEffectiveTaxRate("GB", "standard", day).Return(TaxRateRow{Percentage: "20"}, nil)
+			},
+		},
+		{
+			name:         "No Rate",
+			jurisdiction: "GB",
+			category:     "Reduced",
+			wantErr:      ErrNoTaxRate,
+			mockFunc: func(mockRepo *MockTaxRateRepository) {
+				mockRepo.EXPECT().GetEffectiveTaxRate("GB", "reduced", day).Return(TaxRateRow{}, sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "Repo Error",
+			jurisdiction: "GB",
+			wantErr:      sql.ErrConnDone,
+			mockFunc: func(mockRepo *MockTaxRateRepository) {
+				mockRepo.EXPECT().GetEffectiveTaxRate("GB", "standard", day).Return(TaxRateRow{}, sql.ErrConnDone)
+			},
+		},
+	}
+	controller := gomock.NewController(t)
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := NewMockTaxRateRepository(controller)
+			tt.mockFunc(mockRepo)
+			service := NewTaxRateService(mockRepo)
+			result, err := service.EffectivePercentage(tt.jurisdiction, tt.category, on)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+//go:build wireinject
+// +build wireinject
+
+package tax
+
+import (
+	"github.com/google/wire"
+	"inventory-service-go/commons"
+)
+
+func InitializeTaxRateService() (TaxRateService, error) {
+	wire.Build(
+		NewTaxRateService,
+		NewTaxRateRepository,
+		commons.GetDB,
+		wire.Bind(new(TaxRateRepository), new(*TaxRateRepositoryImpl)),
+		wire.Bind(new(TaxRateService), new(*TaxRateServiceImpl)),
+	)
+	return nil, nil
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source repository.go -destination mock_repository.go -package tenant
+//
+
+// Package tenant is a generated GoMock package.
+package tenant
+
+import (
+	context "context"
+	commons "inventory-service-go/commons"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTenantRepository is a mock of TenantRepository interface.
+type MockTenantRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantRepositoryMockRecorder
+}
+
+// MockTenantRepositoryMockRecorder is the mock recorder for MockTenantRepository.
+type MockTenantRepositoryMockRecorder struct {
+	mock *MockTenantRepository
+}
+
+// NewMockTenantRepository creates a new mock instance.
+func NewMockTenantRepository(ctrl *gomock.Controller) *MockTenantRepository {
+	mock := &MockTenantRepository{ctrl: ctrl}
+	mock.recorder = &MockTenantRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantRepository) EXPECT() *MockTenantRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTenant mocks base method.
+func (m *MockTenantRepository) CreateTenant(ctx context.Context, request CreateTenantRequest) (TenantRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTenant", ctx, request)
+	ret0, _ := ret[0].(TenantRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTenant indicates an expected call of CreateTenant.
+func (mr *MockTenantRepositoryMockRecorder) CreateTenant(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTenant", reflect.TypeOf((*MockTenantRepository)(nil).CreateTenant), ctx, request)
+}
+
+// GetTenant mocks base method.
+func (m *MockTenantRepository) GetTenant(ctx context.Context, id uuid.UUID) (TenantRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenant", ctx, id)
+	ret0, _ := ret[0].(TenantRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenant indicates an expected call of GetTenant.
+func (mr *MockTenantRepositoryMockRecorder) GetTenant(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenant", reflect.TypeOf((*MockTenantRepository)(nil).GetTenant), ctx, id)
+}
+
+// GetTenantByName mocks base method.
+func (m *MockTenantRepository) GetTenantByName(ctx context.Context, name string) (TenantRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenantByName", ctx, name)
+	ret0, _ := ret[0].(TenantRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenantByName indicates an expected call of GetTenantByName.
+func (mr *MockTenantRepositoryMockRecorder) GetTenantByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantByName", reflect.TypeOf((*MockTenantRepository)(nil).GetTenantByName), ctx, name)
+}
+
+// GetTenants mocks base method.
+func (m *MockTenantRepository) GetTenants(ctx context.Context, pagination *commons.Pagination) ([]TenantRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenants", ctx, pagination)
+	ret0, _ := ret[0].([]TenantRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenants indicates an expected call of GetTenants.
+func (mr *MockTenantRepositoryMockRecorder) GetTenants(ctx, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenants", reflect.TypeOf((*MockTenantRepository)(nil).GetTenants), ctx, pagination)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source service.go -destination mock_service.go -package tenant
+//
+
+// Package tenant is a generated GoMock package.
+package tenant
+
+import (
+	context "context"
+	commons "inventory-service-go/commons"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTenantService is a mock of TenantService interface.
+type MockTenantService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantServiceMockRecorder
+}
+
+// MockTenantServiceMockRecorder is the mock recorder for MockTenantService.
+type MockTenantServiceMockRecorder struct {
+	mock *MockTenantService
+}
+
+// NewMockTenantService creates a new mock instance.
+func NewMockTenantService(ctrl *gomock.Controller) *MockTenantService {
+	mock := &MockTenantService{ctrl: ctrl}
+	mock.recorder = &MockTenantServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantService) EXPECT() *MockTenantServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateTenant mocks base method.
+func (m *MockTenantService) CreateTenant(ctx context.Context, request CreateTenantRequest) (Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTenant", ctx, request)
+	ret0, _ := ret[0].(Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTenant indicates an expected call of CreateTenant.
+func (mr *MockTenantServiceMockRecorder) CreateTenant(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTenant", reflect.TypeOf((*MockTenantService)(nil).CreateTenant), ctx, request)
+}
+
+// GetTenant mocks base method.
+func (m *MockTenantService) GetTenant(ctx context.Context, id uuid.UUID) (Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenant", ctx, id)
+	ret0, _ := ret[0].(Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenant indicates an expected call of GetTenant.
+func (mr *MockTenantServiceMockRecorder) GetTenant(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenant", reflect.TypeOf((*MockTenantService)(nil).GetTenant), ctx, id)
+}
+
+// GetTenantByName mocks base method.
+func (m *MockTenantService) GetTenantByName(ctx context.Context, name string) (Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenantByName", ctx, name)
+	ret0, _ := ret[0].(Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenantByName indicates an expected call of GetTenantByName.
+func (mr *MockTenantServiceMockRecorder) GetTenantByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantByName", reflect.TypeOf((*MockTenantService)(nil).GetTenantByName), ctx, name)
+}
+
+// GetTenants mocks base method.
+func (m *MockTenantService) GetTenants(ctx context.Context, pagination *commons.Pagination) ([]Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenants", ctx, pagination)
+	ret0, _ := ret[0].([]Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenants indicates an expected call of GetTenants.
+func (mr *MockTenantServiceMockRecorder) GetTenants(ctx, pagination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenants", reflect.TypeOf((*MockTenantService)(nil).GetTenants), ctx, pagination)
+}
@@ -0,0 +1,83 @@
+package tenant
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"inventory-service-go/commons"
+	"inventory-service-go/metrics"
+	"inventory-service-go/tracing"
+	"time"
+)
+
+type TenantRow struct {
+	Id            int64     `db:"id"`
+	AltId         uuid.UUID `db:"alt_id"`
+	Name          string    `db:"name"`
+	CreatedBy     string    `db:"created_by"`
+	CreatedAt     time.Time `db:"created_at"`
+	LastChangedBy string    `db:"last_changed_by"`
+	LastUpdate    time.Time `db:"last_update"`
+}
+
+type CreateTenantRequest struct {
+	Name      string `json:"name"`
+	CreatedBy string `json:"created_by" audit:"actor"`
+}
+
+const (
+	CreateTenantQuery            = `INSERT INTO tenants (name, created_by, last_changed_by) VALUES ($1, $2, $2) RETURNING *`
+	GetTenantQuery               = `SELECT * FROM tenants WHERE alt_id = $1`
+	GetTenantByNameQuery         = `SELECT * FROM tenants WHERE name = $1`
+	GetAllTenantsQuery           = `SELECT * FROM tenants ORDER BY id`
+	GetAllTenantsPaginationQuery = `SELECT * FROM tenants WHERE id > $1 ORDER BY id LIMIT $2`
+)
+
+type TenantRepository interface {
+	CreateTenant(ctx context.Context, request CreateTenantRequest) (TenantRow, error)
+	GetTenant(ctx context.Context, id uuid.UUID) (TenantRow, error)
+	GetTenantByName(ctx context.Context, name string) (TenantRow, error)
+	GetTenants(ctx context.Context, pagination *commons.Pagination) ([]TenantRow, error)
+}
+
+// TenantRepositoryImpl reads the tenants table itself, which is not scoped to a tenant.
+type TenantRepositoryImpl struct {
+	db *sqlx.DB
+}
+
+func NewTenantRepository(db *sqlx.DB) *TenantRepositoryImpl {
+	return &TenantRepositoryImpl{db: db}
+}
+
+func (r *TenantRepositoryImpl) CreateTenant(ctx context.Context, request CreateTenantRequest) (TenantRow, error) {
+	defer metrics.ObserveQuery("tenant", "CreateTenant")()
+	var row TenantRow
+	err := r.db.GetContext(tracing.Statement(ctx, "CreateTenant"), &row, CreateTenantQuery, request.Name, request.CreatedBy)
+	return row, err
+}
+
+func (r *TenantRepositoryImpl) GetTenant(ctx context.Context, id uuid.UUID) (TenantRow, error) {
+	defer metrics.ObserveQuery("tenant", "GetTenant")()
+	var row TenantRow
+	err := r.db.GetContext(tracing.Statement(ctx, "GetTenant"), &row, GetTenantQuery, id)
+	return row, err
+}
+
+func (r *TenantRepositoryImpl) GetTenantByName(ctx context.Context, name string) (TenantRow, error) {
+	defer metrics.ObserveQuery("tenant", "GetTenantByName")()
+	var row TenantRow
+	err := r.db.GetContext(tracing.Statement(ctx, "GetTenantByName"), &row, GetTenantByNameQuery, name)
+	return row, err
+}
+
+func (r *TenantRepositoryImpl) GetTenants(ctx context.Context, pagination *commons.Pagination) ([]TenantRow, error) {
+	defer metrics.ObserveQuery("tenant", "GetTenants")()
+	var rows []TenantRow
+	var err error
+	if pagination != nil {
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetTenantsPage"), &rows, GetAllTenantsPaginationQuery, pagination.LastId, pagination.PageSize)
+	} else {
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetTenants"), &rows, GetAllTenantsQuery)
+	}
+	return rows, err
+}
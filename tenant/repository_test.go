@@ -0,0 +1,71 @@
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+var tenantColumns = []string{"id", "alt_id", "name", "created_by", "created_at", "last_changed_by", "last_update"}
+
+func TestTenantRepositoryImpl_CreateTenant(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	id := uuid.New()
+	mock.ExpectQuery("INSERT INTO tenants").WithArgs("acme", "admin").
+		WillReturnRows(sqlmock.NewRows(tenantColumns).AddRow(2, id, "acme", "admin", now, "admin", now))
+	mock.ExpectQuery("INSERT INTO tenants").WillReturnError(errors.New("error"))
+
+	r := NewTenantRepository(sqlx.NewDb(db, "mockDb"))
+
+	row, err := r.CreateTenant(context.Background(), CreateTenantRequest{Name: "acme", CreatedBy: "admin"})
+	assert.Nil(t, err)
+	assert.Equal(t, id, row.AltId)
+	_, err = r.CreateTenant(context.Background(), CreateTenantRequest{Name: "acme", CreatedBy: "admin"})
+	assert.Error(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantRepositoryImpl_Get(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	row := func() *sqlmock.Rows {
+		return sqlmock.NewRows(tenantColumns).AddRow(1, commons.DefaultTenant, "default", "migration", now, "migration", now)
+	}
+	mock.ExpectQuery("SELECT \\* FROM tenants WHERE alt_id = \\$1").WithArgs(commons.DefaultTenant).WillReturnRows(row())
+	mock.ExpectQuery("SELECT \\* FROM tenants WHERE name = \\$1").WithArgs("default").WillReturnRows(row())
+	mock.ExpectQuery("SELECT \\* FROM tenants WHERE name = \\$1").WithArgs("unknown").WillReturnRows(sqlmock.NewRows(tenantColumns))
+	mock.ExpectQuery("SELECT \\* FROM tenants ORDER BY id").WillReturnRows(row())
+	mock.ExpectQuery("SELECT \\* FROM tenants WHERE id > \\$1 ORDER BY id LIMIT \\$2").WithArgs(1, 10).WillReturnRows(sqlmock.NewRows(tenantColumns))
+
+	r := NewTenantRepository(sqlx.NewDb(db, "mockDb"))
+
+	byId, err := r.GetTenant(context.Background(), commons.DefaultTenant)
+	assert.Nil(t, err)
+	assert.Equal(t, "default", byId.Name)
+	byName, err := r.GetTenantByName(context.Background(), "default")
+	assert.Nil(t, err)
+	assert.Equal(t, commons.DefaultTenant, byName.AltId)
+	_, err = r.GetTenantByName(context.Background(), "unknown")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	all, err := r.GetTenants(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, all, 1)
+	page, err := r.GetTenants(context.Background(), &commons.Pagination{LastId: 1, PageSize: 10})
+	assert.Nil(t, err)
+	assert.Empty(t, page)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,92 @@
+// Package tenant manages the organisations sharing the service. Every row of the tenant tables belongs to a tenant and
+// the row-level security policies only let a request see the rows of the tenant of its token, see commons.TenantDB.
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"strings"
+	"time"
+)
+
+var ErrInvalidTenant = commons.NewValidationError("invalid tenant")
+
+type Tenant struct {
+	Seq       int               `json:"seq"`
+	Id        uuid.UUID         `json:"id"`
+	Name      string            `json:"name"`
+	AuditInfo commons.AuditInfo `json:"audit_info"`
+}
+
+func fromRow(row TenantRow) Tenant {
+	return Tenant{
+		Seq:  int(row.Id),
+		Id:   row.AltId,
+		Name: row.Name,
+		AuditInfo: commons.AuditInfo{
+			CreatedBy:      row.CreatedBy,
+			CreatedAt:      row.CreatedAt.Format(time.RFC3339),
+			LastChangedBy:  row.LastChangedBy,
+			LastUpdate:     row.LastUpdate.Format(time.RFC3339),
+			CreatedTime:    row.CreatedAt,
+			LastUpdateTime: row.LastUpdate,
+		},
+	}
+}
+
+type TenantService interface {
+	CreateTenant(ctx context.Context, request CreateTenantRequest) (Tenant, error)
+	GetTenant(ctx context.Context, id uuid.UUID) (Tenant, error)
+	GetTenantByName(ctx context.Context, name string) (Tenant, error)
+	GetTenants(ctx context.Context, pagination *commons.Pagination) ([]Tenant, error)
+}
+
+type TenantServiceImpl struct {
+	repo TenantRepository
+}
+
+func NewTenantService(repo TenantRepository) *TenantServiceImpl {
+	return &TenantServiceImpl{repo: repo}
+}
+
+func (s *TenantServiceImpl) CreateTenant(ctx context.Context, request CreateTenantRequest) (Tenant, error) {
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" || len(request.Name) > 255 {
+		return Tenant{}, fmt.Errorf("%w: name must be between 1 and 255 characters", ErrInvalidTenant)
+	}
+	row, err := s.repo.CreateTenant(ctx, request)
+	if err != nil {
+		return Tenant{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *TenantServiceImpl) GetTenant(ctx context.Context, id uuid.UUID) (Tenant, error) {
+	row, err := s.repo.GetTenant(ctx, id)
+	if err != nil {
+		return Tenant{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *TenantServiceImpl) GetTenantByName(ctx context.Context, name string) (Tenant, error) {
+	row, err := s.repo.GetTenantByName(ctx, name)
+	if err != nil {
+		return Tenant{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *TenantServiceImpl) GetTenants(ctx context.Context, pagination *commons.Pagination) ([]Tenant, error) {
+	rows, err := s.repo.GetTenants(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+	tenants := make([]Tenant, len(rows))
+	for i, row := range rows {
+		tenants[i] = fromRow(row)
+	}
+	return tenants, nil
+}
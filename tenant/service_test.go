@@ -0,0 +1,86 @@
+package tenant
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+func tenantRow(name string) TenantRow {
+	now := time.Now()
+	return TenantRow{Id: 2, AltId: uuid.New(), Name: name, CreatedBy: "admin", CreatedAt: now, LastChangedBy: "admin", LastUpdate: now}
+}
+
+func TestTenantService_CreateTenant(t *testing.T) {
+	testCases := []struct {
+		name     string
+		request  CreateTenantRequest
+		wantErr  error
+		mockFunc func(mockRepo *MockTenantRepository)
+	}{
+		{
+			name:    "Create Tenant Successfully",
+			request: CreateTenantRequest{Name: " acme ", CreatedBy: "admin"},
+			mockFunc: func(mockRepo *MockTenantRepository) {
+				mockRepo.EXPECT().CreateTenant(gomock.Any(), CreateTenantRequest{Name: "acme", CreatedBy: "admin"}).Return(tenantRow("acme"), nil)
+			},
+		},
+		{
+			name:    "Empty Name",
+			request: CreateTenantRequest{Name: " ", CreatedBy: "admin"},
+			wantErr: ErrInvalidTenant,
+		},
+		{
+			name:    "Repository Error",
+			request: CreateTenantRequest{Name: "acme", CreatedBy: "admin"},
+			wantErr: sql.ErrConnDone,
+			mockFunc: func(mockRepo *MockTenantRepository) {
+				mockRepo.EXPECT().CreateTenant(gomock.Any(), gomock.Any()).Return(TenantRow{}, sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := NewMockTenantRepository(gomock.NewController(t))
+			if tc.mockFunc != nil {
+				tc.mockFunc(mockRepo)
+			}
+			created, err := NewTenantService(mockRepo).CreateTenant(context.Background(), tc.request)
+			if tc.wantErr != nil {
+				assert.True(t, errors.Is(err, tc.wantErr))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "acme", created.Name)
+			assert.Equal(t, "admin", created.AuditInfo.CreatedBy)
+		})
+	}
+}
+
+func TestTenantService_Get(t *testing.T) {
+	row := tenantRow("acme")
+	mockRepo := NewMockTenantRepository(gomock.NewController(t))
+	mockRepo.EXPECT().GetTenant(gomock.Any(), row.AltId).Return(row, nil)
+	mockRepo.EXPECT().GetTenantByName(gomock.Any(), "acme").Return(row, nil)
+	mockRepo.EXPECT().GetTenantByName(gomock.Any(), "unknown").Return(TenantRow{}, sql.ErrNoRows)
+	mockRepo.EXPECT().GetTenants(gomock.Any(), &commons.Pagination{LastId: 1, PageSize: 10}).Return([]TenantRow{row}, nil)
+	service := NewTenantService(mockRepo)
+
+	byId, err := service.GetTenant(context.Background(), row.AltId)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, byId.Seq)
+	byName, err := service.GetTenantByName(context.Background(), "acme")
+	assert.NoError(t, err)
+	assert.Equal(t, row.AltId, byName.Id)
+	_, err = service.GetTenantByName(context.Background(), "unknown")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	all, err := service.GetTenants(context.Background(), &commons.Pagination{LastId: 1, PageSize: 10})
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+}
@@ -0,0 +1,20 @@
+//go:build wireinject
+// +build wireinject
+
+package tenant
+
+import (
+	"github.com/google/wire"
+	"inventory-service-go/commons"
+)
+
+func InitializeTenantService() (TenantService, error) {
+	wire.Build(
+		NewTenantService,
+		NewTenantRepository,
+		commons.GetDB,
+		wire.Bind(new(TenantRepository), new(*TenantRepositoryImpl)),
+		wire.Bind(new(TenantService), new(*TenantServiceImpl)),
+	)
+	return nil, nil
+}
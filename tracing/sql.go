@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+type statementKey struct{}
+
+type querySpanKey struct{}
+
+// Statement names the SQL statement run with the context, the name of its constant by convention:
+//
+//	r.db.SelectContext(tracing.Statement(ctx, "GetInvoiceWithItemsQuery"), &results, GetInvoiceWithItemsQuery, id)
+func Statement(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, statementKey{}, name)
+}
+
+// QueryTracer gives every SQL statement run within a trace a span named after the statement, or after its first
+// keyword, e.g. COMMIT, when it was not named. Statements outside of a trace, like those of the background workers,
+// are not traced.
+type QueryTracer struct{}
+
+func (QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	name, ok := ctx.Value(statementKey{}).(string)
+	if !ok {
+		name, _, _ = strings.Cut(strings.TrimSpace(data.SQL), " ")
+		name = strings.ToUpper(name)
+	}
+	ctx, span := tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation.name", name),
+			attribute.String("db.query.text", data.SQL),
+		),
+	)
+	return context.WithValue(ctx, querySpanKey{}, span)
+}
+
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span, ok := ctx.Value(querySpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	}
+	span.SetAttributes(attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected()))
+	span.End()
+}
@@ -0,0 +1,114 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+	"sync"
+)
+
+const (
+	instrumentationName = "inventory-service-go"
+	defaultServiceName  = "inventory-service-go"
+)
+
+// Settings are the exporters Setup sends the spans to, see config.Tracing.
+type Settings struct {
+	Exporters   []string
+	Endpoint    string
+	ServiceName string
+}
+
+// ExporterFactory creates the exporter spans are sent to, see RegisterExporter.
+type ExporterFactory func(ctx context.Context, settings Settings) (sdktrace.SpanExporter, error)
+
+var (
+	exportersMu sync.RWMutex
+	exporters   = map[string]ExporterFactory{
+		"stdout": func(context.Context, Settings) (sdktrace.SpanExporter, error) {
+			return stdouttrace.New(stdouttrace.WithPrettyPrint())
+		},
+		// a local collector on localhost:4318 without an endpoint
+		"otlp": func(ctx context.Context, settings Settings) (sdktrace.SpanExporter, error) {
+			if settings.Endpoint == "" {
+				return otlptracehttp.New(ctx, otlptracehttp.WithInsecure())
+			}
+			return otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(settings.Endpoint))
+		},
+	}
+)
+
+// RegisterExporter makes an exporter available to Settings.Exporters under the name.
+func RegisterExporter(name string, factory ExporterFactory) {
+	exportersMu.Lock()
+	defer exportersMu.Unlock()
+	exporters[name] = factory
+}
+
+// Setup installs the W3C trace context propagator and a tracer provider sending the spans to the exporters named in
+// the settings, e.g. "stdout" and "otlp". Spans are not recorded without one or with "none", incoming trace context is
+// still passed on. The resource attributes of OTEL_RESOURCE_ATTRIBUTES are added, the service name is the one of the
+// settings.
+func Setup(ctx context.Context, settings Settings) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	serviceName := settings.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithAttributes(attribute.String("service.name", serviceName)),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	options := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	exportersMu.RLock()
+	defer exportersMu.RUnlock()
+	for _, name := range settings.Exporters {
+		name = strings.TrimSpace(name)
+		if name == "" || name == "none" {
+			continue
+		}
+		factory, ok := exporters[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown trace exporter %q", name)
+		}
+		exporter, err := factory(ctx, settings)
+		if err != nil {
+			return nil, fmt.Errorf("creating the %s trace exporter: %w", name, err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start starts a span that is a child of the span in the context.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End ends the span, marking it as failed when err is set.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
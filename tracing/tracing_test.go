@@ -0,0 +1,137 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func record(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return recorder
+}
+
+func attributeValue(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := record(t)
+	e := echo.New()
+	e.Use(Middleware())
+	e.GET("/items/:id", func(c echo.Context) error {
+		_, span := Start(c.Request().Context(), "ItemService.GetItem")
+		End(span, nil)
+		return c.String(http.StatusOK, "OK")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set("traceparent", traceparent)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		child, server := spans[0], spans[1]
+		assert.Equal(t, "GET /items/:id", server.Name())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+		assert.Equal(t, int64(http.StatusOK), attributeValue(server, "http.response.status_code").AsInt64())
+		assert.Equal(t, server.SpanContext().SpanID(), child.Parent().SpanID())
+		assert.Contains(t, rec.Header().Get("traceparent"), server.SpanContext().SpanID().String())
+	}
+}
+
+func TestMiddleware_Errors(t *testing.T) {
+	recorder := record(t)
+	e := echo.New()
+	e.Use(Middleware())
+	e.GET("/boom", func(c echo.Context) error { return errors.New("BOOM") })
+	e.GET("/missing", func(c echo.Context) error { return echo.ErrNotFound })
+
+	for _, path := range []string{"/boom", "/missing"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, int64(http.StatusInternalServerError), attributeValue(spans[0], "http.response.status_code").AsInt64())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, int64(http.StatusNotFound), attributeValue(spans[1], "http.response.status_code").AsInt64())
+		assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	}
+}
+
+func TestQueryTracer(t *testing.T) {
+	recorder := record(t)
+	tracer := QueryTracer{}
+
+	// outside of a trace
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	assert.Empty(t, recorder.Ended())
+
+	parent, span := Start(context.Background(), "InvoiceService.GetInvoice")
+	defer span.End()
+	ctx = tracer.TraceQueryStart(Statement(parent, "GetInvoiceWithItemsQuery"), nil, pgx.TraceQueryStartData{SQL: "SELECT * FROM invoices"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 2")})
+	ctx = tracer.TraceQueryStart(parent, nil, pgx.TraceQueryStartData{SQL: " commit"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("BOOM")})
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "GetInvoiceWithItemsQuery", spans[0].Name())
+		assert.Equal(t, span.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, "SELECT * FROM invoices", attributeValue(spans[0], "db.query.text").AsString())
+		assert.Equal(t, int64(2), attributeValue(spans[0], "db.response.rows_affected").AsInt64())
+		assert.Equal(t, "COMMIT", spans[1].Name())
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+	}
+}
+
+// keptSpans keeps the spans on shutdown, which the in-memory exporter clears.
+type keptSpans struct {
+	*tracetest.InMemoryExporter
+}
+
+func (keptSpans) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestSetup(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	RegisterExporter("memory", func(context.Context, Settings) (sdktrace.SpanExporter, error) { return keptSpans{exporter}, nil })
+
+	shutdown, err := Setup(context.Background(), Settings{Exporters: []string{"none", "memory"}})
+	assert.Nil(t, err)
+	_, span := Start(context.Background(), "span")
+	End(span, nil)
+	assert.Nil(t, shutdown(context.Background()))
+	assert.Len(t, exporter.GetSpans(), 1)
+
+	_, err = Setup(context.Background(), Settings{Exporters: []string{"carrier-pigeon"}})
+	assert.ErrorContains(t, err, `unknown trace exporter "carrier-pigeon"`)
+}
@@ -0,0 +1,100 @@
+package user
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-service-go/cache"
+	"inventory-service-go/commons"
+	"inventory-service-go/logging"
+	"sync"
+)
+
+// CacheName names the cache of the enabled users in invalidations and stats.
+const CacheName = "users"
+
+var (
+	enabledCache     *cache.LRU[string, struct{}]
+	enabledCacheOnce sync.Once
+)
+
+// ProvideUserService puts the cache of the enabled users in front of CheckEnabled, which every request with a token
+// calls, unless its Size is 0. Every user service of the process shares the one cache.
+func ProvideUserService(service *UserServiceImpl, invalidator cache.Invalidator, settings cache.Config) UserService {
+	if !settings.Enabled() {
+		return service
+	}
+	enabledCacheOnce.Do(func() {
+		enabledCache = cache.NewLRU[string, struct{}](CacheName, settings)
+		invalidator.Register(CacheName, enabledCache.Remove, enabledCache.Purge)
+	})
+	return NewCachingUserService(service, enabledCache, invalidator)
+}
+
+// CachingUserService remembers the users CheckEnabled found enabled and forgets a user on every replica when it is
+// disabled. Disabled and unknown users are not cached, they are checked again on every request.
+type CachingUserService struct {
+	service     UserService
+	cache       *cache.LRU[string, struct{}]
+	invalidator cache.Invalidator
+}
+
+func NewCachingUserService(service UserService, cache *cache.LRU[string, struct{}], invalidator cache.Invalidator) *CachingUserService {
+	return &CachingUserService{
+		service:     service,
+		cache:       cache,
+		invalidator: invalidator,
+	}
+}
+
+func (s *CachingUserService) CreateUser(ctx context.Context, request CreateUserRequest) (User, error) {
+	return s.service.CreateUser(ctx, request)
+}
+
+func (s *CachingUserService) GetUser(ctx context.Context, username string) (User, error) {
+	return s.service.GetUser(ctx, username)
+}
+
+func (s *CachingUserService) GetUsers(ctx context.Context, pagination *commons.Pagination) ([]User, error) {
+	return s.service.GetUsers(ctx, pagination)
+}
+
+func (s *CachingUserService) DisableUser(ctx context.Context, username string, changedBy string) (User, error) {
+	defer s.invalidate(ctx, username)
+	return s.service.DisableUser(ctx, username, changedBy)
+}
+
+func (s *CachingUserService) VerifyCredentials(ctx context.Context, username string, password string) (uuid.UUID, error) {
+	return s.service.VerifyCredentials(ctx, username, password)
+}
+
+func (s *CachingUserService) VerifyClient(ctx context.Context, username string, secret string) (uuid.UUID, error) {
+	return s.service.VerifyClient(ctx, username, secret)
+}
+
+func (s *CachingUserService) CheckEnabled(ctx context.Context, username string) error {
+	if _, ok := s.cache.Get(username); ok {
+		return nil
+	}
+	generation := s.cache.Generation()
+	if err := s.service.CheckEnabled(ctx, username); err != nil {
+		return err
+	}
+	s.cache.AddSince(username, struct{}{}, generation)
+	return nil
+}
+
+func (s *CachingUserService) RotateClientSecret(ctx context.Context, username string, changedBy string) (string, error) {
+	return s.service.RotateClientSecret(ctx, username, changedBy)
+}
+
+func (s *CachingUserService) GetServiceAccount(ctx context.Context, username string) (User, error) {
+	return s.service.GetServiceAccount(ctx, username)
+}
+
+// invalidate also runs when disabling failed, it may have been written anyway.
+func (s *CachingUserService) invalidate(ctx context.Context, username string) {
+	s.cache.Remove(username)
+	if err := s.invalidator.Invalidate(CacheName, username); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "Error invalidating the user on the other replicas", "username", username, "error", err)
+	}
+}
@@ -0,0 +1,78 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/cache"
+	"testing"
+)
+
+func newCachingUserService(t *testing.T) (*CachingUserService, *MockUserService, *cache.MockInvalidator) {
+	controller := gomock.NewController(t)
+	mockService := NewMockUserService(controller)
+	mockInvalidator := cache.NewMockInvalidator(controller)
+	c := cache.NewLRU[string, struct{}](CacheName, cache.Config{Size: 10, TTL: cache.DefaultTTL})
+	return NewCachingUserService(mockService, c, mockInvalidator), mockService, mockInvalidator
+}
+
+func TestProvideUserService(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockInvalidator := cache.NewMockInvalidator(controller)
+	service := NewUserService(nil)
+
+	assert.Same(t, service, ProvideUserService(service, mockInvalidator, cache.Config{}))
+
+	mockInvalidator.EXPECT().Register(CacheName, gomock.Any(), gomock.Any()).Times(1)
+	assert.IsType(t, &CachingUserService{}, ProvideUserService(service, mockInvalidator, cache.Config{Size: 10, TTL: cache.DefaultTTL}))
+	// the cache is shared
+	ProvideUserService(service, mockInvalidator, cache.Config{Size: 10, TTL: cache.DefaultTTL})
+}
+
+func TestCachingUserService_CheckEnabled(t *testing.T) {
+	service, mockService, _ := newCachingUserService(t)
+	mockService.EXPECT().CheckEnabled(gomock.Any(), "ann").Return(nil).Times(1)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, service.CheckEnabled(context.Background(), "ann"))
+	}
+	assert.Equal(t, uint64(1), service.cache.Stats().Hits)
+	assert.Equal(t, uint64(1), service.cache.Stats().Misses)
+
+	// disabled and unknown users are checked every time
+	mockService.EXPECT().CheckEnabled(gomock.Any(), "bob").Return(ErrDisabled).Times(2)
+	for i := 0; i < 2; i++ {
+		assert.ErrorIs(t, service.CheckEnabled(context.Background(), "bob"), ErrDisabled)
+	}
+}
+
+func TestCachingUserService_DisableUser(t *testing.T) {
+	testCases := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{
+			name: "Disables The User",
+		},
+		{
+			name:    "Invalidates When Disabling Failed",
+			err:     errors.New("BOOM"),
+			wantErr: errors.New("BOOM"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, mockService, mockInvalidator := newCachingUserService(t)
+			service.cache.Add("ann", struct{}{})
+			mockService.EXPECT().DisableUser(gomock.Any(), "ann", "admin").Return(User{Username: "ann", Disabled: tc.err == nil}, tc.err)
+			mockInvalidator.EXPECT().Invalidate(CacheName, "ann").Return(nil)
+
+			_, err := service.DisableUser(context.Background(), "ann", "admin")
+			assert.Equal(t, tc.wantErr, err)
+			_, ok := service.cache.Get("ann")
+			assert.False(t, ok)
+		})
+	}
+}
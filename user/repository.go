@@ -0,0 +1,104 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"inventory-service-go/commons"
+	"inventory-service-go/metrics"
+	"inventory-service-go/tracing"
+	"time"
+)
+
+// UserRow is a user, PasswordHash being the hash of the client secret of a service account.
+type UserRow struct {
+	Id             int64        `db:"id"`
+	AltId          uuid.UUID    `db:"alt_id"`
+	Username       string       `db:"username"`
+	PasswordHash   string       `db:"password_hash"`
+	ServiceAccount bool         `db:"service_account"`
+	DisabledAt     sql.NullTime `db:"disabled_at"`
+	CreatedBy      string       `db:"created_by"`
+	CreatedAt      time.Time    `db:"created_at"`
+	LastChangedBy  string       `db:"last_changed_by"`
+	LastUpdate     time.Time    `db:"last_update"`
+	TenantId       uuid.UUID    `db:"tenant_id"`
+}
+
+// CreateUserRequest takes the password in the clear, only its hash is stored. Service accounts have none. A user
+// without a Tenant is of the tenant creating it.
+type CreateUserRequest struct {
+	Username       string    `json:"username"`
+	Password       string    `json:"password"`
+	ServiceAccount bool      `json:"service_account"`
+	Tenant         uuid.UUID `json:"tenant_id"`
+	CreatedBy      string    `json:"created_by" audit:"actor"`
+}
+
+const (
+	CreateUserQuery            = `INSERT INTO users (username, password_hash, service_account, created_by, last_changed_by, tenant_id) VALUES ($1, $2, $3, $4, $4, $5) RETURNING *`
+	GetUserQuery               = `SELECT * FROM users WHERE username = $1`
+	GetAllUsersQuery           = `SELECT * FROM users ORDER BY id`
+	GetAllUsersPaginationQuery = `SELECT * FROM users WHERE id > $1 ORDER BY id LIMIT $2`
+	DisableUserQuery           = `UPDATE users SET disabled_at = coalesce(disabled_at, now()), last_changed_by = $2, last_update = now() WHERE username = $1 RETURNING *`
+	SetClientSecretQuery       = `UPDATE users SET password_hash = $2, last_changed_by = $3, last_update = now() WHERE username = $1 AND service_account RETURNING *`
+)
+
+type UserRepository interface {
+	CreateUser(ctx context.Context, request CreateUserRequest, passwordHash string) (UserRow, error)
+	GetUser(ctx context.Context, username string) (UserRow, error)
+	GetUsers(ctx context.Context, pagination *commons.Pagination) ([]UserRow, error)
+	DisableUser(ctx context.Context, username string, changedBy string) (UserRow, error)
+	// SetClientSecret replaces the hash of the client secret of a service account.
+	SetClientSecret(ctx context.Context, username string, secretHash string, changedBy string) (UserRow, error)
+}
+
+type UserRepositoryImpl struct {
+	db *commons.TenantDB
+}
+
+func NewUserRepository(db *sqlx.DB) *UserRepositoryImpl {
+	return &UserRepositoryImpl{db: commons.NewTenantDB(db)}
+}
+
+func (r *UserRepositoryImpl) CreateUser(ctx context.Context, request CreateUserRequest, passwordHash string) (UserRow, error) {
+	defer metrics.ObserveQuery("user", "CreateUser")()
+	var row UserRow
+	err := r.db.GetContext(tracing.Statement(ctx, "CreateUser"), &row, CreateUserQuery, request.Username, passwordHash, request.ServiceAccount, request.CreatedBy, request.Tenant)
+	return row, err
+}
+
+func (r *UserRepositoryImpl) GetUser(ctx context.Context, username string) (UserRow, error) {
+	defer metrics.ObserveQuery("user", "GetUser")()
+	var row UserRow
+	err := r.db.GetContext(tracing.Statement(ctx, "GetUser"), &row, GetUserQuery, username)
+	return row, err
+}
+
+func (r *UserRepositoryImpl) GetUsers(ctx context.Context, pagination *commons.Pagination) ([]UserRow, error) {
+	defer metrics.ObserveQuery("user", "GetUsers")()
+	var rows []UserRow
+	var err error
+	if pagination != nil {
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetUsersPage"), &rows, GetAllUsersPaginationQuery, pagination.LastId, pagination.PageSize)
+	} else {
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetUsers"), &rows, GetAllUsersQuery)
+	}
+	return rows, err
+}
+
+// DisableUser keeps the time the user was first disabled.
+func (r *UserRepositoryImpl) DisableUser(ctx context.Context, username string, changedBy string) (UserRow, error) {
+	defer metrics.ObserveQuery("user", "DisableUser")()
+	var row UserRow
+	err := r.db.GetContext(tracing.Statement(ctx, "DisableUser"), &row, DisableUserQuery, username, changedBy)
+	return row, err
+}
+
+func (r *UserRepositoryImpl) SetClientSecret(ctx context.Context, username string, secretHash string, changedBy string) (UserRow, error) {
+	defer metrics.ObserveQuery("user", "SetClientSecret")()
+	var row UserRow
+	err := r.db.GetContext(tracing.Statement(ctx, "SetClientSecret"), &row, SetClientSecretQuery, username, secretHash, changedBy)
+	return row, err
+}
@@ -0,0 +1,119 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+var userColumns = []string{"id", "alt_id", "username", "password_hash", "service_account", "disabled_at", "created_by", "created_at", "last_changed_by", "last_update"}
+
+func TestUserRepositoryImpl_CreateUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	newUuid := uuid.New()
+	request := CreateUserRequest{Username: "ann", Password: "secret-password", Tenant: commons.DefaultTenant, CreatedBy: "unit_test"}
+	testCases := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"Successful User Creation", false},
+		{"Failed User Creation", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expectation := mock.ExpectQuery("INSERT INTO users").WithArgs("ann", "hash", false, "unit_test", commons.DefaultTenant)
+			if tc.wantErr {
+				expectation.WillReturnError(errors.New("error"))
+			} else {
+				expectation.WillReturnRows(sqlmock.NewRows(userColumns).
+					AddRow(1, newUuid, "ann", "hash", false, nil, "unit_test", now, "unit_test", now))
+			}
+
+			r := NewUserRepository(sqlx.NewDb(db, "mockDb"))
+
+			result, err := r.CreateUser(context.Background(), request, "hash")
+			if tc.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, newUuid, result.AltId)
+				assert.False(t, result.DisabledAt.Valid)
+			}
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepositoryImpl_GetUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	mock.ExpectQuery("SELECT \\* FROM users ORDER BY id").
+		WillReturnRows(sqlmock.NewRows(userColumns).AddRow(1, uuid.New(), "ann", "hash", false, nil, "unit_test", now, "unit_test", now))
+	mock.ExpectQuery("SELECT \\* FROM users WHERE id > \\$1 ORDER BY id LIMIT \\$2").WithArgs(1, 10).
+		WillReturnRows(sqlmock.NewRows(userColumns).AddRow(2, uuid.New(), "billing", "", true, nil, "unit_test", now, "unit_test", now))
+
+	r := NewUserRepository(sqlx.NewDb(db, "mockDb"))
+
+	rows, err := r.GetUsers(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+	rows, err = r.GetUsers(context.Background(), &commons.Pagination{LastId: 1, PageSize: 10})
+	assert.Nil(t, err)
+	assert.True(t, rows[0].ServiceAccount)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_DisableUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	mock.ExpectQuery("UPDATE users SET disabled_at = coalesce\\(disabled_at, now\\(\\)\\)").WithArgs("ann", "unit_test").
+		WillReturnRows(sqlmock.NewRows(userColumns).AddRow(1, uuid.New(), "ann", "hash", false, now, "unit_test", now, "unit_test", now))
+	mock.ExpectQuery("UPDATE users").WithArgs("nobody", "unit_test").WillReturnRows(sqlmock.NewRows(userColumns))
+
+	r := NewUserRepository(sqlx.NewDb(db, "mockDb"))
+
+	row, err := r.DisableUser(context.Background(), "ann", "unit_test")
+	assert.Nil(t, err)
+	assert.True(t, row.DisabledAt.Valid)
+	_, err = r.DisableUser(context.Background(), "nobody", "unit_test")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_SetClientSecret(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	mock.ExpectQuery("UPDATE users SET password_hash = \\$2").WithArgs("billing", "hash", "unit_test").
+		WillReturnRows(sqlmock.NewRows(userColumns).AddRow(1, uuid.New(), "billing", "hash", true, nil, "unit_test", now, "unit_test", now))
+	mock.ExpectQuery("UPDATE users").WithArgs("ann", "hash", "unit_test").WillReturnRows(sqlmock.NewRows(userColumns))
+
+	r := NewUserRepository(sqlx.NewDb(db, "mockDb"))
+
+	row, err := r.SetClientSecret(context.Background(), "billing", "hash", "unit_test")
+	assert.Nil(t, err)
+	assert.Equal(t, "hash", row.PasswordHash)
+	// not a service account
+	_, err = r.SetClientSecret(context.Background(), "ann", "hash", "unit_test")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
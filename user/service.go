@@ -0,0 +1,245 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"inventory-service-go/auth"
+	"inventory-service-go/commons"
+	"strings"
+	"time"
+)
+
+// MinPasswordLength is the shortest password a user is created with.
+const MinPasswordLength = 8
+
+var (
+	ErrInvalidUser = commons.NewValidationError("invalid user")
+	// ErrInvalidCredentials does not tell an unknown user, a wrong password and a disabled user apart.
+	ErrInvalidCredentials = auth.ErrInvalidCredentials
+	ErrDisabled           = errors.New("user is disabled")
+	ErrNotServiceAccount  = errors.New("user is not a service account")
+)
+
+// dummyHash is compared against when the user does not exist, so the response time does not tell whether it does.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a password"), bcrypt.DefaultCost)
+
+type User struct {
+	Seq            int               `json:"seq"`
+	Id             uuid.UUID         `json:"id"`
+	Username       string            `json:"username"`
+	ServiceAccount bool              `json:"service_account"`
+	Disabled       bool              `json:"disabled"`
+	DisabledAt     string            `json:"disabled_at,omitempty"`
+	Tenant         uuid.UUID         `json:"tenant_id"`
+	AuditInfo      commons.AuditInfo `json:"audit_info"`
+}
+
+func fromRow(row UserRow) User {
+	u := User{
+		Seq:            int(row.Id),
+		Id:             row.AltId,
+		Username:       row.Username,
+		ServiceAccount: row.ServiceAccount,
+		Disabled:       row.DisabledAt.Valid,
+		Tenant:         row.TenantId,
+		AuditInfo: commons.AuditInfo{
+			CreatedBy:      row.CreatedBy,
+			CreatedAt:      row.CreatedAt.Format(time.RFC3339),
+			LastChangedBy:  row.LastChangedBy,
+			LastUpdate:     row.LastUpdate.Format(time.RFC3339),
+			CreatedTime:    row.CreatedAt,
+			LastUpdateTime: row.LastUpdate,
+		},
+	}
+	if row.DisabledAt.Valid {
+		u.DisabledAt = row.DisabledAt.Time.Format(time.RFC3339)
+	}
+	return u
+}
+
+type UserService interface {
+	CreateUser(ctx context.Context, request CreateUserRequest) (User, error)
+	GetUser(ctx context.Context, username string) (User, error)
+	GetUsers(ctx context.Context, pagination *commons.Pagination) ([]User, error)
+	// DisableUser keeps the user from signing in, the tokens issued before are no longer valid either.
+	DisableUser(ctx context.Context, username string, changedBy string) (User, error)
+	// VerifyCredentials checks the password of an enabled user and returns the tenant of the user, service accounts
+	// never sign in with a password.
+	VerifyCredentials(ctx context.Context, username string, password string) (uuid.UUID, error)
+	// VerifyClient checks the client secret of an enabled service account and returns its tenant, for the client
+	// credentials grant.
+	VerifyClient(ctx context.Context, username string, secret string) (uuid.UUID, error)
+	// CheckEnabled fails with ErrDisabled for a disabled user and ErrInvalidCredentials for an unknown one, for the
+	// tokens issued to them.
+	CheckEnabled(ctx context.Context, username string) error
+	// RotateClientSecret gives a service account a new client secret, which replaces the one before. Only its hash is
+	// stored, the secret is returned once.
+	RotateClientSecret(ctx context.Context, username string, changedBy string) (string, error)
+	// GetServiceAccount returns the service account tokens may be issued for.
+	GetServiceAccount(ctx context.Context, username string) (User, error)
+}
+
+type UserServiceImpl struct {
+	repo UserRepository
+}
+
+func NewUserService(repo UserRepository) *UserServiceImpl {
+	return &UserServiceImpl{repo: repo}
+}
+
+func (s *UserServiceImpl) CreateUser(ctx context.Context, request CreateUserRequest) (User, error) {
+	request.Username = strings.TrimSpace(request.Username)
+	if request.Username == "" || len(request.Username) > 255 {
+		return User{}, fmt.Errorf("%w: username must be between 1 and 255 characters", ErrInvalidUser)
+	}
+	var hash []byte
+	if request.ServiceAccount {
+		if request.Password != "" {
+			return User{}, fmt.Errorf("%w: service accounts have no password", ErrInvalidUser)
+		}
+	} else {
+		if len(request.Password) < MinPasswordLength {
+			return User{}, fmt.Errorf("%w: password must be at least %d characters", ErrInvalidUser, MinPasswordLength)
+		}
+		var err error
+		if hash, err = bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost); err != nil {
+			return User{}, fmt.Errorf("%w: %v", ErrInvalidUser, err)
+		}
+	}
+	if request.Tenant == uuid.Nil {
+		request.Tenant = tenantOf(ctx)
+	}
+	row, err := s.repo.CreateUser(ctx, request, string(hash))
+	if err != nil {
+		return User{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *UserServiceImpl) GetUser(ctx context.Context, username string) (User, error) {
+	row, err := s.repo.GetUser(ctx, username)
+	if err != nil {
+		return User{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *UserServiceImpl) GetUsers(ctx context.Context, pagination *commons.Pagination) ([]User, error) {
+	rows, err := s.repo.GetUsers(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]User, len(rows))
+	for i, row := range rows {
+		users[i] = fromRow(row)
+	}
+	return users, nil
+}
+
+func (s *UserServiceImpl) DisableUser(ctx context.Context, username string, changedBy string) (User, error) {
+	row, err := s.repo.DisableUser(ctx, username, changedBy)
+	if err != nil {
+		return User{}, err
+	}
+	return fromRow(row), nil
+}
+
+func (s *UserServiceImpl) VerifyCredentials(ctx context.Context, username string, password string) (uuid.UUID, error) {
+	// the tenant is not known before the user is, see commons.AsSystem
+	row, err := s.repo.GetUser(commons.AsSystem(ctx), username)
+	if errors.Is(err, sql.ErrNoRows) {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return uuid.Nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if row.DisabledAt.Valid || row.ServiceAccount || row.PasswordHash == "" {
+		return uuid.Nil, ErrInvalidCredentials
+	}
+	if bcrypt.CompareHashAndPassword([]byte(row.PasswordHash), []byte(password)) != nil {
+		return uuid.Nil, ErrInvalidCredentials
+	}
+	return row.TenantId, nil
+}
+
+func (s *UserServiceImpl) VerifyClient(ctx context.Context, username string, secret string) (uuid.UUID, error) {
+	row, err := s.repo.GetUser(commons.AsSystem(ctx), username)
+	if errors.Is(err, sql.ErrNoRows) {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(secret))
+		return uuid.Nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	// a service account without a secret only has the tokens minted for it
+	if row.DisabledAt.Valid || !row.ServiceAccount || row.PasswordHash == "" {
+		return uuid.Nil, ErrInvalidCredentials
+	}
+	if bcrypt.CompareHashAndPassword([]byte(row.PasswordHash), []byte(secret)) != nil {
+		return uuid.Nil, ErrInvalidCredentials
+	}
+	return row.TenantId, nil
+}
+
+func (s *UserServiceImpl) CheckEnabled(ctx context.Context, username string) error {
+	// the tokens of every tenant are checked, see commons.AsSystem
+	row, err := s.repo.GetUser(commons.AsSystem(ctx), username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidCredentials
+	}
+	if err != nil {
+		return err
+	}
+	if row.DisabledAt.Valid {
+		return ErrDisabled
+	}
+	return nil
+}
+
+func (s *UserServiceImpl) RotateClientSecret(ctx context.Context, username string, changedBy string) (string, error) {
+	if _, err := s.GetServiceAccount(ctx, username); err != nil {
+		return "", err
+	}
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(random)
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	if _, err = s.repo.SetClientSecret(ctx, username, string(hash), changedBy); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// tenantOf is the tenant of ctx, the default tenant for the system.
+func tenantOf(ctx context.Context) uuid.UUID {
+	if tenant, ok := commons.TenantFrom(ctx); ok {
+		return tenant
+	}
+	return commons.DefaultTenant
+}
+
+func (s *UserServiceImpl) GetServiceAccount(ctx context.Context, username string) (User, error) {
+	u, err := s.GetUser(ctx, username)
+	if err != nil {
+		return User{}, err
+	}
+	if !u.ServiceAccount {
+		return User{}, ErrNotServiceAccount
+	}
+	if u.Disabled {
+		return User{}, ErrDisabled
+	}
+	return u, nil
+}
@@ -0,0 +1,223 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+	"inventory-service-go/commons"
+	"testing"
+	"time"
+)
+
+func userRow(username string) UserRow {
+	now := time.Now()
+	return UserRow{Id: 1, AltId: uuid.New(), TenantId: uuid.New(), Username: username, CreatedBy: "unit_test", CreatedAt: now, LastChangedBy: "unit_test", LastUpdate: now}
+}
+
+func TestUserService_CreateUser(t *testing.T) {
+	testCases := []struct {
+		name     string
+		request  CreateUserRequest
+		wantErr  error
+		mockFunc func(mockRepo *MockUserRepository)
+	}{
+		{
+			name:    "Create User Successfully",
+			request: CreateUserRequest{Username: " ann ", Password: "secret-password", CreatedBy: "unit_test"},
+			mockFunc: func(mockRepo *MockUserRepository) {
+				mockRepo.EXPECT().CreateUser(gomock.Any(), CreateUserRequest{Username: "ann", Password: "secret-password", Tenant: commons.DefaultTenant, CreatedBy: "unit_test"}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ CreateUserRequest, hash string) (UserRow, error) {
+						assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(hash), []byte("secret-password")))
+						return userRow("ann"), nil
+					})
+			},
+		},
+		{
+			name:    "Create Service Account Successfully",
+			request: CreateUserRequest{Username: "billing", ServiceAccount: true, CreatedBy: "unit_test"},
+			mockFunc: func(mockRepo *MockUserRepository) {
+				row := userRow("billing")
+				row.ServiceAccount = true
+				mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any(), "").Return(row, nil)
+			},
+		},
+		{
+			name:     "No Username",
+			request:  CreateUserRequest{Username: " ", Password: "secret-password"},
+			wantErr:  ErrInvalidUser,
+			mockFunc: func(*MockUserRepository) {},
+		},
+		{
+			name:     "Short Password",
+			request:  CreateUserRequest{Username: "ann", Password: "short"},
+			wantErr:  ErrInvalidUser,
+			mockFunc: func(*MockUserRepository) {},
+		},
+		{
+			name:     "Service Account With Password",
+			request:  CreateUserRequest{Username: "billing", Password: "secret-password", ServiceAccount: true},
+			wantErr:  ErrInvalidUser,
+			mockFunc: func(*MockUserRepository) {},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := NewMockUserRepository(gomock.NewController(t))
+			tc.mockFunc(mockRepo)
+			service := NewUserService(mockRepo)
+
+			created, err := service.CreateUser(context.Background(), tc.request)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.request.ServiceAccount, created.ServiceAccount)
+				assert.False(t, created.Disabled)
+			}
+		})
+	}
+}
+
+func TestUserService_VerifyCredentials(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret-password"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	enabled := userRow("ann")
+	enabled.PasswordHash = string(hash)
+	disabled := enabled
+	disabled.DisabledAt = sql.NullTime{Time: time.Now(), Valid: true}
+	serviceAccount := userRow("billing")
+	serviceAccount.ServiceAccount = true
+
+	mockRepo := NewMockUserRepository(gomock.NewController(t))
+	mockRepo.EXPECT().GetUser(gomock.Any(), "ann").Return(enabled, nil).Times(2)
+	mockRepo.EXPECT().GetUser(gomock.Any(), "disabled").Return(disabled, nil)
+	mockRepo.EXPECT().GetUser(gomock.Any(), "billing").Return(serviceAccount, nil)
+	mockRepo.EXPECT().GetUser(gomock.Any(), "nobody").Return(UserRow{}, sql.ErrNoRows)
+	service := NewUserService(mockRepo)
+
+	tenant, err := service.VerifyCredentials(context.Background(), "ann", "secret-password")
+	assert.NoError(t, err)
+	assert.Equal(t, enabled.TenantId, tenant)
+	_, err = service.VerifyCredentials(context.Background(), "ann", "wrong-password")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = service.VerifyCredentials(context.Background(), "disabled", "secret-password")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = service.VerifyCredentials(context.Background(), "nobody", "secret-password")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = service.VerifyCredentials(context.Background(), "billing", "")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestUserService_VerifyClient(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("client-secret"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	serviceAccount := userRow("billing")
+	serviceAccount.ServiceAccount, serviceAccount.PasswordHash = true, string(hash)
+	disabled := serviceAccount
+	disabled.DisabledAt = sql.NullTime{Time: time.Now(), Valid: true}
+	noSecret := userRow("nightly")
+	noSecret.ServiceAccount = true
+	person := userRow("ann")
+	person.PasswordHash = string(hash)
+
+	mockRepo := NewMockUserRepository(gomock.NewController(t))
+	mockRepo.EXPECT().GetUser(gomock.Any(), "billing").Return(serviceAccount, nil).Times(2)
+	mockRepo.EXPECT().GetUser(gomock.Any(), "retired").Return(disabled, nil)
+	mockRepo.EXPECT().GetUser(gomock.Any(), "nightly").Return(noSecret, nil)
+	mockRepo.EXPECT().GetUser(gomock.Any(), "ann").Return(person, nil)
+	mockRepo.EXPECT().GetUser(gomock.Any(), "nobody").Return(UserRow{}, sql.ErrNoRows)
+	service := NewUserService(mockRepo)
+
+	tenant, err := service.VerifyClient(context.Background(), "billing", "client-secret")
+	assert.NoError(t, err)
+	assert.Equal(t, serviceAccount.TenantId, tenant)
+	_, err = service.VerifyClient(context.Background(), "billing", "wrong-secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = service.VerifyClient(context.Background(), "retired", "client-secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = service.VerifyClient(context.Background(), "nightly", "")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	// users sign in with /api/v1/authorize
+	_, err = service.VerifyClient(context.Background(), "ann", "client-secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = service.VerifyClient(context.Background(), "nobody", "client-secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestUserService_CheckEnabled(t *testing.T) {
+	disabled := userRow("retired")
+	disabled.DisabledAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	mockRepo := NewMockUserRepository(gomock.NewController(t))
+	mockRepo.EXPECT().GetUser(gomock.Any(), "ann").Return(userRow("ann"), nil)
+	mockRepo.EXPECT().GetUser(gomock.Any(), "retired").Return(disabled, nil)
+	mockRepo.EXPECT().GetUser(gomock.Any(), "nobody").Return(UserRow{}, sql.ErrNoRows)
+	service := NewUserService(mockRepo)
+
+	assert.NoError(t, service.CheckEnabled(context.Background(), "ann"))
+	assert.ErrorIs(t, service.CheckEnabled(context.Background(), "retired"), ErrDisabled)
+	assert.ErrorIs(t, service.CheckEnabled(context.Background(), "nobody"), ErrInvalidCredentials)
+}
+
+func TestUserService_RotateClientSecret(t *testing.T) {
+	serviceAccount := userRow("billing")
+	serviceAccount.ServiceAccount = true
+
+	mockRepo := NewMockUserRepository(gomock.NewController(t))
+	mockRepo.EXPECT().GetUser(gomock.Any(), "billing").Return(serviceAccount, nil)
+	mockRepo.EXPECT().GetUser(gomock.Any(), "ann").Return(userRow("ann"), nil)
+	var stored string
+	mockRepo.EXPECT().SetClientSecret(gomock.Any(), "billing", gomock.Any(), "unit_test").
+		DoAndReturn(func(_ context.Context, _ string, secretHash string, _ string) (UserRow, error) {
+			stored = secretHash
+			return serviceAccount, nil
+		})
+	service := NewUserService(mockRepo)
+
+	secret, err := service.RotateClientSecret(context.Background(), "billing", "unit_test")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, secret)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(stored), []byte(secret)))
+	_, err = service.RotateClientSecret(context.Background(), "ann", "unit_test")
+	assert.ErrorIs(t, err, ErrNotServiceAccount)
+}
+
+func TestUserService_GetServiceAccount(t *testing.T) {
+	serviceAccount := userRow("billing")
+	serviceAccount.ServiceAccount = true
+	disabled := serviceAccount
+	disabled.DisabledAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	mockRepo := NewMockUserRepository(gomock.NewController(t))
+	mockRepo.EXPECT().GetUser(gomock.Any(), "billing").Return(serviceAccount, nil)
+	mockRepo.EXPECT().GetUser(gomock.Any(), "retired").Return(disabled, nil)
+	mockRepo.EXPECT().GetUser(gomock.Any(), "ann").Return(userRow("ann"), nil)
+	mockRepo.EXPECT().GetUser(gomock.Any(), "nobody").Return(UserRow{}, sql.ErrNoRows)
+	service := NewUserService(mockRepo)
+
+	u, err := service.GetServiceAccount(context.Background(), "billing")
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", u.Username)
+	_, err = service.GetServiceAccount(context.Background(), "retired")
+	assert.ErrorIs(t, err, ErrDisabled)
+	_, err = service.GetServiceAccount(context.Background(), "ann")
+	assert.ErrorIs(t, err, ErrNotServiceAccount)
+	_, err = service.GetServiceAccount(context.Background(), "nobody")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUserService_DisableUser(t *testing.T) {
+	row := userRow("ann")
+	row.DisabledAt = sql.NullTime{Time: time.Now(), Valid: true}
+	mockRepo := NewMockUserRepository(gomock.NewController(t))
+	mockRepo.EXPECT().DisableUser(gomock.Any(), "ann", "unit_test").Return(row, nil)
+	service := NewUserService(mockRepo)
+
+	u, err := service.DisableUser(context.Background(), "ann", "unit_test")
+	assert.NoError(t, err)
+	assert.True(t, u.Disabled)
+	assert.NotEmpty(t, u.DisabledAt)
+}
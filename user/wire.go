@@ -0,0 +1,23 @@
+//go:build wireinject
+// +build wireinject
+
+package user
+
+import (
+	"github.com/google/wire"
+	"inventory-service-go/cache"
+	"inventory-service-go/commons"
+)
+
+func InitializeUserService(settings cache.Config) (UserService, error) {
+	wire.Build(
+		ProvideUserService,
+		NewUserService,
+		NewUserRepository,
+		cache.GetBus,
+		commons.GetDB,
+		wire.Bind(new(cache.Invalidator), new(*cache.Bus)),
+		wire.Bind(new(UserRepository), new(*UserRepositoryImpl)),
+	)
+	return nil, nil
+}
@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"context"
+	"inventory-service-go/commons"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const DefaultInterval = 5 * time.Second
+
+// Dispatcher posts the due webhook deliveries on every tick of the interval.
+type Dispatcher struct {
+	service  WebhookService
+	interval time.Duration
+	stop     chan struct{}
+	done     sync.WaitGroup
+}
+
+func NewDispatcher(service WebhookService, interval time.Duration) *Dispatcher {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Dispatcher{service: service, interval: interval}
+}
+
+func (d *Dispatcher) Start() {
+	d.stop = make(chan struct{})
+	d.done.Add(1)
+	go func() {
+		defer d.done.Done()
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+		for {
+			d.RunOnce()
+			select {
+			case <-ticker.C:
+			case <-d.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop waits for a dispatch in progress to finish.
+func (d *Dispatcher) Stop() {
+	close(d.stop)
+	d.done.Wait()
+}
+
+// RunOnce dispatches batches until no more deliveries are due.
+func (d *Dispatcher) RunOnce() {
+	for {
+		dispatched, err := d.service.DispatchDue(commons.AsSystem(context.Background()))
+		if err != nil {
+			// the failed deliveries were recorded, or stay claimed until their lease is over, so the next batch
+			// holds others
+			slog.Error("Error dispatching webhooks", "error", err)
+		}
+		if dispatched < batchSize {
+			return
+		}
+	}
+}
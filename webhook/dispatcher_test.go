@@ -0,0 +1,43 @@
+package webhook
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestDispatcher_RunOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := NewMockWebhookService(ctrl)
+	d := NewDispatcher(mockService, time.Minute)
+
+	gomock.InOrder(
+		mockService.EXPECT().DispatchDue(gomock.Any()).Return(batchSize, nil),
+		mockService.EXPECT().DispatchDue(gomock.Any()).Return(3, nil),
+	)
+	d.RunOnce()
+}
+
+func TestDispatcher_RunOnce_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := NewMockWebhookService(ctrl)
+	d := NewDispatcher(mockService, 0)
+	assert.Equal(t, DefaultInterval, d.interval)
+
+	mockService.EXPECT().DispatchDue(gomock.Any()).Return(0, errors.New("BOOM"))
+	d.RunOnce()
+}
+
+func TestDispatcher_RunOnce_ContinuesAfterAFailedDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockService := NewMockWebhookService(ctrl)
+	d := NewDispatcher(mockService, time.Minute)
+
+	gomock.InOrder(
+		mockService.EXPECT().DispatchDue(gomock.Any()).Return(batchSize, errors.New("BOOM")),
+		mockService.EXPECT().DispatchDue(gomock.Any()).Return(0, nil),
+	)
+	d.RunOnce()
+}
@@ -0,0 +1,260 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"inventory-service-go/commons"
+	"inventory-service-go/metrics"
+	"inventory-service-go/outbox"
+	"inventory-service-go/tracing"
+	"time"
+)
+
+type SubscriptionRow struct {
+	Id            int64     `db:"id"`
+	AltId         uuid.UUID `db:"alt_id"`
+	Url           string    `db:"url"`
+	Secret        string    `db:"secret"`
+	Active        bool      `db:"active"`
+	CreatedBy     string    `db:"created_by"`
+	CreatedAt     time.Time `db:"created_at"`
+	LastChangedBy string    `db:"last_changed_by"`
+	LastUpdate    time.Time `db:"last_update"`
+	TenantId      uuid.UUID `db:"tenant_id"`
+}
+
+type SubscriptionEventRow struct {
+	SubscriptionId uuid.UUID `db:"subscription_id"`
+	EventType      string    `db:"event_type"`
+	TenantId       uuid.UUID `db:"tenant_id"`
+}
+
+// DeliveryRow is one event to be posted to one subscription, along with the outcome of the last attempt.
+type DeliveryRow struct {
+	Id             int64          `db:"id"`
+	AltId          uuid.UUID      `db:"alt_id"`
+	SubscriptionId uuid.UUID      `db:"subscription_id"`
+	EventId        uuid.UUID      `db:"event_id"`
+	EventType      string         `db:"event_type"`
+	Payload        []byte         `db:"payload"`
+	Status         string         `db:"status"`
+	Attempts       int            `db:"attempts"`
+	LastStatusCode sql.NullInt32  `db:"last_status_code"`
+	LastError      sql.NullString `db:"last_error"`
+	NextAttemptAt  time.Time      `db:"next_attempt_at"`
+	DeliveredAt    sql.NullTime   `db:"delivered_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	LastUpdate     time.Time      `db:"last_update"`
+	TenantId       uuid.UUID      `db:"tenant_id"`
+}
+
+type CreateSubscriptionRequest struct {
+	Url        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	CreatedBy  string   `json:"created_by" audit:"actor"`
+}
+
+type UpdateSubscriptionRequest struct {
+	Id            uuid.UUID `json:"id"`
+	Url           string    `json:"url"`
+	EventTypes    []string  `json:"event_types"`
+	Active        bool      `json:"active"`
+	LastChangedBy string    `json:"last_changed_by" audit:"actor"`
+}
+
+// Attempt is the outcome of posting a delivery.
+type Attempt struct {
+	Status        string
+	StatusCode    sql.NullInt32
+	Error         sql.NullString
+	NextAttemptAt time.Time
+}
+
+const (
+	CreateSubscriptionQuery            = `INSERT INTO webhook_subscriptions (url, secret, created_by, last_changed_by) VALUES ($1, $2, $3, $3) RETURNING *`
+	UpdateSubscriptionQuery            = `UPDATE webhook_subscriptions SET url = $2, active = $3, last_changed_by = $4, last_update = now() WHERE alt_id = $1 RETURNING *`
+	DeleteSubscriptionQuery            = `DELETE FROM webhook_subscriptions WHERE alt_id = $1`
+	GetSubscriptionQuery               = `SELECT * FROM webhook_subscriptions WHERE alt_id = $1`
+	GetAllSubscriptionsQuery           = `SELECT * FROM webhook_subscriptions ORDER BY id`
+	GetAllSubscriptionsPaginationQuery = `SELECT * FROM webhook_subscriptions WHERE id > $1 ORDER BY id LIMIT $2`
+	GetSubscriptionsForEventQuery      = `SELECT s.* FROM webhook_subscriptions s JOIN webhook_subscription_events e ON e.subscription_id = s.alt_id WHERE e.event_type = $1 AND s.active ORDER BY s.id`
+	AddSubscriptionEventsQuery         = `INSERT INTO webhook_subscription_events (subscription_id, event_type) VALUES (:subscription_id, :event_type)`
+	DeleteSubscriptionEventsQuery      = `DELETE FROM webhook_subscription_events WHERE subscription_id = $1`
+	GetSubscriptionEventsQuery         = `SELECT * FROM webhook_subscription_events WHERE subscription_id = $1 ORDER BY event_type`
+	EnqueueDeliveryQuery               = `INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload) VALUES ($1, $2, $3, $4) ON CONFLICT (subscription_id, event_id) DO NOTHING`
+	ClaimDueDeliveriesQuery            = `UPDATE webhook_deliveries SET next_attempt_at = $2 WHERE id IN (SELECT id FROM webhook_deliveries WHERE status IN ('pending', 'failed') AND next_attempt_at <= now() ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED) RETURNING *`
+	RecordAttemptQuery                 = `UPDATE webhook_deliveries SET status = $2, attempts = attempts + 1, last_status_code = $3, last_error = $4, next_attempt_at = $5, delivered_at = CASE WHEN $2 = 'delivered' THEN now() ELSE delivered_at END, last_update = now() WHERE alt_id = $1 RETURNING *`
+	GetDeliveryQuery                   = `SELECT * FROM webhook_deliveries WHERE alt_id = $1`
+	GetDeliveriesQuery                 = `SELECT * FROM webhook_deliveries WHERE subscription_id = $1 AND ($2 = '' OR status = $2) ORDER BY id DESC`
+	GetDeliveriesByStatusQuery         = `SELECT * FROM webhook_deliveries WHERE status = $1 ORDER BY id DESC`
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, request CreateSubscriptionRequest, secret string) (SubscriptionRow, error)
+	UpdateSubscription(ctx context.Context, request UpdateSubscriptionRequest) (SubscriptionRow, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error)
+	GetSubscription(ctx context.Context, id uuid.UUID) (SubscriptionRow, error)
+	GetSubscriptions(ctx context.Context, pagination *commons.Pagination) ([]SubscriptionRow, error)
+	GetSubscriptionEvents(ctx context.Context, id uuid.UUID) ([]SubscriptionEventRow, error)
+	GetSubscriptionsForEvent(ctx context.Context, eventType string) ([]SubscriptionRow, error)
+	EnqueueDelivery(ctx context.Context, subscriptionId uuid.UUID, event outbox.Event) error
+	ClaimDueDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]DeliveryRow, error)
+	RecordAttempt(ctx context.Context, id uuid.UUID, attempt Attempt) (DeliveryRow, error)
+	GetDelivery(ctx context.Context, id uuid.UUID) (DeliveryRow, error)
+	GetDeliveries(ctx context.Context, subscriptionId uuid.UUID, status string) ([]DeliveryRow, error)
+	GetDeliveriesByStatus(ctx context.Context, status string) ([]DeliveryRow, error)
+}
+
+type WebhookRepositoryImpl struct {
+	db *commons.TenantDB
+}
+
+func NewWebhookRepository(db *sqlx.DB) *WebhookRepositoryImpl {
+	return &WebhookRepositoryImpl{db: commons.NewTenantDB(db)}
+}
+
+func eventRows(id uuid.UUID, eventTypes []string) []SubscriptionEventRow {
+	rows := make([]SubscriptionEventRow, len(eventTypes))
+	for i, eventType := range eventTypes {
+		rows[i] = SubscriptionEventRow{SubscriptionId: id, EventType: eventType}
+	}
+	return rows
+}
+
+func (r *WebhookRepositoryImpl) CreateSubscription(ctx context.Context, request CreateSubscriptionRequest, secret string) (SubscriptionRow, error) {
+	defer metrics.ObserveQuery("webhook", "CreateSubscription")()
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return SubscriptionRow{}, err
+	}
+	defer tx.Rollback()
+	var row SubscriptionRow
+	if err = tx.GetContext(tracing.Statement(ctx, "CreateSubscriptionQuery"), &row, CreateSubscriptionQuery, request.Url, secret, request.CreatedBy); err != nil {
+		return SubscriptionRow{}, err
+	}
+	if _, err = tx.NamedExecContext(tracing.Statement(ctx, "AddSubscriptionEventsQuery"), AddSubscriptionEventsQuery, eventRows(row.AltId, request.EventTypes)); err != nil {
+		return SubscriptionRow{}, err
+	}
+	return row, tx.Commit()
+}
+
+// UpdateSubscription replaces the event types of the subscription, unless none are given.
+func (r *WebhookRepositoryImpl) UpdateSubscription(ctx context.Context, request UpdateSubscriptionRequest) (SubscriptionRow, error) {
+	defer metrics.ObserveQuery("webhook", "UpdateSubscription")()
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return SubscriptionRow{}, err
+	}
+	defer tx.Rollback()
+	var row SubscriptionRow
+	if err = tx.GetContext(tracing.Statement(ctx, "UpdateSubscriptionQuery"), &row, UpdateSubscriptionQuery, request.Id, request.Url, request.Active, request.LastChangedBy); err != nil {
+		return SubscriptionRow{}, err
+	}
+	if len(request.EventTypes) > 0 {
+		if _, err = tx.ExecContext(tracing.Statement(ctx, "DeleteSubscriptionEventsQuery"), DeleteSubscriptionEventsQuery, request.Id); err != nil {
+			return SubscriptionRow{}, err
+		}
+		if _, err = tx.NamedExecContext(tracing.Statement(ctx, "AddSubscriptionEventsQuery"), AddSubscriptionEventsQuery, eventRows(request.Id, request.EventTypes)); err != nil {
+			return SubscriptionRow{}, err
+		}
+	}
+	return row, tx.Commit()
+}
+
+func (r *WebhookRepositoryImpl) DeleteSubscription(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	defer metrics.ObserveQuery("webhook", "DeleteSubscription")()
+	result, err := r.db.ExecContext(tracing.Statement(ctx, "DeleteSubscriptionQuery"), DeleteSubscriptionQuery, id)
+	if err != nil {
+		return commons.DeleteResult{}, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return commons.DeleteResult{
+		Id:      id,
+		Deleted: rowsAffected > 0,
+	}, nil
+}
+
+func (r *WebhookRepositoryImpl) GetSubscription(ctx context.Context, id uuid.UUID) (SubscriptionRow, error) {
+	defer metrics.ObserveQuery("webhook", "GetSubscription")()
+	var row SubscriptionRow
+	err := r.db.GetContext(tracing.Statement(ctx, "GetSubscriptionQuery"), &row, GetSubscriptionQuery, id)
+	return row, err
+}
+
+func (r *WebhookRepositoryImpl) GetSubscriptions(ctx context.Context, pagination *commons.Pagination) ([]SubscriptionRow, error) {
+	defer metrics.ObserveQuery("webhook", "GetSubscriptions")()
+	var rows []SubscriptionRow
+	var err error
+	if pagination == nil {
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetAllSubscriptionsQuery"), &rows, GetAllSubscriptionsQuery)
+	} else {
+		err = r.db.SelectContext(tracing.Statement(ctx, "GetAllSubscriptionsPaginationQuery"), &rows, GetAllSubscriptionsPaginationQuery, pagination.LastId, pagination.PageSize)
+	}
+	return rows, err
+}
+
+func (r *WebhookRepositoryImpl) GetSubscriptionEvents(ctx context.Context, id uuid.UUID) ([]SubscriptionEventRow, error) {
+	defer metrics.ObserveQuery("webhook", "GetSubscriptionEvents")()
+	var rows []SubscriptionEventRow
+	err := r.db.SelectContext(tracing.Statement(ctx, "GetSubscriptionEventsQuery"), &rows, GetSubscriptionEventsQuery, id)
+	return rows, err
+}
+
+func (r *WebhookRepositoryImpl) GetSubscriptionsForEvent(ctx context.Context, eventType string) ([]SubscriptionRow, error) {
+	defer metrics.ObserveQuery("webhook", "GetSubscriptionsForEvent")()
+	var rows []SubscriptionRow
+	err := r.db.SelectContext(tracing.Statement(ctx, "GetSubscriptionsForEventQuery"), &rows, GetSubscriptionsForEventQuery, eventType)
+	return rows, err
+}
+
+// EnqueueDelivery ignores an event the subscription already has a delivery for, the outbox delivers at least once.
+func (r *WebhookRepositoryImpl) EnqueueDelivery(ctx context.Context, subscriptionId uuid.UUID, event outbox.Event) error {
+	defer metrics.ObserveQuery("webhook", "EnqueueDelivery")()
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(tracing.Statement(ctx, "EnqueueDeliveryQuery"), EnqueueDeliveryQuery, subscriptionId, event.Id, event.Type, payload)
+	return err
+}
+
+// ClaimDueDeliveries leases the due deliveries until leaseUntil, so dispatchers running in several instances do not
+// post them twice, and a dispatcher that dies mid-way has its deliveries retried once the lease is up.
+func (r *WebhookRepositoryImpl) ClaimDueDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]DeliveryRow, error) {
+	defer metrics.ObserveQuery("webhook", "ClaimDueDeliveries")()
+	var rows []DeliveryRow
+	err := r.db.SelectContext(tracing.Statement(ctx, "ClaimDueDeliveriesQuery"), &rows, ClaimDueDeliveriesQuery, limit, leaseUntil)
+	return rows, err
+}
+
+func (r *WebhookRepositoryImpl) RecordAttempt(ctx context.Context, id uuid.UUID, attempt Attempt) (DeliveryRow, error) {
+	defer metrics.ObserveQuery("webhook", "RecordAttempt")()
+	var row DeliveryRow
+	err := r.db.GetContext(tracing.Statement(ctx, "RecordAttemptQuery"), &row, RecordAttemptQuery, id, attempt.Status, attempt.StatusCode, attempt.Error, attempt.NextAttemptAt)
+	return row, err
+}
+
+func (r *WebhookRepositoryImpl) GetDelivery(ctx context.Context, id uuid.UUID) (DeliveryRow, error) {
+	defer metrics.ObserveQuery("webhook", "GetDelivery")()
+	var row DeliveryRow
+	err := r.db.GetContext(tracing.Statement(ctx, "GetDeliveryQuery"), &row, GetDeliveryQuery, id)
+	return row, err
+}
+
+// GetDeliveries is the delivery log of a subscription, newest first, optionally only the ones with the given status.
+func (r *WebhookRepositoryImpl) GetDeliveries(ctx context.Context, subscriptionId uuid.UUID, status string) ([]DeliveryRow, error) {
+	defer metrics.ObserveQuery("webhook", "GetDeliveries")()
+	var rows []DeliveryRow
+	err := r.db.SelectContext(tracing.Statement(ctx, "GetDeliveriesQuery"), &rows, GetDeliveriesQuery, subscriptionId, status)
+	return rows, err
+}
+
+func (r *WebhookRepositoryImpl) GetDeliveriesByStatus(ctx context.Context, status string) ([]DeliveryRow, error) {
+	defer metrics.ObserveQuery("webhook", "GetDeliveriesByStatus")()
+	var rows []DeliveryRow
+	err := r.db.SelectContext(tracing.Statement(ctx, "GetDeliveriesByStatusQuery"), &rows, GetDeliveriesByStatusQuery, status)
+	return rows, err
+}
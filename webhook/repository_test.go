@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"inventory-service-go/outbox"
+	"testing"
+	"time"
+)
+
+var subscriptionColumns = []string{"id", "alt_id", "url", "secret", "active", "created_by", "created_at", "last_changed_by", "last_update"}
+
+var deliveryColumns = []string{"id", "alt_id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts", "last_status_code", "last_error", "next_attempt_at", "delivered_at", "created_at", "last_update"}
+
+func TestWebhookRepositoryImpl_CreateSubscription(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	newUuid := uuid.New()
+	request := CreateSubscriptionRequest{Url: "https://erp.example.com/hooks", EventTypes: []string{outbox.InvoicePaid, outbox.InvoiceCreated}, CreatedBy: "unit_test"}
+	testCases := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"Successful Subscription Creation", false},
+		{"Failed Subscription Creation", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			expectation := mock.ExpectQuery("INSERT INTO webhook_subscriptions").
+				WithArgs("https://erp.example.com/hooks", "whsec_test", "unit_test")
+			if tc.wantErr {
+				expectation.WillReturnError(errors.New("error"))
+				mock.ExpectRollback()
+			} else {
+				expectation.WillReturnRows(sqlmock.NewRows(subscriptionColumns).
+					AddRow(1, newUuid, "https://erp.example.com/hooks", "whsec_test", true, "unit_test", now, "unit_test", now))
+				mock.ExpectExec("INSERT INTO webhook_subscription_events").
+					WithArgs(newUuid, outbox.InvoicePaid, newUuid, outbox.InvoiceCreated).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			}
+
+			r := NewWebhookRepository(sqlx.NewDb(db, "mockDb"))
+
+			result, err := r.CreateSubscription(context.Background(), request, "whsec_test")
+			if tc.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, newUuid, result.AltId)
+				assert.True(t, result.Active)
+			}
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookRepositoryImpl_EnqueueDelivery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	subscriptionId := uuid.New()
+	event, err := outbox.NewEvent(outbox.InvoicePaid, "invoice", uuid.New(), map[string]bool{"paid": true})
+	assert.Nil(t, err)
+	mock.ExpectExec("INSERT INTO webhook_deliveries .+ ON CONFLICT \\(subscription_id, event_id\\) DO NOTHING").
+		WithArgs(subscriptionId, event.Id, outbox.InvoicePaid, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := NewWebhookRepository(sqlx.NewDb(db, "mockDb"))
+
+	assert.Nil(t, r.EnqueueDelivery(context.Background(), subscriptionId, event))
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepositoryImpl_ClaimDueDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+	mock.ExpectQuery("UPDATE webhook_deliveries SET next_attempt_at = \\$2 WHERE id IN \\(SELECT id FROM webhook_deliveries WHERE status IN \\('pending', 'failed'\\)").
+		WithArgs(50, leaseUntil).
+		WillReturnRows(sqlmock.NewRows(deliveryColumns).
+			AddRow(1, uuid.New(), uuid.New(), uuid.New(), outbox.InvoicePaid, []byte(`{}`), StatusFailed, 2, 503, "endpoint responded with 503", leaseUntil, nil, now, now))
+
+	r := NewWebhookRepository(sqlx.NewDb(db, "mockDb"))
+
+	rows, err := r.ClaimDueDeliveries(context.Background(), 50, leaseUntil)
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, sql.NullInt32{Int32: 503, Valid: true}, rows[0].LastStatusCode)
+	assert.Equal(t, 2, rows[0].Attempts)
+}
+
+func TestWebhookRepositoryImpl_RecordAttempt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	now := time.Now()
+	id := uuid.New()
+	attempt := Attempt{Status: StatusDelivered, StatusCode: sql.NullInt32{Int32: 200, Valid: true}, NextAttemptAt: now}
+	mock.ExpectQuery("UPDATE webhook_deliveries SET status = \\$2, attempts = attempts \\+ 1").
+		WithArgs(id, StatusDelivered, attempt.StatusCode, attempt.Error, now).
+		WillReturnRows(sqlmock.NewRows(deliveryColumns).
+			AddRow(1, id, uuid.New(), uuid.New(), outbox.InvoicePaid, []byte(`{}`), StatusDelivered, 1, 200, nil, now, now, now, now))
+
+	r := NewWebhookRepository(sqlx.NewDb(db, "mockDb"))
+
+	row, err := r.RecordAttempt(context.Background(), id, attempt)
+	assert.Nil(t, err)
+	assert.Equal(t, StatusDelivered, row.Status)
+	assert.True(t, row.DeliveredAt.Valid)
+}
@@ -0,0 +1,477 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"inventory-service-go/commons"
+	"inventory-service-go/config"
+	"inventory-service-go/outbox"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+	StatusDead      = "dead"
+)
+
+const (
+	DefaultMaxAttempts = 8
+	// MaxBackoff caps the wait between two attempts of the same delivery.
+	MaxBackoff = 6 * time.Hour
+	// leaseDuration is how long a claimed delivery is left alone, it outlasts the request timeout.
+	leaseDuration = time.Minute
+	batchSize     = 50
+)
+
+var (
+	ErrInvalidSubscription = commons.NewValidationError("invalid webhook subscription")
+	ErrInvalidStatus       = commons.NewValidationError("status must be pending, delivered, failed or dead")
+	// ErrForbiddenAddress is returned for an endpoint on a loopback, link-local, private or otherwise internal
+	// address, the partners' urls must not reach into the network the service runs in.
+	ErrForbiddenAddress = errors.New("webhook endpoints must not be on an internal address")
+)
+
+// Subscription is a partner endpoint and the event types posted to it. Secret is only returned when the
+// subscription is created, it keys the HMAC signature of every delivery.
+type Subscription struct {
+	Seq        int               `json:"seq"`
+	Id         uuid.UUID         `json:"id"`
+	Url        string            `json:"url"`
+	EventTypes []string          `json:"event_types"`
+	Active     bool              `json:"active"`
+	Secret     string            `json:"secret,omitempty"`
+	AuditInfo  commons.AuditInfo `json:"audit_info"`
+}
+
+type Delivery struct {
+	Id             uuid.UUID `json:"id"`
+	SubscriptionId uuid.UUID `json:"subscription_id"`
+	EventId        uuid.UUID `json:"event_id"`
+	EventType      string    `json:"event_type"`
+	Status         string    `json:"status"`
+	Attempts       int       `json:"attempts"`
+	LastStatusCode int       `json:"last_status_code,omitempty"`
+	LastError      string    `json:"last_error,omitempty"`
+	NextAttemptAt  string    `json:"next_attempt_at,omitempty"`
+	DeliveredAt    string    `json:"delivered_at,omitempty"`
+	CreatedAt      string    `json:"created_at"`
+}
+
+func fromRow(row SubscriptionRow, events []SubscriptionEventRow) Subscription {
+	s := Subscription{
+		Seq:        int(row.Id),
+		Id:         row.AltId,
+		Url:        row.Url,
+		EventTypes: []string{},
+		Active:     row.Active,
+		AuditInfo: commons.AuditInfo{
+			CreatedBy:      row.CreatedBy,
+			CreatedAt:      row.CreatedAt.Format(time.RFC3339),
+			LastChangedBy:  row.LastChangedBy,
+			LastUpdate:     row.LastUpdate.Format(time.RFC3339),
+			CreatedTime:    row.CreatedAt,
+			LastUpdateTime: row.LastUpdate,
+		},
+	}
+	for _, e := range events {
+		s.EventTypes = append(s.EventTypes, e.EventType)
+	}
+	return s
+}
+
+func deliveryFromRow(row DeliveryRow) Delivery {
+	d := Delivery{
+		Id:             row.AltId,
+		SubscriptionId: row.SubscriptionId,
+		EventId:        row.EventId,
+		EventType:      row.EventType,
+		Status:         row.Status,
+		Attempts:       row.Attempts,
+		LastStatusCode: int(row.LastStatusCode.Int32),
+		LastError:      row.LastError.String,
+		CreatedAt:      row.CreatedAt.Format(time.RFC3339),
+	}
+	if row.Status == StatusPending || row.Status == StatusFailed {
+		d.NextAttemptAt = row.NextAttemptAt.Format(time.RFC3339)
+	}
+	if row.DeliveredAt.Valid {
+		d.DeliveredAt = row.DeliveredAt.Time.Format(time.RFC3339)
+	}
+	return d
+}
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, request CreateSubscriptionRequest) (Subscription, error)
+	UpdateSubscription(ctx context.Context, request UpdateSubscriptionRequest) (Subscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error)
+	GetSubscription(ctx context.Context, id uuid.UUID) (Subscription, error)
+	GetSubscriptions(ctx context.Context, pagination *commons.Pagination) ([]Subscription, error)
+	// GetDeliveries is the delivery log of a subscription, optionally only the deliveries with the given status.
+	GetDeliveries(ctx context.Context, subscriptionId uuid.UUID, status string) ([]Delivery, error)
+	// GetDeadLetters lists the deliveries of all subscriptions that were given up on.
+	GetDeadLetters(ctx context.Context) ([]Delivery, error)
+	// Redeliver posts a delivery again right away, whatever its status.
+	Redeliver(ctx context.Context, subscriptionId uuid.UUID, deliveryId uuid.UUID) (Delivery, error)
+	// Name and Publish make the service an outbox.Sink, Publish queues a delivery of the event for every active
+	// subscription to its type.
+	Name() string
+	Publish(event outbox.Event) error
+	// DispatchDue posts the deliveries that are due, returning how many were attempted.
+	DispatchDue(ctx context.Context) (int, error)
+}
+
+type WebhookServiceImpl struct {
+	repo        WebhookRepository
+	client      *http.Client
+	maxAttempts int
+	now         func() time.Time
+}
+
+func NewWebhookService(repo WebhookRepository) *WebhookServiceImpl {
+	return &WebhookServiceImpl{
+		repo:        repo,
+		client:      newClient(),
+		maxAttempts: DefaultMaxAttempts,
+		now:         time.Now,
+	}
+}
+
+// NewFromConfig is the service dead-lettering a delivery after the attempts of the settings.
+func NewFromConfig(repo WebhookRepository, settings config.Webhooks) *WebhookServiceImpl {
+	service := NewWebhookService(repo)
+	if settings.MaxAttempts > 0 {
+		service.maxAttempts = settings.MaxAttempts
+	}
+	return service
+}
+
+// newClient dials public addresses only, checking the address actually dialed so a host name resolving to an
+// internal address is refused as well, and does not follow redirects, a 3xx response fails the delivery.
+func newClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: func(network, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		ip, err := netip.ParseAddr(host)
+		if err != nil {
+			return err
+		}
+		if internal(ip) {
+			return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip)
+		}
+		return nil
+	}}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// reservedPrefixes are the internal ranges netip has no predicate for: "this network", the shared address space of
+// carrier-grade NAT and the benchmarking networks.
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+}
+
+// internal tells whether the address is one a partner's endpoint must not be on, e.g. 127.0.0.1, 169.254.169.254
+// or 10.0.0.1.
+func internal(ip netip.Addr) bool {
+	// an IPv4-mapped IPv6 address, e.g. ::ffff:127.0.0.1, reaches the IPv4 address, so it is checked as that one
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() || ip.IsInterfaceLocalMulticast() {
+		return true
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff is 30 seconds after the first failed attempt, doubling with every further one.
+func backoff(attempts int) time.Duration {
+	if attempts >= 10 {
+		return MaxBackoff
+	}
+	return min(30*time.Second<<max(attempts-1, 0), MaxBackoff)
+}
+
+// validateUrl refuses the urls whose host is an internal address or localhost early, the host names resolving to one
+// are only refused when the delivery is posted, see newClient.
+func validateUrl(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https url", ErrInvalidSubscription)
+	}
+	if ip, err := netip.ParseAddr(u.Hostname()); (err == nil && internal(ip)) || strings.EqualFold(u.Hostname(), "localhost") {
+		return fmt.Errorf("%w: %s", ErrInvalidSubscription, ErrForbiddenAddress)
+	}
+	return nil
+}
+
+func normalizeEventTypes(eventTypes []string) ([]string, error) {
+	var normalized []string
+	for _, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if !slices.Contains(outbox.EventTypes, eventType) {
+			return nil, fmt.Errorf("%w: unknown event type %q", ErrInvalidSubscription, eventType)
+		}
+		if !slices.Contains(normalized, eventType) {
+			normalized = append(normalized, eventType)
+		}
+	}
+	return normalized, nil
+}
+
+func validateStatus(status string) error {
+	switch status {
+	case "", StatusPending, StatusDelivered, StatusFailed, StatusDead:
+		return nil
+	default:
+		return ErrInvalidStatus
+	}
+}
+
+func (s *WebhookServiceImpl) CreateSubscription(ctx context.Context, request CreateSubscriptionRequest) (Subscription, error) {
+	request.Url = strings.TrimSpace(request.Url)
+	if err := validateUrl(request.Url); err != nil {
+		return Subscription{}, err
+	}
+	eventTypes, err := normalizeEventTypes(request.EventTypes)
+	if err != nil {
+		return Subscription{}, err
+	}
+	if len(eventTypes) == 0 {
+		return Subscription{}, fmt.Errorf("%w: no event types", ErrInvalidSubscription)
+	}
+	request.EventTypes = eventTypes
+	secret, err := newSecret()
+	if err != nil {
+		return Subscription{}, err
+	}
+	row, err := s.repo.CreateSubscription(ctx, request, secret)
+	if err != nil {
+		return Subscription{}, err
+	}
+	subscription := fromRow(row, eventRows(row.AltId, request.EventTypes))
+	subscription.Secret = row.Secret
+	return subscription, nil
+}
+
+func (s *WebhookServiceImpl) UpdateSubscription(ctx context.Context, request UpdateSubscriptionRequest) (Subscription, error) {
+	request.Url = strings.TrimSpace(request.Url)
+	if err := validateUrl(request.Url); err != nil {
+		return Subscription{}, err
+	}
+	eventTypes, err := normalizeEventTypes(request.EventTypes)
+	if err != nil {
+		return Subscription{}, err
+	}
+	request.EventTypes = eventTypes
+	if _, err = s.repo.UpdateSubscription(ctx, request); err != nil {
+		return Subscription{}, err
+	}
+	return s.GetSubscription(ctx, request.Id)
+}
+
+func (s *WebhookServiceImpl) DeleteSubscription(ctx context.Context, id uuid.UUID) (commons.DeleteResult, error) {
+	return s.repo.DeleteSubscription(ctx, id)
+}
+
+func (s *WebhookServiceImpl) GetSubscription(ctx context.Context, id uuid.UUID) (Subscription, error) {
+	row, err := s.repo.GetSubscription(ctx, id)
+	if err != nil {
+		return Subscription{}, err
+	}
+	events, err := s.repo.GetSubscriptionEvents(ctx, id)
+	if err != nil {
+		return Subscription{}, err
+	}
+	return fromRow(row, events), nil
+}
+
+func (s *WebhookServiceImpl) GetSubscriptions(ctx context.Context, pagination *commons.Pagination) ([]Subscription, error) {
+	rows, err := s.repo.GetSubscriptions(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Subscription, 0, len(rows))
+	for _, row := range rows {
+		events, err := s.repo.GetSubscriptionEvents(ctx, row.AltId)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, fromRow(row, events))
+	}
+	return results, nil
+}
+
+func deliveriesFromRows(rows []DeliveryRow) []Delivery {
+	results := make([]Delivery, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, deliveryFromRow(row))
+	}
+	return results
+}
+
+func (s *WebhookServiceImpl) GetDeliveries(ctx context.Context, subscriptionId uuid.UUID, status string) ([]Delivery, error) {
+	if err := validateStatus(status); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetSubscription(ctx, subscriptionId); err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.GetDeliveries(ctx, subscriptionId, status)
+	if err != nil {
+		return nil, err
+	}
+	return deliveriesFromRows(rows), nil
+}
+
+func (s *WebhookServiceImpl) GetDeadLetters(ctx context.Context) ([]Delivery, error) {
+	rows, err := s.repo.GetDeliveriesByStatus(ctx, StatusDead)
+	if err != nil {
+		return nil, err
+	}
+	return deliveriesFromRows(rows), nil
+}
+
+func (s *WebhookServiceImpl) Redeliver(ctx context.Context, subscriptionId uuid.UUID, deliveryId uuid.UUID) (Delivery, error) {
+	delivery, err := s.repo.GetDelivery(ctx, deliveryId)
+	if err != nil {
+		return Delivery{}, err
+	}
+	if delivery.SubscriptionId != subscriptionId {
+		return Delivery{}, sql.ErrNoRows
+	}
+	subscription, err := s.repo.GetSubscription(ctx, subscriptionId)
+	if err != nil {
+		return Delivery{}, err
+	}
+	row, err := s.attempt(ctx, delivery, subscription)
+	if err != nil {
+		return Delivery{}, err
+	}
+	return deliveryFromRow(row), nil
+}
+
+func (s *WebhookServiceImpl) Name() string {
+	return "webhooks"
+}
+
+// Publish runs as the tenant of the event, so only the subscriptions of the tenant get a delivery.
+func (s *WebhookServiceImpl) Publish(event outbox.Event) error {
+	ctx := commons.WithTenant(context.Background(), event.Tenant)
+	subscriptions, err := s.repo.GetSubscriptionsForEvent(ctx, event.Type)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, subscription := range subscriptions {
+		errs = append(errs, s.repo.EnqueueDelivery(ctx, subscription.AltId, event))
+	}
+	return errors.Join(errs...)
+}
+
+// DispatchDue goes through the whole batch whatever fails, a delivery whose subscription cannot be read is recorded as
+// a failed attempt and the errors are returned together at the end.
+func (s *WebhookServiceImpl) DispatchDue(ctx context.Context) (int, error) {
+	deliveries, err := s.repo.ClaimDueDeliveries(ctx, batchSize, s.now().Add(leaseDuration))
+	if err != nil {
+		return 0, err
+	}
+	subscriptions := map[uuid.UUID]SubscriptionRow{}
+	var errs []error
+	for _, delivery := range deliveries {
+		subscription, found := subscriptions[delivery.SubscriptionId]
+		if !found {
+			if subscription, err = s.repo.GetSubscription(ctx, delivery.SubscriptionId); err != nil {
+				_, recordErr := s.record(ctx, delivery, 0, err)
+				errs = append(errs, fmt.Errorf("delivery %s: %w", delivery.AltId, errors.Join(err, recordErr)))
+				continue
+			}
+			subscriptions[delivery.SubscriptionId] = subscription
+		}
+		if _, err = s.attempt(ctx, delivery, subscription); err != nil {
+			errs = append(errs, fmt.Errorf("delivery %s: %w", delivery.AltId, err))
+		}
+	}
+	return len(deliveries), errors.Join(errs...)
+}
+
+// attempt posts the delivery and records the outcome.
+func (s *WebhookServiceImpl) attempt(ctx context.Context, delivery DeliveryRow, subscription SubscriptionRow) (DeliveryRow, error) {
+	statusCode, postErr := s.post(ctx, delivery, subscription, s.now())
+	return s.record(ctx, delivery, statusCode, postErr)
+}
+
+// record marks the delivery delivered when it did not fail, otherwise failed and retried later, or dead once it has run
+// out of attempts.
+func (s *WebhookServiceImpl) record(ctx context.Context, delivery DeliveryRow, statusCode int, postErr error) (DeliveryRow, error) {
+	now := s.now()
+	attempt := Attempt{Status: StatusDelivered, NextAttemptAt: now}
+	if statusCode > 0 {
+		attempt.StatusCode = sql.NullInt32{Int32: int32(statusCode), Valid: true}
+	}
+	if postErr != nil {
+		attempt.Error = sql.NullString{String: postErr.Error(), Valid: true}
+		attempt.Status = StatusFailed
+		attempt.NextAttemptAt = now.Add(backoff(delivery.Attempts + 1))
+		if delivery.Attempts+1 >= s.maxAttempts {
+			attempt.Status = StatusDead
+		}
+	}
+	return s.repo.RecordAttempt(ctx, delivery.AltId, attempt)
+}
+
+// post is cancelled with the context, so a shutdown does not wait for a slow endpoint.
+func (s *WebhookServiceImpl) post(ctx context.Context, delivery DeliveryRow, subscription SubscriptionRow, now time.Time) (int, error) {
+	if !subscription.Active {
+		return 0, errors.New("subscription is inactive")
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderDeliveryId, delivery.AltId.String())
+	request.Header.Set(HeaderEvent, delivery.EventType)
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	request.Header.Set(HeaderSignature, Sign(subscription.Secret, now, delivery.Payload))
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("endpoint responded with %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
@@ -0,0 +1,393 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"inventory-service-go/config"
+	"inventory-service-go/outbox"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestWebhookService_CreateSubscription(t *testing.T) {
+	now := time.Now()
+	row := SubscriptionRow{Id: 1, AltId: uuid.New(), Url: "https://erp.example.com/hooks", Active: true, CreatedBy: "unit_test", CreatedAt: now, LastChangedBy: "unit_test", LastUpdate: now}
+	request := CreateSubscriptionRequest{Url: " https://erp.example.com/hooks ", EventTypes: []string{outbox.InvoicePaid, " InvoicePaid", outbox.InvoiceCreated}, CreatedBy: "unit_test"}
+	testCases := []struct {
+		name     string
+		request  func() CreateSubscriptionRequest
+		wantErr  error
+		mockFunc func(mockRepo *MockWebhookRepository)
+	}{
+		{
+			name:    "Create Subscription Successfully",
+			request: func() CreateSubscriptionRequest { return request },
+			mockFunc: func(mockRepo *MockWebhookRepository) {
+				normalized := request
+				normalized.Url, normalized.EventTypes = "https://erp.example.com/hooks", []string{outbox.InvoicePaid, outbox.InvoiceCreated}
+				mockRepo.EXPECT().CreateSubscription(gomock.Any(), normalized, gomock.Any()).DoAndReturn(func(_ context.Context, _ CreateSubscriptionRequest, secret string) (SubscriptionRow, error) {
+					created := row
+					created.Secret = secret
+					return created, nil
+				})
+			},
+		},
+		{
+			name:     "Relative Url",
+			request:  func() CreateSubscriptionRequest { r := request; r.Url = "/hooks"; return r },
+			wantErr:  ErrInvalidSubscription,
+			mockFunc: func(*MockWebhookRepository) {},
+		},
+		{
+			name: "Internal Address",
+			request: func() CreateSubscriptionRequest {
+				r := request
+				r.Url = "http://169.254.169.254/latest/meta-data"
+				return r
+			},
+			wantErr:  ErrInvalidSubscription,
+			mockFunc: func(*MockWebhookRepository) {},
+		},
+		{
+			name:     "Localhost",
+			request:  func() CreateSubscriptionRequest { r := request; r.Url = "http://localhost:8080/hooks"; return r },
+			wantErr:  ErrInvalidSubscription,
+			mockFunc: func(*MockWebhookRepository) {},
+		},
+		{
+			name:     "Not Http",
+			request:  func() CreateSubscriptionRequest { r := request; r.Url = "ftp://erp.example.com/hooks"; return r },
+			wantErr:  ErrInvalidSubscription,
+			mockFunc: func(*MockWebhookRepository) {},
+		},
+		{
+			name:     "Unknown Event Type",
+			request:  func() CreateSubscriptionRequest { r := request; r.EventTypes = []string{"InvoiceLost"}; return r },
+			wantErr:  ErrInvalidSubscription,
+			mockFunc: func(*MockWebhookRepository) {},
+		},
+		{
+			name:     "No Event Types",
+			request:  func() CreateSubscriptionRequest { r := request; r.EventTypes = nil; return r },
+			wantErr:  ErrInvalidSubscription,
+			mockFunc: func(*MockWebhookRepository) {},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := NewMockWebhookRepository(ctrl)
+			tc.mockFunc(mockRepo)
+			s := NewWebhookService(mockRepo)
+
+			result, err := s.CreateSubscription(context.Background(), tc.request())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, row.AltId, result.Id)
+			assert.Equal(t, []string{outbox.InvoicePaid, outbox.InvoiceCreated}, result.EventTypes)
+			assert.Regexp(t, "^whsec_[0-9a-f]{64}$", result.Secret)
+		})
+	}
+}
+
+func TestWebhookService_GetSubscription_HidesSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := NewMockWebhookRepository(ctrl)
+	id := uuid.New()
+	mockRepo.EXPECT().GetSubscription(gomock.Any(), id).Return(SubscriptionRow{AltId: id, Secret: "whsec_test"}, nil)
+	mockRepo.EXPECT().GetSubscriptionEvents(gomock.Any(), id).Return([]SubscriptionEventRow{{SubscriptionId: id, EventType: outbox.InvoicePaid}}, nil)
+	s := NewWebhookService(mockRepo)
+
+	result, err := s.GetSubscription(context.Background(), id)
+	assert.Nil(t, err)
+	assert.Empty(t, result.Secret)
+	assert.Equal(t, []string{outbox.InvoicePaid}, result.EventTypes)
+}
+
+func TestWebhookService_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := NewMockWebhookRepository(ctrl)
+	event := outbox.Event{Id: uuid.New(), Type: outbox.InvoicePaid}
+	first, second := SubscriptionRow{AltId: uuid.New()}, SubscriptionRow{AltId: uuid.New()}
+	mockRepo.EXPECT().GetSubscriptionsForEvent(gomock.Any(), outbox.InvoicePaid).Return([]SubscriptionRow{first, second}, nil)
+	mockRepo.EXPECT().EnqueueDelivery(gomock.Any(), first.AltId, event).Return(nil)
+	mockRepo.EXPECT().EnqueueDelivery(gomock.Any(), second.AltId, event).Return(nil)
+	s := NewWebhookService(mockRepo)
+
+	assert.Nil(t, s.Publish(event))
+}
+
+func TestWebhookService_DispatchDue(t *testing.T) {
+	now := time.Unix(1767225600, 0)
+	payload := []byte(`{"type":"InvoicePaid"}`)
+	testCases := []struct {
+		name        string
+		status      int
+		attempts    int
+		active      bool
+		wantAttempt Attempt
+	}{
+		{
+			name:        "Delivered",
+			status:      http.StatusNoContent,
+			active:      true,
+			wantAttempt: Attempt{Status: StatusDelivered, StatusCode: sql.NullInt32{Int32: 204, Valid: true}, NextAttemptAt: now},
+		},
+		{
+			name:     "Failed And Retried Later",
+			status:   http.StatusServiceUnavailable,
+			attempts: 2,
+			active:   true,
+			wantAttempt: Attempt{
+				Status:        StatusFailed,
+				StatusCode:    sql.NullInt32{Int32: 503, Valid: true},
+				Error:         sql.NullString{String: "endpoint responded with 503 Service Unavailable", Valid: true},
+				NextAttemptAt: now.Add(2 * time.Minute),
+			},
+		},
+		{
+			name:     "Dead After The Last Attempt",
+			status:   http.StatusInternalServerError,
+			attempts: DefaultMaxAttempts - 1,
+			active:   true,
+			wantAttempt: Attempt{
+				Status:        StatusDead,
+				StatusCode:    sql.NullInt32{Int32: 500, Valid: true},
+				Error:         sql.NullString{String: "endpoint responded with 500 Internal Server Error", Valid: true},
+				NextAttemptAt: now.Add(backoff(DefaultMaxAttempts)),
+			},
+		},
+		{
+			name:   "Inactive Subscription",
+			status: http.StatusOK,
+			active: false,
+			wantAttempt: Attempt{
+				Status:        StatusFailed,
+				Error:         sql.NullString{String: "subscription is inactive", Valid: true},
+				NextAttemptAt: now.Add(30 * time.Second),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			delivery := DeliveryRow{AltId: uuid.New(), SubscriptionId: uuid.New(), EventType: outbox.InvoicePaid, Payload: payload, Status: StatusPending, Attempts: tc.attempts}
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, payload, body)
+				assert.Equal(t, delivery.AltId.String(), r.Header.Get(HeaderDeliveryId))
+				assert.Equal(t, outbox.InvoicePaid, r.Header.Get(HeaderEvent))
+				assert.True(t, Verify("whsec_test", r.Header.Get(HeaderTimestamp), body, r.Header.Get(HeaderSignature)))
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+			subscription := SubscriptionRow{AltId: delivery.SubscriptionId, Url: server.URL, Secret: "whsec_test", Active: tc.active}
+
+			ctrl := gomock.NewController(t)
+			mockRepo := NewMockWebhookRepository(ctrl)
+			mockRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), batchSize, now.Add(leaseDuration)).Return([]DeliveryRow{delivery}, nil)
+			mockRepo.EXPECT().GetSubscription(gomock.Any(), delivery.SubscriptionId).Return(subscription, nil)
+			mockRepo.EXPECT().RecordAttempt(gomock.Any(), delivery.AltId, tc.wantAttempt).Return(DeliveryRow{}, nil)
+			s := NewWebhookService(mockRepo)
+			s.client = server.Client()
+			s.now = func() time.Time { return now }
+
+			dispatched, err := s.DispatchDue(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, 1, dispatched)
+			if tc.active {
+				assert.Equal(t, 1, requests)
+			} else {
+				assert.Equal(t, 0, requests)
+			}
+		})
+	}
+}
+
+func TestWebhookService_DispatchDue_ContinuesAfterAnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	now := time.Unix(1767225600, 0)
+	unreadable := DeliveryRow{AltId: uuid.New(), SubscriptionId: uuid.New(), Attempts: 1}
+	delivery := DeliveryRow{AltId: uuid.New(), SubscriptionId: uuid.New()}
+
+	ctrl := gomock.NewController(t)
+	mockRepo := NewMockWebhookRepository(ctrl)
+	mockRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), batchSize, now.Add(leaseDuration)).Return([]DeliveryRow{unreadable, delivery}, nil)
+	mockRepo.EXPECT().GetSubscription(gomock.Any(), unreadable.SubscriptionId).Return(SubscriptionRow{}, errors.New("BOOM"))
+	mockRepo.EXPECT().RecordAttempt(gomock.Any(), unreadable.AltId, Attempt{
+		Status:        StatusFailed,
+		Error:         sql.NullString{String: "BOOM", Valid: true},
+		NextAttemptAt: now.Add(time.Minute),
+	}).Return(DeliveryRow{}, nil)
+	mockRepo.EXPECT().GetSubscription(gomock.Any(), delivery.SubscriptionId).Return(SubscriptionRow{AltId: delivery.SubscriptionId, Url: server.URL, Active: true}, nil)
+	mockRepo.EXPECT().RecordAttempt(gomock.Any(), delivery.AltId, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, attempt Attempt) (DeliveryRow, error) {
+		assert.Equal(t, StatusDelivered, attempt.Status)
+		return DeliveryRow{}, nil
+	})
+	s := NewWebhookService(mockRepo)
+	s.client = server.Client()
+	s.now = func() time.Time { return now }
+
+	dispatched, err := s.DispatchDue(context.Background())
+	assert.ErrorContains(t, err, "BOOM")
+	assert.Equal(t, 2, dispatched)
+}
+
+func TestNewClient(t *testing.T) {
+	redirected := 0
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected++
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	defer server.Close()
+
+	t.Run("Refuses Internal Addresses", func(t *testing.T) {
+		_, err := newClient().Post(server.URL, "application/json", nil)
+		assert.ErrorIs(t, err, ErrForbiddenAddress)
+	})
+	t.Run("Does Not Follow Redirects", func(t *testing.T) {
+		client := newClient()
+		client.Transport = http.DefaultTransport
+		response, err := client.Post(server.URL, "application/json", nil)
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected posting to the test server", err)
+		}
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusFound, response.StatusCode)
+		assert.Equal(t, 0, redirected)
+	})
+}
+
+func TestInternal(t *testing.T) {
+	testCases := []struct {
+		address  string
+		internal bool
+	}{
+		{"127.0.0.1", true},
+		{"10.0.0.1", true},
+		{"169.254.169.254", true},
+		{"0.1.2.3", true},
+		{"100.64.0.1", true},
+		{"100.127.255.254", true},
+		{"198.18.0.1", true},
+		{"198.19.255.254", true},
+		{"::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"::ffff:10.0.0.1", true},
+		{"::ffff:100.64.0.1", true},
+		{"100.128.0.1", false},
+		{"198.20.0.1", false},
+		{"93.184.216.34", false},
+		{"::ffff:93.184.216.34", false},
+		{"2606:2800:220:1:248:1893:25c8:1946", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.address, func(t *testing.T) {
+			assert.Equal(t, tc.internal, internal(netip.MustParseAddr(tc.address)))
+		})
+	}
+}
+
+func TestWebhookService_Post_Cancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	s := NewWebhookService(NewMockWebhookRepository(gomock.NewController(t)))
+	s.client = server.Client()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.post(ctx, DeliveryRow{AltId: uuid.New()}, SubscriptionRow{Url: server.URL, Active: true}, time.Now())
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	subscriptionId := uuid.New()
+	delivery := DeliveryRow{AltId: uuid.New(), SubscriptionId: subscriptionId, Status: StatusDead, Attempts: DefaultMaxAttempts}
+	testCases := []struct {
+		name           string
+		subscriptionId uuid.UUID
+		mockFunc       func(mockRepo *MockWebhookRepository)
+		wantErr        error
+	}{
+		{
+			name:           "Redelivers A Dead Letter",
+			subscriptionId: subscriptionId,
+			mockFunc: func(mockRepo *MockWebhookRepository) {
+				mockRepo.EXPECT().GetDelivery(gomock.Any(), delivery.AltId).Return(delivery, nil)
+				mockRepo.EXPECT().GetSubscription(gomock.Any(), subscriptionId).Return(SubscriptionRow{AltId: subscriptionId, Url: server.URL, Active: true}, nil)
+				mockRepo.EXPECT().RecordAttempt(gomock.Any(), delivery.AltId, gomock.Any()).DoAndReturn(func(_ context.Context, id uuid.UUID, attempt Attempt) (DeliveryRow, error) {
+					assert.Equal(t, StatusDelivered, attempt.Status)
+					delivered := delivery
+					delivered.Status = attempt.Status
+					return delivered, nil
+				})
+			},
+		},
+		{
+			name:           "Delivery Of Another Subscription",
+			subscriptionId: uuid.New(),
+			mockFunc: func(mockRepo *MockWebhookRepository) {
+				mockRepo.EXPECT().GetDelivery(gomock.Any(), delivery.AltId).Return(delivery, nil)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := NewMockWebhookRepository(ctrl)
+			tc.mockFunc(mockRepo)
+			s := NewWebhookService(mockRepo)
+			s.client = server.Client()
+
+			result, err := s.Redeliver(context.Background(), tc.subscriptionId, delivery.AltId)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, StatusDelivered, result.Status)
+		})
+	}
+}
+
+func TestWebhookService_GetDeliveries_InvalidStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := NewWebhookService(NewMockWebhookRepository(ctrl))
+
+	_, err := s.GetDeliveries(context.Background(), uuid.New(), "lost")
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, backoff(1))
+	assert.Equal(t, 4*time.Minute, backoff(4))
+	assert.Equal(t, MaxBackoff, backoff(10))
+}
+
+func TestNewFromConfig(t *testing.T) {
+	assert.Equal(t, 3, NewFromConfig(nil, config.Webhooks{MaxAttempts: 3}).maxAttempts)
+	assert.Equal(t, DefaultMaxAttempts, NewFromConfig(nil, config.Webhooks{}).maxAttempts)
+}
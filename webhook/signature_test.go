@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	timestamp := time.Unix(1767225600, 0)
+	body := []byte(`{"type":"InvoicePaid"}`)
+	signature := Sign("whsec_test", timestamp, body)
+
+	assert.True(t, strings.HasPrefix(signature, "sha256="))
+	assert.Len(t, signature, len("sha256=")+64)
+	assert.Equal(t, signature, Sign("whsec_test", timestamp, body))
+	assert.NotEqual(t, signature, Sign("whsec_other", timestamp, body))
+	assert.NotEqual(t, signature, Sign("whsec_test", timestamp.Add(time.Second), body))
+}
+
+func TestVerify(t *testing.T) {
+	timestamp := time.Unix(1767225600, 0)
+	body := []byte(`{"type":"InvoicePaid"}`)
+	signature := Sign("whsec_test", timestamp, body)
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+
+	assert.True(t, Verify("whsec_test", unix, body, signature))
+	assert.False(t, Verify("whsec_test", unix, []byte(`{"type":"InvoiceCreated"}`), signature))
+	assert.False(t, Verify("whsec_other", unix, body, signature))
+	assert.False(t, Verify("whsec_test", "yesterday", body, signature))
+}
+
+func TestNewSecret(t *testing.T) {
+	first, err := newSecret()
+	assert.Nil(t, err)
+	second, err := newSecret()
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(first, "whsec_"))
+	assert.NotEqual(t, first, second)
+}
@@ -0,0 +1,21 @@
+//go:build wireinject
+// +build wireinject
+
+package webhook
+
+import (
+	"github.com/google/wire"
+	"inventory-service-go/commons"
+	"inventory-service-go/config"
+)
+
+func InitializeWebhookService(settings config.Webhooks) (WebhookService, error) {
+	wire.Build(
+		NewFromConfig,
+		NewWebhookRepository,
+		commons.GetDB,
+		wire.Bind(new(WebhookRepository), new(*WebhookRepositoryImpl)),
+		wire.Bind(new(WebhookService), new(*WebhookServiceImpl)),
+	)
+	return nil, nil
+}